@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -16,6 +17,11 @@ type InitServerResponse struct {
 
 // InitServer sends a request to initialize the server in enterprise mode
 func (c *Client) InitServer() (*InitServerResponse, error) {
+	return c.InitServerContext(context.Background())
+}
+
+// InitServerContext is like InitServer, but the request is bound to the given context.
+func (c *Client) InitServerContext(ctx context.Context) (*InitServerResponse, error) {
 	u, _ := url.JoinPath(c.baseURL, "/init")
 
 	// TODO: Replace ModeProd with ModeEnterprise in future.
@@ -34,7 +40,17 @@ func (c *Client) InitServer() (*InitServerResponse, error) {
 		return nil, err
 	}
 
-	resp, err := c.httpClient.Post(u, "application/json", bytes.NewBuffer(body))
+	// the server is initialized without an access token, so the request is built without newRequest
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
 	}
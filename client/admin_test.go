@@ -49,7 +49,7 @@ func TestInitServer(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "", &http.Client{})
+		client := NewClient(server.URL)
 		response, err := client.InitServer()
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
@@ -67,7 +67,7 @@ func TestInitServer(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "", &http.Client{})
+		client := NewClient(server.URL)
 		response, err := client.InitServer()
 
 		if err == nil {
@@ -85,7 +85,7 @@ func TestInitServer(t *testing.T) {
 
 	t.Run("network error", func(t *testing.T) {
 		// Use an invalid URL to simulate network error
-		client := NewClient("http://invalid-url-that-does-not-exist", "", &http.Client{})
+		client := NewClient("http://invalid-url-that-does-not-exist")
 		response, err := client.InitServer()
 
 		if err == nil {
@@ -108,7 +108,7 @@ func TestInitServer(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "", &http.Client{})
+		client := NewClient(server.URL)
 		response, err := client.InitServer()
 
 		if err == nil {
@@ -130,7 +130,7 @@ func TestInitServer(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "", &http.Client{})
+		client := NewClient(server.URL)
 		response, err := client.InitServer()
 
 		if err == nil {
@@ -164,7 +164,7 @@ func TestInitServerWithAccessToken(t *testing.T) {
 	defer server.Close()
 
 	// Test with access token (should be ignored for init)
-	client := NewClient(server.URL, "some-token", &http.Client{})
+	client := NewClient(server.URL, WithToken("some-token"))
 	response, err := client.InitServer()
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
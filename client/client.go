@@ -1,13 +1,21 @@
 // Package client provides HTTP client functionality for interacting with the MCPJungle API.
+//
+// Every method that sends a request has a variant suffixed with Context (eg- ListToolsContext) that binds the request
+// to a context, so that callers can set deadlines and cancel it. The variants without it use context.Background().
+//
+// Client implements Interface. Programs can depend on Interface instead, and use clienttest.Fake in their tests.
 package client
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/mcpjungle/mcpjungle/internal/api"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
@@ -18,14 +26,69 @@ type Client struct {
 	baseURL     string
 	accessToken string
 	httpClient  *http.Client
+	timeout     time.Duration
+	userAgent   string
+	retry       RetryPolicy
+	tlsConfig   *tls.Config
+
+	// idempotencyKeys is true if the client generates an idempotency key for every create request
+	idempotencyKeys bool
+
+	// reconnect paces the attempts to reopen an event stream that ended, its MaxRetries is ignored
+	reconnect RetryPolicy
+	// streamErrorHandler receives the errors of the streams, which can't be returned to the caller
+	streamErrorHandler func(error)
+
+	tracing       *tracing
+	requestHooks  []RequestHook
+	responseHooks []ResponseHook
 }
 
-func NewClient(baseURL string, accessToken string, httpClient *http.Client) *Client {
-	return &Client{
-		baseURL:     baseURL,
-		accessToken: accessToken,
-		httpClient:  httpClient,
+// NewClient creates a client for the MCPJungle server at baseURL, configured with the given options.
+// By default, requests are sent without an access token using http.DefaultClient, and are not retried.
+func NewClient(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    baseURL,
+		httpClient: http.DefaultClient,
+		reconnect:  RetryPolicy{InitialBackoff: 500 * time.Millisecond, MaxBackoff: 30 * time.Second},
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.timeout > 0 || c.tlsConfig != nil {
+		// work on a copy, the http client may be shared with other code
+		hc := *c.httpClient
+		if c.timeout > 0 {
+			hc.Timeout = c.timeout
+		}
+		if c.tlsConfig != nil {
+			hc.Transport = withTLSConfig(hc.Transport, c.tlsConfig)
+		}
+		c.httpClient = &hc
+	}
+	return c
+}
+
+// withTLSConfig returns a copy of the transport that uses the given TLS configuration.
+// Transports other than *http.Transport are returned as is, since they can't be configured.
+func withTLSConfig(rt http.RoundTripper, conf *tls.Config) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	t, ok := rt.(*http.Transport)
+	if !ok {
+		return rt
+	}
+	t = t.Clone()
+	t.TLSClientConfig = conf
+	return t
+}
+
+// NewClientWithToken creates a client the way NewClient used to, before it accepted options.
+//
+// Deprecated: use NewClient with WithToken and WithHTTPClient instead.
+func NewClientWithToken(baseURL string, accessToken string, httpClient *http.Client) *Client {
+	return NewClient(baseURL, WithToken(accessToken), WithHTTPClient(httpClient))
 }
 
 // BaseURL returns the base URL of the MCPJungle server
@@ -35,19 +98,22 @@ func (c *Client) BaseURL() string {
 
 // constructAPIEndpoint constructs the full API endpoint URL where a request must be sent
 func (c *Client) constructAPIEndpoint(suffixPath string) (string, error) {
-	return url.JoinPath(c.baseURL, api.V0ApiPathPrefix, suffixPath)
+	return url.JoinPath(c.baseURL, api.V1ApiPathPrefix, suffixPath)
 }
 
-// newRequest creates a new HTTP request with the specified method, URL, and body.
-// It automatically adds the Authorization header if an access token is present.
-func (c *Client) newRequest(method, url string, body io.Reader) (*http.Request, error) {
-	req, err := http.NewRequest(method, url, body)
+// newRequest creates a new HTTP request bound to ctx with the specified method, URL, and body.
+// It automatically adds the Authorization header if an access token is present, and the User-Agent header if one is set.
+func (c *Client) newRequest(ctx context.Context, method, url string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, err
 	}
 	if c.accessToken != "" {
 		req.Header.Set("Authorization", "Bearer "+c.accessToken)
 	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
 	return req, nil
 }
 
@@ -56,11 +122,57 @@ type ErrorResponse struct {
 	Error string `json:"error"`
 }
 
-// parseErrorResponse parses HTTP error responses (4xx and 5xx) and returns a user-friendly error message
+// APIError is returned when the server responds to a request with an unexpected status code.
+// It lets callers branch on the status code, eg- to tell a missing entity apart from a server failure.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+	// Message describes the error
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// ErrRateLimited matches the error returned when the server rejects a request because of a rate limit,
+// and the retry policy doesn't allow retrying it (anymore). Use errors.As with a *RateLimitError to know when to try again.
+var ErrRateLimited = errors.New("rate limited")
+
+// RateLimitError is returned when the server responds with 429 (Too Many Requests).
+// It also matches ErrRateLimited and *APIError with errors.Is and errors.As.
+type RateLimitError struct {
+	*APIError
+	// RetryAfter is how long the server asked to wait before trying again, 0 if it didn't say.
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return e.APIError
+}
+
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// parseErrorResponse parses HTTP error responses (4xx and 5xx) and returns a user-friendly error message.
+// A 429 response is returned as a *RateLimitError.
 func (c *Client) parseErrorResponse(resp *http.Response) error {
+	err := parseAPIError(resp)
+	if resp.StatusCode == http.StatusTooManyRequests {
+		d, _ := retryAfter(resp)
+		return &RateLimitError{APIError: err, RetryAfter: d}
+	}
+	return err
+}
+
+func parseAPIError(resp *http.Response) *APIError {
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("request failed with status: %d (unable to read error details)", resp.StatusCode)
+		return &APIError{
+			StatusCode: resp.StatusCode,
+			Message:    fmt.Sprintf("request failed with status: %d (unable to read error details)", resp.StatusCode),
+		}
 	}
 
 	// For 4xx and 5xx status codes, try to parse as JSON error response
@@ -69,25 +181,30 @@ func (c *Client) parseErrorResponse(resp *http.Response) error {
 		err := json.Unmarshal(body, &errorResp)
 		if err != nil || errorResp.Error == "" {
 			// If parsing as JSON fails or the error message is empty, return the raw response
-			return fmt.Errorf("request failed with status: %d, message: %s", resp.StatusCode, string(body))
+			return &APIError{
+				StatusCode: resp.StatusCode,
+				Message:    fmt.Sprintf("request failed with status: %d, message: %s", resp.StatusCode, string(body)),
+			}
 		}
 		// Return the parsed error message
-		return fmt.Errorf("%s", errorResp.Error)
+		return &APIError{StatusCode: resp.StatusCode, Message: errorResp.Error}
 	}
 
 	// For any other status code, return the full response
-	return fmt.Errorf("unexpected response with status: %d, body: %s", resp.StatusCode, string(body))
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    fmt.Sprintf("unexpected response with status: %d, body: %s", resp.StatusCode, string(body)),
+	}
 }
 
 // GetServerMetadata fetches metadata about the MCPJungle server.
 func (c *Client) GetServerMetadata(ctx context.Context) (*types.ServerMetadata, error) {
-	req, err := c.newRequest(http.MethodGet, c.baseURL+"/metadata", nil)
+	req, err := c.newRequest(ctx, http.MethodGet, c.baseURL+"/metadata", nil)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
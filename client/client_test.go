@@ -1,11 +1,18 @@
 package client
 
 import (
+	"context"
+	"crypto/tls"
+	"encoding/pem"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewClient(t *testing.T) {
@@ -15,7 +22,7 @@ func TestNewClient(t *testing.T) {
 	accessToken := "test-token"
 	httpClient := &http.Client{}
 
-	client := NewClient(baseURL, accessToken, httpClient)
+	client := NewClient(baseURL, WithToken(accessToken), WithHTTPClient(httpClient))
 
 	if client.baseURL != baseURL {
 		t.Errorf("Expected baseURL %s, got %s", baseURL, client.baseURL)
@@ -28,10 +35,138 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestNewClientOptions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		client := NewClient("https://api.example.com")
+		if client.httpClient != http.DefaultClient {
+			t.Error("Expected http.DefaultClient by default")
+		}
+		if client.retry.MaxRetries != 0 {
+			t.Errorf("Expected no retries by default, got %d", client.retry.MaxRetries)
+		}
+	})
+
+	t.Run("timeout doesn't modify the given http client", func(t *testing.T) {
+		httpClient := &http.Client{}
+		client := NewClient("https://api.example.com", WithHTTPClient(httpClient), WithTimeout(5*time.Second))
+		if client.httpClient.Timeout != 5*time.Second {
+			t.Errorf("Expected a 5s timeout, got %s", client.httpClient.Timeout)
+		}
+		if httpClient.Timeout != 0 {
+			t.Error("Expected the given http client to be left untouched")
+		}
+	})
+
+	t.Run("user agent and token are sent", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ua := r.Header.Get("User-Agent"); ua != "my-app/1.0" {
+				t.Errorf("Expected User-Agent my-app/1.0, got %s", ua)
+			}
+			if auth := r.Header.Get("Authorization"); auth != "Bearer test-token" {
+				t.Errorf("Expected the access token, got %s", auth)
+			}
+			_, _ = w.Write([]byte(`[]`))
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL, WithToken("test-token"), WithUserAgent("my-app/1.0"))
+		if _, err := client.ListServers(); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("compatibility constructor", func(t *testing.T) {
+		httpClient := &http.Client{}
+		client := NewClientWithToken("https://api.example.com", "test-token", httpClient)
+		if client.accessToken != "test-token" || client.httpClient != httpClient {
+			t.Errorf("Unexpected client: %+v", client)
+		}
+	})
+}
+
+func TestTLSOptions(t *testing.T) {
+	t.Parallel()
+
+	// the server requires a client certificate, and reports whether it received one
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			t.Error("Expected a client certificate")
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	// the certificate of the test server is self-signed, so it is its own CA
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	pool, err := LoadCACertPool(caFile)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// any certificate will do as the client's
+	clientCert := server.TLS.Certificates[0]
+
+	t.Run("custom CA and client certificate", func(t *testing.T) {
+		client := NewClient(server.URL, WithRootCAs(pool), WithClientCertificate(clientCert))
+		if _, err := client.ListServers(); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if client.httpClient == http.DefaultClient || client.httpClient.Transport == http.DefaultTransport {
+			t.Error("Expected the default HTTP client and transport to be left untouched")
+		}
+	})
+
+	t.Run("unknown CA", func(t *testing.T) {
+		client := NewClient(server.URL, WithClientCertificate(clientCert))
+		if _, err := client.ListServers(); err == nil || !strings.Contains(err.Error(), "certificate") {
+			t.Errorf("Expected a certificate error, got %v", err)
+		}
+	})
+
+	t.Run("insecure skip verify", func(t *testing.T) {
+		client := NewClient(server.URL, WithInsecureSkipVerify(), WithClientCertificate(clientCert))
+		if _, err := client.ListServers(); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("options apply on top of the TLS config", func(t *testing.T) {
+		conf := &tls.Config{ServerName: "example.com"}
+		client := NewClient(server.URL, WithTLSConfig(conf), WithInsecureSkipVerify())
+		got := client.httpClient.Transport.(*http.Transport).TLSClientConfig
+		if got.ServerName != "example.com" || !got.InsecureSkipVerify {
+			t.Errorf("Unexpected TLS config: %+v", got)
+		}
+		if conf.InsecureSkipVerify {
+			t.Error("Expected the given TLS config to be left untouched")
+		}
+	})
+
+	t.Run("invalid CA file", func(t *testing.T) {
+		bad := filepath.Join(t.TempDir(), "bad.pem")
+		if err := os.WriteFile(bad, []byte("not a certificate"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadCACertPool(bad); err == nil || !strings.Contains(err.Error(), "no valid certificate") {
+			t.Errorf("Expected an invalid certificate error, got %v", err)
+		}
+		if _, err := LoadCACertPool(filepath.Join(t.TempDir(), "missing.pem")); err == nil {
+			t.Error("Expected an error for a missing file")
+		}
+	})
+}
+
 func TestNewClientWithEmptyToken(t *testing.T) {
 	t.Parallel()
 
-	client := NewClient("https://api.example.com", "", &http.Client{})
+	client := NewClient("https://api.example.com")
 
 	if client.accessToken != "" {
 		t.Errorf("Expected empty accessToken, got %s", client.accessToken)
@@ -41,7 +176,7 @@ func TestNewClientWithEmptyToken(t *testing.T) {
 func TestConstructAPIEndpoint(t *testing.T) {
 	t.Parallel()
 
-	client := NewClient("https://api.example.com", "token", &http.Client{})
+	client := NewClient("https://api.example.com", WithToken("token"))
 
 	tests := []struct {
 		name         string
@@ -51,17 +186,17 @@ func TestConstructAPIEndpoint(t *testing.T) {
 		{
 			name:         "simple path",
 			suffixPath:   "servers",
-			expectedPath: "https://api.example.com/api/v0/servers",
+			expectedPath: "https://api.example.com/api/v1/servers",
 		},
 		{
 			name:         "nested path",
 			suffixPath:   "servers/test-server",
-			expectedPath: "https://api.example.com/api/v0/servers/test-server",
+			expectedPath: "https://api.example.com/api/v1/servers/test-server",
 		},
 		{
 			name:         "empty suffix",
 			suffixPath:   "",
-			expectedPath: "https://api.example.com/api/v0",
+			expectedPath: "https://api.example.com/api/v1",
 		},
 	}
 
@@ -81,11 +216,11 @@ func TestConstructAPIEndpoint(t *testing.T) {
 func TestNewRequest(t *testing.T) {
 	t.Parallel()
 
-	client := NewClient("https://api.example.com", "test-token", &http.Client{})
+	client := NewClient("https://api.example.com", WithToken("test-token"))
 
 	t.Run("request with access token", func(t *testing.T) {
 		body := strings.NewReader("test body")
-		req, err := client.newRequest(http.MethodPost, "https://api.example.com/test", body)
+		req, err := client.newRequest(context.Background(), http.MethodPost, "https://api.example.com/test", body)
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -105,8 +240,8 @@ func TestNewRequest(t *testing.T) {
 	})
 
 	t.Run("request without access token", func(t *testing.T) {
-		clientNoToken := NewClient("https://api.example.com", "", &http.Client{})
-		req, err := clientNoToken.newRequest(http.MethodGet, "https://api.example.com/test", nil)
+		clientNoToken := NewClient("https://api.example.com")
+		req, err := clientNoToken.newRequest(context.Background(), http.MethodGet, "https://api.example.com/test", nil)
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -118,7 +253,7 @@ func TestNewRequest(t *testing.T) {
 	})
 
 	t.Run("request with nil body", func(t *testing.T) {
-		req, err := client.newRequest(http.MethodGet, "https://api.example.com/test", nil)
+		req, err := client.newRequest(context.Background(), http.MethodGet, "https://api.example.com/test", nil)
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -132,10 +267,10 @@ func TestNewRequest(t *testing.T) {
 func TestNewRequestWithInvalidURL(t *testing.T) {
 	t.Parallel()
 
-	client := NewClient("https://api.example.com", "token", &http.Client{})
+	client := NewClient("https://api.example.com", WithToken("token"))
 
 	// Test with invalid URL
-	req, err := client.newRequest(http.MethodGet, "://invalid-url", nil)
+	req, err := client.newRequest(context.Background(), http.MethodGet, "://invalid-url", nil)
 	if err == nil {
 		t.Error("Expected error for invalid URL, got nil")
 	}
@@ -166,7 +301,7 @@ func TestClientIntegration(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token", &http.Client{})
+	client := NewClient(server.URL, WithToken("test-token"))
 
 	// Test constructAPIEndpoint + newRequest integration
 	endpoint, err := client.constructAPIEndpoint("test")
@@ -174,7 +309,7 @@ func TestClientIntegration(t *testing.T) {
 		t.Fatalf("Failed to construct endpoint: %v", err)
 	}
 
-	req, err := client.newRequest(http.MethodGet, endpoint, nil)
+	req, err := client.newRequest(context.Background(), http.MethodGet, endpoint, nil)
 	if err != nil {
 		t.Fatalf("Failed to create request: %v", err)
 	}
@@ -209,7 +344,7 @@ func TestClientWithDifferentHTTPClients(t *testing.T) {
 	accessToken := "test-token"
 
 	// Test with default HTTP client
-	client1 := NewClient(baseURL, accessToken, &http.Client{})
+	client1 := NewClient(baseURL, WithToken(accessToken))
 	if client1.httpClient == nil {
 		t.Error("Expected non-nil httpClient")
 	}
@@ -218,7 +353,7 @@ func TestClientWithDifferentHTTPClients(t *testing.T) {
 	customClient := &http.Client{
 		Timeout: 30, // This would be a proper timeout in real usage
 	}
-	client2 := NewClient(baseURL, accessToken, customClient)
+	client2 := NewClient(baseURL, WithToken(accessToken), WithHTTPClient(customClient))
 	if client2.httpClient != customClient {
 		t.Error("Expected httpClient to match custom client")
 	}
@@ -227,7 +362,7 @@ func TestClientWithDifferentHTTPClients(t *testing.T) {
 func TestConstructAPIEndpointWithComplexPaths(t *testing.T) {
 	t.Parallel()
 
-	client := NewClient("https://api.example.com", "token", &http.Client{})
+	client := NewClient("https://api.example.com", WithToken("token"))
 
 	tests := []struct {
 		name         string
@@ -237,17 +372,17 @@ func TestConstructAPIEndpointWithComplexPaths(t *testing.T) {
 		{
 			name:         "path with query params",
 			suffixPath:   "tools?server=test",
-			expectedPath: "https://api.example.com/api/v0/tools%3Fserver=test",
+			expectedPath: "https://api.example.com/api/v1/tools%3Fserver=test",
 		},
 		{
 			name:         "path with special characters",
 			suffixPath:   "servers/test-server-123",
-			expectedPath: "https://api.example.com/api/v0/servers/test-server-123",
+			expectedPath: "https://api.example.com/api/v1/servers/test-server-123",
 		},
 		{
 			name:         "path with multiple segments",
 			suffixPath:   "tool-groups/my-group/tools",
-			expectedPath: "https://api.example.com/api/v0/tool-groups/my-group/tools",
+			expectedPath: "https://api.example.com/api/v1/tool-groups/my-group/tools",
 		},
 	}
 
@@ -273,7 +408,7 @@ func (badReader) Close() error             { return nil }
 func TestParseErrorResponse(t *testing.T) {
 	t.Parallel()
 
-	client := NewClient("https://api.example.com", "token", &http.Client{})
+	client := NewClient("https://api.example.com", WithToken("token"))
 
 	tests := []struct {
 		name           string
@@ -351,6 +486,44 @@ func TestParseErrorResponse(t *testing.T) {
 			if err != nil && !strings.Contains(err.Error(), tt.expectContains) {
 				t.Errorf("Expected error to contain %q, got %q", tt.expectContains, err.Error())
 			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Expected an *APIError, got %T", err)
+			}
+			if apiErr.StatusCode != tt.statusCode {
+				t.Errorf("Expected status code %d, got %d", tt.statusCode, apiErr.StatusCode)
+			}
 		})
 	}
 }
+
+func TestContextVariants(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := client.ListToolsContext(ctx, "")
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err := client.DeleteToolGroupContext(ctx, "group")
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded, got %v", err)
+		}
+	})
+}
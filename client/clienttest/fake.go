@@ -0,0 +1,804 @@
+// Package clienttest provides a fake implementation of client.Interface, so that programs using the
+// MCPJungle client can be unit-tested without a running MCPJungle server.
+package clienttest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/client"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// ErrNotImplemented is returned by the methods of Fake whose function is not set.
+var ErrNotImplemented = errors.New("clienttest: method not implemented by the fake")
+
+// Fake implements client.Interface by calling the function set for each operation.
+// The functions take the arguments of the context-aware variant of an operation, and the variant without
+// a context calls it with context.Background(), so setting ListToolsFunc fakes both ListTools & ListToolsContext.
+// Operations whose function is nil return zero values and ErrNotImplemented.
+type Fake struct {
+	// URL is returned by BaseURL
+	URL string
+
+	// server
+	InitServerFunc        func(ctx context.Context) (*client.InitServerResponse, error)
+	GetServerMetadataFunc func(ctx context.Context) (*types.ServerMetadata, error)
+	GetHealthDetailsFunc  func(ctx context.Context) (*types.HealthDetails, error)
+	HealthFunc            func(ctx context.Context) (*types.ReadinessResponse, error)
+	ServerInfoFunc        func(ctx context.Context) (*types.ServerInfo, error)
+	GetStatsFunc          func(ctx context.Context) (*types.RegistryStats, error)
+	SubscribeEventsFunc   func(ctx context.Context) (<-chan types.RegistryEvent, error)
+
+	// MCP servers
+	RegisterServerFunc     func(ctx context.Context, server *types.RegisterServerInput) (*types.McpServer, error)
+	TestServerFunc         func(ctx context.Context, input *types.TestServerInput) (*types.ServerTestReport, error)
+	ListServersFunc        func(ctx context.Context) ([]*types.McpServer, error)
+	UpdateServerFunc       func(ctx context.Context, name string, server *types.RegisterServerInput) (*types.McpServer, error)
+	DeregisterServerFunc   func(ctx context.Context, name string) error
+	EnableServerFunc       func(ctx context.Context, name string) (*types.EnableDisableServerResult, error)
+	DisableServerFunc      func(ctx context.Context, name string) (*types.EnableDisableServerResult, error)
+	GetServerConsumersFunc func(ctx context.Context, name string) (*types.ServerConsumers, error)
+
+	// tools
+	ListToolsFunc              func(ctx context.Context, server string) ([]*types.Tool, error)
+	ListToolsWithOptionsFunc   func(ctx context.Context, opts *types.ListToolsOptions) ([]*types.Tool, error)
+	GetToolFunc                func(ctx context.Context, name string) (*types.Tool, error)
+	EnableToolsFunc            func(ctx context.Context, name string) ([]string, error)
+	DisableToolsFunc           func(ctx context.Context, name string) ([]string, error)
+	EnableToolsBulkFunc        func(ctx context.Context, entities []string) ([]types.BulkEntityResult, error)
+	DisableToolsBulkFunc       func(ctx context.Context, entities []string) ([]types.BulkEntityResult, error)
+	InvokeToolFunc             func(ctx context.Context, name string, input map[string]any) (*types.ToolInvokeResult, error)
+	InvokeToolStreamFunc       func(ctx context.Context, name string, input map[string]any) (<-chan types.ToolInvokeStreamEvent, error)
+	InvokeToolWithProgressFunc func(ctx context.Context, name string, input map[string]any, onProgress func(types.ToolProgress)) (*types.ToolInvokeResult, error)
+	InvokeToolAsyncFunc        func(ctx context.Context, name string, input map[string]any) (*types.ToolInvocationJob, error)
+	GetJobFunc                 func(ctx context.Context, id string) (*types.ToolInvocationJob, error)
+	WaitForJobFunc             func(ctx context.Context, jobID string, pollInterval time.Duration) (*types.ToolInvokeResult, error)
+	ListInvocationsFunc        func(ctx context.Context, opts *types.ListInvocationsOptions) ([]*types.ToolInvocation, error)
+	SearchFunc                 func(ctx context.Context, query string, resultType types.SearchResultType, limit int) ([]types.SearchResult, error)
+
+	// prompts
+	ListPromptsFunc       func(ctx context.Context, serverName string) ([]model.Prompt, error)
+	GetPromptFunc         func(ctx context.Context, name string) (*model.Prompt, error)
+	GetPromptWithArgsFunc func(ctx context.Context, name string, arguments map[string]string) (*types.PromptResult, error)
+	EnablePromptsFunc     func(ctx context.Context, entity string) ([]string, error)
+	DisablePromptsFunc    func(ctx context.Context, entity string) ([]string, error)
+
+	// tool groups
+	CreateToolGroupFunc   func(ctx context.Context, group *types.ToolGroup) (*types.CreateToolGroupResponse, error)
+	ListToolGroupsFunc    func(ctx context.Context) ([]types.ToolGroup, error)
+	GetToolGroupFunc      func(ctx context.Context, name string) (*types.GetToolGroupResponse, error)
+	UpdateToolGroupFunc   func(ctx context.Context, group *types.ToolGroup) (*types.UpdateToolGroupResponse, error)
+	PatchToolGroupFunc    func(ctx context.Context, name string, patch *types.PatchToolGroupInput) (*types.UpdateToolGroupResponse, error)
+	ValidateToolGroupFunc func(ctx context.Context, group *types.ToolGroup) (*types.ValidateToolGroupResponse, error)
+	DeleteToolGroupFunc   func(ctx context.Context, name string) error
+
+	// MCP clients
+	CreateMcpClientFunc     func(ctx context.Context, mcpClient *types.McpClient) (string, error)
+	ListMcpClientsFunc      func(ctx context.Context) ([]types.McpClient, error)
+	DeleteMcpClientFunc     func(ctx context.Context, name string) error
+	UpdateMcpClientFunc     func(ctx context.Context, name string, patch *types.PatchMcpClientInput) (*types.McpClient, error)
+	RenewMcpClientTokenFunc func(ctx context.Context, token string) (*types.RenewMcpClientTokenResponse, error)
+
+	// users
+	CreateUserFunc           func(ctx context.Context, user *types.CreateUserRequest) (*types.CreateUserResponse, error)
+	ListUsersFunc            func(ctx context.Context) ([]*types.User, error)
+	DeleteUserFunc           func(ctx context.Context, username string) error
+	SuspendUserFunc          func(ctx context.Context, username string) (*types.User, error)
+	ActivateUserFunc         func(ctx context.Context, username string) (*types.User, error)
+	SetUserToolPolicyFunc    func(ctx context.Context, username string, policy *types.ToolPolicy) (*types.User, error)
+	DeleteUserToolPolicyFunc func(ctx context.Context, username string) error
+	WhoamiFunc               func(ctx context.Context, accessToken string) (*types.User, error)
+	RenewAccessTokenFunc     func(ctx context.Context) (*types.RenewAccessTokenResponse, error)
+
+	// teams
+	CreateTeamFunc func(ctx context.Context, team *types.Team) (*types.Team, error)
+	ListTeamsFunc  func(ctx context.Context) ([]*types.Team, error)
+	GetTeamFunc    func(ctx context.Context, name string) (*types.Team, error)
+	UpdateTeamFunc func(ctx context.Context, team *types.Team) (*types.Team, error)
+	DeleteTeamFunc func(ctx context.Context, name string) error
+
+	// logs
+	GetLogsFunc    func(ctx context.Context, opts *types.LogsOptions) ([]types.LogEntry, error)
+	FollowLogsFunc func(ctx context.Context, opts *types.LogsOptions) (<-chan types.LogEntry, error)
+}
+
+var _ client.Interface = (*Fake)(nil)
+
+func (f *Fake) BaseURL() string {
+	return f.URL
+}
+
+func (f *Fake) InitServerContext(ctx context.Context) (*client.InitServerResponse, error) {
+	if f.InitServerFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.InitServerFunc(ctx)
+}
+
+func (f *Fake) InitServer() (*client.InitServerResponse, error) {
+	return f.InitServerContext(context.Background())
+}
+
+func (f *Fake) GetServerMetadata(ctx context.Context) (*types.ServerMetadata, error) {
+	if f.GetServerMetadataFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.GetServerMetadataFunc(ctx)
+}
+
+func (f *Fake) GetHealthDetails(ctx context.Context) (*types.HealthDetails, error) {
+	if f.GetHealthDetailsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.GetHealthDetailsFunc(ctx)
+}
+
+func (f *Fake) Health(ctx context.Context) (*types.ReadinessResponse, error) {
+	if f.HealthFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.HealthFunc(ctx)
+}
+
+func (f *Fake) ServerInfo(ctx context.Context) (*types.ServerInfo, error) {
+	if f.ServerInfoFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.ServerInfoFunc(ctx)
+}
+
+func (f *Fake) GetStatsContext(ctx context.Context) (*types.RegistryStats, error) {
+	if f.GetStatsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.GetStatsFunc(ctx)
+}
+
+func (f *Fake) GetStats() (*types.RegistryStats, error) {
+	return f.GetStatsContext(context.Background())
+}
+
+func (f *Fake) SubscribeEvents(ctx context.Context) (<-chan types.RegistryEvent, error) {
+	if f.SubscribeEventsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.SubscribeEventsFunc(ctx)
+}
+
+func (f *Fake) RegisterServerContext(ctx context.Context, server *types.RegisterServerInput) (*types.McpServer, error) {
+	if f.RegisterServerFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.RegisterServerFunc(ctx, server)
+}
+
+func (f *Fake) RegisterServer(server *types.RegisterServerInput) (*types.McpServer, error) {
+	return f.RegisterServerContext(context.Background(), server)
+}
+
+// RegisterServerFromFileContext parses the configuration file like the client does, then calls RegisterServerFunc.
+func (f *Fake) RegisterServerFromFileContext(ctx context.Context, path string) (*types.McpServer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return f.RegisterServerFromReaderContext(ctx, file)
+}
+
+func (f *Fake) RegisterServerFromFile(path string) (*types.McpServer, error) {
+	return f.RegisterServerFromFileContext(context.Background(), path)
+}
+
+// RegisterServerFromReaderContext parses the configuration like the client does, then calls RegisterServerFunc.
+func (f *Fake) RegisterServerFromReaderContext(ctx context.Context, r io.Reader) (*types.McpServer, error) {
+	input, err := client.ParseServerConfig(r)
+	if err != nil {
+		return nil, err
+	}
+	return f.RegisterServerContext(ctx, input)
+}
+
+func (f *Fake) RegisterServerFromReader(r io.Reader) (*types.McpServer, error) {
+	return f.RegisterServerFromReaderContext(context.Background(), r)
+}
+
+func (f *Fake) TestServerContext(ctx context.Context, input *types.TestServerInput) (*types.ServerTestReport, error) {
+	if f.TestServerFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.TestServerFunc(ctx, input)
+}
+
+func (f *Fake) TestServer(input *types.TestServerInput) (*types.ServerTestReport, error) {
+	return f.TestServerContext(context.Background(), input)
+}
+
+func (f *Fake) ListServersContext(ctx context.Context) ([]*types.McpServer, error) {
+	if f.ListServersFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.ListServersFunc(ctx)
+}
+
+func (f *Fake) ListServers() ([]*types.McpServer, error) {
+	return f.ListServersContext(context.Background())
+}
+
+func (f *Fake) UpdateServerContext(ctx context.Context, name string, server *types.RegisterServerInput) (*types.McpServer, error) {
+	if f.UpdateServerFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.UpdateServerFunc(ctx, name, server)
+}
+
+func (f *Fake) UpdateServer(name string, server *types.RegisterServerInput) (*types.McpServer, error) {
+	return f.UpdateServerContext(context.Background(), name, server)
+}
+
+func (f *Fake) DeregisterServerContext(ctx context.Context, name string) error {
+	if f.DeregisterServerFunc == nil {
+		return ErrNotImplemented
+	}
+	return f.DeregisterServerFunc(ctx, name)
+}
+
+func (f *Fake) DeregisterServer(name string) error {
+	return f.DeregisterServerContext(context.Background(), name)
+}
+
+func (f *Fake) EnableServerContext(ctx context.Context, name string) (*types.EnableDisableServerResult, error) {
+	if f.EnableServerFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.EnableServerFunc(ctx, name)
+}
+
+func (f *Fake) EnableServer(name string) (*types.EnableDisableServerResult, error) {
+	return f.EnableServerContext(context.Background(), name)
+}
+
+func (f *Fake) DisableServerContext(ctx context.Context, name string) (*types.EnableDisableServerResult, error) {
+	if f.DisableServerFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.DisableServerFunc(ctx, name)
+}
+
+func (f *Fake) DisableServer(name string) (*types.EnableDisableServerResult, error) {
+	return f.DisableServerContext(context.Background(), name)
+}
+
+func (f *Fake) GetServerConsumersContext(ctx context.Context, name string) (*types.ServerConsumers, error) {
+	if f.GetServerConsumersFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.GetServerConsumersFunc(ctx, name)
+}
+
+func (f *Fake) GetServerConsumers(name string) (*types.ServerConsumers, error) {
+	return f.GetServerConsumersContext(context.Background(), name)
+}
+
+func (f *Fake) ListToolsContext(ctx context.Context, server string) ([]*types.Tool, error) {
+	if f.ListToolsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.ListToolsFunc(ctx, server)
+}
+
+func (f *Fake) ListTools(server string) ([]*types.Tool, error) {
+	return f.ListToolsContext(context.Background(), server)
+}
+
+func (f *Fake) ListToolsWithOptionsContext(ctx context.Context, opts *types.ListToolsOptions) ([]*types.Tool, error) {
+	if f.ListToolsWithOptionsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.ListToolsWithOptionsFunc(ctx, opts)
+}
+
+func (f *Fake) ListToolsWithOptions(opts *types.ListToolsOptions) ([]*types.Tool, error) {
+	return f.ListToolsWithOptionsContext(context.Background(), opts)
+}
+
+func (f *Fake) GetToolContext(ctx context.Context, name string) (*types.Tool, error) {
+	if f.GetToolFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.GetToolFunc(ctx, name)
+}
+
+func (f *Fake) GetTool(name string) (*types.Tool, error) {
+	return f.GetToolContext(context.Background(), name)
+}
+
+func (f *Fake) EnableToolsContext(ctx context.Context, name string) ([]string, error) {
+	if f.EnableToolsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.EnableToolsFunc(ctx, name)
+}
+
+func (f *Fake) EnableTools(name string) ([]string, error) {
+	return f.EnableToolsContext(context.Background(), name)
+}
+
+func (f *Fake) DisableToolsContext(ctx context.Context, name string) ([]string, error) {
+	if f.DisableToolsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.DisableToolsFunc(ctx, name)
+}
+
+func (f *Fake) DisableTools(name string) ([]string, error) {
+	return f.DisableToolsContext(context.Background(), name)
+}
+
+func (f *Fake) EnableToolsBulkContext(ctx context.Context, entities []string) ([]types.BulkEntityResult, error) {
+	if f.EnableToolsBulkFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.EnableToolsBulkFunc(ctx, entities)
+}
+
+func (f *Fake) EnableToolsBulk(entities []string) ([]types.BulkEntityResult, error) {
+	return f.EnableToolsBulkContext(context.Background(), entities)
+}
+
+func (f *Fake) DisableToolsBulkContext(ctx context.Context, entities []string) ([]types.BulkEntityResult, error) {
+	if f.DisableToolsBulkFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.DisableToolsBulkFunc(ctx, entities)
+}
+
+func (f *Fake) DisableToolsBulk(entities []string) ([]types.BulkEntityResult, error) {
+	return f.DisableToolsBulkContext(context.Background(), entities)
+}
+
+func (f *Fake) InvokeToolContext(ctx context.Context, name string, input map[string]any) (*types.ToolInvokeResult, error) {
+	if f.InvokeToolFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.InvokeToolFunc(ctx, name, input)
+}
+
+func (f *Fake) InvokeTool(name string, input map[string]any) (*types.ToolInvokeResult, error) {
+	return f.InvokeToolContext(context.Background(), name, input)
+}
+
+func (f *Fake) InvokeToolStream(ctx context.Context, name string, input map[string]any) (<-chan types.ToolInvokeStreamEvent, error) {
+	if f.InvokeToolStreamFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.InvokeToolStreamFunc(ctx, name, input)
+}
+
+func (f *Fake) InvokeToolWithProgress(ctx context.Context, name string, input map[string]any, onProgress func(types.ToolProgress)) (*types.ToolInvokeResult, error) {
+	if f.InvokeToolWithProgressFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.InvokeToolWithProgressFunc(ctx, name, input, onProgress)
+}
+
+func (f *Fake) InvokeToolAsyncContext(ctx context.Context, name string, input map[string]any) (*types.ToolInvocationJob, error) {
+	if f.InvokeToolAsyncFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.InvokeToolAsyncFunc(ctx, name, input)
+}
+
+func (f *Fake) InvokeToolAsync(name string, input map[string]any) (*types.ToolInvocationJob, error) {
+	return f.InvokeToolAsyncContext(context.Background(), name, input)
+}
+
+func (f *Fake) GetJobContext(ctx context.Context, id string) (*types.ToolInvocationJob, error) {
+	if f.GetJobFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.GetJobFunc(ctx, id)
+}
+
+func (f *Fake) GetJob(id string) (*types.ToolInvocationJob, error) {
+	return f.GetJobContext(context.Background(), id)
+}
+
+func (f *Fake) WaitForJob(ctx context.Context, jobID string, pollInterval time.Duration) (*types.ToolInvokeResult, error) {
+	if f.WaitForJobFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.WaitForJobFunc(ctx, jobID, pollInterval)
+}
+
+func (f *Fake) ListInvocationsContext(ctx context.Context, opts *types.ListInvocationsOptions) ([]*types.ToolInvocation, error) {
+	if f.ListInvocationsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.ListInvocationsFunc(ctx, opts)
+}
+
+func (f *Fake) ListInvocations(opts *types.ListInvocationsOptions) ([]*types.ToolInvocation, error) {
+	return f.ListInvocationsContext(context.Background(), opts)
+}
+
+func (f *Fake) SearchContext(ctx context.Context, query string, resultType types.SearchResultType, limit int) ([]types.SearchResult, error) {
+	if f.SearchFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.SearchFunc(ctx, query, resultType, limit)
+}
+
+func (f *Fake) Search(query string, resultType types.SearchResultType, limit int) ([]types.SearchResult, error) {
+	return f.SearchContext(context.Background(), query, resultType, limit)
+}
+
+func (f *Fake) ListPromptsContext(ctx context.Context, serverName string) ([]model.Prompt, error) {
+	if f.ListPromptsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.ListPromptsFunc(ctx, serverName)
+}
+
+func (f *Fake) ListPrompts(serverName string) ([]model.Prompt, error) {
+	return f.ListPromptsContext(context.Background(), serverName)
+}
+
+func (f *Fake) GetPromptContext(ctx context.Context, name string) (*model.Prompt, error) {
+	if f.GetPromptFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.GetPromptFunc(ctx, name)
+}
+
+func (f *Fake) GetPrompt(name string) (*model.Prompt, error) {
+	return f.GetPromptContext(context.Background(), name)
+}
+
+func (f *Fake) GetPromptWithArgsContext(ctx context.Context, name string, arguments map[string]string) (*types.PromptResult, error) {
+	if f.GetPromptWithArgsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.GetPromptWithArgsFunc(ctx, name, arguments)
+}
+
+func (f *Fake) GetPromptWithArgs(name string, arguments map[string]string) (*types.PromptResult, error) {
+	return f.GetPromptWithArgsContext(context.Background(), name, arguments)
+}
+
+func (f *Fake) EnablePromptsContext(ctx context.Context, entity string) ([]string, error) {
+	if f.EnablePromptsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.EnablePromptsFunc(ctx, entity)
+}
+
+func (f *Fake) EnablePrompts(entity string) ([]string, error) {
+	return f.EnablePromptsContext(context.Background(), entity)
+}
+
+func (f *Fake) DisablePromptsContext(ctx context.Context, entity string) ([]string, error) {
+	if f.DisablePromptsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.DisablePromptsFunc(ctx, entity)
+}
+
+func (f *Fake) DisablePrompts(entity string) ([]string, error) {
+	return f.DisablePromptsContext(context.Background(), entity)
+}
+
+func (f *Fake) CreateToolGroupContext(ctx context.Context, group *types.ToolGroup) (*types.CreateToolGroupResponse, error) {
+	if f.CreateToolGroupFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.CreateToolGroupFunc(ctx, group)
+}
+
+func (f *Fake) CreateToolGroup(group *types.ToolGroup) (*types.CreateToolGroupResponse, error) {
+	return f.CreateToolGroupContext(context.Background(), group)
+}
+
+func (f *Fake) ListToolGroupsContext(ctx context.Context) ([]types.ToolGroup, error) {
+	if f.ListToolGroupsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.ListToolGroupsFunc(ctx)
+}
+
+func (f *Fake) ListToolGroups() ([]types.ToolGroup, error) {
+	return f.ListToolGroupsContext(context.Background())
+}
+
+func (f *Fake) GetToolGroupContext(ctx context.Context, name string) (*types.GetToolGroupResponse, error) {
+	if f.GetToolGroupFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.GetToolGroupFunc(ctx, name)
+}
+
+func (f *Fake) GetToolGroup(name string) (*types.GetToolGroupResponse, error) {
+	return f.GetToolGroupContext(context.Background(), name)
+}
+
+func (f *Fake) UpdateToolGroupContext(ctx context.Context, group *types.ToolGroup) (*types.UpdateToolGroupResponse, error) {
+	if f.UpdateToolGroupFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.UpdateToolGroupFunc(ctx, group)
+}
+
+func (f *Fake) UpdateToolGroup(group *types.ToolGroup) (*types.UpdateToolGroupResponse, error) {
+	return f.UpdateToolGroupContext(context.Background(), group)
+}
+
+func (f *Fake) PatchToolGroupContext(ctx context.Context, name string, patch *types.PatchToolGroupInput) (*types.UpdateToolGroupResponse, error) {
+	if f.PatchToolGroupFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.PatchToolGroupFunc(ctx, name, patch)
+}
+
+func (f *Fake) PatchToolGroup(name string, patch *types.PatchToolGroupInput) (*types.UpdateToolGroupResponse, error) {
+	return f.PatchToolGroupContext(context.Background(), name, patch)
+}
+
+func (f *Fake) ValidateToolGroupContext(ctx context.Context, group *types.ToolGroup) (*types.ValidateToolGroupResponse, error) {
+	if f.ValidateToolGroupFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.ValidateToolGroupFunc(ctx, group)
+}
+
+func (f *Fake) ValidateToolGroup(group *types.ToolGroup) (*types.ValidateToolGroupResponse, error) {
+	return f.ValidateToolGroupContext(context.Background(), group)
+}
+
+func (f *Fake) DeleteToolGroupContext(ctx context.Context, name string) error {
+	if f.DeleteToolGroupFunc == nil {
+		return ErrNotImplemented
+	}
+	return f.DeleteToolGroupFunc(ctx, name)
+}
+
+func (f *Fake) DeleteToolGroup(name string) error {
+	return f.DeleteToolGroupContext(context.Background(), name)
+}
+
+func (f *Fake) CreateMcpClientContext(ctx context.Context, mcpClient *types.McpClient) (string, error) {
+	if f.CreateMcpClientFunc == nil {
+		return "", ErrNotImplemented
+	}
+	return f.CreateMcpClientFunc(ctx, mcpClient)
+}
+
+func (f *Fake) CreateMcpClient(mcpClient *types.McpClient) (string, error) {
+	return f.CreateMcpClientContext(context.Background(), mcpClient)
+}
+
+func (f *Fake) ListMcpClientsContext(ctx context.Context) ([]types.McpClient, error) {
+	if f.ListMcpClientsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.ListMcpClientsFunc(ctx)
+}
+
+func (f *Fake) ListMcpClients() ([]types.McpClient, error) {
+	return f.ListMcpClientsContext(context.Background())
+}
+
+func (f *Fake) DeleteMcpClientContext(ctx context.Context, name string) error {
+	if f.DeleteMcpClientFunc == nil {
+		return ErrNotImplemented
+	}
+	return f.DeleteMcpClientFunc(ctx, name)
+}
+
+func (f *Fake) DeleteMcpClient(name string) error {
+	return f.DeleteMcpClientContext(context.Background(), name)
+}
+
+func (f *Fake) UpdateMcpClientContext(
+	ctx context.Context, name string, patch *types.PatchMcpClientInput,
+) (*types.McpClient, error) {
+	if f.UpdateMcpClientFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.UpdateMcpClientFunc(ctx, name, patch)
+}
+
+func (f *Fake) UpdateMcpClient(name string, patch *types.PatchMcpClientInput) (*types.McpClient, error) {
+	return f.UpdateMcpClientContext(context.Background(), name, patch)
+}
+
+func (f *Fake) RenewMcpClientTokenContext(
+	ctx context.Context, token string,
+) (*types.RenewMcpClientTokenResponse, error) {
+	if f.RenewMcpClientTokenFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.RenewMcpClientTokenFunc(ctx, token)
+}
+
+func (f *Fake) RenewMcpClientToken(token string) (*types.RenewMcpClientTokenResponse, error) {
+	return f.RenewMcpClientTokenContext(context.Background(), token)
+}
+
+func (f *Fake) CreateUserContext(ctx context.Context, user *types.CreateUserRequest) (*types.CreateUserResponse, error) {
+	if f.CreateUserFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.CreateUserFunc(ctx, user)
+}
+
+func (f *Fake) CreateUser(user *types.CreateUserRequest) (*types.CreateUserResponse, error) {
+	return f.CreateUserContext(context.Background(), user)
+}
+
+func (f *Fake) ListUsersContext(ctx context.Context) ([]*types.User, error) {
+	if f.ListUsersFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.ListUsersFunc(ctx)
+}
+
+func (f *Fake) ListUsers() ([]*types.User, error) {
+	return f.ListUsersContext(context.Background())
+}
+
+func (f *Fake) DeleteUserContext(ctx context.Context, username string) error {
+	if f.DeleteUserFunc == nil {
+		return ErrNotImplemented
+	}
+	return f.DeleteUserFunc(ctx, username)
+}
+
+func (f *Fake) DeleteUser(username string) error {
+	return f.DeleteUserContext(context.Background(), username)
+}
+
+func (f *Fake) SuspendUserContext(ctx context.Context, username string) (*types.User, error) {
+	if f.SuspendUserFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.SuspendUserFunc(ctx, username)
+}
+
+func (f *Fake) SuspendUser(username string) (*types.User, error) {
+	return f.SuspendUserContext(context.Background(), username)
+}
+
+func (f *Fake) ActivateUserContext(ctx context.Context, username string) (*types.User, error) {
+	if f.ActivateUserFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.ActivateUserFunc(ctx, username)
+}
+
+func (f *Fake) ActivateUser(username string) (*types.User, error) {
+	return f.ActivateUserContext(context.Background(), username)
+}
+
+func (f *Fake) SetUserToolPolicyContext(
+	ctx context.Context, username string, policy *types.ToolPolicy,
+) (*types.User, error) {
+	if f.SetUserToolPolicyFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.SetUserToolPolicyFunc(ctx, username, policy)
+}
+
+func (f *Fake) SetUserToolPolicy(username string, policy *types.ToolPolicy) (*types.User, error) {
+	return f.SetUserToolPolicyContext(context.Background(), username, policy)
+}
+
+func (f *Fake) DeleteUserToolPolicyContext(ctx context.Context, username string) error {
+	if f.DeleteUserToolPolicyFunc == nil {
+		return ErrNotImplemented
+	}
+	return f.DeleteUserToolPolicyFunc(ctx, username)
+}
+
+func (f *Fake) DeleteUserToolPolicy(username string) error {
+	return f.DeleteUserToolPolicyContext(context.Background(), username)
+}
+
+func (f *Fake) WhoamiContext(ctx context.Context, accessToken string) (*types.User, error) {
+	if f.WhoamiFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.WhoamiFunc(ctx, accessToken)
+}
+
+func (f *Fake) Whoami(accessToken string) (*types.User, error) {
+	return f.WhoamiContext(context.Background(), accessToken)
+}
+
+func (f *Fake) RenewAccessTokenContext(ctx context.Context) (*types.RenewAccessTokenResponse, error) {
+	if f.RenewAccessTokenFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.RenewAccessTokenFunc(ctx)
+}
+
+func (f *Fake) RenewAccessToken() (*types.RenewAccessTokenResponse, error) {
+	return f.RenewAccessTokenContext(context.Background())
+}
+
+func (f *Fake) CreateTeamContext(ctx context.Context, team *types.Team) (*types.Team, error) {
+	if f.CreateTeamFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.CreateTeamFunc(ctx, team)
+}
+
+func (f *Fake) CreateTeam(team *types.Team) (*types.Team, error) {
+	return f.CreateTeamContext(context.Background(), team)
+}
+
+func (f *Fake) ListTeamsContext(ctx context.Context) ([]*types.Team, error) {
+	if f.ListTeamsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.ListTeamsFunc(ctx)
+}
+
+func (f *Fake) ListTeams() ([]*types.Team, error) {
+	return f.ListTeamsContext(context.Background())
+}
+
+func (f *Fake) GetTeamContext(ctx context.Context, name string) (*types.Team, error) {
+	if f.GetTeamFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.GetTeamFunc(ctx, name)
+}
+
+func (f *Fake) GetTeam(name string) (*types.Team, error) {
+	return f.GetTeamContext(context.Background(), name)
+}
+
+func (f *Fake) UpdateTeamContext(ctx context.Context, team *types.Team) (*types.Team, error) {
+	if f.UpdateTeamFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.UpdateTeamFunc(ctx, team)
+}
+
+func (f *Fake) UpdateTeam(team *types.Team) (*types.Team, error) {
+	return f.UpdateTeamContext(context.Background(), team)
+}
+
+func (f *Fake) DeleteTeamContext(ctx context.Context, name string) error {
+	if f.DeleteTeamFunc == nil {
+		return ErrNotImplemented
+	}
+	return f.DeleteTeamFunc(ctx, name)
+}
+
+func (f *Fake) DeleteTeam(name string) error {
+	return f.DeleteTeamContext(context.Background(), name)
+}
+
+func (f *Fake) GetLogsContext(ctx context.Context, opts *types.LogsOptions) ([]types.LogEntry, error) {
+	if f.GetLogsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.GetLogsFunc(ctx, opts)
+}
+
+func (f *Fake) GetLogs(opts *types.LogsOptions) ([]types.LogEntry, error) {
+	return f.GetLogsContext(context.Background(), opts)
+}
+
+func (f *Fake) FollowLogs(ctx context.Context, opts *types.LogsOptions) (<-chan types.LogEntry, error) {
+	if f.FollowLogsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return f.FollowLogsFunc(ctx, opts)
+}
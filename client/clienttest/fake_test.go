@@ -0,0 +1,69 @@
+package clienttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/client"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// enabledToolNames is the kind of code a downstream program would test with the fake.
+func enabledToolNames(c client.Interface, server string) ([]string, error) {
+	tools, err := c.ListTools(server)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, t := range tools {
+		if t.Enabled {
+			names = append(names, t.Name)
+		}
+	}
+	return names, nil
+}
+
+func TestFake(t *testing.T) {
+	t.Parallel()
+
+	t.Run("function is called by both variants", func(t *testing.T) {
+		var gotServer string
+		f := &Fake{
+			ListToolsFunc: func(ctx context.Context, server string) ([]*types.Tool, error) {
+				gotServer = server
+				return []*types.Tool{
+					{Name: "time__get_current_time", Enabled: true},
+					{Name: "time__convert_time", Enabled: false},
+				}, nil
+			},
+		}
+
+		names, err := enabledToolNames(f, "time")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if gotServer != "time" || len(names) != 1 || names[0] != "time__get_current_time" {
+			t.Errorf("Unexpected names %v for server %s", names, gotServer)
+		}
+
+		tools, err := f.ListToolsContext(context.Background(), "time")
+		if err != nil || len(tools) != 2 {
+			t.Errorf("Expected the context variant to call the same function, got %v, %v", tools, err)
+		}
+	})
+
+	t.Run("unset function", func(t *testing.T) {
+		f := &Fake{URL: "http://localhost:8080"}
+
+		if _, err := f.ListServers(); !errors.Is(err, ErrNotImplemented) {
+			t.Errorf("Expected ErrNotImplemented, got %v", err)
+		}
+		if err := f.DeleteUser("alice"); !errors.Is(err, ErrNotImplemented) {
+			t.Errorf("Expected ErrNotImplemented, got %v", err)
+		}
+		if f.BaseURL() != "http://localhost:8080" {
+			t.Errorf("Unexpected base URL: %s", f.BaseURL())
+		}
+	})
+}
@@ -0,0 +1,177 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// EventsReconnected is the type of the event SubscribeEvents delivers after it reconnects to the server.
+// The server doesn't keep past events, so the ones published while the client was disconnected are lost:
+// any state derived from the events (eg- a cache of the registry) must be refreshed when this event is received.
+// It is only set by the client, so its ID and Entity are empty.
+const EventsReconnected types.RegistryEventType = "events.reconnected"
+
+// maxEventSize is the size of the largest server-sent event the client reads, eg- a large tool result.
+const maxEventSize = 16 << 20
+
+// SubscribeEvents opens a stream of registry change events.
+// Events are delivered on the returned channel as they happen, until the context is cancelled.
+// If the stream is interrupted, eg- because the server restarted, the client reconnects with an increasing delay
+// and delivers an EventsReconnected event once it succeeds.
+// It stops (and closes the channel) if the server rejects the subscription, eg- because the access token was revoked.
+// The errors that interrupt or stop the stream are passed to the handler set with WithStreamErrorHandler.
+func (c *Client) SubscribeEvents(ctx context.Context) (<-chan types.RegistryEvent, error) {
+	u, _ := c.constructAPIEndpoint("/events")
+	body, err := c.openEventStream(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan types.RegistryEvent)
+	go c.followEventStream(ctx, u, body, events)
+	return events, nil
+}
+
+// followEventStream delivers the registry events read from body on out, and reopens the stream at u
+// every time it ends, until the context is cancelled or the server rejects the request. It closes out when done.
+func (c *Client) followEventStream(ctx context.Context, u string, body io.ReadCloser, out chan<- types.RegistryEvent) {
+	defer close(out)
+	for {
+		err := forwardEventStream(ctx, body, out, "registry event", c.reportStreamError)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			c.reportStreamError(fmt.Errorf("the event stream was interrupted: %w", err))
+		}
+		if body = c.reopenEventStream(ctx, u); body == nil {
+			return
+		}
+
+		select {
+		case out <- types.RegistryEvent{Type: EventsReconnected, Timestamp: time.Now()}:
+		case <-ctx.Done():
+			body.Close()
+			return
+		}
+	}
+}
+
+// reopenEventStream tries to open the event stream at u until it succeeds, waiting longer after each failure.
+// It returns nil if the context is cancelled, or if the server rejects the request since retrying won't help then.
+func (c *Client) reopenEventStream(ctx context.Context, u string) io.ReadCloser {
+	for attempt := 0; ; attempt++ {
+		timer := time.NewTimer(c.reconnect.backoff(attempt, nil))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+
+		body, err := c.openEventStream(ctx, u)
+		if err == nil {
+			return body
+		}
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode >= 400 && apiErr.StatusCode < 500 &&
+			apiErr.StatusCode != http.StatusRequestTimeout && apiErr.StatusCode != http.StatusTooManyRequests {
+			c.reportStreamError(fmt.Errorf("stopped following the event stream: %w", err))
+			return nil
+		}
+	}
+}
+
+// openEventStream sends a request to an endpoint that streams server-sent events (SSE)
+// and returns the body of the response.
+func (c *Client) openEventStream(ctx context.Context, u string) (io.ReadCloser, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, c.parseErrorResponse(resp)
+	}
+	return resp.Body, nil
+}
+
+// readEventStream decodes the JSON data of the server-sent events read from body and delivers them on out,
+// until the context is cancelled or the stream ends. It closes both body and out when done.
+// The events that can't be decoded and the error that ended the stream, if any, are passed to onError,
+// where what describes the events.
+func readEventStream[T any](ctx context.Context, body io.ReadCloser, out chan<- T, what string, onError func(error)) {
+	defer close(out)
+	if err := forwardEventStream(ctx, body, out, what, onError); err != nil && ctx.Err() == nil {
+		onError(fmt.Errorf("failed to read the stream of %ss: %w", what, err))
+	}
+}
+
+// forwardEventStream is like readEventStream but leaves out open, so that more events can be delivered on it,
+// and returns the error that ended the stream instead of passing it to onError.
+// It returns nil if the stream ended normally, and the context's error if it was cancelled.
+func forwardEventStream[T any](
+	ctx context.Context, body io.ReadCloser, out chan<- T, what string, onError func(error),
+) error {
+	defer body.Close()
+
+	var data strings.Builder
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(nil, maxEventSize)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// a blank line marks the end of an event, other fields like id & event are
+		// also part of the JSON data, so only the data field needs to be read
+		if line == "" {
+			if data.Len() == 0 {
+				continue
+			}
+			var e T
+			if err := json.Unmarshal([]byte(data.String()), &e); err != nil {
+				onError(fmt.Errorf("failed to decode %s: %w", what, err))
+			} else {
+				select {
+				case out <- e:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+			data.Reset()
+			continue
+		}
+
+		if v, ok := strings.CutPrefix(line, "data:"); ok {
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(v, " "))
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return scanner.Err()
+}
+
+// reportStreamError passes an error of a stream to the handler set with WithStreamErrorHandler, if any.
+func (c *Client) reportStreamError(err error) {
+	if c.streamErrorHandler != nil {
+		c.streamErrorHandler(err)
+	}
+}
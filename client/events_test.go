@@ -0,0 +1,154 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestSubscribeEvents(t *testing.T) {
+	t.Parallel()
+
+	t.Run("receives events and reconnects", func(t *testing.T) {
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasSuffix(r.URL.Path, "/api/v1/events") {
+				t.Errorf("Expected path to end with /api/v1/events, got %s", r.URL.Path)
+			}
+			if r.Header.Get("Authorization") != "Bearer test-token" {
+				t.Errorf("Expected bearer token to be sent, got %q", r.Header.Get("Authorization"))
+			}
+			switch requests.Add(1) {
+			case 1:
+				w.Header().Set("Content-Type", "text/event-stream")
+				fmt.Fprint(w, ": keep-alive\n\n")
+				fmt.Fprint(w, "id:1\nevent:server.registered\ndata:{\"id\":1,\"type\":\"server.registered\",\"entity\":\"calculator\"}\n\n")
+				fmt.Fprint(w, "id:2\nevent:tools.disabled\n"+
+					"data:{\"id\":2,\"type\":\"tools.disabled\",\"entity\":\"calculator\",\"affected\":[\"calculator__add\"]}\n\n")
+			case 2:
+				// the server is restarting
+				w.WriteHeader(http.StatusServiceUnavailable)
+			case 3:
+				w.Header().Set("Content-Type", "text/event-stream")
+				fmt.Fprint(w, "id:1\nevent:server.deregistered\ndata:{\"id\":1,\"type\":\"server.deregistered\",\"entity\":\"calculator\"}\n\n")
+			default:
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"error": "invalid access token"}`))
+			}
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL, WithToken("test-token"))
+		client.reconnect = fastRetries
+		events, err := client.SubscribeEvents(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// the channel is closed once the server rejects the subscription
+		var received []string
+		for e := range events {
+			received = append(received, fmt.Sprintf("%d %s %s %v", e.ID, e.Type, e.Entity, e.Affected))
+		}
+		expected := []string{
+			"1 server.registered calculator []",
+			"2 tools.disabled calculator [calculator__add]",
+			"0 events.reconnected  []",
+			"1 server.deregistered calculator []",
+		}
+		if strings.Join(received, "\n") != strings.Join(expected, "\n") {
+			t.Errorf("Expected events %v, got %v", expected, received)
+		}
+		if requests.Load() != 4 {
+			t.Errorf("Expected 4 requests, got %d", requests.Load())
+		}
+	})
+
+	t.Run("reports stream errors", func(t *testing.T) {
+		var requests atomic.Int32
+		large := strings.Repeat("x", 100_000)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if requests.Add(1) > 1 {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"error": "invalid access token"}`))
+				return
+			}
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "data:{not json\n\n")
+			fmt.Fprintf(w, "data:{\"id\":1,\"type\":\"server.registered\",\"entity\":\"%s\"}\n\n", large)
+		}))
+		defer server.Close()
+
+		var mu sync.Mutex
+		var errs []string
+		client := NewClient(server.URL, WithStreamErrorHandler(func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, err.Error())
+		}))
+		client.reconnect = fastRetries
+		events, err := client.SubscribeEvents(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		var received []types.RegistryEvent
+		for e := range events {
+			received = append(received, e)
+		}
+		if len(received) != 1 || received[0].Entity != large {
+			t.Errorf("Expected the large event to be received, got %d events", len(received))
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if len(errs) != 2 || !strings.Contains(errs[0], "failed to decode registry event") ||
+			!strings.Contains(errs[1], "stopped following the event stream") {
+			t.Errorf("Expected the decoding & subscription errors to be reported, got %v", errs)
+		}
+	})
+
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// the stream always ends right away
+			w.Header().Set("Content-Type", "text/event-stream")
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL)
+		client.reconnect = fastRetries
+		ctx, cancel := context.WithCancel(context.Background())
+		events, err := client.SubscribeEvents(ctx)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if e := <-events; e.Type != EventsReconnected {
+			t.Errorf("Expected a reconnection event, got %+v", e)
+		}
+		cancel()
+		for range events {
+			// drain the events delivered before the cancellation was noticed
+		}
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error": "only admins can subscribe to events"}`))
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL)
+		_, err := client.SubscribeEvents(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "only admins") {
+			t.Errorf("Expected error from server, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// GetHealthDetails fetches the readiness checks of the MCPJungle server along with the health of every
+// registered MCP server.
+// This is expensive, since the server connects to every MCP server (and starts the stdio ones) to check them.
+func (c *Client) GetHealthDetails(ctx context.Context) (*types.HealthDetails, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.baseURL+"/health/details", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", req.URL.String(), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var details types.HealthDetails
+	if err := json.NewDecoder(resp.Body).Decode(&details); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &details, nil
+}
+
+// Health runs the readiness checks of the MCPJungle server.
+// A server that is not ready is not an error: its status tells whether it is ready (see types.ReadinessResponse.Ready),
+// and its checks tell why not. An error is only returned if the server can't be reached or responds unexpectedly.
+func (c *Client) Health(ctx context.Context) (*types.ReadinessResponse, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.baseURL+"/readyz", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", req.URL.String(), err)
+	}
+	defer resp.Body.Close()
+
+	// the server responds with 503 when it is not ready, along with the failed checks
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusServiceUnavailable {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var readiness types.ReadinessResponse
+	if err := json.NewDecoder(resp.Body).Decode(&readiness); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			// not a readiness response, eg- from a proxy in front of the server
+			return nil, fmt.Errorf("server is unavailable (status %d)", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &readiness, nil
+}
+
+// ServerInfo describes the MCPJungle server: its version, its mode, whether it is initialized,
+// and whether it is ready to serve requests along with the health of its dependencies.
+// Programs embedding the client can use it to wait for the server to be ready before starting.
+func (c *Client) ServerInfo(ctx context.Context) (*types.ServerInfo, error) {
+	metadata, err := c.GetServerMetadata(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server metadata: %w", err)
+	}
+	readiness, err := c.Health(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check server health: %w", err)
+	}
+	return &types.ServerInfo{ServerMetadata: *metadata, ReadinessResponse: *readiness}, nil
+}
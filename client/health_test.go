@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestGetHealthDetails(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/health/details" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("Expected the access token to be sent, got %q", r.Header.Get("Authorization"))
+		}
+		_ = json.NewEncoder(w).Encode(types.HealthDetails{
+			ReadinessResponse: types.ReadinessResponse{Status: types.HealthStatusOK},
+			Servers: []types.UpstreamServerHealth{
+				{Name: "time", Healthy: true},
+				{Name: "github", Healthy: false, Error: "connection refused"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	details, err := client.GetHealthDetails(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(details.Servers) != 2 || details.Servers[1].Healthy || details.Servers[1].Error != "connection refused" {
+		t.Errorf("Unexpected health details: %+v", details)
+	}
+}
+
+func TestHealth(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ready", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet || r.URL.Path != "/readyz" {
+				t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			}
+			_, _ = w.Write([]byte(`{"status":"ok","checks":{"database":"ok"}}`))
+		}))
+		defer server.Close()
+
+		health, err := NewClient(server.URL).Health(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !health.Ready() || health.Checks["database"] != types.HealthCheckOK {
+			t.Errorf("Unexpected health: %+v", health)
+		}
+	})
+
+	t.Run("not ready", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"status":"unavailable","checks":{"database":"connection refused"}}`))
+		}))
+		defer server.Close()
+
+		health, err := NewClient(server.URL).Health(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if health.Ready() || health.Checks["database"] != "connection refused" {
+			t.Errorf("Unexpected health: %+v", health)
+		}
+	})
+
+	t.Run("unavailable without a readiness response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`<html>service unavailable</html>`))
+		}))
+		defer server.Close()
+
+		if _, err := NewClient(server.URL).Health(context.Background()); err == nil {
+			t.Error("Expected an error")
+		}
+	})
+}
+
+func TestServerInfo(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/metadata":
+			_, _ = w.Write([]byte(`{"version":"v1.2.3","mode":"enterprise","initialized":true}`))
+		case "/readyz":
+			_, _ = w.Write([]byte(`{"status":"degraded","checks":{"database":"timeout","mcp_proxy":"ok"}}`))
+		default:
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	info, err := NewClient(server.URL).ServerInfo(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if info.Version != "v1.2.3" || info.Mode != "enterprise" || !info.Initialized {
+		t.Errorf("Unexpected metadata: %+v", info.ServerMetadata)
+	}
+	if info.Status != types.HealthStatusDegraded || info.Ready() || info.Checks["database"] != "timeout" {
+		t.Errorf("Unexpected readiness: %+v", info.ReadinessResponse)
+	}
+}
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/mcpjungle/mcpjungle/internal/api"
+)
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey returns a context that makes the create request it is used for (eg- RegisterServerContext)
+// carry the given idempotency key. The server records the response to the first request sent with a key and
+// returns it again for the next ones, so a create request can be sent again with the same key, eg- after the program
+// restarted, without creating a duplicate or failing because the entity already exists.
+// Keys are kept by the server for a limited time (24h by default) and must not be reused for a different request.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// setIdempotencyKey adds the Idempotency-Key header to a create request, with the key from the request's context
+// or a new one if the client is configured to generate them.
+// The key is set once per request, so that retries of the request share it.
+func (c *Client) setIdempotencyKey(req *http.Request) {
+	key, _ := req.Context().Value(idempotencyKeyContextKey{}).(string)
+	if key == "" && c.idempotencyKeys {
+		key = uuid.NewString()
+	}
+	if key != "" {
+		req.Header.Set(api.IdempotencyKeyHeader, key)
+	}
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestIdempotencyKeys(t *testing.T) {
+	t.Parallel()
+
+	// keyServer fails the first request with a 502 and records the idempotency key of every request
+	keyServer := func(t *testing.T) (*httptest.Server, func() []string) {
+		var mu sync.Mutex
+		var keys []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			keys = append(keys, r.Header.Get("Idempotency-Key"))
+			first := len(keys) == 1
+			mu.Unlock()
+			if first {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"name":"time"}`))
+		}))
+		t.Cleanup(server.Close)
+		return server, func() []string {
+			mu.Lock()
+			defer mu.Unlock()
+			return append([]string(nil), keys...)
+		}
+	}
+
+	t.Run("generated key is sent again with retries", func(t *testing.T) {
+		server, keys := keyServer(t)
+		client := NewClient(server.URL, WithIdempotencyKeys(), WithRetryPolicy(fastRetries))
+
+		if _, err := client.RegisterServer(&types.RegisterServerInput{Name: "time"}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		k := keys()
+		if len(k) != 2 || k[0] == "" || k[0] != k[1] {
+			t.Errorf("Expected 2 requests with the same key, got %q", k)
+		}
+	})
+
+	t.Run("key from the context", func(t *testing.T) {
+		server, keys := keyServer(t)
+		client := NewClient(server.URL, WithRetryPolicy(fastRetries))
+
+		ctx := WithIdempotencyKey(context.Background(), "register-time")
+		if _, err := client.RegisterServerContext(ctx, &types.RegisterServerInput{Name: "time"}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if k := keys(); len(k) != 2 || k[0] != "register-time" || k[1] != "register-time" {
+			t.Errorf("Expected 2 requests with the key from the context, got %q", k)
+		}
+	})
+
+	t.Run("no key by default", func(t *testing.T) {
+		server, keys := keyServer(t)
+		client := NewClient(server.URL, WithRetryPolicy(fastRetries))
+
+		// without a key, the request may have been processed, so it isn't retried
+		if _, err := client.CreateToolGroup(&types.ToolGroup{Name: "g"}); err == nil {
+			t.Error("Expected the 502 error")
+		}
+		if k := keys(); len(k) != 1 || k[0] != "" {
+			t.Errorf("Expected a single request without key, got %q", k)
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies the spans created by the client.
+const tracerName = "github.com/mcpjungle/mcpjungle/client"
+
+// RequestHook is called right before each attempt to send a request, including retries.
+// It may modify the request, eg- to add headers. The request's body must not be read.
+type RequestHook func(req *http.Request)
+
+// ResponseHook is called after each attempt to send a request, with either the response or the error,
+// and the time the attempt took until the response headers were received.
+// The response body must not be read or closed.
+type ResponseHook func(req *http.Request, resp *http.Response, err error, elapsed time.Duration)
+
+// tracing holds what the client needs to trace its requests.
+type tracing struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// send sends a single attempt of the request, wrapped in the hooks and in a span if tracing is enabled.
+// attempt is the number of times the request was sent before, 0 for the first attempt.
+func (c *Client) send(req *http.Request, attempt int) (*http.Response, error) {
+	if c.tracing != nil {
+		var span trace.Span
+		req, span = c.startSpan(req, attempt)
+		defer span.End()
+
+		resp, err := c.sendWithHooks(req)
+		endSpan(span, resp, err)
+		return resp, err
+	}
+	return c.sendWithHooks(req)
+}
+
+func (c *Client) sendWithHooks(req *http.Request) (*http.Response, error) {
+	for _, h := range c.requestHooks {
+		h(req)
+	}
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	elapsed := time.Since(start)
+	for _, h := range c.responseHooks {
+		h(req, resp, err, elapsed)
+	}
+	return resp, err
+}
+
+// startSpan starts a client span for an attempt of the request, and injects its context in the request's headers
+// so that the server's spans join the same trace.
+func (c *Client) startSpan(req *http.Request, attempt int) (*http.Request, trace.Span) {
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.URLFull(req.URL.Redacted()),
+		semconv.ServerAddress(req.URL.Hostname()),
+	}
+	if port, err := strconv.Atoi(req.URL.Port()); err == nil {
+		attrs = append(attrs, semconv.ServerPort(port))
+	}
+	if attempt > 0 {
+		attrs = append(attrs, semconv.HTTPRequestResendCount(attempt))
+	}
+
+	ctx, span := c.tracing.tracer.Start(
+		req.Context(), req.Method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...),
+	)
+	c.tracing.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+	return req.WithContext(ctx), span
+}
+
+// endSpan records the outcome of an attempt in its span.
+func endSpan(span trace.Span, resp *http.Response, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+}
+
+// newTracing returns the tracing configuration for the given provider and propagator,
+// falling back to the global ones set with the otel package.
+func newTracing(tp trace.TracerProvider, propagator propagation.TextMapPropagator) *tracing {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	if propagator == nil {
+		propagator = otel.GetTextMapPropagator()
+	}
+	return &tracing{tracer: tp.Tracer(tracerName), propagator: propagator}
+}
@@ -0,0 +1,143 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracing(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	var traceparents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparents = append(traceparents, r.Header.Get("Traceparent"))
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	client := NewClient(server.URL, WithTracing(tp, propagation.TraceContext{}), WithRetryPolicy(fastRetries))
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	if _, err := client.ListServersContext(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	parent.End()
+
+	// one span per attempt, both children of the caller's span
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("Expected 3 spans, got %d", len(spans))
+	}
+	attempts := spans[:2]
+	for i, span := range attempts {
+		if span.SpanKind() != trace.SpanKindClient || span.Name() != http.MethodGet {
+			t.Errorf("Unexpected span %s of kind %s", span.Name(), span.SpanKind())
+		}
+		if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("Expected span %d to be a child of the caller's span", i)
+		}
+		// the server receives the context of the attempt's span
+		sc := trace.SpanContextFromContext(propagation.TraceContext{}.Extract(
+			context.Background(), propagation.HeaderCarrier{"Traceparent": []string{traceparents[i]}},
+		))
+		if sc.SpanID() != span.SpanContext().SpanID() {
+			t.Errorf("Expected the trace context of span %d to be propagated, got %q", i, traceparents[i])
+		}
+	}
+
+	if attempts[0].Status().Code != codes.Error || !hasAttribute(attempts[0].Attributes(), semconv.HTTPResponseStatusCode(503)) {
+		t.Errorf("Expected the first attempt to fail with 503, got %+v", attempts[0].Attributes())
+	}
+	if !hasAttribute(attempts[1].Attributes(), semconv.HTTPRequestResendCount(1)) {
+		t.Errorf("Expected the second attempt to have a resend count, got %+v", attempts[1].Attributes())
+	}
+	if attempts[1].Status().Code == codes.Error {
+		t.Error("Expected the second attempt to succeed")
+	}
+}
+
+func hasAttribute(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, a := range attrs {
+		if a == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestHooks(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Request-Source") != "hook" {
+			t.Errorf("Expected the header set by the request hook")
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	var calls []string
+	client := NewClient(
+		server.URL,
+		WithRequestHook(func(req *http.Request) {
+			calls = append(calls, "request 1")
+			req.Header.Set("X-Request-Source", "hook")
+		}),
+		WithRequestHook(func(req *http.Request) {
+			calls = append(calls, "request 2")
+		}),
+		WithResponseHook(func(req *http.Request, resp *http.Response, err error, elapsed time.Duration) {
+			if err != nil || resp.StatusCode != http.StatusOK || elapsed <= 0 {
+				t.Errorf("Unexpected response %v, error %v after %s", resp, err, elapsed)
+			}
+			calls = append(calls, "response "+req.Method)
+		}),
+	)
+	if _, err := client.ListServers(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []string{"request 1", "request 2", "response GET"}
+	if len(calls) != len(expected) {
+		t.Fatalf("Expected calls %v, got %v", expected, calls)
+	}
+	for i := range expected {
+		if calls[i] != expected[i] {
+			t.Errorf("Expected call %d to be %s, got %s", i, expected[i], calls[i])
+		}
+	}
+
+	t.Run("response hook gets errors", func(t *testing.T) {
+		var hookErr error
+		client := NewClient("http://127.0.0.1:1", WithResponseHook(
+			func(req *http.Request, resp *http.Response, err error, elapsed time.Duration) {
+				hookErr = err
+			},
+		))
+		if _, err := client.ListServers(); err == nil {
+			t.Fatal("Expected an error")
+		}
+		if hookErr == nil {
+			t.Error("Expected the response hook to get the error")
+		}
+	})
+}
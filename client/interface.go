@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// Interface is implemented by Client and covers all the operations of the MCPJungle API.
+// Programs using the client can depend on it instead of *Client, so that their unit tests can use
+// a fake like the one in the clienttest package instead of a running MCPJungle server.
+type Interface interface {
+	// server
+	InitServer() (*InitServerResponse, error)
+	InitServerContext(ctx context.Context) (*InitServerResponse, error)
+	BaseURL() string
+	GetServerMetadata(ctx context.Context) (*types.ServerMetadata, error)
+	GetHealthDetails(ctx context.Context) (*types.HealthDetails, error)
+	Health(ctx context.Context) (*types.ReadinessResponse, error)
+	ServerInfo(ctx context.Context) (*types.ServerInfo, error)
+	GetStats() (*types.RegistryStats, error)
+	GetStatsContext(ctx context.Context) (*types.RegistryStats, error)
+	SubscribeEvents(ctx context.Context) (<-chan types.RegistryEvent, error)
+
+	// MCP servers
+	RegisterServer(server *types.RegisterServerInput) (*types.McpServer, error)
+	RegisterServerContext(ctx context.Context, server *types.RegisterServerInput) (*types.McpServer, error)
+	RegisterServerFromFile(path string) (*types.McpServer, error)
+	RegisterServerFromFileContext(ctx context.Context, path string) (*types.McpServer, error)
+	RegisterServerFromReader(r io.Reader) (*types.McpServer, error)
+	RegisterServerFromReaderContext(ctx context.Context, r io.Reader) (*types.McpServer, error)
+	TestServer(input *types.TestServerInput) (*types.ServerTestReport, error)
+	TestServerContext(ctx context.Context, input *types.TestServerInput) (*types.ServerTestReport, error)
+	ListServers() ([]*types.McpServer, error)
+	ListServersContext(ctx context.Context) ([]*types.McpServer, error)
+	UpdateServer(name string, server *types.RegisterServerInput) (*types.McpServer, error)
+	UpdateServerContext(ctx context.Context, name string, server *types.RegisterServerInput) (*types.McpServer, error)
+	DeregisterServer(name string) error
+	DeregisterServerContext(ctx context.Context, name string) error
+	EnableServer(name string) (*types.EnableDisableServerResult, error)
+	EnableServerContext(ctx context.Context, name string) (*types.EnableDisableServerResult, error)
+	DisableServer(name string) (*types.EnableDisableServerResult, error)
+	DisableServerContext(ctx context.Context, name string) (*types.EnableDisableServerResult, error)
+	GetServerConsumers(name string) (*types.ServerConsumers, error)
+	GetServerConsumersContext(ctx context.Context, name string) (*types.ServerConsumers, error)
+
+	// tools
+	ListTools(server string) ([]*types.Tool, error)
+	ListToolsContext(ctx context.Context, server string) ([]*types.Tool, error)
+	ListToolsWithOptions(opts *types.ListToolsOptions) ([]*types.Tool, error)
+	ListToolsWithOptionsContext(ctx context.Context, opts *types.ListToolsOptions) ([]*types.Tool, error)
+	GetTool(name string) (*types.Tool, error)
+	GetToolContext(ctx context.Context, name string) (*types.Tool, error)
+	EnableTools(name string) ([]string, error)
+	EnableToolsContext(ctx context.Context, name string) ([]string, error)
+	DisableTools(name string) ([]string, error)
+	DisableToolsContext(ctx context.Context, name string) ([]string, error)
+	EnableToolsBulk(entities []string) ([]types.BulkEntityResult, error)
+	EnableToolsBulkContext(ctx context.Context, entities []string) ([]types.BulkEntityResult, error)
+	DisableToolsBulk(entities []string) ([]types.BulkEntityResult, error)
+	DisableToolsBulkContext(ctx context.Context, entities []string) ([]types.BulkEntityResult, error)
+	InvokeTool(name string, input map[string]any) (*types.ToolInvokeResult, error)
+	InvokeToolContext(ctx context.Context, name string, input map[string]any) (*types.ToolInvokeResult, error)
+	InvokeToolStream(ctx context.Context, name string, input map[string]any) (<-chan types.ToolInvokeStreamEvent, error)
+	InvokeToolWithProgress(ctx context.Context, name string, input map[string]any, onProgress func(types.ToolProgress)) (*types.ToolInvokeResult, error)
+	InvokeToolAsync(name string, input map[string]any) (*types.ToolInvocationJob, error)
+	InvokeToolAsyncContext(ctx context.Context, name string, input map[string]any) (*types.ToolInvocationJob, error)
+	GetJob(id string) (*types.ToolInvocationJob, error)
+	GetJobContext(ctx context.Context, id string) (*types.ToolInvocationJob, error)
+	WaitForJob(ctx context.Context, jobID string, pollInterval time.Duration) (*types.ToolInvokeResult, error)
+	ListInvocations(opts *types.ListInvocationsOptions) ([]*types.ToolInvocation, error)
+	ListInvocationsContext(ctx context.Context, opts *types.ListInvocationsOptions) ([]*types.ToolInvocation, error)
+	Search(query string, resultType types.SearchResultType, limit int) ([]types.SearchResult, error)
+	SearchContext(ctx context.Context, query string, resultType types.SearchResultType, limit int) ([]types.SearchResult, error)
+
+	// prompts
+	ListPrompts(serverName string) ([]model.Prompt, error)
+	ListPromptsContext(ctx context.Context, serverName string) ([]model.Prompt, error)
+	GetPrompt(name string) (*model.Prompt, error)
+	GetPromptContext(ctx context.Context, name string) (*model.Prompt, error)
+	GetPromptWithArgs(name string, arguments map[string]string) (*types.PromptResult, error)
+	GetPromptWithArgsContext(ctx context.Context, name string, arguments map[string]string) (*types.PromptResult, error)
+	EnablePrompts(entity string) ([]string, error)
+	EnablePromptsContext(ctx context.Context, entity string) ([]string, error)
+	DisablePrompts(entity string) ([]string, error)
+	DisablePromptsContext(ctx context.Context, entity string) ([]string, error)
+
+	// tool groups
+	CreateToolGroup(group *types.ToolGroup) (*types.CreateToolGroupResponse, error)
+	CreateToolGroupContext(ctx context.Context, group *types.ToolGroup) (*types.CreateToolGroupResponse, error)
+	ListToolGroups() ([]types.ToolGroup, error)
+	ListToolGroupsContext(ctx context.Context) ([]types.ToolGroup, error)
+	GetToolGroup(name string) (*types.GetToolGroupResponse, error)
+	GetToolGroupContext(ctx context.Context, name string) (*types.GetToolGroupResponse, error)
+	UpdateToolGroup(group *types.ToolGroup) (*types.UpdateToolGroupResponse, error)
+	UpdateToolGroupContext(ctx context.Context, group *types.ToolGroup) (*types.UpdateToolGroupResponse, error)
+	PatchToolGroup(name string, patch *types.PatchToolGroupInput) (*types.UpdateToolGroupResponse, error)
+	PatchToolGroupContext(ctx context.Context, name string, patch *types.PatchToolGroupInput) (*types.UpdateToolGroupResponse, error)
+	ValidateToolGroup(group *types.ToolGroup) (*types.ValidateToolGroupResponse, error)
+	ValidateToolGroupContext(ctx context.Context, group *types.ToolGroup) (*types.ValidateToolGroupResponse, error)
+	DeleteToolGroup(name string) error
+	DeleteToolGroupContext(ctx context.Context, name string) error
+
+	// MCP clients
+	CreateMcpClient(mcpClient *types.McpClient) (string, error)
+	CreateMcpClientContext(ctx context.Context, mcpClient *types.McpClient) (string, error)
+	ListMcpClients() ([]types.McpClient, error)
+	ListMcpClientsContext(ctx context.Context) ([]types.McpClient, error)
+	DeleteMcpClient(name string) error
+	DeleteMcpClientContext(ctx context.Context, name string) error
+	UpdateMcpClient(name string, patch *types.PatchMcpClientInput) (*types.McpClient, error)
+	UpdateMcpClientContext(ctx context.Context, name string, patch *types.PatchMcpClientInput) (*types.McpClient, error)
+	RenewMcpClientToken(token string) (*types.RenewMcpClientTokenResponse, error)
+	RenewMcpClientTokenContext(ctx context.Context, token string) (*types.RenewMcpClientTokenResponse, error)
+
+	// users
+	CreateUser(user *types.CreateUserRequest) (*types.CreateUserResponse, error)
+	CreateUserContext(ctx context.Context, user *types.CreateUserRequest) (*types.CreateUserResponse, error)
+	ListUsers() ([]*types.User, error)
+	ListUsersContext(ctx context.Context) ([]*types.User, error)
+	DeleteUser(username string) error
+	DeleteUserContext(ctx context.Context, username string) error
+	SuspendUser(username string) (*types.User, error)
+	SuspendUserContext(ctx context.Context, username string) (*types.User, error)
+	ActivateUser(username string) (*types.User, error)
+	ActivateUserContext(ctx context.Context, username string) (*types.User, error)
+	SetUserToolPolicy(username string, policy *types.ToolPolicy) (*types.User, error)
+	SetUserToolPolicyContext(ctx context.Context, username string, policy *types.ToolPolicy) (*types.User, error)
+	DeleteUserToolPolicy(username string) error
+	DeleteUserToolPolicyContext(ctx context.Context, username string) error
+	Whoami(accessToken string) (*types.User, error)
+	WhoamiContext(ctx context.Context, accessToken string) (*types.User, error)
+	RenewAccessToken() (*types.RenewAccessTokenResponse, error)
+	RenewAccessTokenContext(ctx context.Context) (*types.RenewAccessTokenResponse, error)
+
+	// teams
+	CreateTeam(team *types.Team) (*types.Team, error)
+	CreateTeamContext(ctx context.Context, team *types.Team) (*types.Team, error)
+	ListTeams() ([]*types.Team, error)
+	ListTeamsContext(ctx context.Context) ([]*types.Team, error)
+	GetTeam(name string) (*types.Team, error)
+	GetTeamContext(ctx context.Context, name string) (*types.Team, error)
+	UpdateTeam(team *types.Team) (*types.Team, error)
+	UpdateTeamContext(ctx context.Context, team *types.Team) (*types.Team, error)
+	DeleteTeam(name string) error
+	DeleteTeamContext(ctx context.Context, name string) error
+
+	// logs
+	GetLogs(opts *types.LogsOptions) ([]types.LogEntry, error)
+	GetLogsContext(ctx context.Context, opts *types.LogsOptions) ([]types.LogEntry, error)
+	FollowLogs(ctx context.Context, opts *types.LogsOptions) (<-chan types.LogEntry, error)
+}
+
+var _ Interface = (*Client)(nil)
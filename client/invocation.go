@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// ListInvocations fetches the recorded tool invocations that match the given filters, most recent first.
+func (c *Client) ListInvocations(opts *types.ListInvocationsOptions) ([]*types.ToolInvocation, error) {
+	return c.ListInvocationsContext(context.Background(), opts)
+}
+
+// ListInvocationsContext is like ListInvocations, but the request is bound to the given context.
+func (c *Client) ListInvocationsContext(ctx context.Context, opts *types.ListInvocationsOptions) ([]*types.ToolInvocation, error) {
+	u, _ := c.constructAPIEndpoint("/invocations")
+	q := url.Values{}
+	if opts.Tool != "" {
+		q.Add("tool", opts.Tool)
+	}
+	if opts.Caller != "" {
+		q.Add("caller", opts.Caller)
+	}
+	if opts.Client != "" {
+		q.Add("client", opts.Client)
+	}
+	if opts.Outcome != "" {
+		q.Add("outcome", string(opts.Outcome))
+	}
+	if !opts.Since.IsZero() {
+		q.Add("since", opts.Since.Format(time.RFC3339))
+	}
+	if !opts.Until.IsZero() {
+		q.Add("until", opts.Until.Format(time.RFC3339))
+	}
+	return listAll[*types.ToolInvocation](ctx, c, u, q)
+}
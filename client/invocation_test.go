@@ -0,0 +1,45 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestListInvocations(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/api/v1/invocations") {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("tool") != "git__push" || q.Get("caller") != "cursor" || q.Get("outcome") != "error" {
+			t.Errorf("Unexpected filters: %s", r.URL.RawQuery)
+		}
+		if q.Get("since") != "2025-10-01T12:00:00Z" || q.Has("until") {
+			t.Errorf("Unexpected time range: %s", r.URL.RawQuery)
+		}
+		_ = json.NewEncoder(w).Encode([]types.ToolInvocation{
+			{ID: 7, Tool: "git__push", Caller: "cursor", Outcome: types.InvocationOutcomeError, Error: "rejected"},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	invocations, err := client.ListInvocations(&types.ListInvocationsOptions{
+		Tool: "git__push", Caller: "cursor", Outcome: types.InvocationOutcomeError, Since: since,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(invocations) != 1 || invocations[0].ID != 7 || invocations[0].Error != "rejected" {
+		t.Errorf("Unexpected invocations: %+v", invocations)
+	}
+}
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// InvokeToolAsync starts invoking a tool in the background and returns the job tracking the invocation.
+// Use GetJob to retrieve the status & result of the job.
+func (c *Client) InvokeToolAsync(name string, input map[string]any) (*types.ToolInvocationJob, error) {
+	return c.InvokeToolAsyncContext(context.Background(), name, input)
+}
+
+// InvokeToolAsyncContext is like InvokeToolAsync, but the request is bound to the given context.
+func (c *Client) InvokeToolAsyncContext(ctx context.Context, name string, input map[string]any) (*types.ToolInvocationJob, error) {
+	payload := make(map[string]any, len(input)+1)
+	for k, v := range input {
+		payload[k] = v
+	}
+	payload["name"] = name
+
+	body, _ := json.Marshal(payload)
+	u, _ := c.constructAPIEndpoint("/tools/invoke")
+	req, err := c.newRequest(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	q := req.URL.Query()
+	q.Add("async", "true")
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request to server failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var job types.ToolInvocationJob
+	if err := json.NewDecoder(resp.Body).Decode(&job); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &job, nil
+}
+
+// GetJob fetches the status of an asynchronous tool invocation, along with its result if it succeeded.
+func (c *Client) GetJob(id string) (*types.ToolInvocationJob, error) {
+	return c.GetJobContext(context.Background(), id)
+}
+
+// GetJobContext is like GetJob, but the request is bound to the given context.
+func (c *Client) GetJobContext(ctx context.Context, id string) (*types.ToolInvocationJob, error) {
+	u, _ := c.constructAPIEndpoint("/jobs/" + url.PathEscape(id))
+	req, err := c.newRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var job types.ToolInvocationJob
+	if err := json.NewDecoder(resp.Body).Decode(&job); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &job, nil
+}
+
+// ErrJobFailed is returned by WaitForJob when the job completes without a result.
+var ErrJobFailed = errors.New("job failed")
+
+// defaultJobPollInterval is used by WaitForJob when no poll interval is given.
+const defaultJobPollInterval = time.Second
+
+// WaitForJob polls an asynchronous tool invocation job every pollInterval until it completes,
+// and returns the result of the tool call.
+// A job that fails is reported as an error wrapping ErrJobFailed.
+// Waiting stops with the context's error once the context is done; the job itself keeps running on the server.
+// If pollInterval is not positive, the job is polled every second.
+func (c *Client) WaitForJob(ctx context.Context, jobID string, pollInterval time.Duration) (*types.ToolInvokeResult, error) {
+	if pollInterval <= 0 {
+		pollInterval = defaultJobPollInterval
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		job, err := c.GetJobContext(ctx, jobID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, err
+		}
+		switch job.Status {
+		case types.JobStatusSucceeded:
+			return job.Result, nil
+		case types.JobStatusFailed:
+			return nil, fmt.Errorf("%w: %s", ErrJobFailed, job.Error)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
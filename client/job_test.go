@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestInvokeToolAsync(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/tools/invoke") {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if r.URL.Query().Get("async") != "true" {
+			t.Errorf("Expected async=true query param, got %s", r.URL.RawQuery)
+		}
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["name"] != "reports__annual" || body["year"] != "2025" {
+			t.Errorf("Unexpected request body: %v", body)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(types.ToolInvocationJob{
+			ID: "job-1", Tool: "reports__annual", Status: types.JobStatusPending,
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	input := map[string]any{"year": "2025"}
+	job, err := client.InvokeToolAsync("reports__annual", input)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if job.ID != "job-1" || job.Status != types.JobStatusPending {
+		t.Errorf("Unexpected job: %+v", job)
+	}
+	if _, ok := input["name"]; ok {
+		t.Error("Expected caller's input not to be mutated")
+	}
+}
+
+func TestGetJob(t *testing.T) {
+	t.Parallel()
+
+	t.Run("successful get", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasSuffix(r.URL.Path, "/jobs/job-1") {
+				t.Errorf("Expected path to end with /jobs/job-1, got %s", r.URL.Path)
+			}
+			_ = json.NewEncoder(w).Encode(types.ToolInvocationJob{
+				ID:     "job-1",
+				Status: types.JobStatusSucceeded,
+				Result: &types.ToolInvokeResult{Content: []map[string]any{{"type": "text", "text": "done"}}},
+			})
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL, WithToken("test-token"))
+		job, err := client.GetJob("job-1")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if job.Status != types.JobStatusSucceeded || job.Result.Content[0]["text"] != "done" {
+			t.Errorf("Unexpected job: %+v", job)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"job not found"}`))
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL, WithToken("test-token"))
+		_, err := client.GetJob("missing")
+		if err == nil || !strings.Contains(err.Error(), "job not found") {
+			t.Errorf("Expected job not found error, got %v", err)
+		}
+	})
+}
+
+func TestWaitForJob(t *testing.T) {
+	t.Parallel()
+
+	// jobServer reports the job as running for the first polls, then with the given final state
+	jobServer := func(t *testing.T, runningPolls int32, final types.ToolInvocationJob) (*httptest.Server, *atomic.Int32) {
+		var polls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasSuffix(r.URL.Path, "/jobs/job-1") {
+				t.Errorf("Expected path to end with /jobs/job-1, got %s", r.URL.Path)
+			}
+			if polls.Add(1) <= runningPolls {
+				_ = json.NewEncoder(w).Encode(types.ToolInvocationJob{ID: "job-1", Status: types.JobStatusRunning})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(final)
+		}))
+		t.Cleanup(server.Close)
+		return server, &polls
+	}
+
+	t.Run("succeeded", func(t *testing.T) {
+		server, polls := jobServer(t, 2, types.ToolInvocationJob{
+			ID:     "job-1",
+			Status: types.JobStatusSucceeded,
+			Result: &types.ToolInvokeResult{Content: []map[string]any{{"type": "text", "text": "done"}}},
+		})
+
+		client := NewClient(server.URL)
+		result, err := client.WaitForJob(context.Background(), "job-1", time.Millisecond)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.Content[0]["text"] != "done" {
+			t.Errorf("Unexpected result: %+v", result)
+		}
+		if polls.Load() != 3 {
+			t.Errorf("Expected 3 polls, got %d", polls.Load())
+		}
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		server, _ := jobServer(t, 0, types.ToolInvocationJob{ID: "job-1", Status: types.JobStatusFailed, Error: "server is down"})
+
+		client := NewClient(server.URL)
+		_, err := client.WaitForJob(context.Background(), "job-1", time.Millisecond)
+		if !errors.Is(err, ErrJobFailed) || !strings.Contains(err.Error(), "server is down") {
+			t.Errorf("Expected ErrJobFailed with the job's error, got %v", err)
+		}
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		server, _ := jobServer(t, 1000, types.ToolInvocationJob{})
+
+		client := NewClient(server.URL)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := client.WaitForJob(ctx, "job-1", 10*time.Millisecond)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+		}
+	})
+
+	t.Run("job not found", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"job not found"}`))
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL)
+		_, err := client.WaitForJob(context.Background(), "missing", time.Millisecond)
+		if err == nil || !strings.Contains(err.Error(), "job not found") {
+			t.Errorf("Expected job not found error, got %v", err)
+		}
+	})
+}
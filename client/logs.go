@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// GetLogs fetches the recent entries of the server's logs that match the given filters, oldest first.
+func (c *Client) GetLogs(opts *types.LogsOptions) ([]types.LogEntry, error) {
+	return c.GetLogsContext(context.Background(), opts)
+}
+
+// GetLogsContext is like GetLogs, but the request is bound to the given context.
+func (c *Client) GetLogsContext(ctx context.Context, opts *types.LogsOptions) ([]types.LogEntry, error) {
+	u, _ := c.constructAPIEndpoint("/logs")
+	u += "?" + logsQuery(opts).Encode()
+	req, err := c.newRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var entries []types.LogEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return entries, nil
+}
+
+// FollowLogs opens a stream of the server's log entries that match the given filters.
+// The recent entries are delivered first on the returned channel, followed by new entries as they are logged,
+// until the context is cancelled or the server closes the stream, after which the channel is closed.
+// The errors that end the stream are passed to the handler set with WithStreamErrorHandler.
+func (c *Client) FollowLogs(ctx context.Context, opts *types.LogsOptions) (<-chan types.LogEntry, error) {
+	u, _ := c.constructAPIEndpoint("/logs")
+	q := logsQuery(opts)
+	q.Set("follow", "true")
+	body, err := c.openEventStream(ctx, u+"?"+q.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(chan types.LogEntry)
+	go readEventStream(ctx, body, entries, "log entry", c.reportStreamError)
+	return entries, nil
+}
+
+func logsQuery(opts *types.LogsOptions) url.Values {
+	q := url.Values{}
+	if opts.Server != "" {
+		q.Add("server", opts.Server)
+	}
+	if !opts.Since.IsZero() {
+		q.Add("since", opts.Since.Format(time.RFC3339))
+	}
+	return q
+}
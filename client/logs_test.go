@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestGetLogs(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/api/v1/logs") {
+			t.Errorf("Expected path to end with /api/v1/logs, got %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("server") != "time" {
+			t.Errorf("Expected server=time, got %s", q.Get("server"))
+		}
+		if q.Get("since") != "2025-06-01T12:00:00Z" {
+			t.Errorf("Expected since=2025-06-01T12:00:00Z, got %s", q.Get("since"))
+		}
+		if q.Has("follow") {
+			t.Errorf("Expected no follow query param, got %s", q.Get("follow"))
+		}
+		_, _ = w.Write([]byte(`[{"id":7,"time":"2025-06-01T12:00:01Z","level":"info",` +
+			`"message":"stdio MCP server stderr","fields":{"server":"time","stderr":"ready"}}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	entries, err := client.GetLogs(&types.LogsOptions{Server: "time", Since: since})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+	if entries[0].ID != 7 || entries[0].Message != "stdio MCP server stderr" || entries[0].Fields["stderr"] != "ready" {
+		t.Errorf("Unexpected entry %+v", entries[0])
+	}
+}
+
+func TestFollowLogs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("receives entries until the stream ends", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("follow") != "true" {
+				t.Errorf("Expected follow=true, got %s", r.URL.Query().Get("follow"))
+			}
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "id:1\nevent:log\ndata:{\"id\":1,\"level\":\"info\",\"message\":\"one\"}\n\n")
+			fmt.Fprint(w, ": keep-alive\n\n")
+			fmt.Fprint(w, "id:2\nevent:log\ndata:{\"id\":2,\"level\":\"warn\",\"message\":\"two\"}\n\n")
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL)
+		entries, err := client.FollowLogs(context.Background(), &types.LogsOptions{})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		var received []string
+		for e := range entries {
+			received = append(received, fmt.Sprintf("%d %s %s", e.ID, e.Level, e.Message))
+		}
+		expected := []string{"1 info one", "2 warn two"}
+		if strings.Join(received, "\n") != strings.Join(expected, "\n") {
+			t.Errorf("Expected entries %v, got %v", expected, received)
+		}
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error": "server logs are not available"}`))
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL)
+		_, err := client.FollowLogs(context.Background(), &types.LogsOptions{})
+		if err == nil || !strings.Contains(err.Error(), "not available") {
+			t.Errorf("Expected error from server, got %v", err)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -10,40 +11,29 @@ import (
 )
 
 func (c *Client) ListMcpClients() ([]types.McpClient, error) {
-	u, _ := c.constructAPIEndpoint("/clients")
-
-	req, err := c.newRequest(http.MethodGet, u, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, c.parseErrorResponse(resp)
-	}
-
-	var clients []types.McpClient
-	if err := json.NewDecoder(resp.Body).Decode(&clients); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
+	return c.ListMcpClientsContext(context.Background())
+}
 
-	return clients, nil
+// ListMcpClientsContext is like ListMcpClients, but the request is bound to the given context.
+func (c *Client) ListMcpClientsContext(ctx context.Context) ([]types.McpClient, error) {
+	u, _ := c.constructAPIEndpoint("/clients")
+	return listAll[types.McpClient](ctx, c, u, nil)
 }
 
 func (c *Client) DeleteMcpClient(name string) error {
+	return c.DeleteMcpClientContext(context.Background(), name)
+}
+
+// DeleteMcpClientContext is like DeleteMcpClient, but the request is bound to the given context.
+func (c *Client) DeleteMcpClientContext(ctx context.Context, name string) error {
 	u, _ := c.constructAPIEndpoint("/clients/" + name)
 
-	req, err := c.newRequest(http.MethodDelete, u, nil)
+	req, err := c.newRequest(ctx, http.MethodDelete, u, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to send request to %s: %w", u, err)
 	}
@@ -57,6 +47,11 @@ func (c *Client) DeleteMcpClient(name string) error {
 }
 
 func (c *Client) CreateMcpClient(mcpClient *types.McpClient) (string, error) {
+	return c.CreateMcpClientContext(context.Background(), mcpClient)
+}
+
+// CreateMcpClientContext is like CreateMcpClient, but the request is bound to the given context.
+func (c *Client) CreateMcpClientContext(ctx context.Context, mcpClient *types.McpClient) (string, error) {
 	u, _ := c.constructAPIEndpoint("/clients")
 
 	body, err := json.Marshal(mcpClient)
@@ -64,13 +59,14 @@ func (c *Client) CreateMcpClient(mcpClient *types.McpClient) (string, error) {
 		return "", fmt.Errorf("failed to marshal client data: %w", err)
 	}
 
-	req, err := c.newRequest(http.MethodPost, u, bytes.NewReader(body))
+	req, err := c.newRequest(ctx, http.MethodPost, u, bytes.NewReader(body))
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	c.setIdempotencyKey(req)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return "", fmt.Errorf("failed to send request to %s: %w", u, err)
 	}
@@ -89,3 +85,80 @@ func (c *Client) CreateMcpClient(mcpClient *types.McpClient) (string, error) {
 
 	return response.AccessToken, nil
 }
+
+// UpdateMcpClient updates only the fields of an MCP client that are set in the patch, leaving the others unchanged.
+// The client keeps its access token, so the agents using it don't need to be reconfigured.
+func (c *Client) UpdateMcpClient(name string, patch *types.PatchMcpClientInput) (*types.McpClient, error) {
+	return c.UpdateMcpClientContext(context.Background(), name, patch)
+}
+
+// UpdateMcpClientContext is like UpdateMcpClient, but the request is bound to the given context.
+func (c *Client) UpdateMcpClientContext(
+	ctx context.Context, name string, patch *types.PatchMcpClientInput,
+) (*types.McpClient, error) {
+	u, _ := c.constructAPIEndpoint("/clients/" + name)
+
+	body, err := json.Marshal(patch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal client data: %w", err)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPatch, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var mcpClient types.McpClient
+	if err := json.NewDecoder(resp.Body).Decode(&mcpClient); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &mcpClient, nil
+}
+
+// RenewMcpClientToken sends a request to replace the access token of an MCP client by a new one, authenticated
+// with the client's current token instead of the one this Client is configured with.
+// The current token must not have expired yet, and it no longer works once the request succeeds.
+// Long-running agents call this before their token expires to rotate it without losing access.
+func (c *Client) RenewMcpClientToken(token string) (*types.RenewMcpClientTokenResponse, error) {
+	return c.RenewMcpClientTokenContext(context.Background(), token)
+}
+
+// RenewMcpClientTokenContext is like RenewMcpClientToken, but the request is bound to the given context.
+func (c *Client) RenewMcpClientTokenContext(
+	ctx context.Context, token string,
+) (*types.RenewMcpClientTokenResponse, error) {
+	u, _ := c.constructAPIEndpoint("/clients/token/renew")
+
+	req, err := c.newRequest(ctx, http.MethodPost, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request to %s: %w", u, err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var renewResp types.RenewMcpClientTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&renewResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &renewResp, nil
+}
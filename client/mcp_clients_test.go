@@ -49,7 +49,7 @@ func TestListMcpClients(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		clients, err := client.ListMcpClients()
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
@@ -77,7 +77,7 @@ func TestListMcpClients(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		clients, err := client.ListMcpClients()
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
@@ -95,7 +95,7 @@ func TestListMcpClients(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		clients, err := client.ListMcpClients()
 
 		if err == nil {
@@ -112,7 +112,7 @@ func TestListMcpClients(t *testing.T) {
 	})
 
 	t.Run("network error", func(t *testing.T) {
-		client := NewClient("http://invalid-url", "test-token", &http.Client{})
+		client := NewClient("http://invalid-url", WithToken("test-token"))
 		clients, err := client.ListMcpClients()
 
 		if err == nil {
@@ -139,7 +139,7 @@ func TestDeleteMcpClient(t *testing.T) {
 			if r.Method != "DELETE" {
 				t.Errorf("Expected DELETE method, got %s", r.Method)
 			}
-			expectedPath := "/api/v0/clients/" + clientName
+			expectedPath := "/api/v1/clients/" + clientName
 			if !strings.HasSuffix(r.URL.Path, expectedPath) {
 				t.Errorf("Expected path to end with %s, got %s", expectedPath, r.URL.Path)
 			}
@@ -155,7 +155,7 @@ func TestDeleteMcpClient(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		err := client.DeleteMcpClient(clientName)
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
@@ -169,7 +169,7 @@ func TestDeleteMcpClient(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		err := client.DeleteMcpClient("non-existent-client")
 
 		if err == nil {
@@ -183,7 +183,7 @@ func TestDeleteMcpClient(t *testing.T) {
 	})
 
 	t.Run("network error", func(t *testing.T) {
-		client := NewClient("http://invalid-url", "test-token", &http.Client{})
+		client := NewClient("http://invalid-url", WithToken("test-token"))
 		err := client.DeleteMcpClient("test-client")
 
 		if err == nil {
@@ -248,7 +248,7 @@ func TestCreateMcpClient(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		mcpClient := &types.McpClient{
 			Name:        "test-client",
 			Description: "Test client description",
@@ -272,7 +272,7 @@ func TestCreateMcpClient(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		mcpClient := &types.McpClient{
 			Name:        "test-client",
 			Description: "Test client description",
@@ -295,7 +295,7 @@ func TestCreateMcpClient(t *testing.T) {
 	})
 
 	t.Run("network error", func(t *testing.T) {
-		client := NewClient("http://invalid-url", "test-token", &http.Client{})
+		client := NewClient("http://invalid-url", WithToken("test-token"))
 		mcpClient := &types.McpClient{
 			Name:        "test-client",
 			Description: "Test client description",
@@ -324,7 +324,7 @@ func TestCreateMcpClient(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		mcpClient := &types.McpClient{
 			Name:        "test-client",
 			Description: "Test client description",
@@ -372,7 +372,7 @@ func TestCreateMcpClientWithEmptyAllowList(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token", &http.Client{})
+	client := NewClient(server.URL, WithToken("test-token"))
 	mcpClient := &types.McpClient{
 		Name:        "test-client",
 		Description: "Test client with empty allow list",
@@ -384,3 +384,70 @@ func TestCreateMcpClientWithEmptyAllowList(t *testing.T) {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 }
+
+func TestUpdateMcpClient(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || !strings.HasSuffix(r.URL.Path, "/clients/cursor") {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "mcp client does not exist"})
+			return
+		}
+		var patch map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		if _, ok := patch["description"]; ok {
+			t.Error("Expected the description to be left out of the request")
+		}
+		_ = json.NewEncoder(w).Encode(types.McpClient{Name: "cursor", AllowList: []string{"github", "time"}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	allowList := []string{"github", "time"}
+	updated, err := client.UpdateMcpClient("cursor", &types.PatchMcpClientInput{AllowList: &allowList})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(updated.AllowList) != 2 {
+		t.Errorf("Unexpected allow list: %v", updated.AllowList)
+	}
+
+	if _, err := client.UpdateMcpClient("claude", &types.PatchMcpClientInput{AllowList: &allowList}); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+func TestRenewMcpClientToken(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/clients/token/renew") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// the request is authenticated with the MCP client's token, not the user's
+		if auth := r.Header.Get("Authorization"); auth != "Bearer client-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "MCP client token has expired"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(types.RenewMcpClientTokenResponse{Name: "agent", AccessToken: "new-token"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("user-token"))
+	resp, err := client.RenewMcpClientToken("client-token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resp.Name != "agent" || resp.AccessToken != "new-token" {
+		t.Errorf("Unexpected response: %+v", resp)
+	}
+
+	if _, err := client.RenewMcpClientToken("expired-token"); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
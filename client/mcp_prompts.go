@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -14,45 +15,32 @@ import (
 
 // ListPrompts retrieves all prompts or prompts filtered by server name
 func (c *Client) ListPrompts(serverName string) ([]model.Prompt, error) {
+	return c.ListPromptsContext(context.Background(), serverName)
+}
+
+// ListPromptsContext is like ListPrompts, but the request is bound to the given context.
+func (c *Client) ListPromptsContext(ctx context.Context, serverName string) ([]model.Prompt, error) {
 	u, err := c.constructAPIEndpoint("/prompts")
 	if err != nil {
 		return nil, fmt.Errorf("failed to construct API endpoint: %w", err)
 	}
 
 	// Add server filter if specified
+	q := url.Values{}
 	if serverName != "" {
-		parsed, _ := url.Parse(u)
-		q := parsed.Query()
 		q.Set("server", serverName)
-		parsed.RawQuery = q.Encode()
-		u = parsed.String()
-	}
-
-	req, err := c.newRequest(http.MethodGet, u, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, c.parseErrorResponse(resp)
 	}
 
-	var prompts []model.Prompt
-	if err := json.NewDecoder(resp.Body).Decode(&prompts); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	return prompts, nil
+	return listAll[model.Prompt](ctx, c, u, q)
 }
 
 // GetPrompt retrieves a specific prompt by name
 func (c *Client) GetPrompt(name string) (*model.Prompt, error) {
+	return c.GetPromptContext(context.Background(), name)
+}
+
+// GetPromptContext is like GetPrompt, but the request is bound to the given context.
+func (c *Client) GetPromptContext(ctx context.Context, name string) (*model.Prompt, error) {
 	u, err := c.constructAPIEndpoint("/prompt")
 	if err != nil {
 		return nil, fmt.Errorf("failed to construct API endpoint: %w", err)
@@ -65,12 +53,12 @@ func (c *Client) GetPrompt(name string) (*model.Prompt, error) {
 	parsed.RawQuery = q.Encode()
 	u = parsed.String()
 
-	req, err := c.newRequest(http.MethodGet, u, nil)
+	req, err := c.newRequest(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
@@ -90,6 +78,11 @@ func (c *Client) GetPrompt(name string) (*model.Prompt, error) {
 
 // GetPromptWithArgs retrieves a prompt with arguments and returns the rendered template
 func (c *Client) GetPromptWithArgs(name string, arguments map[string]string) (*types.PromptResult, error) {
+	return c.GetPromptWithArgsContext(context.Background(), name, arguments)
+}
+
+// GetPromptWithArgsContext is like GetPromptWithArgs, but the request is bound to the given context.
+func (c *Client) GetPromptWithArgsContext(ctx context.Context, name string, arguments map[string]string) (*types.PromptResult, error) {
 	u, err := c.constructAPIEndpoint("/prompts/render")
 	if err != nil {
 		return nil, fmt.Errorf("failed to construct API endpoint: %w", err)
@@ -105,13 +98,13 @@ func (c *Client) GetPromptWithArgs(name string, arguments map[string]string) (*t
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := c.newRequest(http.MethodPost, u, bytes.NewBuffer(body))
+	req, err := c.newRequest(ctx, http.MethodPost, u, bytes.NewBuffer(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
@@ -131,16 +124,26 @@ func (c *Client) GetPromptWithArgs(name string, arguments map[string]string) (*t
 
 // EnablePrompts enables one or more prompts
 func (c *Client) EnablePrompts(entity string) ([]string, error) {
-	return c.setPromptsEnabled(entity, true)
+	return c.EnablePromptsContext(context.Background(), entity)
+}
+
+// EnablePromptsContext is like EnablePrompts, but the request is bound to the given context.
+func (c *Client) EnablePromptsContext(ctx context.Context, entity string) ([]string, error) {
+	return c.setPromptsEnabled(ctx, entity, true)
 }
 
 // DisablePrompts disables one or more prompts
 func (c *Client) DisablePrompts(entity string) ([]string, error) {
-	return c.setPromptsEnabled(entity, false)
+	return c.DisablePromptsContext(context.Background(), entity)
+}
+
+// DisablePromptsContext is like DisablePrompts, but the request is bound to the given context.
+func (c *Client) DisablePromptsContext(ctx context.Context, entity string) ([]string, error) {
+	return c.setPromptsEnabled(ctx, entity, false)
 }
 
 // setPromptsEnabled is a helper function to enable or disable prompts
-func (c *Client) setPromptsEnabled(entity string, enabled bool) ([]string, error) {
+func (c *Client) setPromptsEnabled(ctx context.Context, entity string, enabled bool) ([]string, error) {
 	var endpoint string
 	if enabled {
 		endpoint = "/prompts/enable"
@@ -160,12 +163,12 @@ func (c *Client) setPromptsEnabled(entity string, enabled bool) ([]string, error
 	parsed.RawQuery = q.Encode()
 	u = parsed.String()
 
-	req, err := c.newRequest(http.MethodPost, u, nil)
+	req, err := c.newRequest(ctx, http.MethodPost, u, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
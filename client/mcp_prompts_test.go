@@ -35,7 +35,7 @@ func TestListPrompts(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "token", &http.Client{})
+		client := NewClient(server.URL, WithToken("token"))
 		result, err := client.ListPrompts("")
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
@@ -55,7 +55,7 @@ func TestListPrompts(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "token", &http.Client{})
+		client := NewClient(server.URL, WithToken("token"))
 		_, err := client.ListPrompts("srv")
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
@@ -69,7 +69,7 @@ func TestListPrompts(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "token", &http.Client{})
+		client := NewClient(server.URL, WithToken("token"))
 		result, err := client.ListPrompts("")
 		if err == nil || result != nil {
 			t.Error("Expected error and nil result")
@@ -91,7 +91,7 @@ func TestGetPrompt(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "token", &http.Client{})
+		client := NewClient(server.URL, WithToken("token"))
 		result, err := client.GetPrompt("prompt1")
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
@@ -108,7 +108,7 @@ func TestGetPrompt(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "token", &http.Client{})
+		client := NewClient(server.URL, WithToken("token"))
 		result, err := client.GetPrompt("missing")
 		if err == nil || result != nil {
 			t.Error("Expected error and nil result")
@@ -148,7 +148,7 @@ func TestGetPromptWithArgs(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "token", &http.Client{})
+		client := NewClient(server.URL, WithToken("token"))
 		result, err := client.GetPromptWithArgs("greet", map[string]string{"name": "Alice"})
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
@@ -179,7 +179,7 @@ func TestGetPromptWithArgs(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "token", &http.Client{})
+		client := NewClient(server.URL, WithToken("token"))
 		result, err := client.GetPromptWithArgs("greet", map[string]string{"name": "Bob"})
 		if err == nil || result != nil {
 			t.Error("Expected error and nil result")
@@ -207,7 +207,7 @@ func TestEnableDisablePrompts(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "token", &http.Client{})
+		client := NewClient(server.URL, WithToken("token"))
 		result, err := client.EnablePrompts("test-entity")
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
@@ -231,7 +231,7 @@ func TestEnableDisablePrompts(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "token", &http.Client{})
+		client := NewClient(server.URL, WithToken("token"))
 		result, err := client.DisablePrompts("test-entity")
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
@@ -248,7 +248,7 @@ func TestEnableDisablePrompts(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "token", &http.Client{})
+		client := NewClient(server.URL, WithToken("token"))
 		result, err := client.EnablePrompts("fail-entity")
 		if err == nil || result != nil {
 			t.Error("Expected error and nil result")
@@ -262,7 +262,7 @@ func TestEnableDisablePrompts(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "token", &http.Client{})
+		client := NewClient(server.URL, WithToken("token"))
 		result, err := client.DisablePrompts("fail-entity")
 		if err == nil || result != nil {
 			t.Error("Expected error and nil result")
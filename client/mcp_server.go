@@ -2,28 +2,38 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"os"
 
 	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gopkg.in/yaml.v3"
 )
 
 // RegisterServer registers a new MCP server with the registry.
 func (c *Client) RegisterServer(server *types.RegisterServerInput) (*types.McpServer, error) {
+	return c.RegisterServerContext(context.Background(), server)
+}
+
+// RegisterServerContext is like RegisterServer, but the request is bound to the given context.
+func (c *Client) RegisterServerContext(ctx context.Context, server *types.RegisterServerInput) (*types.McpServer, error) {
 	u, _ := c.constructAPIEndpoint("/servers")
 	body, err := json.Marshal(server)
 	if err != nil {
 		return nil, fmt.Errorf("failed to serialize server data into JSON: %w", err)
 	}
 
-	req, err := c.newRequest(http.MethodPost, u, bytes.NewReader(body))
+	req, err := c.newRequest(ctx, http.MethodPost, u, bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	c.setIdempotencyKey(req)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
 	}
@@ -40,15 +50,120 @@ func (c *Client) RegisterServer(server *types.RegisterServerInput) (*types.McpSe
 	return &registeredServer, nil
 }
 
-// ListServers fetches the list of registered servers.
-func (c *Client) ListServers() ([]*types.McpServer, error) {
-	u, _ := c.constructAPIEndpoint("/servers")
-	req, err := c.newRequest(http.MethodGet, u, nil)
+// ParseServerConfig reads the configuration of an MCP server in the format accepted by 'mcpjungle register --conf',
+// either as JSON or as its YAML equivalent.
+func ParseServerConfig(r io.Reader) (*types.RegisterServerInput, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read server config: %w", err)
+	}
+
+	// JSON is valid YAML, so both formats are decoded as YAML, then converted to JSON to use the json tags
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse server config: %w", err)
+	}
+	j, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse server config: %w", err)
+	}
+	var input types.RegisterServerInput
+	if err := json.Unmarshal(j, &input); err != nil {
+		return nil, fmt.Errorf("failed to parse server config: %w", err)
+	}
+	return &input, nil
+}
+
+// RegisterServerFromFile registers the MCP server configured in the given JSON or YAML file.
+// See ParseServerConfig for the format of the file.
+func (c *Client) RegisterServerFromFile(path string) (*types.McpServer, error) {
+	return c.RegisterServerFromFileContext(context.Background(), path)
+}
+
+// RegisterServerFromFileContext is like RegisterServerFromFile, but the request is bound to the given context.
+func (c *Client) RegisterServerFromFileContext(ctx context.Context, path string) (*types.McpServer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open server config file: %w", err)
+	}
+	defer f.Close()
+	return c.RegisterServerFromReaderContext(ctx, f)
+}
+
+// RegisterServerFromReader registers the MCP server whose JSON or YAML configuration is read from r.
+// See ParseServerConfig for the format of the configuration.
+func (c *Client) RegisterServerFromReader(r io.Reader) (*types.McpServer, error) {
+	return c.RegisterServerFromReaderContext(context.Background(), r)
+}
+
+// RegisterServerFromReaderContext is like RegisterServerFromReader, but the request is bound to the given context.
+func (c *Client) RegisterServerFromReaderContext(ctx context.Context, r io.Reader) (*types.McpServer, error) {
+	input, err := ParseServerConfig(r)
+	if err != nil {
+		return nil, err
+	}
+	return c.RegisterServerContext(ctx, input)
+}
+
+// UpdateServer replaces the configuration of a registered MCP server, eg- to point it to a new URL
+// or to rotate its bearer token, without deregistering it.
+// The name of the server can't be changed, so the name in the input may be left empty.
+func (c *Client) UpdateServer(name string, server *types.RegisterServerInput) (*types.McpServer, error) {
+	return c.UpdateServerContext(context.Background(), name, server)
+}
+
+// UpdateServerContext is like UpdateServer, but the request is bound to the given context.
+func (c *Client) UpdateServerContext(ctx context.Context, name string, server *types.RegisterServerInput) (*types.McpServer, error) {
+	u, _ := c.constructAPIEndpoint("/servers/" + name)
+	body, err := json.Marshal(server)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize server data into JSON: %w", err)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPut, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var updatedServer types.McpServer
+	if err := json.NewDecoder(resp.Body).Decode(&updatedServer); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &updatedServer, nil
+}
+
+// TestServer tests the connectivity to a registered MCP server or to one that is about to be registered.
+// A failed test is not an error, it is described by the returned report.
+func (c *Client) TestServer(input *types.TestServerInput) (*types.ServerTestReport, error) {
+	return c.TestServerContext(context.Background(), input)
+}
+
+// TestServerContext is like TestServer, but the request is bound to the given context.
+func (c *Client) TestServerContext(ctx context.Context, input *types.TestServerInput) (*types.ServerTestReport, error) {
+	u, _ := c.constructAPIEndpoint("/servers/test")
+	body, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize test input into JSON: %w", err)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, u, bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
 	}
@@ -58,19 +173,35 @@ func (c *Client) ListServers() ([]*types.McpServer, error) {
 		return nil, c.parseErrorResponse(resp)
 	}
 
-	var servers []*types.McpServer
-	if err := json.NewDecoder(resp.Body).Decode(&servers); err != nil {
+	var report types.ServerTestReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
-	return servers, nil
+	return &report, nil
+}
+
+// ListServers fetches the list of registered servers.
+func (c *Client) ListServers() ([]*types.McpServer, error) {
+	return c.ListServersContext(context.Background())
+}
+
+// ListServersContext is like ListServers, but the request is bound to the given context.
+func (c *Client) ListServersContext(ctx context.Context) ([]*types.McpServer, error) {
+	u, _ := c.constructAPIEndpoint("/servers")
+	return listAll[*types.McpServer](ctx, c, u, nil)
 }
 
 // DeregisterServer deletes a server by name.
 func (c *Client) DeregisterServer(name string) error {
+	return c.DeregisterServerContext(context.Background(), name)
+}
+
+// DeregisterServerContext is like DeregisterServer, but the request is bound to the given context.
+func (c *Client) DeregisterServerContext(ctx context.Context, name string) error {
 	u, _ := c.constructAPIEndpoint("/servers/" + name)
-	req, _ := c.newRequest(http.MethodDelete, u, nil)
+	req, _ := c.newRequest(ctx, http.MethodDelete, u, nil)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to send request to %s: %w", u, err)
 	}
@@ -84,15 +215,25 @@ func (c *Client) DeregisterServer(name string) error {
 
 // EnableServer sends API request to enable a server by name.
 func (c *Client) EnableServer(name string) (*types.EnableDisableServerResult, error) {
-	return c.setServerEnabled(name, true)
+	return c.EnableServerContext(context.Background(), name)
+}
+
+// EnableServerContext is like EnableServer, but the request is bound to the given context.
+func (c *Client) EnableServerContext(ctx context.Context, name string) (*types.EnableDisableServerResult, error) {
+	return c.setServerEnabled(ctx, name, true)
 }
 
 // DisableServer sends API request to disable a server by name.
 func (c *Client) DisableServer(name string) (*types.EnableDisableServerResult, error) {
-	return c.setServerEnabled(name, false)
+	return c.DisableServerContext(context.Background(), name)
+}
+
+// DisableServerContext is like DisableServer, but the request is bound to the given context.
+func (c *Client) DisableServerContext(ctx context.Context, name string) (*types.EnableDisableServerResult, error) {
+	return c.setServerEnabled(ctx, name, false)
 }
 
-func (c *Client) setServerEnabled(name string, enabled bool) (*types.EnableDisableServerResult, error) {
+func (c *Client) setServerEnabled(ctx context.Context, name string, enabled bool) (*types.EnableDisableServerResult, error) {
 	api := "enable"
 	if !enabled {
 		api = "disable"
@@ -104,12 +245,12 @@ func (c *Client) setServerEnabled(name string, enabled bool) (*types.EnableDisab
 		return nil, fmt.Errorf("failed to construct API endpoint: %w", err)
 	}
 
-	req, err := c.newRequest(http.MethodPost, u, nil)
+	req, err := c.newRequest(ctx, http.MethodPost, u, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
@@ -126,3 +267,38 @@ func (c *Client) setServerEnabled(name string, enabled bool) (*types.EnableDisab
 
 	return &result, nil
 }
+
+// GetServerConsumers sends API request to get the MCP clients & tool groups that reference a server by name,
+// ie, the ones affected if the server is deregistered.
+func (c *Client) GetServerConsumers(name string) (*types.ServerConsumers, error) {
+	return c.GetServerConsumersContext(context.Background(), name)
+}
+
+// GetServerConsumersContext is like GetServerConsumers, but the request is bound to the given context.
+func (c *Client) GetServerConsumersContext(ctx context.Context, name string) (*types.ServerConsumers, error) {
+	u, err := c.constructAPIEndpoint(fmt.Sprintf("/servers/%s/consumers", name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct API endpoint: %w", err)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var consumers types.ServerConsumers
+	if err := json.NewDecoder(resp.Body).Decode(&consumers); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &consumers, nil
+}
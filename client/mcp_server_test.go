@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -48,7 +50,7 @@ func TestRegisterServer(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		serverInput := &types.RegisterServerInput{
 			Name:      "test-server",
 			Transport: "stdio",
@@ -78,7 +80,7 @@ func TestRegisterServer(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		serverInput := &types.RegisterServerInput{
 			Name:      "test-server",
 			Transport: "stdio",
@@ -101,7 +103,7 @@ func TestRegisterServer(t *testing.T) {
 	})
 
 	t.Run("network error", func(t *testing.T) {
-		client := NewClient("http://invalid-url", "test-token", &http.Client{})
+		client := NewClient("http://invalid-url", WithToken("test-token"))
 		serverInput := &types.RegisterServerInput{
 			Name:      "test-server",
 			Transport: "stdio",
@@ -123,6 +125,176 @@ func TestRegisterServer(t *testing.T) {
 	})
 }
 
+func TestParseServerConfig(t *testing.T) {
+	t.Parallel()
+
+	configs := map[string]string{
+		"json": `{
+			"name": "filesystem",
+			"transport": "stdio",
+			"command": "npx",
+			"args": ["-y", "@modelcontextprotocol/server-filesystem", "."],
+			"env": {"DEBUG": "1"}
+		}`,
+		"yaml": `
+name: filesystem
+transport: stdio
+command: npx
+args: ["-y", "@modelcontextprotocol/server-filesystem", "."]
+env:
+  DEBUG: "1"
+`,
+	}
+	for format, config := range configs {
+		input, err := ParseServerConfig(strings.NewReader(config))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", format, err)
+		}
+		if input.Name != "filesystem" || input.Transport != "stdio" || input.Command != "npx" {
+			t.Errorf("%s: unexpected config: %+v", format, input)
+		}
+		if len(input.Args) != 3 || input.Args[2] != "." || input.Env["DEBUG"] != "1" {
+			t.Errorf("%s: unexpected args or env: %+v", format, input)
+		}
+	}
+
+	if _, err := ParseServerConfig(strings.NewReader("name: [unterminated")); err == nil {
+		t.Error("Expected an error for an invalid config")
+	}
+}
+
+func TestRegisterServerFromFile(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var input types.RegisterServerInput
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		if input.Name != "calculator" || input.URL != "http://127.0.0.1:8000/mcp" {
+			t.Errorf("Unexpected server config: %+v", input)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(types.McpServer{Name: input.Name, Transport: input.Transport, URL: input.URL})
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "calculator.yaml")
+	config := "name: calculator\ntransport: streamable_http\nurl: http://127.0.0.1:8000/mcp\n"
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	s, err := client.RegisterServerFromFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if s.Name != "calculator" {
+		t.Errorf("Expected Name calculator, got %s", s.Name)
+	}
+
+	if _, err := client.RegisterServerFromFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestUpdateServer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("successful update", func(t *testing.T) {
+		expectedServer := &types.McpServer{
+			Name:      "test-server",
+			Transport: "streamable_http",
+			URL:       "http://localhost:9000/mcp",
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Verify request method and path
+			if r.Method != http.MethodPut {
+				t.Errorf("Expected PUT method, got %s", r.Method)
+			}
+			if !strings.HasSuffix(r.URL.Path, "/servers/test-server") {
+				t.Errorf("Expected path to end with /servers/test-server, got %s", r.URL.Path)
+			}
+			if contentType := r.Header.Get("Content-Type"); contentType != "application/json" {
+				t.Errorf("Expected Content-Type application/json, got %s", contentType)
+			}
+			if authHeader := r.Header.Get("Authorization"); authHeader != "Bearer test-token" {
+				t.Errorf("Expected Authorization header 'Bearer test-token', got %s", authHeader)
+			}
+
+			var input types.RegisterServerInput
+			if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+				t.Errorf("Failed to decode request body: %v", err)
+			}
+			if input.URL != expectedServer.URL {
+				t.Errorf("Expected URL %s in the request, got %s", expectedServer.URL, input.URL)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(expectedServer)
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL, WithToken("test-token"))
+		serverInput := &types.RegisterServerInput{
+			Transport: "streamable_http",
+			URL:       "http://localhost:9000/mcp",
+		}
+
+		response, err := client.UpdateServer("test-server", serverInput)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if response.Name != expectedServer.Name {
+			t.Errorf("Expected Name %s, got %s", expectedServer.Name, response.Name)
+		}
+		if response.URL != expectedServer.URL {
+			t.Errorf("Expected URL %s, got %s", expectedServer.URL, response.URL)
+		}
+	})
+
+	t.Run("server not found", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"record not found"}`))
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL, WithToken("test-token"))
+		response, err := client.UpdateServer("missing", &types.RegisterServerInput{Transport: "stdio", Command: "true"})
+
+		if err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+		if response != nil {
+			t.Error("Expected nil response on error")
+		}
+		if !strings.Contains(err.Error(), "record not found") {
+			t.Errorf("Expected error to contain 'record not found', got %s", err.Error())
+		}
+	})
+
+	t.Run("network error", func(t *testing.T) {
+		client := NewClient("http://invalid-url", WithToken("test-token"))
+
+		response, err := client.UpdateServer("test-server", &types.RegisterServerInput{Transport: "stdio", Command: "true"})
+
+		if err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+		if response != nil {
+			t.Error("Expected nil response on error")
+		}
+		if !strings.Contains(err.Error(), "failed to send request") {
+			t.Errorf("Expected error to contain 'failed to send request', got %s", err.Error())
+		}
+	})
+}
+
 func TestListServers(t *testing.T) {
 	t.Parallel()
 
@@ -162,7 +334,7 @@ func TestListServers(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		servers, err := client.ListServers()
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
@@ -187,7 +359,7 @@ func TestListServers(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		servers, err := client.ListServers()
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
@@ -205,7 +377,7 @@ func TestListServers(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		servers, err := client.ListServers()
 
 		if err == nil {
@@ -233,7 +405,7 @@ func TestDeregisterServer(t *testing.T) {
 			if r.Method != "DELETE" {
 				t.Errorf("Expected DELETE method, got %s", r.Method)
 			}
-			expectedPath := "/api/v0/servers/" + serverName
+			expectedPath := "/api/v1/servers/" + serverName
 			if !strings.HasSuffix(r.URL.Path, expectedPath) {
 				t.Errorf("Expected path to end with %s, got %s", expectedPath, r.URL.Path)
 			}
@@ -249,7 +421,7 @@ func TestDeregisterServer(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		err := client.DeregisterServer(serverName)
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
@@ -263,7 +435,7 @@ func TestDeregisterServer(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		err := client.DeregisterServer("non-existent-server")
 
 		if err == nil {
@@ -277,7 +449,7 @@ func TestDeregisterServer(t *testing.T) {
 	})
 
 	t.Run("network error", func(t *testing.T) {
-		client := NewClient("http://invalid-url", "test-token", &http.Client{})
+		client := NewClient("http://invalid-url", WithToken("test-token"))
 		err := client.DeregisterServer("test-server")
 
 		if err == nil {
@@ -289,3 +461,35 @@ func TestDeregisterServer(t *testing.T) {
 		}
 	})
 }
+
+func TestGetServerConsumers(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v1/servers/github/consumers" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": "mcp server not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(
+			`{"server": "github", "mcp_clients": ["cursor"], "tool_groups": [{"name": "triage", "tools": ["github__create_issue"]}]}`,
+		))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	consumers, err := client.GetServerConsumers("github")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(consumers.McpClients) != 1 || consumers.McpClients[0] != "cursor" {
+		t.Errorf("Unexpected MCP clients: %v", consumers.McpClients)
+	}
+	if len(consumers.ToolGroups) != 1 || consumers.ToolGroups[0].Tools[0] != "github__create_issue" {
+		t.Errorf("Unexpected tool groups: %v", consumers.ToolGroups)
+	}
+
+	if _, err := client.GetServerConsumers("missing"); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
@@ -2,9 +2,14 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
@@ -12,35 +17,48 @@ import (
 // ListTools fetches the list of tools, optionally filtered by server name.
 // If server is an empty string, this method fetches all tools.
 func (c *Client) ListTools(server string) ([]*types.Tool, error) {
+	return c.ListToolsContext(context.Background(), server)
+}
+
+// ListToolsContext is like ListTools, but the request is bound to the given context.
+func (c *Client) ListToolsContext(ctx context.Context, server string) ([]*types.Tool, error) {
+	return c.ListToolsWithOptionsContext(ctx, &types.ListToolsOptions{Server: server})
+}
+
+// ListToolsWithOptions fetches the list of tools that match the given filters, in the given sort order.
+// The filtering and sorting is performed by the server.
+func (c *Client) ListToolsWithOptions(opts *types.ListToolsOptions) ([]*types.Tool, error) {
+	return c.ListToolsWithOptionsContext(context.Background(), opts)
+}
+
+// ListToolsWithOptionsContext is like ListToolsWithOptions, but the request is bound to the given context.
+func (c *Client) ListToolsWithOptionsContext(ctx context.Context, opts *types.ListToolsOptions) ([]*types.Tool, error) {
 	u, _ := c.constructAPIEndpoint("/tools")
-	req, _ := c.newRequest(http.MethodGet, u, nil)
-	if server != "" {
-		q := req.URL.Query()
-		q.Add("server", server)
-		req.URL.RawQuery = q.Encode()
+	q := url.Values{}
+	if opts.Server != "" {
+		q.Add("server", opts.Server)
 	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request to %s: %w", req.URL.String(), err)
+	if opts.Enabled != nil {
+		q.Add("enabled", strconv.FormatBool(*opts.Enabled))
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, c.parseErrorResponse(resp)
+	if opts.NameContains != "" {
+		q.Add("name_contains", opts.NameContains)
 	}
-
-	var tools []*types.Tool
-	if err := json.NewDecoder(resp.Body).Decode(&tools); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if opts.Sort != "" {
+		q.Add("sort", string(opts.Sort))
 	}
-	return tools, nil
+	return listAll[*types.Tool](ctx, c, u, q)
 }
 
 // EnableTools enables a tool or all tools provided by an MCP server.
 func (c *Client) EnableTools(name string) ([]string, error) {
+	return c.EnableToolsContext(context.Background(), name)
+}
+
+// EnableToolsContext is like EnableTools, but the request is bound to the given context.
+func (c *Client) EnableToolsContext(ctx context.Context, name string) ([]string, error) {
 	u, _ := c.constructAPIEndpoint("/tools/enable")
-	req, err := c.newRequest(http.MethodPost, u, nil)
+	req, err := c.newRequest(ctx, http.MethodPost, u, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -49,7 +67,7 @@ func (c *Client) EnableTools(name string) ([]string, error) {
 	q.Add("entity", name)
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request to %s: %w", req.URL.String(), err)
 	}
@@ -68,8 +86,13 @@ func (c *Client) EnableTools(name string) ([]string, error) {
 
 // DisableTools disables a tool or all tools provided by an MCP server.
 func (c *Client) DisableTools(name string) ([]string, error) {
+	return c.DisableToolsContext(context.Background(), name)
+}
+
+// DisableToolsContext is like DisableTools, but the request is bound to the given context.
+func (c *Client) DisableToolsContext(ctx context.Context, name string) ([]string, error) {
 	u, _ := c.constructAPIEndpoint("/tools/disable")
-	req, err := c.newRequest(http.MethodPost, u, nil)
+	req, err := c.newRequest(ctx, http.MethodPost, u, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -78,7 +101,7 @@ func (c *Client) DisableTools(name string) ([]string, error) {
 	q.Add("entity", name)
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request to %s: %w", req.URL.String(), err)
 	}
@@ -95,15 +118,73 @@ func (c *Client) DisableTools(name string) ([]string, error) {
 	return tools, nil
 }
 
+// EnableToolsBulk enables multiple entities in a single request.
+// Each entity can be a tool name, an MCP server name or a glob pattern matched against canonical tool names.
+// The server processes each entity independently and returns one result per entity.
+func (c *Client) EnableToolsBulk(entities []string) ([]types.BulkEntityResult, error) {
+	return c.EnableToolsBulkContext(context.Background(), entities)
+}
+
+// EnableToolsBulkContext is like EnableToolsBulk, but the request is bound to the given context.
+func (c *Client) EnableToolsBulkContext(ctx context.Context, entities []string) ([]types.BulkEntityResult, error) {
+	return c.setToolsEnabledBulk(ctx, "/tools/enable", entities)
+}
+
+// DisableToolsBulk disables multiple entities in a single request.
+// It accepts the same entities as EnableToolsBulk.
+func (c *Client) DisableToolsBulk(entities []string) ([]types.BulkEntityResult, error) {
+	return c.DisableToolsBulkContext(context.Background(), entities)
+}
+
+// DisableToolsBulkContext is like DisableToolsBulk, but the request is bound to the given context.
+func (c *Client) DisableToolsBulkContext(ctx context.Context, entities []string) ([]types.BulkEntityResult, error) {
+	return c.setToolsEnabledBulk(ctx, "/tools/disable", entities)
+}
+
+func (c *Client) setToolsEnabledBulk(ctx context.Context, path string, entities []string) ([]types.BulkEntityResult, error) {
+	body, err := json.Marshal(&types.BulkEntitiesRequest{Entities: entities})
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize request: %w", err)
+	}
+
+	u, _ := c.constructAPIEndpoint(path)
+	req, err := c.newRequest(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", req.URL.String(), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var results []types.BulkEntityResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("failed to decode API response: %w", err)
+	}
+	return results, nil
+}
+
 // GetTool fetches a specific tool by its name.
 func (c *Client) GetTool(name string) (*types.Tool, error) {
+	return c.GetToolContext(context.Background(), name)
+}
+
+// GetToolContext is like GetTool, but the request is bound to the given context.
+func (c *Client) GetToolContext(ctx context.Context, name string) (*types.Tool, error) {
 	u, _ := c.constructAPIEndpoint("/tool")
-	req, _ := c.newRequest(http.MethodGet, u, nil)
+	req, _ := c.newRequest(ctx, http.MethodGet, u, nil)
 	q := req.URL.Query()
 	q.Add("name", name)
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request to %s: %w", req.URL.String(), err)
 	}
@@ -120,40 +201,198 @@ func (c *Client) GetTool(name string) (*types.Tool, error) {
 	return &tool, nil
 }
 
+var (
+	// ErrGatewayTimeout is returned when MCPJungle itself doesn't respond to a tool call before the context's deadline.
+	ErrGatewayTimeout = errors.New("gateway timeout")
+	// ErrUpstreamTimeout is returned when MCPJungle reports that the upstream MCP server didn't respond in time.
+	ErrUpstreamTimeout = errors.New("upstream timeout")
+)
+
+// upstreamTimeoutMargin is subtracted from the caller's deadline when asking MCPJungle to time out the upstream call,
+// so that MCPJungle can report the upstream timeout before the request itself is canceled.
+const upstreamTimeoutMargin = time.Second
+
 // InvokeTool sends a JSON payload to invoke a tool.
 // For now, this function only supports invoking tools that return a string response.
 func (c *Client) InvokeTool(name string, input map[string]any) (*types.ToolInvokeResult, error) {
-	// We need to insert the tool name into the POST payload
-	// In order not to mutate the user-supplied input, create a shallow copy of the input
-	// and add the name field to it.
-	payload := make(map[string]any, len(input)+1)
-	for k, v := range input {
-		payload[k] = v
-	}
-	payload["name"] = name
+	return c.InvokeToolContext(context.Background(), name, input)
+}
 
-	body, _ := json.Marshal(payload)
-	u, _ := c.constructAPIEndpoint("/tools/invoke")
-	req, err := c.newRequest(http.MethodPost, u, bytes.NewReader(body))
+// InvokeToolContext is like InvokeTool, but the request is bound to the given context.
+// If the context has a deadline, MCPJungle is asked to give up on the upstream MCP server slightly earlier,
+// so a timeout can be attributed to either MCPJungle (ErrGatewayTimeout) or the upstream server (ErrUpstreamTimeout).
+func (c *Client) InvokeToolContext(ctx context.Context, name string, input map[string]any) (*types.ToolInvokeResult, error) {
+	req, err := c.newInvokeToolRequest(ctx, name, input)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%w: mcpjungle did not respond before the deadline", ErrGatewayTimeout)
+		}
 		return nil, fmt.Errorf("request to server failed: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusGatewayTimeout {
+		return nil, fmt.Errorf("%w: %w", ErrUpstreamTimeout, c.parseErrorResponse(resp))
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, c.parseErrorResponse(resp)
 	}
 
 	var result *types.ToolInvokeResult
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%w: mcpjungle did not respond before the deadline", ErrGatewayTimeout)
+		}
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	return result, nil
 }
+
+// InvokeToolStream invokes a tool and returns a channel that yields the events of the invocation as they arrive:
+// the progress notifications the upstream MCP server sends during the call, followed by a single final event
+// holding either the result or the error. The channel is closed after the final event.
+//
+// If the stream ends without a final event, an error event is synthesized, so callers can rely on it.
+// The channel is also closed when ctx is done, in which case ctx.Err() tells why.
+// Callers that stop reading early must cancel ctx to release the underlying connection.
+func (c *Client) InvokeToolStream(
+	ctx context.Context, name string, input map[string]any,
+) (<-chan types.ToolInvokeStreamEvent, error) {
+	// stops reading the response once the final event has been delivered
+	streamCtx, cancel := context.WithCancel(ctx)
+
+	req, err := c.newInvokeToolRequest(streamCtx, name, input)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	q := req.URL.Query()
+	q.Set("stream", "true")
+	req.URL.RawQuery = q.Encode()
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.do(req)
+	if err != nil {
+		cancel()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%w: mcpjungle did not respond before the deadline", ErrGatewayTimeout)
+		}
+		return nil, fmt.Errorf("request to server failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer cancel()
+		defer resp.Body.Close()
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	raw := make(chan types.ToolInvokeStreamEvent)
+	// streamErr is only written before raw is closed
+	var streamErr error
+	go readEventStream(streamCtx, resp.Body, raw, "tool invocation event", func(err error) {
+		c.reportStreamError(err)
+		streamErr = err
+	})
+
+	events := make(chan types.ToolInvokeStreamEvent)
+	go func() {
+		defer close(events)
+		defer cancel()
+
+		send := func(e types.ToolInvokeStreamEvent) bool {
+			select {
+			case events <- e:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		for e := range raw {
+			if !send(e) {
+				return
+			}
+			if e.Type == types.ToolInvokeStreamEventResult || e.Type == types.ToolInvokeStreamEventError {
+				return
+			}
+		}
+		if ctx.Err() == nil {
+			msg := "the stream ended before the result of the tool was received"
+			if streamErr != nil {
+				msg += ": " + streamErr.Error()
+			}
+			send(types.ToolInvokeStreamEvent{Type: types.ToolInvokeStreamEventError, Error: msg})
+		}
+	}()
+	return events, nil
+}
+
+// InvokeToolWithProgress is like InvokeToolContext, but the progress notifications the upstream MCP server sends
+// during the call are passed to onProgress as soon as they arrive.
+// It is a convenience wrapper around InvokeToolStream.
+func (c *Client) InvokeToolWithProgress(
+	ctx context.Context, name string, input map[string]any, onProgress func(types.ToolProgress),
+) (*types.ToolInvokeResult, error) {
+	events, err := c.InvokeToolStream(ctx, name, input)
+	if err != nil {
+		return nil, err
+	}
+	for e := range events {
+		switch e.Type {
+		case types.ToolInvokeStreamEventProgress:
+			if onProgress != nil && e.Progress != nil {
+				onProgress(*e.Progress)
+			}
+		case types.ToolInvokeStreamEventResult:
+			return e.Result, nil
+		case types.ToolInvokeStreamEventError:
+			if e.TimedOut {
+				return nil, fmt.Errorf("%w: %s", ErrUpstreamTimeout, e.Error)
+			}
+			return nil, errors.New(e.Error)
+		}
+	}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("%w: mcpjungle did not respond before the deadline", ErrGatewayTimeout)
+	}
+	return nil, ctx.Err()
+}
+
+// newInvokeToolRequest creates the request to invoke a tool, bound to the given context.
+// If the context has a deadline, MCPJungle is asked to give up on the upstream MCP server slightly earlier.
+func (c *Client) newInvokeToolRequest(ctx context.Context, name string, input map[string]any) (*http.Request, error) {
+	// We need to insert the tool name into the POST payload
+	// In order not to mutate the user-supplied input, create a shallow copy of the input
+	// and add the name field to it.
+	payload := make(map[string]any, len(input)+1)
+	for k, v := range input {
+		payload[k] = v
+	}
+	payload["name"] = name
+
+	body, _ := json.Marshal(payload)
+	u, _ := c.constructAPIEndpoint("/tools/invoke")
+	req, err := c.newRequest(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout := time.Until(deadline)
+		if timeout > 2*upstreamTimeoutMargin {
+			timeout -= upstreamTimeoutMargin
+		}
+		if timeout > 0 {
+			q := req.URL.Query()
+			q.Set("timeout", timeout.Round(time.Millisecond).String())
+			req.URL.RawQuery = q.Encode()
+		}
+	}
+	return req, nil
+}
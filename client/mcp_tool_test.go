@@ -1,11 +1,16 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
@@ -36,9 +41,9 @@ func TestListTools(t *testing.T) {
 				t.Errorf("Expected path to end with /tools, got %s", r.URL.Path)
 			}
 
-			// Verify no query parameters
-			if r.URL.RawQuery != "" {
-				t.Errorf("Expected no query parameters, got %s", r.URL.RawQuery)
+			// Verify no server filter
+			if r.URL.Query().Has("server") {
+				t.Errorf("Expected no server query parameter, got %s", r.URL.RawQuery)
 			}
 
 			// Return success response
@@ -48,7 +53,7 @@ func TestListTools(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		tools, err := client.ListTools("")
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
@@ -79,7 +84,7 @@ func TestListTools(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		_, err := client.ListTools("test-server")
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
@@ -93,7 +98,7 @@ func TestListTools(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		tools, err := client.ListTools("")
 
 		if err == nil {
@@ -138,7 +143,7 @@ func TestEnableTools(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		tools, err := client.EnableTools("test-tool")
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
@@ -162,7 +167,7 @@ func TestEnableTools(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		tools, err := client.EnableTools("non-existent-tool")
 
 		if err == nil {
@@ -207,7 +212,7 @@ func TestDisableTools(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		tools, err := client.DisableTools("test-tool")
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
@@ -225,7 +230,7 @@ func TestDisableTools(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		tools, err := client.DisableTools("non-existent-tool")
 
 		if err == nil {
@@ -242,6 +247,48 @@ func TestDisableTools(t *testing.T) {
 	})
 }
 
+func TestDisableToolsBulk(t *testing.T) {
+	t.Parallel()
+
+	expected := []types.BulkEntityResult{
+		{Entity: "github__*", Affected: []string{"github__create_issue", "github__list_issues"}},
+		{Entity: "unknown", Error: "failed to get MCP server unknown: not found"},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST method, got %s", r.Method)
+		}
+		if !strings.HasSuffix(r.URL.Path, "/tools/disable") {
+			t.Errorf("Expected path to end with /tools/disable, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Has("entity") {
+			t.Error("Expected no entity query param in a bulk request")
+		}
+
+		var req types.BulkEntitiesRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+		if !reflect.DeepEqual(req.Entities, []string{"github__*", "unknown"}) {
+			t.Errorf("Unexpected entities in request body: %v", req.Entities)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(expected)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	results, err := client.DisableToolsBulk([]string{"github__*", "unknown"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("Expected results %v, got %v", expected, results)
+	}
+}
+
 func TestInvokeTool(t *testing.T) {
 	t.Parallel()
 
@@ -280,7 +327,7 @@ func TestInvokeTool(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		input := map[string]any{"param": "value"}
 		result, err := client.InvokeTool("test-tool", input)
 		if err != nil {
@@ -309,7 +356,7 @@ func TestInvokeTool(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		input := map[string]any{"invalid": "input"}
 		result, err := client.InvokeTool("test-tool", input)
 		if err != nil {
@@ -328,7 +375,7 @@ func TestInvokeTool(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		input := map[string]any{"param": "value"}
 		result, err := client.InvokeTool("non-existent-tool", input)
 
@@ -346,6 +393,81 @@ func TestInvokeTool(t *testing.T) {
 	})
 }
 
+func TestInvokeToolContextTimeout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("gateway timeout", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// the deadline is too short to leave a margin, so it is forwarded as is
+			if got := r.URL.Query().Get("timeout"); got == "" {
+				t.Error("Expected the timeout query param to be set")
+			}
+			// the client disconnecting is only noticed once the body has been read
+			_, _ = io.Copy(io.Discard, r.Body)
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL)
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		_, err := client.InvokeToolContext(ctx, "slow-tool", nil)
+		if !errors.Is(err, ErrGatewayTimeout) {
+			t.Fatalf("Expected ErrGatewayTimeout, got %v", err)
+		}
+	})
+
+	t.Run("upstream timeout", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			timeout, err := time.ParseDuration(r.URL.Query().Get("timeout"))
+			if err != nil {
+				t.Errorf("Expected a valid timeout query param: %v", err)
+			}
+			// mcpjungle must be asked to give up before the client does
+			if timeout > 9*time.Second {
+				t.Errorf("Expected the timeout to leave a margin, got %s", timeout)
+			}
+			w.WriteHeader(http.StatusGatewayTimeout)
+			_, _ = w.Write([]byte(`{"error": "MCP server did not respond within 9s"}`))
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		_, err := client.InvokeToolContext(ctx, "slow-tool", nil)
+		if !errors.Is(err, ErrUpstreamTimeout) {
+			t.Fatalf("Expected ErrUpstreamTimeout, got %v", err)
+		}
+		if errors.Is(err, ErrGatewayTimeout) {
+			t.Error("Expected the error not to be a gateway timeout")
+		}
+		if !strings.Contains(err.Error(), "MCP server did not respond within 9s") {
+			t.Errorf("Expected the error to contain the server's message, got %s", err.Error())
+		}
+	})
+
+	t.Run("no deadline", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Has("timeout") {
+				t.Error("Expected no timeout query param without a deadline")
+			}
+			_ = json.NewEncoder(w).Encode(&types.ToolInvokeResult{})
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL)
+		if _, err := client.InvokeToolContext(context.Background(), "tool", nil); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+}
+
 func TestGetTool(t *testing.T) {
 	t.Parallel()
 
@@ -378,7 +500,7 @@ func TestGetTool(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		tool, err := client.GetTool("test-tool")
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
@@ -399,7 +521,7 @@ func TestGetTool(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		tool, err := client.GetTool("non-existent-tool")
 
 		if err == nil {
@@ -415,3 +537,159 @@ func TestGetTool(t *testing.T) {
 		}
 	})
 }
+
+func TestInvokeToolWithProgress(t *testing.T) {
+	t.Parallel()
+
+	newStreamServer := func(events ...string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("stream") != "true" {
+				t.Errorf("Expected the stream query param to be set")
+			}
+			w.Header().Set("Content-Type", "text/event-stream")
+			for _, e := range events {
+				_, _ = w.Write([]byte("data:" + e + "\n\n"))
+				w.(http.Flusher).Flush()
+			}
+		}))
+	}
+
+	t.Run("progress and result", func(t *testing.T) {
+		server := newStreamServer(
+			`{"type": "progress", "progress": {"progress": 1, "total": 2, "message": "step 1"}}`,
+			`{"type": "progress", "progress": {"progress": 2, "total": 2}}`,
+			`{"type": "result", "result": {"content": [{"type": "text", "text": "done"}]}}`,
+		)
+		defer server.Close()
+
+		var progress []types.ToolProgress
+		client := NewClient(server.URL)
+		result, err := client.InvokeToolWithProgress(context.Background(), "indexer__index", nil, func(p types.ToolProgress) {
+			progress = append(progress, p)
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(progress) != 2 || progress[0].Message != "step 1" || progress[1].Progress != 2 {
+			t.Errorf("Unexpected progress notifications: %+v", progress)
+		}
+		if result.Content[0]["text"] != "done" {
+			t.Errorf("Unexpected result: %+v", result)
+		}
+	})
+
+	t.Run("upstream timeout", func(t *testing.T) {
+		server := newStreamServer(`{"type": "error", "error": "MCP server did not respond within 1s", "timed_out": true}`)
+		defer server.Close()
+
+		client := NewClient(server.URL)
+		_, err := client.InvokeToolWithProgress(context.Background(), "indexer__index", nil, nil)
+		if !errors.Is(err, ErrUpstreamTimeout) {
+			t.Fatalf("Expected ErrUpstreamTimeout, got %v", err)
+		}
+	})
+
+	t.Run("stream ends without result", func(t *testing.T) {
+		server := newStreamServer()
+		defer server.Close()
+
+		client := NewClient(server.URL)
+		_, err := client.InvokeToolWithProgress(context.Background(), "indexer__index", nil, nil)
+		if err == nil || !strings.Contains(err.Error(), "stream ended") {
+			t.Fatalf("Expected an error about the stream ending, got %v", err)
+		}
+	})
+}
+
+func TestInvokeToolStream(t *testing.T) {
+	t.Parallel()
+
+	newStreamServer := func(events ...string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			for _, e := range events {
+				_, _ = w.Write([]byte("data:" + e + "\n\n"))
+				w.(http.Flusher).Flush()
+			}
+		}))
+	}
+	collect := func(events <-chan types.ToolInvokeStreamEvent) []types.ToolInvokeStreamEvent {
+		var all []types.ToolInvokeStreamEvent
+		for e := range events {
+			all = append(all, e)
+		}
+		return all
+	}
+
+	t.Run("events end with the result", func(t *testing.T) {
+		server := newStreamServer(
+			`{"type": "progress", "progress": {"progress": 1, "total": 2, "message": "step 1"}}`,
+			`{"type": "result", "result": {"content": [{"type": "text", "text": "done"}]}}`,
+			// anything after the final event is ignored
+			`{"type": "progress", "progress": {"progress": 2, "total": 2}}`,
+		)
+		defer server.Close()
+
+		events, err := NewClient(server.URL).InvokeToolStream(context.Background(), "indexer__index", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		all := collect(events)
+		if len(all) != 2 {
+			t.Fatalf("Expected 2 events, got %+v", all)
+		}
+		if all[0].Type != types.ToolInvokeStreamEventProgress || all[0].Progress.Message != "step 1" {
+			t.Errorf("Unexpected progress event: %+v", all[0])
+		}
+		if all[1].Type != types.ToolInvokeStreamEventResult || all[1].Result.Content[0]["text"] != "done" {
+			t.Errorf("Unexpected result event: %+v", all[1])
+		}
+	})
+
+	t.Run("final error event is synthesized", func(t *testing.T) {
+		server := newStreamServer(`{"type": "progress", "progress": {"progress": 1}}`)
+		defer server.Close()
+
+		events, err := NewClient(server.URL).InvokeToolStream(context.Background(), "indexer__index", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		all := collect(events)
+		last := all[len(all)-1]
+		if last.Type != types.ToolInvokeStreamEventError || !strings.Contains(last.Error, "stream ended") {
+			t.Errorf("Expected a final error event, got %+v", all)
+		}
+	})
+
+	t.Run("request error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"tool not found"}`))
+		}))
+		defer server.Close()
+
+		_, err := NewClient(server.URL).InvokeToolStream(context.Background(), "indexer__missing", nil)
+		if err == nil || !strings.Contains(err.Error(), "tool not found") {
+			t.Errorf("Expected the API error, got %v", err)
+		}
+	})
+
+	t.Run("canceled by the caller", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}))
+		defer server.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		events, err := NewClient(server.URL).InvokeToolStream(ctx, "indexer__index", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		cancel()
+		if all := collect(events); len(all) != 0 {
+			t.Errorf("Expected no events, got %+v", all)
+		}
+	})
+}
@@ -0,0 +1,166 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Option configures optional behaviour of a Client.
+type Option func(*Client)
+
+// WithToken makes the client authenticate its requests with the given access token.
+func WithToken(accessToken string) Option {
+	return func(c *Client) {
+		c.accessToken = accessToken
+	}
+}
+
+// WithHTTPClient makes the client send its requests with the given HTTP client instead of http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithTimeout limits the time a request may take, including reading the response body.
+// It is applied to a copy of the HTTP client, so the one given to WithHTTPClient is left untouched.
+// Since streams (eg- FollowLogs, SubscribeEvents) are also cut after this time,
+// prefer a context deadline to bound individual calls if the client is used for streaming.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithRetryPolicy makes the client retry requests that fail with a transient error, according to the given policy.
+// By default, requests are not retried.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = p
+	}
+}
+
+// WithIdempotencyKeys makes the client send a new idempotency key with every request that creates something
+// (RegisterServer, CreateToolGroup, CreateMcpClient, CreateUser and CreateTeam)
+// unless one is set with WithIdempotencyKey.
+// A request that fails after it may have been processed (eg- the connection was reset) can then be retried safely,
+// so the retry policy applies to these requests as if they were idempotent.
+func WithIdempotencyKeys() Option {
+	return func(c *Client) {
+		c.idempotencyKeys = true
+	}
+}
+
+// WithStreamErrorHandler sets a function called with the errors of the streams opened by the client
+// (SubscribeEvents, FollowLogs & InvokeToolStream), which can't be returned to the caller once the stream is open:
+// the events that can't be decoded, and the errors that interrupt or end a stream. By default, they're ignored.
+// The function is called from the goroutine reading the stream, so it must not block.
+func WithStreamErrorHandler(h func(error)) Option {
+	return func(c *Client) {
+		c.streamErrorHandler = h
+	}
+}
+
+// WithTLSConfig makes the client use the given TLS configuration to connect to the server.
+// It replaces the changes made by the TLS options given before it, and those given after it are applied on top of it.
+//
+// TLS options are applied to a copy of the HTTP client's transport, so the one given to WithHTTPClient is left
+// untouched. They require that transport to be an *http.Transport (or nil, for the default transport).
+// A custom http.RoundTripper is expected to handle TLS itself, and the options are ignored for it.
+func WithTLSConfig(conf *tls.Config) Option {
+	return func(c *Client) {
+		if conf != nil {
+			c.tlsConfig = conf.Clone()
+		}
+	}
+}
+
+// WithRootCAs makes the client verify the server's certificate against the given CA certificates
+// instead of the system's, eg- for a registry behind a private PKI. See LoadCACertPool.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(c *Client) {
+		c.tlsConf().RootCAs = pool
+	}
+}
+
+// WithClientCertificate makes the client present the given certificate to the server (mutual TLS).
+// Use tls.LoadX509KeyPair to load it from PEM files.
+func WithClientCertificate(cert tls.Certificate) Option {
+	return func(c *Client) {
+		conf := c.tlsConf()
+		conf.Certificates = append(conf.Certificates, cert)
+	}
+}
+
+// WithInsecureSkipVerify disables the verification of the server's certificate.
+// The connection is then open to man-in-the-middle attacks, so this must only be used for testing.
+func WithInsecureSkipVerify() Option {
+	return func(c *Client) {
+		c.tlsConf().InsecureSkipVerify = true
+	}
+}
+
+// LoadCACertPool reads CA certificate bundles in PEM format, for use with WithRootCAs.
+// Each file may contain several certificates, and must contain at least one.
+func LoadCACertPool(files ...string) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	for _, f := range files {
+		pem, err := os.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid certificate found in CA certificate file %s", f)
+		}
+	}
+	return pool, nil
+}
+
+// tlsConf returns the TLS configuration being built by the options, creating it if needed.
+func (c *Client) tlsConf() *tls.Config {
+	if c.tlsConfig == nil {
+		c.tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	return c.tlsConfig
+}
+
+// WithTracing wraps every attempt to send a request in an OpenTelemetry client span, and propagates the trace context
+// to the server in the request headers, so that the server's spans join the caller's trace.
+// The global tracer provider and propagator set with the otel package are used in place of nil arguments.
+func WithTracing(tp trace.TracerProvider, propagator propagation.TextMapPropagator) Option {
+	return func(c *Client) {
+		c.tracing = newTracing(tp, propagator)
+	}
+}
+
+// WithRequestHook adds a hook called before each attempt to send a request, eg- for logging or custom headers.
+// Hooks are called in the order they were added.
+func WithRequestHook(h RequestHook) Option {
+	return func(c *Client) {
+		c.requestHooks = append(c.requestHooks, h)
+	}
+}
+
+// WithResponseHook adds a hook called after each attempt to send a request, eg- for logging or metrics.
+// Hooks are called in the order they were added.
+func WithResponseHook(h ResponseHook) Option {
+	return func(c *Client) {
+		c.responseHooks = append(c.responseHooks, h)
+	}
+}
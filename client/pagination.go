@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// listPageSize is the maximum number of items requested in a single page when listing entities.
+const listPageSize = 500
+
+// listAll fetches all items from a paginated list API, one page at a time.
+// query contains any additional query parameters to send with each request (eg- filters).
+func listAll[T any](ctx context.Context, c *Client, u string, query url.Values) ([]T, error) {
+	var items []T
+	for {
+		page, total, err := listPage[T](ctx, c, u, query, len(items))
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+
+		// Servers that don't support pagination return all items at once without reporting the total count.
+		if total < 0 || len(page) == 0 || len(items) >= total {
+			return items, nil
+		}
+	}
+}
+
+// listPage fetches a single page of items starting at offset from a paginated list API.
+// It also returns the total number of items reported by the server, or -1 if the server did not report it.
+func listPage[T any](ctx context.Context, c *Client, u string, query url.Values, offset int) ([]T, int, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	q := req.URL.Query()
+	for k, vs := range query {
+		for _, v := range vs {
+			q.Add(k, v)
+		}
+	}
+	q.Set(types.LimitQueryParam, strconv.Itoa(listPageSize))
+	q.Set(types.OffsetQueryParam, strconv.Itoa(offset))
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, c.parseErrorResponse(resp)
+	}
+
+	var page []T
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	total, err := strconv.Atoi(resp.Header.Get(types.TotalCountHeader))
+	if err != nil {
+		total = -1
+	}
+	return page, total, nil
+}
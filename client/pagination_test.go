@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestListAll(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fetches all pages", func(t *testing.T) {
+		total := listPageSize*2 + 10
+		requests := 0
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			limit, _ := strconv.Atoi(r.URL.Query().Get(types.LimitQueryParam))
+			offset, _ := strconv.Atoi(r.URL.Query().Get(types.OffsetQueryParam))
+			if r.URL.Query().Get("server") != "srv" {
+				t.Errorf("Expected server=srv in every page request, got %s", r.URL.RawQuery)
+			}
+
+			var page []*types.User
+			for i := offset; i < min(offset+limit, total); i++ {
+				page = append(page, &types.User{Username: "user" + strconv.Itoa(i)})
+			}
+			w.Header().Set(types.TotalCountHeader, strconv.Itoa(total))
+			_ = json.NewEncoder(w).Encode(page)
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL)
+		users, err := listAll[*types.User](context.Background(), client, server.URL+"/users", map[string][]string{"server": {"srv"}})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(users) != total {
+			t.Errorf("Expected %d users, got %d", total, len(users))
+		}
+		if requests != 3 {
+			t.Errorf("Expected 3 page requests, got %d", requests)
+		}
+		if users[total-1].Username != "user"+strconv.Itoa(total-1) {
+			t.Errorf("Unexpected last user: %s", users[total-1].Username)
+		}
+	})
+
+	t.Run("server without pagination support", func(t *testing.T) {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			_ = json.NewEncoder(w).Encode([]*types.User{{Username: "alice"}, {Username: "bob"}})
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL)
+		users, err := listAll[*types.User](context.Background(), client, server.URL+"/users", nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(users) != 2 {
+			t.Errorf("Expected 2 users, got %d", len(users))
+		}
+		if requests != 1 {
+			t.Errorf("Expected a single request, got %d", requests)
+		}
+	})
+}
@@ -0,0 +1,184 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/api"
+)
+
+// RetryPolicy controls how the client retries requests that fail with a transient error.
+//
+// A request is retried if the server responds with 429 (Too Many Requests) or 503 (Service Unavailable),
+// or if it can't be connected to. These mean that the request was not processed, so any request is retried.
+// A 502 (Bad Gateway) response or a connection reset by the server may happen after the request was processed,
+// so only idempotent requests (GET, HEAD, PUT, DELETE, and those sent with an idempotency key) are retried in these cases.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of times a request is retried after the first attempt.
+	// Retries are disabled if it is 0.
+	MaxRetries int
+
+	// InitialBackoff is the maximum delay before the first retry. It doubles after each retry.
+	// The actual delay is picked at random below this maximum (full jitter),
+	// so that many clients failing at once don't retry in lockstep.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between 2 attempts.
+	MaxBackoff time.Duration
+
+	// Budget caps the total time spent waiting between the attempts of a single request.
+	// The last response (or error) is returned once the next delay would exceed it. 0 means no cap.
+	Budget time.Duration
+
+	// MaxRetryAfter caps the delay the server may ask for with the Retry-After header (or rate-limit headers).
+	// A request the server asks to retry later than this is not retried, and fails with ErrRateLimited
+	// if it was rate limited. 0 means no cap, but the Budget still applies.
+	MaxRetryAfter time.Duration
+}
+
+// DefaultRetryPolicy returns a policy suitable for most callers:
+// up to 3 retries, starting at 200ms and spending at most 10s waiting in total.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Budget:         10 * time.Second,
+	}
+}
+
+// do sends the request and retries it according to the client's retry policy.
+// All requests must be sent through this method, so that the policy applies to them consistently.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	var waited time.Duration
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(req, attempt)
+		if attempt >= c.retry.MaxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		delay := c.retry.backoff(attempt, resp)
+		if c.retry.Budget > 0 && waited+delay > c.retry.Budget {
+			return resp, err
+		}
+		if _, ok := retryAfter(resp); ok && c.retry.MaxRetryAfter > 0 && delay > c.retry.MaxRetryAfter {
+			return resp, err
+		}
+		// the body was consumed by the previous attempt, so it must be rewound
+		if req.Body != nil {
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			req.Body = body
+		}
+		if resp != nil {
+			// drain the body so that the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		waited += delay
+	}
+}
+
+// backoff returns how long to wait before the retry that follows the given attempt (starting at 0).
+// The delay requested by the server takes precedence, if any.
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if d, ok := retryAfter(resp); ok {
+		return d
+	}
+
+	limit := p.InitialBackoff
+	for i := 0; i < attempt && (p.MaxBackoff <= 0 || limit < p.MaxBackoff); i++ {
+		limit *= 2
+	}
+	if p.MaxBackoff > 0 && limit > p.MaxBackoff {
+		limit = p.MaxBackoff
+	}
+	if limit <= 0 {
+		return 0
+	}
+	return rand.N(limit + 1)
+}
+
+// retryAfter returns the delay the server asks to wait for before sending the request again.
+// It comes from the Retry-After header, either in seconds or as an HTTP date.
+// Rate-limited responses without it may instead tell when the rate limit resets, in seconds,
+// with the RateLimit-Reset header or the widespread X-RateLimit-Reset header, which may also be a Unix timestamp.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+			return time.Duration(secs) * time.Second, true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return max(time.Until(t), 0), true
+		}
+		return 0, false
+	}
+	if resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	for _, h := range []string{"RateLimit-Reset", "X-RateLimit-Reset"} {
+		secs, err := strconv.ParseInt(resp.Header.Get(h), 10, 64)
+		if err != nil || secs < 0 {
+			continue
+		}
+		// a delay of more than a year can only be a timestamp
+		if secs > 365*24*60*60 {
+			return max(time.Until(time.Unix(secs, 0)), 0), true
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	return 0, false
+}
+
+// shouldRetry returns true if the outcome of sending the request is a transient failure worth retrying.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		if req.Context().Err() != nil {
+			// the caller gave up
+			return false
+		}
+		if errors.Is(err, syscall.ECONNREFUSED) {
+			return true
+		}
+		resetByServer := errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+		return resetByServer && isIdempotent(req)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway:
+		return isIdempotent(req)
+	}
+	return false
+}
+
+// isIdempotent returns true if sending the request more than once has the same effect as sending it once.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get(api.IdempotencyKeyHeader) != ""
+}
@@ -0,0 +1,298 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fastRetries retries quickly, so that the tests don't wait.
+var fastRetries = RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+// failingServer responds with the given status code to the first failures requests, then succeeds.
+// It returns the server and the number of requests it received.
+func failingServer(t *testing.T, status, failures int) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		if int(n) <= failures {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"error":"try again later"}`))
+			return
+		}
+		// the body must be sent again with each attempt
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			if !strings.Contains(string(body), "github__*") {
+				t.Errorf("Unexpected request body: %s", body)
+			}
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled by default", func(t *testing.T) {
+		server, requests := failingServer(t, http.StatusServiceUnavailable, 1)
+		client := NewClient(server.URL)
+
+		_, err := client.ListServers()
+		if err == nil || !strings.Contains(err.Error(), "try again later") {
+			t.Errorf("Expected the 503 error, got %v", err)
+		}
+		if requests.Load() != 1 {
+			t.Errorf("Expected 1 request, got %d", requests.Load())
+		}
+	})
+
+	t.Run("retries until success", func(t *testing.T) {
+		server, requests := failingServer(t, http.StatusServiceUnavailable, 2)
+		client := NewClient(server.URL, WithRetryPolicy(fastRetries))
+
+		if _, err := client.ListServers(); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if requests.Load() != 3 {
+			t.Errorf("Expected 3 requests, got %d", requests.Load())
+		}
+	})
+
+	t.Run("body is sent again", func(t *testing.T) {
+		server, requests := failingServer(t, http.StatusTooManyRequests, 1)
+		client := NewClient(server.URL, WithRetryPolicy(fastRetries))
+
+		if _, err := client.EnableToolsBulk([]string{"github__*"}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if requests.Load() != 2 {
+			t.Errorf("Expected 2 requests, got %d", requests.Load())
+		}
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		server, requests := failingServer(t, http.StatusServiceUnavailable, 10)
+		client := NewClient(server.URL, WithRetryPolicy(fastRetries))
+
+		_, err := client.ListServers()
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("Expected the last 503 error, got %v", err)
+		}
+		if requests.Load() != 4 {
+			t.Errorf("Expected 4 requests, got %d", requests.Load())
+		}
+	})
+
+	t.Run("bad gateway is only retried for idempotent requests", func(t *testing.T) {
+		server, requests := failingServer(t, http.StatusBadGateway, 1)
+		client := NewClient(server.URL, WithRetryPolicy(fastRetries))
+
+		if _, err := client.EnableToolsBulk([]string{"github__*"}); err == nil {
+			t.Errorf("Expected the 502 error for a POST request")
+		}
+		if _, err := client.ListServers(); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		// 1 failed POST, then a successful GET
+		if requests.Load() != 2 {
+			t.Errorf("Expected 2 requests, got %d", requests.Load())
+		}
+	})
+
+	t.Run("budget", func(t *testing.T) {
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		// the server asks to wait longer than the budget allows, so the request isn't retried
+		policy := fastRetries
+		policy.Budget = 500 * time.Millisecond
+		client := NewClient(server.URL, WithRetryPolicy(policy))
+
+		if _, err := client.ListServers(); err == nil {
+			t.Errorf("Expected an error")
+		}
+		if requests.Load() != 1 {
+			t.Errorf("Expected 1 request, got %d", requests.Load())
+		}
+	})
+
+	t.Run("context canceled while waiting", func(t *testing.T) {
+		server, _ := failingServer(t, http.StatusServiceUnavailable, 10)
+		client := NewClient(server.URL, WithRetryPolicy(RetryPolicy{MaxRetries: 3, InitialBackoff: time.Minute}))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		if _, err := client.ListServersContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+		}
+	})
+
+	t.Run("connection reset", func(t *testing.T) {
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if requests.Add(1) == 1 {
+				// close the connection without responding
+				conn, _, _ := w.(http.Hijacker).Hijack()
+				conn.Close()
+				return
+			}
+			_, _ = w.Write([]byte(`{"name": "time", "enabled": true}`))
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL, WithRetryPolicy(fastRetries))
+		tool, err := client.GetTool("time__get_current_time")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if tool.Name != "time" || requests.Load() != 2 {
+			t.Errorf("Unexpected tool %+v after %d requests", tool, requests.Load())
+		}
+	})
+}
+
+func TestRateLimited(t *testing.T) {
+	t.Parallel()
+
+	// rateLimitedServer rejects the first limited requests with a 429 and the given headers, then succeeds
+	rateLimitedServer := func(t *testing.T, limited int32, header http.Header) (*httptest.Server, *atomic.Int32) {
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if requests.Add(1) <= limited {
+				maps.Copy(w.Header(), header)
+				w.WriteHeader(http.StatusTooManyRequests)
+				_, _ = w.Write([]byte(`{"error":"slow down"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`[]`))
+		}))
+		t.Cleanup(server.Close)
+		return server, &requests
+	}
+
+	t.Run("typed error without retries", func(t *testing.T) {
+		server, _ := rateLimitedServer(t, 1, http.Header{"Retry-After": []string{"7"}})
+		client := NewClient(server.URL)
+
+		_, err := client.ListServers()
+		if !errors.Is(err, ErrRateLimited) {
+			t.Fatalf("Expected ErrRateLimited, got %v", err)
+		}
+		var rateLimitErr *RateLimitError
+		if !errors.As(err, &rateLimitErr) || rateLimitErr.RetryAfter != 7*time.Second {
+			t.Errorf("Expected a RateLimitError asking to retry after 7s, got %v", err)
+		}
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests || apiErr.Message != "slow down" {
+			t.Errorf("Expected the APIError to be available, got %v", err)
+		}
+	})
+
+	t.Run("retried after the requested delay", func(t *testing.T) {
+		server, requests := rateLimitedServer(t, 1, http.Header{"Retry-After": []string{"0"}})
+		client := NewClient(server.URL, WithRetryPolicy(fastRetries))
+
+		if _, err := client.ListServers(); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if requests.Load() != 2 {
+			t.Errorf("Expected 2 requests, got %d", requests.Load())
+		}
+	})
+
+	t.Run("retry after exceeds the cap", func(t *testing.T) {
+		server, requests := rateLimitedServer(t, 1, http.Header{"Retry-After": []string{"60"}})
+		policy := fastRetries
+		policy.MaxRetryAfter = time.Second
+		client := NewClient(server.URL, WithRetryPolicy(policy))
+
+		if _, err := client.ListServers(); !errors.Is(err, ErrRateLimited) {
+			t.Errorf("Expected ErrRateLimited, got %v", err)
+		}
+		if requests.Load() != 1 {
+			t.Errorf("Expected 1 request, got %d", requests.Load())
+		}
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		server, requests := rateLimitedServer(t, 10, http.Header{"X-Ratelimit-Reset": []string{"0"}})
+		client := NewClient(server.URL, WithRetryPolicy(fastRetries))
+
+		if _, err := client.ListServers(); !errors.Is(err, ErrRateLimited) {
+			t.Errorf("Expected ErrRateLimited, got %v", err)
+		}
+		if requests.Load() != 4 {
+			t.Errorf("Expected 4 requests, got %d", requests.Load())
+		}
+	})
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	reset := strconv.FormatInt(time.Now().Add(2*time.Minute).Unix(), 10)
+	tests := []struct {
+		name   string
+		status int
+		header http.Header
+		min    time.Duration
+		max    time.Duration
+		ok     bool
+	}{
+		{"seconds", http.StatusServiceUnavailable, http.Header{"Retry-After": {"3"}}, 3 * time.Second, 3 * time.Second, true},
+		{"http date", http.StatusTooManyRequests, http.Header{"Retry-After": {date}}, 55 * time.Second, time.Minute, true},
+		{"date in the past", http.StatusTooManyRequests, http.Header{"Retry-After": {"Mon, 02 Jan 2006 15:04:05 GMT"}}, 0, 0, true},
+		{"invalid", http.StatusTooManyRequests, http.Header{"Retry-After": {"soon"}}, 0, 0, false},
+		{"ratelimit reset", http.StatusTooManyRequests, http.Header{"Ratelimit-Reset": {"5"}}, 5 * time.Second, 5 * time.Second, true},
+		{"reset timestamp", http.StatusTooManyRequests, http.Header{"X-Ratelimit-Reset": {reset}}, 115 * time.Second, 2 * time.Minute, true},
+		{"reset ignored unless rate limited", http.StatusServiceUnavailable, http.Header{"Ratelimit-Reset": {"5"}}, 0, 0, false},
+		{"no header", http.StatusTooManyRequests, http.Header{}, 0, 0, false},
+	}
+	for _, tt := range tests {
+		d, ok := retryAfter(&http.Response{StatusCode: tt.status, Header: tt.header})
+		if ok != tt.ok || d < tt.min || d > tt.max {
+			t.Errorf("%s: expected a delay between %s and %s (%t), got %s (%t)", tt.name, tt.min, tt.max, tt.ok, d, ok)
+		}
+	}
+	if _, ok := retryAfter(nil); ok {
+		t.Error("Expected no delay without a response")
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	t.Parallel()
+
+	p := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for attempt, limit := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, time.Second, time.Second} {
+		if d := p.backoff(attempt, nil); d < 0 || d > limit {
+			t.Errorf("attempt %d: expected a delay up to %s, got %s", attempt, limit, d)
+		}
+	}
+	// the delay is still capped after many attempts
+	if d := p.backoff(100, nil); d < 0 || d > time.Second {
+		t.Errorf("Expected a delay up to 1s, got %s", d)
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+	if d := p.backoff(0, resp); d != 3*time.Second {
+		t.Errorf("Expected the Retry-After delay, got %s", d)
+	}
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// Search finds the tools and prompts matching the given query, ranked by relevance.
+// resultType optionally restricts the search to either tools or prompts.
+// At most limit results are returned, all matches are returned if limit is 0.
+func (c *Client) Search(query string, resultType types.SearchResultType, limit int) ([]types.SearchResult, error) {
+	return c.SearchContext(context.Background(), query, resultType, limit)
+}
+
+// SearchContext is like Search, but the request is bound to the given context.
+func (c *Client) SearchContext(ctx context.Context, query string, resultType types.SearchResultType, limit int) ([]types.SearchResult, error) {
+	u, _ := c.constructAPIEndpoint("/search")
+	req, err := c.newRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	q := req.URL.Query()
+	q.Add("q", query)
+	if resultType != "" {
+		q.Add("type", string(resultType))
+	}
+	if limit > 0 {
+		q.Add(types.LimitQueryParam, strconv.Itoa(limit))
+	}
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var results []types.SearchResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return results, nil
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("successful search", func(t *testing.T) {
+		expected := []types.SearchResult{
+			{Type: types.SearchResultTool, Name: "github__create_pull_request", Enabled: true, Score: 12},
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasSuffix(r.URL.Path, "/search") {
+				t.Errorf("Expected path to end with /search, got %s", r.URL.Path)
+			}
+			q := r.URL.Query()
+			if q.Get("q") != "pull request" || q.Get("type") != "tool" || q.Get("limit") != "5" {
+				t.Errorf("Unexpected query parameters: %s", r.URL.RawQuery)
+			}
+			_ = json.NewEncoder(w).Encode(expected)
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL, WithToken("test-token"))
+		results, err := client.Search("pull request", types.SearchResultTool, 5)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(results) != 1 || results[0].Name != expected[0].Name {
+			t.Errorf("Unexpected results: %v", results)
+		}
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"missing 'q' query parameter"}`))
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL, WithToken("test-token"))
+		_, err := client.Search("", "", 0)
+		if err == nil || !strings.Contains(err.Error(), "missing 'q' query parameter") {
+			t.Errorf("Expected error about missing query, got %v", err)
+		}
+	})
+}
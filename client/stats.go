@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// GetStats fetches an overview of the registry's entities and the calls recently made through mcpjungle.
+func (c *Client) GetStats() (*types.RegistryStats, error) {
+	return c.GetStatsContext(context.Background())
+}
+
+// GetStatsContext is like GetStats, but the request is bound to the given context.
+func (c *Client) GetStatsContext(ctx context.Context) (*types.RegistryStats, error) {
+	u, _ := c.constructAPIEndpoint("/stats")
+	req, err := c.newRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var stats types.RegistryStats
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &stats, nil
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestGetStats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("successful get", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet || !strings.HasSuffix(r.URL.Path, "/api/v1/stats") {
+				t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			}
+			_ = json.NewEncoder(w).Encode(types.RegistryStats{
+				Servers: 2,
+				Tools:   types.EntityCounts{Total: 5, Enabled: 4, Disabled: 1},
+				Invocations: types.InvocationStats{
+					WindowSeconds: 3600, ToolCalls: 10, ToolCallErrors: 1, ToolCallErrorRate: 0.1,
+				},
+			})
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL, WithToken("test-token"))
+		stats, err := client.GetStats()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if stats.Servers != 2 || stats.Tools.Disabled != 1 || stats.Invocations.ToolCalls != 10 {
+			t.Errorf("Unexpected stats: %+v", stats)
+		}
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error":"user is not an admin"}`))
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL, WithToken("test-token"))
+		_, err := client.GetStats()
+		if err == nil || !strings.Contains(err.Error(), "user is not an admin") {
+			t.Errorf("Expected admin error, got %v", err)
+		}
+	})
+}
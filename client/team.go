@@ -0,0 +1,123 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// CreateTeam sends a request to create a new team of users in mcpjungle
+func (c *Client) CreateTeam(team *types.Team) (*types.Team, error) {
+	return c.CreateTeamContext(context.Background(), team)
+}
+
+// CreateTeamContext is like CreateTeam, but the request is bound to the given context.
+func (c *Client) CreateTeamContext(ctx context.Context, team *types.Team) (*types.Team, error) {
+	u, _ := c.constructAPIEndpoint("/teams")
+	return c.sendTeam(ctx, http.MethodPost, u, team, http.StatusCreated)
+}
+
+// ListTeams sends a request to list all teams in mcpjungle
+func (c *Client) ListTeams() ([]*types.Team, error) {
+	return c.ListTeamsContext(context.Background())
+}
+
+// ListTeamsContext is like ListTeams, but the request is bound to the given context.
+func (c *Client) ListTeamsContext(ctx context.Context) ([]*types.Team, error) {
+	u, _ := c.constructAPIEndpoint("/teams")
+	return listAll[*types.Team](ctx, c, u, nil)
+}
+
+// GetTeam sends a request to get the members & permissions of a team
+func (c *Client) GetTeam(name string) (*types.Team, error) {
+	return c.GetTeamContext(context.Background(), name)
+}
+
+// GetTeamContext is like GetTeam, but the request is bound to the given context.
+func (c *Client) GetTeamContext(ctx context.Context, name string) (*types.Team, error) {
+	u, _ := c.constructAPIEndpoint("/teams/" + name)
+	return c.sendTeam(ctx, http.MethodGet, u, nil, http.StatusOK)
+}
+
+// UpdateTeam sends a request to replace the description, members and permissions of a team
+func (c *Client) UpdateTeam(team *types.Team) (*types.Team, error) {
+	return c.UpdateTeamContext(context.Background(), team)
+}
+
+// UpdateTeamContext is like UpdateTeam, but the request is bound to the given context.
+func (c *Client) UpdateTeamContext(ctx context.Context, team *types.Team) (*types.Team, error) {
+	u, _ := c.constructAPIEndpoint("/teams/" + team.Name)
+	return c.sendTeam(ctx, http.MethodPut, u, team, http.StatusOK)
+}
+
+// DeleteTeam sends a request to delete a team from mcpjungle
+func (c *Client) DeleteTeam(name string) error {
+	return c.DeleteTeamContext(context.Background(), name)
+}
+
+// DeleteTeamContext is like DeleteTeam, but the request is bound to the given context.
+func (c *Client) DeleteTeamContext(ctx context.Context, name string) error {
+	u, _ := c.constructAPIEndpoint("/teams/" + name)
+
+	req, err := c.newRequest(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request to %s: %w", u, err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return c.parseErrorResponse(resp)
+	}
+	return nil
+}
+
+// sendTeam sends a request with the given team as its body, if any, and decodes the team returned by the server.
+func (c *Client) sendTeam(
+	ctx context.Context, method, u string, team *types.Team, expectedStatus int,
+) (*types.Team, error) {
+	var body io.Reader
+	if team != nil {
+		b, err := json.Marshal(team)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewBuffer(b)
+	}
+
+	req, err := c.newRequest(ctx, method, u, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request to %s: %w", u, err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if method == http.MethodPost {
+		c.setIdempotencyKey(req)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != expectedStatus {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var t types.Team
+	if err := json.NewDecoder(resp.Body).Decode(&t); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &t, nil
+}
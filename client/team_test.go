@@ -0,0 +1,76 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestTeams(t *testing.T) {
+	t.Parallel()
+
+	teams := map[string]*types.Team{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Path[len("/api/v0/teams"):]
+		if len(name) > 0 {
+			name = name[1:]
+		}
+		switch {
+		case r.Method == http.MethodPost && name == "":
+			if r.Header.Get("Content-Type") != "application/json" {
+				t.Errorf("Expected Content-Type application/json, got %s", r.Header.Get("Content-Type"))
+			}
+			var team types.Team
+			_ = json.NewDecoder(r.Body).Decode(&team)
+			teams[team.Name] = &team
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(team)
+		case r.Method == http.MethodGet && teams[name] != nil:
+			_ = json.NewEncoder(w).Encode(teams[name])
+		case r.Method == http.MethodPut && teams[name] != nil:
+			var team types.Team
+			_ = json.NewDecoder(r.Body).Decode(&team)
+			teams[name] = &team
+			_ = json.NewEncoder(w).Encode(team)
+		case r.Method == http.MethodDelete && teams[name] != nil:
+			delete(teams, name)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "team not found"})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+
+	created, err := client.CreateTeam(&types.Team{Name: "platform", Members: []string{"alice"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if created.Name != "platform" || len(created.Members) != 1 {
+		t.Errorf("Unexpected team created: %+v", created)
+	}
+
+	created.Servers = []string{"github"}
+	if _, err := client.UpdateTeam(created); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	got, err := client.GetTeam("platform")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(got.Servers) != 1 || got.Servers[0] != "github" {
+		t.Errorf("Expected the team to administer github, got %+v", got.Servers)
+	}
+
+	if err := client.DeleteTeam("platform"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.GetTeam("platform"); err == nil {
+		t.Error("Expected an error for a deleted team")
+	}
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -11,6 +12,11 @@ import (
 
 // CreateToolGroup sends API request to create a new Tool Group.
 func (c *Client) CreateToolGroup(group *types.ToolGroup) (*types.CreateToolGroupResponse, error) {
+	return c.CreateToolGroupContext(context.Background(), group)
+}
+
+// CreateToolGroupContext is like CreateToolGroup, but the request is bound to the given context.
+func (c *Client) CreateToolGroupContext(ctx context.Context, group *types.ToolGroup) (*types.CreateToolGroupResponse, error) {
 	u, _ := c.constructAPIEndpoint("/tool-groups")
 
 	body, err := json.Marshal(group)
@@ -18,13 +24,14 @@ func (c *Client) CreateToolGroup(group *types.ToolGroup) (*types.CreateToolGroup
 		return nil, err
 	}
 
-	req, err := c.newRequest(http.MethodPost, u, bytes.NewBuffer(body))
+	req, err := c.newRequest(ctx, http.MethodPost, u, bytes.NewBuffer(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request to %s: %w", u, err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	c.setIdempotencyKey(req)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
 	}
@@ -43,14 +50,19 @@ func (c *Client) CreateToolGroup(group *types.ToolGroup) (*types.CreateToolGroup
 
 // DeleteToolGroup sends API request to delete a Tool Group by name.
 func (c *Client) DeleteToolGroup(name string) error {
+	return c.DeleteToolGroupContext(context.Background(), name)
+}
+
+// DeleteToolGroupContext is like DeleteToolGroup, but the request is bound to the given context.
+func (c *Client) DeleteToolGroupContext(ctx context.Context, name string) error {
 	u, _ := c.constructAPIEndpoint("/tool-groups/" + name)
 
-	req, err := c.newRequest(http.MethodDelete, u, nil)
+	req, err := c.newRequest(ctx, http.MethodDelete, u, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request to %s: %w", u, err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to send request to %s: %w", u, err)
 	}
@@ -65,14 +77,30 @@ func (c *Client) DeleteToolGroup(name string) error {
 
 // ListToolGroups sends API request to list all Tool Groups.
 func (c *Client) ListToolGroups() ([]types.ToolGroup, error) {
+	return c.ListToolGroupsContext(context.Background())
+}
+
+// ListToolGroupsContext is like ListToolGroups, but the request is bound to the given context.
+func (c *Client) ListToolGroupsContext(ctx context.Context) ([]types.ToolGroup, error) {
 	u, _ := c.constructAPIEndpoint("/tool-groups")
+	return listAll[types.ToolGroup](ctx, c, u, nil)
+}
 
-	req, err := c.newRequest(http.MethodGet, u, nil)
+// GetToolGroup sends API request to get details of a specific Tool Group by name.
+func (c *Client) GetToolGroup(name string) (*types.GetToolGroupResponse, error) {
+	return c.GetToolGroupContext(context.Background(), name)
+}
+
+// GetToolGroupContext is like GetToolGroup, but the request is bound to the given context.
+func (c *Client) GetToolGroupContext(ctx context.Context, name string) (*types.GetToolGroupResponse, error) {
+	u, _ := c.constructAPIEndpoint("/tool-groups/" + name)
+
+	req, err := c.newRequest(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request to %s: %w", u, err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
 	}
@@ -82,23 +110,73 @@ func (c *Client) ListToolGroups() ([]types.ToolGroup, error) {
 		return nil, c.parseErrorResponse(resp)
 	}
 
-	var groups []types.ToolGroup
-	if err := json.NewDecoder(resp.Body).Decode(&groups); err != nil {
+	var group types.GetToolGroupResponse
+	if err := json.NewDecoder(resp.Body).Decode(&group); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
-	return groups, nil
+	return &group, nil
 }
 
-// GetToolGroup sends API request to get details of a specific Tool Group by name.
-func (c *Client) GetToolGroup(name string) (*types.GetToolGroupResponse, error) {
+func (c *Client) UpdateToolGroup(group *types.ToolGroup) (*types.UpdateToolGroupResponse, error) {
+	return c.UpdateToolGroupContext(context.Background(), group)
+}
+
+// UpdateToolGroupContext is like UpdateToolGroup, but the request is bound to the given context.
+func (c *Client) UpdateToolGroupContext(ctx context.Context, group *types.ToolGroup) (*types.UpdateToolGroupResponse, error) {
+	u, _ := c.constructAPIEndpoint("/tool-groups/" + group.Name)
+
+	body, err := json.Marshal(group)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPut, u, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request to %s: %w", u, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var updateResp types.UpdateToolGroupResponse
+	if err := json.NewDecoder(resp.Body).Decode(&updateResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &updateResp, nil
+}
+
+// PatchToolGroup updates only the fields of a tool group that are set in the patch, leaving the others unchanged.
+// Unlike UpdateToolGroup, this doesn't require fetching the full configuration of the group first.
+func (c *Client) PatchToolGroup(name string, patch *types.PatchToolGroupInput) (*types.UpdateToolGroupResponse, error) {
+	return c.PatchToolGroupContext(context.Background(), name, patch)
+}
+
+// PatchToolGroupContext is like PatchToolGroup, but the request is bound to the given context.
+func (c *Client) PatchToolGroupContext(
+	ctx context.Context, name string, patch *types.PatchToolGroupInput,
+) (*types.UpdateToolGroupResponse, error) {
 	u, _ := c.constructAPIEndpoint("/tool-groups/" + name)
 
-	req, err := c.newRequest(http.MethodGet, u, nil)
+	body, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPatch, u, bytes.NewBuffer(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request to %s: %w", u, err)
 	}
+	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
 	}
@@ -108,28 +186,38 @@ func (c *Client) GetToolGroup(name string) (*types.GetToolGroupResponse, error)
 		return nil, c.parseErrorResponse(resp)
 	}
 
-	var group types.GetToolGroupResponse
-	if err := json.NewDecoder(resp.Body).Decode(&group); err != nil {
+	var patchResp types.UpdateToolGroupResponse
+	if err := json.NewDecoder(resp.Body).Decode(&patchResp); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
-	return &group, nil
+	return &patchResp, nil
 }
 
-func (c *Client) UpdateToolGroup(group *types.ToolGroup) (*types.UpdateToolGroupResponse, error) {
-	u, _ := c.constructAPIEndpoint("/tool-groups/" + group.Name)
+// ValidateToolGroup checks the configuration of a tool group without creating it,
+// and returns the tools the group would expose.
+// An invalid configuration is not an error, it is described by the returned response.
+func (c *Client) ValidateToolGroup(group *types.ToolGroup) (*types.ValidateToolGroupResponse, error) {
+	return c.ValidateToolGroupContext(context.Background(), group)
+}
+
+// ValidateToolGroupContext is like ValidateToolGroup, but the request is bound to the given context.
+func (c *Client) ValidateToolGroupContext(
+	ctx context.Context, group *types.ToolGroup,
+) (*types.ValidateToolGroupResponse, error) {
+	u, _ := c.constructAPIEndpoint("/tool-groups/validate")
 
 	body, err := json.Marshal(group)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := c.newRequest(http.MethodPut, u, bytes.NewBuffer(body))
+	req, err := c.newRequest(ctx, http.MethodPost, u, bytes.NewBuffer(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request to %s: %w", u, err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
 	}
@@ -139,9 +227,9 @@ func (c *Client) UpdateToolGroup(group *types.ToolGroup) (*types.UpdateToolGroup
 		return nil, c.parseErrorResponse(resp)
 	}
 
-	var updateResp types.UpdateToolGroupResponse
-	if err := json.NewDecoder(resp.Body).Decode(&updateResp); err != nil {
+	var validateResp types.ValidateToolGroupResponse
+	if err := json.NewDecoder(resp.Body).Decode(&validateResp); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
-	return &updateResp, nil
+	return &validateResp, nil
 }
@@ -16,9 +16,9 @@ func TestCreateToolGroup(t *testing.T) {
 	t.Run("successful creation", func(t *testing.T) {
 		expectedResponse := &types.CreateToolGroupResponse{
 			ToolGroupEndpoints: &types.ToolGroupEndpoints{
-				StreamableHTTPEndpoint: "/api/v0/tool-groups/test-group",
-				SSEEndpoint:            "/api/v0/tool-groups/test-group/sse",
-				SSEMessageEndpoint:     "/api/v0/tool-groups/test-group/sse/message",
+				StreamableHTTPEndpoint: "/api/v1/tool-groups/test-group",
+				SSEEndpoint:            "/api/v1/tool-groups/test-group/sse",
+				SSEMessageEndpoint:     "/api/v1/tool-groups/test-group/sse/message",
 			},
 		}
 
@@ -54,7 +54,7 @@ func TestCreateToolGroup(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		toolGroup := &types.ToolGroup{
 			Name:          "test-group",
 			Description:   "Test tool group",
@@ -78,7 +78,7 @@ func TestCreateToolGroup(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		toolGroup := &types.ToolGroup{
 			Name:          "test-group",
 			Description:   "Test tool group",
@@ -127,7 +127,7 @@ func TestGetToolGroup(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		group, err := client.GetToolGroup("test-group")
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
@@ -151,7 +151,7 @@ func TestGetToolGroup(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		group, err := client.GetToolGroup("non-existent-group")
 
 		if err == nil {
@@ -179,7 +179,7 @@ func TestDeleteToolGroup(t *testing.T) {
 			if r.Method != "DELETE" {
 				t.Errorf("Expected DELETE method, got %s", r.Method)
 			}
-			expectedPath := "/api/v0/tool-groups/" + groupName
+			expectedPath := "/api/v1/tool-groups/" + groupName
 			if !strings.HasSuffix(r.URL.Path, expectedPath) {
 				t.Errorf("Expected path to end with %s, got %s", expectedPath, r.URL.Path)
 			}
@@ -189,7 +189,7 @@ func TestDeleteToolGroup(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		err := client.DeleteToolGroup(groupName)
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
@@ -203,7 +203,7 @@ func TestDeleteToolGroup(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		err := client.DeleteToolGroup("non-existent-group")
 
 		if err == nil {
@@ -250,7 +250,7 @@ func TestListToolGroups(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		groups, err := client.ListToolGroups()
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
@@ -275,7 +275,7 @@ func TestListToolGroups(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		groups, err := client.ListToolGroups()
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
@@ -293,7 +293,7 @@ func TestListToolGroups(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		groups, err := client.ListToolGroups()
 
 		if err == nil {
@@ -309,3 +309,127 @@ func TestListToolGroups(t *testing.T) {
 		}
 	})
 }
+
+func TestUpdateToolGroup(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || !strings.HasSuffix(r.URL.Path, "/tool-groups/test-group") {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var group types.ToolGroup
+		if err := json.NewDecoder(r.Body).Decode(&group); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		_ = json.NewEncoder(w).Encode(types.UpdateToolGroupResponse{
+			Name: "test-group",
+			Old:  &types.ToolGroup{Name: "test-group", IncludedTools: []string{"tool1"}},
+			New:  &group,
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	resp, err := client.UpdateToolGroup(&types.ToolGroup{Name: "test-group", IncludedTools: []string{"tool1", "tool2"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(resp.Old.IncludedTools) != 1 || len(resp.New.IncludedTools) != 2 {
+		t.Errorf("Unexpected response: old %+v, new %+v", resp.Old, resp.New)
+	}
+}
+
+func TestPatchToolGroup(t *testing.T) {
+	t.Parallel()
+
+	t.Run("successful patch", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPatch || !strings.HasSuffix(r.URL.Path, "/tool-groups/test-group") {
+				t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			}
+			// only the fields set in the patch must be sent
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("Failed to decode request body: %v", err)
+			}
+			if len(body) != 2 || body["description"] != "new" || body["read_only"] != false {
+				t.Errorf("Unexpected request body: %v", body)
+			}
+			_ = json.NewEncoder(w).Encode(types.UpdateToolGroupResponse{
+				Name: "test-group",
+				Old:  &types.ToolGroup{Name: "test-group", Description: "old", ReadOnly: true},
+				New:  &types.ToolGroup{Name: "test-group", Description: "new"},
+			})
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL, WithToken("test-token"))
+		description, readOnly := "new", false
+		resp, err := client.PatchToolGroup("test-group", &types.PatchToolGroupInput{
+			Description: &description,
+			ReadOnly:    &readOnly,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if resp.Old.Description != "old" || resp.New.Description != "new" {
+			t.Errorf("Unexpected response: old %+v, new %+v", resp.Old, resp.New)
+		}
+	})
+
+	t.Run("group not found", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"tool group missing does not exist"}`))
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL, WithToken("test-token"))
+		_, err := client.PatchToolGroup("missing", &types.PatchToolGroupInput{})
+		if err == nil || !strings.Contains(err.Error(), "does not exist") {
+			t.Errorf("Expected not found error, got %v", err)
+		}
+	})
+}
+
+func TestValidateToolGroup(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/tool-groups/validate") {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var group types.ToolGroup
+		if err := json.NewDecoder(r.Body).Decode(&group); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		if len(group.IncludedServers) == 0 {
+			_ = json.NewEncoder(w).Encode(types.ValidateToolGroupResponse{Error: "tool group must contain at least one tool"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(types.ValidateToolGroupResponse{
+			Valid:          true,
+			EffectiveTools: []string{"time__get_current_time"},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+
+	resp, err := client.ValidateToolGroup(&types.ToolGroup{Name: "time", IncludedServers: []string{"time"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !resp.Valid || len(resp.EffectiveTools) != 1 || resp.EffectiveTools[0] != "time__get_current_time" {
+		t.Errorf("Unexpected response: %+v", resp)
+	}
+
+	// an invalid configuration is not an error
+	resp, err = client.ValidateToolGroup(&types.ToolGroup{Name: "empty"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resp.Valid || !strings.Contains(resp.Error, "at least one tool") {
+		t.Errorf("Unexpected response: %+v", resp)
+	}
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -11,6 +12,11 @@ import (
 
 // CreateUser sends a request to create a new authenticated, human user in mcpjungle
 func (c *Client) CreateUser(user *types.CreateUserRequest) (*types.CreateUserResponse, error) {
+	return c.CreateUserContext(context.Background(), user)
+}
+
+// CreateUserContext is like CreateUser, but the request is bound to the given context.
+func (c *Client) CreateUserContext(ctx context.Context, user *types.CreateUserRequest) (*types.CreateUserResponse, error) {
 	u, _ := c.constructAPIEndpoint("/users")
 
 	body, err := json.Marshal(user)
@@ -18,13 +24,14 @@ func (c *Client) CreateUser(user *types.CreateUserRequest) (*types.CreateUserRes
 		return nil, err
 	}
 
-	req, err := c.newRequest(http.MethodPost, u, bytes.NewBuffer(body))
+	req, err := c.newRequest(ctx, http.MethodPost, u, bytes.NewBuffer(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request to %s: %w", u, err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	c.setIdempotencyKey(req)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
 	}
@@ -43,14 +50,19 @@ func (c *Client) CreateUser(user *types.CreateUserRequest) (*types.CreateUserRes
 
 // DeleteUser sends a request to delete a user from mcpjungle
 func (c *Client) DeleteUser(username string) error {
+	return c.DeleteUserContext(context.Background(), username)
+}
+
+// DeleteUserContext is like DeleteUser, but the request is bound to the given context.
+func (c *Client) DeleteUserContext(ctx context.Context, username string) error {
 	u, _ := c.constructAPIEndpoint("/users/" + username)
 
-	req, err := c.newRequest(http.MethodDelete, u, nil)
+	req, err := c.newRequest(ctx, http.MethodDelete, u, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request to %s: %w", u, err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to send request to %s: %w", u, err)
 	}
@@ -63,16 +75,108 @@ func (c *Client) DeleteUser(username string) error {
 	return nil
 }
 
-// ListUsers sends a request to list all users in mcpjungle
-func (c *Client) ListUsers() ([]*types.User, error) {
-	u, _ := c.constructAPIEndpoint("/users")
+// RenewAccessToken sends a request to replace the access token of the authenticated user by a new one.
+// The client's current token no longer works once the request succeeds, so it must switch to the returned one.
+func (c *Client) RenewAccessToken() (*types.RenewAccessTokenResponse, error) {
+	return c.RenewAccessTokenContext(context.Background())
+}
+
+// RenewAccessTokenContext is like RenewAccessToken, but the request is bound to the given context.
+func (c *Client) RenewAccessTokenContext(ctx context.Context) (*types.RenewAccessTokenResponse, error) {
+	u, _ := c.constructAPIEndpoint("/users/me/token")
+
+	req, err := c.newRequest(ctx, http.MethodPost, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request to %s: %w", u, err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var renewResp types.RenewAccessTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&renewResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &renewResp, nil
+}
+
+// SuspendUser sends a request to suspend a user, so that their access token is rejected until they are activated
+// again. The user's account is preserved.
+func (c *Client) SuspendUser(username string) (*types.User, error) {
+	return c.SuspendUserContext(context.Background(), username)
+}
+
+// SuspendUserContext is like SuspendUser, but the request is bound to the given context.
+func (c *Client) SuspendUserContext(ctx context.Context, username string) (*types.User, error) {
+	return c.setUserActive(ctx, username, "suspend")
+}
+
+// ActivateUser sends a request to lift the suspension of a user.
+func (c *Client) ActivateUser(username string) (*types.User, error) {
+	return c.ActivateUserContext(context.Background(), username)
+}
+
+// ActivateUserContext is like ActivateUser, but the request is bound to the given context.
+func (c *Client) ActivateUserContext(ctx context.Context, username string) (*types.User, error) {
+	return c.setUserActive(ctx, username, "activate")
+}
+
+func (c *Client) setUserActive(ctx context.Context, username, action string) (*types.User, error) {
+	u, _ := c.constructAPIEndpoint("/users/" + username + "/" + action)
+
+	req, err := c.newRequest(ctx, http.MethodPost, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request to %s: %w", u, err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var user types.User
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &user, nil
+}
+
+// SetUserToolPolicy sends a request to restrict the tools a user may see & invoke via the API
+// to those allowed by the given policy.
+func (c *Client) SetUserToolPolicy(username string, policy *types.ToolPolicy) (*types.User, error) {
+	return c.SetUserToolPolicyContext(context.Background(), username, policy)
+}
+
+// SetUserToolPolicyContext is like SetUserToolPolicy, but the request is bound to the given context.
+func (c *Client) SetUserToolPolicyContext(
+	ctx context.Context, username string, policy *types.ToolPolicy,
+) (*types.User, error) {
+	u, _ := c.constructAPIEndpoint("/users/" + username + "/tool-policy")
+
+	body, err := json.Marshal(policy)
+	if err != nil {
+		return nil, err
+	}
 
-	req, err := c.newRequest(http.MethodGet, u, nil)
+	req, err := c.newRequest(ctx, http.MethodPut, u, bytes.NewBuffer(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request to %s: %w", u, err)
 	}
+	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
 	}
@@ -82,24 +186,67 @@ func (c *Client) ListUsers() ([]*types.User, error) {
 		return nil, c.parseErrorResponse(resp)
 	}
 
-	var users []*types.User
-	if err := json.NewDecoder(resp.Body).Decode(&users); err != nil {
+	var user types.User
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
-	return users, nil
+	return &user, nil
+}
+
+// DeleteUserToolPolicy sends a request to let a user see & invoke all tools via the API again.
+func (c *Client) DeleteUserToolPolicy(username string) error {
+	return c.DeleteUserToolPolicyContext(context.Background(), username)
+}
+
+// DeleteUserToolPolicyContext is like DeleteUserToolPolicy, but the request is bound to the given context.
+func (c *Client) DeleteUserToolPolicyContext(ctx context.Context, username string) error {
+	u, _ := c.constructAPIEndpoint("/users/" + username + "/tool-policy")
+
+	req, err := c.newRequest(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request to %s: %w", u, err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return c.parseErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// ListUsers sends a request to list all users in mcpjungle
+func (c *Client) ListUsers() ([]*types.User, error) {
+	return c.ListUsersContext(context.Background())
+}
+
+// ListUsersContext is like ListUsers, but the request is bound to the given context.
+func (c *Client) ListUsersContext(ctx context.Context) ([]*types.User, error) {
+	u, _ := c.constructAPIEndpoint("/users")
+	return listAll[*types.User](ctx, c, u, nil)
 }
 
 // Whoami sends a request to get information about the user associated with the provided access token
 func (c *Client) Whoami(accessToken string) (*types.User, error) {
+	return c.WhoamiContext(context.Background(), accessToken)
+}
+
+// WhoamiContext is like Whoami, but the request is bound to the given context.
+func (c *Client) WhoamiContext(ctx context.Context, accessToken string) (*types.User, error) {
 	u, _ := c.constructAPIEndpoint("/users/whoami")
 
-	req, err := c.newRequest(http.MethodGet, u, nil)
+	req, err := c.newRequest(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request to %s: %w", u, err)
 	}
 	req.Header.Set("Authorization", "Bearer "+accessToken)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
 	}
@@ -50,7 +50,7 @@ func TestCreateUser(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		createUserRequest := &types.CreateUserRequest{
 			Username: "testuser",
 		}
@@ -72,7 +72,7 @@ func TestCreateUser(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		createUserRequest := &types.CreateUserRequest{
 			Username: "testuser",
 		}
@@ -93,7 +93,7 @@ func TestCreateUser(t *testing.T) {
 	})
 
 	t.Run("network error", func(t *testing.T) {
-		client := NewClient("http://invalid-url", "test-token", &http.Client{})
+		client := NewClient("http://invalid-url", WithToken("test-token"))
 		createUserRequest := &types.CreateUserRequest{
 			Username: "testuser",
 		}
@@ -150,7 +150,7 @@ func TestListUsers(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		users, err := client.ListUsers()
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
@@ -178,7 +178,7 @@ func TestListUsers(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		users, err := client.ListUsers()
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
@@ -196,7 +196,7 @@ func TestListUsers(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		users, err := client.ListUsers()
 
 		if err == nil {
@@ -224,7 +224,7 @@ func TestDeleteUser(t *testing.T) {
 			if r.Method != "DELETE" {
 				t.Errorf("Expected DELETE method, got %s", r.Method)
 			}
-			expectedPath := "/api/v0/users/" + username
+			expectedPath := "/api/v1/users/" + username
 			if !strings.HasSuffix(r.URL.Path, expectedPath) {
 				t.Errorf("Expected path to end with %s, got %s", expectedPath, r.URL.Path)
 			}
@@ -240,7 +240,7 @@ func TestDeleteUser(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		err := client.DeleteUser(username)
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
@@ -254,7 +254,7 @@ func TestDeleteUser(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := NewClient(server.URL, "test-token", &http.Client{})
+		client := NewClient(server.URL, WithToken("test-token"))
 		err := client.DeleteUser("non-existent-user")
 
 		if err == nil {
@@ -268,7 +268,7 @@ func TestDeleteUser(t *testing.T) {
 	})
 
 	t.Run("network error", func(t *testing.T) {
-		client := NewClient("http://invalid-url", "test-token", &http.Client{})
+		client := NewClient("http://invalid-url", WithToken("test-token"))
 		err := client.DeleteUser("testuser")
 
 		if err == nil {
@@ -281,6 +281,112 @@ func TestDeleteUser(t *testing.T) {
 	})
 }
 
+func TestRenewAccessToken(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/users/me/token") {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer old-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid access token"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(types.RenewAccessTokenResponse{Username: "alice", AccessToken: "new-token"})
+	}))
+	defer server.Close()
+
+	resp, err := NewClient(server.URL, WithToken("old-token")).RenewAccessToken()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resp.Username != "alice" || resp.AccessToken != "new-token" {
+		t.Errorf("Unexpected response: %+v", resp)
+	}
+
+	if _, err := NewClient(server.URL, WithToken("wrong-token")).RenewAccessToken(); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+func TestSuspendAndActivateUser(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST method, got %s", r.Method)
+		}
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/users/alice/suspend"):
+			_ = json.NewEncoder(w).Encode(types.User{Username: "alice", Role: "user", Suspended: true})
+		case strings.HasSuffix(r.URL.Path, "/users/alice/activate"):
+			_ = json.NewEncoder(w).Encode(types.User{Username: "alice", Role: "user"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "user not found"})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	user, err := client.SuspendUser("alice")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !user.Suspended {
+		t.Error("Expected the user to be suspended")
+	}
+
+	user, err = client.ActivateUser("alice")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if user.Suspended {
+		t.Error("Expected the user to be active")
+	}
+
+	if _, err := client.SuspendUser("bob"); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+func TestUserToolPolicy(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/users/alice/tool-policy") {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		switch r.Method {
+		case http.MethodPut:
+			var policy types.ToolPolicy
+			if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+				t.Errorf("Failed to decode request body: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(types.User{Username: "alice", Role: "user", ToolPolicy: &policy})
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	user, err := client.SetUserToolPolicy("alice", &types.ToolPolicy{Servers: []string{"github"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if user.ToolPolicy == nil || len(user.ToolPolicy.Servers) != 1 || user.ToolPolicy.Servers[0] != "github" {
+		t.Errorf("Unexpected tool policy: %+v", user.ToolPolicy)
+	}
+
+	if err := client.DeleteUserToolPolicy("alice"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
 func TestCreateUserWithDifferentUsernames(t *testing.T) {
 	t.Parallel()
 
@@ -315,7 +421,7 @@ func TestCreateUserWithDifferentUsernames(t *testing.T) {
 			}))
 			defer server.Close()
 
-			client := NewClient(server.URL, "test-token", &http.Client{})
+			client := NewClient(server.URL, WithToken("test-token"))
 			createUserRequest := &types.CreateUserRequest{
 				Username: tc.username,
 			}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/mcpjungle/mcpjungle/internal/backup"
+	"github.com/mcpjungle/mcpjungle/internal/migrations"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupCmdOutput string
+	backupCmdDBPath string
+
+	restoreCmdFile   string
+	restoreCmdDBPath string
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up the registry data to a file",
+	Long: "Export all the registry data (MCP servers with their tools & prompts, tool groups, MCP clients, users\n" +
+		"and the server configuration) to a portable JSON file, which can be restored into a SQLite or Postgres database.\n\n" +
+		"This command connects to the database directly, so it must be run with the same database configuration\n" +
+		"as the server (DATABASE_URL, the POSTGRES_* environment variables or the SQLite database path).\n\n" +
+		"NOTE: The backup contains the access tokens of users & clients and the credentials of MCP servers in plain text,\n" +
+		"so store it securely.",
+	Args: cobra.NoArgs,
+	RunE: runBackup,
+	Annotations: map[string]string{
+		"group": string(subCommandGroupAdvanced),
+		"order": "12",
+	},
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore the registry data from a backup file",
+	Long: "Import the registry data from a file created by the backup command.\n\n" +
+		"The database must not contain any MCP servers, tool groups, MCP clients or users yet.\n" +
+		"Its schema is migrated to the latest version before the data is restored.\n" +
+		"Stop the mcpjungle server before restoring, then start it again once the restore is complete.",
+	Args: cobra.NoArgs,
+	RunE: runRestore,
+	Annotations: map[string]string{
+		"group": string(subCommandGroupAdvanced),
+		"order": "13",
+	},
+}
+
+func init() {
+	dbPathUsage := fmt.Sprintf(
+		"path of the SQLite database file, used when no Postgres database is configured (overrides env var %s)",
+		DBPathEnvVar,
+	)
+
+	backupCmd.Flags().StringVarP(&backupCmdOutput, "output", "o", "", "path of the backup file to write")
+	_ = backupCmd.MarkFlagRequired("output")
+	backupCmd.Flags().StringVar(&backupCmdDBPath, "db-path", "", dbPathUsage)
+
+	restoreCmd.Flags().StringVarP(&restoreCmdFile, "file", "f", "", "path of the backup file to restore")
+	_ = restoreCmd.MarkFlagRequired("file")
+	restoreCmd.Flags().StringVar(&restoreCmdDBPath, "db-path", "", dbPathUsage)
+
+	rootCmd.AddCommand(backupCmd)
+	rootCmd.AddCommand(restoreCmd)
+}
+
+func runBackup(cmd *cobra.Command, args []string) error {
+	dbConn, err := connectDBForCommand(backupCmdDBPath)
+	if err != nil {
+		return err
+	}
+	if err := migrations.Check(dbConn); err != nil {
+		return fmt.Errorf("the database schema is not up-to-date, run 'mcpjungle migrate up' first: %w", err)
+	}
+
+	b, err := backup.Export(dbConn)
+	if err != nil {
+		return fmt.Errorf("failed to export registry data: %w", err)
+	}
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode backup: %w", err)
+	}
+	// the backup contains credentials, so it is only readable by its owner
+	if err := os.WriteFile(backupCmdOutput, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write backup file: %w", err)
+	}
+
+	cmd.Printf("Backed up %s to %s\n", describeBackup(b), backupCmdOutput)
+	return nil
+}
+
+func runRestore(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(restoreCmdFile)
+	if err != nil {
+		return fmt.Errorf("failed to read backup file: %w", err)
+	}
+	var b backup.Backup
+	if err := json.Unmarshal(data, &b); err != nil {
+		return fmt.Errorf("invalid backup file: %w", err)
+	}
+
+	dbConn, err := connectDBForCommand(restoreCmdDBPath)
+	if err != nil {
+		return err
+	}
+	if err := migrations.Migrate(dbConn); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+	if err := backup.Restore(dbConn, &b); err != nil {
+		if errors.Is(err, backup.ErrRegistryNotEmpty) {
+			return fmt.Errorf("%w, restore into a new database instead", err)
+		}
+		return fmt.Errorf("failed to restore registry data: %w", err)
+	}
+
+	cmd.Printf("Restored %s from %s\n", describeBackup(&b), restoreCmdFile)
+	return nil
+}
+
+// describeBackup summarizes the contents of a backup
+func describeBackup(b *backup.Backup) string {
+	var tools, prompts int
+	for _, s := range b.McpServers {
+		tools += len(s.Tools)
+		prompts += len(s.Prompts)
+	}
+	return fmt.Sprintf(
+		"%d MCP servers (%d tools, %d prompts), %d tool groups, %d MCP clients, %d users and %d teams",
+		len(b.McpServers), tools, prompts, len(b.ToolGroups), len(b.McpClients), len(b.Users), len(b.Teams),
+	)
+}
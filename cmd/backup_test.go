@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/internal/db"
+	"github.com/mcpjungle/mcpjungle/internal/migrations"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestBackupCommandStructure(t *testing.T) {
+	testhelpers.AssertEqual(t, "backup", backupCmd.Use)
+	testhelpers.TestCommandAnnotations(t, backupCmd.Annotations, []testhelpers.CommandAnnotationTest{
+		{Key: "group", Expected: string(subCommandGroupAdvanced)},
+		{Key: "order", Expected: "12"},
+	})
+	testhelpers.AssertNotNil(t, backupCmd.Flags().ShorthandLookup("o"))
+
+	testhelpers.AssertEqual(t, "restore", restoreCmd.Use)
+	testhelpers.TestCommandAnnotations(t, restoreCmd.Annotations, []testhelpers.CommandAnnotationTest{
+		{Key: "group", Expected: string(subCommandGroupAdvanced)},
+		{Key: "order", Expected: "13"},
+	})
+	testhelpers.AssertNotNil(t, restoreCmd.Flags().ShorthandLookup("f"))
+}
+
+func TestRunBackupAndRestore(t *testing.T) {
+	withEnv(map[string]string{DBUrlEnvVar: "", PostgresHostEnvVar: ""}, func() {
+		dir := t.TempDir()
+		srcPath := filepath.Join(dir, "src.db")
+
+		src, err := db.NewSQLiteDBConnection(srcPath, logger.NewNop())
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertNoError(t, migrations.Migrate(src))
+		server, err := model.NewStdioServer("time", "", "uvx", []string{"mcp-server-time"}, nil)
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertNoError(t, src.Create(server).Error)
+		testhelpers.AssertNoError(t, src.Create(&model.Tool{Name: "get_time", ServerID: server.ID}).Error)
+
+		backupCmdDBPath = srcPath
+		backupCmdOutput = filepath.Join(dir, "backup.json")
+		restoreCmdDBPath = filepath.Join(dir, "dst.db")
+		restoreCmdFile = backupCmdOutput
+		defer func() {
+			backupCmdDBPath, backupCmdOutput, restoreCmdDBPath, restoreCmdFile = "", "", "", ""
+		}()
+
+		var out bytes.Buffer
+		backupCmd.SetOut(&out)
+		restoreCmd.SetOut(&out)
+
+		testhelpers.AssertNoError(t, runBackup(backupCmd, nil))
+		testhelpers.AssertStringContains(t, out.String(), "Backed up 1 MCP servers (1 tools, 0 prompts)")
+		info, err := os.Stat(backupCmdOutput)
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, os.FileMode(0o600), info.Mode().Perm())
+
+		out.Reset()
+		testhelpers.AssertNoError(t, runRestore(restoreCmd, nil))
+		testhelpers.AssertStringContains(t, out.String(), "Restored 1 MCP servers (1 tools, 0 prompts)")
+
+		// the registry isn't empty anymore
+		err = runRestore(restoreCmd, nil)
+		testhelpers.AssertError(t, err)
+		testhelpers.AssertStringContains(t, err.Error(), "restore into a new database")
+	})
+}
@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/loadtest"
+	"github.com/mcpjungle/mcpjungle/pkg/version"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// benchViaMCP calls the tools through the streamable http MCP proxy, like MCP clients do
+	benchViaMCP = "mcp"
+	// benchViaAPI calls the tools through the REST API, like 'mcpjungle invoke' does
+	benchViaAPI = "api"
+)
+
+var (
+	benchCmdInput       string
+	benchCmdGroupName   string
+	benchCmdVia         string
+	benchCmdAccessToken string
+	benchCmdRate        float64
+	benchCmdConcurrency int
+	benchCmdDuration    time.Duration
+	benchCmdRequests    int
+	benchCmdTimeout     time.Duration
+)
+
+// errToolCallFailed is reported for the calls whose result is a tool error
+var errToolCallFailed = errors.New("tool returned an error")
+
+var benchCmd = &cobra.Command{
+	Use:   "bench <tool>",
+	Short: "Load-test tool calls through mcpjungle",
+	Long: "Calls a tool repeatedly at the given rate & concurrency and reports the latency & errors of the calls,\n" +
+		"so the performance of mcpjungle can be measured and compared between releases.\n\n" +
+		"By default the tool is called through the MCP proxy (or a tool group's endpoint with --group),\n" +
+		"over a single MCP session like most MCP clients. Use --via api to call it through the REST API instead.\n\n" +
+		"The benchmark runs for --duration or until --requests calls were made, whichever comes first.\n" +
+		"Calls that fail and calls whose result is a tool error are both counted as errors.\n\n" +
+		"In enterprise mode, supply the access token of an MCP client via the --access-token flag or the\n" +
+		fmt.Sprintf("%s environment variable.\n\n", BridgeAccessTokenEnvVar) +
+		"NOTE: the tool is really called, so prefer a tool without side effects.",
+	Example: `  # call a tool as fast as 10 concurrent clients can for 30 seconds
+  mcpjungle bench time__get_current_time --input '{"timezone": "UTC"}'
+
+  # make 1000 calls at 50 calls per second through a tool group
+  mcpjungle bench time__get_current_time --group claude-tools --rate 50 --requests 1000`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBench,
+	Annotations: map[string]string{
+		"group": string(subCommandGroupAdvanced),
+		"order": "23",
+	},
+}
+
+func init() {
+	benchCmd.Flags().StringVar(&benchCmdInput, "input", "{}", "valid JSON payload sent with every call")
+	benchCmd.Flags().StringVar(&benchCmdGroupName, "group", "", "call the tool through a tool group's MCP endpoint")
+	benchCmd.Flags().StringVar(
+		&benchCmdVia,
+		"via",
+		benchViaMCP,
+		fmt.Sprintf("how the tool is called, one of: %s, %s", benchViaMCP, benchViaAPI),
+	)
+	benchCmd.Flags().StringVar(
+		&benchCmdAccessToken,
+		"access-token",
+		"",
+		fmt.Sprintf("MCP client access token (overrides env var %s)", BridgeAccessTokenEnvVar),
+	)
+	benchCmd.Flags().Float64Var(
+		&benchCmdRate, "rate", 0, "number of calls started per second (default: as fast as the concurrency allows)",
+	)
+	benchCmd.Flags().IntVar(&benchCmdConcurrency, "concurrency", 10, "maximum number of calls in flight")
+	benchCmd.Flags().DurationVar(&benchCmdDuration, "duration", 30*time.Second, "how long to make calls for, eg- 1m")
+	benchCmd.Flags().IntVar(&benchCmdRequests, "requests", 0, "total number of calls to make (default: no limit)")
+	benchCmd.Flags().DurationVar(
+		&benchCmdTimeout, "timeout", 0, "maximum time to wait for each call, eg- 10s (default: no timeout)",
+	)
+	addOutputFlag(benchCmd)
+
+	rootCmd.AddCommand(benchCmd)
+}
+
+// newMCPBenchCall connects to the given MCP endpoint and returns a function that calls the tool over that session,
+// along with a function that closes the session.
+func newMCPBenchCall(ctx context.Context, endpoint, accessToken, tool string, input map[string]any) (loadtest.CallFunc, func(), error) {
+	c, err := newBenchClient(ctx, endpoint, accessToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	call := func(ctx context.Context) error {
+		req := mcp.CallToolRequest{}
+		req.Params.Name = tool
+		req.Params.Arguments = input
+		res, err := c.CallTool(ctx, req)
+		if err != nil {
+			return err
+		}
+		if res.IsError {
+			return errToolCallFailed
+		}
+		return nil
+	}
+	return call, func() { _ = c.Close() }, nil
+}
+
+// newAPIBenchCall returns a function that calls the tool through the REST API.
+func newAPIBenchCall(tool string, input map[string]any) loadtest.CallFunc {
+	return func(ctx context.Context) error {
+		res, err := apiClient.InvokeToolContext(ctx, tool, input)
+		if err != nil {
+			return err
+		}
+		if res.IsError {
+			return errToolCallFailed
+		}
+		return nil
+	}
+}
+
+// withCallTimeout bounds every call made by the given function to the timeout, if any.
+func withCallTimeout(call loadtest.CallFunc, timeout time.Duration) loadtest.CallFunc {
+	if timeout <= 0 {
+		return call
+	}
+	return func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return call(ctx)
+	}
+}
+
+func runBench(cmd *cobra.Command, args []string) error {
+	tool := args[0]
+
+	var input map[string]any
+	if err := json.Unmarshal([]byte(benchCmdInput), &input); err != nil {
+		return fmt.Errorf("invalid input: %w", err)
+	}
+
+	conf := loadtest.Config{
+		Rate:        benchCmdRate,
+		Concurrency: benchCmdConcurrency,
+		Duration:    benchCmdDuration,
+		Requests:    benchCmdRequests,
+	}
+	// --requests alone makes the benchmark run until all the calls were made
+	if cmd.Flags().Changed("requests") && !cmd.Flags().Changed("duration") {
+		conf.Duration = 0
+	}
+	if err := conf.Validate(); err != nil {
+		return fmt.Errorf("invalid benchmark: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var call loadtest.CallFunc
+	switch benchCmdVia {
+	case benchViaMCP:
+		accessToken := benchCmdAccessToken
+		if accessToken == "" {
+			accessToken = os.Getenv(BridgeAccessTokenEnvVar)
+		}
+		endpoint, err := proxyStreamableHTTPEndpoint(apiClient.BaseURL())
+		if benchCmdGroupName != "" {
+			endpoint, err = groupStreamableHTTPEndpoint(apiClient.BaseURL(), benchCmdGroupName)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to construct MCP endpoint: %w", err)
+		}
+
+		var closeSession func()
+		call, closeSession, err = newMCPBenchCall(ctx, endpoint, accessToken, tool, input)
+		if err != nil {
+			return fmt.Errorf("failed to connect to %s: %w", endpoint, err)
+		}
+		defer closeSession()
+	case benchViaAPI:
+		if benchCmdGroupName != "" {
+			return fmt.Errorf("--group is only supported with --via %s", benchViaMCP)
+		}
+		call = newAPIBenchCall(tool, input)
+	default:
+		return fmt.Errorf("invalid value for --via flag: '%s', expected one of %s, %s", benchCmdVia, benchViaMCP, benchViaAPI)
+	}
+
+	cmd.PrintErrf("Calling %s via %s, press Ctrl+C to stop early\n", tool, benchCmdVia)
+	report, err := loadtest.Run(ctx, conf, withCallTimeout(call, benchCmdTimeout))
+	if err != nil {
+		return err
+	}
+
+	if ok, err := printStructured(cmd, report); ok || err != nil {
+		return err
+	}
+	printBenchReport(cmd, report)
+	return nil
+}
+
+func printBenchReport(cmd *cobra.Command, r *loadtest.Report) {
+	cmd.Printf("Calls:       %d (%d failed, %.1f%% error rate)\n", r.Calls, r.Errors, r.ErrorRate()*100)
+	cmd.Printf("Duration:    %s\n", time.Duration(r.ElapsedSeconds*float64(time.Second)).Round(time.Millisecond))
+	cmd.Printf("Throughput:  %.1f calls/s\n", r.Throughput)
+	if r.Calls == 0 {
+		return
+	}
+	l := r.Latency
+	cmd.Printf(
+		"Latency:     min %.1fms, mean %.1fms, p50 %.1fms, p90 %.1fms, p99 %.1fms, max %.1fms\n",
+		l.Min, l.Mean, l.P50, l.P90, l.P99, l.Max,
+	)
+
+	if len(r.ErrorMessages) == 0 {
+		return
+	}
+	// most frequent errors first
+	messages := make([]string, 0, len(r.ErrorMessages))
+	for msg := range r.ErrorMessages {
+		messages = append(messages, msg)
+	}
+	slices.SortFunc(messages, func(a, b string) int {
+		if r.ErrorMessages[a] != r.ErrorMessages[b] {
+			return r.ErrorMessages[b] - r.ErrorMessages[a]
+		}
+		return strings.Compare(a, b)
+	})
+	cmd.Println()
+	cmd.Println("Errors:")
+	for _, msg := range messages {
+		cmd.Printf("  %6d  %s\n", r.ErrorMessages[msg], msg)
+	}
+	if r.OtherErrors > 0 {
+		cmd.Printf("  %6d  (other errors)\n", r.OtherErrors)
+	}
+}
+
+// newBenchClient creates and initializes an MCP client connected to the given endpoint.
+func newBenchClient(ctx context.Context, endpoint, accessToken string) (*client.Client, error) {
+	var opts []transport.StreamableHTTPCOption
+	if accessToken != "" {
+		o := transport.WithHTTPHeaders(map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		})
+		opts = append(opts, o)
+	}
+
+	c, err := client.NewStreamableHttpClient(endpoint, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create streamable HTTP client: %w", err)
+	}
+	if err := c.Start(ctx); err != nil {
+		return nil, fmt.Errorf("failed to start streamable HTTP client: %w", err)
+	}
+
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{
+		Name:    "mcpjungle bench",
+		Version: version.GetVersion(),
+	}
+	initRequest.Params.Capabilities = mcp.ClientCapabilities{}
+
+	if _, err := c.Initialize(ctx, initRequest); err != nil {
+		_ = c.Close()
+		return nil, fmt.Errorf("failed to initialize connection with %s: %w", endpoint, err)
+	}
+	return c, nil
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/loadtest"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/spf13/cobra"
+)
+
+func TestBenchCommandStructure(t *testing.T) {
+	t.Parallel()
+
+	testhelpers.AssertEqual(t, "bench <tool>", benchCmd.Use)
+	testhelpers.AssertNotNil(t, benchCmd.RunE)
+	testhelpers.TestCommandAnnotations(t, benchCmd.Annotations, []testhelpers.CommandAnnotationTest{
+		{Key: "group", Expected: string(subCommandGroupAdvanced)},
+		{Key: "order", Expected: "23"},
+	})
+
+	for _, flag := range []string{"input", "group", "via", "access-token", "rate", "concurrency", "duration", "requests", "timeout", "output"} {
+		testhelpers.AssertNotNil(t, benchCmd.Flags().Lookup(flag))
+	}
+}
+
+func TestMCPBenchCall(t *testing.T) {
+	t.Parallel()
+
+	proxy := server.NewMCPServer("proxy", "test")
+	proxy.AddTool(
+		mcp.NewTool("time__get_current_time"),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if request.GetArguments()["timezone"] != "UTC" {
+				return mcp.NewToolResultError("unknown timezone"), nil
+			}
+			return mcp.NewToolResultText("12:00"), nil
+		},
+	)
+	streamable := server.NewStreamableHTTPServer(proxy)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		streamable.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	conf := loadtest.Config{Concurrency: 4, Requests: 20}
+
+	call, closeSession, err := newMCPBenchCall(ctx, ts.URL, "secret", "time__get_current_time", map[string]any{"timezone": "UTC"})
+	testhelpers.AssertNoError(t, err)
+	defer closeSession()
+	report, err := loadtest.Run(ctx, conf, call)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 20, report.Calls)
+	testhelpers.AssertEqual(t, 0, report.Errors)
+
+	// tool errors are counted as failed calls
+	call, closeSession, err = newMCPBenchCall(ctx, ts.URL, "secret", "time__get_current_time", nil)
+	testhelpers.AssertNoError(t, err)
+	defer closeSession()
+	report, err = loadtest.Run(ctx, conf, call)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 20, report.Errors)
+	testhelpers.AssertEqual(t, 20, report.ErrorMessages[errToolCallFailed.Error()])
+
+	_, _, err = newMCPBenchCall(ctx, ts.URL, "wrong", "time__get_current_time", nil)
+	testhelpers.AssertError(t, err)
+}
+
+func TestPrintBenchReport(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+
+	printBenchReport(cmd, &loadtest.Report{
+		Calls:          200,
+		Errors:         3,
+		ElapsedSeconds: 2,
+		Throughput:     100,
+		Latency:        loadtest.Latency{Min: 1, Mean: 4.25, P50: 4, P90: 7, P99: 12.5, Max: 20},
+		ErrorMessages:  map[string]int{"timeout": 1, "tool returned an error": 2},
+	})
+	testhelpers.AssertStringContains(t, out.String(), "Calls:       200 (3 failed, 1.5% error rate)")
+	testhelpers.AssertStringContains(t, out.String(), "Duration:    2s")
+	testhelpers.AssertStringContains(t, out.String(), "Throughput:  100.0 calls/s")
+	testhelpers.AssertStringContains(t, out.String(), "p50 4.0ms, p90 7.0ms, p99 12.5ms, max 20.0ms")
+	testhelpers.AssertStringContains(t, out.String(), "       2  tool returned an error\n       1  timeout\n")
+}
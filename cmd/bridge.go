@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/mcpjungle/mcpjungle/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// BridgeAccessTokenEnvVar is the environment variable from which the bridge reads the MCP client access token
+// if it is not supplied via the --access-token flag.
+const BridgeAccessTokenEnvVar = "MCPJUNGLE_CLIENT_ACCESS_TOKEN"
+
+var (
+	bridgeCmdGroupName   string
+	bridgeCmdAccessToken string
+)
+
+var bridgeCmd = &cobra.Command{
+	Use:   "bridge",
+	Short: "Serve a tool group over stdio",
+	Long: "Relays MCP messages between stdio and a tool group's streamable http endpoint, exactly like\n" +
+		"'mcpjungle connect stdio --group', which it is a shortcut for.\n" +
+		"This allows MCP clients that only support the stdio transport to consume tool groups.\n\n" +
+		"In enterprise mode, supply the access token of an MCP client that is allowed to access the group's servers\n" +
+		fmt.Sprintf("via the --access-token flag or the %s environment variable.", BridgeAccessTokenEnvVar),
+	Example: `  # Serve the "claude-tools" group over stdio
+  mcpjungle bridge --group claude-tools
+
+  # Serve a group from a remote mcpjungle server in enterprise mode
+  MCPJUNGLE_CLIENT_ACCESS_TOKEN=<token> mcpjungle bridge --group claude-tools --registry https://mcpjungle.example.com`,
+	Args: cobra.NoArgs,
+	RunE: runBridge,
+	Annotations: map[string]string{
+		"group": string(subCommandGroupAdvanced),
+		"order": "9",
+	},
+}
+
+func init() {
+	bridgeCmd.Flags().StringVar(&bridgeCmdGroupName, "group", "", "name of the tool group to serve")
+	bridgeCmd.Flags().StringVar(
+		&bridgeCmdAccessToken,
+		"access-token",
+		"",
+		fmt.Sprintf("MCP client access token (overrides env var %s)", BridgeAccessTokenEnvVar),
+	)
+	_ = bridgeCmd.MarkFlagRequired("group")
+
+	rootCmd.AddCommand(bridgeCmd)
+}
+
+// groupStreamableHTTPEndpoint returns the streamable http endpoint of a tool group on the given registry server.
+func groupStreamableHTTPEndpoint(registryURL, groupName string) (string, error) {
+	return url.JoinPath(registryURL, api.V0PathPrefix, "groups", url.PathEscape(groupName), "mcp")
+}
+
+// runBridge relays the MCP messages of a tool group like 'connect stdio --group', which it is a shortcut for.
+func runBridge(cmd *cobra.Command, args []string) error {
+	endpoint, err := groupStreamableHTTPEndpoint(apiClient.BaseURL(), bridgeCmdGroupName)
+	if err != nil {
+		return fmt.Errorf("failed to construct endpoint of tool group %s: %w", bridgeCmdGroupName, err)
+	}
+	return relayStdio(cmd, endpoint, clientAccessToken(bridgeCmdAccessToken))
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestBridgeCommandStructure(t *testing.T) {
+	t.Parallel()
+
+	testhelpers.AssertEqual(t, "bridge", bridgeCmd.Use)
+	testhelpers.AssertEqual(t, "Serve a tool group over stdio", bridgeCmd.Short)
+	testhelpers.AssertTrue(t, len(bridgeCmd.Long) > 0, "Long description should not be empty")
+
+	annotationTests := []testhelpers.CommandAnnotationTest{
+		{Key: "group", Expected: string(subCommandGroupAdvanced)},
+		{Key: "order", Expected: "9"},
+	}
+	testhelpers.TestCommandAnnotations(t, bridgeCmd.Annotations, annotationTests)
+
+	testhelpers.AssertNotNil(t, bridgeCmd.RunE)
+	testhelpers.AssertNotNil(t, bridgeCmd.Flags().Lookup("group"))
+	testhelpers.AssertNotNil(t, bridgeCmd.Flags().Lookup("access-token"))
+}
+
+func TestGroupStreamableHTTPEndpoint(t *testing.T) {
+	t.Parallel()
+
+	endpoint, err := groupStreamableHTTPEndpoint("http://127.0.0.1:8080", "claude-tools")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, "http://127.0.0.1:8080/v0/groups/claude-tools/mcp", endpoint)
+
+	endpoint, err = groupStreamableHTTPEndpoint("https://mcpjungle.example.com/", "g1")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, "https://mcpjungle.example.com/v0/groups/g1/mcp", endpoint)
+}
@@ -2,14 +2,23 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 
 	"gopkg.in/yaml.v3"
 )
 
 const ClientConfigFileName = ".mcpjungle.conf"
 
+// DefaultProfile is the name of the profile made of the top-level registry_url & access_token of the configuration.
+const DefaultProfile = "default"
+
+// ErrProfileNotFound is returned when a profile does not exist in the client configuration.
+var ErrProfileNotFound = errors.New("profile not found")
+
 // ClientConfig represents the MCPJungle client configuration stored in the user's home directory.
 // It can contain configuration for both a standard user and an admin user.
 type ClientConfig struct {
@@ -17,6 +26,70 @@ type ClientConfig struct {
 	RegistryURL string `yaml:"registry_url"`
 	// AccessToken is the access token used for authentication with the MCPJungle server.
 	AccessToken string `yaml:"access_token"`
+
+	// CurrentProfile is the profile used when none is selected with the --profile flag.
+	// Empty means the default profile.
+	CurrentProfile string `yaml:"current_profile,omitempty"`
+	// Profiles are additional named registries, eg- to switch between dev & prod gateways.
+	Profiles map[string]Profile `yaml:"profiles,omitempty"`
+}
+
+// Profile is a registry server along with the access token to authenticate with it.
+type Profile struct {
+	RegistryURL string `yaml:"registry_url"`
+	AccessToken string `yaml:"access_token"`
+}
+
+// ProfileName returns the name of the profile to use: the given name if not empty, otherwise the current profile.
+func (c *ClientConfig) ProfileName(name string) string {
+	if name != "" {
+		return name
+	}
+	if c.CurrentProfile != "" {
+		return c.CurrentProfile
+	}
+	return DefaultProfile
+}
+
+// Profile returns the profile with the given name, or the current profile if the name is empty.
+// The default profile always exists, even if it is empty.
+func (c *ClientConfig) Profile(name string) (Profile, error) {
+	name = c.ProfileName(name)
+	if name == DefaultProfile {
+		return Profile{RegistryURL: c.RegistryURL, AccessToken: c.AccessToken}, nil
+	}
+	p, ok := c.Profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("%w: '%s'", ErrProfileNotFound, name)
+	}
+	return p, nil
+}
+
+// SetProfile stores a profile under the given name, or under the current profile if the name is empty.
+// The profile is created if it does not exist.
+func (c *ClientConfig) SetProfile(name string, p Profile) {
+	name = c.ProfileName(name)
+	if name == DefaultProfile {
+		c.RegistryURL = p.RegistryURL
+		c.AccessToken = p.AccessToken
+		return
+	}
+	if c.Profiles == nil {
+		c.Profiles = make(map[string]Profile)
+	}
+	c.Profiles[name] = p
+}
+
+// ProfileNames returns the names of all the profiles, sorted, starting with the default profile.
+func (c *ClientConfig) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles)+1)
+	for name := range c.Profiles {
+		if name != DefaultProfile {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return append([]string{DefaultProfile}, names...)
 }
 
 // AbsPath returns the absolute path to the client configuration file.
@@ -32,16 +105,21 @@ func AbsPath() (string, error) {
 
 // Save saves the ClientConfig to the file system at AbsPath().
 // If the file does not exist, this method creates it.
+// Since the config contains the access token, the file is only readable & writable by its owner.
 func Save(c *ClientConfig) error {
 	path, err := AbsPath()
 	if err != nil {
 		return err
 	}
-	f, err := os.Create(path)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
+	// the file may have been created with looser permissions by an older version of mcpjungle
+	if err := f.Chmod(0o600); err != nil {
+		return err
+	}
 
 	encoder := yaml.NewEncoder(f)
 	defer encoder.Close()
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
@@ -314,3 +316,71 @@ func TestConfigIntegration(t *testing.T) {
 		}
 	})
 }
+
+func TestSaveFilePermissions(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+
+	path, err := AbsPath()
+	if err != nil {
+		t.Fatalf("AbsPath returned error: %v", err)
+	}
+	// a config file created by an older version with looser permissions
+	if err := os.WriteFile(path, []byte("access_token: old\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	if err := Save(&ClientConfig{AccessToken: "secret"}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat config file: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("Expected config file permissions to be 0600, got %o", perm)
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cfg := &ClientConfig{RegistryURL: "http://localhost:8080", AccessToken: "dev-token"}
+	cfg.SetProfile("prod", Profile{RegistryURL: "https://mcpjungle.example.com", AccessToken: "prod-token"})
+	cfg.CurrentProfile = "prod"
+	if err := Save(cfg); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	cfg = Load()
+
+	p, err := cfg.Profile("")
+	if err != nil {
+		t.Fatalf("Profile returned error: %v", err)
+	}
+	if p.AccessToken != "prod-token" || p.RegistryURL != "https://mcpjungle.example.com" {
+		t.Errorf("Expected the current profile to be prod, got %+v", p)
+	}
+
+	p, err = cfg.Profile(DefaultProfile)
+	if err != nil {
+		t.Fatalf("Profile returned error: %v", err)
+	}
+	if p.AccessToken != "dev-token" || p.RegistryURL != "http://localhost:8080" {
+		t.Errorf("Expected the default profile to be the top-level values, got %+v", p)
+	}
+
+	if _, err := cfg.Profile("staging"); !errors.Is(err, ErrProfileNotFound) {
+		t.Errorf("Expected ErrProfileNotFound for an unknown profile, got %v", err)
+	}
+
+	cfg.SetProfile(DefaultProfile, Profile{RegistryURL: "http://127.0.0.1:8080", AccessToken: "new-dev-token"})
+	if cfg.AccessToken != "new-dev-token" || cfg.RegistryURL != "http://127.0.0.1:8080" {
+		t.Errorf("Expected the default profile to update the top-level values, got %+v", cfg)
+	}
+
+	cfg.SetProfile("staging", Profile{AccessToken: "staging-token"})
+	names := cfg.ProfileNames()
+	if strings.Join(names, ",") != "default,prod,staging" {
+		t.Errorf("Expected profiles default,prod,staging, got %v", names)
+	}
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// confirm describes what a destructive command is about to remove and asks the user to confirm it.
+// Anything but "y" or "yes" aborts, including an empty or closed standard input,
+// so that scripts must explicitly pass --yes to skip the confirmation.
+// The prompt is written to stderr so that it is still shown with --quiet.
+func confirm(cmd *cobra.Command, description string) error {
+	w := cmd.ErrOrStderr()
+	fmt.Fprintln(w, description)
+	fmt.Fprint(w, "Do you want to continue? [y/N]: ")
+
+	line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	if line == "" {
+		// stdin is closed, end the prompt's line
+		fmt.Fprintln(w)
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return nil
+	}
+	return fmt.Errorf("aborted, nothing was removed (pass --yes to skip the confirmation)")
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/spf13/cobra"
+)
+
+func TestConfirm(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		confirm bool
+	}{
+		{input: "y\n", confirm: true},
+		{input: "YES\n", confirm: true},
+		{input: " yes ", confirm: true},
+		{input: "n\n", confirm: false},
+		{input: "\n", confirm: false},
+		{input: "", confirm: false},
+	}
+	for _, tt := range tests {
+		cmd := &cobra.Command{}
+		var stderr bytes.Buffer
+		cmd.SetIn(strings.NewReader(tt.input))
+		cmd.SetErr(&stderr)
+
+		err := confirm(cmd, "Tool group 'claude' will be deleted.")
+		testhelpers.AssertStringContains(t, stderr.String(), "Tool group 'claude' will be deleted.\nDo you want to continue? [y/N]: ")
+		if tt.confirm {
+			testhelpers.AssertNoError(t, err)
+		} else {
+			testhelpers.AssertError(t, err)
+			testhelpers.AssertStringContains(t, err.Error(), "pass --yes")
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/spf13/cobra"
+)
+
+// maxStdioMessageSize is the maximum size of a JSON-RPC message read from stdin.
+// Tool call arguments can be large, eg- file contents, so this is well above bufio's default of 64KB.
+const maxStdioMessageSize = 10 * 1024 * 1024
+
+var (
+	connectStdioCmdGroupName   string
+	connectStdioCmdAccessToken string
+)
+
+var connectCmd = &cobra.Command{
+	Use:   "connect",
+	Short: "Connect local MCP clients to the MCP proxy",
+	Annotations: map[string]string{
+		"group": string(subCommandGroupAdvanced),
+		"order": "18",
+	},
+}
+
+var connectStdioCmd = &cobra.Command{
+	Use:   "stdio",
+	Short: "Relay MCP messages between stdio and the MCP proxy",
+	Long: "Speaks MCP over stdio on the local side and forwards every message to the registry's MCP proxy\n" +
+		"(or a tool group's endpoint with --group) over streamable http, relaying the responses & notifications back.\n" +
+		"This lets any MCP client that only supports the stdio transport use mcpjungle, with tools, prompts & " +
+		"everything else the proxy serves.\n\n" +
+		"In enterprise mode, supply the access token of an MCP client via the --access-token flag or the\n" +
+		fmt.Sprintf("%s environment variable.\n\n", BridgeAccessTokenEnvVar) +
+		"NOTE: requests sent by the proxy to the client (eg- sampling) are not supported.",
+	Example: `  # in the MCP configuration of a stdio-only client
+  {
+    "command": "mcpjungle",
+    "args": ["connect", "stdio", "--registry", "https://mcpjungle.example.com"],
+    "env": {"MCPJUNGLE_CLIENT_ACCESS_TOKEN": "<token>"}
+  }
+
+  # only expose the "claude-tools" group
+  mcpjungle connect stdio --group claude-tools`,
+	Args: cobra.NoArgs,
+	RunE: runConnectStdio,
+}
+
+func init() {
+	connectStdioCmd.Flags().StringVar(
+		&connectStdioCmdGroupName,
+		"group",
+		"",
+		"name of a tool group to connect to, instead of the whole MCP proxy",
+	)
+	connectStdioCmd.Flags().StringVar(
+		&connectStdioCmdAccessToken,
+		"access-token",
+		"",
+		fmt.Sprintf("MCP client access token (overrides env var %s)", BridgeAccessTokenEnvVar),
+	)
+
+	connectCmd.AddCommand(connectStdioCmd)
+	rootCmd.AddCommand(connectCmd)
+}
+
+// proxyStreamableHTTPEndpoint returns the streamable http endpoint of the MCP proxy on the given registry server.
+func proxyStreamableHTTPEndpoint(registryURL string) (string, error) {
+	return url.JoinPath(registryURL, "mcp")
+}
+
+func runConnectStdio(cmd *cobra.Command, args []string) error {
+	var endpoint string
+	var err error
+	if connectStdioCmdGroupName != "" {
+		endpoint, err = groupStreamableHTTPEndpoint(apiClient.BaseURL(), connectStdioCmdGroupName)
+	} else {
+		endpoint, err = proxyStreamableHTTPEndpoint(apiClient.BaseURL())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to construct MCP endpoint: %w", err)
+	}
+	return relayStdio(cmd, endpoint, clientAccessToken(connectStdioCmdAccessToken))
+}
+
+// clientAccessToken returns the MCP client access token supplied via a flag, or else via the environment.
+func clientAccessToken(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	return os.Getenv(BridgeAccessTokenEnvVar)
+}
+
+// relayStdio relays the MCP messages between stdio and the given streamable http endpoint until stdin is closed
+// or the process is interrupted.
+func relayStdio(cmd *cobra.Command, endpoint, accessToken string) error {
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	f, err := newStdioForwarder(ctx, endpoint, accessToken, os.Stdout)
+	if err != nil {
+		return err
+	}
+	defer f.close()
+
+	// stdout is reserved for the MCP protocol, so all diagnostics must go to stderr
+	cmd.PrintErrf("Relaying MCP messages between stdio and %s\n", endpoint)
+
+	if err := f.run(ctx, cmd.InOrStdin()); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("stdio connection stopped: %w", err)
+	}
+	return nil
+}
+
+// stdioForwarder relays JSON-RPC messages between a local MCP client speaking over stdio
+// and a streamable http MCP endpoint.
+type stdioForwarder struct {
+	upstream *transport.StreamableHTTP
+
+	// mu serializes the messages written to out, one per line
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// newStdioForwarder creates a forwarder to the given endpoint, writing the messages received from it to out.
+func newStdioForwarder(ctx context.Context, endpoint, accessToken string, out io.Writer) (*stdioForwarder, error) {
+	var opts []transport.StreamableHTTPCOption
+	if accessToken != "" {
+		opts = append(opts, transport.WithHTTPHeaders(map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}))
+	}
+	upstream, err := transport.NewStreamableHTTP(endpoint, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create streamable HTTP transport: %w", err)
+	}
+	if err := upstream.Start(ctx); err != nil {
+		return nil, fmt.Errorf("failed to start streamable HTTP transport: %w", err)
+	}
+
+	f := &stdioForwarder{upstream: upstream, out: out}
+	upstream.SetNotificationHandler(func(n mcp.JSONRPCNotification) {
+		f.write(n)
+	})
+	return f, nil
+}
+
+// run forwards the messages read from in, one per line, until in is exhausted or the context is cancelled.
+// Requests are forwarded concurrently, except for initialize which must complete before anything else is sent.
+func (f *stdioForwarder) run(ctx context.Context, in io.Reader) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxStdioMessageSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var msg struct {
+			ID     *mcp.RequestId  `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if err := json.Unmarshal(line, &msg); err != nil {
+			f.write(transport.NewJSONRPCErrorResponse(mcp.NewRequestId(nil), mcp.PARSE_ERROR, err.Error(), nil))
+			continue
+		}
+
+		switch {
+		case msg.Method == "":
+			// a response to a request sent by the server, which is never sent by the proxy
+			continue
+		case msg.ID == nil:
+			var n mcp.JSONRPCNotification
+			if err := json.Unmarshal(line, &n); err != nil {
+				continue
+			}
+			if err := f.upstream.SendNotification(ctx, n); err != nil && ctx.Err() == nil {
+				return fmt.Errorf("failed to forward notification %s: %w", n.Method, err)
+			}
+		default:
+			req := transport.JSONRPCRequest{JSONRPC: mcp.JSONRPC_VERSION, ID: *msg.ID, Method: msg.Method}
+			// a nil params field would be sent as null, which servers may reject
+			if len(msg.Params) > 0 {
+				req.Params = msg.Params
+			}
+			if req.Method == string(mcp.MethodInitialize) {
+				f.forwardRequest(ctx, req)
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				f.forwardRequest(ctx, req)
+			}()
+		}
+	}
+	return scanner.Err()
+}
+
+// forwardRequest sends a request upstream and writes its response.
+// If the request can't be sent, an error response is written instead so that the client isn't left waiting.
+func (f *stdioForwarder) forwardRequest(ctx context.Context, req transport.JSONRPCRequest) {
+	resp, err := f.upstream.SendRequest(ctx, req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		resp = transport.NewJSONRPCErrorResponse(req.ID, mcp.INTERNAL_ERROR, err.Error(), nil)
+	}
+
+	// the negotiated protocol version must be sent along with all subsequent requests
+	if req.Method == string(mcp.MethodInitialize) && resp.Error == nil {
+		var result mcp.InitializeResult
+		if err := json.Unmarshal(resp.Result, &result); err == nil && result.ProtocolVersion != "" {
+			f.upstream.SetProtocolVersion(result.ProtocolVersion)
+		}
+	}
+	f.write(resp)
+}
+
+// write writes a message on its own line.
+func (f *stdioForwarder) write(msg any) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, _ = f.out.Write(append(data, '\n'))
+}
+
+func (f *stdioForwarder) close() {
+	_ = f.upstream.Close()
+}
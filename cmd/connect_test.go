@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestConnectCommandStructure(t *testing.T) {
+	t.Parallel()
+
+	annotationTests := []testhelpers.CommandAnnotationTest{
+		{Key: "group", Expected: string(subCommandGroupAdvanced)},
+		{Key: "order", Expected: "18"},
+	}
+	testhelpers.TestCommandAnnotations(t, connectCmd.Annotations, annotationTests)
+
+	testhelpers.AssertEqual(t, "stdio", connectStdioCmd.Use)
+	testhelpers.AssertEqual(t, connectCmd, connectStdioCmd.Parent())
+	testhelpers.AssertNotNil(t, connectStdioCmd.RunE)
+	testhelpers.AssertNotNil(t, connectStdioCmd.Flags().Lookup("group"))
+	testhelpers.AssertNotNil(t, connectStdioCmd.Flags().Lookup("access-token"))
+}
+
+func TestProxyStreamableHTTPEndpoint(t *testing.T) {
+	t.Parallel()
+
+	endpoint, err := proxyStreamableHTTPEndpoint("https://mcpjungle.example.com/")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, "https://mcpjungle.example.com/mcp", endpoint)
+}
+
+func TestStdioForwarder(t *testing.T) {
+	t.Parallel()
+
+	// fake proxy endpoint that requires a bearer token
+	proxy := server.NewMCPServer("proxy", "test", server.WithPromptCapabilities(false))
+	proxy.AddTool(
+		mcp.NewTool("time__get_current_time", mcp.WithDescription("Get current time")),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("12:00"), nil
+		},
+	)
+	proxy.AddPrompt(
+		mcp.NewPrompt("greeting"),
+		func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			return mcp.NewGetPromptResult("greeting", []mcp.PromptMessage{
+				mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent("hello")),
+			}), nil
+		},
+	)
+	streamable := server.NewStreamableHTTPServer(proxy)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		streamable.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	var out bytes.Buffer
+	f, err := newStdioForwarder(ctx, ts.URL, "secret", &out)
+	testhelpers.AssertNoError(t, err)
+	defer f.close()
+
+	in := strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26",` +
+			`"capabilities":{},"clientInfo":{"name":"test","version":"1.0"}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"time__get_current_time"}}`,
+		`{"jsonrpc":"2.0","id":"p","method":"prompts/get","params":{"name":"greeting"}}`,
+		`not json`,
+	}, "\n")
+	testhelpers.AssertNoError(t, f.run(ctx, strings.NewReader(in)))
+
+	// responses to concurrent requests may be written in any order
+	responses := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var msg struct {
+			ID     json.RawMessage `json:"id"`
+			Result json.RawMessage `json:"result"`
+			Error  *struct {
+				Code int `json:"code"`
+			} `json:"error"`
+		}
+		testhelpers.AssertNoError(t, json.Unmarshal([]byte(line), &msg))
+		if msg.Error != nil {
+			responses[string(msg.ID)] = "error"
+			continue
+		}
+		responses[string(msg.ID)] = string(msg.Result)
+	}
+
+	testhelpers.AssertEqual(t, 4, len(responses))
+	testhelpers.AssertStringContains(t, responses["1"], `"serverInfo":{"name":"proxy"`)
+	testhelpers.AssertStringContains(t, responses["2"], `"text":"12:00"`)
+	testhelpers.AssertStringContains(t, responses[`"p"`], `"text":"hello"`)
+	testhelpers.AssertEqual(t, "error", responses["null"])
+}
+
+func TestStdioForwarderUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error": "invalid MCP client token"}`))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	var out bytes.Buffer
+	f, err := newStdioForwarder(ctx, ts.URL, "wrong", &out)
+	testhelpers.AssertNoError(t, err)
+	defer f.close()
+
+	in := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`
+	testhelpers.AssertNoError(t, f.run(ctx, strings.NewReader(in)))
+
+	// the client gets an error response rather than waiting forever
+	testhelpers.AssertStringContains(t, out.String(), `"id":1,"error":{"code":-32603`)
+	testhelpers.AssertStringContains(t, out.String(), "401")
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"github.com/spf13/cobra"
@@ -27,6 +28,8 @@ var createMcpClientCmd = &cobra.Command{
 		"This returns an access token which should be sent by your client in the " +
 		"`Authorization: Bearer {token}` http header.\n" +
 		"This also lets you control which MCO servers the client can access.\n" +
+		"With --token-ttl, the access token expires after the given duration, and the client must renew it before " +
+		"then with the current token (see `mcpjungle token renew-mcp-client`).\n" +
 		"This command is only available in Enterprise mode.",
 	RunE: runCreateMcpClient,
 }
@@ -42,10 +45,24 @@ var createUserCmd = &cobra.Command{
 	RunE: runCreateUser,
 }
 
+var createTeamCmd = &cobra.Command{
+	Use:   "team [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Create a team of users (Enterprise mode)",
+	Long: "Create a team of users in MCPJungle.\n" +
+		"Permissions are granted to a team rather than to its members individually.\n" +
+		"The members of a team may administer the tool groups and MCP servers granted to it, like admins can:\n" +
+		"- View, update and delete the tool groups\n" +
+		"- Update, enable, disable and deregister the MCP servers",
+	Example: "  mcpjungle create team platform --members alice,bob --tool-groups claude-tools --servers github",
+	RunE:    runCreateTeam,
+}
+
 var createToolGroupCmd = &cobra.Command{
 	Use:   "group",
 	Short: "Create a Group of MCP Tools",
-	Long: "Create a new Group of MCP Tools by supplying a configuration file.\n" +
+	Long: "Create a new Group of MCP Tools by supplying a configuration file,\n" +
+		"or by picking servers & tools from lists with --interactive.\n" +
 		"A group lets you expose only a handful of Tools that you choose.\n" +
 		"This limits the number of tools your MCP client sees, increasing calling accuracy of the LLM.\n\n" +
 		"You can include tools by:\n" +
@@ -54,14 +71,25 @@ var createToolGroupCmd = &cobra.Command{
 		"  - Excluding specific tools with 'excluded_tools'\n\n" +
 		"Once you create a tool group, it is accessible as a streamable http MCP server at the following endpoint:\n" +
 		"    /v0/groups/{group_name}/mcp\n",
+	Example: "  mcpjungle create group -c ./group.json\n" +
+		"  mcpjungle create group --interactive",
 	RunE: runCreateToolGroup,
 }
 
 var (
 	createMcpClientCmdAllowedServers string
 	createMcpClientCmdDescription    string
+	createMcpClientCmdTokenTTL       time.Duration
+	createMcpClientCmdQuota          int64
+	createMcpClientCmdQuotaPeriod    string
 
 	createToolGroupConfigFilePath string
+	createToolGroupCmdInteractive bool
+
+	createTeamCmdDescription string
+	createTeamCmdMembers     string
+	createTeamCmdToolGroups  string
+	createTeamCmdServers     string
 )
 
 func init() {
@@ -79,6 +107,25 @@ func init() {
 		"Description of the MCP client. This is optional and can be used to provide additional context.",
 	)
 
+	createMcpClientCmd.Flags().DurationVar(
+		&createMcpClientCmdTokenTTL,
+		"token-ttl",
+		0,
+		"How long the client's access tokens live, eg- 720h. By default, they never expire.",
+	)
+	createMcpClientCmd.Flags().Int64Var(
+		&createMcpClientCmdQuota,
+		"quota",
+		0,
+		"Number of tool calls the client may make per quota period. By default, it is unlimited.",
+	)
+	createMcpClientCmd.Flags().StringVar(
+		&createMcpClientCmdQuotaPeriod,
+		"quota-period",
+		string(types.QuotaPeriodDaily),
+		"Period after which the client's quota resets: daily or monthly",
+	)
+
 	createToolGroupCmd.Flags().StringVarP(
 		&createToolGroupConfigFilePath,
 		"conf",
@@ -86,29 +133,54 @@ func init() {
 		"",
 		"Path to a JSON configuration file for the Group",
 	)
-	_ = createToolGroupCmd.MarkFlagRequired("conf")
+	createToolGroupCmd.Flags().BoolVarP(
+		&createToolGroupCmdInteractive,
+		"interactive",
+		"i",
+		false,
+		"Pick the servers & tools of the Group from lists instead of supplying a configuration file",
+	)
+	createToolGroupCmd.MarkFlagsOneRequired("conf", "interactive")
+	createToolGroupCmd.MarkFlagsMutuallyExclusive("conf", "interactive")
+
+	createTeamCmd.Flags().StringVar(&createTeamCmdDescription, "description", "", "Description of the team")
+	createTeamCmd.Flags().StringVar(
+		&createTeamCmdMembers, "members", "", "Comma-separated list of the usernames of the team's members",
+	)
+	createTeamCmd.Flags().StringVar(
+		&createTeamCmdToolGroups,
+		"tool-groups",
+		"",
+		"Comma-separated list of the tool groups that the members of the team may administer",
+	)
+	createTeamCmd.Flags().StringVar(
+		&createTeamCmdServers,
+		"servers",
+		"",
+		"Comma-separated list of the MCP servers that the members of the team may administer",
+	)
 
 	createCmd.AddCommand(createMcpClientCmd)
 	createCmd.AddCommand(createUserCmd)
 	createCmd.AddCommand(createToolGroupCmd)
+	createCmd.AddCommand(createTeamCmd)
 
 	rootCmd.AddCommand(createCmd)
 }
 
 func runCreateMcpClient(cmd *cobra.Command, args []string) error {
-	// convert the comma-separated list of allowed servers into a slice
-	allowList := make([]string, 0)
-	for _, s := range strings.Split(createMcpClientCmdAllowedServers, ",") {
-		trimmed := strings.TrimSpace(s)
-		if trimmed != "" {
-			allowList = append(allowList, trimmed)
-		}
+	if createMcpClientCmdTokenTTL < 0 {
+		return fmt.Errorf("token TTL must not be negative")
 	}
-
 	c := &types.McpClient{
-		Name:        args[0],
-		Description: createMcpClientCmdDescription,
-		AllowList:   allowList,
+		Name:            args[0],
+		Description:     createMcpClientCmdDescription,
+		AllowList:       splitNames(createMcpClientCmdAllowedServers),
+		TokenTTLSeconds: int64(createMcpClientCmdTokenTTL / time.Second),
+	}
+	if createMcpClientCmdQuota > 0 {
+		c.QuotaLimit = createMcpClientCmdQuota
+		c.QuotaPeriod = types.QuotaPeriod(createMcpClientCmdQuotaPeriod)
 	}
 
 	token, err := apiClient.CreateMcpClient(c)
@@ -119,16 +191,33 @@ func runCreateMcpClient(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("server returned an empty token, this was unexpected")
 	}
 
-	fmt.Printf("MCP client '%s' created successfully!\n", c.Name)
+	if quietMode {
+		// the token can't be retrieved later, so it is printed on its own for scripts to capture
+		fmt.Println(token)
+		return nil
+	}
+
+	w := cmd.OutOrStdout()
+	fmt.Fprintf(w, "MCP client '%s' created successfully!\n", c.Name)
 
 	if len(c.AllowList) > 0 {
-		fmt.Println("Servers accessible: " + strings.Join(c.AllowList, ","))
+		fmt.Fprintln(w, "Servers accessible: "+strings.Join(c.AllowList, ","))
 	} else {
-		fmt.Println("This client does not have access to any MCP servers.")
+		fmt.Fprintln(w, "This client does not have access to any MCP servers.")
+	}
+	if c.QuotaLimit > 0 {
+		fmt.Fprintf(w, "Quota: %d tool calls %s\n", c.QuotaLimit, c.QuotaPeriod)
 	}
 
-	fmt.Printf("\nAccess token: %s\n", token)
-	fmt.Println("Your client should send this token in the `Authorization: Bearer {token}` HTTP header.")
+	fmt.Fprintf(w, "\nAccess token: %s\n", token)
+	fmt.Fprintln(w, "Your client should send this token in the `Authorization: Bearer {token}` HTTP header.")
+	if c.TokenTTLSeconds > 0 {
+		fmt.Fprintf(
+			w,
+			"The token expires in %s, your client must renew it before then to keep its access.\n",
+			createMcpClientCmdTokenTTL,
+		)
+	}
 
 	return nil
 }
@@ -154,6 +243,34 @@ func runCreateUser(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runCreateTeam(cmd *cobra.Command, args []string) error {
+	t, err := apiClient.CreateTeam(&types.Team{
+		Name:        args[0],
+		Description: createTeamCmdDescription,
+		Members:     splitNames(createTeamCmdMembers),
+		ToolGroups:  splitNames(createTeamCmdToolGroups),
+		Servers:     splitNames(createTeamCmdServers),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create team: %w", err)
+	}
+	cmd.Printf("Team '%s' created successfully\n", t.Name)
+	printTeamPermissions(cmd, t)
+	return nil
+}
+
+// splitNames converts a comma-separated list of names into a slice, leaving out the empty names.
+func splitNames(v string) []string {
+	names := make([]string, 0)
+	for _, s := range strings.Split(v, ",") {
+		trimmed := strings.TrimSpace(s)
+		if trimmed != "" {
+			names = append(names, trimmed)
+		}
+	}
+	return names
+}
+
 func readToolGroupConfig(filePath string) (*types.ToolGroup, error) {
 	var input types.ToolGroup
 
@@ -169,9 +286,18 @@ func readToolGroupConfig(filePath string) (*types.ToolGroup, error) {
 }
 
 func runCreateToolGroup(cmd *cobra.Command, args []string) error {
-	group, err := readToolGroupConfig(createToolGroupConfigFilePath)
+	var group *types.ToolGroup
+	var err error
+	if createToolGroupCmdInteractive {
+		group, err = runGroupWizard(cmd)
+	} else {
+		group, err = readToolGroupConfig(createToolGroupConfigFilePath)
+		if err != nil {
+			err = fmt.Errorf("failed to read config file %s: %w", createToolGroupConfigFilePath, err)
+		}
+	}
 	if err != nil {
-		return fmt.Errorf("failed to read config file %s: %w", createToolGroupConfigFilePath, err)
+		return err
 	}
 
 	resp, err := apiClient.CreateToolGroup(group)
@@ -189,3 +315,34 @@ func runCreateToolGroup(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// runGroupWizard asks the user to describe the group, previews the tools it would expose and asks for confirmation.
+func runGroupWizard(cmd *cobra.Command) (*types.ToolGroup, error) {
+	servers, err := apiClient.ListServers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list servers: %w", err)
+	}
+	tools, err := apiClient.ListTools("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tools: %w", err)
+	}
+	if len(tools) == 0 {
+		return nil, fmt.Errorf("there are no tools in the registry, register an MCP server first")
+	}
+
+	w := newGroupWizard(cmd, servers, tools)
+	group, err := w.run()
+	if err != nil {
+		return nil, err
+	}
+	w.preview(group)
+
+	ok, err := w.askYesNo("Create the group?", true)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("aborted, the group was not created")
+	}
+	return group, nil
+}
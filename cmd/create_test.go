@@ -23,7 +23,7 @@ func TestCreateCommandStructure(t *testing.T) {
 
 	// Test subcommands count
 	subcommands := createCmd.Commands()
-	testhelpers.AssertEqual(t, 3, len(subcommands))
+	testhelpers.AssertEqual(t, 4, len(subcommands))
 }
 
 func TestCreateMcpClientSubcommand(t *testing.T) {
@@ -47,6 +47,10 @@ func TestCreateMcpClientSubcommand(t *testing.T) {
 	descriptionFlag := createMcpClientCmd.Flags().Lookup("description")
 	testhelpers.AssertNotNil(t, descriptionFlag)
 	testhelpers.AssertTrue(t, len(descriptionFlag.Usage) > 0, "Description flag should have usage description")
+
+	tokenTTLFlag := createMcpClientCmd.Flags().Lookup("token-ttl")
+	testhelpers.AssertNotNil(t, tokenTTLFlag)
+	testhelpers.AssertEqual(t, "0s", tokenTTLFlag.DefValue)
 }
 
 func TestCreateUserSubcommand(t *testing.T) {
@@ -131,7 +135,7 @@ func TestCreateCommandIntegration(t *testing.T) {
 
 	// Test all create subcommands are properly configured
 	subcommands := createCmd.Commands()
-	expectedSubcommands := []string{"mcp-client", "user", "group"}
+	expectedSubcommands := []string{"mcp-client", "user", "group", "team"}
 
 	testhelpers.AssertEqual(t, len(expectedSubcommands), len(subcommands))
 
@@ -6,9 +6,12 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var deleteCmdYes bool
+
 var deleteCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Delete entities from mcpjungle",
+	Long:  "Delete entities from mcpjungle.\nYou are asked to confirm the deletion, unless --yes is set.",
 	Annotations: map[string]string{
 		"group": string(subCommandGroupAdvanced),
 		"order": "5",
@@ -44,25 +47,73 @@ var deleteToolGroupCmd = &cobra.Command{
 	RunE: runDeleteToolGroup,
 }
 
+var deleteTeamCmd = &cobra.Command{
+	Use:   "team [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Delete a team of users (Enterprise mode)",
+	Long: "Delete a team from mcpjungle.\n" +
+		"This instantly revokes the permissions granted to its members by the team, the users themselves are not deleted.",
+	RunE: runDeleteTeam,
+}
+
 func init() {
+	deleteCmd.PersistentFlags().BoolVarP(
+		&deleteCmdYes,
+		"yes",
+		"y",
+		false,
+		"delete the entity without asking for confirmation",
+	)
+
 	deleteCmd.AddCommand(deleteMcpClientCmd)
 	deleteCmd.AddCommand(deleteUserCmd)
 	deleteCmd.AddCommand(deleteToolGroupCmd)
+	deleteCmd.AddCommand(deleteTeamCmd)
 
 	rootCmd.AddCommand(deleteCmd)
 }
 
 func runDeleteMcpClient(cmd *cobra.Command, args []string) error {
 	name := args[0]
+	if !deleteCmdYes {
+		description := fmt.Sprintf("MCP client '%s' will be deleted and its access token revoked.", name)
+		if clients, err := apiClient.ListMcpClients(); err == nil {
+			for _, c := range clients {
+				if c.Name == name {
+					description = fmt.Sprintf(
+						"MCP client '%s' will be deleted and its access to %d MCP servers revoked.", name, len(c.AllowList),
+					)
+					break
+				}
+			}
+		}
+		if err := confirm(cmd, description); err != nil {
+			return err
+		}
+	}
 	if err := apiClient.DeleteMcpClient(name); err != nil {
 		return fmt.Errorf("failed to delete the client: %w", err)
 	}
-	fmt.Printf("MCP client '%s' deleted successfully (if it existed)!\n", name)
+	fmt.Fprintf(cmd.OutOrStdout(), "MCP client '%s' deleted successfully (if it existed)!\n", name)
 	return nil
 }
 
 func runDeleteUser(cmd *cobra.Command, args []string) error {
 	username := args[0]
+	if !deleteCmdYes {
+		description := fmt.Sprintf("User '%s' will be deleted and their access revoked.", username)
+		if users, err := apiClient.ListUsers(); err == nil {
+			for _, u := range users {
+				if u.Username == username {
+					description = fmt.Sprintf("User '%s' (%s) will be deleted and their access revoked.", username, u.Role)
+					break
+				}
+			}
+		}
+		if err := confirm(cmd, description); err != nil {
+			return err
+		}
+	}
 	if err := apiClient.DeleteUser(username); err != nil {
 		return fmt.Errorf("failed to delete the user: %w", err)
 	}
@@ -72,9 +123,45 @@ func runDeleteUser(cmd *cobra.Command, args []string) error {
 
 func runDeleteToolGroup(cmd *cobra.Command, args []string) error {
 	name := args[0]
+	if !deleteCmdYes {
+		description := fmt.Sprintf("Tool group '%s' will be deleted and its endpoint will no longer be available.", name)
+		// the group's servers, exclusions and read-only mode decide which tools it exposes,
+		// so they are resolved by validating its configuration
+		if group, err := apiClient.GetToolGroup(name); err == nil {
+			if v, err := apiClient.ValidateToolGroup(group.ToolGroup); err == nil && v.Valid {
+				description = fmt.Sprintf(
+					"Tool group '%s' with %d tools will be deleted and its endpoint will no longer be available.",
+					name, len(v.EffectiveTools),
+				)
+			}
+		}
+		if err := confirm(cmd, description); err != nil {
+			return err
+		}
+	}
 	if err := apiClient.DeleteToolGroup(name); err != nil {
 		return fmt.Errorf("failed to delete the tool group: %w", err)
 	}
 	cmd.Printf("Tool group '%s' deleted successfully!\n", name)
 	return nil
 }
+
+func runDeleteTeam(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if !deleteCmdYes {
+		description := fmt.Sprintf("Team '%s' will be deleted and the permissions of its members revoked.", name)
+		if t, err := apiClient.GetTeam(name); err == nil {
+			description = fmt.Sprintf(
+				"Team '%s' will be deleted and the permissions of its %d members revoked.", name, len(t.Members),
+			)
+		}
+		if err := confirm(cmd, description); err != nil {
+			return err
+		}
+	}
+	if err := apiClient.DeleteTeam(name); err != nil {
+		return fmt.Errorf("failed to delete the team: %w", err)
+	}
+	cmd.Printf("Team '%s' deleted successfully\n", name)
+	return nil
+}
@@ -1,8 +1,14 @@
 package cmd
 
 import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/mcpjungle/mcpjungle/client"
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
 )
 
@@ -22,7 +28,7 @@ func TestDeleteCommandStructure(t *testing.T) {
 
 	// Test subcommands count
 	subcommands := deleteCmd.Commands()
-	testhelpers.AssertEqual(t, 3, len(subcommands))
+	testhelpers.AssertEqual(t, 4, len(subcommands))
 }
 
 func TestDeleteMcpClientSubcommand(t *testing.T) {
@@ -108,7 +114,7 @@ func TestDeleteCommandIntegration(t *testing.T) {
 
 	// Test all delete subcommands are properly configured
 	subcommands := deleteCmd.Commands()
-	expectedSubcommands := []string{"mcp-client", "user", "group"}
+	expectedSubcommands := []string{"mcp-client", "user", "group", "team"}
 
 	testhelpers.AssertEqual(t, len(expectedSubcommands), len(subcommands))
 
@@ -131,3 +137,39 @@ func TestDeleteCommandArgumentValidation(t *testing.T) {
 	testhelpers.AssertNotNil(t, deleteUserCmd.Args)
 	testhelpers.AssertNotNil(t, deleteToolGroupCmd.Args)
 }
+
+func TestDeleteToolGroupConfirmation(t *testing.T) {
+	var deleted bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/tool-groups/validate"):
+			_, _ = w.Write([]byte(`{"valid": true, "effective_tools": ["git__status", "git__diff", "time__now"]}`))
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"name": "dev", "included_servers": ["git"], "included_tools": ["time__now"]}`))
+		case r.Method == http.MethodDelete:
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	originalClient := apiClient
+	apiClient = client.NewClient(server.URL)
+	defer func() { apiClient = originalClient }()
+
+	var stderr bytes.Buffer
+	deleteToolGroupCmd.SetErr(&stderr)
+	deleteToolGroupCmd.SetOut(io.Discard)
+	deleteToolGroupCmd.SetIn(strings.NewReader("n\n"))
+	defer func() {
+		deleteToolGroupCmd.SetErr(nil)
+		deleteToolGroupCmd.SetOut(nil)
+		deleteToolGroupCmd.SetIn(nil)
+	}()
+
+	// the tools of the group's servers are counted along with the ones it includes explicitly
+	err := runDeleteToolGroup(deleteToolGroupCmd, []string{"dev"})
+	testhelpers.AssertError(t, err)
+	testhelpers.AssertStringContains(t, stderr.String(), "Tool group 'dev' with 3 tools will be deleted")
+	testhelpers.AssertFalse(t, deleted, "group must not be deleted without confirmation")
+}
@@ -2,16 +2,21 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"github.com/spf13/cobra"
 )
 
+var deregisterCmdYes bool
+
 var deregisterMCPServerCmd = &cobra.Command{
 	Use:   "deregister",
 	Short: "Deregister an MCP Server",
-	Long:  "Remove an MCP server from the registry. This also deregisters all tools provided by the server.",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runDeregisterMCPServer,
+	Long: "Remove an MCP server from the registry. This also deregisters all tools provided by the server.\n" +
+		"You are asked to confirm the removal, unless --yes is set.",
+	Args: cobra.ExactArgs(1),
+	RunE: runDeregisterMCPServer,
 	Annotations: map[string]string{
 		"group": string(subCommandGroupBasic),
 		"order": "6",
@@ -19,16 +24,53 @@ var deregisterMCPServerCmd = &cobra.Command{
 }
 
 func init() {
+	deregisterMCPServerCmd.Flags().BoolVarP(
+		&deregisterCmdYes,
+		"yes",
+		"y",
+		false,
+		"deregister the server without asking for confirmation",
+	)
 	rootCmd.AddCommand(deregisterMCPServerCmd)
 }
 
 func runDeregisterMCPServer(cmd *cobra.Command, args []string) error {
 	server := args[0]
+	if !deregisterCmdYes {
+		description := fmt.Sprintf("MCP server '%s' will be deregistered along with all its tools.", server)
+		if tools, err := apiClient.ListTools(server); err == nil {
+			description = fmt.Sprintf("MCP server '%s' will be deregistered along with its %d tools.", server, len(tools))
+		}
+		if consumers, err := apiClient.GetServerConsumers(server); err == nil {
+			description += describeConsumers(consumers)
+		}
+		if err := confirm(cmd, description); err != nil {
+			return err
+		}
+	}
 	if err := apiClient.DeregisterServer(server); err != nil {
 		return fmt.Errorf("failed to deregister MCP server %s: %w", server, err)
 	}
-	fmt.Printf("Successfully deregistered MCP server %s\n", server)
-	fmt.Println("The tools provided by this server have also been deregistered.")
+	fmt.Fprintf(cmd.OutOrStdout(), "Successfully deregistered MCP server %s\n", server)
+	fmt.Fprintln(cmd.OutOrStdout(), "The tools provided by this server have also been deregistered.")
 	// TODO: Output the list of tools that were deregistered.
 	return nil
 }
+
+// describeConsumers describes the MCP clients & tool groups that lose access to a deregistered server's tools.
+func describeConsumers(consumers *types.ServerConsumers) string {
+	var d string
+	if len(consumers.McpClients) > 0 {
+		d += fmt.Sprintf(
+			"\nMCP clients allowed to access it: %s", strings.Join(consumers.McpClients, ", "),
+		)
+	}
+	if len(consumers.ToolGroups) > 0 {
+		groups := make([]string, len(consumers.ToolGroups))
+		for i, g := range consumers.ToolGroups {
+			groups[i] = g.Name
+		}
+		d += fmt.Sprintf("\nTool groups exposing its tools: %s", strings.Join(groups, ", "))
+	}
+	return d
+}
@@ -1,8 +1,14 @@
 package cmd
 
 import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/mcpjungle/mcpjungle/client"
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
 )
 
@@ -50,3 +56,50 @@ func TestDeregisterCommandArgumentValidation(t *testing.T) {
 	// Test that command properly validates arguments
 	testhelpers.AssertNotNil(t, deregisterMCPServerCmd.Args)
 }
+
+func TestDeregisterConfirmation(t *testing.T) {
+	var deregistered bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/servers/time/consumers"):
+			_, _ = w.Write([]byte(`{"server": "time", "mcp_clients": ["cursor"], "tool_groups": [{"name": "clock", "tools": ["time__get_current_time"]}]}`))
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`[{"name": "time__get_current_time"}, {"name": "time__convert_time"}]`))
+		case r.Method == http.MethodDelete:
+			deregistered = true
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	originalClient := apiClient
+	apiClient = client.NewClient(server.URL)
+	defer func() { apiClient = originalClient }()
+
+	var stderr bytes.Buffer
+	deregisterMCPServerCmd.SetErr(&stderr)
+	deregisterMCPServerCmd.SetOut(io.Discard)
+	defer func() {
+		deregisterMCPServerCmd.SetErr(nil)
+		deregisterMCPServerCmd.SetOut(nil)
+		deregisterMCPServerCmd.SetIn(nil)
+	}()
+
+	t.Run("declined", func(t *testing.T) {
+		deregisterMCPServerCmd.SetIn(strings.NewReader("n\n"))
+		err := runDeregisterMCPServer(deregisterMCPServerCmd, []string{"time"})
+		testhelpers.AssertError(t, err)
+		testhelpers.AssertStringContains(t, stderr.String(), "MCP server 'time' will be deregistered along with its 2 tools.")
+		testhelpers.AssertStringContains(t, stderr.String(), "MCP clients allowed to access it: cursor")
+		testhelpers.AssertStringContains(t, stderr.String(), "Tool groups exposing its tools: clock")
+		testhelpers.AssertFalse(t, deregistered, "server must not be deregistered without confirmation")
+	})
+
+	t.Run("skipped with --yes", func(t *testing.T) {
+		deregisterCmdYes = true
+		defer func() { deregisterCmdYes = false }()
+
+		testhelpers.AssertNoError(t, runDeregisterMCPServer(deregisterMCPServerCmd, []string{"time"}))
+		testhelpers.AssertTrue(t, deregistered, "server must be deregistered")
+	})
+}
@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/mcpjungle/mcpjungle/client"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffCmdFile     string
+	diffCmdOnly     string
+	diffCmdExitCode bool
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare a registry file to the live registry",
+	Long: "Compare the MCP servers, tool groups & MCP clients declared in a file to the ones in the registry,\n" +
+		"and print the differences. The file has the format written by 'mcpjungle export', in JSON or YAML.\n\n" +
+		"Entities are marked with:\n" +
+		"  + if they are missing from the registry, 'mcpjungle import' would create them\n" +
+		"  ~ if their definition in the registry is different, followed by the fields that differ\n" +
+		"  - if they only exist in the registry\n\n" +
+		"The values of environment variables are never printed, only the names of those that differ,\n" +
+		"so that the diff can safely be shown in CI logs. Bearer tokens can't be compared since the registry never returns them.",
+	Example: "  mcpjungle diff -f registry.yaml\n" +
+		"  mcpjungle diff -f registry.yaml --only servers,groups --exit-code",
+	Args: cobra.NoArgs,
+	RunE: runDiff,
+	Annotations: map[string]string{
+		"group": string(subCommandGroupAdvanced),
+		"order": "21",
+	},
+}
+
+func init() {
+	diffCmd.Flags().StringVarP(
+		&diffCmdFile,
+		"file",
+		"f",
+		"",
+		"File to compare to the registry, '-' to read it from the standard input",
+	)
+	_ = diffCmd.MarkFlagRequired("file")
+	diffCmd.Flags().StringVar(
+		&diffCmdOnly,
+		"only",
+		"",
+		"Comma-separated list of the kinds of entities to compare: 'servers', 'groups' and/or 'clients' (default: all)",
+	)
+	diffCmd.Flags().BoolVar(
+		&diffCmdExitCode,
+		"exit-code",
+		false,
+		"Exit with status 1 if there are differences, eg- to fail a CI check",
+	)
+
+	rootCmd.AddCommand(diffCmd)
+}
+
+// registryDiff accumulates the differences between a registry file and the live registry
+type registryDiff struct {
+	w                       io.Writer
+	created, changed, extra int
+}
+
+func (d *registryDiff) missing(kind, name string) {
+	d.created++
+	fmt.Fprintf(d.w, "+ %s %s\n", kind, name)
+}
+
+func (d *registryDiff) different(kind, name string, fields []string) {
+	if len(fields) == 0 {
+		return
+	}
+	d.changed++
+	fmt.Fprintf(d.w, "~ %s %s\n", kind, name)
+	for _, f := range fields {
+		fmt.Fprintf(d.w, "    %s\n", f)
+	}
+}
+
+func (d *registryDiff) onlyInRegistry(kind, name string) {
+	d.extra++
+	fmt.Fprintf(d.w, "- %s %s\n", kind, name)
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	kinds, err := parseRegistryEntityKinds(diffCmdOnly)
+	if err != nil {
+		return err
+	}
+	file, err := readRegistryFile(cmd, diffCmdFile)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	d := &registryDiff{w: cmd.OutOrStdout()}
+	if slices.Contains(kinds, registryEntityServers) {
+		if err := diffServers(d, file.Servers); err != nil {
+			return err
+		}
+	}
+	if slices.Contains(kinds, registryEntityGroups) {
+		if err := diffToolGroups(d, file.ToolGroups); err != nil {
+			return err
+		}
+	}
+	if slices.Contains(kinds, registryEntityClients) {
+		clients, err := apiClient.ListMcpClients()
+		var apiErr *client.APIError
+		switch {
+		case diffCmdOnly == "" && errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden:
+			// MCP clients only exist in enterprise mode, which doesn't prevent comparing everything else
+			if len(file.McpClients) > 0 {
+				cmd.PrintErrln("Skipping MCP clients, they are only available in enterprise mode")
+			}
+		case err != nil:
+			return fmt.Errorf("failed to list MCP clients: %w", err)
+		default:
+			diffMcpClients(d, file.McpClients, clients)
+		}
+	}
+
+	if d.created+d.changed+d.extra == 0 {
+		fmt.Fprintln(d.w, "No differences, the registry matches the file")
+		return nil
+	}
+	fmt.Fprintf(d.w, "\n%d to create, %d different, %d only in the registry\n", d.created, d.changed, d.extra)
+	if diffCmdExitCode {
+		return ErrSilent
+	}
+	return nil
+}
+
+func diffServers(d *registryDiff, want []*types.RegisterServerInput) error {
+	servers, err := apiClient.ListServers()
+	if err != nil {
+		return fmt.Errorf("failed to list servers: %w", err)
+	}
+	live := make(map[string]*types.McpServer, len(servers))
+	for _, s := range servers {
+		live[s.Name] = s
+	}
+
+	declared := make(map[string]bool, len(want))
+	for _, w := range want {
+		declared[w.Name] = true
+		s, ok := live[w.Name]
+		if !ok {
+			d.missing("server", w.Name)
+			continue
+		}
+		var fields []string
+		fields = diffField(fields, "transport", s.Transport, w.Transport)
+		fields = diffField(fields, "description", s.Description, w.Description)
+		fields = diffField(fields, "url", s.URL, w.URL)
+		fields = diffField(fields, "command", s.Command, w.Command)
+		if !slices.Equal(s.Args, w.Args) {
+			fields = append(fields, fmt.Sprintf("args: %s -> %s", formatSchemaValue(s.Args), formatSchemaValue(w.Args)))
+		}
+		if names := diffEnvNames(s.Env, w.Env); len(names) > 0 {
+			fields = append(fields, "env: "+strings.Join(names, ", ")+" differ")
+		}
+		d.different("server", w.Name, fields)
+	}
+	for _, s := range servers {
+		if !declared[s.Name] {
+			d.onlyInRegistry("server", s.Name)
+		}
+	}
+	return nil
+}
+
+func diffToolGroups(d *registryDiff, want []*types.ToolGroup) error {
+	groups, err := apiClient.ListToolGroups()
+	if err != nil {
+		return fmt.Errorf("failed to list tool groups: %w", err)
+	}
+	live := make(map[string]bool, len(groups))
+	for _, g := range groups {
+		live[g.Name] = true
+	}
+
+	declared := make(map[string]bool, len(want))
+	for _, w := range want {
+		declared[w.Name] = true
+		if !live[w.Name] {
+			d.missing("tool group", w.Name)
+			continue
+		}
+		// the list only contains the names of the groups, not their configuration
+		resp, err := apiClient.GetToolGroup(w.Name)
+		if err != nil {
+			return fmt.Errorf("failed to get tool group %s: %w", w.Name, err)
+		}
+		g := resp.ToolGroup
+		var fields []string
+		fields = diffField(fields, "description", g.Description, w.Description)
+		fields = diffSet(fields, "included_tools", g.IncludedTools, w.IncludedTools)
+		fields = diffSet(fields, "included_servers", g.IncludedServers, w.IncludedServers)
+		fields = diffSet(fields, "excluded_tools", g.ExcludedTools, w.ExcludedTools)
+		if g.ReadOnly != w.ReadOnly {
+			fields = append(fields, fmt.Sprintf("read_only: %t -> %t", g.ReadOnly, w.ReadOnly))
+		}
+		if !maps.Equal(g.CachedTools, w.CachedTools) {
+			fields = append(fields, fmt.Sprintf(
+				"cached_tools: %s -> %s", formatSchemaValue(g.CachedTools), formatSchemaValue(w.CachedTools),
+			))
+		}
+		d.different("tool group", w.Name, fields)
+	}
+	for _, g := range groups {
+		if !declared[g.Name] {
+			d.onlyInRegistry("tool group", g.Name)
+		}
+	}
+	return nil
+}
+
+func diffMcpClients(d *registryDiff, want []*types.McpClient, clients []types.McpClient) {
+	live := make(map[string]types.McpClient, len(clients))
+	for _, c := range clients {
+		live[c.Name] = c
+	}
+
+	declared := make(map[string]bool, len(want))
+	for _, w := range want {
+		declared[w.Name] = true
+		c, ok := live[w.Name]
+		if !ok {
+			d.missing("MCP client", w.Name)
+			continue
+		}
+		var fields []string
+		fields = diffField(fields, "description", c.Description, w.Description)
+		fields = diffSet(fields, "allow_list", c.AllowList, w.AllowList)
+		d.different("MCP client", w.Name, fields)
+	}
+	for _, c := range clients {
+		if !declared[c.Name] {
+			d.onlyInRegistry("MCP client", c.Name)
+		}
+	}
+}
+
+// diffField appends the difference between the live & declared values of a field, if any.
+func diffField(fields []string, name, live, declared string) []string {
+	if live == declared {
+		return fields
+	}
+	return append(fields, fmt.Sprintf("%s: %q -> %q", name, live, declared))
+}
+
+// diffSet appends the difference between the live & declared values of a field whose order doesn't matter, if any.
+func diffSet(fields []string, name string, live, declared []string) []string {
+	var added, removed []string
+	for _, v := range declared {
+		if !slices.Contains(live, v) {
+			added = append(added, "+"+v)
+		}
+	}
+	for _, v := range live {
+		if !slices.Contains(declared, v) {
+			removed = append(removed, "-"+v)
+		}
+	}
+	if len(added)+len(removed) == 0 {
+		return fields
+	}
+	return append(fields, fmt.Sprintf("%s: %s", name, strings.Join(append(removed, added...), " ")))
+}
+
+// diffEnvNames returns the sorted names of the environment variables that differ, without their values.
+func diffEnvNames(live, declared map[string]string) []string {
+	var names []string
+	for k, v := range declared {
+		if lv, ok := live[k]; !ok || lv != v {
+			names = append(names, k)
+		}
+	}
+	for k := range live {
+		if _, ok := declared[k]; !ok {
+			names = append(names, k)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestDiffSet(t *testing.T) {
+	fields := diffSet(nil, "allow_list", []string{"a", "b"}, []string{"b", "a"})
+	testhelpers.AssertEqual(t, 0, len(fields))
+
+	fields = diffSet(nil, "allow_list", []string{"a", "b"}, []string{"b", "c"})
+	testhelpers.AssertEqual(t, 1, len(fields))
+	testhelpers.AssertEqual(t, "allow_list: -a +c", fields[0])
+}
+
+func TestDiffEnvNames(t *testing.T) {
+	names := diffEnvNames(
+		map[string]string{"TOKEN": "old", "REGION": "eu", "DEBUG": "1"},
+		map[string]string{"TOKEN": "new", "REGION": "eu", "PORT": "8080"},
+	)
+	testhelpers.AssertEqual(t, 3, len(names))
+	testhelpers.AssertEqual(t, "DEBUG", names[0])
+	testhelpers.AssertEqual(t, "PORT", names[1])
+	testhelpers.AssertEqual(t, "TOKEN", names[2])
+}
+
+func TestRunDiff(t *testing.T) {
+	useFakeRegistry(t, &fakeRegistry{
+		servers: []*types.McpServer{
+			{Name: "time", Transport: "stdio", Command: "uvx", Env: map[string]string{"TZ": "UTC"}},
+			{Name: "github", Transport: "streamable_http", URL: "https://api.githubcopilot.com/mcp/"},
+		},
+		groups: []*types.ToolGroup{
+			{Name: "claude", IncludedServers: []string{"time"}},
+		},
+	})
+
+	file := filepath.Join(t.TempDir(), "registry.yaml")
+	testhelpers.AssertNoError(t, os.WriteFile(file, []byte(`
+servers:
+  - name: time
+    transport: stdio
+    command: uvx
+    env:
+      TZ: Europe/Paris
+  - name: context7
+    transport: streamable_http
+    url: https://mcp.context7.com/mcp
+tool_groups:
+  - name: claude
+    included_servers: [time, context7]
+`), 0o644))
+
+	var out, errOut bytes.Buffer
+	diffCmd.SetOut(&out)
+	diffCmd.SetErr(&errOut)
+	defer diffCmd.SetOut(nil)
+	defer diffCmd.SetErr(nil)
+	diffCmdFile = file
+	defer func() { diffCmdFile = "" }()
+
+	t.Run("differences", func(t *testing.T) {
+		out.Reset()
+		testhelpers.AssertNoError(t, runDiff(diffCmd, nil))
+
+		testhelpers.AssertStringContains(t, out.String(), "~ server time\n    env: TZ differ")
+		testhelpers.AssertStringContains(t, out.String(), "+ server context7")
+		testhelpers.AssertStringContains(t, out.String(), "- server github")
+		testhelpers.AssertStringContains(t, out.String(), "~ tool group claude\n    included_servers: +context7")
+		testhelpers.AssertStringContains(t, out.String(), "1 to create, 2 different, 1 only in the registry")
+		// the values of environment variables must never be printed
+		testhelpers.AssertStringNotContains(t, out.String(), "Europe/Paris")
+		testhelpers.AssertStringNotContains(t, out.String(), "UTC")
+	})
+
+	t.Run("exit code", func(t *testing.T) {
+		diffCmdExitCode = true
+		defer func() { diffCmdExitCode = false }()
+
+		err := runDiff(diffCmd, nil)
+		testhelpers.AssertTrue(t, errors.Is(err, ErrSilent), "expected ErrSilent when there are differences")
+	})
+
+	t.Run("no differences", func(t *testing.T) {
+		out.Reset()
+		diffCmdOnly = "groups"
+		defer func() { diffCmdOnly = "" }()
+		testhelpers.AssertNoError(t, os.WriteFile(file, []byte(`{"tool_groups": [{"name": "claude", "included_servers": ["time"]}]}`), 0o644))
+
+		testhelpers.AssertNoError(t, runDiff(diffCmd, nil))
+		testhelpers.AssertStringContains(t, out.String(), "No differences")
+	})
+}
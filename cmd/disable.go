@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/spf13/cobra"
 )
@@ -12,6 +13,8 @@ import (
        (Deprecated, for backward-compatibility) Disable all tools from a mcp server
      disable [toolname]
        (Deprecated, for backward compatibility) Disable a specific mcp tool
+     disable [pattern]...
+       Disable all tools matching one or more glob patterns
      disable tool [servername]
        Disable all tools from a mcp server
      disable tool [toolname]
@@ -30,7 +33,9 @@ var disableCmd = &cobra.Command{
 	Long: "Disable one or more tools or prompts globally.\n" +
 		"If an entity is disabled in mcpjungle, it CANNOT be consumed by mcp clients via the gateway.\n\n" +
 		"NOTE: For backward-compatibility, you can still run 'disable [name]' to disable a tool or all tools from a mcp server.\n" +
-		"But the recommended way to achieve this now is 'disable tool [name]'.",
+		"But the recommended way to achieve this now is 'disable tool [name]'.\n\n" +
+		"Glob patterns are matched against the canonical names of all tools, so \"disable 'github__*'\" disables\n" +
+		"every matching tool and prints the result for each of them.",
 	Annotations: map[string]string{
 		"group": string(subCommandGroupAdvanced),
 		"order": "2",
@@ -39,12 +44,14 @@ var disableCmd = &cobra.Command{
 }
 
 var disableToolsCmd = &cobra.Command{
-	Use:   "tool [name]",
-	Args:  cobra.ExactArgs(1),
+	Use:   "tool [name]...",
+	Args:  cobra.MinimumNArgs(1),
 	Short: "Disable one or more MCP tools globally",
 	Long: "Specify the name of a tool or MCP server to disable it in the mcp proxy.\n" +
 		"If a server is specified, all tools provided by that server will be disabled.\n" +
-		"If a tool is disabled, it cannot be viewed or called by mcp clients.",
+		"If a tool is disabled, it cannot be viewed or called by mcp clients.\n\n" +
+		"Multiple tools, servers and glob patterns (eg- 'github__*') can be supplied at once.\n" +
+		"A pattern is matched against the canonical names of all tools.",
 	RunE: runDisableTools,
 }
 
@@ -74,15 +81,18 @@ func init() {
 	rootCmd.AddCommand(disableCmd)
 }
 
-// runDisable checks if the command is called as `mcpjungle disable [name]`
-// and redirects to `mcpjungle disable tool [name]`.
+// runDisable checks if the command is called as `mcpjungle disable [name]...`
+// and redirects to `mcpjungle disable tool [name]...`.
 // This is to maintain backward compatibility with older versions of the CLI that only supported disabling tools & servers.
 func runDisable(cmd *cobra.Command, args []string) error {
-	if len(args) == 1 && cmd.CalledAs() == "disable" {
-		cmd.Println(
-			"Warning: 'disable [name]' is deprecated. Please use 'disable tool [name]' or 'disable server [name]' instead.",
-		)
-		cmd.Println()
+	if len(args) > 0 && cmd.CalledAs() == "disable" {
+		// a glob pattern can only match tools, so 'disable github__*' isn't ambiguous and doesn't need the warning
+		if !slices.ContainsFunc(args, isToolPattern) {
+			cmd.Println(
+				"Warning: 'disable [name]' is deprecated. Please use 'disable tool [name]' or 'disable server [name]' instead.",
+			)
+			cmd.Println()
+		}
 
 		// only disable tools, because this was the behaviour before prompts were introduced
 		// to disable everything, users should now use `disable server [name]`
@@ -93,6 +103,14 @@ func runDisable(cmd *cobra.Command, args []string) error {
 }
 
 func runDisableTools(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 || isToolPattern(args[0]) {
+		results, err := apiClient.DisableToolsBulk(args)
+		if err != nil {
+			return fmt.Errorf("failed to disable tools: %w", err)
+		}
+		return printBulkEntityResults(cmd, results, "disabled")
+	}
+
 	name := args[0]
 	toolsDisabled, err := apiClient.DisableTools(name)
 	if err != nil {
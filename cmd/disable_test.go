@@ -1,9 +1,16 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/mcpjungle/mcpjungle/client"
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
 )
 
 func TestDisableCommandStructure(t *testing.T) {
@@ -40,3 +47,31 @@ func TestDisableCommandStructure(t *testing.T) {
 		}
 	})
 }
+
+func TestRunDisablePattern(t *testing.T) {
+	var got types.BulkEntitiesRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testhelpers.AssertEqual(t, "/api/v1/tools/disable", r.URL.Path)
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_ = json.NewEncoder(w).Encode([]types.BulkEntityResult{
+			{Entity: "github__*", Affected: []string{"github__create_issue", "github__list_issues"}},
+		})
+	}))
+	defer server.Close()
+
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+	apiClient = client.NewClient(server.URL)
+
+	var out bytes.Buffer
+	// the command must be executed for it to know how it was called
+	cmd := &cobra.Command{Use: "disable", RunE: runDisable}
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"github__*"})
+
+	testhelpers.AssertNoError(t, cmd.Execute())
+	testhelpers.AssertEqual(t, 1, len(got.Entities))
+	testhelpers.AssertEqual(t, "github__*", got.Entities[0])
+	// a pattern isn't ambiguous, so no deprecation warning is printed
+	testhelpers.AssertEqual(t, "github__*: disabled\n    - github__create_issue\n    - github__list_issues\n", out.String())
+}
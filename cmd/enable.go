@@ -2,7 +2,10 @@ package cmd
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"github.com/spf13/cobra"
 )
 
@@ -12,6 +15,8 @@ import (
        (Deprecated, for backward-compatibility) Enable all tools from a mcp server
      enable [toolname]
        (Deprecated, for backward compatibility) Enable a specific mcp tool
+     enable [pattern]...
+       Enable all tools matching one or more glob patterns
      enable tool [servername]
        Enable all tools from a mcp server
      enable tool [toolname]
@@ -30,7 +35,9 @@ var enableCmd = &cobra.Command{
 	Long: "Enable one or more tools or prompts globally.\n" +
 		"If an entity is enabled in mcpjungle, it can be consumed by mcp clients via the gateway.\n\n" +
 		"NOTE: For backward-compatibility, you can still run 'enable [name]' to enable a tool or all tools from a mcp server.\n" +
-		"But the recommended way to achieve this now is 'enable tool [name]'.",
+		"But the recommended way to achieve this now is 'enable tool [name]'.\n\n" +
+		"Glob patterns are matched against the canonical names of all tools, so \"enable 'github__*'\" enables\n" +
+		"every matching tool and prints the result for each of them.",
 	Annotations: map[string]string{
 		"group": string(subCommandGroupAdvanced),
 		"order": "3",
@@ -39,12 +46,14 @@ var enableCmd = &cobra.Command{
 }
 
 var enableToolsCmd = &cobra.Command{
-	Use:   "tool [name]",
-	Args:  cobra.ExactArgs(1),
+	Use:   "tool [name]...",
+	Args:  cobra.MinimumNArgs(1),
 	Short: "Enable one or more MCP tools globally",
 	Long: "Specify the name of a tool or MCP server to enable it in the mcp proxy.\n" +
 		"If a server is specified, all tools provided by that server will be enabled.\n" +
-		"If a tool is enabled, it can be viewed and called by mcp clients.",
+		"If a tool is enabled, it can be viewed and called by mcp clients.\n\n" +
+		"Multiple tools, servers and glob patterns (eg- 'github__*') can be supplied at once.\n" +
+		"A pattern is matched against the canonical names of all tools.",
 	RunE: runEnableTools,
 }
 
@@ -75,15 +84,18 @@ func init() {
 	rootCmd.AddCommand(enableCmd)
 }
 
-// runEnable checks if the command is called as `mcpjungle enable [name]`
-// and redirects to `mcpjungle enable tool [name]`.
+// runEnable checks if the command is called as `mcpjungle enable [name]...`
+// and redirects to `mcpjungle enable tool [name]...`.
 // This is to maintain backward compatibility with older versions of the CLI that only supported enabling tools & servers.
 func runEnable(cmd *cobra.Command, args []string) error {
-	if len(args) == 1 && cmd.CalledAs() == "enable" {
-		cmd.Println(
-			"Warning: 'enable [name]' is deprecated. Please use 'enable tool [name]' or 'enable server [name]' instead.",
-		)
-		cmd.Println()
+	if len(args) > 0 && cmd.CalledAs() == "enable" {
+		// a glob pattern can only match tools, so 'enable github__*' isn't ambiguous and doesn't need the warning
+		if !slices.ContainsFunc(args, isToolPattern) {
+			cmd.Println(
+				"Warning: 'enable [name]' is deprecated. Please use 'enable tool [name]' or 'enable server [name]' instead.",
+			)
+			cmd.Println()
+		}
 		return runEnableTools(cmd, args)
 	}
 	// Otherwise, just show help message
@@ -91,6 +103,14 @@ func runEnable(cmd *cobra.Command, args []string) error {
 }
 
 func runEnableTools(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 || isToolPattern(args[0]) {
+		results, err := apiClient.EnableToolsBulk(args)
+		if err != nil {
+			return fmt.Errorf("failed to enable tools: %w", err)
+		}
+		return printBulkEntityResults(cmd, results, "enabled")
+	}
+
 	name := args[0]
 	toolsEnabled, err := apiClient.EnableTools(name)
 	if err != nil {
@@ -152,3 +172,33 @@ func runEnableServer(cmd *cobra.Command, args []string) error {
 	cmd.Println()
 	return nil
 }
+
+// isToolPattern returns true if the given tool entity is a glob pattern rather than a tool or server name.
+func isToolPattern(entity string) bool {
+	return strings.ContainsAny(entity, "*?[")
+}
+
+// printBulkEntityResults prints the outcome of a bulk enable/disable request.
+// It returns an error if any of the entities could not be processed.
+func printBulkEntityResults(cmd *cobra.Command, results []types.BulkEntityResult, action string) error {
+	failed := 0
+	for _, r := range results {
+		if r.Error != "" {
+			failed++
+			cmd.Printf("%s: failed: %s\n", r.Entity, r.Error)
+			continue
+		}
+		if len(r.Affected) == 0 {
+			cmd.Printf("%s: already %s\n", r.Entity, action)
+			continue
+		}
+		cmd.Printf("%s: %s\n", r.Entity, action)
+		for _, name := range r.Affected {
+			cmd.Printf("    - %s\n", name)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d entities could not be %s", failed, len(results), action)
+	}
+	return nil
+}
@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
 )
 
 func TestEnableCommandStructure(t *testing.T) {
@@ -40,3 +43,35 @@ func TestEnableCommandStructure(t *testing.T) {
 		}
 	})
 }
+
+func TestIsToolPattern(t *testing.T) {
+	testhelpers.AssertTrue(t, isToolPattern("github__*"), "Expected * to denote a pattern")
+	testhelpers.AssertTrue(t, isToolPattern("time__get_?"), "Expected ? to denote a pattern")
+	testhelpers.AssertFalse(t, isToolPattern("github__create_issue"), "Expected tool name not to be a pattern")
+	testhelpers.AssertFalse(t, isToolPattern("github"), "Expected server name not to be a pattern")
+}
+
+func TestPrintBulkEntityResults(t *testing.T) {
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+
+	results := []types.BulkEntityResult{
+		{Entity: "github__*", Affected: []string{"github__create_issue", "github__list_issues"}},
+		{Entity: "time", Affected: []string{}},
+		{Entity: "unknown", Error: "server not found"},
+	}
+	err := printBulkEntityResults(cmd, results, "enabled")
+	testhelpers.AssertError(t, err)
+	testhelpers.AssertEqual(t, "1 of 3 entities could not be enabled", err.Error())
+
+	expected := "github__*: enabled\n" +
+		"    - github__create_issue\n" +
+		"    - github__list_issues\n" +
+		"time: already enabled\n" +
+		"unknown: failed: server not found\n"
+	testhelpers.AssertEqual(t, expected, out.String())
+
+	out.Reset()
+	testhelpers.AssertNoError(t, printBulkEntityResults(cmd, results[:2], "enabled"))
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/mcpjungle/mcpjungle/client"
+)
+
+// Exit codes of the mcpjungle CLI, so that scripts & CI can branch on the outcome of a command.
+const (
+	// ExitCodeOK is returned when the command succeeded
+	ExitCodeOK = 0
+	// ExitCodeError is returned for any failure not covered by a more specific exit code
+	ExitCodeError = 1
+	// ExitCodeNotFound is returned when the entity (eg- server, tool, group) the command acts on does not exist
+	ExitCodeNotFound = 2
+	// ExitCodeToolError is returned when a tool was called successfully but reported an error
+	ExitCodeToolError = 3
+	// ExitCodeAuthFailure is returned when the registry server rejected the access token or its permissions
+	ExitCodeAuthFailure = 4
+)
+
+// ErrToolError is returned when a tool was called successfully but returned an error result.
+var ErrToolError = errors.New("the tool returned an error")
+
+// ExitCode returns the exit code for an error returned by Execute.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitCodeOK
+	}
+	if errors.Is(err, ErrToolError) {
+		return ExitCodeToolError
+	}
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusNotFound:
+			return ExitCodeNotFound
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return ExitCodeAuthFailure
+		}
+	}
+	return ExitCodeError
+}
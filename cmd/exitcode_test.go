@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/client"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestExitCode(t *testing.T) {
+	apiErr := func(status int) error {
+		return fmt.Errorf("failed to get tool: %w", &client.APIError{StatusCode: status, Message: "error"})
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"no error", nil, ExitCodeOK},
+		{"generic error", errors.New("invalid input"), ExitCodeError},
+		{"silent error", ErrSilent, ExitCodeError},
+		{"not found", apiErr(http.StatusNotFound), ExitCodeNotFound},
+		{"tool error", fmt.Errorf("job result: %w", ErrToolError), ExitCodeToolError},
+		{"unauthorized", apiErr(http.StatusUnauthorized), ExitCodeAuthFailure},
+		{"forbidden", apiErr(http.StatusForbidden), ExitCodeAuthFailure},
+		{"server error", apiErr(http.StatusInternalServerError), ExitCodeError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testhelpers.AssertEqual(t, tt.want, ExitCode(tt.err))
+		})
+	}
+}
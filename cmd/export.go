@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/mcpjungle/mcpjungle/client"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/mcpjungle/mcpjungle/pkg/version"
+	"github.com/spf13/cobra"
+)
+
+// registryEntityKind is a kind of entity covered by the export & import commands
+type registryEntityKind string
+
+const (
+	registryEntityServers registryEntityKind = "servers"
+	registryEntityGroups  registryEntityKind = "groups"
+	registryEntityClients registryEntityKind = "clients"
+)
+
+// registryEntityKinds lists the kinds of entities in the order they must be imported,
+// since groups refer to the tools of servers and clients to servers.
+var registryEntityKinds = []registryEntityKind{registryEntityServers, registryEntityGroups, registryEntityClients}
+
+// registryExport is the file written by 'mcpjungle export' and read by 'mcpjungle import'.
+// Its entries use the same format as the configuration files of 'register' & 'create group'.
+type registryExport struct {
+	// Version is the version of mcpjungle that exported the file
+	Version    string                       `json:"version"`
+	Servers    []*types.RegisterServerInput `json:"servers,omitempty"`
+	ToolGroups []*types.ToolGroup           `json:"tool_groups,omitempty"`
+	McpClients []*types.McpClient           `json:"mcp_clients,omitempty"`
+}
+
+var (
+	exportCmdFile string
+	exportCmdOnly string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the MCP servers, tool groups & MCP clients to a file",
+	Long: "Export the definitions of the MCP servers, tool groups & MCP clients of the registry to a single JSON file,\n" +
+		"which can be imported into another registry with 'mcpjungle import'.\n" +
+		"Unlike 'mcpjungle backup', this goes through the registry server's API, so it works against a remote registry\n" +
+		"and only covers the entities' definitions, not their tools, prompts or history.\n\n" +
+		"NOTE: Secrets are not exported: the bearer tokens of MCP servers must be added to the file before importing it,\n" +
+		"and new access tokens are generated for the imported MCP clients.\n" +
+		"The environment variables of STDIO servers are exported as they are, so review the file before sharing it.",
+	Example: "  mcpjungle export --file registry.json\n" +
+		"  mcpjungle export --only servers,groups > registry.json",
+	Args: cobra.NoArgs,
+	RunE: runExport,
+	Annotations: map[string]string{
+		"group": string(subCommandGroupAdvanced),
+		"order": "19",
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(
+		&exportCmdFile,
+		"file",
+		"f",
+		"",
+		"File to write the export to (default: standard output)",
+	)
+	exportCmd.Flags().StringVar(
+		&exportCmdOnly,
+		"only",
+		"",
+		"Comma-separated list of the kinds of entities to export: 'servers', 'groups' and/or 'clients' (default: all)",
+	)
+
+	rootCmd.AddCommand(exportCmd)
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	kinds, err := parseRegistryEntityKinds(exportCmdOnly)
+	if err != nil {
+		return err
+	}
+
+	export := &registryExport{Version: version.GetVersion()}
+	if slices.Contains(kinds, registryEntityServers) {
+		servers, err := apiClient.ListServers()
+		if err != nil {
+			return fmt.Errorf("failed to list servers: %w", err)
+		}
+		for _, s := range servers {
+			export.Servers = append(export.Servers, &types.RegisterServerInput{
+				Name:        s.Name,
+				Transport:   s.Transport,
+				Description: s.Description,
+				URL:         s.URL,
+				Command:     s.Command,
+				Args:        s.Args,
+				Env:         s.Env,
+			})
+		}
+	}
+	if slices.Contains(kinds, registryEntityGroups) {
+		groups, err := apiClient.ListToolGroups()
+		if err != nil {
+			return fmt.Errorf("failed to list tool groups: %w", err)
+		}
+		// the list only contains the names of the groups, not their configuration
+		for _, g := range groups {
+			resp, err := apiClient.GetToolGroup(g.Name)
+			if err != nil {
+				return fmt.Errorf("failed to get tool group %s: %w", g.Name, err)
+			}
+			export.ToolGroups = append(export.ToolGroups, resp.ToolGroup)
+		}
+	}
+	if slices.Contains(kinds, registryEntityClients) {
+		clients, err := apiClient.ListMcpClients()
+		var apiErr *client.APIError
+		switch {
+		case exportCmdOnly == "" && errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden:
+			// MCP clients only exist in enterprise mode, which doesn't prevent exporting everything else
+			cmd.PrintErrln("Skipping MCP clients, they are only available in enterprise mode")
+		case err != nil:
+			return fmt.Errorf("failed to list MCP clients: %w", err)
+		}
+		for i := range clients {
+			export.McpClients = append(export.McpClients, &clients[i])
+		}
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode export: %w", err)
+	}
+	data = append(data, '\n')
+
+	if exportCmdFile == "" {
+		_, err = cmd.OutOrStdout().Write(data)
+		return err
+	}
+	// the file may contain credentials passed to STDIO servers via their environment
+	if err := os.WriteFile(exportCmdFile, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	cmd.Printf(
+		"Exported %d servers, %d tool groups & %d MCP clients to %s\n",
+		len(export.Servers), len(export.ToolGroups), len(export.McpClients), exportCmdFile,
+	)
+	return nil
+}
+
+// parseRegistryEntityKinds parses the value of an --only flag, an empty value selects all kinds.
+// The kinds are returned in the order they must be imported.
+func parseRegistryEntityKinds(v string) ([]registryEntityKind, error) {
+	if strings.TrimSpace(v) == "" {
+		return registryEntityKinds, nil
+	}
+	selected := make(map[registryEntityKind]bool)
+	for _, s := range strings.Split(v, ",") {
+		k := registryEntityKind(strings.ToLower(strings.TrimSpace(s)))
+		if k == "" {
+			continue
+		}
+		if !slices.Contains(registryEntityKinds, k) {
+			return nil, fmt.Errorf(
+				"invalid value for --only flag: '%s', expected a comma-separated list of '%s', '%s' & '%s'",
+				strings.TrimSpace(s), registryEntityServers, registryEntityGroups, registryEntityClients,
+			)
+		}
+		selected[k] = true
+	}
+	kinds := make([]registryEntityKind, 0, len(selected))
+	for _, k := range registryEntityKinds {
+		if selected[k] {
+			kinds = append(kinds, k)
+		}
+	}
+	return kinds, nil
+}
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/client"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// fakeRegistry is a minimal registry server that keeps the servers, tool groups & MCP clients in memory
+type fakeRegistry struct {
+	mu         sync.Mutex
+	enterprise bool
+	servers    []*types.McpServer
+	groups     []*types.ToolGroup
+	clients    []*types.McpClient
+}
+
+func (f *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1")
+	switch {
+	case path == "/servers" && r.Method == http.MethodGet:
+		_ = json.NewEncoder(w).Encode(f.servers)
+	case path == "/servers" && r.Method == http.MethodPost:
+		var in types.RegisterServerInput
+		_ = json.NewDecoder(r.Body).Decode(&in)
+		s := &types.McpServer{Name: in.Name, Transport: in.Transport, URL: in.URL, Command: in.Command, Args: in.Args}
+		f.servers = append(f.servers, s)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(s)
+	case path == "/tool-groups" && r.Method == http.MethodGet:
+		names := make([]types.ToolGroup, len(f.groups))
+		for i, g := range f.groups {
+			names[i] = types.ToolGroup{Name: g.Name}
+		}
+		_ = json.NewEncoder(w).Encode(names)
+	case path == "/tool-groups" && r.Method == http.MethodPost:
+		var g types.ToolGroup
+		_ = json.NewDecoder(r.Body).Decode(&g)
+		f.groups = append(f.groups, &g)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(types.ToolGroupEndpoints{})
+	case strings.HasPrefix(path, "/tool-groups/"):
+		for _, g := range f.groups {
+			if g.Name == strings.TrimPrefix(path, "/tool-groups/") {
+				_ = json.NewEncoder(w).Encode(types.GetToolGroupResponse{ToolGroup: g})
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	case path == "/clients" && !f.enterprise:
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error": "this request is only allowed in enterprise mode"}`))
+	case path == "/clients" && r.Method == http.MethodGet:
+		_ = json.NewEncoder(w).Encode(f.clients)
+	case path == "/clients" && r.Method == http.MethodPost:
+		var c types.McpClient
+		_ = json.NewDecoder(r.Body).Decode(&c)
+		f.clients = append(f.clients, &c)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"access_token": "token-` + c.Name + `"}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// useFakeRegistry points the API client to the given registry for the duration of a test
+func useFakeRegistry(t *testing.T, f *fakeRegistry) {
+	server := httptest.NewServer(f)
+	originalClient := apiClient
+	apiClient = client.NewClient(server.URL)
+	t.Cleanup(func() {
+		apiClient = originalClient
+		server.Close()
+	})
+}
+
+func TestParseRegistryEntityKinds(t *testing.T) {
+	kinds, err := parseRegistryEntityKinds("")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 3, len(kinds))
+
+	kinds, err = parseRegistryEntityKinds("clients, Servers")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 2, len(kinds))
+	// kinds are returned in the order they must be imported
+	testhelpers.AssertEqual(t, registryEntityServers, kinds[0])
+	testhelpers.AssertEqual(t, registryEntityClients, kinds[1])
+
+	_, err = parseRegistryEntityKinds("servers,users")
+	testhelpers.AssertError(t, err)
+	testhelpers.AssertStringContains(t, err.Error(), "invalid value for --only flag: 'users'")
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	source := &fakeRegistry{
+		enterprise: true,
+		servers: []*types.McpServer{
+			{Name: "time", Transport: "stdio", Command: "uvx", Args: []string{"mcp-server-time"}},
+			{Name: "context7", Transport: "streamable_http", URL: "https://mcp.context7.com/mcp"},
+		},
+		groups: []*types.ToolGroup{
+			{Name: "claude", IncludedServers: []string{"time"}, ExcludedTools: []string{"time__convert_time"}},
+		},
+		clients: []*types.McpClient{{Name: "cursor", AllowList: []string{"time"}}},
+	}
+	useFakeRegistry(t, source)
+
+	file := filepath.Join(t.TempDir(), "registry.json")
+	exportCmdFile = file
+	defer func() { exportCmdFile = "" }()
+	testhelpers.AssertNoError(t, runExport(exportCmd, nil))
+
+	data, err := os.ReadFile(file)
+	testhelpers.AssertNoError(t, err)
+	var export registryExport
+	testhelpers.AssertNoError(t, json.Unmarshal(data, &export))
+	testhelpers.AssertEqual(t, 2, len(export.Servers))
+	testhelpers.AssertEqual(t, 1, len(export.ToolGroups))
+	testhelpers.AssertEqual(t, "time__convert_time", export.ToolGroups[0].ExcludedTools[0])
+	testhelpers.AssertEqual(t, 1, len(export.McpClients))
+
+	// the target registry already has the time server
+	target := &fakeRegistry{
+		enterprise: true,
+		servers:    []*types.McpServer{{Name: "time", Transport: "stdio", Command: "npx"}},
+	}
+	useFakeRegistry(t, target)
+
+	var out bytes.Buffer
+	importCmd.SetOut(&out)
+	defer importCmd.SetOut(nil)
+	testhelpers.AssertNoError(t, runImport(importCmd, []string{file}))
+
+	testhelpers.AssertStringContains(t, out.String(), "Skipped server time, it already exists")
+	testhelpers.AssertStringContains(t, out.String(), "Imported server context7")
+	testhelpers.AssertStringContains(t, out.String(), "Imported tool group claude")
+	testhelpers.AssertStringContains(t, out.String(), "access token: token-cursor")
+	testhelpers.AssertStringContains(t, out.String(), "3 imported, 1 skipped, 0 failed")
+
+	testhelpers.AssertEqual(t, 2, len(target.servers))
+	// existing entities are never modified
+	testhelpers.AssertEqual(t, "npx", target.servers[0].Command)
+	testhelpers.AssertEqual(t, "time", target.groups[0].IncludedServers[0])
+	testhelpers.AssertEqual(t, "cursor", target.clients[0].Name)
+}
+
+func TestExportOnly(t *testing.T) {
+	useFakeRegistry(t, &fakeRegistry{
+		servers: []*types.McpServer{{Name: "time", Transport: "stdio", Command: "uvx"}},
+		groups:  []*types.ToolGroup{{Name: "claude"}},
+	})
+
+	var out bytes.Buffer
+	exportCmd.SetOut(&out)
+	defer exportCmd.SetOut(nil)
+
+	t.Run("all kinds in development mode", func(t *testing.T) {
+		out.Reset()
+		// MCP clients are skipped since the registry isn't in enterprise mode
+		testhelpers.AssertNoError(t, runExport(exportCmd, nil))
+		var export registryExport
+		testhelpers.AssertNoError(t, json.Unmarshal(out.Bytes(), &export))
+		testhelpers.AssertEqual(t, 1, len(export.Servers))
+		testhelpers.AssertEqual(t, 1, len(export.ToolGroups))
+		testhelpers.AssertEqual(t, 0, len(export.McpClients))
+	})
+
+	t.Run("selected kinds", func(t *testing.T) {
+		out.Reset()
+		exportCmdOnly = "groups"
+		defer func() { exportCmdOnly = "" }()
+
+		testhelpers.AssertNoError(t, runExport(exportCmd, nil))
+		var export registryExport
+		testhelpers.AssertNoError(t, json.Unmarshal(out.Bytes(), &export))
+		testhelpers.AssertEqual(t, 0, len(export.Servers))
+		testhelpers.AssertEqual(t, 1, len(export.ToolGroups))
+	})
+
+	t.Run("clients explicitly selected in development mode", func(t *testing.T) {
+		exportCmdOnly = "clients"
+		defer func() { exportCmdOnly = "" }()
+
+		err := runExport(exportCmd, nil)
+		testhelpers.AssertError(t, err)
+		testhelpers.AssertStringContains(t, err.Error(), "only allowed in enterprise mode")
+	})
+}
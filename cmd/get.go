@@ -3,14 +3,18 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"github.com/spf13/cobra"
 )
 
 var getCmd = &cobra.Command{
 	Use:   "get",
-	Short: "Get entities like Prompts and Tool Groups",
+	Short: "Get entities like Tools, Prompts and Tool Groups",
 	Annotations: map[string]string{
 		"group": string(subCommandGroupAdvanced),
 		"order": "1",
@@ -28,6 +32,17 @@ var getGroupCmd = &cobra.Command{
 	RunE: runGetGroup,
 }
 
+var getToolCmd = &cobra.Command{
+	Use:   "tool [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Get all the details of a tool",
+	Long: "Get all the details of a tool by its canonical name: its description, the MCP server providing it,\n" +
+		"whether it is enabled, the hints (annotations) given by the server, its input & output schemas\n" +
+		"and the tool groups that include it.\n" +
+		"To only see how to call the tool, use the `usage` command instead.",
+	RunE: runGetTool,
+}
+
 var getPromptCmd = &cobra.Command{
 	Use:   "prompt [name]",
 	Args:  cobra.ExactArgs(1),
@@ -42,6 +57,31 @@ var getPromptCmd = &cobra.Command{
 	RunE: runGetPrompt,
 }
 
+var getTeamCmd = &cobra.Command{
+	Use:   "team [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Get the members & permissions of a team (Enterprise mode)",
+	RunE:  runGetTeam,
+}
+
+var getConsumersCmd = &cobra.Command{
+	Use:   "consumers [server]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Get the MCP clients & tool groups that reference an MCP server",
+	Long: "List the MCP clients allowed to access an MCP server and the tool groups that expose its tools.\n" +
+		"They lose access to the server's tools if it is deregistered, so check them before deregistering it.",
+	RunE: runGetConsumers,
+}
+
+var getJobCmd = &cobra.Command{
+	Use:   "job [id]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Get the status of an asynchronous tool invocation",
+	Long: "Get the status of a tool invocation started with 'mcpjungle invoke --async'.\n" +
+		"Once the job has succeeded, the result of the tool call is printed as well.",
+	RunE: runGetJob,
+}
+
 func init() {
 	getPromptCmd.Flags().StringToStringVar(
 		&getPromptArgs,
@@ -50,8 +90,13 @@ func init() {
 		"Arguments to pass to the prompt (this flag can be specified multiple times)",
 	)
 
+	getCmd.AddCommand(getToolCmd)
 	getCmd.AddCommand(getGroupCmd)
 	getCmd.AddCommand(getPromptCmd)
+	getCmd.AddCommand(getJobCmd)
+	getCmd.AddCommand(getTeamCmd)
+	getCmd.AddCommand(getConsumersCmd)
+	addOutputFlag(getCmd)
 	rootCmd.AddCommand(getCmd)
 }
 
@@ -62,11 +107,19 @@ func runGetGroup(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get tool group: %w", err)
 	}
 
+	if ok, err := printStructured(cmd, group); ok || err != nil {
+		return err
+	}
+
 	cmd.Println(group.Name)
 	if group.Description != "" {
 		cmd.Println()
 		cmd.Println("Description: " + group.Description)
 	}
+	if group.ReadOnly {
+		cmd.Println()
+		cmd.Println("Read-only: tools annotated as destructive or not read-only are not exposed by this group")
+	}
 
 	cmd.Println()
 	cmd.Println("MCP Server streamable http endpoint:")
@@ -109,6 +162,14 @@ func runGetGroup(cmd *cobra.Command, args []string) error {
 	}
 	cmd.Println()
 
+	if len(group.CachedTools) > 0 {
+		cmd.Println("Cached Tools:")
+		for i, t := range slices.Sorted(maps.Keys(group.CachedTools)) {
+			cmd.Printf("%d. %s (ttl: %s)\n", i+1, t, group.CachedTools[t])
+		}
+		cmd.Println()
+	}
+
 	cmd.Println(
 		"NOTE: If a tool in this group is disabled globally or has been deleted, " +
 			"then it will not be available via the group's MCP endpoint.",
@@ -117,6 +178,143 @@ func runGetGroup(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// toolDetails is the output of 'get tool', the tool along with the tool groups that include it
+type toolDetails struct {
+	*types.Tool
+	Server string   `json:"server"`
+	Groups []string `json:"groups"`
+}
+
+func runGetTool(cmd *cobra.Command, args []string) error {
+	tool, err := apiClient.GetTool(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get tool '%s': %w", args[0], err)
+	}
+	server, _, _ := strings.Cut(tool.Name, "__")
+	details := &toolDetails{Tool: tool, Server: server, Groups: []string{}}
+
+	groups, err := apiClient.ListToolGroups()
+	if err != nil {
+		return fmt.Errorf("failed to list tool groups: %w", err)
+	}
+	// groups that include the tool but don't expose it because it may modify its environment
+	var hidden []string
+	for _, g := range groups {
+		group, err := apiClient.GetToolGroup(g.Name)
+		if err != nil {
+			return fmt.Errorf("failed to get tool group '%s': %w", g.Name, err)
+		}
+		if !groupIncludesTool(group.ToolGroup, tool.Name) {
+			continue
+		}
+		details.Groups = append(details.Groups, group.Name)
+		if group.ReadOnly && isWriteTool(tool) {
+			hidden = append(hidden, group.Name)
+		}
+	}
+
+	if ok, err := printStructured(cmd, details); ok || err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	status := "ENABLED"
+	if !tool.Enabled {
+		status = "DISABLED"
+	}
+	fmt.Fprintf(w, "%s  [%s]\n", tool.Name, status)
+	if tool.Description != "" {
+		fmt.Fprintln(w, tool.Description)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "MCP server: "+server)
+
+	fmt.Fprintln(w)
+	if a := tool.Annotations; a == nil || *a == (types.ToolAnnotations{}) {
+		fmt.Fprintln(w, "Annotations: None")
+	} else {
+		fmt.Fprintln(w, "Annotations:")
+		if a.Title != "" {
+			fmt.Fprintln(w, "  Title: "+a.Title)
+		}
+		fmt.Fprintln(w, "  Read-only: "+formatHint(a.ReadOnlyHint))
+		fmt.Fprintln(w, "  Destructive: "+formatHint(a.DestructiveHint))
+		fmt.Fprintln(w, "  Idempotent: "+formatHint(a.IdempotentHint))
+		fmt.Fprintln(w, "  Open world: "+formatHint(a.OpenWorldHint))
+	}
+
+	fmt.Fprintln(w)
+	if len(tool.InputSchema.Properties) == 0 {
+		fmt.Fprintln(w, "Input Parameters: None")
+	} else {
+		fmt.Fprintln(w, "Input Parameters:")
+		printToolParams(w, toolParams(tool.InputSchema))
+	}
+
+	fmt.Fprintln(w)
+	if len(tool.OutputSchema) == 0 {
+		fmt.Fprintln(w, "Output Schema: None")
+	} else {
+		j, err := json.MarshalIndent(tool.OutputSchema, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode output schema: %w", err)
+		}
+		fmt.Fprintln(w, "Output Schema:")
+		fmt.Fprintln(w, string(j))
+	}
+
+	fmt.Fprintln(w)
+	if len(details.Groups) == 0 {
+		fmt.Fprintln(w, "Tool Groups: None")
+	} else {
+		fmt.Fprintln(w, "Tool Groups:")
+		for i, g := range details.Groups {
+			if slices.Contains(hidden, g) {
+				fmt.Fprintf(w, "%d. %s (not exposed, the group is read-only)\n", i+1, g)
+			} else {
+				fmt.Fprintf(w, "%d. %s\n", i+1, g)
+			}
+		}
+	}
+
+	return nil
+}
+
+// groupIncludesTool returns true if the tool is included in the group,
+// either explicitly or because the group includes all the tools of its MCP server.
+func groupIncludesTool(g *types.ToolGroup, name string) bool {
+	if slices.Contains(g.IncludedTools, name) {
+		return true
+	}
+	server, _, _ := strings.Cut(name, "__")
+	return slices.Contains(g.IncludedServers, server) && !slices.Contains(g.ExcludedTools, name)
+}
+
+// isWriteTool returns true if the tool's annotations indicate that it may modify its environment,
+// in which case read-only groups don't expose it.
+func isWriteTool(t *types.Tool) bool {
+	a := t.Annotations
+	if a == nil {
+		return false
+	}
+	if a.DestructiveHint != nil && *a.DestructiveHint {
+		return true
+	}
+	return a.ReadOnlyHint != nil && !*a.ReadOnlyHint
+}
+
+// formatHint formats a tool annotation hint, which is unknown if the MCP server didn't provide it.
+func formatHint(h *bool) string {
+	switch {
+	case h == nil:
+		return "unknown"
+	case *h:
+		return "yes"
+	default:
+		return "no"
+	}
+}
+
 func runGetPrompt(cmd *cobra.Command, args []string) error {
 	name := args[0]
 
@@ -131,6 +329,10 @@ func runGetPrompt(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get prompt: %w", err)
 	}
 
+	if ok, err := printStructured(cmd, result); ok || err != nil {
+		return err
+	}
+
 	// Pretty print the result
 	cmd.Printf("Prompt: %s\n", name)
 	if result.Description != "" {
@@ -154,3 +356,110 @@ func runGetPrompt(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runGetJob(cmd *cobra.Command, args []string) error {
+	job, err := apiClient.GetJob(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get job: %w", err)
+	}
+
+	if ok, err := printStructured(cmd, job); ok || err != nil {
+		return err
+	}
+
+	cmd.Printf("Job ID: %s\n", job.ID)
+	cmd.Printf("Tool: %s\n", job.Tool)
+	cmd.Printf("Status: %s\n", job.Status)
+	cmd.Printf("Created at: %s\n", job.CreatedAt.Format(time.RFC3339))
+	if job.StartedAt != nil {
+		cmd.Printf("Started at: %s\n", job.StartedAt.Format(time.RFC3339))
+	}
+	if job.CompletedAt != nil {
+		cmd.Printf("Completed at: %s\n", job.CompletedAt.Format(time.RFC3339))
+	}
+
+	switch {
+	case job.Status == types.JobStatusFailed:
+		cmd.Println()
+		return fmt.Errorf("job failed: %s", job.Error)
+	case job.Result != nil:
+		cmd.Println()
+		return printToolInvokeResult(cmd, job.Result, defaultArtifactOptions())
+	case !job.Status.IsTerminal():
+		cmd.Println()
+		cmd.Println("The job has not completed yet, run this command again later to get its result.")
+	}
+	return nil
+}
+
+func runGetTeam(cmd *cobra.Command, args []string) error {
+	t, err := apiClient.GetTeam(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get team: %w", err)
+	}
+
+	if ok, err := printStructured(cmd, t); ok || err != nil {
+		return err
+	}
+
+	cmd.Println(t.Name)
+	if t.Description != "" {
+		cmd.Println()
+		cmd.Println("Description: " + t.Description)
+	}
+	printTeamPermissions(cmd, t)
+	return nil
+}
+
+func runGetConsumers(cmd *cobra.Command, args []string) error {
+	consumers, err := apiClient.GetServerConsumers(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get the consumers of MCP server %s: %w", args[0], err)
+	}
+
+	if ok, err := printStructured(cmd, consumers); ok || err != nil {
+		return err
+	}
+
+	if len(consumers.McpClients) == 0 {
+		cmd.Println("MCP clients: None")
+	} else {
+		cmd.Println("MCP clients:")
+		for i, name := range consumers.McpClients {
+			cmd.Printf("%d. %s\n", i+1, name)
+		}
+	}
+	cmd.Println()
+
+	if len(consumers.ToolGroups) == 0 {
+		cmd.Println("Tool groups: None")
+	} else {
+		cmd.Println("Tool groups:")
+		for i, g := range consumers.ToolGroups {
+			cmd.Printf("%d. %s (%s)\n", i+1, g.Name, strings.Join(g.Tools, ", "))
+		}
+	}
+	return nil
+}
+
+// printTeamPermissions prints the members of a team and the entities they may administer.
+func printTeamPermissions(cmd *cobra.Command, t *types.Team) {
+	for _, list := range []struct {
+		title string
+		names []string
+	}{
+		{"Members", t.Members},
+		{"Tool groups administered", t.ToolGroups},
+		{"MCP servers administered", t.Servers},
+	} {
+		cmd.Println()
+		if len(list.names) == 0 {
+			cmd.Printf("%s: None\n", list.title)
+			continue
+		}
+		cmd.Printf("%s:\n", list.title)
+		for i, name := range list.names {
+			cmd.Printf("%d. %s\n", i+1, name)
+		}
+	}
+}
@@ -1,15 +1,22 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/mcpjungle/mcpjungle/client"
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
 
 func TestGetCommandStructure(t *testing.T) {
 	t.Run("command_properties", func(t *testing.T) {
 		testhelpers.AssertEqual(t, "get", getCmd.Use)
-		testhelpers.AssertEqual(t, "Get entities like Prompts and Tool Groups", getCmd.Short)
+		testhelpers.AssertEqual(t, "Get entities like Tools, Prompts and Tool Groups", getCmd.Short)
 	})
 
 	t.Run("command_annotations", func(t *testing.T) {
@@ -48,3 +55,76 @@ func TestGetGroupSubcommand(t *testing.T) {
 		}
 	})
 }
+
+func TestGetJobSubcommand(t *testing.T) {
+	testhelpers.AssertEqual(t, "job [id]", getJobCmd.Use)
+	testhelpers.AssertEqual(t, "Get the status of an asynchronous tool invocation", getJobCmd.Short)
+	testhelpers.AssertNotNil(t, getJobCmd.RunE)
+	testhelpers.AssertNotNil(t, getJobCmd.Args)
+	testhelpers.AssertTrue(t, getJobCmd.Args(getJobCmd, []string{}) != nil, "job ID should be required")
+	testhelpers.AssertNoError(t, getJobCmd.Args(getJobCmd, []string{"some-id"}))
+
+	found := false
+	for _, c := range getCmd.Commands() {
+		if c == getJobCmd {
+			found = true
+		}
+	}
+	testhelpers.AssertTrue(t, found, "job subcommand should be registered under get")
+}
+
+func TestGetTool(t *testing.T) {
+	readOnly := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v1/tool":
+			_ = json.NewEncoder(w).Encode(types.Tool{
+				Name:        "github__create_issue",
+				Enabled:     true,
+				Description: "Create an issue",
+				InputSchema: types.ToolInputSchema{
+					Type:       "object",
+					Properties: map[string]any{"title": map[string]any{"type": "string"}},
+					Required:   []string{"title"},
+				},
+				OutputSchema: map[string]any{"type": "object"},
+				Annotations:  &types.ToolAnnotations{ReadOnlyHint: &readOnly},
+			})
+		case r.URL.Path == "/api/v1/tool-groups":
+			_ = json.NewEncoder(w).Encode([]types.ToolGroup{{Name: "all"}, {Name: "reader"}, {Name: "other"}})
+		case strings.HasPrefix(r.URL.Path, "/api/v1/tool-groups/"):
+			groups := map[string]*types.ToolGroup{
+				"all":    {Name: "all", IncludedServers: []string{"github"}},
+				"reader": {Name: "reader", IncludedTools: []string{"github__create_issue"}, ReadOnly: true},
+				"other":  {Name: "other", IncludedServers: []string{"github"}, ExcludedTools: []string{"github__create_issue"}},
+			}
+			_ = json.NewEncoder(w).Encode(types.GetToolGroupResponse{
+				ToolGroup: groups[strings.TrimPrefix(r.URL.Path, "/api/v1/tool-groups/")],
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	originalClient := apiClient
+	apiClient = client.NewClient(server.URL)
+	defer func() { apiClient = originalClient }()
+
+	var out bytes.Buffer
+	getToolCmd.SetOut(&out)
+	defer getToolCmd.SetOut(nil)
+
+	testhelpers.AssertNoError(t, runGetTool(getToolCmd, []string{"github__create_issue"}))
+	for _, expected := range []string{
+		"github__create_issue  [ENABLED]\nCreate an issue\n",
+		"MCP server: github",
+		"  Read-only: no\n  Destructive: unknown",
+		"title  string  yes",
+		"Output Schema:\n{\n  \"type\": \"object\"\n}",
+		"Tool Groups:\n1. all\n2. reader (not exposed, the group is read-only)\n",
+	} {
+		testhelpers.AssertStringContains(t, out.String(), expected)
+	}
+	testhelpers.AssertFalse(t, strings.Contains(out.String(), "other"), "excluded tools must not be listed in the group")
+}
@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// groupWizard builds a tool group by asking the user questions on the standard input,
+// so that they can pick servers & tools from numbered lists instead of writing their names in a JSON file.
+type groupWizard struct {
+	in  *bufio.Reader
+	out io.Writer
+
+	servers []*types.McpServer
+	tools   []*types.Tool
+}
+
+func newGroupWizard(cmd *cobra.Command, servers []*types.McpServer, tools []*types.Tool) *groupWizard {
+	return &groupWizard{
+		in: bufio.NewReader(cmd.InOrStdin()),
+		// the questions are written to stderr so that they are still shown with --quiet
+		out:     cmd.ErrOrStderr(),
+		servers: servers,
+		tools:   tools,
+	}
+}
+
+// ask prints a question and returns the trimmed answer.
+// It fails if the standard input is closed before an answer is given.
+func (w *groupWizard) ask(question string) (string, error) {
+	fmt.Fprint(w.out, question)
+	line, err := w.in.ReadString('\n')
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("failed to read answer: %w", err)
+		}
+		if line == "" {
+			fmt.Fprintln(w.out)
+			return "", fmt.Errorf("aborted, the standard input was closed")
+		}
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// askYesNo asks a yes/no question, an empty answer returns the default.
+func (w *groupWizard) askYesNo(question string, def bool) (bool, error) {
+	choices := "[y/N]"
+	if def {
+		choices = "[Y/n]"
+	}
+	for {
+		answer, err := w.ask(question + " " + choices + ": ")
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		fmt.Fprintln(w.out, "Please answer y or n")
+	}
+}
+
+// selectItems prints a numbered list of items and returns the indexes of those the user selects.
+// It asks again until the selection is valid.
+func (w *groupWizard) selectItems(title string, items []string) ([]int, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+	fmt.Fprintln(w.out)
+	fmt.Fprintln(w.out, title)
+	for i, item := range items {
+		fmt.Fprintf(w.out, "  %3d) %s\n", i+1, item)
+	}
+	for {
+		answer, err := w.ask("Enter numbers or ranges (eg- 1,3,5-7), 'all' or leave empty for none: ")
+		if err != nil {
+			return nil, err
+		}
+		selected, err := parseSelection(answer, len(items))
+		if err == nil {
+			return selected, nil
+		}
+		fmt.Fprintln(w.out, err)
+	}
+}
+
+// run asks all the questions and returns the group described by the answers.
+func (w *groupWizard) run() (*types.ToolGroup, error) {
+	g := &types.ToolGroup{}
+
+	for g.Name == "" {
+		name, err := w.ask("Name of the group: ")
+		if err != nil {
+			return nil, err
+		}
+		g.Name = name
+	}
+	description, err := w.ask("Description (optional): ")
+	if err != nil {
+		return nil, err
+	}
+	g.Description = description
+
+	serverItems := make([]string, len(w.servers))
+	for i, s := range w.servers {
+		serverItems[i] = fmt.Sprintf("%s (%d tools)", s.Name, len(w.serverTools(s.Name)))
+	}
+	selected, err := w.selectItems("Include all the tools of these MCP servers:", serverItems)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range selected {
+		g.IncludedServers = append(g.IncludedServers, w.servers[i].Name)
+	}
+
+	// tools of the included servers can be excluded, those of the other servers can be included individually
+	var fromIncluded, others []*types.Tool
+	for _, t := range w.tools {
+		server, _, _ := strings.Cut(t.Name, "__")
+		if slices.Contains(g.IncludedServers, server) {
+			fromIncluded = append(fromIncluded, t)
+		} else {
+			others = append(others, t)
+		}
+	}
+
+	selected, err = w.selectItems("Exclude these tools of the servers selected above:", toolItems(fromIncluded))
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range selected {
+		g.ExcludedTools = append(g.ExcludedTools, fromIncluded[i].Name)
+	}
+
+	selected, err = w.selectItems("Include these individual tools:", toolItems(others))
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range selected {
+		g.IncludedTools = append(g.IncludedTools, others[i].Name)
+	}
+
+	fmt.Fprintln(w.out)
+	if g.ReadOnly, err = w.askYesNo("Leave out the tools that can modify data (read-only group)?", false); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// serverTools returns the tools of the given server.
+func (w *groupWizard) serverTools(server string) []*types.Tool {
+	var tools []*types.Tool
+	for _, t := range w.tools {
+		if s, _, _ := strings.Cut(t.Name, "__"); s == server {
+			tools = append(tools, t)
+		}
+	}
+	return tools
+}
+
+// effectiveTools returns the names of the tools the group would expose, in the order they are listed by the registry.
+// It mirrors how the server resolves a group: included tools & servers, minus the excluded tools,
+// minus the tools that can modify data if the group is read-only.
+func (w *groupWizard) effectiveTools(g *types.ToolGroup) []string {
+	var names []string
+	for _, t := range w.tools {
+		server, _, _ := strings.Cut(t.Name, "__")
+		included := slices.Contains(g.IncludedTools, t.Name) || slices.Contains(g.IncludedServers, server)
+		if !included || slices.Contains(g.ExcludedTools, t.Name) {
+			continue
+		}
+		if g.ReadOnly && isWriteTool(t) {
+			continue
+		}
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// preview prints the tools the group would expose.
+func (w *groupWizard) preview(g *types.ToolGroup) {
+	tools := w.effectiveTools(g)
+	fmt.Fprintln(w.out)
+	fmt.Fprintf(w.out, "Tool group %s will expose %d tools:\n", g.Name, len(tools))
+	for _, t := range tools {
+		fmt.Fprintln(w.out, "  "+t)
+	}
+}
+
+func toolItems(tools []*types.Tool) []string {
+	items := make([]string, len(tools))
+	for i, t := range tools {
+		items[i] = t.Name
+		if !t.Enabled {
+			items[i] += " (disabled)"
+		}
+	}
+	return items
+}
+
+// parseSelection parses a selection like "1,3,5-7" of items numbered from 1 to n and returns their 0-based indexes.
+// "all" selects every item and an empty selection selects none.
+func parseSelection(s string, n int) ([]int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	if strings.EqualFold(s, "all") {
+		all := make([]int, n)
+		for i := range all {
+			all[i] = i
+		}
+		return all, nil
+	}
+
+	var selected []int
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		lo, hi, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(strings.TrimSpace(lo))
+		end := start
+		if err == nil && isRange {
+			end, err = strconv.Atoi(strings.TrimSpace(hi))
+		}
+		if err != nil || start < 1 || end > n || start > end {
+			return nil, fmt.Errorf("invalid selection: '%s', expected numbers between 1 and %d", part, n)
+		}
+		for i := start - 1; i < end; i++ {
+			if !slices.Contains(selected, i) {
+				selected = append(selected, i)
+			}
+		}
+	}
+	slices.Sort(selected)
+	return selected, nil
+}
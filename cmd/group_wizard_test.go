@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/client"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestParseSelection(t *testing.T) {
+	selected, err := parseSelection("", 5)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 0, len(selected))
+
+	selected, err = parseSelection("all", 3)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 3, len(selected))
+
+	selected, err = parseSelection("4, 1-2,2", 5)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 3, len(selected))
+	testhelpers.AssertEqual(t, 0, selected[0])
+	testhelpers.AssertEqual(t, 1, selected[1])
+	testhelpers.AssertEqual(t, 3, selected[2])
+
+	for _, invalid := range []string{"0", "6", "3-1", "a", "1-x"} {
+		_, err = parseSelection(invalid, 5)
+		testhelpers.AssertError(t, err)
+	}
+}
+
+func TestCreateToolGroupInteractive(t *testing.T) {
+	destructive := true
+	var created types.ToolGroup
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/servers":
+			_ = json.NewEncoder(w).Encode([]*types.McpServer{{Name: "github"}, {Name: "time"}})
+		case "/api/v1/tools":
+			_ = json.NewEncoder(w).Encode([]*types.Tool{
+				{Name: "github__search_issues", Enabled: true},
+				{Name: "github__create_issue", Enabled: true},
+				{Name: "github__delete_repo", Enabled: true, Annotations: &types.ToolAnnotations{DestructiveHint: &destructive}},
+				{Name: "time__get_current_time", Enabled: true},
+				{Name: "time__convert_time", Enabled: false},
+			})
+		case "/api/v1/tool-groups":
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(types.ToolGroupEndpoints{StreamableHTTPEndpoint: "http://localhost/v0/groups/claude/mcp"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+	apiClient = client.NewClient(server.URL)
+
+	createToolGroupCmdInteractive = true
+	defer func() { createToolGroupCmdInteractive = false }()
+
+	var out, errOut bytes.Buffer
+	createToolGroupCmd.SetOut(&out)
+	createToolGroupCmd.SetErr(&errOut)
+	defer createToolGroupCmd.SetOut(nil)
+	defer createToolGroupCmd.SetErr(nil)
+	defer createToolGroupCmd.SetIn(nil)
+
+	t.Run("create", func(t *testing.T) {
+		// name, description, include github, exclude create_issue (after an invalid answer),
+		// include get_current_time, read-only, confirm
+		createToolGroupCmd.SetIn(strings.NewReader("claude\nfor claude\n1\n9\n2\n1\ny\n\n"))
+
+		testhelpers.AssertNoError(t, runCreateToolGroup(createToolGroupCmd, nil))
+
+		testhelpers.AssertEqual(t, "claude", created.Name)
+		testhelpers.AssertEqual(t, "for claude", created.Description)
+		testhelpers.AssertEqual(t, "github", created.IncludedServers[0])
+		testhelpers.AssertEqual(t, "github__create_issue", created.ExcludedTools[0])
+		testhelpers.AssertEqual(t, "time__get_current_time", created.IncludedTools[0])
+		testhelpers.AssertTrue(t, created.ReadOnly, "expected a read-only group")
+
+		testhelpers.AssertStringContains(t, errOut.String(), "invalid selection: '9'")
+		testhelpers.AssertStringContains(t, errOut.String(), "time__convert_time (disabled)")
+		// the destructive tool is left out of the read-only group
+		testhelpers.AssertStringContains(t, errOut.String(), "will expose 2 tools:\n  github__search_issues\n  time__get_current_time\n")
+		testhelpers.AssertStringContains(t, out.String(), "Tool Group claude created successfully")
+	})
+
+	t.Run("declined", func(t *testing.T) {
+		created = types.ToolGroup{}
+		// all servers are selected, so no individual tools are offered
+		createToolGroupCmd.SetIn(strings.NewReader("claude\n\nall\n\n\nn\n"))
+
+		err := runCreateToolGroup(createToolGroupCmd, nil)
+		testhelpers.AssertError(t, err)
+		testhelpers.AssertStringContains(t, err.Error(), "the group was not created")
+		testhelpers.AssertEqual(t, "", created.Name)
+	})
+
+	t.Run("closed input", func(t *testing.T) {
+		createToolGroupCmd.SetIn(strings.NewReader("claude\n"))
+
+		err := runCreateToolGroup(createToolGroupCmd, nil)
+		testhelpers.AssertError(t, err)
+		testhelpers.AssertStringContains(t, err.Error(), "standard input was closed")
+	})
+}
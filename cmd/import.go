@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var importCmdOnly string
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import MCP servers, tool groups & MCP clients from a file",
+	Long: "Import the MCP servers, tool groups & MCP clients from a file written by 'mcpjungle export'.\n" +
+		"The file can also be written in YAML, with the same fields.\n" +
+		"Use '-' as the file to read it from the standard input.\n\n" +
+		"Servers are registered first, then tool groups, then MCP clients, since groups refer to the servers' tools.\n" +
+		"Entities that already exist in the registry are skipped, they are never modified.\n" +
+		"The access tokens generated for the imported MCP clients are printed, since they can't be retrieved later.",
+	Example: "  mcpjungle import registry.json\n" +
+		"  mcpjungle export --registry http://dev:8080 | mcpjungle import - --registry http://prod:8080",
+	Args: cobra.ExactArgs(1),
+	RunE: runImport,
+	Annotations: map[string]string{
+		"group": string(subCommandGroupAdvanced),
+		"order": "20",
+	},
+}
+
+func init() {
+	importCmd.Flags().StringVar(
+		&importCmdOnly,
+		"only",
+		"",
+		"Comma-separated list of the kinds of entities to import: 'servers', 'groups' and/or 'clients' (default: all)",
+	)
+
+	rootCmd.AddCommand(importCmd)
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	kinds, err := parseRegistryEntityKinds(importCmdOnly)
+	if err != nil {
+		return err
+	}
+
+	export, err := readRegistryFile(cmd, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read import file: %w", err)
+	}
+
+	w := cmd.OutOrStdout()
+	var imported, skipped, failed int
+	report := func(kind, name string, err error) {
+		if err != nil {
+			failed++
+			cmd.PrintErrf("Failed to import %s %s: %v\n", kind, name, err)
+			return
+		}
+		imported++
+		fmt.Fprintf(w, "Imported %s %s\n", kind, name)
+	}
+	skip := func(kind, name string) {
+		skipped++
+		fmt.Fprintf(w, "Skipped %s %s, it already exists\n", kind, name)
+	}
+
+	if slices.Contains(kinds, registryEntityServers) && len(export.Servers) > 0 {
+		servers, err := apiClient.ListServers()
+		if err != nil {
+			return fmt.Errorf("failed to list servers: %w", err)
+		}
+		existing := make(map[string]bool, len(servers))
+		for _, s := range servers {
+			existing[s.Name] = true
+		}
+		for _, s := range export.Servers {
+			if existing[s.Name] {
+				skip("server", s.Name)
+				continue
+			}
+			_, err := apiClient.RegisterServer(s)
+			report("server", s.Name, err)
+		}
+	}
+
+	if slices.Contains(kinds, registryEntityGroups) && len(export.ToolGroups) > 0 {
+		groups, err := apiClient.ListToolGroups()
+		if err != nil {
+			return fmt.Errorf("failed to list tool groups: %w", err)
+		}
+		existing := make(map[string]bool, len(groups))
+		for _, g := range groups {
+			existing[g.Name] = true
+		}
+		for _, g := range export.ToolGroups {
+			if existing[g.Name] {
+				skip("tool group", g.Name)
+				continue
+			}
+			_, err := apiClient.CreateToolGroup(g)
+			report("tool group", g.Name, err)
+		}
+	}
+
+	if slices.Contains(kinds, registryEntityClients) && len(export.McpClients) > 0 {
+		clients, err := apiClient.ListMcpClients()
+		if err != nil {
+			return fmt.Errorf("failed to list MCP clients: %w", err)
+		}
+		existing := make(map[string]bool, len(clients))
+		for _, c := range clients {
+			existing[c.Name] = true
+		}
+		for _, c := range export.McpClients {
+			if existing[c.Name] {
+				skip("MCP client", c.Name)
+				continue
+			}
+			token, err := apiClient.CreateMcpClient(c)
+			report("MCP client", c.Name, err)
+			if err == nil {
+				fmt.Fprintf(w, "  access token: %s\n", token)
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "\n%d imported, %d skipped, %d failed\n", imported, skipped, failed)
+	if failed > 0 {
+		return fmt.Errorf("failed to import %d entities", failed)
+	}
+	return nil
+}
+
+// readRegistryFile reads a file in the format written by 'mcpjungle export', or its YAML equivalent.
+// The path '-' reads the file from the command's standard input.
+func readRegistryFile(cmd *cobra.Command, path string) (*registryExport, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON is valid YAML, so both formats are decoded as YAML, then converted to JSON to use the json tags
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse file: %w", err)
+	}
+	j, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse file: %w", err)
+	}
+	var export registryExport
+	if err := json.Unmarshal(j, &export); err != nil {
+		return nil, fmt.Errorf("failed to parse file: %w", err)
+	}
+	return &export, nil
+}
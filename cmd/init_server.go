@@ -3,16 +3,28 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"os"
 
 	"github.com/mcpjungle/mcpjungle/cmd/config"
 	"github.com/spf13/cobra"
 )
 
+var (
+	initServerCmdAdminTokenOut string
+	initServerCmdNoSave        bool
+)
+
 var initServerCmd = &cobra.Command{
 	Use:   "init-server",
 	Short: "Initialize the MCPJungle Server (for Enterprise Mode only)",
 	Long: "If the MCPJungle Server was started in Enterprise Mode, use this command to initialize the server.\n" +
-		"Initialization is required before you can use the server.\n",
+		"Initialization is required before you can use the server.\n\n" +
+		"The command never prompts, so it can be used to bootstrap a server from a script or pipeline.\n" +
+		"Use --admin-token-out to write the admin access token to a file readable only by you,\n" +
+		"--no-save to leave the client configuration untouched and --output json to get a machine-readable result.\n" +
+		"The token is only included in the json/yaml output if it isn't written to a file.",
+	Example: "  mcpjungle init-server\n" +
+		"  mcpjungle init-server --registry https://mcpjungle.example.com --no-save --admin-token-out ./admin.token -o json",
 	RunE: runInitServer,
 	Annotations: map[string]string{
 		"group": string(subCommandGroupAdvanced),
@@ -20,12 +32,50 @@ var initServerCmd = &cobra.Command{
 	},
 }
 
+// initServerResult is the machine-readable outcome of init-server.
+type initServerResult struct {
+	RegistryURL string `json:"registry_url"`
+	// AdminAccessToken is omitted if the token was written to AdminTokenFile
+	AdminAccessToken string `json:"admin_access_token,omitempty"`
+	AdminTokenFile   string `json:"admin_token_file,omitempty"`
+	// ConfigFile is the client configuration file the token was saved to, if any
+	ConfigFile string `json:"config_file,omitempty"`
+}
+
 func init() {
+	initServerCmd.Flags().StringVar(
+		&initServerCmdAdminTokenOut,
+		"admin-token-out",
+		"",
+		"Write the admin access token to this file (readable only by you), it must not exist already",
+	)
+	initServerCmd.Flags().BoolVar(
+		&initServerCmdNoSave,
+		"no-save",
+		false,
+		"Don't save the admin access token in the client configuration",
+	)
+	addOutputFlag(initServerCmd)
 	rootCmd.AddCommand(initServerCmd)
 }
 
 func runInitServer(cmd *cobra.Command, args []string) error {
-	fmt.Println("Initializing the MCPJungle Server in Enterprise Mode...")
+	format, err := getOutputFormat()
+	if err != nil {
+		return err
+	}
+
+	// the server can only be initialized once, so catch this mistake before doing it
+	if initServerCmdAdminTokenOut != "" {
+		if _, err := os.Stat(initServerCmdAdminTokenOut); err == nil {
+			return fmt.Errorf("admin token file %s already exists", initServerCmdAdminTokenOut)
+		}
+	}
+
+	w := cmd.OutOrStdout()
+	if format == outputFormatTable {
+		fmt.Fprintln(w, "Initializing the MCPJungle Server in Enterprise Mode...")
+	}
 	resp, err := apiClient.InitServer()
 	if err != nil {
 		return fmt.Errorf("failed to initialize the server: %w", err)
@@ -35,21 +85,68 @@ func runInitServer(cmd *cobra.Command, args []string) error {
 		return errors.New("server initialization failed: no admin access token received")
 	}
 
-	// Create new client configuration
-	cfg := &config.ClientConfig{
-		RegistryURL: apiClient.BaseURL(),
-		AccessToken: resp.AdminAccessToken,
+	result := &initServerResult{RegistryURL: apiClient.BaseURL()}
+
+	if initServerCmdAdminTokenOut != "" {
+		if err := writeAdminToken(initServerCmdAdminTokenOut, resp.AdminAccessToken); err != nil {
+			// the server is initialized at this point and the token can't be retrieved again,
+			// so it is shown below instead of the file path
+			cmd.PrintErrf("Warning: failed to write the admin access token: %v\n", err)
+		} else {
+			result.AdminTokenFile = initServerCmdAdminTokenOut
+		}
 	}
-	if err := config.Save(cfg); err != nil {
-		return fmt.Errorf("failed to create client configuration: %w", err)
+
+	if !initServerCmdNoSave {
+		// save the admin credentials in the selected profile, keeping the other profiles
+		cfg := config.Load()
+		cfg.SetProfile(profileName, config.Profile{
+			RegistryURL: apiClient.BaseURL(),
+			AccessToken: resp.AdminAccessToken,
+		})
+		if err := config.Save(cfg); err != nil {
+			return fmt.Errorf("failed to create client configuration: %w", err)
+		}
+
+		cfgPath, err := config.AbsPath()
+		if err != nil {
+			return fmt.Errorf("failed to get client configuration path: %w", err)
+		}
+		result.ConfigFile = cfgPath
 	}
 
-	cfgPath, err := config.AbsPath()
-	if err != nil {
-		return fmt.Errorf("failed to get client configuration path: %w", err)
+	if format != outputFormatTable {
+		if result.AdminTokenFile == "" {
+			result.AdminAccessToken = resp.AdminAccessToken
+		}
+		_, err := printStructured(cmd, result)
+		return err
+	}
+
+	if result.AdminTokenFile != "" {
+		fmt.Fprintln(w, "Your Admin access token has been written to", result.AdminTokenFile)
+	}
+	if result.ConfigFile != "" {
+		fmt.Fprintln(w, "Your Admin access token has been saved to", result.ConfigFile)
+	}
+	if result.AdminTokenFile == "" && result.ConfigFile == "" {
+		fmt.Fprintln(w, "Your Admin access token (it won't be shown again):", resp.AdminAccessToken)
 	}
-	fmt.Println("Your Admin access token has been saved to", cfgPath)
 
-	fmt.Println("All done!")
+	fmt.Fprintln(w, "All done!")
 	return nil
 }
+
+// writeAdminToken writes the admin access token to a new file that is only readable & writable by its owner.
+// It refuses to overwrite an existing file, which may have looser permissions or hold another token.
+func writeAdminToken(path, token string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(token + "\n"); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -1,8 +1,16 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/mcpjungle/mcpjungle/client"
+	"github.com/mcpjungle/mcpjungle/cmd/config"
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
 )
 
@@ -53,3 +61,91 @@ func TestInitServerCommandStructure(t *testing.T) {
 		}
 	})
 }
+
+func TestRunInitServer(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_ = json.NewEncoder(w).Encode(client.InitServerResponse{AdminAccessToken: "admin-token"})
+	}))
+	defer server.Close()
+
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+	apiClient = client.NewClient(server.URL)
+
+	var out bytes.Buffer
+	initServerCmd.SetOut(&out)
+	defer initServerCmd.SetOut(nil)
+
+	reset := func() {
+		out.Reset()
+		initServerCmdAdminTokenOut = ""
+		initServerCmdNoSave = false
+		outputCmdFormat = string(outputFormatTable)
+	}
+
+	t.Run("token saved in the client configuration", func(t *testing.T) {
+		reset()
+		t.Setenv("HOME", t.TempDir())
+
+		testhelpers.AssertNoError(t, runInitServer(initServerCmd, nil))
+		testhelpers.AssertStringContains(t, out.String(), "Your Admin access token has been saved to")
+		testhelpers.AssertEqual(t, "admin-token", config.Load().AccessToken)
+	})
+
+	t.Run("scripted with json output", func(t *testing.T) {
+		reset()
+		defer reset()
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+		initServerCmdAdminTokenOut = filepath.Join(t.TempDir(), "admin.token")
+		initServerCmdNoSave = true
+		outputCmdFormat = string(outputFormatJSON)
+
+		testhelpers.AssertNoError(t, runInitServer(initServerCmd, nil))
+
+		var result initServerResult
+		testhelpers.AssertNoError(t, json.Unmarshal(out.Bytes(), &result))
+		testhelpers.AssertEqual(t, server.URL, result.RegistryURL)
+		testhelpers.AssertEqual(t, initServerCmdAdminTokenOut, result.AdminTokenFile)
+		// the token is only in the file, never in the output
+		testhelpers.AssertEqual(t, "", result.AdminAccessToken)
+		testhelpers.AssertEqual(t, "", result.ConfigFile)
+
+		data, err := os.ReadFile(initServerCmdAdminTokenOut)
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, "admin-token\n", string(data))
+		info, err := os.Stat(initServerCmdAdminTokenOut)
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, os.FileMode(0o600), info.Mode().Perm())
+
+		_, err = os.Stat(filepath.Join(home, config.ClientConfigFileName))
+		testhelpers.AssertTrue(t, os.IsNotExist(err), "expected no client configuration to be saved")
+	})
+
+	t.Run("json output includes the token if it isn't written to a file", func(t *testing.T) {
+		reset()
+		defer reset()
+		t.Setenv("HOME", t.TempDir())
+		initServerCmdNoSave = true
+		outputCmdFormat = string(outputFormatJSON)
+
+		testhelpers.AssertNoError(t, runInitServer(initServerCmd, nil))
+		testhelpers.AssertStringContains(t, out.String(), `"admin_access_token": "admin-token"`)
+	})
+
+	t.Run("existing token file", func(t *testing.T) {
+		reset()
+		defer reset()
+		initServerCmdAdminTokenOut = filepath.Join(t.TempDir(), "admin.token")
+		testhelpers.AssertNoError(t, os.WriteFile(initServerCmdAdminTokenOut, []byte("old"), 0o644))
+		before := requests
+
+		err := runInitServer(initServerCmd, nil)
+		testhelpers.AssertError(t, err)
+		testhelpers.AssertStringContains(t, err.Error(), "already exists")
+		// the server must not be initialized, since the token would be lost
+		testhelpers.AssertEqual(t, before, requests)
+	})
+}
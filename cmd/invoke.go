@@ -1,13 +1,16 @@
 package cmd
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 )
@@ -15,14 +18,23 @@ import (
 var (
 	invokeCmdInput     string
 	invokeCmdGroupName string
+	invokeCmdAsync     bool
+	invokeCmdOutputDir string
+	invokeCmdNoSave    bool
+	invokeCmdTimeout   time.Duration
+	invokeCmdStream    bool
 )
 
 var invokeToolCmd = &cobra.Command{
 	Use:   "invoke <name>",
 	Short: "Invoke a tool",
-	Long:  "Invokes a tool supplied by a registered MCP server",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runInvokeTool,
+	Long: "Invokes a tool supplied by a registered MCP server\n\n" +
+		"Binary content returned by the tool (images, audio & blob resources) is saved to files with generated names,\n" +
+		"in the current directory unless --output-dir is set. Use --no-save to only print its metadata instead.\n\n" +
+		"With --stream, the progress notifications sent by the MCP server while the tool runs are printed to stderr\n" +
+		"as they arrive, instead of waiting silently for the result. Not all MCP servers send progress notifications.",
+	Args: cobra.ExactArgs(1),
+	RunE: runInvokeTool,
 	Annotations: map[string]string{
 		"group": string(subCommandGroupBasic),
 		"order": "5",
@@ -32,6 +44,39 @@ var invokeToolCmd = &cobra.Command{
 func init() {
 	invokeToolCmd.Flags().StringVar(&invokeCmdInput, "input", "{}", "valid JSON payload")
 	invokeToolCmd.Flags().StringVar(&invokeCmdGroupName, "group", "", "invoke the tool within a tool group's context")
+	invokeToolCmd.Flags().BoolVar(
+		&invokeCmdAsync,
+		"async",
+		false,
+		"invoke the tool in the background and print the ID of the job instead of waiting for the result",
+	)
+	invokeToolCmd.Flags().StringVar(
+		&invokeCmdOutputDir,
+		"output-dir",
+		"",
+		"directory where the binary content returned by the tool is saved, created if needed (default: current directory)",
+	)
+	invokeToolCmd.Flags().BoolVar(
+		&invokeCmdNoSave,
+		"no-save",
+		false,
+		"don't save the binary content returned by the tool, only print its metadata",
+	)
+	invokeToolCmd.Flags().DurationVar(
+		&invokeCmdTimeout,
+		"timeout",
+		0,
+		"maximum time to wait for the tool's result, eg- 30s (default: no timeout)",
+	)
+	invokeToolCmd.Flags().BoolVar(
+		&invokeCmdStream,
+		"stream",
+		false,
+		"print the progress notifications of the tool live while waiting for its result",
+	)
+	invokeToolCmd.MarkFlagsMutuallyExclusive("output-dir", "no-save")
+	invokeToolCmd.MarkFlagsMutuallyExclusive("async", "timeout")
+	invokeToolCmd.MarkFlagsMutuallyExclusive("async", "stream")
 	rootCmd.AddCommand(invokeToolCmd)
 }
 
@@ -150,9 +195,45 @@ func getFileExtensionFromMimeType(mimeType string) string {
 	return ".bin"
 }
 
+// artifactOptions controls what happens to the binary content returned by a tool.
+type artifactOptions struct {
+	// dir is the directory where the content is saved
+	dir string
+	// noSave only prints the metadata of the content instead of saving it
+	noSave bool
+	fs     afero.Fs
+}
+
+// defaultArtifactOptions saves the binary content in the current directory.
+func defaultArtifactOptions() artifactOptions {
+	return artifactOptions{dir: ".", fs: afero.NewOsFs()}
+}
+
+// saveArtifact saves binary content returned by a tool to a file with a generated name and prints its absolute path.
+// label describes the content in the output, eg- "Image".
+func saveArtifact(cmd *cobra.Command, opts artifactOptions, label, prefix, ext, mimeType string, data []byte) error {
+	if opts.noSave {
+		cmd.Printf("[%s not saved: %s, %d bytes]\n", label, mimeType, len(data))
+		return nil
+	}
+
+	if err := opts.fs.MkdirAll(opts.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	path := filepath.Join(opts.dir, fmt.Sprintf("%s_%d%s", prefix, time.Now().UnixNano(), ext))
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if err := afero.WriteFile(opts.fs, path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s to disk: %w", strings.ToLower(label), err)
+	}
+	cmd.Printf("[%s saved as %s]\n", label, path)
+	return nil
+}
+
 // unpackResourceContent is the core implementation for processing resource content
 // It handles embedded resource content from MCP tool responses.
-func unpackResourceContent(cmd *cobra.Command, c map[string]any, tmpDir string, fs afero.Fs) error {
+func unpackResourceContent(cmd *cobra.Command, c map[string]any, opts artifactOptions) error {
 	resource, ok := c["resource"].(map[string]any)
 	if !ok {
 		return fmt.Errorf("resource content item does not have a valid 'resource' field: %v", c)
@@ -175,14 +256,14 @@ func unpackResourceContent(cmd *cobra.Command, c map[string]any, tmpDir string,
 
 	// Handle blob resource content
 	if blob, ok := resource["blob"].(string); ok {
-		return handleBlobResource(cmd, blob, mimeType, tmpDir, fs)
+		return handleBlobResource(cmd, blob, mimeType, opts)
 	}
 
 	return fmt.Errorf("resource content does not contain 'text' or 'blob' field: %v", resource)
 }
 
 // handleBlobResource processes blob resource content by decoding base64 data and saving to file
-func handleBlobResource(cmd *cobra.Command, blobData, mimeType, tmpDir string, fs afero.Fs) error {
+func handleBlobResource(cmd *cobra.Command, blobData, mimeType string, opts artifactOptions) error {
 	// Decode base64 blob data
 	data, err := base64.StdEncoding.DecodeString(blobData)
 	if err != nil {
@@ -192,17 +273,7 @@ func handleBlobResource(cmd *cobra.Command, blobData, mimeType, tmpDir string, f
 	// Determine file extension from MIME type
 	ext := getFileExtensionFromMimeType(mimeType)
 
-	// Generate unique filename
-	filename := fmt.Sprintf("resource_%d%s", time.Now().UnixNano(), ext)
-	fullPath := filepath.Join(tmpDir, filename)
-
-	// Write file to disk
-	if err := afero.WriteFile(fs, fullPath, data, 0o644); err != nil {
-		return fmt.Errorf("failed to write resource to disk: %w", err)
-	}
-
-	cmd.Printf("[Resource saved as %s]\n", filename)
-	return nil
+	return saveArtifact(cmd, opts, "Resource", "resource", ext, mimeType, data)
 }
 
 // unpackResourceLinkContent handles resource link content from MCP tool responses
@@ -263,11 +334,71 @@ func runInvokeTool(cmd *cobra.Command, args []string) error {
 		cmd.Println()
 	}
 
-	result, err := apiClient.InvokeTool(toolName, input)
+	if invokeCmdAsync {
+		job, err := apiClient.InvokeToolAsync(toolName, input)
+		if err != nil {
+			return fmt.Errorf("failed to invoke tool: %w", err)
+		}
+		cmd.Printf("Tool '%s' is being invoked in the background.\n", toolName)
+		cmd.Printf("Job ID: %s\n\n", job.ID)
+		cmd.Printf("Check its status and result by running 'mcpjungle get job %s'\n", job.ID)
+		return nil
+	}
+
+	if invokeCmdTimeout < 0 {
+		return fmt.Errorf("invalid value for --timeout: '%s', expected a positive duration", invokeCmdTimeout)
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if invokeCmdTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, invokeCmdTimeout)
+		defer cancel()
+	}
+
+	var result *types.ToolInvokeResult
+	var err error
+	if invokeCmdStream {
+		result, err = apiClient.InvokeToolWithProgress(ctx, toolName, input, func(p types.ToolProgress) {
+			if !quietMode {
+				fmt.Fprintln(cmd.ErrOrStderr(), formatToolProgress(p))
+			}
+		})
+	} else {
+		result, err = apiClient.InvokeToolContext(ctx, toolName, input)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to invoke tool: %w", err)
 	}
 
+	opts := defaultArtifactOptions()
+	if invokeCmdOutputDir != "" {
+		opts.dir = invokeCmdOutputDir
+	}
+	opts.noSave = invokeCmdNoSave
+	return printToolInvokeResult(cmd, result, opts)
+}
+
+// formatToolProgress formats a progress notification, eg- "[3/10 30%] indexing files".
+func formatToolProgress(p types.ToolProgress) string {
+	progress := strconv.FormatFloat(p.Progress, 'f', -1, 64)
+	if p.Total > 0 {
+		progress = fmt.Sprintf(
+			"%s/%s %.0f%%", progress, strconv.FormatFloat(p.Total, 'f', -1, 64), p.Progress/p.Total*100,
+		)
+	}
+	if p.Message == "" {
+		return "[" + progress + "]"
+	}
+	return "[" + progress + "] " + p.Message
+}
+
+// printToolInvokeResult prints all the content returned by a tool call.
+// Binary content like images & audio is saved to files as per the given options.
+// It returns ErrToolError if the tool reported an error, after printing the content.
+func printToolInvokeResult(cmd *cobra.Command, result *types.ToolInvokeResult, opts artifactOptions) error {
 	if result.IsError {
 		cmd.Println("The tool returned an error:")
 		for k, v := range result.Meta {
@@ -301,25 +432,23 @@ func runInvokeTool(cmd *cobra.Command, args []string) error {
 			if err != nil {
 				return err
 			}
-			filename := fmt.Sprintf("image_%d%s", time.Now().UnixNano(), ext)
-			if err := os.WriteFile(filename, imgData, 0o644); err != nil {
-				return fmt.Errorf("failed to write image to disk: %w", err)
+			mimeType, _ := c["mimeType"].(string)
+			if err := saveArtifact(cmd, opts, "Image", "image", ext, mimeType, imgData); err != nil {
+				return err
 			}
-			cmd.Printf("[Image saved as %s]\n", filename)
 
 		case "audio":
 			audioData, ext, err := getAudioContent(c)
 			if err != nil {
 				return err
 			}
-			filename := fmt.Sprintf("audio_%d%s", time.Now().UnixNano(), ext)
-			if err := os.WriteFile(filename, audioData, 0o644); err != nil {
-				return fmt.Errorf("failed to write audio to disk: %w", err)
+			mimeType, _ := c["mimeType"].(string)
+			if err := saveArtifact(cmd, opts, "Audio", "audio", ext, mimeType, audioData); err != nil {
+				return err
 			}
-			cmd.Printf("[Audio saved as %s]\n", filename)
 
 		case "resource":
-			err := unpackResourceContent(cmd, c, ".", afero.NewOsFs())
+			err := unpackResourceContent(cmd, c, opts)
 			if err != nil {
 				return err
 			}
@@ -355,5 +484,8 @@ func runInvokeTool(cmd *cobra.Command, args []string) error {
 		cmd.Println()
 	}
 
+	if result.IsError {
+		return ErrToolError
+	}
 	return nil
 }
@@ -3,10 +3,13 @@ package cmd
 import (
 	"bytes"
 	"encoding/base64"
+	"errors"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 )
@@ -40,6 +43,22 @@ func TestInvokeCommandStructure(t *testing.T) {
 	testhelpers.AssertNotNil(t, groupFlag)
 	testhelpers.AssertTrue(t, len(groupFlag.Usage) > 0, "Group flag should have usage description")
 
+	asyncFlag := invokeToolCmd.Flags().Lookup("async")
+	testhelpers.AssertNotNil(t, asyncFlag)
+	testhelpers.AssertEqual(t, "false", asyncFlag.DefValue)
+
+	testhelpers.AssertNotNil(t, invokeToolCmd.Flags().Lookup("output-dir"))
+	noSaveFlag := invokeToolCmd.Flags().Lookup("no-save")
+	testhelpers.AssertNotNil(t, noSaveFlag)
+	testhelpers.AssertEqual(t, "false", noSaveFlag.DefValue)
+	timeoutFlag := invokeToolCmd.Flags().Lookup("timeout")
+	testhelpers.AssertNotNil(t, timeoutFlag)
+	testhelpers.AssertEqual(t, "0s", timeoutFlag.DefValue)
+
+	streamFlag := invokeToolCmd.Flags().Lookup("stream")
+	testhelpers.AssertNotNil(t, streamFlag)
+	testhelpers.AssertEqual(t, "false", streamFlag.DefValue)
+
 	// Test long description content
 	longDesc := invokeToolCmd.Long
 	expectedPhrases := []string{
@@ -214,7 +233,7 @@ func TestHandleResourceContent(t *testing.T) {
 			cmd.SetOut(&output)
 			cmd.SetErr(&output)
 
-			err = unpackResourceContent(cmd, tt.input, tmpDir, fs)
+			err = unpackResourceContent(cmd, tt.input, artifactOptions{dir: tmpDir, fs: fs})
 
 			// Check error expectations
 			if tt.expectedError != "" {
@@ -248,8 +267,8 @@ func TestHandleResourceContent(t *testing.T) {
 						t.Errorf("Expected file with extension %q, but got %q", tt.expectedExt, filename)
 					}
 
-					// Verify output mentions the saved file
-					if !strings.Contains(actualOutput, "[Resource saved as "+filename+"]") {
+					// Verify output mentions the absolute path of the saved file
+					if !strings.Contains(actualOutput, "[Resource saved as "+filepath.Join(tmpDir, filename)+"]") {
 						t.Errorf("Expected output to mention saved file %q", filename)
 					}
 				}
@@ -266,3 +285,73 @@ func TestHandleResourceContent(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintToolInvokeResultError(t *testing.T) {
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	result := &types.ToolInvokeResult{
+		IsError: true,
+		Content: []map[string]any{{"type": "text", "text": "city not found"}},
+	}
+	err := printToolInvokeResult(cmd, result, defaultArtifactOptions())
+	testhelpers.AssertTrue(t, errors.Is(err, ErrToolError), "Expected ErrToolError for an error result")
+	testhelpers.AssertStringContains(t, out.String(), "The tool returned an error:")
+	testhelpers.AssertStringContains(t, out.String(), "city not found")
+
+	result.IsError = false
+	testhelpers.AssertNoError(t, printToolInvokeResult(cmd, result, defaultArtifactOptions()))
+}
+
+func TestPrintToolInvokeResultArtifacts(t *testing.T) {
+	result := &types.ToolInvokeResult{
+		Content: []map[string]any{
+			{"type": "image", "mimeType": "image/png", "data": base64.StdEncoding.EncodeToString([]byte("fake png"))},
+			{"type": "audio", "mimeType": "audio/wav", "data": base64.StdEncoding.EncodeToString([]byte("fake wav"))},
+		},
+	}
+
+	t.Run("saved to the output directory", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+
+		// the output directory is created if it doesn't exist
+		fs := afero.NewMemMapFs()
+		opts := artifactOptions{dir: "/artifacts/run1", fs: fs}
+		testhelpers.AssertNoError(t, printToolInvokeResult(cmd, result, opts))
+
+		files, err := afero.ReadDir(fs, "/artifacts/run1")
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, 2, len(files))
+		for _, f := range files {
+			testhelpers.AssertStringContains(t, out.String(), filepath.Join("/artifacts/run1", f.Name())+"]")
+		}
+		testhelpers.AssertStringContains(t, out.String(), "[Image saved as /artifacts/run1/image_")
+		testhelpers.AssertStringContains(t, out.String(), "[Audio saved as /artifacts/run1/audio_")
+	})
+
+	t.Run("not saved", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+
+		fs := afero.NewMemMapFs()
+		opts := artifactOptions{dir: "/artifacts", noSave: true, fs: fs}
+		testhelpers.AssertNoError(t, printToolInvokeResult(cmd, result, opts))
+
+		exists, err := afero.DirExists(fs, "/artifacts")
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertFalse(t, exists, "Expected no output directory to be created")
+		testhelpers.AssertStringContains(t, out.String(), "[Image not saved: image/png, 8 bytes]")
+		testhelpers.AssertStringContains(t, out.String(), "[Audio not saved: audio/wav, 8 bytes]")
+	})
+}
+
+func TestFormatToolProgress(t *testing.T) {
+	testhelpers.AssertEqual(t, "[3/10 30%] indexing files", formatToolProgress(types.ToolProgress{Progress: 3, Total: 10, Message: "indexing files"}))
+	testhelpers.AssertEqual(t, "[0.5/1 50%]", formatToolProgress(types.ToolProgress{Progress: 0.5, Total: 1}))
+	// the total is unknown
+	testhelpers.AssertEqual(t, "[42] pages crawled", formatToolProgress(types.ToolProgress{Progress: 42, Message: "pages crawled"}))
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"github.com/spf13/cobra"
@@ -11,6 +12,10 @@ import (
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List resources like MCP servers, tools, etc",
+	Long: "List resources like MCP servers, tools, etc.\n\n" +
+		"When the output is a terminal, the resources are printed as an aligned table with colors.\n" +
+		"Use --no-color or set the " + NoColorEnvVar + " environment variable to disable the colors.\n" +
+		"When the output is piped or redirected, the resources are printed as plain text instead.",
 	Annotations: map[string]string{
 		"group": string(subCommandGroupBasic),
 		"order": "3",
@@ -18,12 +23,27 @@ var listCmd = &cobra.Command{
 }
 
 var (
-	listToolsCmdServerName string
-	listToolsCmdGroupName  string
+	listToolsCmdServerName   string
+	listToolsCmdGroupName    string
+	listToolsCmdEnabled      bool
+	listToolsCmdNameContains string
+	listToolsCmdSort         string
 )
 
 var listPromptsCmdServerName string
 
+var listServersCmdHealth bool
+
+var (
+	listInvocationsCmdTool    string
+	listInvocationsCmdCaller  string
+	listInvocationsCmdClient  string
+	listInvocationsCmdOutcome string
+	listInvocationsCmdSince   string
+	listInvocationsCmdUntil   string
+	listInvocationsCmdLimit   int
+)
+
 var listToolsCmd = &cobra.Command{
 	Use:   "tools",
 	Short: "List available tools",
@@ -46,7 +66,11 @@ var listPromptsCmd = &cobra.Command{
 var listServersCmd = &cobra.Command{
 	Use:   "servers",
 	Short: "List registered MCP servers",
-	RunE:  runListServers,
+	Long: "List the MCP servers registered in mcpjungle.\n\n" +
+		"With --health, mcpjungle also checks whether each server is reachable and the unhealthy ones are highlighted.\n" +
+		"The health is only printed in the human-oriented output, use 'curl <registry>/health/details' to get it as JSON.\n" +
+		"This connects to every MCP server (and starts the stdio ones), so it can take a while.",
+	RunE: runListServers,
 }
 
 var listMcpClientsCmd = &cobra.Command{
@@ -64,12 +88,30 @@ var listUsersCmd = &cobra.Command{
 	RunE:  runListUsers,
 }
 
+var listTeamsCmd = &cobra.Command{
+	Use:   "teams",
+	Short: "List teams of users (Enterprise mode)",
+	Long:  "List the teams of users and the tool groups & MCP servers their members may administer.",
+	RunE:  runListTeams,
+}
+
 var listGroupsCmd = &cobra.Command{
 	Use:   "groups",
 	Short: "List tool groups",
 	RunE:  runListGroups,
 }
 
+var listInvocationsCmd = &cobra.Command{
+	Use:   "invocations",
+	Short: "List recent tool invocations (admin only)",
+	Long: "List the tool calls recently made through mcpjungle, most recent first.\n" +
+		"The server only keeps a limited number of invocations, configured by the " + ToolInvocationHistorySizeEnvVar +
+		" environment variable.\n\n" +
+		"The --since and --until flags accept either a duration relative to now (eg- 30m, 24h)" +
+		" or an RFC 3339 timestamp (eg- 2025-10-01T12:00:00Z).",
+	RunE: runListInvocations,
+}
+
 func init() {
 	listToolsCmd.Flags().StringVar(
 		&listToolsCmdServerName,
@@ -83,6 +125,27 @@ func init() {
 		"",
 		"Filter tools by tool group name",
 	)
+	listToolsCmd.Flags().BoolVar(
+		&listToolsCmdEnabled,
+		"enabled",
+		false,
+		"Only list enabled tools (use --enabled=false to only list disabled tools)",
+	)
+	listToolsCmd.Flags().StringVar(
+		&listToolsCmdNameContains,
+		"name-contains",
+		"",
+		"Only list tools whose name contains this text (case-insensitive)",
+	)
+	listToolsCmd.Flags().StringVar(
+		&listToolsCmdSort,
+		"sort",
+		"",
+		fmt.Sprintf(
+			"Sort tools by one of: %s, %s, %s",
+			types.ToolSortName, types.ToolSortServer, types.ToolSortUpdatedAt,
+		),
+	)
 
 	listPromptsCmd.Flags().StringVar(
 		&listPromptsCmdServerName,
@@ -91,12 +154,74 @@ func init() {
 		"Filter prompts by server name",
 	)
 
+	listInvocationsCmd.Flags().StringVar(
+		&listInvocationsCmdTool,
+		"tool",
+		"",
+		"Only list invocations of this tool (canonical name)",
+	)
+	listInvocationsCmd.Flags().StringVar(
+		&listInvocationsCmdCaller,
+		"caller",
+		"",
+		"Only list invocations made by this MCP client or user",
+	)
+	listInvocationsCmd.Flags().StringVar(
+		&listInvocationsCmdClient,
+		"client",
+		"",
+		"Only list invocations made by this MCP client",
+	)
+	listInvocationsCmd.Flags().StringVar(
+		&listInvocationsCmdOutcome,
+		"outcome",
+		"",
+		fmt.Sprintf(
+			"Only list invocations with this outcome: %s or %s",
+			types.InvocationOutcomeSuccess, types.InvocationOutcomeError,
+		),
+	)
+	listInvocationsCmd.Flags().StringVar(
+		&listInvocationsCmdSince,
+		"since",
+		"",
+		"Only list invocations made after this time (eg- 1h or 2025-10-01T12:00:00Z)",
+	)
+	listInvocationsCmd.Flags().StringVar(
+		&listInvocationsCmdUntil,
+		"until",
+		"",
+		"Only list invocations made before this time (eg- 30m or 2025-10-01T13:00:00Z)",
+	)
+	listServersCmd.Flags().BoolVar(
+		&listServersCmdHealth,
+		"health",
+		false,
+		"Check the health of every MCP server and highlight the unhealthy ones",
+	)
+
+	listInvocationsCmd.Flags().IntVar(
+		&listInvocationsCmdLimit,
+		"limit",
+		20,
+		"Maximum number of invocations to list, 0 lists all of them",
+	)
+
 	listCmd.AddCommand(listToolsCmd)
 	listCmd.AddCommand(listPromptsCmd)
 	listCmd.AddCommand(listServersCmd)
 	listCmd.AddCommand(listMcpClientsCmd)
 	listCmd.AddCommand(listUsersCmd)
+	listCmd.AddCommand(listTeamsCmd)
 	listCmd.AddCommand(listGroupsCmd)
+	listCmd.AddCommand(listInvocationsCmd)
+	addOutputFlag(listCmd)
+	listCmd.PersistentFlags().BoolVar(
+		&listCmdNoColor,
+		"no-color",
+		false,
+		"Disable the colors in the table output",
+	)
 
 	rootCmd.AddCommand(listCmd)
 }
@@ -111,6 +236,16 @@ func runListTools(cmd *cobra.Command, args []string) error {
 	var err error
 	var contextInfo string
 
+	// filtering and sorting is done by the server
+	opts := &types.ListToolsOptions{
+		Server:       listToolsCmdServerName,
+		NameContains: listToolsCmdNameContains,
+		Sort:         types.ToolSortKey(listToolsCmdSort),
+	}
+	if cmd.Flags().Changed("enabled") {
+		opts.Enabled = &listToolsCmdEnabled
+	}
+
 	if listToolsCmdGroupName != "" {
 		// Get tools from specific group
 		group, err := apiClient.GetToolGroup(listToolsCmdGroupName)
@@ -118,11 +253,11 @@ func runListTools(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to get tool group '%s': %w", listToolsCmdGroupName, err)
 		}
 
-		// Get all tools first, then filter by group's included tools.
+		// Get all tools matching the filters first, then filter by group's included tools.
 		// This is necessary because a group might contain tools that do not currently exist in mcpjungle.
 		// for eg- the tool was deleted after group creation or the group includes a non-existent tool.
 		// ListTools only returns tools that actually exist in mcpjungle, so we must cross-check.
-		allTools, err := apiClient.ListTools("")
+		allTools, err := apiClient.ListToolsWithOptions(opts)
 		if err != nil {
 			return fmt.Errorf("failed to list all tools: %w", err)
 		}
@@ -146,7 +281,7 @@ func runListTools(cmd *cobra.Command, args []string) error {
 		}
 	} else {
 		// no group specified, list tools from specific server (if flag is set) or all servers
-		tools, err = apiClient.ListTools(listToolsCmdServerName)
+		tools, err = apiClient.ListToolsWithOptions(opts)
 		if err != nil {
 			return fmt.Errorf("failed to list tools: %w", err)
 		}
@@ -156,8 +291,14 @@ func runListTools(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if ok, err := printStructured(cmd, tools); ok || err != nil {
+		return err
+	}
+
 	if len(tools) == 0 {
-		if listToolsCmdGroupName != "" {
+		if opts.Enabled != nil || opts.NameContains != "" {
+			cmd.Println("There are no tools matching the given filters")
+		} else if listToolsCmdGroupName != "" {
 			cmd.Printf("There are no valid tools in group '%s'\n", listToolsCmdGroupName)
 		} else if listToolsCmdServerName != "" {
 			cmd.Printf("There are no tools from mcp server '%s'\n", listToolsCmdServerName)
@@ -172,6 +313,17 @@ func runListTools(cmd *cobra.Command, args []string) error {
 		cmd.Printf("%s:\n\n", contextInfo)
 	}
 
+	if useTable(cmd) {
+		tbl := newTable("NAME", "STATUS", "DESCRIPTION")
+		for _, t := range tools {
+			tbl.addCells(tableCell{text: t.Name}, statusCell(t.Enabled), tableCell{text: truncateDescription(t.Description)})
+		}
+		tbl.print(cmd.OutOrStdout())
+		fmt.Fprintln(cmd.OutOrStdout())
+		cmd.Println("Run 'usage <tool name>' to see a tool's usage or 'invoke <tool name>' to call one")
+		return nil
+	}
+
 	for i, t := range tools {
 		ed := "ENABLED"
 		if !t.Enabled {
@@ -193,67 +345,165 @@ func runListServers(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to list servers: %w", err)
 	}
 
+	if ok, err := printStructured(cmd, servers); ok || err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
 	if len(servers) == 0 {
-		fmt.Println("There are no MCP servers in the registry")
+		fmt.Fprintln(w, "There are no MCP servers in the registry")
+		return nil
+	}
+
+	var health map[string]types.UpstreamServerHealth
+	if listServersCmdHealth {
+		details, err := apiClient.GetHealthDetails(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("failed to check the health of the servers: %w", err)
+		}
+		health = make(map[string]types.UpstreamServerHealth, len(details.Servers))
+		for _, h := range details.Servers {
+			health[h.Name] = h
+		}
+	}
+
+	if useTable(cmd) {
+		header := []string{"NAME", "TRANSPORT", "ENDPOINT"}
+		if health != nil {
+			header = append(header, "HEALTH")
+		}
+		tbl := newTable(header...)
+		for _, s := range servers {
+			cells := []tableCell{{text: s.Name}, {text: s.Transport}, {text: serverEndpoint(s)}}
+			if health == nil {
+				tbl.addCells(cells...)
+				continue
+			}
+			h := health[s.Name]
+			if h.Healthy {
+				tbl.addCells(append(cells, tableCell{text: "healthy", color: colorGreen})...)
+				continue
+			}
+			tbl.addCells(append(cells, tableCell{text: "unhealthy: " + h.Error})...)
+			tbl.highlightRow(colorRed)
+		}
+		tbl.print(w)
 		return nil
 	}
+
 	for i, s := range servers {
-		fmt.Printf("%d. %s\n", i+1, s.Name)
+		fmt.Fprintf(w, "%d. %s\n", i+1, s.Name)
 
 		if s.Description != "" {
-			fmt.Println(s.Description)
+			fmt.Fprintln(w, s.Description)
 		}
 
-		fmt.Println("Transport: " + s.Transport)
+		fmt.Fprintln(w, "Transport: "+s.Transport)
 
 		t, _ := types.ValidateTransport(s.Transport)
 		if t == types.TransportStreamableHTTP || t == types.TransportSSE {
-			fmt.Println("URL: " + s.URL)
+			fmt.Fprintln(w, "URL: "+s.URL)
 		} else {
 			if len(s.Args) > 0 {
-				fmt.Println("Command: " + s.Command + " " + strings.Join(s.Args, " "))
+				fmt.Fprintln(w, "Command: "+s.Command+" "+strings.Join(s.Args, " "))
 			} else {
-				fmt.Println("Command: " + s.Command)
+				fmt.Fprintln(w, "Command: "+s.Command)
 			}
 
 			if len(s.Env) > 0 {
-				fmt.Printf("Environment variables: %s\n", s.Env)
+				fmt.Fprintf(w, "Environment variables: %s\n", s.Env)
+			}
+		}
+
+		if h, ok := health[s.Name]; ok {
+			if h.Healthy {
+				fmt.Fprintf(w, "Health: healthy (%dms)\n", h.LatencyMs)
+			} else {
+				fmt.Fprintf(w, "Health: unhealthy (%s)\n", h.Error)
 			}
 		}
 
 		if i < len(servers)-1 {
-			fmt.Println()
+			fmt.Fprintln(w)
 		}
 	}
 
 	return nil
 }
 
+// tokenExpiry formats the expiry time of an access token, which never expires if it is nil.
+func tokenExpiry(expiresAt *time.Time) string {
+	if expiresAt == nil {
+		return "never"
+	}
+	return expiresAt.Local().Format(time.RFC3339)
+}
+
+// clientQuota formats the quota status of an MCP client, eg- "12/1000 daily, resets at ...".
+func clientQuota(c *types.McpClient) string {
+	if c.QuotaLimit <= 0 {
+		return "unlimited"
+	}
+	status := fmt.Sprintf("%d/%d %s", c.QuotaUsed, c.QuotaLimit, c.QuotaPeriod)
+	if c.QuotaResetsAt != nil {
+		status += ", resets at " + c.QuotaResetsAt.Local().Format(time.RFC3339)
+	}
+	return status
+}
+
 func runListMcpClients(cmd *cobra.Command, args []string) error {
 	clients, err := apiClient.ListMcpClients()
 	if err != nil {
 		return fmt.Errorf("failed to list MCP clients: %w", err)
 	}
 
+	if ok, err := printStructured(cmd, clients); ok || err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
 	if len(clients) == 0 {
-		fmt.Println("There are no MCP clients in the registry")
+		fmt.Fprintln(w, "There are no MCP clients in the registry")
+		return nil
+	}
+
+	if useTable(cmd) {
+		tbl := newTable("NAME", "ALLOWED SERVERS", "TOKEN EXPIRES", "QUOTA", "DESCRIPTION")
+		for _, c := range clients {
+			tbl.addRow(
+				c.Name,
+				strings.Join(c.AllowList, ","),
+				tokenExpiry(c.TokenExpiresAt),
+				clientQuota(&c),
+				truncateDescription(c.Description),
+			)
+		}
+		tbl.print(w)
 		return nil
 	}
 	for i, c := range clients {
-		fmt.Printf("%d. %s\n", i+1, c.Name)
+		fmt.Fprintf(w, "%d. %s\n", i+1, c.Name)
 
 		if c.Description != "" {
-			fmt.Println("Description: ", c.Description)
+			fmt.Fprintln(w, "Description: ", c.Description)
 		}
 
 		if len(c.AllowList) > 0 {
-			fmt.Println("Allowed servers: " + strings.Join(c.AllowList, ","))
+			fmt.Fprintln(w, "Allowed servers: "+strings.Join(c.AllowList, ","))
 		} else {
-			fmt.Println("This client does not have access to any MCP servers.")
+			fmt.Fprintln(w, "This client does not have access to any MCP servers.")
+		}
+
+		if c.TokenExpiresAt != nil {
+			fmt.Fprintln(w, "Access token expires: "+tokenExpiry(c.TokenExpiresAt))
+		}
+
+		if c.QuotaLimit > 0 {
+			fmt.Fprintln(w, "Quota: "+clientQuota(&c))
 		}
 
 		if i < len(clients)-1 {
-			fmt.Println()
+			fmt.Fprintln(w)
 		}
 	}
 
@@ -266,16 +516,40 @@ func runListUsers(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to list users: %w", err)
 	}
 
+	if ok, err := printStructured(cmd, users); ok || err != nil {
+		return err
+	}
+
 	if len(users) == 0 {
 		cmd.Println("There are no users in the registry")
 		return nil
 	}
+
+	if useTable(cmd) {
+		tbl := newTable("USERNAME", "ROLE", "STATUS", "ALLOWED SERVERS", "ALLOWED GROUPS")
+		for _, u := range users {
+			servers, groups := "All", "All"
+			if u.ToolPolicy != nil {
+				servers, groups = joinOrNone(u.ToolPolicy.Servers), joinOrNone(u.ToolPolicy.ToolGroups)
+			}
+			tbl.addRow(u.Username, u.Role, userStatus(u), servers, groups)
+		}
+		tbl.print(cmd.OutOrStdout())
+		return nil
+	}
 	for i, u := range users {
-		if u.Role == string(types.UserRoleAdmin) {
+		switch {
+		case u.Role == string(types.UserRoleAdmin):
 			cmd.Printf("%d. %s  [ADMIN]\n", i+1, u.Username)
-		} else {
+		case u.Suspended:
+			cmd.Printf("%d. %s  [SUSPENDED]\n", i+1, u.Username)
+		default:
 			cmd.Printf("%d. %s\n", i+1, u.Username)
 		}
+		if u.ToolPolicy != nil {
+			cmd.Printf("  Allowed servers: %s\n", joinOrNone(u.ToolPolicy.Servers))
+			cmd.Printf("  Allowed tool groups: %s\n", joinOrNone(u.ToolPolicy.ToolGroups))
+		}
 
 		if i < len(users)-1 {
 			cmd.Println()
@@ -285,16 +559,90 @@ func runListUsers(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// userStatus describes whether a user may currently access mcpjungle.
+func userStatus(u *types.User) string {
+	if u.Suspended {
+		return "suspended"
+	}
+	return "active"
+}
+
+func runListTeams(cmd *cobra.Command, args []string) error {
+	teams, err := apiClient.ListTeams()
+	if err != nil {
+		return fmt.Errorf("failed to list teams: %w", err)
+	}
+
+	if ok, err := printStructured(cmd, teams); ok || err != nil {
+		return err
+	}
+
+	if len(teams) == 0 {
+		cmd.Println("There are no teams in the registry")
+		return nil
+	}
+
+	if useTable(cmd) {
+		tbl := newTable("NAME", "MEMBERS", "TOOL GROUPS", "SERVERS", "DESCRIPTION")
+		for _, t := range teams {
+			tbl.addRow(
+				t.Name,
+				strings.Join(t.Members, ","),
+				strings.Join(t.ToolGroups, ","),
+				strings.Join(t.Servers, ","),
+				truncateDescription(t.Description),
+			)
+		}
+		tbl.print(cmd.OutOrStdout())
+		return nil
+	}
+	for i, t := range teams {
+		cmd.Printf("%d. %s\n", i+1, t.Name)
+		if t.Description != "" {
+			cmd.Println("Description: ", t.Description)
+		}
+		cmd.Printf("Members: %s\n", joinOrNone(t.Members))
+		cmd.Printf("Tool groups administered: %s\n", joinOrNone(t.ToolGroups))
+		cmd.Printf("MCP servers administered: %s\n", joinOrNone(t.Servers))
+
+		if i < len(teams)-1 {
+			cmd.Println()
+		}
+	}
+	return nil
+}
+
+// joinOrNone joins the names with commas, or returns "None" if there are none.
+func joinOrNone(names []string) string {
+	if len(names) == 0 {
+		return "None"
+	}
+	return strings.Join(names, ",")
+}
+
 func runListGroups(cmd *cobra.Command, args []string) error {
 	groups, err := apiClient.ListToolGroups()
 	if err != nil {
 		return fmt.Errorf("failed to list tool groups: %w", err)
 	}
 
+	if ok, err := printStructured(cmd, groups); ok || err != nil {
+		return err
+	}
+
 	if len(groups) == 0 {
 		cmd.Println("There are no tool groups in the registry")
 		return nil
 	}
+
+	if useTable(cmd) {
+		tbl := newTable("NAME", "DESCRIPTION")
+		for _, g := range groups {
+			tbl.addRow(g.Name, truncateDescription(g.Description))
+		}
+		tbl.print(cmd.OutOrStdout())
+		return nil
+	}
 	for i, g := range groups {
 		cmd.Printf("%d. %s\n", i+1, g.Name)
 		if g.Description != "" {
@@ -315,10 +663,25 @@ func runListPrompts(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to list prompts: %w", err)
 	}
 
+	if ok, err := printStructured(cmd, prompts); ok || err != nil {
+		return err
+	}
+
 	if len(prompts) == 0 {
 		cmd.Println("No prompts found")
 		return nil
 	}
+
+	if useTable(cmd) {
+		tbl := newTable("NAME", "STATUS", "DESCRIPTION")
+		for _, p := range prompts {
+			tbl.addCells(tableCell{text: p.Name}, statusCell(p.Enabled), tableCell{text: truncateDescription(p.Description)})
+		}
+		tbl.print(cmd.OutOrStdout())
+		fmt.Fprintln(cmd.OutOrStdout())
+		cmd.Println("Run 'get prompt <prompt name>' to retrieve a prompt template")
+		return nil
+	}
 	for i, p := range prompts {
 		ed := "ENABLED"
 		if !p.Enabled {
@@ -335,3 +698,101 @@ func runListPrompts(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runListInvocations(cmd *cobra.Command, args []string) error {
+	opts := &types.ListInvocationsOptions{
+		Tool:    listInvocationsCmdTool,
+		Caller:  listInvocationsCmdCaller,
+		Client:  listInvocationsCmdClient,
+		Outcome: types.InvocationOutcome(listInvocationsCmdOutcome),
+	}
+	var err error
+	if opts.Since, err = parseTimeFlag(listInvocationsCmdSince); err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	if opts.Until, err = parseTimeFlag(listInvocationsCmdUntil); err != nil {
+		return fmt.Errorf("invalid --until: %w", err)
+	}
+
+	invocations, err := apiClient.ListInvocations(opts)
+	if err != nil {
+		return fmt.Errorf("failed to list tool invocations: %w", err)
+	}
+
+	if listInvocationsCmdLimit > 0 && len(invocations) > listInvocationsCmdLimit {
+		invocations = invocations[:listInvocationsCmdLimit]
+	}
+
+	if ok, err := printStructured(cmd, invocations); ok || err != nil {
+		return err
+	}
+
+	if len(invocations) == 0 {
+		cmd.Println("No tool invocations found")
+		return nil
+	}
+
+	if useTable(cmd) {
+		tbl := newTable("TIME", "TOOL", "OUTCOME", "DURATION", "CALLER")
+		for _, inv := range invocations {
+			outcome := tableCell{text: strings.ToUpper(string(inv.Outcome)), color: colorGreen}
+			if inv.Outcome == types.InvocationOutcomeError {
+				outcome.color = colorRed
+			}
+			tbl.addCells(
+				tableCell{text: inv.InvokedAt.Local().Format(time.RFC3339)},
+				tableCell{text: inv.Tool},
+				outcome,
+				tableCell{text: fmt.Sprintf("%dms", inv.DurationMs)},
+				tableCell{text: inv.Caller},
+			)
+		}
+		tbl.print(cmd.OutOrStdout())
+		return nil
+	}
+	for i, inv := range invocations {
+		cmd.Printf(
+			"%d. %s  [%s]  %s  (%dms)\n",
+			i+1, inv.Tool, strings.ToUpper(string(inv.Outcome)), inv.InvokedAt.Local().Format(time.RFC3339), inv.DurationMs,
+		)
+		if inv.Caller != "" {
+			cmd.Println("Caller: " + inv.Caller)
+		}
+		if inv.Arguments != "" {
+			cmd.Println("Arguments: " + inv.Arguments)
+		}
+		if inv.Error != "" {
+			cmd.Println("Error: " + inv.Error)
+		}
+		if i < len(invocations)-1 {
+			cmd.Println()
+		}
+	}
+
+	return nil
+}
+
+// serverEndpoint returns the URL of a remote MCP server or the command that starts a stdio one.
+func serverEndpoint(s *types.McpServer) string {
+	t, _ := types.ValidateTransport(s.Transport)
+	if t == types.TransportStreamableHTTP || t == types.TransportSSE {
+		return s.URL
+	}
+	return strings.TrimSpace(s.Command + " " + strings.Join(s.Args, " "))
+}
+
+// parseTimeFlag parses a time flag given either as a duration before now or as an RFC 3339 timestamp.
+// An empty value returns the zero time.
+func parseTimeFlag(v string) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(v); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("'%s' is neither a duration (eg- 1h) nor an RFC 3339 timestamp", v)
+	}
+	return t, nil
+}
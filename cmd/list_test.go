@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"testing"
+	"time"
 
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
 
 func TestListCommandStructure(t *testing.T) {
@@ -35,6 +37,12 @@ func TestListToolsSubcommand(t *testing.T) {
 	serverFlag := listToolsCmd.Flags().Lookup("server")
 	testhelpers.AssertNotNil(t, serverFlag)
 	testhelpers.AssertTrue(t, len(serverFlag.Usage) > 0, "Server flag should have usage description")
+
+	for _, name := range []string{"enabled", "name-contains", "sort"} {
+		flag := listToolsCmd.Flags().Lookup(name)
+		testhelpers.AssertNotNil(t, flag)
+		testhelpers.AssertTrue(t, len(flag.Usage) > 0, name+" flag should have usage description")
+	}
 }
 
 func TestListServersSubcommand(t *testing.T) {
@@ -93,6 +101,45 @@ func TestListPromptsSubcommand(t *testing.T) {
 	testhelpers.AssertTrue(t, len(serverFlag.Usage) > 0, "Server flag should have usage description")
 }
 
+func TestListInvocationsSubcommand(t *testing.T) {
+	testhelpers.AssertEqual(t, "invocations", listInvocationsCmd.Use)
+	testhelpers.AssertNotNil(t, listInvocationsCmd.RunE)
+
+	for _, name := range []string{"tool", "caller", "outcome", "since", "until", "limit"} {
+		flag := listInvocationsCmd.Flags().Lookup(name)
+		testhelpers.AssertNotNil(t, flag)
+		testhelpers.AssertTrue(t, len(flag.Usage) > 0, name+" flag should have usage description")
+	}
+}
+
+func TestParseTimeFlag(t *testing.T) {
+	got, err := parseTimeFlag("")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, got.IsZero(), "Expected zero time for an empty flag")
+
+	got, err = parseTimeFlag("1h")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, time.Since(got) >= time.Hour && time.Since(got) < time.Hour+time.Minute, "Expected a time 1h ago")
+
+	got, err = parseTimeFlag("2025-10-01T12:00:00Z")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, got.Equal(time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)), "Expected the given timestamp")
+
+	_, err = parseTimeFlag("yesterday")
+	testhelpers.AssertTrue(t, err != nil, "Expected an error for an invalid time")
+}
+
+func TestClientQuota(t *testing.T) {
+	testhelpers.AssertEqual(t, "unlimited", clientQuota(&types.McpClient{Name: "cursor"}))
+
+	c := &types.McpClient{Name: "agent", QuotaLimit: 1000, QuotaPeriod: types.QuotaPeriodMonthly, QuotaUsed: 12}
+	testhelpers.AssertEqual(t, "12/1000 monthly", clientQuota(c))
+
+	resetsAt := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	c.QuotaResetsAt = &resetsAt
+	testhelpers.AssertStringContains(t, clientQuota(c), "12/1000 monthly, resets at ")
+}
+
 // Integration tests for list commands
 func TestListCommandIntegration(t *testing.T) {
 	// Verify that listCmd is properly initialized
@@ -100,7 +147,7 @@ func TestListCommandIntegration(t *testing.T) {
 
 	// Test all list subcommands are properly configured
 	subcommands := listCmd.Commands()
-	expectedSubcommands := []string{"tools", "prompts", "servers", "mcp-clients", "users", "groups"}
+	expectedSubcommands := []string{"tools", "prompts", "servers", "mcp-clients", "users", "teams", "groups", "invocations"}
 
 	testhelpers.AssertEqual(t, len(expectedSubcommands), len(subcommands))
 
@@ -1,7 +1,11 @@
 package cmd
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/mcpjungle/mcpjungle/cmd/config"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
@@ -10,11 +14,27 @@ import (
 
 var loginCmd = &cobra.Command{
 	Use:   "login [access_token]",
-	Args:  cobra.ExactArgs(1),
+	Args:  cobra.MaximumNArgs(1),
 	Short: "Log in to MCPJungle (Enterprise mode)",
 	Long: "Log in to your MCPJungle account with your access token.\n" +
-		"This will store the access token in your local configuration file, allowing you to make authenticated requests to the MCPJungle API server.\n" +
-		"If you're a standard user, your access token must be generated by an administrator.",
+		"The token is verified against the registry server, then stored in your local configuration file " +
+		"(" + config.ClientConfigFileName + " in your home directory), allowing you to make authenticated requests " +
+		"to the MCPJungle API server.\n" +
+		"If you're a standard user, your access token must be generated by an administrator.\n\n" +
+		"If the access token is not given as an argument, it is read from the standard input, " +
+		"which keeps it out of your shell history.\n" +
+		"If the --registry flag is set, the registry server URL is stored in the configuration file as well, " +
+		"so that you don't need to set the flag in later commands.\n\n" +
+		"With the --profile flag, the token & URL are saved in that profile instead, which is created if needed.\n" +
+		"Use 'mcpjungle use-context' to switch between profiles.",
+	Example: `  # log in to a remote registry, entering the token when prompted
+  mcpjungle login --registry https://mcpjungle.example.com
+
+  # read the token from a file
+  mcpjungle login < token.txt
+
+  # save the credentials of the production gateway in a separate profile
+  mcpjungle login --profile prod --registry https://mcpjungle.prod.example.com`,
 	Annotations: map[string]string{
 		"group": string(subCommandGroupAdvanced),
 		"order": "7",
@@ -27,7 +47,15 @@ func init() {
 }
 
 func runLogin(cmd *cobra.Command, args []string) error {
-	accessToken := args[0]
+	var accessToken string
+	if len(args) > 0 {
+		accessToken = args[0]
+	} else {
+		var err error
+		if accessToken, err = readAccessToken(cmd); err != nil {
+			return err
+		}
+	}
 
 	user, err := apiClient.Whoami(accessToken)
 	if err != nil {
@@ -42,9 +70,16 @@ func runLogin(cmd *cobra.Command, args []string) error {
 		cmd.Println("You are an administrator of MCPJungle")
 	}
 
-	cfg := &config.ClientConfig{
-		AccessToken: accessToken,
+	// update the existing configuration rather than overwriting it,
+	// the selected profile is created if it doesn't exist yet
+	cfg := config.Load()
+	name := cfg.ProfileName(profileName)
+	profile, _ := cfg.Profile(name)
+	profile.AccessToken = accessToken
+	if cmd.Flags().Changed("registry") {
+		profile.RegistryURL = registryServerURL
 	}
+	cfg.SetProfile(name, profile)
 	if err := config.Save(cfg); err != nil {
 		return fmt.Errorf("failed to create client configuration: %w", err)
 	}
@@ -53,7 +88,28 @@ func runLogin(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to get client configuration path: %w", err)
 	}
-	fmt.Println("Your access token has been saved to", cfgPath)
+	if name == config.DefaultProfile {
+		fmt.Fprintln(cmd.OutOrStdout(), "Your access token has been saved to", cfgPath)
+	} else {
+		fmt.Fprintf(cmd.OutOrStdout(), "Your access token has been saved to %s in profile '%s'\n", cfgPath, name)
+	}
+	if cmd.Flags().Changed("registry") {
+		fmt.Fprintln(cmd.OutOrStdout(), "The registry server URL has been saved as well:", profile.RegistryURL)
+	}
 
 	return nil
 }
+
+// readAccessToken reads the access token from the first line of the command's standard input.
+func readAccessToken(cmd *cobra.Command) (string, error) {
+	cmd.Print("Access token: ")
+	line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read access token: %w", err)
+	}
+	token := strings.TrimSpace(line)
+	if token == "" {
+		return "", fmt.Errorf("no access token given")
+	}
+	return token, nil
+}
@@ -1,9 +1,17 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/mcpjungle/mcpjungle/client"
+	"github.com/mcpjungle/mcpjungle/cmd/config"
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
 
 func TestLoginCommandStructure(t *testing.T) {
@@ -24,3 +32,86 @@ func TestLoginCommandStructure(t *testing.T) {
 		testhelpers.AssertNotNil(t, loginCmd.Args)
 	})
 }
+
+func TestRunLogin(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer valid-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid access token"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(types.User{Username: "alice", Role: string(types.UserRoleUser)})
+	}))
+	defer server.Close()
+
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+	apiClient = client.NewClient(server.URL)
+
+	t.Run("token read from stdin, existing config is preserved", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		testhelpers.AssertNoError(t, config.Save(&config.ClientConfig{RegistryURL: "https://mcpjungle.example.com"}))
+
+		var out bytes.Buffer
+		loginCmd.SetOut(&out)
+		loginCmd.SetIn(strings.NewReader("valid-token\n"))
+		defer func() {
+			loginCmd.SetOut(nil)
+			loginCmd.SetIn(nil)
+		}()
+
+		testhelpers.AssertNoError(t, runLogin(loginCmd, nil))
+		testhelpers.AssertStringContains(t, out.String(), "You are now logged in as alice")
+
+		cfg := config.Load()
+		testhelpers.AssertEqual(t, "valid-token", cfg.AccessToken)
+		testhelpers.AssertEqual(t, "https://mcpjungle.example.com", cfg.RegistryURL)
+	})
+
+	t.Run("invalid token is not saved", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+
+		var out bytes.Buffer
+		loginCmd.SetOut(&out)
+		defer loginCmd.SetOut(nil)
+
+		err := runLogin(loginCmd, []string{"wrong-token"})
+		testhelpers.AssertError(t, err)
+		testhelpers.AssertEqual(t, ExitCodeAuthFailure, ExitCode(err))
+		testhelpers.AssertEqual(t, "", config.Load().AccessToken)
+	})
+
+	t.Run("empty stdin", func(t *testing.T) {
+		var out bytes.Buffer
+		loginCmd.SetOut(&out)
+		loginCmd.SetIn(strings.NewReader(""))
+		defer func() {
+			loginCmd.SetOut(nil)
+			loginCmd.SetIn(nil)
+		}()
+
+		err := runLogin(loginCmd, nil)
+		testhelpers.AssertError(t, err)
+		testhelpers.AssertStringContains(t, err.Error(), "no access token given")
+	})
+	t.Run("token saved in a new profile", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		testhelpers.AssertNoError(t, config.Save(&config.ClientConfig{AccessToken: "dev-token"}))
+
+		profileName = "prod"
+		defer func() { profileName = "" }()
+
+		var out bytes.Buffer
+		loginCmd.SetOut(&out)
+		defer loginCmd.SetOut(nil)
+
+		testhelpers.AssertNoError(t, runLogin(loginCmd, []string{"valid-token"}))
+		testhelpers.AssertStringContains(t, out.String(), "in profile 'prod'")
+
+		cfg := config.Load()
+		testhelpers.AssertEqual(t, "dev-token", cfg.AccessToken)
+		p, err := cfg.Profile("prod")
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, "valid-token", p.AccessToken)
+	})
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logsCmdServer string
+	logsCmdSince  string
+	logsCmdFollow bool
+)
+
+var logsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "Show the logs of the mcpjungle server (admin only)",
+	Long: "Show the recent logs of the mcpjungle server, including the output of the processes of STDIO MCP servers.\n" +
+		"The server keeps its most recent log entries in memory (see the LOG_BUFFER_SIZE environment variable),\n" +
+		"so only the entries logged since it last started are available.",
+	Example: "  mcpjungle logs --server filesystem --since 15m\n" +
+		"  mcpjungle logs --follow",
+	Args: cobra.NoArgs,
+	RunE: runLogs,
+	Annotations: map[string]string{
+		"group": string(subCommandGroupAdvanced),
+		"order": "16",
+	},
+}
+
+func init() {
+	logsCmd.Flags().StringVar(
+		&logsCmdServer,
+		"server",
+		"",
+		"Only show the logs about this MCP server, including the output of its process for STDIO servers",
+	)
+	logsCmd.Flags().StringVar(
+		&logsCmdSince,
+		"since",
+		"",
+		"Only show the logs since this time, either a duration before now (eg- 1h) or an RFC 3339 timestamp",
+	)
+	logsCmd.Flags().BoolVarP(
+		&logsCmdFollow,
+		"follow",
+		"f",
+		false,
+		"Keep streaming new log entries as they are logged, until interrupted",
+	)
+
+	rootCmd.AddCommand(logsCmd)
+}
+
+func runLogs(cmd *cobra.Command, args []string) error {
+	opts := &types.LogsOptions{Server: logsCmdServer}
+	var err error
+	if opts.Since, err = parseTimeFlag(logsCmdSince); err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+
+	w := cmd.OutOrStdout()
+	if !logsCmdFollow {
+		entries, err := apiClient.GetLogs(opts)
+		if err != nil {
+			return fmt.Errorf("failed to get logs: %w", err)
+		}
+		for _, e := range entries {
+			printLogEntry(w, e)
+		}
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	entries, err := apiClient.FollowLogs(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed to follow logs: %w", err)
+	}
+	for e := range entries {
+		printLogEntry(w, e)
+	}
+	return nil
+}
+
+// printLogEntry prints a log entry on a single line, its fields sorted by name.
+func printLogEntry(w io.Writer, e types.LogEntry) {
+	var b strings.Builder
+	b.WriteString(e.Time.Format(time.RFC3339))
+	b.WriteString("  ")
+	fmt.Fprintf(&b, "%-5s", strings.ToUpper(e.Level))
+	b.WriteString("  ")
+	b.WriteString(e.Message)
+
+	keys := make([]string, 0, len(e.Fields))
+	for k := range e.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&b, "  %s=%s", k, formatSchemaValue(e.Fields[k]))
+	}
+	fmt.Fprintln(w, b.String())
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/client"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestLogsCommandFlags(t *testing.T) {
+	testhelpers.AssertNotNil(t, logsCmd.Flags().Lookup("server"))
+	testhelpers.AssertNotNil(t, logsCmd.Flags().Lookup("since"))
+	follow := logsCmd.Flags().Lookup("follow")
+	testhelpers.AssertNotNil(t, follow)
+	testhelpers.AssertEqual(t, "f", follow.Shorthand)
+	testhelpers.AssertEqual(t, "false", follow.DefValue)
+	testhelpers.AssertEqual(t, string(subCommandGroupAdvanced), logsCmd.Annotations["group"])
+}
+
+func TestPrintLogEntry(t *testing.T) {
+	var out bytes.Buffer
+	printLogEntry(&out, types.LogEntry{
+		Time:    time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+		Level:   "info",
+		Message: "stdio MCP server stderr",
+		Fields:  map[string]any{"stderr": "ready", "server": "time", "pid": float64(42)},
+	})
+	want := "2025-06-01T12:00:00Z  INFO   stdio MCP server stderr  pid=42  server=time  stderr=ready\n"
+	testhelpers.AssertEqual(t, want, out.String())
+}
+
+func TestRunLogs(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		if r.URL.Query().Get("follow") == "true" {
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "id:2\nevent:log\ndata:{\"id\":2,\"time\":\"2025-06-01T12:00:01Z\",\"level\":\"warn\",\"message\":\"slow\"}\n\n")
+			return
+		}
+		_, _ = w.Write([]byte(`[{"id":1,"time":"2025-06-01T12:00:00Z","level":"error","message":"failed"}]`))
+	}))
+	defer server.Close()
+
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+	apiClient = client.NewClient(server.URL)
+
+	defer func() {
+		logsCmdServer, logsCmdSince, logsCmdFollow = "", "", false
+	}()
+
+	var out bytes.Buffer
+	logsCmd.SetOut(&out)
+	defer logsCmd.SetOut(nil)
+
+	logsCmdServer = "time"
+	testhelpers.AssertNoError(t, runLogs(logsCmd, nil))
+	testhelpers.AssertEqual(t, "server=time", query)
+	testhelpers.AssertEqual(t, "2025-06-01T12:00:00Z  ERROR  failed\n", out.String())
+
+	out.Reset()
+	logsCmdFollow = true
+	logsCmd.SetContext(context.Background())
+	testhelpers.AssertNoError(t, runLogs(logsCmd, nil))
+	testhelpers.AssertEqual(t, "follow=true&server=time", query)
+	testhelpers.AssertEqual(t, "2025-06-01T12:00:01Z  WARN   slow\n", out.String())
+
+	logsCmdSince = "yesterday"
+	err := runLogs(logsCmd, nil)
+	testhelpers.AssertError(t, err)
+	testhelpers.AssertStringContains(t, err.Error(), "invalid --since")
+}
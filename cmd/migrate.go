@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mcpjungle/mcpjungle/internal/migrations"
+	"github.com/mcpjungle/mcpjungle/pkg/version"
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateCmdDBPath    string
+	migrateSQLCmdOutput string
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Manage the schema of the MCPJungle database",
+	Long: "Apply, roll back & inspect the versioned migrations of the MCPJungle database schema.\n" +
+		"Migrations applied to the database are recorded in its schema_migrations table.\n\n" +
+		"This command connects to the database directly, so it must be run with the same database configuration\n" +
+		"as the server (DATABASE_URL, the POSTGRES_* environment variables or the SQLite database path).\n" +
+		"The server applies pending migrations when it starts, so 'migrate up' is only needed to upgrade\n" +
+		"the schema before starting a new version of the server.",
+	Annotations: map[string]string{
+		"group": string(subCommandGroupAdvanced),
+		"order": "11",
+	},
+}
+
+var migrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Apply all pending migrations",
+	Args:  cobra.NoArgs,
+	RunE:  runMigrateUp,
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:   "down [n]",
+	Short: "Roll back the last n applied migrations",
+	Long: "Roll back the last n migrations applied to the database, in reverse order.\n" +
+		"Rolling back a migration can delete data, so back up the database first.\n" +
+		"The server must be downgraded to the version matching the remaining migrations before it is restarted.",
+	Args: cobra.ExactArgs(1),
+	RunE: runMigrateDown,
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show which migrations have been applied",
+	Args:  cobra.NoArgs,
+	RunE:  runMigrateStatus,
+}
+
+var migrateSQLCmd = &cobra.Command{
+	Use:   "sql",
+	Short: "Export the SQL of the pending migrations",
+	Long: "Write the SQL statements that 'migrate up' would run against the configured database to a file,\n" +
+		"without changing the database. On an empty database, this is the DDL of the whole schema.\n\n" +
+		"This lets DBAs review the schema changes and apply them through their own change-management process.\n" +
+		"The statements also record the migrations in the schema_migrations table,\n" +
+		"so the server recognizes a database migrated by running them as up-to-date.\n" +
+		"Since the statements are specific to the database & its current schema, export them against the database\n" +
+		"they will be applied to (or a copy of it).",
+	Args: cobra.NoArgs,
+	RunE: runMigrateSQL,
+}
+
+func init() {
+	migrateCmd.PersistentFlags().StringVar(
+		&migrateCmdDBPath,
+		"db-path",
+		"",
+		fmt.Sprintf(
+			"path of the SQLite database file, used when no Postgres database is configured (overrides env var %s)",
+			DBPathEnvVar,
+		),
+	)
+
+	migrateCmd.AddCommand(migrateUpCmd)
+	migrateCmd.AddCommand(migrateDownCmd)
+	migrateCmd.AddCommand(migrateStatusCmd)
+
+	migrateSQLCmd.Flags().StringVarP(&migrateSQLCmdOutput, "output", "o", "", "path of the SQL file to write")
+	_ = migrateSQLCmd.MarkFlagRequired("output")
+	migrateCmd.AddCommand(migrateSQLCmd)
+	rootCmd.AddCommand(migrateCmd)
+}
+
+func runMigrateUp(cmd *cobra.Command, args []string) error {
+	dbConn, err := connectDBForCommand(migrateCmdDBPath)
+	if err != nil {
+		return err
+	}
+	applied, err := migrations.Apply(dbConn)
+	for _, m := range applied {
+		cmd.Printf("Applied migration %d (%s)\n", m.Version, m.Name)
+	}
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		cmd.Println("The database is up to date, no migrations to apply")
+	}
+	return nil
+}
+
+func runMigrateDown(cmd *cobra.Command, args []string) error {
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n <= 0 {
+		return fmt.Errorf("invalid number of migrations '%s', expected a positive integer", args[0])
+	}
+	dbConn, err := connectDBForCommand(migrateCmdDBPath)
+	if err != nil {
+		return err
+	}
+	reverted, err := migrations.Rollback(dbConn, n)
+	for _, m := range reverted {
+		cmd.Printf("Rolled back migration %d (%s)\n", m.Version, m.Name)
+	}
+	if err != nil {
+		return err
+	}
+	if len(reverted) == 0 {
+		cmd.Println("No migrations to roll back")
+	}
+	return nil
+}
+
+func runMigrateStatus(cmd *cobra.Command, args []string) error {
+	dbConn, err := connectDBForCommand(migrateCmdDBPath)
+	if err != nil {
+		return err
+	}
+	statuses, err := migrations.GetStatus(dbConn)
+	if err != nil {
+		return err
+	}
+	for _, s := range statuses {
+		state := "pending"
+		if s.Applied {
+			state = "applied at " + s.AppliedAt.Format("2006-01-02 15:04:05 MST")
+		}
+		cmd.Printf("%04d  %-30s %s\n", s.Version, s.Name, state)
+	}
+	return nil
+}
+
+func runMigrateSQL(cmd *cobra.Command, args []string) error {
+	dbConn, err := connectDBForCommand(migrateCmdDBPath)
+	if err != nil {
+		return err
+	}
+	scripts, err := migrations.SQL(dbConn)
+	if err != nil {
+		return err
+	}
+	if len(scripts) == 0 {
+		cmd.Println("The database is up to date, no migrations to apply")
+		return nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(
+		&b, "-- Pending migrations of the mcpjungle %s database, generated by mcpjungle %s\n",
+		dbConn.Name(), version.GetVersion(),
+	)
+	pending := 0
+	for _, s := range scripts {
+		if s.Migration == nil {
+			b.WriteString("\n-- Create the table recording the applied migrations\n")
+		} else {
+			fmt.Fprintf(&b, "\n-- Migration %d (%s)\n", s.Migration.Version, s.Migration.Name)
+			pending++
+		}
+		for _, stmt := range s.Statements {
+			b.WriteString(stmt + ";\n")
+		}
+	}
+	if err := os.WriteFile(migrateSQLCmdOutput, []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write SQL file: %w", err)
+	}
+	cmd.Printf("Wrote the SQL of %d pending migrations to %s\n", pending, migrateSQLCmdOutput)
+	return nil
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestMigrateCommandStructure(t *testing.T) {
+	testhelpers.AssertEqual(t, "migrate", migrateCmd.Use)
+	testhelpers.TestCommandAnnotations(t, migrateCmd.Annotations, []testhelpers.CommandAnnotationTest{
+		{Key: "group", Expected: string(subCommandGroupAdvanced)},
+		{Key: "order", Expected: "11"},
+	})
+	testhelpers.AssertEqual(t, 4, len(migrateCmd.Commands()))
+	testhelpers.AssertNotNil(t, migrateCmd.PersistentFlags().Lookup("db-path"))
+}
+
+func TestRunMigrate(t *testing.T) {
+	withEnv(map[string]string{DBUrlEnvVar: "", PostgresHostEnvVar: ""}, func() {
+		migrateCmdDBPath = filepath.Join(t.TempDir(), "mcpjungle.db")
+		defer func() { migrateCmdDBPath = "" }()
+
+		var out bytes.Buffer
+		migrateUpCmd.SetOut(&out)
+		migrateDownCmd.SetOut(&out)
+		migrateStatusCmd.SetOut(&out)
+
+		testhelpers.AssertNoError(t, runMigrateStatus(migrateStatusCmd, nil))
+		testhelpers.AssertStringContains(t, out.String(), "initial_schema")
+		testhelpers.AssertStringContains(t, out.String(), "pending")
+
+		out.Reset()
+		testhelpers.AssertNoError(t, runMigrateUp(migrateUpCmd, nil))
+		testhelpers.AssertStringContains(t, out.String(), "Applied migration 1 (initial_schema)")
+
+		out.Reset()
+		testhelpers.AssertNoError(t, runMigrateUp(migrateUpCmd, nil))
+		testhelpers.AssertStringContains(t, out.String(), "up to date")
+
+		out.Reset()
+		testhelpers.AssertNoError(t, runMigrateStatus(migrateStatusCmd, nil))
+		testhelpers.AssertStringContains(t, out.String(), "applied at")
+
+		out.Reset()
+		testhelpers.AssertNoError(t, runMigrateDown(migrateDownCmd, []string{"1"}))
+		testhelpers.AssertStringContains(t, out.String(), "Rolled back migration 8 (add_client_quotas)")
+		testhelpers.AssertFalse(t, strings.Contains(out.String(), "add_idempotency_keys"), "only the last migration must be rolled back")
+
+		out.Reset()
+		testhelpers.AssertNoError(t, runMigrateDown(migrateDownCmd, []string{"7"}))
+		testhelpers.AssertStringContains(t, out.String(), "Rolled back migration 2 (add_tool_output_schema)")
+		testhelpers.AssertStringContains(t, out.String(), "Rolled back migration 1 (initial_schema)")
+
+		testhelpers.AssertError(t, runMigrateDown(migrateDownCmd, []string{"zero"}))
+		testhelpers.AssertError(t, runMigrateDown(migrateDownCmd, []string{"0"}))
+	})
+}
+
+func TestRunMigrateSQL(t *testing.T) {
+	withEnv(map[string]string{DBUrlEnvVar: "", PostgresHostEnvVar: ""}, func() {
+		migrateCmdDBPath = filepath.Join(t.TempDir(), "mcpjungle.db")
+		migrateSQLCmdOutput = filepath.Join(t.TempDir(), "schema.sql")
+		defer func() {
+			migrateCmdDBPath = ""
+			migrateSQLCmdOutput = ""
+		}()
+
+		var out bytes.Buffer
+		migrateSQLCmd.SetOut(&out)
+		migrateUpCmd.SetOut(&out)
+
+		testhelpers.AssertNoError(t, runMigrateSQL(migrateSQLCmd, nil))
+		testhelpers.AssertStringContains(t, out.String(), "Wrote the SQL of 8 pending migrations")
+		sql, err := os.ReadFile(migrateSQLCmdOutput)
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertStringContains(t, string(sql), "-- Migration 1 (initial_schema)")
+		testhelpers.AssertStringContains(t, string(sql), "CREATE TABLE `mcp_servers`")
+
+		// the database wasn't migrated
+		out.Reset()
+		testhelpers.AssertNoError(t, runMigrateUp(migrateUpCmd, nil))
+		testhelpers.AssertStringContains(t, out.String(), "Applied migration 1 (initial_schema)")
+
+		out.Reset()
+		testhelpers.AssertNoError(t, runMigrateSQL(migrateSQLCmd, nil))
+		testhelpers.AssertStringContains(t, out.String(), "up to date")
+	})
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// outputFormat is the format in which the list & get commands print the resources
+type outputFormat string
+
+const (
+	// outputFormatTable is the human-oriented text printed by default
+	outputFormatTable outputFormat = "table"
+	outputFormatJSON  outputFormat = "json"
+	outputFormatYAML  outputFormat = "yaml"
+)
+
+var outputCmdFormat string
+
+// addOutputFlag adds the --output flag to a command and all its subcommands.
+func addOutputFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(
+		&outputCmdFormat,
+		"output",
+		"o",
+		string(outputFormatTable),
+		fmt.Sprintf(
+			"Output format, one of: %s, %s, %s",
+			outputFormatTable, outputFormatJSON, outputFormatYAML,
+		),
+	)
+}
+
+// getOutputFormat returns the output format given by the --output flag.
+func getOutputFormat() (outputFormat, error) {
+	switch f := outputFormat(outputCmdFormat); f {
+	case "", outputFormatTable:
+		return outputFormatTable, nil
+	case outputFormatJSON, outputFormatYAML:
+		return f, nil
+	default:
+		return "", fmt.Errorf(
+			"invalid value for --output flag: '%s', expected one of %s, %s, %s",
+			outputCmdFormat, outputFormatTable, outputFormatJSON, outputFormatYAML,
+		)
+	}
+}
+
+// printStructured prints v to the command's standard output in the machine-readable format
+// requested by the --output flag, and returns true if it did.
+// It returns false if the human-oriented text must be printed instead.
+//
+// The fields are named after their json tags in both formats, so that the output matches the HTTP API.
+func printStructured(cmd *cobra.Command, v any) (bool, error) {
+	format, err := getOutputFormat()
+	if err != nil {
+		return false, err
+	}
+	if format == outputFormatTable {
+		return false, nil
+	}
+
+	// print an empty list rather than null when there are no resources
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice && rv.IsNil() {
+		v = reflect.MakeSlice(rv.Type(), 0, 0).Interface()
+	}
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return true, fmt.Errorf("failed to encode output: %w", err)
+	}
+	if format == outputFormatYAML {
+		if out, err = jsonToYAML(out); err != nil {
+			return true, fmt.Errorf("failed to encode output: %w", err)
+		}
+	}
+	w := cmd.OutOrStdout()
+	if _, err := w.Write(out); err != nil {
+		return true, err
+	}
+	if out[len(out)-1] != '\n' {
+		_, err = fmt.Fprintln(w)
+	}
+	return true, err
+}
+
+// jsonToYAML converts a JSON document to YAML, keeping the order of the fields.
+func jsonToYAML(j []byte) ([]byte, error) {
+	// JSON is valid YAML, so it can be decoded as a YAML node and re-encoded in block style.
+	var node yaml.Node
+	if err := yaml.Unmarshal(j, &node); err != nil {
+		return nil, err
+	}
+	resetStyle(&node)
+	return yaml.Marshal(&node)
+}
+
+// resetStyle clears the flow & quoting styles of the node and its children,
+// so that they are encoded in the default YAML style.
+func resetStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		resetStyle(c)
+	}
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/client"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// withOutputFormat sets the --output flag for the duration of a test
+func withOutputFormat(t *testing.T, format string) {
+	original := outputCmdFormat
+	outputCmdFormat = format
+	t.Cleanup(func() { outputCmdFormat = original })
+}
+
+func TestOutputFlag(t *testing.T) {
+	for _, c := range []*cobra.Command{listCmd, getCmd} {
+		flag := c.PersistentFlags().Lookup("output")
+		testhelpers.AssertNotNil(t, flag)
+		testhelpers.AssertEqual(t, "o", flag.Shorthand)
+		testhelpers.AssertEqual(t, "table", flag.DefValue)
+	}
+}
+
+func TestPrintStructured(t *testing.T) {
+	groups := []types.ToolGroup{
+		{Name: "claude", Description: "tools for claude", IncludedTools: []string{"time__now", "true"}},
+	}
+
+	t.Run("table", func(t *testing.T) {
+		withOutputFormat(t, "table")
+		var out bytes.Buffer
+		listGroupsCmd.SetOut(&out)
+		defer listGroupsCmd.SetOut(nil)
+
+		ok, err := printStructured(listGroupsCmd, groups)
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertFalse(t, ok, "Expected no structured output for the table format")
+		testhelpers.AssertEqual(t, "", out.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		withOutputFormat(t, "json")
+		var out bytes.Buffer
+		listGroupsCmd.SetOut(&out)
+		defer listGroupsCmd.SetOut(nil)
+
+		ok, err := printStructured(listGroupsCmd, groups)
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertTrue(t, ok, "Expected structured output")
+
+		var got []types.ToolGroup
+		testhelpers.AssertNoError(t, json.Unmarshal(out.Bytes(), &got))
+		testhelpers.AssertEqual(t, 1, len(got))
+		testhelpers.AssertEqual(t, "claude", got[0].Name)
+		testhelpers.AssertEqual(t, 2, len(got[0].IncludedTools))
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		withOutputFormat(t, "yaml")
+		var out bytes.Buffer
+		listGroupsCmd.SetOut(&out)
+		defer listGroupsCmd.SetOut(nil)
+
+		ok, err := printStructured(listGroupsCmd, groups)
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertTrue(t, ok, "Expected structured output")
+
+		want := "- name: claude\n" +
+			"  included_tools:\n" +
+			"    - time__now\n" +
+			"    - \"true\"\n" +
+			"  description: tools for claude\n"
+		testhelpers.AssertEqual(t, want, out.String())
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		withOutputFormat(t, "json")
+		var out bytes.Buffer
+		listGroupsCmd.SetOut(&out)
+		defer listGroupsCmd.SetOut(nil)
+
+		var none []types.ToolGroup
+		_, err := printStructured(listGroupsCmd, none)
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, "[]\n", out.String())
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		withOutputFormat(t, "xml")
+		_, err := printStructured(listGroupsCmd, groups)
+		testhelpers.AssertError(t, err)
+		testhelpers.AssertStringContains(t, err.Error(), "invalid value for --output flag: 'xml'")
+	})
+}
+
+func TestRunListServersJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]*types.McpServer{
+			{Name: "time", Transport: "stdio", Command: "uvx", Args: []string{"mcp-server-time"}},
+		})
+	}))
+	defer server.Close()
+
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+	apiClient = client.NewClient(server.URL)
+
+	withOutputFormat(t, "json")
+	var out bytes.Buffer
+	listServersCmd.SetOut(&out)
+	defer listServersCmd.SetOut(nil)
+
+	testhelpers.AssertNoError(t, runListServers(listServersCmd, nil))
+
+	var got []*types.McpServer
+	testhelpers.AssertNoError(t, json.Unmarshal(out.Bytes(), &got))
+	testhelpers.AssertEqual(t, 1, len(got))
+	testhelpers.AssertEqual(t, "time", got[0].Name)
+	testhelpers.AssertEqual(t, "uvx", got[0].Command)
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/migrations"
+	"github.com/mcpjungle/mcpjungle/internal/service/retention"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+var pruneCmdDBPath string
+
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete the historical data that is older than its retention window",
+	Long: "Delete the historical data that is older than its retention window, ie, the tool invocation history,\n" +
+		"completed tool invocation jobs, soft-deleted records and idempotency keys.\n\n" +
+		"The retention windows are configured with the following environment variables:\n" +
+		"  " + RetentionInvocationHistoryEnvVar + " (default: 0, the history is only capped by its size)\n" +
+		"  " + RetentionJobsEnvVar + " (default: " + retention.DefaultJobRetention.String() + ")\n" +
+		"  " + RetentionSoftDeletedEnvVar + " (default: " + retention.DefaultSoftDeletedRetention.String() + ")\n" +
+		"  " + RetentionIdempotencyKeysEnvVar + " (default: " + retention.DefaultIdempotencyKeyRetention.String() + ")\n" +
+		"A window of 0 keeps that data forever.\n\n" +
+		"The server already prunes this data in the background (see " + RetentionPruneIntervalEnvVar + "),\n" +
+		"this command lets you prune it on demand or from a scheduled job.\n\n" +
+		"This command connects to the database directly, so it must be run with the same database configuration\n" +
+		"as the server (DATABASE_URL, the POSTGRES_* environment variables or the SQLite database path).",
+	Args: cobra.NoArgs,
+	RunE: runPrune,
+	Annotations: map[string]string{
+		"group": string(subCommandGroupAdvanced),
+		"order": "15",
+	},
+}
+
+func init() {
+	pruneCmd.Flags().StringVar(
+		&pruneCmdDBPath,
+		"db-path",
+		"",
+		fmt.Sprintf(
+			"path of the SQLite database file, used when no Postgres database is configured (overrides env var %s)",
+			DBPathEnvVar,
+		),
+	)
+	rootCmd.AddCommand(pruneCmd)
+}
+
+func runPrune(cmd *cobra.Command, args []string) error {
+	dbConn, err := connectDBForCommand(pruneCmdDBPath)
+	if err != nil {
+		return err
+	}
+	conf, err := getRetentionConfig()
+	if err != nil {
+		return err
+	}
+	if err := migrations.Check(dbConn); err != nil {
+		return fmt.Errorf("the database schema is not up-to-date, run 'mcpjungle migrate up' first: %w", err)
+	}
+
+	var r retention.Result
+	err = dbConn.Transaction(func(tx *gorm.DB) error {
+		r, err = retention.NewPruner(tx, conf, logger.NewNop()).Prune(time.Now())
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to prune historical data: %w", err)
+	}
+	cmd.Printf(
+		"Deleted %d tool invocations, %d completed jobs, %d soft-deleted records and %d idempotency keys\n",
+		r.Invocations, r.Jobs, r.SoftDeleted, r.IdempotencyKeys,
+	)
+	return nil
+}
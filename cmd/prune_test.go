@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/db"
+	"github.com/mcpjungle/mcpjungle/internal/migrations"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestPruneCommandStructure(t *testing.T) {
+	testhelpers.AssertEqual(t, "prune", pruneCmd.Use)
+	testhelpers.TestCommandAnnotations(t, pruneCmd.Annotations, []testhelpers.CommandAnnotationTest{
+		{Key: "group", Expected: string(subCommandGroupAdvanced)},
+		{Key: "order", Expected: "15"},
+	})
+	testhelpers.AssertNotNil(t, pruneCmd.Flags().Lookup("db-path"))
+}
+
+func TestRunPrune(t *testing.T) {
+	withEnv(map[string]string{
+		DBUrlEnvVar:                      "",
+		PostgresHostEnvVar:               "",
+		RetentionInvocationHistoryEnvVar: "24h",
+	}, func() {
+		pruneCmdDBPath = filepath.Join(t.TempDir(), "mcpjungle.db")
+		defer func() { pruneCmdDBPath = "" }()
+
+		dbConn, err := db.NewSQLiteDBConnection(pruneCmdDBPath, logger.NewNop())
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertNoError(t, migrations.Migrate(dbConn))
+		for _, age := range []time.Duration{time.Hour, 48 * time.Hour} {
+			inv := &model.ToolInvocation{
+				ToolName:  "a__b",
+				Outcome:   types.InvocationOutcomeSuccess,
+				CreatedAt: time.Now().Add(-age),
+			}
+			testhelpers.AssertNoError(t, dbConn.Create(inv).Error)
+		}
+
+		var out bytes.Buffer
+		pruneCmd.SetOut(&out)
+		testhelpers.AssertNoError(t, runPrune(pruneCmd, nil))
+		testhelpers.AssertStringContains(t, out.String(), "Deleted 1 tool invocations")
+
+		var count int64
+		testhelpers.AssertNoError(t, dbConn.Model(&model.ToolInvocation{}).Count(&count).Error)
+		testhelpers.AssertEqual(t, int64(1), count)
+	})
+}
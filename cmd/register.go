@@ -1,10 +1,10 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 
+	"github.com/mcpjungle/mcpjungle/client"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"github.com/spf13/cobra"
 )
@@ -22,7 +22,7 @@ var registerMCPServerCmd = &cobra.Command{
 	Use:   "register",
 	Short: "Register an MCP Server",
 	Long: "Register an MCP Server in mcpjungle.\n" +
-		"The recommended way is to specify the json configuration file for your mcp server.\n" +
+		"The recommended way is to specify the json (or yaml) configuration file for your mcp server.\n" +
 		"Flags are provided for convenience if you want to register a streamable http based server.\n" +
 		"But a config file is *required* if you want to register a server using stdio or sse transport.\n" +
 		"\nNOTE: A server's name is unique across mcpjungle and must not contain\nany whitespaces, special characters or multiple consecutive underscores '__'.",
@@ -78,7 +78,7 @@ func init() {
 		"conf",
 		"c",
 		"",
-		"Path to a JSON configuration file for the MCP server.\n"+
+		"Path to a JSON (or YAML) configuration file for the MCP server.\n"+
 			"If provided, the mcp server will be registered using the configuration in the file.\n"+
 			"All other flags will be ignored.",
 	)
@@ -87,18 +87,17 @@ func init() {
 }
 
 func readMcpServerConfig(filePath string) (types.RegisterServerInput, error) {
-	var input types.RegisterServerInput
-
-	data, err := os.ReadFile(filePath)
+	f, err := os.Open(filePath)
 	if err != nil {
-		return input, fmt.Errorf("failed to read config file %s: %w", filePath, err)
-	}
-	// Parse JSON config
-	if err := json.Unmarshal(data, &input); err != nil {
-		return input, fmt.Errorf("failed to parse config file: %w", err)
+		return types.RegisterServerInput{}, fmt.Errorf("failed to read config file %s: %w", filePath, err)
 	}
+	defer f.Close()
 
-	return input, nil
+	input, err := client.ParseServerConfig(f)
+	if err != nil {
+		return types.RegisterServerInput{}, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	return *input, nil
 }
 
 func runRegisterMCPServer(cmd *cobra.Command, args []string) error {
@@ -126,7 +125,7 @@ func runRegisterMCPServer(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to register server: %w", err)
 	}
-	fmt.Printf("Server %s registered successfully!\n", s.Name)
+	fmt.Fprintf(cmd.OutOrStdout(), "Server %s registered successfully!\n", s.Name)
 
 	if types.McpServerTransport(s.Transport) == types.TransportSSE {
 		cmd.Println()
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mcpjungle/mcpjungle/internal/encryption"
+	"github.com/mcpjungle/mcpjungle/internal/migrations"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+var rekeyCmdDBPath string
+
+var rekeyCmd = &cobra.Command{
+	Use:   "rekey",
+	Short: "Re-encrypt the credentials stored in the database with the current encryption key",
+	Long: "Re-encrypt the credentials stored in the database (MCP server configurations and the access tokens\n" +
+		"of users & MCP clients) with the key in the " + EncryptionKeyEnvVar + " environment variable.\n\n" +
+		"Run it after enabling encryption on an existing database to encrypt the credentials stored in plain text,\n" +
+		"or after rotating the key to re-encrypt the credentials with the new key.\n" +
+		"To rotate the key, move the current key to " + EncryptionPreviousKeysEnvVar + ", set the new key\n" +
+		"in " + EncryptionKeyEnvVar + " and run this command.\n" +
+		"Once it completes, the previous keys are no longer needed.\n\n" +
+		"This command connects to the database directly, so it must be run with the same database configuration\n" +
+		"as the server (DATABASE_URL, the POSTGRES_* environment variables or the SQLite database path).",
+	Args: cobra.NoArgs,
+	RunE: runRekey,
+	Annotations: map[string]string{
+		"group": string(subCommandGroupAdvanced),
+		"order": "14",
+	},
+}
+
+func init() {
+	rekeyCmd.Flags().StringVar(
+		&rekeyCmdDBPath,
+		"db-path",
+		"",
+		fmt.Sprintf(
+			"path of the SQLite database file, used when no Postgres database is configured (overrides env var %s)",
+			DBPathEnvVar,
+		),
+	)
+	rootCmd.AddCommand(rekeyCmd)
+}
+
+func runRekey(cmd *cobra.Command, args []string) error {
+	dbConn, err := connectDBForCommand(rekeyCmdDBPath)
+	if err != nil {
+		return err
+	}
+	if !encryption.Enabled() {
+		return fmt.Errorf("%s environment variable must be set to re-encrypt the credentials", EncryptionKeyEnvVar)
+	}
+	if err := migrations.Check(dbConn); err != nil {
+		return fmt.Errorf("the database schema is not up-to-date, run 'mcpjungle migrate up' first: %w", err)
+	}
+
+	var n int64
+	err = dbConn.Transaction(func(tx *gorm.DB) error {
+		n, err = encryption.Rekey(tx, model.EncryptedModels...)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to re-encrypt credentials: %w", err)
+	}
+	cmd.Printf("Re-encrypted the credentials of %d records\n", n)
+	return nil
+}
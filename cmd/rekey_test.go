@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/base64"
+	"path/filepath"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/internal/db"
+	"github.com/mcpjungle/mcpjungle/internal/encryption"
+	"github.com/mcpjungle/mcpjungle/internal/migrations"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/user"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestRekeyCommandStructure(t *testing.T) {
+	testhelpers.AssertEqual(t, "rekey", rekeyCmd.Use)
+	testhelpers.TestCommandAnnotations(t, rekeyCmd.Annotations, []testhelpers.CommandAnnotationTest{
+		{Key: "group", Expected: string(subCommandGroupAdvanced)},
+		{Key: "order", Expected: "14"},
+	})
+	testhelpers.AssertNotNil(t, rekeyCmd.Flags().Lookup("db-path"))
+}
+
+func TestRunRekey(t *testing.T) {
+	defer encryption.SetKeyring(nil)
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, encryption.KeySize))
+
+	withEnv(map[string]string{DBUrlEnvVar: "", PostgresHostEnvVar: "", EncryptionKeyEnvVar: ""}, func() {
+		rekeyCmdDBPath = filepath.Join(t.TempDir(), "mcpjungle.db")
+		defer func() { rekeyCmdDBPath = "" }()
+
+		// the database was created before encryption was enabled
+		encryption.SetKeyring(nil)
+		dbConn, err := db.NewSQLiteDBConnection(rekeyCmdDBPath, logger.NewNop())
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertNoError(t, migrations.Migrate(dbConn))
+		admin, err := user.NewUserService(dbConn).CreateAdminUser()
+		testhelpers.AssertNoError(t, err)
+
+		// no key is configured
+		testhelpers.AssertError(t, runRekey(rekeyCmd, nil))
+
+		withEnv(map[string]string{EncryptionKeyEnvVar: key}, func() {
+			var out bytes.Buffer
+			rekeyCmd.SetOut(&out)
+			testhelpers.AssertNoError(t, runRekey(rekeyCmd, nil))
+			testhelpers.AssertStringContains(t, out.String(), "Re-encrypted the credentials of 1 records")
+		})
+
+		var stored string
+		testhelpers.AssertNoError(t, dbConn.Model(&model.User{}).Select("access_token").Scan(&stored).Error)
+		testhelpers.AssertTrue(t, encryption.IsEncrypted(stored), "access token should be encrypted")
+
+		found, err := user.NewUserService(dbConn).GetUserByAccessToken(admin.AccessToken)
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, admin.Username, found.Username)
+	})
+}
@@ -20,7 +20,7 @@ package cmd
 import (
 	"errors"
 	"fmt"
-	"net/http"
+	"io"
 	"sort"
 	"strconv"
 
@@ -61,6 +61,13 @@ var ErrSilent = errors.New("SilentErr")
 
 var registryServerURL string
 
+// profileName is the profile of the client configuration to use, empty for the current profile
+var profileName string
+
+// quietMode suppresses the output of commands, so that scripts can rely on their exit code alone.
+// Errors are still printed.
+var quietMode bool
+
 // apiClient is the global API client used by command handlers to interact with the MCPJungle registry server.
 // It is not the best choice to rely on a global variable, but cobra doesn't seem to provide any neat way to
 // pass an object down the command tree.
@@ -107,20 +114,44 @@ func Execute() error {
 		"http://127.0.0.1:"+BindPortDefault,
 		"Base URL of the MCPJungle registry server",
 	)
+	rootCmd.PersistentFlags().BoolVarP(
+		&quietMode,
+		"quiet",
+		"q",
+		false,
+		"Suppress all output except errors, see the exit codes in the README",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&profileName,
+		"profile",
+		"",
+		"Profile of the client configuration to use, instead of the current one (see 'mcpjungle use-context')",
+	)
 
 	// Initialize the API client with the registry server URL & client configuration (if any)
-	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if quietMode {
+			cmd.Root().SetOut(io.Discard)
+		}
+
 		cfg := config.Load()
+		profile, err := cfg.Profile(profileName)
+		// login & init-server create the profile if it doesn't exist yet,
+		// use-context must keep working to switch away from a profile that was removed
+		if err != nil && cmd != loginCmd && cmd != initServerCmd && cmd != useContextCmd {
+			return err
+		}
 
 		// determine the registry server URL to use
-		// precedence: command line flag explicitly set by user > config file > flag default value
+		// precedence: command line flag explicitly set by user > profile in config file > flag default value
 		var u string
 		if cmd.Flags().Changed("registry") {
 			u = registryServerURL
 
 			// if the user explicitly set the --registry flag, but the config file doesn't have
-			// a registry_url entry, print a tip to let them know they can set it in the config file
-			if cfg.RegistryURL == "" {
+			// a registry_url entry, print a tip to let them know they can set it in the config file.
+			// login stores the URL in the config file itself.
+			if profile.RegistryURL == "" && cmd != loginCmd {
 				if cfgFilePath, err := config.AbsPath(); err == nil {
 					cmd.Printf(
 						"TIP: You can set `registry_url: %s` in %s to avoid setting the --registry flag every time.\n\n",
@@ -130,13 +161,19 @@ func Execute() error {
 				}
 			}
 
-		} else if cfg.RegistryURL != "" {
-			u = cfg.RegistryURL
+		} else if profile.RegistryURL != "" {
+			u = profile.RegistryURL
 		} else {
 			u = registryServerURL
 		}
 
-		apiClient = client.NewClient(u, cfg.AccessToken, http.DefaultClient)
+		apiClient = client.NewClient(
+			u,
+			client.WithToken(profile.AccessToken),
+			client.WithUserAgent("mcpjungle-cli/"+version.GetVersion()),
+			client.WithStreamErrorHandler(func(err error) { cmd.PrintErrln("Warning:", err) }),
+		)
+		return nil
 	}
 
 	return rootCmd.Execute()
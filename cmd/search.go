@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	searchCmdType  string
+	searchCmdLimit int
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search for tools and prompts",
+	Long: "Search the names and descriptions of all tools and prompts in mcpjungle.\n" +
+		"Results are ranked by relevance, best matches first.",
+	Example: `  # Find tools & prompts that deal with pull requests
+  mcpjungle search "pull request"
+
+  # Only search tools
+  mcpjungle search "read file" --type tool`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSearch,
+	Annotations: map[string]string{
+		"group": string(subCommandGroupBasic),
+		"order": "8",
+	},
+}
+
+func init() {
+	searchCmd.Flags().StringVar(
+		&searchCmdType,
+		"type",
+		"",
+		fmt.Sprintf("Only search entities of this type (%s or %s)", types.SearchResultTool, types.SearchResultPrompt),
+	)
+	searchCmd.Flags().IntVar(&searchCmdLimit, "limit", 10, "Maximum number of results to display")
+
+	rootCmd.AddCommand(searchCmd)
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	query := strings.Join(args, " ")
+
+	results, err := apiClient.Search(query, types.SearchResultType(searchCmdType), searchCmdLimit)
+	if err != nil {
+		return fmt.Errorf("failed to search: %w", err)
+	}
+
+	if len(results) == 0 {
+		cmd.Printf("No tools or prompts found matching '%s'\n", query)
+		return nil
+	}
+
+	for i, r := range results {
+		status := ""
+		if !r.Enabled {
+			status = "  [DISABLED]"
+		}
+		cmd.Printf("%d. [%s] %s%s\n", i+1, r.Type, r.Name, status)
+		if r.Description != "" {
+			cmd.Println(r.Description)
+		}
+		cmd.Println()
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestSearchCommandStructure(t *testing.T) {
+	t.Parallel()
+
+	testhelpers.AssertEqual(t, "search <query>", searchCmd.Use)
+	testhelpers.AssertEqual(t, "Search for tools and prompts", searchCmd.Short)
+	testhelpers.AssertTrue(t, len(searchCmd.Long) > 0, "Long description should not be empty")
+
+	annotationTests := []testhelpers.CommandAnnotationTest{
+		{Key: "group", Expected: string(subCommandGroupBasic)},
+		{Key: "order", Expected: "8"},
+	}
+	testhelpers.TestCommandAnnotations(t, searchCmd.Annotations, annotationTests)
+
+	testhelpers.AssertNotNil(t, searchCmd.RunE)
+	testhelpers.AssertNotNil(t, searchCmd.Flags().Lookup("type"))
+
+	limitFlag := searchCmd.Flags().Lookup("limit")
+	testhelpers.AssertNotNil(t, limitFlag)
+	testhelpers.AssertEqual(t, "10", limitFlag.DefValue)
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/mcpjungle/mcpjungle/internal/service/alert"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+)
+
+// ShutdownDrainTimeoutEnvVar is how long the server waits for the requests and the async tool invocations in flight
+// to complete when it is stopped with SIGTERM or SIGINT (eg- "30s"), before closing the remaining connections
+const ShutdownDrainTimeoutEnvVar = "SHUTDOWN_DRAIN_TIMEOUT"
+
+const defaultShutdownDrainTimeout = 30 * time.Second
+
+// envFilePath is the file from which the server reads environment variables, in addition to its actual environment
+const envFilePath = ".env"
+
+// Environment variables set by systemd (or any compatible supervisor) when it passes listening sockets to the
+// server, see sd_listen_fds(3).
+const (
+	listenPIDEnvVar     = "LISTEN_PID"
+	listenFDsEnvVar     = "LISTEN_FDS"
+	listenFDNamesEnvVar = "LISTEN_FDNAMES"
+
+	// listenFDsStart is the first file descriptor passed by the supervisor
+	listenFDsStart = 3
+)
+
+// getShutdownDrainTimeout returns how long the server is drained for when it is stopped.
+func getShutdownDrainTimeout() (time.Duration, error) {
+	return getDurationEnv(ShutdownDrainTimeoutEnvVar, defaultShutdownDrainTimeout, "a duration like '30s'")
+}
+
+// inheritedListener returns the listening socket passed to the server by systemd socket activation, or nil if
+// there is none. The socket outlives the server process, so connections made while the server restarts are queued
+// instead of being refused.
+func inheritedListener() (net.Listener, error) {
+	if os.Getenv(listenPIDEnvVar) != strconv.Itoa(os.Getpid()) {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(os.Getenv(listenFDsEnvVar))
+	if err != nil || n < 1 {
+		return nil, nil
+	}
+	if n > 1 {
+		return nil, fmt.Errorf("expected a single listening socket to be passed by the supervisor, got %d", n)
+	}
+	// the child processes (eg- stdio MCP servers) must not take the socket for themselves
+	for _, envVar := range []string{listenPIDEnvVar, listenFDsEnvVar, listenFDNamesEnvVar} {
+		_ = os.Unsetenv(envVar)
+	}
+
+	f := os.NewFile(uintptr(listenFDsStart), "listen-fd")
+	defer f.Close()
+	l, err := net.FileListener(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to use the listening socket passed by the supervisor: %w", err)
+	}
+	return l, nil
+}
+
+// envFile keeps track of the environment variables set from the .env file, so that the file can be reloaded.
+// Like when the server starts, variables set in the actual environment take precedence over the file.
+type envFile struct {
+	path string
+	// keys are the names of the variables set from the file
+	keys map[string]bool
+}
+
+// loadEnvFile sets the environment variables from the given file, if it exists.
+func loadEnvFile(path string) (*envFile, error) {
+	f := &envFile{path: path, keys: make(map[string]bool)}
+	return f, f.reload()
+}
+
+// reload sets the environment variables from the file again.
+// The variables that were removed from the file are unset.
+func (f *envFile) reload() error {
+	values, err := godotenv.Read(f.path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to read %s: %w", f.path, err)
+	}
+	for key := range f.keys {
+		if _, ok := values[key]; !ok {
+			_ = os.Unsetenv(key)
+			delete(f.keys, key)
+		}
+	}
+	for key, value := range values {
+		if _, set := os.LookupEnv(key); set && !f.keys[key] {
+			continue
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return fmt.Errorf("failed to set %s from %s: %w", key, f.path, err)
+		}
+		f.keys[key] = true
+	}
+	return nil
+}
+
+// reloadServerConfig re-reads the .env file and applies the settings that can change while the server runs:
+// the log level and the settings of the error rate alerts. alerter is nil if alerting was disabled at startup.
+func reloadServerConfig(env *envFile, l logger.Logger, alerter *alert.ErrorRateAlerter) error {
+	if err := env.reload(); err != nil {
+		return err
+	}
+
+	level := strings.ToLower(os.Getenv(LogLevelEnvVar))
+	if level == "" {
+		level = logger.DefaultConfig().Level
+	}
+	if err := logger.SetLevel(l, level); err != nil {
+		return fmt.Errorf("invalid value for %s environment variable: %w", LogLevelEnvVar, err)
+	}
+
+	conf, enabled, err := getAlertConfig()
+	if err != nil {
+		return err
+	}
+	if alerter == nil {
+		if enabled {
+			l.Warn("alerting was disabled when the server started, restart the server to enable it")
+		}
+		return nil
+	}
+	alerter.SetConfig(conf)
+	return nil
+}
+
+// reloadOnSignal reloads the server's configuration every time the process receives SIGHUP, until ctx is done.
+// Failures are logged, and the settings that failed to load are left unchanged.
+func reloadOnSignal(ctx context.Context, env *envFile, l logger.Logger, alerter *alert.ErrorRateAlerter) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(hup)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hup:
+				if err := reloadServerConfig(env, l, alerter); err != nil {
+					l.Error("failed to reload the configuration", logger.ErrorField(err))
+					continue
+				}
+				l.Info("reloaded the configuration")
+			}
+		}
+	}()
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/service/alert"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestGetShutdownDrainTimeout(t *testing.T) {
+	timeout, err := getShutdownDrainTimeout()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, defaultShutdownDrainTimeout, timeout)
+
+	t.Setenv(ShutdownDrainTimeoutEnvVar, "2m")
+	timeout, err = getShutdownDrainTimeout()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 2*time.Minute, timeout)
+
+	for _, v := range []string{"soon", "-1s"} {
+		t.Setenv(ShutdownDrainTimeoutEnvVar, v)
+		_, err = getShutdownDrainTimeout()
+		testhelpers.AssertError(t, err)
+	}
+}
+
+func TestInheritedListenerWithoutSupervisor(t *testing.T) {
+	t.Setenv(listenPIDEnvVar, "1")
+	t.Setenv(listenFDsEnvVar, "1")
+	l, err := inheritedListener()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, l == nil, "the sockets passed to another process must be ignored")
+}
+
+func TestEnvFileReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	write := func(content string) {
+		testhelpers.AssertNoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	t.Setenv("MCPJUNGLE_TEST_FROM_ENV", "env")
+	t.Cleanup(func() {
+		_ = os.Unsetenv("MCPJUNGLE_TEST_FROM_FILE")
+		_ = os.Unsetenv("MCPJUNGLE_TEST_REMOVED")
+	})
+
+	// a missing file is not an error
+	env, err := loadEnvFile(path)
+	testhelpers.AssertNoError(t, err)
+
+	write("MCPJUNGLE_TEST_FROM_FILE=one\nMCPJUNGLE_TEST_REMOVED=x\nMCPJUNGLE_TEST_FROM_ENV=file\n")
+	testhelpers.AssertNoError(t, env.reload())
+	testhelpers.AssertEqual(t, "one", os.Getenv("MCPJUNGLE_TEST_FROM_FILE"))
+	testhelpers.AssertEqual(t, "x", os.Getenv("MCPJUNGLE_TEST_REMOVED"))
+	testhelpers.AssertEqual(t, "env", os.Getenv("MCPJUNGLE_TEST_FROM_ENV"))
+
+	write("MCPJUNGLE_TEST_FROM_FILE=two\n")
+	testhelpers.AssertNoError(t, env.reload())
+	testhelpers.AssertEqual(t, "two", os.Getenv("MCPJUNGLE_TEST_FROM_FILE"))
+	_, set := os.LookupEnv("MCPJUNGLE_TEST_REMOVED")
+	testhelpers.AssertFalse(t, set, "the variables removed from the file must be unset")
+	testhelpers.AssertEqual(t, "env", os.Getenv("MCPJUNGLE_TEST_FROM_ENV"))
+}
+
+func TestReloadServerConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	env, err := loadEnvFile(path)
+	testhelpers.AssertNoError(t, err)
+	t.Cleanup(func() {
+		_ = os.Unsetenv(LogLevelEnvVar)
+		_ = os.Unsetenv(AlertWebhookURLEnvVar)
+	})
+
+	buf := logger.NewBuffer(10)
+	l, err := logger.New(&logger.Config{Level: "info", Development: true, Buffer: buf})
+	testhelpers.AssertNoError(t, err)
+	alerter := alert.NewErrorRateAlerter(telemetry.NewNoopCustomMetrics(), alert.Config{}, l)
+
+	testhelpers.AssertNoError(t, os.WriteFile(path, []byte("LOG_LEVEL=debug\nALERT_WEBHOOK_URL=http://localhost:9999\n"), 0o600))
+	testhelpers.AssertNoError(t, reloadServerConfig(env, l, alerter))
+	l.Debug("recorded")
+	testhelpers.AssertEqual(t, 1, len(buf.Entries()))
+
+	// an invalid setting is reported
+	testhelpers.AssertNoError(t, os.WriteFile(path, []byte("ALERT_WEBHOOK_URL=localhost\n"), 0o600))
+	testhelpers.AssertError(t, reloadServerConfig(env, l, alerter))
+	l.Debug("dropped, the log level is back to its default")
+	testhelpers.AssertEqual(t, 1, len(buf.Entries()))
+
+	// alerting can't be enabled without a restart
+	testhelpers.AssertNoError(t, os.WriteFile(path, []byte("ALERT_WEBHOOK_URL=http://localhost:9999\n"), 0o600))
+	testhelpers.AssertNoError(t, reloadServerConfig(env, l, nil))
+	testhelpers.AssertStringContains(t, buf.Entries()[1].Message, "restart the server to enable it")
+}
@@ -1,9 +1,22 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/base64"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/api"
+	"github.com/mcpjungle/mcpjungle/internal/db"
+	"github.com/mcpjungle/mcpjungle/internal/encryption"
+	"github.com/mcpjungle/mcpjungle/internal/service/invocation"
+	"github.com/mcpjungle/mcpjungle/internal/service/job"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/service/retention"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
 )
 
 func TestStartCommandStructure(t *testing.T) {
@@ -60,6 +73,27 @@ func TestStartCommandFlags(t *testing.T) {
 			t.Error("enterprise flag should have usage description")
 		}
 	})
+
+	t.Run("start command has ephemeral flag", func(t *testing.T) {
+		ephemeralFlag := startServerCmd.Flags().Lookup("ephemeral")
+		if ephemeralFlag == nil {
+			t.Fatal("Start command missing 'ephemeral' flag")
+		}
+		if ephemeralFlag.DefValue != "false" {
+			t.Errorf("ephemeral flag should be disabled by default, got %s", ephemeralFlag.DefValue)
+		}
+	})
+
+	t.Run("start command has OTLP flags", func(t *testing.T) {
+		for _, name := range []string{
+			"otlp-endpoint", "otlp-protocol", "otlp-headers", "otlp-insecure",
+			"otlp-ca-cert", "otlp-client-cert", "otlp-client-key",
+		} {
+			if startServerCmd.Flags().Lookup(name) == nil {
+				t.Errorf("Start command missing '%s' flag", name)
+			}
+		}
+	})
 }
 
 // Helper to set and unset env vars for a test
@@ -245,3 +279,752 @@ func TestGetPostgresDSN(t *testing.T) {
 		})
 	})
 }
+
+func TestGetHTTPServerConfig(t *testing.T) {
+	t.Run("uses defaults when no env vars are set", func(t *testing.T) {
+		conf, err := getHTTPServerConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if conf != api.DefaultHTTPServerConfig() {
+			t.Errorf("expected default config, got %+v", conf)
+		}
+	})
+
+	t.Run("env vars override defaults", func(t *testing.T) {
+		withEnv(map[string]string{
+			HTTPReadTimeoutEnvVar:            "15s",
+			HTTPWriteTimeoutEnvVar:           "0",
+			HTTPStreamWriteTimeoutEnvVar:     "1m",
+			HTTPMaxHeaderBytesEnvVar:         "4096",
+			HTTPCompressionMinBytesEnvVar:    "0",
+			HTTPStreamResponseMinBytesEnvVar: "65536",
+		}, func() {
+			conf, err := getHTTPServerConfig()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if conf.ReadTimeout != 15*time.Second {
+				t.Errorf("expected read timeout 15s, got %s", conf.ReadTimeout)
+			}
+			if conf.WriteTimeout != 0 {
+				t.Errorf("expected write timeout to be disabled, got %s", conf.WriteTimeout)
+			}
+			if conf.StreamWriteTimeout != time.Minute {
+				t.Errorf("expected stream write timeout 1m, got %s", conf.StreamWriteTimeout)
+			}
+			if conf.MaxHeaderBytes != 4096 {
+				t.Errorf("expected max header bytes 4096, got %d", conf.MaxHeaderBytes)
+			}
+			if conf.CompressionMinBytes != 0 {
+				t.Errorf("expected compression to be disabled, got min bytes %d", conf.CompressionMinBytes)
+			}
+			if conf.StreamResponseMinBytes != 65536 {
+				t.Errorf("expected stream response min bytes 65536, got %d", conf.StreamResponseMinBytes)
+			}
+			if conf.IdleTimeout != api.DefaultHTTPServerConfig().IdleTimeout {
+				t.Errorf("expected default idle timeout, got %s", conf.IdleTimeout)
+			}
+		})
+	})
+
+	invalid := map[string]string{
+		HTTPIdleTimeoutEnvVar:            "forever",
+		HTTPReadHeaderTimeoutEnvVar:      "-5s",
+		HTTPMaxHeaderBytesEnvVar:         "lots",
+		HTTPStreamWriteTimeoutEnvVar:     "10",
+		HTTPCompressionMinBytesEnvVar:    "-1",
+		HTTPStreamResponseMinBytesEnvVar: "1MB",
+	}
+	for envVar, value := range invalid {
+		t.Run("rejects invalid "+envVar, func(t *testing.T) {
+			withEnv(map[string]string{envVar: value}, func() {
+				if _, err := getHTTPServerConfig(); err == nil {
+					t.Errorf("expected an error for %s=%s", envVar, value)
+				}
+			})
+		})
+	}
+}
+
+func TestGetToolInvocationHistorySize(t *testing.T) {
+	size, err := getToolInvocationHistorySize()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if size != invocation.DefaultHistorySize {
+		t.Errorf("expected default size %d, got %d", invocation.DefaultHistorySize, size)
+	}
+
+	withEnv(map[string]string{ToolInvocationHistorySizeEnvVar: "0"}, func() {
+		size, err := getToolInvocationHistorySize()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if size != 0 {
+			t.Errorf("expected history to be disabled, got size %d", size)
+		}
+	})
+
+	for _, v := range []string{"-1", "many"} {
+		withEnv(map[string]string{ToolInvocationHistorySizeEnvVar: v}, func() {
+			if _, err := getToolInvocationHistorySize(); err == nil {
+				t.Errorf("expected an error for %s=%s", ToolInvocationHistorySizeEnvVar, v)
+			}
+		})
+	}
+}
+
+func TestIsPrometheusEnabled(t *testing.T) {
+	enabled, err := isPrometheusEnabled()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if enabled {
+		t.Error("expected Prometheus metrics to be disabled by default")
+	}
+
+	withEnv(map[string]string{PrometheusEnabledEnvVar: "TRUE"}, func() {
+		enabled, err := isPrometheusEnabled()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !enabled {
+			t.Errorf("expected Prometheus metrics to be enabled by %s=TRUE", PrometheusEnabledEnvVar)
+		}
+	})
+
+	withEnv(map[string]string{PrometheusEnabledEnvVar: "yes"}, func() {
+		if _, err := isPrometheusEnabled(); err == nil {
+			t.Errorf("expected an error for %s=yes", PrometheusEnabledEnvVar)
+		}
+	})
+}
+
+func TestNewLogger(t *testing.T) {
+	for _, v := range []string{"", "console", "JSON"} {
+		withEnv(map[string]string{LogFormatEnvVar: v}, func() {
+			if _, err := newLogger(nil); err != nil {
+				t.Errorf("unexpected error for %s=%s: %v", LogFormatEnvVar, v, err)
+			}
+		})
+	}
+	withEnv(map[string]string{LogLevelEnvVar: "DEBUG"}, func() {
+		if _, err := newLogger(nil); err != nil {
+			t.Errorf("unexpected error for %s=DEBUG: %v", LogLevelEnvVar, err)
+		}
+	})
+
+	withEnv(map[string]string{LogFormatEnvVar: "xml"}, func() {
+		if _, err := newLogger(nil); err == nil {
+			t.Errorf("expected an error for %s=xml", LogFormatEnvVar)
+		}
+	})
+	withEnv(map[string]string{LogLevelEnvVar: "loud"}, func() {
+		if _, err := newLogger(nil); err == nil {
+			t.Errorf("expected an error for %s=loud", LogLevelEnvVar)
+		}
+	})
+}
+
+func TestGetLogBuffer(t *testing.T) {
+	withEnv(map[string]string{LogBufferSizeEnvVar: ""}, func() {
+		buf, err := getLogBuffer()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if buf == nil {
+			t.Error("expected the log buffer to be enabled by default")
+		}
+	})
+	withEnv(map[string]string{LogBufferSizeEnvVar: "0"}, func() {
+		buf, err := getLogBuffer()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if buf != nil {
+			t.Errorf("expected the log buffer to be disabled by %s=0", LogBufferSizeEnvVar)
+		}
+	})
+	for _, v := range []string{"-1", "lots"} {
+		withEnv(map[string]string{LogBufferSizeEnvVar: v}, func() {
+			if _, err := getLogBuffer(); err == nil {
+				t.Errorf("expected an error for %s=%s", LogBufferSizeEnvVar, v)
+			}
+		})
+	}
+}
+
+func TestGetToolInvocationRedactionPatterns(t *testing.T) {
+	if patterns := getToolInvocationRedactionPatterns(); len(patterns) != 0 {
+		t.Errorf("expected no patterns by default, got %v", patterns)
+	}
+
+	withEnv(map[string]string{ToolInvocationRedactEnvVar: " *token*, ssn ,,"}, func() {
+		patterns := getToolInvocationRedactionPatterns()
+		if len(patterns) != 2 || patterns[0] != "*token*" || patterns[1] != "ssn" {
+			t.Errorf("expected patterns [*token* ssn], got %v", patterns)
+		}
+	})
+}
+
+func TestGetSlowToolCallThreshold(t *testing.T) {
+	threshold, err := getSlowToolCallThreshold()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if threshold != 0 {
+		t.Errorf("expected slow tool call detection to be disabled by default, got %s", threshold)
+	}
+
+	withEnv(map[string]string{SlowToolCallThresholdEnvVar: "10s"}, func() {
+		threshold, err := getSlowToolCallThreshold()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if threshold != 10*time.Second {
+			t.Errorf("expected threshold 10s, got %s", threshold)
+		}
+	})
+
+	for _, v := range []string{"-1s", "slow"} {
+		withEnv(map[string]string{SlowToolCallThresholdEnvVar: v}, func() {
+			if _, err := getSlowToolCallThreshold(); err == nil {
+				t.Errorf("expected an error for %s=%s", SlowToolCallThresholdEnvVar, v)
+			}
+		})
+	}
+}
+
+func TestGetDurationEnv(t *testing.T) {
+	const envVar = "MCPJUNGLE_TEST_DURATION"
+
+	// an unset variable keeps the default, even a default of 0 where 0 isn't a valid value
+	for _, get := range []func(string, time.Duration, string) (time.Duration, error){getDurationEnv, getPositiveDurationEnv} {
+		d, err := get(envVar, 0, "a duration")
+		if err != nil || d != 0 {
+			t.Errorf("expected the default for an unset variable, got %s, %v", d, err)
+		}
+	}
+
+	withEnv(map[string]string{envVar: "0"}, func() {
+		if d, err := getDurationEnv(envVar, time.Minute, "a duration"); err != nil || d != 0 {
+			t.Errorf("expected 0, got %s, %v", d, err)
+		}
+		_, err := getPositiveDurationEnv(envVar, time.Minute, "a positive duration like '1m'")
+		if err == nil || !strings.Contains(err.Error(), "expected a positive duration like '1m'") {
+			t.Errorf("expected an error describing a positive duration, got %v", err)
+		}
+	})
+
+	withEnv(map[string]string{envVar: "-1s"}, func() {
+		if _, err := getDurationEnv(envVar, time.Minute, "a duration"); err == nil {
+			t.Errorf("expected an error for %s=-1s", envVar)
+		}
+	})
+}
+
+func TestGetJobTimeout(t *testing.T) {
+	timeout, err := getJobTimeout()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if timeout != job.DefaultTimeout {
+		t.Errorf("expected the default timeout, got %s", timeout)
+	}
+
+	withEnv(map[string]string{JobTimeoutEnvVar: "10m"}, func() {
+		timeout, err := getJobTimeout()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if timeout != 10*time.Minute {
+			t.Errorf("expected timeout 10m, got %s", timeout)
+		}
+	})
+
+	// a job can't complete without any time to run
+	for _, v := range []string{"0", "-1s", "long"} {
+		withEnv(map[string]string{JobTimeoutEnvVar: v}, func() {
+			if _, err := getJobTimeout(); err == nil {
+				t.Errorf("expected an error for %s=%s", JobTimeoutEnvVar, v)
+			}
+		})
+	}
+}
+
+func TestGetServerCacheTTL(t *testing.T) {
+	ttl, err := getServerCacheTTL()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ttl != mcp.DefaultServerCacheTTL {
+		t.Errorf("expected the default TTL, got %s", ttl)
+	}
+
+	withEnv(map[string]string{ServerCacheTTLEnvVar: "0"}, func() {
+		ttl, err := getServerCacheTTL()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ttl != 0 {
+			t.Errorf("expected caching to be disabled, got %s", ttl)
+		}
+	})
+
+	for _, v := range []string{"-1s", "forever"} {
+		withEnv(map[string]string{ServerCacheTTLEnvVar: v}, func() {
+			if _, err := getServerCacheTTL(); err == nil {
+				t.Errorf("expected an error for %s=%s", ServerCacheTTLEnvVar, v)
+			}
+		})
+	}
+}
+
+func TestGetAlertConfig(t *testing.T) {
+	_, enabled, err := getAlertConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if enabled {
+		t.Error("expected alerting to be disabled by default")
+	}
+
+	withEnv(map[string]string{
+		AlertWebhookURLEnvVar:         "https://hooks.slack.com/services/T000/B000/XXXX",
+		AlertErrorRateThresholdEnvVar: "0.25",
+		AlertWindowEnvVar:             "10m",
+		AlertMinCallsEnvVar:           "20",
+	}, func() {
+		conf, enabled, err := getAlertConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !enabled {
+			t.Fatal("expected alerting to be enabled")
+		}
+		if conf.ErrorRateThreshold != 0.25 || conf.Window != 10*time.Minute || conf.MinCalls != 20 {
+			t.Errorf("unexpected alert config: %+v", conf)
+		}
+	})
+
+	invalid := []map[string]string{
+		{AlertWebhookURLEnvVar: "hooks.slack.com"},
+		{AlertWebhookURLEnvVar: "https://example.com", AlertErrorRateThresholdEnvVar: "50%"},
+		{AlertWebhookURLEnvVar: "https://example.com", AlertErrorRateThresholdEnvVar: "2"},
+		{AlertWebhookURLEnvVar: "https://example.com", AlertWindowEnvVar: "0s"},
+		{AlertWebhookURLEnvVar: "https://example.com", AlertMinCallsEnvVar: "none"},
+	}
+	for _, env := range invalid {
+		withEnv(env, func() {
+			if _, _, err := getAlertConfig(); err == nil {
+				t.Errorf("expected an error for %v", env)
+			}
+		})
+	}
+}
+
+func TestGetOTLPConfig(t *testing.T) {
+	conf, err := getOTLPConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conf != nil {
+		t.Errorf("expected no OTLP config by default, got %v", conf)
+	}
+
+	withEnv(map[string]string{
+		OTLPEndpointEnvVar: "https://otel.example.com",
+		OTLPProtocolEnvVar: "grpc",
+		OTLPHeadersEnvVar:  "api-key=secret",
+		OTLPInsecureEnvVar: "true",
+	}, func() {
+		conf, err := getOTLPConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if conf.Endpoint != "https://otel.example.com" || conf.Protocol != telemetry.OTLPProtocolGRPC ||
+			conf.Headers["api-key"] != "secret" || !conf.Insecure {
+			t.Errorf("unexpected OTLP config: %+v", conf)
+		}
+
+		// flags take precedence over the env vars
+		startServerCmdOTLPEndpoint = "http://localhost:4318"
+		startServerCmdOTLPProtocol = "http"
+		defer func() {
+			startServerCmdOTLPEndpoint = ""
+			startServerCmdOTLPProtocol = ""
+		}()
+		conf, err = getOTLPConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if conf.Endpoint != "http://localhost:4318" || conf.Protocol != telemetry.OTLPProtocolHTTP {
+			t.Errorf("expected flags to override env vars, got %+v", conf)
+		}
+	})
+
+	invalid := []map[string]string{
+		{OTLPEndpointEnvVar: "otel-collector:4318"},
+		{OTLPEndpointEnvVar: "http://localhost:4318", OTLPProtocolEnvVar: "udp"},
+		{OTLPEndpointEnvVar: "http://localhost:4318", OTLPHeadersEnvVar: "api-key"},
+		{OTLPEndpointEnvVar: "http://localhost:4318", OTLPInsecureEnvVar: "maybe"},
+		{OTLPEndpointEnvVar: "https://localhost:4318", OTLPClientCertEnvVar: "/etc/client.pem"},
+	}
+	for _, env := range invalid {
+		withEnv(env, func() {
+			if _, err := getOTLPConfig(); err == nil {
+				t.Errorf("expected an error for %v", env)
+			}
+		})
+	}
+}
+
+func TestGetTracesSampler(t *testing.T) {
+	sampler, ratio, err := getTracesSampler()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sampler != "" || ratio != 1 {
+		t.Errorf("expected the default sampler sampling all traces, got %s with ratio %v", sampler, ratio)
+	}
+
+	withEnv(map[string]string{TracesSamplerEnvVar: "ParentBased_TraceIDRatio", TracesSamplerArgEnvVar: "0.1"}, func() {
+		sampler, ratio, err := getTracesSampler()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sampler != telemetry.TracesSamplerParentBasedRatio || ratio != 0.1 {
+			t.Errorf("unexpected sampler %s with ratio %v", sampler, ratio)
+		}
+	})
+
+	for _, v := range []string{"10%", "-0.5", "2"} {
+		withEnv(map[string]string{TracesSamplerArgEnvVar: v}, func() {
+			if _, _, err := getTracesSampler(); err == nil {
+				t.Errorf("expected an error for %s", v)
+			}
+		})
+	}
+}
+
+func TestIsPprofEnabled(t *testing.T) {
+	enabled, err := isPprofEnabled()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if enabled {
+		t.Error("expected pprof to be disabled by default")
+	}
+
+	withEnv(map[string]string{PprofEnabledEnvVar: "true"}, func() {
+		enabled, err := isPprofEnabled()
+		if err != nil || !enabled {
+			t.Errorf("expected pprof to be enabled by the env var, got %v (err: %v)", enabled, err)
+		}
+	})
+
+	startServerCmdPprofEnabled = true
+	defer func() { startServerCmdPprofEnabled = false }()
+	withEnv(map[string]string{PprofEnabledEnvVar: "false"}, func() {
+		enabled, err := isPprofEnabled()
+		if err != nil || !enabled {
+			t.Errorf("expected the flag to take precedence over the env var, got %v (err: %v)", enabled, err)
+		}
+	})
+}
+
+func TestIsDashboardEnabled(t *testing.T) {
+	enabled, err := isDashboardEnabled()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !enabled {
+		t.Error("expected the dashboard to be enabled by default")
+	}
+
+	withEnv(map[string]string{DashboardEnabledEnvVar: "false"}, func() {
+		enabled, err := isDashboardEnabled()
+		if err != nil || enabled {
+			t.Errorf("expected the dashboard to be disabled by the env var, got %v (err: %v)", enabled, err)
+		}
+	})
+
+	startServerCmdNoDashboard = true
+	defer func() { startServerCmdNoDashboard = false }()
+	withEnv(map[string]string{DashboardEnabledEnvVar: "true"}, func() {
+		enabled, err := isDashboardEnabled()
+		if err != nil || enabled {
+			t.Errorf("expected the flag to take precedence over the env var, got %v (err: %v)", enabled, err)
+		}
+	})
+}
+
+func TestGetDBPath(t *testing.T) {
+	if p := getDBPath(); p != "" {
+		t.Errorf("expected no database path by default, got %s", p)
+	}
+
+	withEnv(map[string]string{DBPathEnvVar: "/var/lib/mcpjungle/db.sqlite"}, func() {
+		if p := getDBPath(); p != "/var/lib/mcpjungle/db.sqlite" {
+			t.Errorf("expected the path from the env var, got %s", p)
+		}
+
+		startServerCmdDBPath = "/tmp/mcpjungle.db"
+		defer func() { startServerCmdDBPath = "" }()
+		if p := getDBPath(); p != "/tmp/mcpjungle.db" {
+			t.Errorf("expected the flag to take precedence over the env var, got %s", p)
+		}
+	})
+}
+
+func TestGetDBPoolConfig(t *testing.T) {
+	defaults := db.DefaultPoolConfig("postgres")
+	conf, err := getDBPoolConfig(defaults)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conf != defaults {
+		t.Errorf("expected the defaults, got %+v", conf)
+	}
+
+	withEnv(map[string]string{
+		DBMaxOpenConnsEnvVar:    "50",
+		DBMaxIdleConnsEnvVar:    "0",
+		DBConnMaxLifetimeEnvVar: "1h",
+		DBConnMaxIdleTimeEnvVar: "30s",
+	}, func() {
+		conf, err := getDBPoolConfig(defaults)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := db.PoolConfig{
+			MaxOpenConns: 50, MaxIdleConns: 0, ConnMaxLifetime: time.Hour, ConnMaxIdleTime: 30 * time.Second,
+		}
+		if conf != expected {
+			t.Errorf("expected %+v, got %+v", expected, conf)
+		}
+	})
+
+	invalid := map[string]string{
+		DBMaxOpenConnsEnvVar:    "-1",
+		DBMaxIdleConnsEnvVar:    "many",
+		DBConnMaxLifetimeEnvVar: "30",
+		DBConnMaxIdleTimeEnvVar: "-5m",
+	}
+	for envVar, value := range invalid {
+		withEnv(map[string]string{envVar: value}, func() {
+			if _, err := getDBPoolConfig(defaults); err == nil {
+				t.Errorf("expected an error for %s=%s", envVar, value)
+			}
+		})
+	}
+}
+
+func TestGetSessionPoolConfig(t *testing.T) {
+	conf, err := getSessionPoolConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conf != mcp.DefaultSessionPoolConfig() {
+		t.Errorf("expected the defaults, got %+v", conf)
+	}
+
+	withEnv(map[string]string{
+		UpstreamSessionPoolSizeEnvVar:            "0",
+		UpstreamSessionIdleTimeoutEnvVar:         "1m",
+		UpstreamSessionHealthCheckIntervalEnvVar: "0",
+	}, func() {
+		conf, err := getSessionPoolConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := mcp.SessionPoolConfig{MaxSessionsPerServer: 0, IdleTimeout: time.Minute, HealthCheckInterval: 0}
+		if conf != expected {
+			t.Errorf("expected %+v, got %+v", expected, conf)
+		}
+	})
+
+	invalid := map[string]string{
+		UpstreamSessionPoolSizeEnvVar:            "-1",
+		UpstreamSessionIdleTimeoutEnvVar:         "0",
+		UpstreamSessionHealthCheckIntervalEnvVar: "often",
+	}
+	for envVar, value := range invalid {
+		withEnv(map[string]string{envVar: value}, func() {
+			if _, err := getSessionPoolConfig(); err == nil {
+				t.Errorf("expected an error for %s=%s", envVar, value)
+			}
+		})
+	}
+}
+
+func TestGetToolLoading(t *testing.T) {
+	lazy, warmUp, err := getToolLoading()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lazy || warmUp {
+		t.Errorf("expected tools to be loaded at startup by default, got lazy=%v warmUp=%v", lazy, warmUp)
+	}
+
+	withEnv(map[string]string{LazyToolLoadingEnvVar: "true", ToolWarmUpEnvVar: "true"}, func() {
+		lazy, warmUp, err := getToolLoading()
+		if err != nil || !lazy || !warmUp {
+			t.Errorf("expected lazy loading with warm-up, got lazy=%v warmUp=%v (err: %v)", lazy, warmUp, err)
+		}
+	})
+
+	// there is nothing to warm up when tools are loaded at startup
+	withEnv(map[string]string{ToolWarmUpEnvVar: "true"}, func() {
+		_, warmUp, err := getToolLoading()
+		if err != nil || warmUp {
+			t.Errorf("expected no warm-up without lazy loading, got %v (err: %v)", warmUp, err)
+		}
+	})
+
+	withEnv(map[string]string{LazyToolLoadingEnvVar: "sometimes"}, func() {
+		if _, _, err := getToolLoading(); err == nil {
+			t.Error("expected an error for an invalid value")
+		}
+	})
+}
+
+func TestGetStdioLimits(t *testing.T) {
+	limits, err := getStdioLimits()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if limits != mcp.DefaultStdioLimits() {
+		t.Errorf("expected the defaults, got %+v", limits)
+	}
+
+	withEnv(map[string]string{StdioMaxProcessesEnvVar: "2", StdioQueueTimeoutEnvVar: "0"}, func() {
+		limits, err := getStdioLimits()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := mcp.StdioLimits{MaxProcessesPerServer: 2, QueueTimeout: 0}
+		if limits != expected {
+			t.Errorf("expected %+v, got %+v", expected, limits)
+		}
+	})
+
+	invalid := map[string]string{
+		StdioMaxProcessesEnvVar: "many",
+		StdioQueueTimeoutEnvVar: "-1s",
+	}
+	for envVar, value := range invalid {
+		withEnv(map[string]string{envVar: value}, func() {
+			if _, err := getStdioLimits(); err == nil {
+				t.Errorf("expected an error for %s=%s", envVar, value)
+			}
+		})
+	}
+}
+
+func TestGetEncryptionKeyring(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, encryption.KeySize))
+	previousKey := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{2}, encryption.KeySize))
+
+	withEnv(map[string]string{EncryptionKeyEnvVar: "", EncryptionPreviousKeysEnvVar: ""}, func() {
+		keyring, err := getEncryptionKeyring()
+		if err != nil || keyring != nil {
+			t.Errorf("expected no keyring by default, got %v (err: %v)", keyring, err)
+		}
+	})
+
+	withEnv(map[string]string{EncryptionKeyEnvVar: key, EncryptionPreviousKeysEnvVar: previousKey + ", "}, func() {
+		keyring, err := getEncryptionKeyring()
+		if err != nil || keyring == nil {
+			t.Fatalf("expected a keyring, got error: %v", err)
+		}
+	})
+
+	invalid := []map[string]string{
+		{EncryptionKeyEnvVar: "not-a-key", EncryptionPreviousKeysEnvVar: ""},
+		{EncryptionKeyEnvVar: key, EncryptionPreviousKeysEnvVar: "not-a-key"},
+		{EncryptionKeyEnvVar: "", EncryptionPreviousKeysEnvVar: previousKey},
+	}
+	for _, env := range invalid {
+		withEnv(env, func() {
+			if _, err := getEncryptionKeyring(); err == nil {
+				t.Errorf("expected an error for %v", env)
+			}
+		})
+	}
+}
+
+func TestGetDBReadDSNs(t *testing.T) {
+	withEnv(map[string]string{DBReadUrlsEnvVar: ""}, func() {
+		if dsns := getDBReadDSNs(); len(dsns) != 0 {
+			t.Errorf("expected no read replicas by default, got %v", dsns)
+		}
+	})
+	withEnv(map[string]string{DBReadUrlsEnvVar: "postgres://replica-1/db, postgres://replica-2/db,"}, func() {
+		dsns := getDBReadDSNs()
+		if len(dsns) != 2 || dsns[0] != "postgres://replica-1/db" || dsns[1] != "postgres://replica-2/db" {
+			t.Errorf("expected 2 read replicas, got %v", dsns)
+		}
+	})
+}
+
+func TestGetRetentionConfig(t *testing.T) {
+	conf, err := getRetentionConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conf != retention.DefaultConfig() {
+		t.Errorf("expected the default retention config, got %+v", conf)
+	}
+
+	withEnv(map[string]string{
+		RetentionInvocationHistoryEnvVar: "720h",
+		RetentionJobsEnvVar:              "0",
+		RetentionSoftDeletedEnvVar:       "48h",
+		RetentionIdempotencyKeysEnvVar:   "1h",
+	}, func() {
+		conf, err := getRetentionConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := retention.Config{InvocationHistory: 720 * time.Hour, SoftDeleted: 48 * time.Hour, IdempotencyKeys: time.Hour}
+		if conf != want {
+			t.Errorf("expected %+v, got %+v", want, conf)
+		}
+	})
+
+	for _, v := range []string{"-1h", "a week"} {
+		withEnv(map[string]string{RetentionJobsEnvVar: v}, func() {
+			if _, err := getRetentionConfig(); err == nil {
+				t.Errorf("expected an error for %s=%s", RetentionJobsEnvVar, v)
+			}
+		})
+	}
+}
+
+func TestGetRetentionPruneInterval(t *testing.T) {
+	interval, err := getRetentionPruneInterval()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if interval != retention.DefaultInterval {
+		t.Errorf("expected the default interval %s, got %s", retention.DefaultInterval, interval)
+	}
+
+	withEnv(map[string]string{RetentionPruneIntervalEnvVar: "0"}, func() {
+		interval, err := getRetentionPruneInterval()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if interval != 0 {
+			t.Errorf("expected background pruning to be disabled, got %s", interval)
+		}
+	})
+
+	withEnv(map[string]string{RetentionPruneIntervalEnvVar: "-1m"}, func() {
+		if _, err := getRetentionPruneInterval(); err == nil {
+			t.Errorf("expected an error for %s=-1m", RetentionPruneIntervalEnvVar)
+		}
+	})
+}
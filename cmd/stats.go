@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsCmdSince string
+	statsCmdUntil string
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show statistics about the registry (admin only)",
+	Long: "Show the number of servers, tools, prompts, tool groups, clients & users in the registry,\n" +
+		"along with the number of tool & prompt calls made through mcpjungle recently and how many of them failed.\n" +
+		"Call counts are kept in memory by the server, so they only cover calls made since it last started.\n\n" +
+		"With --since and/or --until, the number of calls, error rate & 95th percentile latency of each MCP server\n" +
+		"and tool within that time window are shown as well. They are computed from the invocation history,\n" +
+		"which only keeps a limited number of recent calls (see 'list invocations').",
+	Example: "  mcpjungle stats\n" +
+		"  mcpjungle stats --since 24h\n" +
+		"  mcpjungle stats --since 2025-10-01T00:00:00Z --until 2025-10-02T00:00:00Z",
+	Args: cobra.NoArgs,
+	RunE: runStats,
+	Annotations: map[string]string{
+		"group": string(subCommandGroupAdvanced),
+		"order": "10",
+	},
+}
+
+func init() {
+	statsCmd.Flags().StringVar(
+		&statsCmdSince,
+		"since",
+		"",
+		"Show the usage of each server & tool since this time (eg- 24h or 2025-10-01T12:00:00Z)",
+	)
+	statsCmd.Flags().StringVar(
+		&statsCmdUntil,
+		"until",
+		"",
+		"Show the usage of each server & tool until this time (eg- 1h or 2025-10-01T13:00:00Z)",
+	)
+
+	rootCmd.AddCommand(statsCmd)
+}
+
+// usageStats holds the calls made to a server or a tool within a time window
+type usageStats struct {
+	name      string
+	calls     int
+	errors    int
+	durations []int64
+}
+
+func (u *usageStats) errorRate() float64 {
+	return float64(u.errors) / float64(u.calls)
+}
+
+// p95 returns the 95th percentile of the call durations, using the nearest-rank method.
+func (u *usageStats) p95() time.Duration {
+	if len(u.durations) == 0 {
+		return 0
+	}
+	d := slices.Clone(u.durations)
+	slices.Sort(d)
+	rank := (len(d)*95 + 99) / 100
+	return time.Duration(d[max(rank, 1)-1]) * time.Millisecond
+}
+
+// summarizeUsage groups the invocations by server and by tool, sorted by decreasing number of calls.
+func summarizeUsage(invocations []*types.ToolInvocation) (servers, tools []*usageStats) {
+	byServer := make(map[string]*usageStats)
+	byTool := make(map[string]*usageStats)
+	add := func(m map[string]*usageStats, list *[]*usageStats, name string, inv *types.ToolInvocation) {
+		u, ok := m[name]
+		if !ok {
+			u = &usageStats{name: name}
+			m[name] = u
+			*list = append(*list, u)
+		}
+		u.calls++
+		if inv.Outcome == types.InvocationOutcomeError {
+			u.errors++
+		}
+		u.durations = append(u.durations, inv.DurationMs)
+	}
+	for _, inv := range invocations {
+		server, _, _ := strings.Cut(inv.Tool, "__")
+		add(byServer, &servers, server, inv)
+		add(byTool, &tools, inv.Tool, inv)
+	}
+
+	byCalls := func(a, b *usageStats) int {
+		if a.calls != b.calls {
+			return b.calls - a.calls
+		}
+		return strings.Compare(a.name, b.name)
+	}
+	slices.SortFunc(servers, byCalls)
+	slices.SortFunc(tools, byCalls)
+	return servers, tools
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	stats, err := apiClient.GetStats()
+	if err != nil {
+		return fmt.Errorf("failed to get statistics: %w", err)
+	}
+
+	cmd.Println("Registry:")
+	cmd.Printf("  MCP servers:  %d\n", stats.Servers)
+	cmd.Printf("  Tools:        %s\n", formatEntityCounts(stats.Tools))
+	cmd.Printf("  Prompts:      %s\n", formatEntityCounts(stats.Prompts))
+	cmd.Printf("  Tool groups:  %d\n", stats.ToolGroups)
+	cmd.Printf("  MCP clients:  %d\n", stats.McpClients)
+	cmd.Printf("  Users:        %d\n", stats.Users)
+	cmd.Println()
+
+	inv := stats.Invocations
+	window := time.Duration(inv.WindowSeconds) * time.Second
+	cmd.Printf("Invocations (last %s):\n", window)
+	cmd.Printf("  Tool calls:   %d (%d failed, %.1f%% error rate)\n",
+		inv.ToolCalls, inv.ToolCallErrors, inv.ToolCallErrorRate*100)
+	cmd.Printf("  Prompt calls: %d (%d failed, %.1f%% error rate)\n",
+		inv.PromptCalls, inv.PromptCallErrors, inv.PromptCallErrorRate*100)
+
+	if statsCmdSince == "" && statsCmdUntil == "" {
+		return nil
+	}
+	return printUsage(cmd)
+}
+
+// printUsage prints the usage of each server & tool within the time window given by --since & --until.
+func printUsage(cmd *cobra.Command) error {
+	opts := &types.ListInvocationsOptions{}
+	var err error
+	if opts.Since, err = parseTimeFlag(statsCmdSince); err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	if opts.Until, err = parseTimeFlag(statsCmdUntil); err != nil {
+		return fmt.Errorf("invalid --until: %w", err)
+	}
+
+	invocations, err := apiClient.ListInvocations(opts)
+	if err != nil {
+		return fmt.Errorf("failed to list tool invocations: %w", err)
+	}
+
+	window := "all recorded calls"
+	switch {
+	case !opts.Since.IsZero() && !opts.Until.IsZero():
+		window = opts.Since.Local().Format(time.RFC3339) + " to " + opts.Until.Local().Format(time.RFC3339)
+	case !opts.Since.IsZero():
+		window = "since " + opts.Since.Local().Format(time.RFC3339)
+	case !opts.Until.IsZero():
+		window = "until " + opts.Until.Local().Format(time.RFC3339)
+	}
+	cmd.Println()
+	cmd.Printf("Usage (%s):\n", window)
+	if len(invocations) == 0 {
+		cmd.Println("  No tool invocations found")
+		return nil
+	}
+
+	servers, tools := summarizeUsage(invocations)
+	cmd.Println()
+	printUsageTable(cmd, "SERVER", servers)
+	cmd.Println()
+	printUsageTable(cmd, "TOOL", tools)
+	return nil
+}
+
+func printUsageTable(cmd *cobra.Command, kind string, usage []*usageStats) {
+	tbl := newTable(kind, "CALLS", "ERRORS", "ERROR RATE", "P95")
+	// colors are only meaningful in a terminal
+	tbl.color = tbl.color && isTerminal(cmd.OutOrStdout())
+	for _, u := range usage {
+		rate := tableCell{text: fmt.Sprintf("%.1f%%", u.errorRate()*100)}
+		if u.errors > 0 {
+			rate.color = colorRed
+		}
+		tbl.addCells(
+			tableCell{text: u.name},
+			tableCell{text: fmt.Sprintf("%d", u.calls)},
+			tableCell{text: fmt.Sprintf("%d", u.errors)},
+			rate,
+			tableCell{text: u.p95().String()},
+		)
+	}
+	tbl.print(cmd.OutOrStdout())
+}
+
+func formatEntityCounts(c types.EntityCounts) string {
+	return fmt.Sprintf("%d (%d enabled, %d disabled)", c.Total, c.Enabled, c.Disabled)
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/client"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestStatsCommandStructure(t *testing.T) {
+	testhelpers.AssertEqual(t, "stats", statsCmd.Use)
+	testhelpers.AssertNotNil(t, statsCmd.RunE)
+	testhelpers.TestCommandAnnotations(t, statsCmd.Annotations, []testhelpers.CommandAnnotationTest{
+		{Key: "group", Expected: string(subCommandGroupAdvanced)},
+		{Key: "order", Expected: "10"},
+	})
+}
+
+func TestRunStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(types.RegistryStats{
+			Servers:    2,
+			Tools:      types.EntityCounts{Total: 5, Enabled: 4, Disabled: 1},
+			ToolGroups: 1,
+			Invocations: types.InvocationStats{
+				WindowSeconds: 3600, ToolCalls: 8, ToolCallErrors: 2, ToolCallErrorRate: 0.25,
+			},
+		})
+	}))
+	defer server.Close()
+
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+	apiClient = client.NewClient(server.URL)
+
+	var out bytes.Buffer
+	statsCmd.SetOut(&out)
+	defer statsCmd.SetOut(nil)
+
+	testhelpers.AssertNoError(t, runStats(statsCmd, nil))
+	testhelpers.AssertStringContains(t, out.String(), "MCP servers:  2")
+	testhelpers.AssertStringContains(t, out.String(), "Tools:        5 (4 enabled, 1 disabled)")
+	testhelpers.AssertStringContains(t, out.String(), "Invocations (last 1h0m0s):")
+	testhelpers.AssertStringContains(t, out.String(), "Tool calls:   8 (2 failed, 25.0% error rate)")
+}
+
+func TestSummarizeUsage(t *testing.T) {
+	var invocations []*types.ToolInvocation
+	// 20 calls of github__search taking 1 to 20ms, the slowest of which failed
+	for i := 1; i <= 20; i++ {
+		outcome := types.InvocationOutcomeSuccess
+		if i == 20 {
+			outcome = types.InvocationOutcomeError
+		}
+		invocations = append(invocations, &types.ToolInvocation{Tool: "github__search", Outcome: outcome, DurationMs: int64(i)})
+	}
+	invocations = append(invocations,
+		&types.ToolInvocation{Tool: "github__get_issue", Outcome: types.InvocationOutcomeSuccess, DurationMs: 300},
+		&types.ToolInvocation{Tool: "time__get_current_time", Outcome: types.InvocationOutcomeError, DurationMs: 5},
+	)
+
+	servers, tools := summarizeUsage(invocations)
+
+	testhelpers.AssertEqual(t, 2, len(servers))
+	testhelpers.AssertEqual(t, "github", servers[0].name)
+	testhelpers.AssertEqual(t, 21, servers[0].calls)
+	testhelpers.AssertEqual(t, 1, servers[0].errors)
+	testhelpers.AssertEqual(t, "time", servers[1].name)
+	testhelpers.AssertEqual(t, 1.0, servers[1].errorRate())
+
+	testhelpers.AssertEqual(t, 3, len(tools))
+	testhelpers.AssertEqual(t, "github__search", tools[0].name)
+	testhelpers.AssertEqual(t, 0.05, tools[0].errorRate())
+	testhelpers.AssertEqual(t, 19*time.Millisecond, tools[0].p95())
+	testhelpers.AssertEqual(t, 300*time.Millisecond, tools[1].p95())
+}
+
+func TestRunStatsUsage(t *testing.T) {
+	var gotSince string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/invocations" {
+			gotSince = r.URL.Query().Get("since")
+			_ = json.NewEncoder(w).Encode([]*types.ToolInvocation{
+				{Tool: "time__get_current_time", Outcome: types.InvocationOutcomeSuccess, DurationMs: 12},
+				{Tool: "time__get_current_time", Outcome: types.InvocationOutcomeError, DurationMs: 40},
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(types.RegistryStats{Servers: 1})
+	}))
+	defer server.Close()
+
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+	apiClient = client.NewClient(server.URL)
+
+	var out bytes.Buffer
+	statsCmd.SetOut(&out)
+	defer statsCmd.SetOut(nil)
+	statsCmdSince = "24h"
+	defer func() { statsCmdSince = "" }()
+
+	testhelpers.AssertNoError(t, runStats(statsCmd, nil))
+	testhelpers.AssertTrue(t, gotSince != "", "expected the time window to be sent to the invocations API")
+	testhelpers.AssertStringContains(t, out.String(), "Usage (since ")
+	testhelpers.AssertStringContains(t, out.String(), "SERVER  CALLS  ERRORS  ERROR RATE  P95")
+	testhelpers.AssertStringContains(t, out.String(), "time    2      1       50.0%       40ms")
+	testhelpers.AssertStringContains(t, out.String(), "time__get_current_time  2      1       50.0%       40ms")
+
+	statsCmdSince = "yesterday"
+	err := runStats(statsCmd, nil)
+	testhelpers.AssertError(t, err)
+	testhelpers.AssertStringContains(t, err.Error(), "invalid --since")
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+// ANSI escape codes used to color the cells of a table
+const (
+	colorReset = "\033[0m"
+	colorRed   = "\033[31m"
+	colorGreen = "\033[32m"
+	colorBold  = "\033[1m"
+)
+
+// NoColorEnvVar disables the colors in the CLI's output when set to any value, see https://no-color.org
+const NoColorEnvVar = "NO_COLOR"
+
+// tableDescriptionWidth is the maximum width of a description column, longer descriptions are truncated
+const tableDescriptionWidth = 60
+
+var listCmdNoColor bool
+
+// isTerminal reports whether w is an interactive terminal.
+// It is a variable so that tests can emulate a terminal.
+var isTerminal = func(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && (isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd()))
+}
+
+// useTable returns true if the list commands must print an aligned table rather than the plain text,
+// ie, if the human-oriented output is requested and written to a terminal.
+// The plain text is easier to read when piped to other programs or saved to a file.
+func useTable(cmd *cobra.Command) bool {
+	format, err := getOutputFormat()
+	return err == nil && format == outputFormatTable && isTerminal(cmd.OutOrStdout())
+}
+
+// tableCell is a cell of a table, optionally colored
+type tableCell struct {
+	text  string
+	color string
+}
+
+// table prints rows of cells as aligned columns
+type table struct {
+	header []string
+	rows   [][]tableCell
+	color  bool
+}
+
+// newTable creates a table with the given column names.
+// Colors are only used if they aren't disabled by the --no-color flag or the NO_COLOR environment variable.
+func newTable(header ...string) *table {
+	return &table{
+		header: header,
+		color:  !listCmdNoColor && os.Getenv(NoColorEnvVar) == "",
+	}
+}
+
+// addRow adds a row of uncolored cells.
+func (t *table) addRow(cells ...string) {
+	row := make([]tableCell, len(cells))
+	for i, c := range cells {
+		row[i] = tableCell{text: c}
+	}
+	t.rows = append(t.rows, row)
+}
+
+// addCells adds a row of cells, some of which may be colored.
+func (t *table) addCells(cells ...tableCell) {
+	t.rows = append(t.rows, cells)
+}
+
+// highlightRow colors all cells of the last row added to the table.
+func (t *table) highlightRow(color string) {
+	if len(t.rows) == 0 {
+		return
+	}
+	for i := range t.rows[len(t.rows)-1] {
+		t.rows[len(t.rows)-1][i].color = color
+	}
+}
+
+// print writes the table to w, with the columns separated by 2 spaces.
+func (t *table) print(w io.Writer) {
+	widths := make([]int, len(t.header))
+	for i, h := range t.header {
+		widths[i] = utf8.RuneCountInString(h)
+	}
+	for _, row := range t.rows {
+		for i, c := range row {
+			if i < len(widths) {
+				widths[i] = max(widths[i], utf8.RuneCountInString(c.text))
+			}
+		}
+	}
+
+	header := make([]tableCell, len(t.header))
+	for i, h := range t.header {
+		header[i] = tableCell{text: h, color: colorBold}
+	}
+	t.printRow(w, header, widths)
+	for _, row := range t.rows {
+		t.printRow(w, row, widths)
+	}
+}
+
+func (t *table) printRow(w io.Writer, row []tableCell, widths []int) {
+	var b strings.Builder
+	for i, c := range row {
+		if t.color && c.color != "" {
+			b.WriteString(c.color + c.text + colorReset)
+		} else {
+			b.WriteString(c.text)
+		}
+		// the last column isn't padded to avoid trailing spaces
+		if i < len(row)-1 && i < len(widths) {
+			b.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(c.text)+2))
+		}
+	}
+	fmt.Fprintln(w, b.String())
+}
+
+// statusCell returns the ENABLED/DISABLED cell of a tool or prompt, in green or red.
+func statusCell(enabled bool) tableCell {
+	if enabled {
+		return tableCell{text: "ENABLED", color: colorGreen}
+	}
+	return tableCell{text: "DISABLED", color: colorRed}
+}
+
+// truncateDescription returns the first line of a description, truncated to fit in a table column.
+func truncateDescription(s string) string {
+	s, _, multiline := strings.Cut(strings.TrimSpace(s), "\n")
+	s = strings.TrimSpace(s)
+	if utf8.RuneCountInString(s) > tableDescriptionWidth {
+		return string([]rune(s)[:tableDescriptionWidth-3]) + "..."
+	}
+	if multiline {
+		return s + " ..."
+	}
+	return s
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/client"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// emulateTerminal makes the list commands behave as if their output was a terminal for the duration of a test
+func emulateTerminal(t *testing.T) {
+	original := isTerminal
+	isTerminal = func(w io.Writer) bool { return true }
+	t.Cleanup(func() { isTerminal = original })
+}
+
+func TestTablePrint(t *testing.T) {
+	tbl := newTable("NAME", "STATUS", "DESCRIPTION")
+	tbl.color = false
+	tbl.addCells(tableCell{text: "time__get_current_time"}, statusCell(true), tableCell{text: "Get the time"})
+	tbl.addCells(tableCell{text: "time__convert"}, statusCell(false), tableCell{text: ""})
+
+	var out bytes.Buffer
+	tbl.print(&out)
+	expected := "NAME                    STATUS    DESCRIPTION\n" +
+		"time__get_current_time  ENABLED   Get the time\n" +
+		"time__convert           DISABLED  \n"
+	testhelpers.AssertEqual(t, expected, out.String())
+
+	out.Reset()
+	tbl.color = true
+	tbl.print(&out)
+	// the colors don't affect the alignment
+	stripped := strings.NewReplacer(colorBold, "", colorGreen, "", colorRed, "", colorReset, "").Replace(out.String())
+	testhelpers.AssertEqual(t, expected, stripped)
+	testhelpers.AssertStringContains(t, out.String(), colorGreen+"ENABLED"+colorReset+"   Get the time")
+	testhelpers.AssertStringContains(t, out.String(), colorRed+"DISABLED"+colorReset)
+}
+
+func TestTableNoColor(t *testing.T) {
+	t.Setenv(NoColorEnvVar, "1")
+	testhelpers.AssertFalse(t, newTable("NAME").color, "NO_COLOR must disable the colors")
+}
+
+func TestTruncateDescription(t *testing.T) {
+	t.Parallel()
+
+	testhelpers.AssertEqual(t, "Get the time", truncateDescription("  Get the time\n"))
+	testhelpers.AssertEqual(t, "Get the time ...", truncateDescription("Get the time\nin any timezone"))
+
+	long := truncateDescription(strings.Repeat("a", 100))
+	testhelpers.AssertEqual(t, tableDescriptionWidth, len(long))
+	testhelpers.AssertTrue(t, strings.HasSuffix(long, "..."), "long descriptions must be truncated")
+}
+
+func TestUseTable(t *testing.T) {
+	var out bytes.Buffer
+	listToolsCmd.SetOut(&out)
+	defer listToolsCmd.SetOut(nil)
+
+	// a buffer isn't a terminal
+	testhelpers.AssertFalse(t, useTable(listToolsCmd), "plain text must be printed when not writing to a terminal")
+
+	emulateTerminal(t)
+	testhelpers.AssertTrue(t, useTable(listToolsCmd), "a table must be printed to a terminal")
+
+	outputCmdFormat = string(outputFormatJSON)
+	defer func() { outputCmdFormat = string(outputFormatTable) }()
+	testhelpers.AssertFalse(t, useTable(listToolsCmd), "no table must be printed with --output json")
+}
+
+func TestListServersHealthTable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/servers":
+			_ = json.NewEncoder(w).Encode([]*types.McpServer{
+				{Name: "time", Transport: "stdio", Command: "uvx", Args: []string{"mcp-server-time"}},
+				{Name: "context7", Transport: "streamable_http", URL: "https://mcp.context7.com/mcp"},
+			})
+		case "/health/details":
+			_ = json.NewEncoder(w).Encode(types.HealthDetails{Servers: []types.UpstreamServerHealth{
+				{Name: "time", Healthy: true},
+				{Name: "context7", Error: "connection refused"},
+			}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	originalClient := apiClient
+	apiClient = client.NewClient(server.URL)
+	defer func() { apiClient = originalClient }()
+
+	emulateTerminal(t)
+	listServersCmdHealth = true
+	listCmdNoColor = true
+	defer func() {
+		listServersCmdHealth = false
+		listCmdNoColor = false
+	}()
+
+	var out bytes.Buffer
+	listServersCmd.SetOut(&out)
+	listServersCmd.SetContext(context.Background())
+	defer listServersCmd.SetOut(nil)
+
+	testhelpers.AssertNoError(t, runListServers(listServersCmd, nil))
+	expected := "NAME      TRANSPORT        ENDPOINT                      HEALTH\n" +
+		"time      stdio            uvx mcp-server-time           healthy\n" +
+		"context7  streamable_http  https://mcp.context7.com/mcp  unhealthy: connection refused\n"
+	testhelpers.AssertEqual(t, expected, out.String())
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	testServerCmdConfigFilePath string
+	testServerCmdTool           string
+	testServerCmdInput          string
+)
+
+var testCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Test the connectivity to entities like MCP servers",
+	Annotations: map[string]string{
+		"group": string(subCommandGroupAdvanced),
+		"order": "22",
+	},
+}
+
+var testServerCmd = &cobra.Command{
+	Use:   "server [name]",
+	Short: "Test the connectivity to an MCP server (admin only)",
+	Long: "Check that mcpjungle can work with an MCP server: it connects to the server (initialize),\n" +
+		"lists its tools and, if --tool is given, calls one of them. A pass/fail summary with the time taken\n" +
+		"by each step is printed, and the command exits with status 1 if a step fails.\n\n" +
+		"Test a registered server by its name, or a server that isn't registered yet by supplying\n" +
+		"the same configuration file as 'register'. Nothing is registered by this command.\n\n" +
+		"Only tools annotated as read-only can be called, so that a test never modifies any data.",
+	Example: "  mcpjungle test server time\n" +
+		"  mcpjungle test server -c ./github.json\n" +
+		"  mcpjungle test server time --tool get_current_time --input '{\"timezone\": \"UTC\"}'",
+	Args: cobra.MaximumNArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if (len(args) == 1) == (testServerCmdConfigFilePath != "") {
+			return fmt.Errorf("either supply the name of a registered server or a configuration file")
+		}
+		return nil
+	},
+	RunE: runTestServer,
+}
+
+func init() {
+	testServerCmd.Flags().StringVarP(
+		&testServerCmdConfigFilePath,
+		"conf",
+		"c",
+		"",
+		"Path to the JSON configuration file of an MCP server that isn't registered yet",
+	)
+	testServerCmd.Flags().StringVar(
+		&testServerCmdTool,
+		"tool",
+		"",
+		"Name of a read-only tool to call, without the server name prefix",
+	)
+	testServerCmd.Flags().StringVar(
+		&testServerCmdInput,
+		"input",
+		"{}",
+		"Valid JSON payload for the tool call",
+	)
+
+	testCmd.AddCommand(testServerCmd)
+	addOutputFlag(testCmd)
+	rootCmd.AddCommand(testCmd)
+}
+
+func runTestServer(cmd *cobra.Command, args []string) error {
+	input := &types.TestServerInput{Tool: testServerCmdTool}
+	if testServerCmdTool != "" {
+		if err := json.Unmarshal([]byte(testServerCmdInput), &input.Arguments); err != nil {
+			return fmt.Errorf("invalid input: %w", err)
+		}
+	}
+	if len(args) == 1 {
+		input.Name = args[0]
+	} else {
+		conf, err := readMcpServerConfig(testServerCmdConfigFilePath)
+		if err != nil {
+			return err
+		}
+		input.Server = &conf
+	}
+
+	report, err := apiClient.TestServer(input)
+	if err != nil {
+		return fmt.Errorf("failed to test server: %w", err)
+	}
+
+	if ok, err := printStructured(cmd, report); ok || err != nil {
+		if err == nil && !report.Passed {
+			return ErrSilent
+		}
+		return err
+	}
+
+	tbl := newTable("STEP", "RESULT", "DURATION", "DETAILS")
+	// colors are only meaningful in a terminal
+	tbl.color = tbl.color && isTerminal(cmd.OutOrStdout())
+	for _, st := range report.Steps {
+		result := tableCell{text: "PASS", color: colorGreen}
+		details := st.Detail
+		if !st.Passed {
+			result = tableCell{text: "FAIL", color: colorRed}
+			details = st.Error
+		}
+		tbl.addCells(
+			tableCell{text: st.Name},
+			result,
+			tableCell{text: (time.Duration(st.DurationMs) * time.Millisecond).String()},
+			tableCell{text: details},
+		)
+	}
+	tbl.print(cmd.OutOrStdout())
+	fmt.Fprintln(cmd.OutOrStdout())
+
+	if !report.Passed {
+		return fmt.Errorf("MCP server %s failed the connectivity test", report.Server)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "MCP server %s passed the connectivity test\n", report.Server)
+	return nil
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/client"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestTestServerCommandStructure(t *testing.T) {
+	testhelpers.AssertEqual(t, "test", testCmd.Use)
+	testhelpers.TestCommandAnnotations(t, testCmd.Annotations, []testhelpers.CommandAnnotationTest{
+		{Key: "group", Expected: string(subCommandGroupAdvanced)},
+		{Key: "order", Expected: "22"},
+	})
+	testhelpers.AssertEqual(t, "server [name]", testServerCmd.Use)
+	testhelpers.AssertNotNil(t, testServerCmd.Flags().Lookup("conf"))
+	testhelpers.AssertNotNil(t, testServerCmd.Flags().Lookup("tool"))
+	testhelpers.AssertNotNil(t, testServerCmd.InheritedFlags().Lookup("output"))
+
+	// either a name or a configuration file must be supplied
+	testhelpers.AssertError(t, testServerCmd.PreRunE(testServerCmd, nil))
+	testhelpers.AssertNoError(t, testServerCmd.PreRunE(testServerCmd, []string{"time"}))
+}
+
+func TestRunTestServer(t *testing.T) {
+	var got types.TestServerInput
+	report := types.ServerTestReport{Server: "time", Passed: true, Steps: []types.ServerTestStep{
+		{Name: "initialize", Passed: true, DurationMs: 120},
+		{Name: "list tools", Passed: true, DurationMs: 35, Detail: "2 tools"},
+	}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testhelpers.AssertEqual(t, "/api/v1/servers/test", r.URL.Path)
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_ = json.NewEncoder(w).Encode(report)
+	}))
+	defer server.Close()
+
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+	apiClient = client.NewClient(server.URL)
+
+	var out bytes.Buffer
+	testServerCmd.SetOut(&out)
+	defer testServerCmd.SetOut(nil)
+
+	t.Run("registered server passes", func(t *testing.T) {
+		out.Reset()
+		testServerCmdTool = "get_current_time"
+		testServerCmdInput = `{"timezone": "UTC"}`
+		defer func() { testServerCmdTool, testServerCmdInput = "", "{}" }()
+
+		testhelpers.AssertNoError(t, runTestServer(testServerCmd, []string{"time"}))
+		testhelpers.AssertEqual(t, "time", got.Name)
+		testhelpers.AssertEqual(t, "get_current_time", got.Tool)
+		testhelpers.AssertEqual(t, "UTC", got.Arguments["timezone"])
+		testhelpers.AssertStringContains(t, out.String(), "list tools  PASS    35ms      2 tools")
+		testhelpers.AssertStringContains(t, out.String(), "MCP server time passed the connectivity test")
+	})
+
+	t.Run("unregistered server fails", func(t *testing.T) {
+		out.Reset()
+		conf := filepath.Join(t.TempDir(), "time.json")
+		testhelpers.AssertNoError(t, os.WriteFile(conf, []byte(`{"name": "time", "transport": "stdio", "command": "uvx"}`), 0o644))
+		testServerCmdConfigFilePath = conf
+		defer func() { testServerCmdConfigFilePath = "" }()
+		report = types.ServerTestReport{Server: "time", Steps: []types.ServerTestStep{
+			{Name: "initialize", DurationMs: 10, Error: "failed to run stdio MCP server time"},
+		}}
+
+		err := runTestServer(testServerCmd, nil)
+		testhelpers.AssertError(t, err)
+		testhelpers.AssertStringContains(t, err.Error(), "failed the connectivity test")
+		testhelpers.AssertEqual(t, "uvx", got.Server.Command)
+		testhelpers.AssertStringContains(t, out.String(), "FAIL    10ms      failed to run stdio MCP server time")
+	})
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mcpjungle/mcpjungle/cmd/config"
+	"github.com/spf13/cobra"
+)
+
+var tokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Manage access tokens (Enterprise mode)",
+	Annotations: map[string]string{
+		"group": string(subCommandGroupAdvanced),
+		"order": "24",
+	},
+}
+
+var tokenRenewCmd = &cobra.Command{
+	Use:   "renew",
+	Args:  cobra.NoArgs,
+	Short: "Replace your access token by a new one",
+	Long: "Generate a new access token for your user, without the help of an administrator.\n" +
+		"Your current token stops working immediately, eg- if it was leaked. " +
+		"The new token is saved in your client configuration in place of the old one " +
+		"(in the profile selected by the --profile flag, if set).\n\n" +
+		"Any other place you use the old token, eg- MCP clients configured with it, must be updated with the new one.",
+	RunE: runTokenRenew,
+}
+
+var tokenRenewMcpClientCmd = &cobra.Command{
+	Use:   "renew-mcp-client",
+	Args:  cobra.NoArgs,
+	Short: "Replace the access token of an MCP client by a new one",
+	Long: "Generate a new access token for an MCP client, authenticated with the client's current token " +
+		"instead of your own.\n" +
+		"The current token must not have expired yet. It stops working immediately, " +
+		"and the new one lives as long as the client's token TTL.\n" +
+		"Renew the token of a client before it expires, then configure the client with the new token, " +
+		"to rotate it without the client ever losing access.",
+	Example: "  mcpjungle token renew-mcp-client --token $MCP_CLIENT_TOKEN",
+	RunE:    runTokenRenewMcpClient,
+}
+
+var tokenRenewMcpClientCmdToken string
+
+func init() {
+	tokenRenewMcpClientCmd.Flags().StringVar(
+		&tokenRenewMcpClientCmdToken, "token", "", "Current access token of the MCP client",
+	)
+	_ = tokenRenewMcpClientCmd.MarkFlagRequired("token")
+
+	tokenCmd.AddCommand(tokenRenewCmd)
+	tokenCmd.AddCommand(tokenRenewMcpClientCmd)
+	rootCmd.AddCommand(tokenCmd)
+}
+
+func runTokenRenew(cmd *cobra.Command, args []string) error {
+	resp, err := apiClient.RenewAccessToken()
+	if err != nil {
+		return fmt.Errorf("failed to renew access token: %w", err)
+	}
+
+	cfg := config.Load()
+	name := cfg.ProfileName(profileName)
+	profile, _ := cfg.Profile(name)
+	profile.AccessToken = resp.AccessToken
+	cfg.SetProfile(name, profile)
+	if err := config.Save(cfg); err != nil {
+		// the old token no longer works, so the new one must not be lost
+		cmd.Printf("Your new access token is: %s\n", resp.AccessToken)
+		return fmt.Errorf("failed to save the new access token in the client configuration: %w", err)
+	}
+
+	cmd.Printf("The access token of %s has been renewed, the previous one no longer works\n", resp.Username)
+	cfgPath, err := config.AbsPath()
+	if err != nil {
+		return fmt.Errorf("failed to get client configuration path: %w", err)
+	}
+	if name == config.DefaultProfile {
+		cmd.Println("Your new access token has been saved to", cfgPath)
+	} else {
+		cmd.Printf("Your new access token has been saved to %s in profile '%s'\n", cfgPath, name)
+	}
+	return nil
+}
+
+func runTokenRenewMcpClient(cmd *cobra.Command, args []string) error {
+	resp, err := apiClient.RenewMcpClientToken(tokenRenewMcpClientCmdToken)
+	if err != nil {
+		return fmt.Errorf("failed to renew MCP client access token: %w", err)
+	}
+
+	if quietMode {
+		// the new token can't be retrieved later, so it is printed on its own for scripts to capture
+		fmt.Println(resp.AccessToken)
+		return nil
+	}
+
+	cmd.Printf("The access token of MCP client '%s' has been renewed, the previous one no longer works\n", resp.Name)
+	cmd.Printf("\nAccess token: %s\n", resp.AccessToken)
+	if resp.ExpiresAt != nil {
+		cmd.Printf("The token expires at %s, renew it again before then.\n", tokenExpiry(resp.ExpiresAt))
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/client"
+	"github.com/mcpjungle/mcpjungle/cmd/config"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestTokenCommandStructure(t *testing.T) {
+	testhelpers.AssertEqual(t, "token", tokenCmd.Use)
+	testhelpers.TestCommandAnnotations(t, tokenCmd.Annotations, []testhelpers.CommandAnnotationTest{
+		{Key: "group", Expected: string(subCommandGroupAdvanced)},
+		{Key: "order", Expected: "24"},
+	})
+	testhelpers.AssertEqual(t, 2, len(tokenCmd.Commands()))
+	testhelpers.AssertEqual(t, "renew", tokenRenewCmd.Use)
+	testhelpers.AssertEqual(t, "renew-mcp-client", tokenRenewMcpClientCmd.Use)
+	testhelpers.AssertNotNil(t, tokenRenewMcpClientCmd.Flags().Lookup("token"))
+}
+
+func TestRunTokenRenew(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer old-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid access token"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(types.RenewAccessTokenResponse{Username: "alice", AccessToken: "new-token"})
+	}))
+	defer server.Close()
+
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+
+	var out bytes.Buffer
+	tokenRenewCmd.SetOut(&out)
+	defer tokenRenewCmd.SetOut(nil)
+
+	t.Run("new token is saved in the profile", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		cfg := &config.ClientConfig{RegistryURL: "http://localhost:8080", AccessToken: "dev-token"}
+		cfg.SetProfile("prod", config.Profile{RegistryURL: "https://mcpjungle.example.com", AccessToken: "old-token"})
+		testhelpers.AssertNoError(t, config.Save(cfg))
+
+		apiClient = client.NewClient(server.URL, client.WithToken("old-token"))
+		profileName = "prod"
+		defer func() { profileName = "" }()
+
+		out.Reset()
+		testhelpers.AssertNoError(t, runTokenRenew(tokenRenewCmd, nil))
+		testhelpers.AssertStringContains(t, out.String(), "The access token of alice has been renewed")
+		testhelpers.AssertStringContains(t, out.String(), "in profile 'prod'")
+
+		saved := config.Load()
+		prod, err := saved.Profile("prod")
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, "new-token", prod.AccessToken)
+		testhelpers.AssertEqual(t, "https://mcpjungle.example.com", prod.RegistryURL)
+		testhelpers.AssertEqual(t, "dev-token", saved.AccessToken)
+	})
+
+	t.Run("failed renewal keeps the old token", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		testhelpers.AssertNoError(t, config.Save(&config.ClientConfig{AccessToken: "old-token"}))
+
+		apiClient = client.NewClient(server.URL, client.WithToken("wrong-token"))
+
+		err := runTokenRenew(tokenRenewCmd, nil)
+		testhelpers.AssertError(t, err)
+		testhelpers.AssertEqual(t, ExitCodeAuthFailure, ExitCode(err))
+		testhelpers.AssertEqual(t, "old-token", config.Load().AccessToken)
+	})
+}
+
+func TestRunTokenRenewMcpClient(t *testing.T) {
+	expiresAt := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the request must be authenticated with the MCP client's token, not the user's
+		if r.Header.Get("Authorization") != "Bearer client-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "MCP client token has expired"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(
+			types.RenewMcpClientTokenResponse{Name: "agent", AccessToken: "new-token", ExpiresAt: &expiresAt},
+		)
+	}))
+	defer server.Close()
+
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+	apiClient = client.NewClient(server.URL, client.WithToken("user-token"))
+	defer func() { tokenRenewMcpClientCmdToken = "" }()
+
+	var out bytes.Buffer
+	tokenRenewMcpClientCmd.SetOut(&out)
+	defer tokenRenewMcpClientCmd.SetOut(nil)
+
+	tokenRenewMcpClientCmdToken = "client-token"
+	testhelpers.AssertNoError(t, runTokenRenewMcpClient(tokenRenewMcpClientCmd, nil))
+	testhelpers.AssertStringContains(t, out.String(), "The access token of MCP client 'agent' has been renewed")
+	testhelpers.AssertStringContains(t, out.String(), "Access token: new-token")
+	testhelpers.AssertStringContains(t, out.String(), "The token expires at")
+
+	tokenRenewMcpClientCmdToken = "expired-token"
+	err := runTokenRenewMcpClient(tokenRenewMcpClientCmd, nil)
+	testhelpers.AssertError(t, err)
+	testhelpers.AssertStringContains(t, err.Error(), "MCP client token has expired")
+}
@@ -2,7 +2,11 @@ package cmd
 
 import (
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"github.com/mcpjungle/mcpjungle/pkg/util"
 	"github.com/spf13/cobra"
 )
@@ -30,8 +34,80 @@ var updateToolGroupCmd = &cobra.Command{
 	RunE: runUpdateGroup,
 }
 
+var updateTeamCmd = &cobra.Command{
+	Use:   "team [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Update the members & permissions of a team (Enterprise mode)",
+	Long: "Update an existing team of users.\n" +
+		"Only the flags that are set are applied, the rest of the team is left unchanged.\n" +
+		"--members, --tool-groups and --servers replace the existing lists,\n" +
+		"--add-members and --remove-members change the existing members.",
+	Example: "  mcpjungle update team platform --add-members carol --remove-members bob\n" +
+		"  mcpjungle update team platform --tool-groups claude-tools,cursor-tools",
+	RunE: runUpdateTeam,
+}
+
+var updateMcpClientCmd = &cobra.Command{
+	Use:   "mcp-client [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Update the description, allowed servers & quota of an MCP client (Enterprise mode)",
+	Long: "Update an existing MCP client without changing its access token, " +
+		"so the agents using the client keep working.\n" +
+		"Only the flags that are set are applied. --allow replaces the servers the client may access, " +
+		"which takes effect on the client's very next request.\n" +
+		"--quota sets the number of tool calls the client may make per quota period, 0 removes the quota. " +
+		"--reset-quota lets a client that exceeded its quota call tools again right away.",
+	Example: "  mcpjungle update mcp-client cursor-local --allow calculator,github,time\n" +
+		"  mcpjungle update mcp-client cursor-local --description \"Cursor IDE on my laptop\"\n" +
+		"  mcpjungle update mcp-client nightly-agent --quota 10000 --quota-period monthly",
+	RunE: runUpdateMcpClient,
+}
+
+var updateUserCmd = &cobra.Command{
+	Use:   "user [username]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Suspend a user or restrict the tools they may access (Enterprise mode)",
+	Long: "Update the access of an existing user.\n\n" +
+		"--suspend rejects the user's access token until --activate is used, " +
+		"while keeping their account, teams and tool policy, unlike deleting the user.\n\n" +
+		"--allow-servers & --allow-groups restrict the tools the user may see & invoke via the API to the tools of " +
+		"the given MCP servers and the tools exposed by the given tool groups, like an MCP client restricted to them.\n" +
+		"The policy replaces the user's existing one, ie, the servers & groups that are not supplied are no longer allowed.\n" +
+		"Use --unrestricted to let the user access all tools again.\n\n" +
+		"Admins cannot be suspended and may always access all tools.",
+	Example: "  mcpjungle update user alice --allow-servers github,time --allow-groups claude-tools\n" +
+		"  mcpjungle update user alice --unrestricted\n" +
+		"  mcpjungle update user alice --suspend",
+	RunE: runUpdateUser,
+}
+
 var updateToolGroupConfigFilePath string
 
+var (
+	updateMcpClientCmdDescription    string
+	updateMcpClientCmdAllowedServers string
+	updateMcpClientCmdQuota          int64
+	updateMcpClientCmdQuotaPeriod    string
+	updateMcpClientCmdResetQuota     bool
+)
+
+var (
+	updateUserCmdAllowServers string
+	updateUserCmdAllowGroups  string
+	updateUserCmdUnrestricted bool
+	updateUserCmdSuspend      bool
+	updateUserCmdActivate     bool
+)
+
+var (
+	updateTeamCmdDescription   string
+	updateTeamCmdMembers       string
+	updateTeamCmdAddMembers    string
+	updateTeamCmdRemoveMembers string
+	updateTeamCmdToolGroups    string
+	updateTeamCmdServers       string
+)
+
 func init() {
 	updateToolGroupCmd.Flags().StringVarP(
 		&updateToolGroupConfigFilePath,
@@ -42,7 +118,87 @@ func init() {
 	)
 	_ = updateToolGroupCmd.MarkFlagRequired("conf")
 
+	updateTeamCmd.Flags().StringVar(&updateTeamCmdDescription, "description", "", "New description of the team")
+	updateTeamCmd.Flags().StringVar(
+		&updateTeamCmdMembers, "members", "", "Comma-separated list of the usernames of all the team's members",
+	)
+	updateTeamCmd.Flags().StringVar(
+		&updateTeamCmdAddMembers, "add-members", "", "Comma-separated list of the usernames to add to the team",
+	)
+	updateTeamCmd.Flags().StringVar(
+		&updateTeamCmdRemoveMembers,
+		"remove-members",
+		"",
+		"Comma-separated list of the usernames to remove from the team",
+	)
+	updateTeamCmd.Flags().StringVar(
+		&updateTeamCmdToolGroups,
+		"tool-groups",
+		"",
+		"Comma-separated list of all the tool groups that the members of the team may administer",
+	)
+	updateTeamCmd.Flags().StringVar(
+		&updateTeamCmdServers,
+		"servers",
+		"",
+		"Comma-separated list of all the MCP servers that the members of the team may administer",
+	)
+	updateTeamCmd.MarkFlagsMutuallyExclusive("members", "add-members")
+	updateTeamCmd.MarkFlagsMutuallyExclusive("members", "remove-members")
+
+	updateMcpClientCmd.Flags().StringVar(
+		&updateMcpClientCmdDescription, "description", "", "New description of the MCP client",
+	)
+	updateMcpClientCmd.Flags().StringVar(
+		&updateMcpClientCmdAllowedServers,
+		"allow",
+		"",
+		"Comma-separated list of all the MCP servers that the client may access (an empty list revokes all access)",
+	)
+	updateMcpClientCmd.Flags().Int64Var(
+		&updateMcpClientCmdQuota, "quota", 0, "Number of tool calls the client may make per quota period, 0 for unlimited",
+	)
+	updateMcpClientCmd.Flags().StringVar(
+		&updateMcpClientCmdQuotaPeriod,
+		"quota-period",
+		"",
+		"Period after which the client's quota resets: daily or monthly. Changing it starts a new period",
+	)
+	updateMcpClientCmd.Flags().BoolVar(
+		&updateMcpClientCmdResetQuota, "reset-quota", false, "Reset the quota usage of the client right away",
+	)
+	updateMcpClientCmd.MarkFlagsOneRequired("description", "allow", "quota", "quota-period", "reset-quota")
+
+	updateUserCmd.Flags().StringVar(
+		&updateUserCmdAllowServers,
+		"allow-servers",
+		"",
+		"Comma-separated list of the MCP servers whose tools the user may access",
+	)
+	updateUserCmd.Flags().StringVar(
+		&updateUserCmdAllowGroups,
+		"allow-groups",
+		"",
+		"Comma-separated list of the tool groups whose tools the user may access",
+	)
+	updateUserCmd.Flags().BoolVar(
+		&updateUserCmdUnrestricted, "unrestricted", false, "Let the user access all tools",
+	)
+	updateUserCmd.Flags().BoolVar(
+		&updateUserCmdSuspend, "suspend", false, "Suspend the user, ie, reject their access token",
+	)
+	updateUserCmd.Flags().BoolVar(
+		&updateUserCmdActivate, "activate", false, "Lift the suspension of the user",
+	)
+	updateUserCmd.MarkFlagsMutuallyExclusive("unrestricted", "allow-servers")
+	updateUserCmd.MarkFlagsMutuallyExclusive("unrestricted", "allow-groups")
+	updateUserCmd.MarkFlagsMutuallyExclusive("suspend", "activate")
+	updateUserCmd.MarkFlagsOneRequired("unrestricted", "allow-servers", "allow-groups", "suspend", "activate")
+
 	updateCmd.AddCommand(updateToolGroupCmd)
+	updateCmd.AddCommand(updateTeamCmd)
+	updateCmd.AddCommand(updateMcpClientCmd)
+	updateCmd.AddCommand(updateUserCmd)
 	rootCmd.AddCommand(updateCmd)
 }
 
@@ -66,7 +222,11 @@ func runUpdateGroup(cmd *cobra.Command, args []string) error {
 	noChangeInServers := len(serversAdded) == 0 && len(serversRemoved) == 0
 	noChangeInExcluded := len(excludedAdded) == 0 && len(excludedRemoved) == 0
 
-	if resp.Old.Description == resp.New.Description && noChangeInTools && noChangeInServers && noChangeInExcluded {
+	noChangeInReadOnly := resp.Old.ReadOnly == resp.New.ReadOnly
+	noChangeInCached := maps.Equal(resp.Old.CachedTools, resp.New.CachedTools)
+
+	if resp.Old.Description == resp.New.Description && noChangeInReadOnly && noChangeInCached &&
+		noChangeInTools && noChangeInServers && noChangeInExcluded {
 		cmd.Printf("No changes detected for Tool Group %s. Nothing was updated.\n", resp.Name)
 		return nil
 	}
@@ -77,6 +237,10 @@ func runUpdateGroup(cmd *cobra.Command, args []string) error {
 		cmd.Printf("* Description updated from:\n    %s\nto:\n    %s\n\n", resp.Old.Description, resp.New.Description)
 	}
 
+	if !noChangeInReadOnly {
+		cmd.Printf("* read_only updated from %t to %t\n\n", resp.Old.ReadOnly, resp.New.ReadOnly)
+	}
+
 	// Report changes in included_tools
 	if noChangeInTools {
 		cmd.Println("* No changes in included_tools")
@@ -130,5 +294,133 @@ func runUpdateGroup(cmd *cobra.Command, args []string) error {
 		cmd.Println()
 	}
 
+	// Report changes in cached_tools
+	if !noChangeInCached {
+		cmd.Println("* cached_tools updated to:")
+		if len(resp.New.CachedTools) == 0 {
+			cmd.Println("    (none)")
+		}
+		for _, t := range slices.Sorted(maps.Keys(resp.New.CachedTools)) {
+			cmd.Printf("    - %s (ttl: %s)\n", t, resp.New.CachedTools[t])
+		}
+		cmd.Println()
+	}
+
+	return nil
+}
+
+func runUpdateTeam(cmd *cobra.Command, args []string) error {
+	t, err := apiClient.GetTeam(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get team: %w", err)
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("description") {
+		t.Description = updateTeamCmdDescription
+	}
+	if flags.Changed("members") {
+		t.Members = splitNames(updateTeamCmdMembers)
+	}
+	for _, m := range splitNames(updateTeamCmdAddMembers) {
+		if !slices.Contains(t.Members, m) {
+			t.Members = append(t.Members, m)
+		}
+	}
+	remove := splitNames(updateTeamCmdRemoveMembers)
+	t.Members = slices.DeleteFunc(t.Members, func(m string) bool {
+		return slices.Contains(remove, m)
+	})
+	if flags.Changed("tool-groups") {
+		t.ToolGroups = splitNames(updateTeamCmdToolGroups)
+	}
+	if flags.Changed("servers") {
+		t.Servers = splitNames(updateTeamCmdServers)
+	}
+
+	t, err = apiClient.UpdateTeam(t)
+	if err != nil {
+		return fmt.Errorf("failed to update team %s: %w", args[0], err)
+	}
+	cmd.Printf("Team '%s' updated successfully\n", t.Name)
+	printTeamPermissions(cmd, t)
 	return nil
 }
+
+func runUpdateMcpClient(cmd *cobra.Command, args []string) error {
+	patch := &types.PatchMcpClientInput{}
+	flags := cmd.Flags()
+	if flags.Changed("description") {
+		patch.Description = &updateMcpClientCmdDescription
+	}
+	if flags.Changed("allow") {
+		allowList := splitNames(updateMcpClientCmdAllowedServers)
+		patch.AllowList = &allowList
+	}
+	if flags.Changed("quota") {
+		patch.QuotaLimit = &updateMcpClientCmdQuota
+	}
+	if flags.Changed("quota-period") {
+		period := types.QuotaPeriod(updateMcpClientCmdQuotaPeriod)
+		patch.QuotaPeriod = &period
+	}
+	patch.ResetQuota = updateMcpClientCmdResetQuota
+
+	c, err := apiClient.UpdateMcpClient(args[0], patch)
+	if err != nil {
+		return fmt.Errorf("failed to update MCP client %s: %w", args[0], err)
+	}
+	cmd.Printf("MCP client '%s' updated successfully\n", c.Name)
+	if len(c.AllowList) > 0 {
+		cmd.Println("Servers accessible: " + strings.Join(c.AllowList, ","))
+	} else {
+		cmd.Println("This client does not have access to any MCP servers.")
+	}
+	if c.QuotaLimit > 0 {
+		cmd.Println("Quota: " + clientQuota(c))
+	}
+	return nil
+}
+
+func runUpdateUser(cmd *cobra.Command, args []string) error {
+	username := args[0]
+
+	switch {
+	case updateUserCmdSuspend:
+		if _, err := apiClient.SuspendUser(username); err != nil {
+			return fmt.Errorf("failed to suspend user %s: %w", username, err)
+		}
+		cmd.Printf("User '%s' is suspended, their access token is rejected until they are activated\n", username)
+	case updateUserCmdActivate:
+		if _, err := apiClient.ActivateUser(username); err != nil {
+			return fmt.Errorf("failed to activate user %s: %w", username, err)
+		}
+		cmd.Printf("User '%s' is active again\n", username)
+	}
+
+	flags := cmd.Flags()
+	switch {
+	case updateUserCmdUnrestricted:
+		if err := apiClient.DeleteUserToolPolicy(username); err != nil {
+			return fmt.Errorf("failed to update user %s: %w", username, err)
+		}
+		cmd.Printf("User '%s' may now access all tools\n", username)
+	case flags.Changed("allow-servers") || flags.Changed("allow-groups"):
+		u, err := apiClient.SetUserToolPolicy(username, &types.ToolPolicy{
+			Servers:    splitNames(updateUserCmdAllowServers),
+			ToolGroups: splitNames(updateUserCmdAllowGroups),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update user %s: %w", username, err)
+		}
+		cmd.Printf("User '%s' may now only access the tools of:\n", u.Username)
+		printToolPolicy(cmd, u.ToolPolicy)
+	}
+	return nil
+}
+
+// printToolPolicy prints the MCP servers & tool groups whose tools a user may access.
+func printToolPolicy(cmd *cobra.Command, p *types.ToolPolicy) {
+	cmd.Printf("  Servers: %s\n", joinOrNone(p.Servers))
+	cmd.Printf("  Tool groups: %s\n", joinOrNone(p.ToolGroups))
+}
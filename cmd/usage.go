@@ -3,17 +3,24 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"slices"
+	"sort"
 	"strings"
+	"text/tabwriter"
 
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"github.com/spf13/cobra"
 )
 
 var usageCmd = &cobra.Command{
 	Use:   "usage <name>",
 	Short: "Get usage information for a MCP tool",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runGetToolUsage,
+	Long: "Get usage information for a MCP tool.\n" +
+		"This prints a table of the tool's input parameters (name, type, whether it is required, default value,\n" +
+		"allowed values & description) along with an example invocation.",
+	Args: cobra.ExactArgs(1),
+	RunE: runGetToolUsage,
 	Annotations: map[string]string{
 		"group": string(subCommandGroupBasic),
 		"order": "4",
@@ -24,44 +31,181 @@ func init() {
 	rootCmd.AddCommand(usageCmd)
 }
 
+// toolParam is an input parameter of a tool, described by its JSON schema
+type toolParam struct {
+	name     string
+	required bool
+	schema   map[string]any
+}
+
 func runGetToolUsage(cmd *cobra.Command, args []string) error {
 	t, err := apiClient.GetTool(args[0])
 	if err != nil {
 		return fmt.Errorf("failed to get tool '%s': %w", args[0], err)
 	}
 
-	fmt.Println(t.Name)
-	fmt.Println(t.Description)
+	w := cmd.OutOrStdout()
+	fmt.Fprintln(w, t.Name)
+	fmt.Fprintln(w, t.Description)
 
 	if len(t.InputSchema.Properties) == 0 {
-		fmt.Println("This tool does not require any input parameters.")
+		fmt.Fprintln(w, "This tool does not require any input parameters.")
 		return nil
 	}
 
-	fmt.Println()
-	fmt.Println("Input Parameters:")
-	for k, v := range t.InputSchema.Properties {
-		requiredOrOptional := "optional"
-		if slices.Contains(t.InputSchema.Required, k) {
-			requiredOrOptional = "required"
-		}
+	params := toolParams(t.InputSchema)
 
-		boundary := strings.Repeat("=", len(k)+len(requiredOrOptional)+20)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Input Parameters:")
+	printToolParams(w, params)
 
-		fmt.Println(boundary)
-		fmt.Printf("%s (%s)\n", k, requiredOrOptional)
+	payload := make(map[string]any, len(params))
+	for _, p := range params {
+		payload[p.name] = exampleValue(p.name, p.schema)
+	}
+	// the placeholders are enclosed in <>, which must not be escaped
+	var j strings.Builder
+	enc := json.NewEncoder(&j)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(payload); err != nil {
+		return fmt.Errorf("failed to generate example input: %w", err)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Example:")
+	fmt.Fprintf(w, "mcpjungle invoke %s --input '%s'\n", t.Name, strings.TrimSpace(j.String()))
 
-		j, err := json.MarshalIndent(v, "", "  ")
-		if err != nil {
-			// Simply print the raw object if we fail to marshal it
-			fmt.Println(v)
-		} else {
-			fmt.Println(string(j))
+	return nil
+}
+
+// toolParams returns the input parameters of a tool, the required ones first, then sorted by name.
+func toolParams(s types.ToolInputSchema) []toolParam {
+	params := make([]toolParam, 0, len(s.Properties))
+	for name, v := range s.Properties {
+		schema, _ := v.(map[string]any)
+		params = append(params, toolParam{
+			name:     name,
+			required: slices.Contains(s.Required, name),
+			schema:   schema,
+		})
+	}
+	sort.Slice(params, func(i, j int) bool {
+		if params[i].required != params[j].required {
+			return params[i].required
 		}
-		fmt.Println(boundary)
+		return params[i].name < params[j].name
+	})
+	return params
+}
 
-		fmt.Println()
+// printToolParams prints the parameters as a table.
+func printToolParams(w io.Writer, params []toolParam) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tTYPE\tREQUIRED\tDEFAULT\tVALUES\tDESCRIPTION")
+	for _, p := range params {
+		required := "no"
+		if p.required {
+			required = "yes"
+		}
+		def := "-"
+		if v, ok := p.schema["default"]; ok {
+			def = formatSchemaValue(v)
+		}
+		values := "-"
+		if enum, ok := p.schema["enum"].([]any); ok && len(enum) > 0 {
+			vs := make([]string, len(enum))
+			for i, e := range enum {
+				vs[i] = formatSchemaValue(e)
+			}
+			values = strings.Join(vs, ", ")
+		}
+		desc, _ := p.schema["description"].(string)
+		// a newline would break the table
+		desc = strings.Join(strings.Fields(desc), " ")
+		if desc == "" {
+			desc = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", p.name, schemaType(p.schema), required, def, values, desc)
 	}
+	_ = tw.Flush()
+}
 
-	return nil
+// schemaType returns a readable type of a JSON schema, eg- "string", "integer | null" or "array of string".
+func schemaType(s map[string]any) string {
+	switch t := s["type"].(type) {
+	case string:
+		if t == "array" {
+			if items, ok := s["items"].(map[string]any); ok {
+				if it := schemaType(items); it != "any" {
+					return "array of " + it
+				}
+			}
+		}
+		return t
+	case []any:
+		ts := make([]string, 0, len(t))
+		for _, v := range t {
+			if s, ok := v.(string); ok {
+				ts = append(ts, s)
+			}
+		}
+		if len(ts) > 0 {
+			return strings.Join(ts, " | ")
+		}
+	}
+	for _, key := range []string{"anyOf", "oneOf"} {
+		variants, ok := s[key].([]any)
+		if !ok {
+			continue
+		}
+		ts := make([]string, 0, len(variants))
+		for _, v := range variants {
+			if vs, ok := v.(map[string]any); ok {
+				ts = append(ts, schemaType(vs))
+			}
+		}
+		if len(ts) > 0 {
+			return strings.Join(ts, " | ")
+		}
+	}
+	return "any"
+}
+
+// exampleValue returns a value of a parameter for the example invocation.
+// It is the default or first allowed value of the parameter if the schema has one,
+// otherwise a placeholder of the parameter's type.
+func exampleValue(name string, s map[string]any) any {
+	if v, ok := s["default"]; ok {
+		return v
+	}
+	if enum, ok := s["enum"].([]any); ok && len(enum) > 0 {
+		return enum[0]
+	}
+	// for union types, the first type is used
+	typ, _, _ := strings.Cut(schemaType(s), " ")
+	switch typ {
+	case "string":
+		return "<" + name + ">"
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return false
+	case "array":
+		return []any{}
+	case "object":
+		return map[string]any{}
+	default:
+		return nil
+	}
+}
+
+// formatSchemaValue formats a value found in a JSON schema, eg- a default value.
+func formatSchemaValue(v any) string {
+	if s, ok := v.(string); ok && s != "" {
+		return s
+	}
+	j, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(j)
 }
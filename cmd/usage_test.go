@@ -1,7 +1,14 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+
+	"github.com/mcpjungle/mcpjungle/client"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
 
 func TestUsageCommandStructure(t *testing.T) {
@@ -48,3 +55,69 @@ func TestUsageCommandStructure(t *testing.T) {
 		}
 	})
 }
+
+func TestSchemaType(t *testing.T) {
+	tests := []struct {
+		schema map[string]any
+		want   string
+	}{
+		{map[string]any{"type": "string"}, "string"},
+		{map[string]any{"type": []any{"integer", "null"}}, "integer | null"},
+		{map[string]any{"type": "array", "items": map[string]any{"type": "string"}}, "array of string"},
+		{map[string]any{"type": "array"}, "array"},
+		{map[string]any{"anyOf": []any{map[string]any{"type": "string"}, map[string]any{"type": "null"}}}, "string | null"},
+		{map[string]any{}, "any"},
+	}
+	for _, tt := range tests {
+		if got := schemaType(tt.schema); got != tt.want {
+			t.Errorf("schemaType(%v) = %q, expected %q", tt.schema, got, tt.want)
+		}
+	}
+}
+
+func TestRunGetToolUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(types.Tool{
+			Name:        "weather__forecast",
+			Description: "Get the weather forecast",
+			InputSchema: types.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]any{
+					"city": map[string]any{"type": "string", "description": "Name of the\ncity"},
+					"days": map[string]any{"type": "integer", "default": 3},
+					"unit": map[string]any{"type": "string", "enum": []any{"celsius", "fahrenheit"}},
+				},
+				Required: []string{"city"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	originalClient := apiClient
+	defer func() { apiClient = originalClient }()
+	apiClient = client.NewClient(server.URL)
+
+	var out bytes.Buffer
+	usageCmd.SetOut(&out)
+	defer usageCmd.SetOut(nil)
+
+	if err := runGetToolUsage(usageCmd, []string{"weather__forecast"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `weather__forecast
+Get the weather forecast
+
+Input Parameters:
+NAME  TYPE     REQUIRED  DEFAULT  VALUES               DESCRIPTION
+city  string   yes       -        -                    Name of the city
+days  integer  no        3        -                    -
+unit  string   no        -        celsius, fahrenheit  -
+
+Example:
+mcpjungle invoke weather__forecast --input '{"city":"<city>","days":3,"unit":"celsius"}'
+`
+	if out.String() != want {
+		t.Errorf("unexpected output:\n%s\nexpected:\n%s", out.String(), want)
+	}
+}
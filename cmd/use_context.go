@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/mcpjungle/mcpjungle/cmd/config"
+	"github.com/spf13/cobra"
+)
+
+var useContextCmd = &cobra.Command{
+	Use:   "use-context [profile]",
+	Short: "Switch between the registry profiles of the client configuration",
+	Long: "Switch the profile used by all commands, so that you don't need to set the --registry & --profile flags " +
+		"when working with several registry servers, eg- dev & prod gateways.\n" +
+		"A profile is a registry server URL along with an access token. Profiles are created by " +
+		"'mcpjungle login --profile <name>'.\n" +
+		"The '" + config.DefaultProfile + "' profile is made of the top-level registry_url & access_token " +
+		"of the configuration file.\n\n" +
+		"Without any argument, this command lists the profiles, the current one marked with '*'.",
+	Example: "  mcpjungle use-context prod\n" +
+		"  mcpjungle use-context " + config.DefaultProfile,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runUseContext,
+	Annotations: map[string]string{
+		"group": string(subCommandGroupAdvanced),
+		"order": "17",
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(useContextCmd)
+}
+
+func runUseContext(cmd *cobra.Command, args []string) error {
+	cfg := config.Load()
+	w := cmd.OutOrStdout()
+
+	if len(args) == 0 {
+		current := cfg.ProfileName("")
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "CURRENT\tNAME\tREGISTRY")
+		for _, name := range cfg.ProfileNames() {
+			p, _ := cfg.Profile(name)
+			marker := ""
+			if name == current {
+				marker = "*"
+			}
+			registry := p.RegistryURL
+			if registry == "" {
+				registry = "-"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", marker, name, registry)
+		}
+		return tw.Flush()
+	}
+
+	name := args[0]
+	if _, err := cfg.Profile(name); err != nil {
+		return fmt.Errorf("%w, create it with 'mcpjungle login --profile %s'", err, name)
+	}
+	if name == config.DefaultProfile {
+		cfg.CurrentProfile = ""
+	} else {
+		cfg.CurrentProfile = name
+	}
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save client configuration: %w", err)
+	}
+	fmt.Fprintf(w, "Switched to profile '%s'\n", name)
+	return nil
+}
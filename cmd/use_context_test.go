@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/cmd/config"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestRunUseContext(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cfg := &config.ClientConfig{RegistryURL: "http://localhost:8080", AccessToken: "dev-token"}
+	cfg.SetProfile("prod", config.Profile{RegistryURL: "https://mcpjungle.example.com", AccessToken: "prod-token"})
+	testhelpers.AssertNoError(t, config.Save(cfg))
+
+	var out bytes.Buffer
+	useContextCmd.SetOut(&out)
+	defer useContextCmd.SetOut(nil)
+
+	t.Run("switch to a profile", func(t *testing.T) {
+		out.Reset()
+		testhelpers.AssertNoError(t, runUseContext(useContextCmd, []string{"prod"}))
+		testhelpers.AssertEqual(t, "Switched to profile 'prod'\n", out.String())
+		testhelpers.AssertEqual(t, "prod", config.Load().CurrentProfile)
+	})
+
+	t.Run("list profiles", func(t *testing.T) {
+		out.Reset()
+		testhelpers.AssertNoError(t, runUseContext(useContextCmd, nil))
+		want := "CURRENT  NAME     REGISTRY\n" +
+			"         default  http://localhost:8080\n" +
+			"*        prod     https://mcpjungle.example.com\n"
+		testhelpers.AssertEqual(t, want, out.String())
+	})
+
+	t.Run("switch back to the default profile", func(t *testing.T) {
+		out.Reset()
+		testhelpers.AssertNoError(t, runUseContext(useContextCmd, []string{config.DefaultProfile}))
+		testhelpers.AssertEqual(t, "", config.Load().CurrentProfile)
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		err := runUseContext(useContextCmd, []string{"staging"})
+		testhelpers.AssertError(t, err)
+		testhelpers.AssertStringContains(t, err.Error(), "profile not found: 'staging'")
+		testhelpers.AssertEqual(t, "", config.Load().CurrentProfile)
+	})
+}
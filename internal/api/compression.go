@@ -0,0 +1,185 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+)
+
+// Content encodings supported for compressing responses, in order of preference
+const (
+	encodingGzip    = "gzip"
+	encodingDeflate = "deflate"
+)
+
+// compressResponses is middleware that compresses JSON responses with gzip or deflate,
+// as negotiated with the client via the Accept-Encoding header.
+// Responses smaller than minBytes are sent uncompressed because compressing them isn't worth the overhead.
+// Responses of any other content type, like event streams, are never compressed so that they can be
+// delivered to the client as soon as they are written.
+func compressResponses(minBytes int, l logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if minBytes <= 0 || encoding == "" || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		w := &compressResponseWriter{ResponseWriter: original, encoding: encoding, minBytes: minBytes}
+		c.Writer = w
+		defer func() {
+			if err := w.finish(); err != nil {
+				l.Warn(
+					"failed to write compressed response", logger.String("path", c.Request.URL.Path), logger.ErrorField(err),
+				)
+			}
+			c.Writer = original
+		}()
+
+		c.Next()
+	}
+}
+
+// negotiateEncoding returns the supported content encoding that the client accepts,
+// or an empty string if it doesn't accept any of them.
+func negotiateEncoding(acceptEncoding string) string {
+	accepted := make(map[string]bool)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		accepted[name] = q > 0
+	}
+	for _, enc := range []string{encodingGzip, encodingDeflate} {
+		if ok, found := accepted[enc]; found {
+			if ok {
+				return enc
+			}
+			continue
+		}
+		if accepted["*"] {
+			return enc
+		}
+	}
+	return ""
+}
+
+// compressResponseWriter buffers the beginning of a JSON response until it is large enough to be worth compressing.
+// Once it is, the headers are updated and the rest of the response is compressed on the fly.
+type compressResponseWriter struct {
+	gin.ResponseWriter
+	encoding string
+	minBytes int
+
+	// decided is true once the writer has determined whether to compress the response
+	decided bool
+	buf     bytes.Buffer
+	// enc is the compressing writer, nil if the response isn't compressed
+	enc io.WriteCloser
+}
+
+func (w *compressResponseWriter) Write(data []byte) (int, error) {
+	if w.decided {
+		if w.enc != nil {
+			return w.enc.Write(data)
+		}
+		return w.ResponseWriter.Write(data)
+	}
+
+	if !strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		// pass the response through as-is
+		w.decided = true
+		return w.ResponseWriter.Write(data)
+	}
+
+	w.buf.Write(data)
+	if w.buf.Len() >= w.minBytes {
+		if err := w.startCompressing(); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+// Unwrap returns the underlying response writer, for use by http.ResponseController.
+func (w *compressResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *compressResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush sends everything written so far to the client.
+// A response that is flushed before it is large enough to be compressed is sent uncompressed.
+func (w *compressResponseWriter) Flush() {
+	if !w.decided {
+		if err := w.sendBuffered(); err != nil {
+			return
+		}
+	}
+	if f, ok := w.enc.(interface{ Flush() error }); ok {
+		if err := f.Flush(); err != nil {
+			return
+		}
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *compressResponseWriter) startCompressing() error {
+	w.decided = true
+
+	h := w.Header()
+	h.Set("Content-Encoding", w.encoding)
+	h.Add("Vary", "Accept-Encoding")
+	h.Del("Content-Length")
+
+	if w.encoding == encodingGzip {
+		w.enc = gzip.NewWriter(w.ResponseWriter)
+	} else {
+		// the deflate content encoding is actually the zlib format, see RFC 9110
+		w.enc = zlib.NewWriter(w.ResponseWriter)
+	}
+	_, err := w.enc.Write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+// sendBuffered sends the buffered part of the response uncompressed.
+func (w *compressResponseWriter) sendBuffered() error {
+	w.decided = true
+	w.Header().Add("Vary", "Accept-Encoding")
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	_, err := w.ResponseWriter.Write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+// finish writes out the rest of the response once the handler has returned.
+func (w *compressResponseWriter) finish() error {
+	if !w.decided {
+		return w.sendBuffered()
+	}
+	if w.enc != nil {
+		return w.enc.Close()
+	}
+	return nil
+}
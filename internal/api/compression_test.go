@@ -0,0 +1,112 @@
+package api
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected string
+	}{
+		{header: "", expected: ""},
+		{header: "gzip", expected: encodingGzip},
+		{header: "deflate", expected: encodingDeflate},
+		{header: "gzip, deflate, br", expected: encodingGzip},
+		{header: "br, deflate", expected: encodingDeflate},
+		{header: "gzip;q=0, deflate;q=0.5", expected: encodingDeflate},
+		{header: "GZIP", expected: encodingGzip},
+		{header: "*", expected: encodingGzip},
+		{header: "gzip;q=0, *", expected: encodingDeflate},
+		{header: "identity", expected: ""},
+	}
+	for _, tt := range tests {
+		testhelpers.AssertEqual(t, tt.expected, negotiateEncoding(tt.header))
+	}
+}
+
+func TestCompressResponses(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	largeText := strings.Repeat("tool description ", 200)
+
+	router := gin.New()
+	router.Use(compressResponses(1024, logger.NewNop()))
+	router.GET("/large", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"text": largeText})
+	})
+	router.GET("/small", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"text": "hello"})
+	})
+	router.GET("/stream", func(c *gin.Context) {
+		c.Header("Content-Type", "text/event-stream")
+		c.String(http.StatusOK, "data: "+largeText+"\n\n")
+	})
+
+	do := func(path, acceptEncoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("large JSON response is gzipped", func(t *testing.T) {
+		w := do("/large", "gzip, deflate")
+		testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+		testhelpers.AssertEqual(t, "gzip", w.Header().Get("Content-Encoding"))
+		testhelpers.AssertEqual(t, "Accept-Encoding", w.Header().Get("Vary"))
+		testhelpers.AssertTrue(t, w.Body.Len() < len(largeText), "Expected the response to be compressed")
+
+		r, err := gzip.NewReader(w.Body)
+		if err != nil {
+			t.Fatalf("failed to read gzipped body: %v", err)
+		}
+		body, err := io.ReadAll(r)
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertStringContains(t, string(body), largeText)
+	})
+
+	t.Run("large JSON response is deflated", func(t *testing.T) {
+		w := do("/large", "deflate")
+		testhelpers.AssertEqual(t, "deflate", w.Header().Get("Content-Encoding"))
+
+		r, err := zlib.NewReader(w.Body)
+		if err != nil {
+			t.Fatalf("failed to read deflated body: %v", err)
+		}
+		body, err := io.ReadAll(r)
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertStringContains(t, string(body), largeText)
+	})
+
+	t.Run("response is not compressed if the client doesn't accept it", func(t *testing.T) {
+		w := do("/large", "")
+		testhelpers.AssertEqual(t, "", w.Header().Get("Content-Encoding"))
+		testhelpers.AssertStringContains(t, w.Body.String(), largeText)
+	})
+
+	t.Run("small response is not compressed", func(t *testing.T) {
+		w := do("/small", "gzip")
+		testhelpers.AssertEqual(t, "", w.Header().Get("Content-Encoding"))
+		testhelpers.AssertEqual(t, `{"text":"hello"}`, w.Body.String())
+	})
+
+	t.Run("event stream is not compressed", func(t *testing.T) {
+		w := do("/stream", "gzip")
+		testhelpers.AssertEqual(t, "", w.Header().Get("Content-Encoding"))
+		testhelpers.AssertStringContains(t, w.Body.String(), largeText)
+	})
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DashboardPath is the path at which the web dashboard is served when it is enabled.
+const DashboardPath = "/ui"
+
+// dashboardFiles holds the web dashboard, a single-page app that browses the registry through the v1 API.
+// It is plain HTML, CSS & JavaScript, so that it needs no build step.
+//
+//go:embed dashboard
+var dashboardFiles embed.FS
+
+// dashboardCSP only lets the dashboard load its own files and call the API of the server that serves it,
+// so that the access token kept by the browser cannot be sent anywhere else.
+const dashboardCSP = "default-src 'self'; frame-ancestors 'none'; base-uri 'none'; form-action 'none'"
+
+// registerDashboardRoutes serves the web dashboard.
+// The dashboard's files are public, the data it shows is fetched from the API with the user's access token.
+func (s *Server) registerDashboardRoutes(r *gin.Engine) error {
+	files, err := fs.Sub(dashboardFiles, "dashboard")
+	if err != nil {
+		return err
+	}
+	fileServer := http.StripPrefix(DashboardPath, http.FileServer(http.FS(files)))
+
+	r.GET(DashboardPath, func(c *gin.Context) {
+		c.Redirect(http.StatusMovedPermanently, DashboardPath+"/")
+	})
+	r.GET(DashboardPath+"/*filepath", func(c *gin.Context) {
+		c.Header("Content-Security-Policy", dashboardCSP)
+		c.Header("X-Content-Type-Options", "nosniff")
+		fileServer.ServeHTTP(c.Writer, c.Request)
+	})
+	return nil
+}
@@ -0,0 +1,211 @@
+// The dashboard is a read-only view of the registry, built on the v1 registry API.
+// In enterprise mode, the access token of the user is kept in the session storage of the browser.
+"use strict";
+
+const API = "/api/v1";
+const TOKEN_KEY = "mcpjungle-token";
+
+const $ = (id) => document.getElementById(id);
+
+// views maps the route of each page to the API endpoint it shows and the columns of its table.
+const views = {
+  servers: {
+    title: "MCP servers",
+    path: API + "/servers",
+    columns: [
+      ["Name", (s) => s.name],
+      ["Transport", (s) => s.transport],
+      ["Description", (s) => s.description],
+      ["URL / Command", (s) => s.url || [s.command].concat(s.args || []).join(" ")],
+    ],
+  },
+  tools: {
+    title: "Tools",
+    path: API + "/tools",
+    columns: [
+      ["Name", (t) => t.name],
+      ["Enabled", (t) => status(t.enabled, "yes", "no")],
+      ["Description", (t) => t.description],
+    ],
+  },
+  groups: {
+    title: "Tool groups",
+    path: API + "/tool-groups",
+    columns: [
+      ["Name", (g) => g.name],
+      ["Description", (g) => g.description],
+      ["Servers", (g) => (g.included_servers || []).join(", ")],
+      ["Tools", (g) => (g.included_tools || []).join(", ")],
+      ["Excluded tools", (g) => (g.excluded_tools || []).join(", ")],
+    ],
+  },
+  clients: {
+    title: "MCP clients",
+    path: API + "/clients",
+    columns: [
+      ["Name", (c) => c.name],
+      ["Description", (c) => c.description],
+      ["Allowed servers", (c) => (c.allow_list || []).join(", ")],
+      ["Token expires", (c) => time(c.token_expires_at)],
+    ],
+  },
+  invocations: {
+    title: "Recent tool invocations",
+    path: API + "/invocations?limit=100",
+    columns: [
+      ["Time", (i) => time(i.invoked_at)],
+      ["Tool", (i) => i.tool],
+      ["Caller", (i) => i.caller],
+      ["Outcome", (i) => status(i.outcome === "success", i.outcome, i.outcome)],
+      ["Duration", (i) => i.duration_ms + " ms"],
+      ["Error", (i) => i.error],
+    ],
+  },
+};
+
+function token() {
+  return sessionStorage.getItem(TOKEN_KEY);
+}
+
+// api fetches an endpoint of the server, and asks the user to sign in if they're not authenticated.
+async function api(path) {
+  const headers = {};
+  if (token()) {
+    headers.Authorization = "Bearer " + token();
+  }
+  const resp = await fetch(path, { headers });
+  if (resp.status === 401) {
+    sessionStorage.removeItem(TOKEN_KEY);
+    showSignIn(true);
+    throw new Error("Sign in to continue.");
+  }
+  const body = await resp.json().catch(() => ({}));
+  if (!resp.ok) {
+    throw new Error(body.error || resp.status + " " + resp.statusText);
+  }
+  return body;
+}
+
+// status returns a cell showing a value that is either good or bad.
+function status(good, goodText, badText) {
+  const span = document.createElement("span");
+  span.className = good ? "ok" : "bad";
+  span.textContent = good ? goodText : badText;
+  return span;
+}
+
+function time(value) {
+  return value ? new Date(value).toLocaleString() : "";
+}
+
+// table builds a table of the given rows. Values are always set as text, never as HTML.
+function table(columns, rows) {
+  const t = document.createElement("table");
+  const head = t.createTHead().insertRow();
+  for (const [name] of columns) {
+    const th = document.createElement("th");
+    th.textContent = name;
+    head.appendChild(th);
+  }
+  const body = t.createTBody();
+  if (rows.length === 0) {
+    const cell = body.insertRow().insertCell();
+    cell.colSpan = columns.length;
+    cell.className = "empty";
+    cell.textContent = "Nothing to show";
+  }
+  for (const row of rows) {
+    const tr = body.insertRow();
+    for (const [, value] of columns) {
+      const cell = tr.insertCell();
+      const v = value(row);
+      if (v instanceof Node) {
+        cell.appendChild(v);
+      } else {
+        cell.textContent = v === undefined || v === null ? "" : String(v);
+      }
+    }
+  }
+  return t;
+}
+
+function heading(text) {
+  const h = document.createElement("h2");
+  h.textContent = text;
+  return h;
+}
+
+async function renderHealth(content) {
+  const h = await api("/health/details");
+  content.append(
+    heading("Health: " + h.status),
+    table(
+      [["Check", (c) => c[0]], ["Result", (c) => status(c[1] === "ok", c[1], c[1])]],
+      Object.entries(h.checks || {}),
+    ),
+    heading("Upstream MCP servers"),
+    table(
+      [
+        ["Name", (s) => s.name],
+        ["Transport", (s) => s.transport],
+        ["Healthy", (s) => status(s.healthy, "yes", "no")],
+        ["Latency", (s) => s.latency_ms + " ms"],
+        ["Consecutive failures", (s) => s.consecutive_failures],
+        ["Error", (s) => s.error],
+      ],
+      h.servers || [],
+    ),
+  );
+}
+
+async function render() {
+  const route = location.hash.replace(/^#\//, "") || "servers";
+  for (const a of $("nav").querySelectorAll("a")) {
+    a.classList.toggle("active", a.getAttribute("href") === "#/" + route);
+  }
+  const content = $("content");
+  content.replaceChildren();
+  $("error").hidden = true;
+
+  try {
+    if (route === "health") {
+      await renderHealth(content);
+      return;
+    }
+    const view = views[route] || views.servers;
+    const rows = await api(view.path);
+    content.append(heading(view.title), table(view.columns, rows || []));
+  } catch (err) {
+    $("error").textContent = err.message;
+    $("error").hidden = false;
+  }
+}
+
+function showSignIn(show) {
+  $("sign-in").hidden = !show;
+  $("sign-out").hidden = show || !token();
+}
+
+async function init() {
+  const meta = await api("/metadata").catch(() => ({}));
+  $("metadata").textContent = [meta.version, meta.mode].filter(Boolean).join(" · ");
+  // "production" is the deprecated name of the enterprise mode
+  showSignIn((meta.mode === "enterprise" || meta.mode === "production") && !token());
+
+  $("sign-in").addEventListener("submit", (e) => {
+    e.preventDefault();
+    sessionStorage.setItem(TOKEN_KEY, $("token").value.trim());
+    $("token").value = "";
+    showSignIn(false);
+    render();
+  });
+  $("sign-out").addEventListener("click", () => {
+    sessionStorage.removeItem(TOKEN_KEY);
+    showSignIn(true);
+    $("content").replaceChildren();
+  });
+  window.addEventListener("hashchange", render);
+  render();
+}
+
+init();
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestDashboardRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	get := func(s *Server, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		s, err := NewServer(&ServerOptions{})
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, http.StatusNotFound, get(s, DashboardPath+"/").Code)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		s, err := NewServer(&ServerOptions{DashboardEnabled: true})
+		testhelpers.AssertNoError(t, err)
+
+		w := get(s, DashboardPath)
+		testhelpers.AssertEqual(t, http.StatusMovedPermanently, w.Code)
+		testhelpers.AssertEqual(t, DashboardPath+"/", w.Header().Get("Location"))
+
+		// the dashboard is served without authentication, it calls the API with the user's token
+		w = get(s, DashboardPath+"/")
+		testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+		testhelpers.AssertStringContains(t, w.Header().Get("Content-Type"), "text/html")
+		testhelpers.AssertStringContains(t, w.Header().Get("Content-Security-Policy"), "default-src 'self'")
+		testhelpers.AssertStringContains(t, w.Body.String(), `<script src="app.js"></script>`)
+
+		w = get(s, DashboardPath+"/app.js")
+		testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+		testhelpers.AssertStringContains(t, w.Header().Get("Content-Type"), "javascript")
+		testhelpers.AssertStringContains(t, w.Body.String(), `const API = "/api/v1";`)
+
+		testhelpers.AssertEqual(t, http.StatusNotFound, get(s, DashboardPath+"/missing.js").Code)
+	})
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// conditionalGET is middleware that adds an ETag to successful responses and honors the If-None-Match
+// request header, so that clients polling an endpoint don't have to download an unchanged response again.
+// The ETag is derived from the response body, which is buffered until the handler returns.
+// It is a weak ETag because the response may be compressed differently depending on the client.
+func conditionalGET() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		original := c.Writer
+		w := &bufferedResponseWriter{ResponseWriter: original}
+		c.Writer = w
+		c.Next()
+		c.Writer = original
+
+		if w.Status() != http.StatusOK {
+			_, _ = original.Write(w.body.Bytes())
+			return
+		}
+
+		sum := sha256.Sum256(w.body.Bytes())
+		etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+		original.Header().Set("ETag", etag)
+
+		if etagMatches(c.GetHeader("If-None-Match"), etag) {
+			h := original.Header()
+			h.Del("Content-Type")
+			h.Del("Content-Length")
+			original.WriteHeader(http.StatusNotModified)
+			original.WriteHeaderNow()
+			return
+		}
+		_, _ = original.Write(w.body.Bytes())
+	}
+}
+
+// etagMatches returns true if the If-None-Match header value matches the given ETag.
+// As required for If-None-Match, ETags are compared weakly, ie, ignoring the weakness indicator.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// bufferedResponseWriter holds back the response body until the handler returns.
+type bufferedResponseWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedResponseWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestEtagMatches(t *testing.T) {
+	etag := `W/"abc"`
+	testhelpers.AssertFalse(t, etagMatches("", etag), "empty header should not match")
+	testhelpers.AssertTrue(t, etagMatches(`W/"abc"`, etag), "identical ETag should match")
+	testhelpers.AssertTrue(t, etagMatches(`"abc"`, etag), "strong form of the ETag should match")
+	testhelpers.AssertTrue(t, etagMatches(`"xyz", W/"abc"`, etag), "ETag in a list should match")
+	testhelpers.AssertTrue(t, etagMatches("*", etag), "wildcard should match")
+	testhelpers.AssertFalse(t, etagMatches(`W/"xyz"`, etag), "different ETag should not match")
+}
+
+func TestConditionalGET(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tools := []string{"calculator__add"}
+	router := gin.New()
+	router.GET("/tools", conditionalGET(), func(c *gin.Context) {
+		c.JSON(http.StatusOK, tools)
+	})
+	router.GET("/broken", conditionalGET(), func(c *gin.Context) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "boom"})
+	})
+
+	do := func(path, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do("/tools", "")
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+	testhelpers.AssertEqual(t, `["calculator__add"]`, w.Body.String())
+	etag := w.Header().Get("ETag")
+	testhelpers.AssertTrue(t, etag != "", "Expected an ETag in the response")
+
+	// unchanged response
+	w = do("/tools", etag)
+	testhelpers.AssertEqual(t, http.StatusNotModified, w.Code)
+	testhelpers.AssertEqual(t, 0, w.Body.Len())
+	testhelpers.AssertEqual(t, etag, w.Header().Get("ETag"))
+
+	// changed response
+	tools = append(tools, "calculator__subtract")
+	w = do("/tools", etag)
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+	testhelpers.AssertEqual(t, `["calculator__add","calculator__subtract"]`, w.Body.String())
+	testhelpers.AssertTrue(t, w.Header().Get("ETag") != etag, "Expected the ETag to change along with the response")
+
+	// errors are passed through without an ETag
+	w = do("/broken", "*")
+	testhelpers.AssertEqual(t, http.StatusInternalServerError, w.Code)
+	testhelpers.AssertEqual(t, "", w.Header().Get("ETag"))
+	testhelpers.AssertStringContains(t, w.Body.String(), "boom")
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+// eventStreamKeepAliveInterval is how often a comment is sent on an idle event stream
+// so that proxies and load balancers don't close the connection.
+const eventStreamKeepAliveInterval = 30 * time.Second
+
+// eventsHandler streams registry change events to the client as server-sent events (SSE).
+// The stream stays open until the client disconnects.
+func (s *Server) eventsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if s.eventBroker == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "registry events are not available"})
+			return
+		}
+
+		stream, unsubscribe := s.eventBroker.Subscribe()
+		defer unsubscribe()
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		c.Header("X-Accel-Buffering", "no")
+		c.Status(http.StatusOK)
+		c.Writer.Flush()
+
+		keepAlive := time.NewTicker(eventStreamKeepAliveInterval)
+		defer keepAlive.Stop()
+
+		c.Stream(func(w io.Writer) bool {
+			select {
+			case <-c.Request.Context().Done():
+				return false
+			case e, ok := <-stream:
+				if !ok {
+					return false
+				}
+				c.Render(-1, sse.Event{Id: strconv.FormatUint(e.ID, 10), Event: string(e.Type), Data: e})
+				return true
+			case <-keepAlive.C:
+				_, err := io.WriteString(w, ": keep-alive\n\n")
+				return err == nil
+			}
+		})
+	}
+}
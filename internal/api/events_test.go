@@ -0,0 +1,82 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/service/events"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestEventsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	broker := events.NewBroker(logger.NewNop())
+	s := &Server{eventBroker: broker}
+	router := gin.New()
+	router.GET("/events", s.eventsHandler())
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/events", nil)
+	testhelpers.AssertNoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to connect to event stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	testhelpers.AssertEqual(t, http.StatusOK, resp.StatusCode)
+	testhelpers.AssertEqual(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// the handler has subscribed by the time the response headers are received
+	broker.Publish(types.RegistryEventToolsDisabled, "calculator", []string{"calculator__add", "calculator__sub"})
+
+	var id, event, data string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+		switch {
+		case strings.HasPrefix(line, "id:"):
+			id = strings.TrimPrefix(line, "id:")
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimPrefix(line, "event:")
+		case strings.HasPrefix(line, "data:"):
+			data = strings.TrimPrefix(line, "data:")
+		}
+	}
+
+	testhelpers.AssertEqual(t, "1", id)
+	testhelpers.AssertEqual(t, string(types.RegistryEventToolsDisabled), event)
+
+	var e types.RegistryEvent
+	testhelpers.AssertNoError(t, json.Unmarshal([]byte(data), &e))
+	testhelpers.AssertEqual(t, types.RegistryEventToolsDisabled, e.Type)
+	testhelpers.AssertEqual(t, "calculator", e.Entity)
+	testhelpers.AssertEqual(t, 2, len(e.Affected))
+}
+
+func TestEventsHandlerWithoutBroker(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	s := &Server{}
+	router := gin.New()
+	router.GET("/events", s.eventsHandler())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events", nil))
+	testhelpers.AssertEqual(t, http.StatusServiceUnavailable, w.Code)
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/migrations"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/mcpjungle/mcpjungle/pkg/version"
+)
+
+// dbPingTimeout is the maximum time allowed for the database to respond to a readiness check
+const dbPingTimeout = 2 * time.Second
+
+// Names of the readiness checks
+const (
+	readinessCheckDatabase   = "database"
+	readinessCheckMigrations = "migrations"
+	readinessCheckMCPProxy   = "mcp_proxy"
+)
+
+// livenessHandler reports that the server process is alive.
+// It never depends on anything external, so it only fails if the process is unable to serve requests.
+func livenessHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": types.HealthStatusOK})
+}
+
+// readinessHandler reports whether the server is able to serve traffic.
+// It responds with 503 Service Unavailable if any readiness check fails,
+// unless the server is only degraded, ie, it serves the MCP proxy while the database is down.
+func (s *Server) readinessHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		resp := s.checkReadiness(c)
+		status := http.StatusOK
+		if resp.Status == types.HealthStatusUnavailable {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, resp)
+	}
+}
+
+// healthDetailsHandler reports the readiness checks along with the health of all upstream MCP servers.
+// Unlike the readiness endpoint, it always responds with 200 OK as long as the details could be gathered.
+func (s *Server) healthDetailsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		resp := &types.HealthDetails{
+			ReadinessResponse: *s.checkReadiness(c),
+			Version:           version.GetVersion(),
+		}
+
+		cfg, err := s.configService.GetConfig()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get server config: " + err.Error()})
+			return
+		}
+		resp.Mode = string(cfg.Mode)
+		resp.Initialized = cfg.Initialized
+
+		servers, err := s.mcpService.CheckServersHealth(c)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check MCP servers: " + err.Error()})
+			return
+		}
+		resp.Servers = servers
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// metadataHandler describes the server: its version, and its mode if it is initialized.
+// It doesn't require authentication, and reports what it can even if the database is unavailable.
+func (s *Server) metadataHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		m := &types.ServerMetadata{
+			Version: version.GetVersion(),
+		}
+		if s.configService != nil {
+			if cfg, err := s.configService.GetConfig(); err == nil && cfg.Initialized {
+				m.Mode = string(cfg.Mode)
+				m.Initialized = true
+			}
+		}
+		c.JSON(http.StatusOK, m)
+	}
+}
+
+// checkReadiness runs all readiness checks.
+// Server initialization is deliberately not a readiness check, because an enterprise server can only be
+// initialized after it starts receiving traffic.
+func (s *Server) checkReadiness(ctx context.Context) *types.ReadinessResponse {
+	resp := &types.ReadinessResponse{
+		Status: types.HealthStatusOK,
+		Checks: map[string]string{
+			readinessCheckDatabase:   types.HealthCheckOK,
+			readinessCheckMigrations: types.HealthCheckOK,
+			readinessCheckMCPProxy:   types.HealthCheckOK,
+		},
+	}
+	fail := func(check, reason string) {
+		resp.Status = types.HealthStatusUnavailable
+		resp.Checks[check] = reason
+	}
+
+	dbReachable := true
+	if err := s.pingDB(ctx); err != nil {
+		dbReachable = false
+		fail(readinessCheckDatabase, err.Error())
+		fail(readinessCheckMigrations, "skipped because the database is unreachable")
+	} else if err := migrations.CheckVersion(s.db); err != nil {
+		fail(readinessCheckMigrations, err.Error())
+	}
+
+	if s.mcpService == nil || s.mcpProxyServer == nil || s.sseMcpProxyServer == nil {
+		fail(readinessCheckMCPProxy, "MCP proxy server is not initialized")
+	} else if !dbReachable && s.dbMonitor != nil {
+		// the proxy keeps serving tool calls while the database is down, so the server must keep receiving traffic
+		resp.Status = types.HealthStatusDegraded
+	}
+
+	return resp
+}
+
+// pingDB verifies that the database is reachable.
+func (s *Server) pingDB(ctx context.Context) error {
+	if s.db == nil {
+		return errors.New("database connection is not configured")
+	}
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, dbPingTimeout)
+	defer cancel()
+	return sqlDB.PingContext(ctx)
+}
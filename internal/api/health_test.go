@@ -0,0 +1,189 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/migrations"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/config"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/gorm"
+)
+
+// newHealthTestServer creates a fully wired server backed by the given database.
+func newHealthTestServer(t *testing.T, db *gorm.DB) *Server {
+	t.Helper()
+
+	proxy := server.NewMCPServer("proxy", "test")
+	sseProxy := server.NewMCPServer("sse proxy", "test")
+	mcpService, err := mcp.NewMCPService(db, proxy, sseProxy, telemetry.NewNoopCustomMetrics(), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+
+	s, err := NewServer(&ServerOptions{
+		Port:              "8080",
+		MCPProxyServer:    proxy,
+		SseMcpProxyServer: sseProxy,
+		DB:                db,
+		MCPService:        mcpService,
+		ConfigService:     config.NewServerConfigService(db),
+	})
+	testhelpers.AssertNoError(t, err)
+	return s
+}
+
+func getReadiness(t *testing.T, s *Server) (int, *types.ReadinessResponse) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	s.router.ServeHTTP(w, req)
+
+	var resp types.ReadinessResponse
+	testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return w.Code, &resp
+}
+
+func TestLiveness(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	s, err := NewServer(&ServerOptions{Port: "8080"})
+	testhelpers.AssertNoError(t, err)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	s.router.ServeHTTP(w, req)
+
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+	testhelpers.AssertEqual(t, `{"status":"ok"}`, w.Body.String())
+}
+
+func TestReadiness(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("ready", func(t *testing.T) {
+		db, err := testhelpers.CreateTestDB()
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertNoError(t, migrations.Migrate(db))
+
+		code, resp := getReadiness(t, newHealthTestServer(t, db))
+		testhelpers.AssertEqual(t, http.StatusOK, code)
+		testhelpers.AssertEqual(t, types.HealthStatusOK, resp.Status)
+		for _, check := range []string{readinessCheckDatabase, readinessCheckMigrations, readinessCheckMCPProxy} {
+			testhelpers.AssertEqual(t, types.HealthCheckOK, resp.Checks[check])
+		}
+	})
+
+	t.Run("migrations not applied", func(t *testing.T) {
+		db, err := testhelpers.CreateTestDB()
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertNoError(t, migrations.Migrate(db))
+		s := newHealthTestServer(t, db)
+		_, err = migrations.Rollback(db, 1)
+		testhelpers.AssertNoError(t, err)
+
+		code, resp := getReadiness(t, s)
+		testhelpers.AssertEqual(t, http.StatusServiceUnavailable, code)
+		testhelpers.AssertEqual(t, types.HealthStatusUnavailable, resp.Status)
+		testhelpers.AssertEqual(t, types.HealthCheckOK, resp.Checks[readinessCheckDatabase])
+		testhelpers.AssertStringContains(t, resp.Checks[readinessCheckMigrations], "is not applied yet")
+	})
+
+	t.Run("degraded while database unreachable", func(t *testing.T) {
+		db, err := testhelpers.CreateTestDB()
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertNoError(t, migrations.Migrate(db))
+		s := newHealthTestServer(t, db)
+		s.dbMonitor = newUnavailableDBMonitor(t)
+		sqlDB, err := db.DB()
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertNoError(t, sqlDB.Close())
+
+		// the proxy keeps serving traffic, so the server remains ready
+		code, resp := getReadiness(t, s)
+		testhelpers.AssertEqual(t, http.StatusOK, code)
+		testhelpers.AssertEqual(t, types.HealthStatusDegraded, resp.Status)
+		testhelpers.AssertTrue(t, resp.Checks[readinessCheckDatabase] != types.HealthCheckOK, "Expected database check to fail")
+		testhelpers.AssertEqual(t, types.HealthCheckOK, resp.Checks[readinessCheckMCPProxy])
+	})
+
+	t.Run("database unreachable and proxy not initialized", func(t *testing.T) {
+		s, err := NewServer(&ServerOptions{Port: "8080"})
+		testhelpers.AssertNoError(t, err)
+
+		code, resp := getReadiness(t, s)
+		testhelpers.AssertEqual(t, http.StatusServiceUnavailable, code)
+		testhelpers.AssertTrue(t, resp.Checks[readinessCheckDatabase] != types.HealthCheckOK, "Expected database check to fail")
+		testhelpers.AssertTrue(t, resp.Checks[readinessCheckMigrations] != types.HealthCheckOK, "Expected migrations check to fail")
+		testhelpers.AssertTrue(t, resp.Checks[readinessCheckMCPProxy] != types.HealthCheckOK, "Expected MCP proxy check to fail")
+	})
+}
+
+func TestHealthDetails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, err := testhelpers.CreateTestDB()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, migrations.Migrate(db))
+	s := newHealthTestServer(t, db)
+	testhelpers.AssertNoError(t, s.InitDev())
+
+	unreachable := &model.McpServer{
+		Name:      "unreachable",
+		Transport: types.TransportStdio,
+		Config:    []byte(`{"command": "mcpjungle-nonexistent-command"}`),
+	}
+	testhelpers.AssertNoError(t, db.Create(unreachable).Error)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/health/details", nil)
+	s.router.ServeHTTP(w, req)
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+
+	var resp types.HealthDetails
+	testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	testhelpers.AssertEqual(t, types.HealthStatusOK, resp.Status)
+	testhelpers.AssertEqual(t, string(model.ModeDev), resp.Mode)
+	testhelpers.AssertTrue(t, resp.Initialized, "Expected server to be initialized")
+	testhelpers.AssertEqual(t, 1, len(resp.Servers))
+	testhelpers.AssertEqual(t, "unreachable", resp.Servers[0].Name)
+	testhelpers.AssertFalse(t, resp.Servers[0].Healthy, "Expected upstream server to be unhealthy")
+}
+
+func TestMetadata(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	getMetadata := func(t *testing.T, s *Server) *types.ServerMetadata {
+		t.Helper()
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metadata", nil))
+		testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+
+		var m types.ServerMetadata
+		testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &m))
+		return &m
+	}
+
+	db, err := testhelpers.CreateTestDB()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, migrations.Migrate(db))
+	s := newHealthTestServer(t, db)
+
+	m := getMetadata(t, s)
+	testhelpers.AssertFalse(t, m.Initialized, "Expected the server not to be initialized")
+	testhelpers.AssertEqual(t, "", m.Mode)
+
+	testhelpers.AssertNoError(t, s.InitDev())
+	m = getMetadata(t, s)
+	testhelpers.AssertTrue(t, m.Initialized, "Expected the server to be initialized")
+	testhelpers.AssertEqual(t, string(model.ModeDev), m.Mode)
+	testhelpers.AssertTrue(t, m.Version != "", "Expected the version to be set")
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+)
+
+// HTTPServerConfig holds the timeouts & limits of the HTTP server.
+// A zero timeout means no timeout.
+type HTTPServerConfig struct {
+	// ReadTimeout is the maximum duration for reading an entire request, including the body.
+	ReadTimeout time.Duration
+	// ReadHeaderTimeout is the maximum duration for reading the headers of a request.
+	ReadHeaderTimeout time.Duration
+	// WriteTimeout is the maximum duration for writing a response, counted from when the request headers are read.
+	// It doesn't apply to streaming endpoints, see StreamWriteTimeout.
+	WriteTimeout time.Duration
+	// IdleTimeout is the maximum duration to wait for the next request on a keep-alive connection.
+	IdleTimeout time.Duration
+	// MaxHeaderBytes is the maximum size of the headers of a request.
+	MaxHeaderBytes int
+
+	// StreamWriteTimeout is the maximum duration of a single write on a streaming endpoint,
+	// ie, the MCP proxy endpoints and the registry events stream.
+	// These endpoints keep responses open for a long time, so WriteTimeout would cut them short.
+	// Instead, a stream is only closed if the client doesn't accept a write within this duration.
+	StreamWriteTimeout time.Duration
+
+	// CompressionMinBytes is the minimum size of a registry API response to be compressed,
+	// if the client accepts a compressed response. Zero disables compression.
+	CompressionMinBytes int
+
+	// StreamResponseMinBytes is the minimum size of a tool's response to be streamed to the client
+	// while it is being encoded, instead of being encoded in memory as a whole first. Zero disables streaming.
+	StreamResponseMinBytes int
+}
+
+// DefaultHTTPServerConfig returns the HTTP server configuration used unless the user overrides it.
+// The write timeout leaves enough time for slow tool calls made via the API,
+// longer calls should be made asynchronously.
+func DefaultHTTPServerConfig() HTTPServerConfig {
+	return HTTPServerConfig{
+		ReadTimeout:        1 * time.Minute,
+		ReadHeaderTimeout:  10 * time.Second,
+		WriteTimeout:       5 * time.Minute,
+		IdleTimeout:        2 * time.Minute,
+		MaxHeaderBytes:     http.DefaultMaxHeaderBytes,
+		StreamWriteTimeout: 30 * time.Second,
+
+		CompressionMinBytes:    1024,
+		StreamResponseMinBytes: 1 << 20,
+	}
+}
+
+// newHTTPServer creates the HTTP server serving the given handler with the given configuration.
+func newHTTPServer(addr string, handler http.Handler, conf HTTPServerConfig) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadTimeout:       conf.ReadTimeout,
+		ReadHeaderTimeout: conf.ReadHeaderTimeout,
+		WriteTimeout:      conf.WriteTimeout,
+		IdleTimeout:       conf.IdleTimeout,
+		MaxHeaderBytes:    conf.MaxHeaderBytes,
+	}
+}
+
+// streamingEndpoint is middleware for endpoints that keep their responses open for a long time.
+// It lifts the server's write timeout for the request and instead applies the stream write timeout
+// to every individual write.
+//
+// Long-lived GET streams (eg- SSE streams & the registry events stream) are ended as soon as the server
+// starts draining, so that their clients reconnect to another instance instead of holding up the shutdown.
+// Other requests, like tool calls whose results are streamed, are left to complete.
+func (s *Server) streamingEndpoint() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if s.draining != nil && c.Request.Method == http.MethodGet {
+			ctx, cancel := context.WithCancel(c.Request.Context())
+			defer cancel()
+			stop := context.AfterFunc(s.draining, cancel)
+			defer stop()
+			c.Request = c.Request.WithContext(ctx)
+		}
+
+		rc := http.NewResponseController(c.Writer)
+		if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+			if !errors.Is(err, http.ErrNotSupported) {
+				s.logger.Warn(
+					"failed to lift the write deadline of streaming request",
+					logger.String("path", c.Request.URL.Path), logger.ErrorField(err),
+				)
+			}
+			c.Next()
+			return
+		}
+
+		if s.httpConfig.StreamWriteTimeout > 0 {
+			c.Writer = &deadlineResponseWriter{
+				ResponseWriter: c.Writer,
+				rc:             rc,
+				timeout:        s.httpConfig.StreamWriteTimeout,
+			}
+		}
+		c.Next()
+	}
+}
+
+// deadlineResponseWriter sets a new write deadline before every write to the response.
+type deadlineResponseWriter struct {
+	gin.ResponseWriter
+	rc      *http.ResponseController
+	timeout time.Duration
+}
+
+// Unwrap returns the underlying response writer, for use by http.ResponseController.
+func (w *deadlineResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *deadlineResponseWriter) extendDeadline() {
+	// the deadline can't be set once the handler has hijacked the connection, in which case it's not needed anyway
+	_ = w.rc.SetWriteDeadline(time.Now().Add(w.timeout))
+}
+
+func (w *deadlineResponseWriter) Write(data []byte) (int, error) {
+	w.extendDeadline()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *deadlineResponseWriter) WriteString(s string) (int, error) {
+	w.extendDeadline()
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *deadlineResponseWriter) Flush() {
+	w.extendDeadline()
+	w.ResponseWriter.Flush()
+}
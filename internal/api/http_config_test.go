@@ -0,0 +1,65 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestStreamingEndpointLiftsWriteTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	conf := HTTPServerConfig{WriteTimeout: 100 * time.Millisecond, StreamWriteTimeout: time.Second}
+	s := &Server{httpConfig: conf, logger: logger.NewNop()}
+
+	// both handlers respond after the server's write timeout has passed
+	slowHandler := func(c *gin.Context) {
+		c.Writer.WriteHeader(http.StatusOK)
+		c.Writer.Flush()
+		time.Sleep(300 * time.Millisecond)
+		_, _ = c.Writer.WriteString("done")
+	}
+	router := gin.New()
+	router.GET("/stream", s.streamingEndpoint(), slowHandler)
+	router.GET("/regular", slowHandler)
+
+	ts := httptest.NewUnstartedServer(router)
+	ts.Config = newHTTPServer("", router, conf)
+	ts.Start()
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/stream")
+	if err != nil {
+		t.Fatalf("request to streaming endpoint failed: %v", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, "done", string(body))
+
+	// the write timeout still applies to other endpoints
+	resp, err = http.Get(ts.URL + "/regular")
+	if err == nil {
+		body, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+	}
+	testhelpers.AssertTrue(t, err != nil || string(body) != "done", "Expected the write timeout to cut the response short")
+}
+
+func TestNewHTTPServer(t *testing.T) {
+	conf := DefaultHTTPServerConfig()
+	srv := newHTTPServer(":8080", http.NotFoundHandler(), conf)
+
+	testhelpers.AssertEqual(t, ":8080", srv.Addr)
+	testhelpers.AssertEqual(t, conf.ReadTimeout, srv.ReadTimeout)
+	testhelpers.AssertEqual(t, conf.ReadHeaderTimeout, srv.ReadHeaderTimeout)
+	testhelpers.AssertEqual(t, conf.WriteTimeout, srv.WriteTimeout)
+	testhelpers.AssertEqual(t, conf.IdleTimeout, srv.IdleTimeout)
+	testhelpers.AssertEqual(t, conf.MaxHeaderBytes, srv.MaxHeaderBytes)
+}
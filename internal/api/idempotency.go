@@ -0,0 +1,90 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/service/idempotency"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+)
+
+// IdempotencyKeyHeader is the request header carrying the idempotency key chosen by the client
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// idempotentReplayedHeader is set on the responses replayed for a request sent again with the same idempotency key
+const idempotentReplayedHeader = "Idempotent-Replayed"
+
+// idempotent is middleware that makes requests sent with an Idempotency-Key header safe to retry.
+// The response to the first request sent with a key is recorded and replayed for the next ones,
+// so that a retried registration doesn't fail because the server is already registered.
+// Server errors are not recorded, the request can be retried with the same key after one.
+// Requests without the header are processed as usual.
+func (s *Server) idempotent() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" || s.idempotencyService == nil {
+			c.Next()
+			return
+		}
+		if len(key) > idempotency.MaxKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "the idempotency key is too long"})
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "failed to read request body: " + err.Error()})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		rec, recorded, err := s.idempotencyService.Begin(&idempotency.Request{
+			Caller: currentUsername(c),
+			Key:    key,
+			Method: c.Request.Method,
+			Path:   c.Request.URL.Path,
+			Body:   body,
+		})
+		switch {
+		case errors.Is(err, idempotency.ErrKeyReused):
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, idempotency.ErrInProgress):
+			c.Header("Retry-After", "1")
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		case err != nil:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if recorded != nil {
+			c.Header(idempotentReplayedHeader, "true")
+			c.Data(recorded.StatusCode, recorded.ContentType, recorded.Body)
+			c.Abort()
+			return
+		}
+
+		original := c.Writer
+		w := &bufferedResponseWriter{ResponseWriter: original}
+		c.Writer = w
+		c.Next()
+		c.Writer = original
+
+		if w.Status() >= http.StatusInternalServerError {
+			err = s.idempotencyService.Abandon(rec)
+		} else {
+			err = s.idempotencyService.Complete(rec, &idempotency.Response{
+				StatusCode:  w.Status(),
+				ContentType: w.Header().Get("Content-Type"),
+				Body:        w.body.Bytes(),
+			})
+		}
+		if err != nil {
+			s.logger.Warn("failed to record idempotent request", logger.String("key", key), logger.ErrorField(err))
+		}
+		_, _ = original.Write(w.body.Bytes())
+	}
+}
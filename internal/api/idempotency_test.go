@@ -0,0 +1,88 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/service/idempotency"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestIdempotent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+	s := &Server{idempotencyService: idempotency.NewService(setup.DB), logger: logger.NewNop()}
+
+	// the handler registers servers, it fails if one is registered twice
+	registered := map[string]bool{}
+	calls := 0
+	router := gin.New()
+	router.POST("/servers", s.idempotent(), func(c *gin.Context) {
+		calls++
+		var input struct{ Name string }
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if input.Name == "flaky" {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "upstream unavailable"})
+			return
+		}
+		if registered[input.Name] {
+			c.JSON(http.StatusConflict, gin.H{"error": "server already exists"})
+			return
+		}
+		registered[input.Name] = true
+		c.JSON(http.StatusCreated, gin.H{"name": input.Name})
+	})
+
+	post := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/servers", strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("retry gets the recorded response", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			w := post("key-1", `{"name":"time"}`)
+			testhelpers.AssertEqual(t, http.StatusCreated, w.Code)
+			testhelpers.AssertEqual(t, `{"name":"time"}`, w.Body.String())
+			testhelpers.AssertStringContains(t, w.Header().Get("Content-Type"), "application/json")
+			testhelpers.AssertEqual(t, i == 1, w.Header().Get(idempotentReplayedHeader) == "true")
+		}
+		testhelpers.AssertEqual(t, 1, calls)
+	})
+
+	t.Run("key reused for another request", func(t *testing.T) {
+		w := post("key-1", `{"name":"github"}`)
+		testhelpers.AssertEqual(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("server errors are not recorded", func(t *testing.T) {
+		calls = 0
+		post("key-2", `{"name":"flaky"}`)
+		w := post("key-2", `{"name":"flaky"}`)
+		testhelpers.AssertEqual(t, http.StatusInternalServerError, w.Code)
+		testhelpers.AssertEqual(t, 2, calls)
+	})
+
+	t.Run("requests without a key are processed as usual", func(t *testing.T) {
+		w := post("", `{"name":"time"}`)
+		testhelpers.AssertEqual(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("key too long", func(t *testing.T) {
+		w := post(strings.Repeat("k", idempotency.MaxKeyLength+1), `{"name":"fetch"}`)
+		testhelpers.AssertEqual(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/invocation"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// listInvocationsHandler returns the recorded tool invocations, most recent first.
+// The invocations can be filtered by tool, caller, MCP client, outcome and time range.
+func (s *Server) listInvocationsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if s.invocationHistory == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "tool invocation history is not available"})
+			return
+		}
+
+		filter := invocation.ListFilter{
+			Tool:    c.Query("tool"),
+			Caller:  c.Query("caller"),
+			Client:  c.Query("client"),
+			Outcome: types.InvocationOutcome(c.Query("outcome")),
+		}
+		if filter.Outcome != "" &&
+			filter.Outcome != types.InvocationOutcomeSuccess && filter.Outcome != types.InvocationOutcomeError {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf(
+					"invalid outcome: must be '%s' or '%s'", types.InvocationOutcomeSuccess, types.InvocationOutcomeError,
+				),
+			})
+			return
+		}
+		for _, p := range []struct {
+			name  string
+			value *time.Time
+		}{{"since", &filter.Since}, {"until", &filter.Until}} {
+			v := c.Query(p.name)
+			if v == "" {
+				continue
+			}
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid %s: must be an RFC 3339 timestamp", p.name)})
+				return
+			}
+			*p.value = t
+		}
+
+		page, ok := pageQuery(c)
+		if !ok {
+			return
+		}
+
+		records, total, err := s.invocationHistory.List(filter, page)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		setTotalCount(c, total)
+
+		invocations := make([]types.ToolInvocation, len(records))
+		for i, r := range records {
+			invocations[i] = toAPIInvocation(&r)
+		}
+		c.JSON(http.StatusOK, invocations)
+	}
+}
+
+// toAPIInvocation converts a tool invocation model to its API representation.
+func toAPIInvocation(inv *model.ToolInvocation) types.ToolInvocation {
+	return types.ToolInvocation{
+		ID:                 inv.ID,
+		Tool:               inv.ToolName,
+		Caller:             inv.Caller,
+		Client:             inv.Client,
+		Outcome:            inv.Outcome,
+		Error:              inv.Error,
+		DurationMs:         inv.DurationMs,
+		Arguments:          inv.Arguments,
+		ArgumentsTruncated: inv.ArgumentsTruncated,
+		InvokedAt:          inv.CreatedAt,
+	}
+}
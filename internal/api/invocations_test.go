@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/invocation"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestListInvocationsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	history := invocation.NewHistoryService(setup.DB, 10, logger.NewNop())
+	ctx := context.WithValue(context.Background(), "client", &model.McpClient{Name: "cursor"})
+	history.RecordToolInvocation(
+		ctx, "git__status", map[string]any{"path": "."}, telemetry.ToolCallOutcomeSuccess, nil, time.Millisecond,
+	)
+	history.RecordToolInvocation(ctx, "git__push", nil, telemetry.ToolCallOutcomeError, nil, time.Millisecond)
+
+	s := &Server{invocationHistory: history}
+	router := gin.New()
+	router.GET("/invocations", s.listInvocationsHandler())
+
+	do := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/invocations"+query, nil))
+		return w
+	}
+
+	w := do("?client=cursor&outcome=success&since=2000-01-01T00:00:00Z")
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+	var invocations []types.ToolInvocation
+	testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &invocations))
+	testhelpers.AssertEqual(t, 1, len(invocations))
+	testhelpers.AssertEqual(t, "git__status", invocations[0].Tool)
+	testhelpers.AssertEqual(t, "cursor", invocations[0].Caller)
+	testhelpers.AssertEqual(t, "cursor", invocations[0].Client)
+	testhelpers.AssertEqual(t, `{"path":"."}`, invocations[0].Arguments)
+
+	w = do("?outcome=maybe")
+	testhelpers.AssertEqual(t, http.StatusBadRequest, w.Code)
+
+	w = do("?until=yesterday")
+	testhelpers.AssertEqual(t, http.StatusBadRequest, w.Code)
+	testhelpers.AssertStringContains(t, w.Body.String(), "invalid until")
+}
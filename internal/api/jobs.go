@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/job"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// getJobHandler returns the status of an asynchronous tool invocation, along with its result once it succeeds.
+// In enterprise mode, standard users can only view the jobs they started.
+func (s *Server) getJobHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		j, err := s.jobService.GetJob(c.Param("id"))
+		if err != nil {
+			if errors.Is(err, job.ErrJobNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get job: " + err.Error()})
+			return
+		}
+
+		if u, ok := c.Get("user"); ok {
+			// respond with Not Found so that users can't learn about the existence of other users' jobs
+			if u, ok := u.(*model.User); ok && u.Role != types.UserRoleAdmin && u.Username != j.CreatedBy {
+				c.JSON(http.StatusNotFound, gin.H{"error": job.ErrJobNotFound.Error()})
+				return
+			}
+		}
+
+		result, err := j.GetResult()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to decode job result: " + err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, toAPIJob(j, result))
+	}
+}
+
+// toAPIJob converts a job model to its API representation.
+func toAPIJob(j *model.ToolInvocationJob, result *types.ToolInvokeResult) *types.ToolInvocationJob {
+	return &types.ToolInvocationJob{
+		ID:          j.JobID,
+		Tool:        j.ToolName,
+		Status:      j.Status,
+		Result:      result,
+		Error:       j.Error,
+		CreatedAt:   j.CreatedAt,
+		StartedAt:   j.StartedAt,
+		CompletedAt: j.CompletedAt,
+	}
+}
+
+// currentUsername returns the username of the authenticated user, or an empty string if there is none,
+// which is the case in development mode.
+func currentUsername(c *gin.Context) string {
+	u, ok := c.Get("user")
+	if !ok {
+		return ""
+	}
+	if u, ok := u.(*model.User); ok {
+		return u.Username
+	}
+	return ""
+}
+
+// jobContext returns a context for a job started by the request, which keeps the values the job needs from the
+// request once the request is done. The job must not use c itself, since gin reuses it for other requests.
+func jobContext(c *gin.Context) context.Context {
+	ctx := c.Request.Context()
+	if u, ok := c.Get("user"); ok {
+		ctx = context.WithValue(ctx, "user", u)
+	}
+	return ctx
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/job"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+type fakeToolInvoker struct {
+	// caller is the user found in the context of the last invocation
+	caller *string
+}
+
+func (f fakeToolInvoker) InvokeTool(ctx context.Context, name string, args map[string]any) (*types.ToolInvokeResult, error) {
+	if u, ok := ctx.Value("user").(*model.User); ok && f.caller != nil {
+		*f.caller = u.Username
+	}
+	return &types.ToolInvokeResult{Content: []map[string]any{{"type": "text", "text": "report for " + args["year"].(string)}}}, nil
+}
+
+func TestAsyncToolInvocation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	var caller string
+	jobService, err := job.NewJobService(setup.DB, fakeToolInvoker{caller: &caller}, job.DefaultTimeout, logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+	s := &Server{jobService: jobService}
+
+	// the authenticated user is supplied via a header to simulate the auth middleware
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if name := c.GetHeader("X-Test-User"); name != "" {
+			c.Set("user", &model.User{Username: name, Role: types.UserRole(c.GetHeader("X-Test-Role"))})
+		}
+	})
+	router.POST("/tools/invoke", s.invokeToolHandler())
+	router.GET("/jobs/:id", s.getJobHandler())
+
+	do := func(method, path, body, user string, role types.UserRole) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if user != "" {
+			req.Header.Set("X-Test-User", user)
+			req.Header.Set("X-Test-Role", string(role))
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(http.MethodPost, "/tools/invoke?async=true", `{"name": "reports__annual", "year": "2025"}`, "alice", types.UserRoleUser)
+	testhelpers.AssertEqual(t, http.StatusAccepted, w.Code)
+
+	var started types.ToolInvocationJob
+	testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &started))
+	testhelpers.AssertTrue(t, started.ID != "", "Expected a job ID")
+	testhelpers.AssertEqual(t, "reports__annual", started.Tool)
+	testhelpers.AssertEqual(t, types.JobStatusPending, started.Status)
+
+	jobService.Wait()
+
+	w = do(http.MethodGet, "/jobs/"+started.ID, "", "alice", types.UserRoleUser)
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+	var completed types.ToolInvocationJob
+	testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &completed))
+	testhelpers.AssertEqual(t, types.JobStatusSucceeded, completed.Status)
+	testhelpers.AssertEqual(t, "report for 2025", completed.Result.Content[0]["text"])
+	testhelpers.AssertNotNil(t, completed.CompletedAt)
+	testhelpers.AssertEqual(t, "alice", caller)
+
+	// other standard users can't see the job, but admins can
+	w = do(http.MethodGet, "/jobs/"+started.ID, "", "bob", types.UserRoleUser)
+	testhelpers.AssertEqual(t, http.StatusNotFound, w.Code)
+	w = do(http.MethodGet, "/jobs/"+started.ID, "", "admin", types.UserRoleAdmin)
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+
+	w = do(http.MethodGet, "/jobs/unknown", "", "", "")
+	testhelpers.AssertEqual(t, http.StatusNotFound, w.Code)
+
+	w = do(http.MethodPost, "/tools/invoke?async=maybe", `{"name": "reports__annual"}`, "", "")
+	testhelpers.AssertEqual(t, http.StatusBadRequest, w.Code)
+}
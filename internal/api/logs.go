@@ -0,0 +1,114 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// logsHandler returns the recent entries of the server's logs, oldest first.
+// The entries can be filtered by MCP server, via the "server" query param, and by time, via the "since" query param.
+// If the "follow" query param is true, the entries are streamed as server-sent events (SSE) instead,
+// followed by new entries as they are logged, until the client disconnects.
+func (s *Server) logsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if s.logBuffer == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "server logs are not available"})
+			return
+		}
+
+		server := c.Query("server")
+		var since time.Time
+		if v := c.Query("since"); v != "" {
+			var err error
+			if since, err = time.Parse(time.RFC3339, v); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid since: must be an RFC 3339 timestamp"})
+				return
+			}
+		}
+		follow := false
+		if v := c.Query("follow"); v != "" {
+			var err error
+			if follow, err = strconv.ParseBool(v); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid follow: must be true or false"})
+				return
+			}
+		}
+		match := func(e logger.Entry) bool {
+			if server != "" && e.Fields["server"] != server {
+				return false
+			}
+			return since.IsZero() || !e.Time.Before(since)
+		}
+
+		if !follow {
+			entries := make([]types.LogEntry, 0)
+			for _, e := range s.logBuffer.Entries() {
+				if match(e) {
+					entries = append(entries, toAPILogEntry(e))
+				}
+			}
+			c.JSON(http.StatusOK, entries)
+			return
+		}
+
+		// subscribe before reading the recorded entries so that no entry is missed in between,
+		// the entries received twice are skipped by their ID
+		stream, unsubscribe := s.logBuffer.Subscribe()
+		defer unsubscribe()
+		backlog := s.logBuffer.Entries()
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		c.Header("X-Accel-Buffering", "no")
+		c.Status(http.StatusOK)
+
+		var lastID uint64
+		for _, e := range backlog {
+			lastID = e.ID
+			if match(e) {
+				c.Render(-1, sse.Event{Id: strconv.FormatUint(e.ID, 10), Event: "log", Data: toAPILogEntry(e)})
+			}
+		}
+		c.Writer.Flush()
+
+		keepAlive := time.NewTicker(eventStreamKeepAliveInterval)
+		defer keepAlive.Stop()
+
+		c.Stream(func(w io.Writer) bool {
+			select {
+			case <-c.Request.Context().Done():
+				return false
+			case e, ok := <-stream:
+				if !ok {
+					return false
+				}
+				if e.ID > lastID && match(e) {
+					c.Render(-1, sse.Event{Id: strconv.FormatUint(e.ID, 10), Event: "log", Data: toAPILogEntry(e)})
+				}
+				return true
+			case <-keepAlive.C:
+				_, err := io.WriteString(w, ": keep-alive\n\n")
+				return err == nil
+			}
+		})
+	}
+}
+
+// toAPILogEntry converts a log entry recorded by the server to its API representation.
+func toAPILogEntry(e logger.Entry) types.LogEntry {
+	return types.LogEntry{
+		ID:      e.ID,
+		Time:    e.Time,
+		Level:   e.Level,
+		Message: e.Message,
+		Fields:  e.Fields,
+	}
+}
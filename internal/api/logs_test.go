@@ -0,0 +1,127 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func newTestLogBuffer(t *testing.T) (*logger.Buffer, logger.Logger) {
+	buf := logger.NewBuffer(100)
+	l, err := logger.New(&logger.Config{Level: "info", Development: true, Buffer: buf})
+	testhelpers.AssertNoError(t, err)
+	return buf, l
+}
+
+func TestLogsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	buf, l := newTestLogBuffer(t)
+	l.Info("server started")
+	l.WithFields(logger.String("server", "time")).Info("stdio MCP server stderr", logger.String("stderr", "ready"))
+
+	s := &Server{logBuffer: buf}
+	router := gin.New()
+	router.GET("/logs", s.logsHandler())
+
+	t.Run("all entries", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/logs", nil))
+		testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+
+		var entries []types.LogEntry
+		testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &entries))
+		testhelpers.AssertEqual(t, 2, len(entries))
+		testhelpers.AssertEqual(t, "server started", entries[0].Message)
+		testhelpers.AssertEqual(t, "info", entries[0].Level)
+	})
+
+	t.Run("filtered by server", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/logs?server=time", nil))
+		testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+
+		var entries []types.LogEntry
+		testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &entries))
+		testhelpers.AssertEqual(t, 1, len(entries))
+		testhelpers.AssertEqual(t, "ready", entries[0].Fields["stderr"])
+	})
+
+	t.Run("filtered by time", func(t *testing.T) {
+		since := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/logs?since="+since, nil))
+		testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+		testhelpers.AssertEqual(t, "[]", w.Body.String())
+	})
+
+	t.Run("invalid since", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/logs?since=yesterday", nil))
+		testhelpers.AssertEqual(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestLogsHandlerFollow(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	buf, l := newTestLogBuffer(t)
+	l.Info("before following")
+
+	s := &Server{logBuffer: buf}
+	router := gin.New()
+	router.GET("/logs", s.logsHandler())
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/logs?follow=true", nil)
+	testhelpers.AssertNoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to connect to log stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	testhelpers.AssertEqual(t, http.StatusOK, resp.StatusCode)
+	testhelpers.AssertStringContains(t, resp.Header.Get("Content-Type"), "text/event-stream")
+
+	// the handler has subscribed by the time the response headers are received
+	l.Info("after following")
+
+	var messages []string
+	scanner := bufio.NewScanner(resp.Body)
+	for len(messages) < 2 && scanner.Scan() {
+		if data, ok := strings.CutPrefix(scanner.Text(), "data:"); ok {
+			var e types.LogEntry
+			testhelpers.AssertNoError(t, json.Unmarshal([]byte(data), &e))
+			messages = append(messages, e.Message)
+		}
+	}
+	testhelpers.AssertEqual(t, 2, len(messages))
+	testhelpers.AssertEqual(t, "before following", messages[0])
+	testhelpers.AssertEqual(t, "after following", messages[1])
+}
+
+func TestLogsHandlerWithoutBuffer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	s := &Server{}
+	router := gin.New()
+	router.GET("/logs", s.logsHandler())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/logs", nil))
+	testhelpers.AssertEqual(t, http.StatusServiceUnavailable, w.Code)
+}
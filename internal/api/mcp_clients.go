@@ -1,19 +1,28 @@
 package api
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcpclient"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
 
 func (s *Server) listMcpClientsHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		clients, err := s.mcpClientService.ListClients()
+		page, ok := pageQuery(c)
+		if !ok {
+			return
+		}
+		clients, total, err := s.mcpClientService.ListClientsPage(page)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
+		setTotalCount(c, total)
 		c.JSON(http.StatusOK, clients)
 	}
 }
@@ -29,9 +38,17 @@ func (s *Server) createMcpClientHandler() gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
 			return
 		}
+		if req.TokenTTLSeconds < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "token_ttl_seconds must not be negative"})
+			return
+		}
 		// TODO: if allow list in the request is null, convert it to an empty JSON array
 		client, err := s.mcpClientService.CreateClient(req)
 		if err != nil {
+			if errors.Is(err, mcpclient.ErrInvalidQuota) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
@@ -39,6 +56,33 @@ func (s *Server) createMcpClientHandler() gin.HandlerFunc {
 	}
 }
 
+// patchMcpClientHandler updates only the fields of an MCP client that are set in the request.
+// The client's access token is left unchanged, so the agents using it keep working.
+func (s *Server) patchMcpClientHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Param("name")
+		var patch types.PatchMcpClientInput
+		if err := c.ShouldBindJSON(&patch); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+		client, err := s.mcpClientService.PatchClient(name, &patch)
+		if err != nil {
+			if errors.Is(err, mcpclient.ErrClientNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("mcp client %s does not exist", name)})
+				return
+			}
+			if errors.Is(err, mcpclient.ErrInvalidQuota) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, client)
+	}
+}
+
 func (s *Server) deleteMcpClientHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		name := c.Param("name")
@@ -53,3 +97,30 @@ func (s *Server) deleteMcpClientHandler() gin.HandlerFunc {
 		c.Status(http.StatusNoContent)
 	}
 }
+
+// renewMcpClientTokenHandler lets the authenticated MCP client replace its own access token by a new one,
+// before the current one expires. The previous token stops working immediately.
+func (s *Server) renewMcpClientTokenHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		current, ok := c.Request.Context().Value("client").(*model.McpClient)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			return
+		}
+
+		client, err := s.mcpClientService.RenewClientToken(current.Name)
+		if err != nil {
+			if errors.Is(err, mcpclient.ErrClientNotFound) {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid MCP client token"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, &types.RenewMcpClientTokenResponse{
+			Name:        client.Name,
+			AccessToken: client.AccessToken,
+			ExpiresAt:   client.TokenExpiresAt,
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcpclient"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestRenewMcpClientToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	mcpClientService := mcpclient.NewMCPClientService(setup.DB)
+	client, err := mcpClientService.CreateClient(model.McpClient{Name: "agent", TokenTTLSeconds: 3600})
+	testhelpers.AssertNoError(t, err)
+
+	server := &Server{mcpClientService: mcpClientService}
+	router := gin.New()
+	router.Use(func(c *gin.Context) { c.Set("mode", model.ModeEnterprise) })
+	server.registerMcpClientTokenRoutes(router.Group("/", server.checkAuthForMcpProxyAccess()))
+
+	renew := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/clients/token/renew", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := renew(client.AccessToken)
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+	var resp types.RenewMcpClientTokenResponse
+	testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	testhelpers.AssertEqual(t, "agent", resp.Name)
+	testhelpers.AssertTrue(t, resp.AccessToken != client.AccessToken, "a new token must be issued")
+	testhelpers.AssertNotNil(t, resp.ExpiresAt)
+	testhelpers.AssertFalse(t, resp.ExpiresAt.Before(*client.TokenExpiresAt), "the new token must not expire earlier")
+
+	// the previous token no longer works, the new one does
+	testhelpers.AssertEqual(t, http.StatusUnauthorized, renew(client.AccessToken).Code)
+	testhelpers.AssertEqual(t, http.StatusOK, renew(resp.AccessToken).Code)
+}
+
+func TestRenewMcpClientTokenDevMode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	server := &Server{mcpClientService: mcpclient.NewMCPClientService(setup.DB)}
+	router := gin.New()
+	router.Use(func(c *gin.Context) { c.Set("mode", model.ModeDev) })
+	server.registerMcpClientTokenRoutes(router.Group("/", server.checkAuthForMcpProxyAccess()))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/clients/token/renew", nil))
+	testhelpers.AssertEqual(t, http.StatusForbidden, w.Code)
+}
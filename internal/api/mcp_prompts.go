@@ -5,28 +5,22 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
 
 func (s *Server) listPromptsHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		server := c.Query("server")
-		var (
-			prompts []model.Prompt
-			err     error
-		)
-		if server == "" {
-			// no server specified, list all prompts
-			prompts, err = s.mcpService.ListPrompts()
-		} else {
-			// server specified, list prompts for that server
-			prompts, err = s.mcpService.ListPromptsByServer(server)
+		page, ok := pageQuery(c)
+		if !ok {
+			return
 		}
+		// if no server is specified, all prompts are listed
+		prompts, total, err := s.mcpService.ListPromptsPage(c.Query("server"), page)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
+		setTotalCount(c, total)
 		c.JSON(http.StatusOK, prompts)
 	}
 }
@@ -43,7 +37,7 @@ func (s *Server) getPromptHandler() gin.HandlerFunc {
 		}
 		prompt, err := s.mcpService.GetPrompt(name)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get prompt: " + err.Error()})
+			c.JSON(lookupErrorStatus(err), gin.H{"error": "failed to get prompt: " + err.Error()})
 			return
 		}
 
@@ -76,7 +70,7 @@ func (s *Server) getPromptWithArgsHandler() gin.HandlerFunc {
 
 		resp, err := s.mcpService.GetPromptWithArgs(c, request.Name, args)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get prompt: " + err.Error()})
+			c.JSON(lookupErrorStatus(err), gin.H{"error": "failed to get prompt: " + err.Error()})
 			return
 		}
 
@@ -97,6 +91,9 @@ func (s *Server) enablePromptsHandler() gin.HandlerFunc {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to enable prompt(s): " + err.Error()})
 			return
 		}
+		if len(enabledPrompts) > 0 {
+			s.eventBroker.Publish(types.RegistryEventPromptsEnabled, entity, enabledPrompts)
+		}
 		c.JSON(http.StatusOK, enabledPrompts)
 	}
 }
@@ -114,6 +111,9 @@ func (s *Server) disablePromptsHandler() gin.HandlerFunc {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to disable prompt(s): " + err.Error()})
 			return
 		}
+		if len(disabledPrompts) > 0 {
+			s.eventBroker.Publish(types.RegistryEventPromptsDisabled, entity, disabledPrompts)
+		}
 		c.JSON(http.StatusOK, disabledPrompts)
 	}
 }
@@ -3,6 +3,7 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"slices"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mcpjungle/mcpjungle/internal/model"
@@ -17,67 +18,119 @@ func (s *Server) registerServerHandler() gin.HandlerFunc {
 			return
 		}
 
-		transport, err := types.ValidateTransport(input.Transport)
+		server, err := newMcpServerModel(&input)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
-		var server *model.McpServer
+		if err := s.mcpService.RegisterMcpServer(c, server); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		s.eventBroker.Publish(types.RegistryEventServerRegistered, server.Name, nil)
 
-		switch transport {
-		case types.TransportStreamableHTTP:
-			server, err = model.NewStreamableHTTPServer(
-				input.Name,
-				input.Description,
-				input.URL,
-				input.BearerToken,
-			)
-			if err != nil {
-				c.JSON(
-					http.StatusBadRequest,
-					gin.H{"error": fmt.Sprintf("Error creating streamable http server: %v", err)},
-				)
-				return
-			}
-		case types.TransportStdio:
-			server, err = model.NewStdioServer(
-				input.Name,
-				input.Description,
-				input.Command,
-				input.Args,
-				input.Env,
-			)
+		c.JSON(http.StatusCreated, server)
+	}
+}
+
+// newMcpServerModel creates the model of an MCP server from its configuration, validating it.
+func newMcpServerModel(input *types.RegisterServerInput) (*model.McpServer, error) {
+	transport, err := types.ValidateTransport(input.Transport)
+	if err != nil {
+		return nil, err
+	}
+
+	switch transport {
+	case types.TransportStreamableHTTP:
+		server, err := model.NewStreamableHTTPServer(input.Name, input.Description, input.URL, input.BearerToken)
+		if err != nil {
+			return nil, fmt.Errorf("Error creating streamable http server: %v", err)
+		}
+		return server, nil
+	case types.TransportStdio:
+		server, err := model.NewStdioServer(input.Name, input.Description, input.Command, input.Args, input.Env)
+		if err != nil {
+			return nil, fmt.Errorf("Error creating stdio server: %v", err)
+		}
+		return server, nil
+	default:
+		// transport is SSE
+		server, err := model.NewSSEServer(input.Name, input.Description, input.URL, input.BearerToken)
+		if err != nil {
+			return nil, fmt.Errorf("Error creating SSE server: %v", err)
+		}
+		return server, nil
+	}
+}
+
+// testServerHandler checks that mcpjungle can connect to an MCP server and list its tools,
+// and optionally call one of its read-only tools.
+// The server is either a registered one or one that is about to be registered.
+// The report is returned with a 200 status whether the test passed or not.
+func (s *Server) testServerHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input types.TestServerInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if (input.Name == "") == (input.Server == nil) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "exactly one of 'name' and 'server' must be set"})
+			return
+		}
+
+		var server *model.McpServer
+		var err error
+		if input.Name != "" {
+			server, err = s.mcpService.GetMcpServer(input.Name)
 			if err != nil {
-				c.JSON(
-					http.StatusBadRequest,
-					gin.H{"error": fmt.Sprintf("Error creating stdio server: %v", err)},
-				)
+				c.JSON(lookupErrorStatus(err), gin.H{"error": err.Error()})
 				return
 			}
-		default:
-			// transport is SSE
-			server, err = model.NewSSEServer(
-				input.Name,
-				input.Description,
-				input.URL,
-				input.BearerToken,
-			)
+		} else {
+			server, err = newMcpServerModel(input.Server)
 			if err != nil {
-				c.JSON(
-					http.StatusBadRequest,
-					gin.H{"error": fmt.Sprintf("Error creating SSE server: %v", err)},
-				)
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 				return
 			}
 		}
 
-		if err := s.mcpService.RegisterMcpServer(c, server); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusOK, s.mcpService.TestMcpServer(c, server, input.Tool, input.Arguments))
+	}
+}
+
+// updateServerHandler replaces the configuration of a registered MCP server.
+// The name in the request body may be omitted, but it must match the name in the path otherwise.
+func (s *Server) updateServerHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Param("name")
+
+		var input types.RegisterServerInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if input.Name == "" {
+			input.Name = name
+		} else if input.Name != name {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "the name of an MCP server cannot be changed"})
 			return
 		}
 
-		c.JSON(http.StatusCreated, server)
+		server, err := newMcpServerModel(&input)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := s.mcpService.UpdateMcpServer(c, name, server); err != nil {
+			c.JSON(lookupErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		s.eventBroker.Publish(types.RegistryEventServerUpdated, name, nil)
+
+		c.JSON(http.StatusOK, server)
 	}
 }
 
@@ -86,21 +139,51 @@ func (s *Server) deregisterServerHandler() gin.HandlerFunc {
 		name := c.Param("name")
 
 		if err := s.mcpService.DeregisterMcpServer(name); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			c.JSON(lookupErrorStatus(err), gin.H{"error": err.Error()})
 			return
 		}
+		s.eventBroker.Publish(types.RegistryEventServerDeregistered, name, nil)
 
 		c.Status(http.StatusNoContent)
 	}
 }
 
+// getServerConsumersHandler returns the MCP clients & tool groups that reference an MCP server,
+// so that admins can assess the impact of deregistering it beforehand.
+func (s *Server) getServerConsumersHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Param("name")
+		if _, err := s.mcpService.GetMcpServer(name); err != nil {
+			c.JSON(lookupErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		clients, err := s.mcpClientService.ListClientsWithServerAccess(name)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		groups, err := s.toolGroupService.ListGroupsUsingServer(name)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, &types.ServerConsumers{Server: name, McpClients: clients, ToolGroups: groups})
+	}
+}
+
 func (s *Server) listServersHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		records, err := s.mcpService.ListMcpServers()
+		page, ok := pageQuery(c)
+		if !ok {
+			return
+		}
+		records, total, err := s.mcpService.ListMcpServersPage(page)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
+		setTotalCount(c, total)
 
 		servers := make([]*types.McpServer, len(records))
 
@@ -164,9 +247,10 @@ func (s *Server) enableServerHandler() gin.HandlerFunc {
 
 		tools, prompts, err := s.mcpService.EnableMcpServer(name)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			c.JSON(lookupErrorStatus(err), gin.H{"error": err.Error()})
 			return
 		}
+		s.eventBroker.Publish(types.RegistryEventServerEnabled, name, append(slices.Clone(tools), prompts...))
 
 		result := types.EnableDisableServerResult{
 			Name:            name,
@@ -183,9 +267,10 @@ func (s *Server) disableServerHandler() gin.HandlerFunc {
 
 		tools, prompts, err := s.mcpService.DisableMcpServer(name)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			c.JSON(lookupErrorStatus(err), gin.H{"error": err.Error()})
 			return
 		}
+		s.eventBroker.Publish(types.RegistryEventServerDisabled, name, append(slices.Clone(tools), prompts...))
 
 		result := types.EnableDisableServerResult{
 			Name:            name,
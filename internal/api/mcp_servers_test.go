@@ -1,10 +1,20 @@
 package api
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mark3labs/mcp-go/server"
 	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcpclient"
+	"github.com/mcpjungle/mcpjungle/internal/service/toolgroup"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
@@ -216,3 +226,91 @@ func TestInputStructureValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestTestServerHandlerValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	s := &Server{}
+	router := gin.New()
+	router.POST("/servers/test", s.testServerHandler())
+
+	tests := map[string]struct {
+		body          string
+		expectedError string
+	}{
+		"neither name nor server": {
+			body:          `{"tool": "get_current_time"}`,
+			expectedError: "exactly one of 'name' and 'server' must be set",
+		},
+		"both name and server": {
+			body:          `{"name": "time", "server": {"name": "time", "transport": "stdio", "command": "uvx"}}`,
+			expectedError: "exactly one of 'name' and 'server' must be set",
+		},
+		"invalid server configuration": {
+			body:          `{"server": {"name": "time", "transport": "carrier_pigeon"}}`,
+			expectedError: "unsupported transport",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/servers/test", strings.NewReader(tt.body))
+			router.ServeHTTP(w, req)
+
+			testhelpers.AssertEqual(t, http.StatusBadRequest, w.Code)
+			testhelpers.AssertStringContains(t, w.Body.String(), tt.expectedError)
+		})
+	}
+}
+
+func TestGetServerConsumersHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	clock := setup.CreateTestMcpServer("clock", "", types.TransportStreamableHTTP, []byte(`{"url": "http://localhost:1"}`))
+	setup.CreateTestTool("now", "", clock.ID, true, []byte(`{"type":"object"}`))
+	setup.CreateTestTool("sleep", "", clock.ID, true, []byte(`{"type":"object"}`))
+	setup.CreateTestMcpServer("unused", "", types.TransportStreamableHTTP, []byte(`{"url": "http://localhost:1"}`))
+
+	proxy := server.NewMCPServer("proxy", "test")
+	mcpService, err := mcp.NewMCPService(setup.DB, proxy, proxy, telemetry.NewNoopCustomMetrics(), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+	toolGroupService, err := toolgroup.NewToolGroupService(setup.DB, mcpService, telemetry.NewNoopCustomMetrics())
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, toolGroupService.CreateToolGroup(
+		&model.ToolGroup{Name: "time", IncludedTools: []byte(`["clock__now"]`)},
+	))
+	mcpClientService := mcpclient.NewMCPClientService(setup.DB)
+	_, err = mcpClientService.CreateClient(model.McpClient{Name: "cursor", AllowList: []byte(`["clock"]`)})
+	testhelpers.AssertNoError(t, err)
+
+	s := &Server{mcpService: mcpService, mcpClientService: mcpClientService, toolGroupService: toolGroupService}
+	router := gin.New()
+	router.GET("/servers/:name/consumers", s.getServerConsumersHandler())
+	get := func(name string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/servers/"+name+"/consumers", nil))
+		return w
+	}
+
+	w := get("clock")
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+	var consumers types.ServerConsumers
+	testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &consumers))
+	testhelpers.AssertEqual(t, "clock", consumers.Server)
+	testhelpers.AssertEqual(t, 1, len(consumers.McpClients))
+	testhelpers.AssertEqual(t, "cursor", consumers.McpClients[0])
+	testhelpers.AssertEqual(t, 1, len(consumers.ToolGroups))
+	testhelpers.AssertEqual(t, "time", consumers.ToolGroups[0].Name)
+	testhelpers.AssertEqual(t, "clock__now", consumers.ToolGroups[0].Tools[0])
+
+	// a server without consumers has empty lists rather than null ones
+	w = get("unused")
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+	testhelpers.AssertStringContains(t, w.Body.String(), `"mcp_clients":[]`)
+	testhelpers.AssertStringContains(t, w.Body.String(), `"tool_groups":[]`)
+
+	testhelpers.AssertEqual(t, http.StatusNotFound, get("missing").Code)
+}
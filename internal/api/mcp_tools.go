@@ -1,39 +1,126 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
 
+	"github.com/gin-contrib/sse"
 	"github.com/gin-gonic/gin"
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
 	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
 
-// listToolsHandler returns a list of all tools, or all tools for a given mcp server if "server" query param is provided
+// listToolsHandler returns a list of all tools the authenticated user may access.
+// The tools can be filtered via the "server", "enabled" and "name_contains" query params and
+// sorted via the "sort" query param.
 func (s *Server) listToolsHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		server := c.Query("server")
-		var (
-			tools []model.Tool
-			err   error
-		)
-		if server == "" {
-			// no server specified, list all tools
-			tools, err = s.mcpService.ListTools()
-		} else {
-			// server specified, list tools for that server
-			tools, err = s.mcpService.ListToolsByServer(server)
+		opts := &types.ListToolsOptions{
+			Server:       c.Query("server"),
+			NameContains: c.Query("name_contains"),
+			Sort:         types.ToolSortKey(c.Query("sort")),
+		}
+		if v := c.Query("enabled"); v != "" {
+			enabled, err := strconv.ParseBool(v)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid enabled: must be true or false"})
+				return
+			}
+			opts.Enabled = &enabled
 		}
+		switch opts.Sort {
+		case "", types.ToolSortName, types.ToolSortServer, types.ToolSortUpdatedAt:
+		default:
+			c.JSON(
+				http.StatusBadRequest,
+				gin.H{"error": fmt.Sprintf(
+					"invalid sort: must be one of %s, %s, %s",
+					types.ToolSortName, types.ToolSortServer, types.ToolSortUpdatedAt,
+				)},
+			)
+			return
+		}
+
+		page, ok := pageQuery(c)
+		if !ok {
+			return
+		}
+
+		allowed, err := s.userToolAccessFilter(c)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
-		c.JSON(http.StatusOK, tools)
+		if allowed == nil {
+			tools, total, err := s.mcpService.ListToolsFiltered(opts, page)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			setTotalCount(c, total)
+			c.JSON(http.StatusOK, tools)
+			return
+		}
+
+		// the tools the user may access are only known in memory, so they are filtered and paginated there
+		tools, _, err := s.mcpService.ListToolsFiltered(opts, types.Page{})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		tools = slices.DeleteFunc(tools, func(t model.Tool) bool { return !allowed(t.Name) })
+		c.JSON(http.StatusOK, paginate(c, tools, page))
 	}
 }
 
 // invokeToolHandler forwards the JSON body to the tool URL and streams response back.
+// If the "async" query param is true, the tool is invoked in the background and the ID of the job is returned
+// immediately. The job's status & result can then be retrieved from the jobs API.
+// Otherwise, the "timeout" query param limits how long the upstream MCP server is waited for,
+// a 504 is returned if it doesn't respond in time.
+// If the "stream" query param is true, the progress notifications of the upstream MCP server and then the result
+// are streamed as server-sent events instead, see types.ToolInvokeStreamEvent.
 func (s *Server) invokeToolHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		async := false
+		if v := c.Query("async"); v != "" {
+			var err error
+			if async, err = strconv.ParseBool(v); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid async: must be true or false"})
+				return
+			}
+		}
+		stream := false
+		if v := c.Query("stream"); v != "" {
+			var err error
+			if stream, err = strconv.ParseBool(v); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid stream: must be true or false"})
+				return
+			}
+		}
+		if async && stream {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "async and stream cannot be used together"})
+			return
+		}
+		var timeout time.Duration
+		if v := c.Query("timeout"); v != "" {
+			var err error
+			if timeout, err = time.ParseDuration(v); err != nil || timeout <= 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid timeout: must be a positive duration (eg- 30s)"})
+				return
+			}
+		}
+
 		var args map[string]any
 		if err := json.NewDecoder(c.Request.Body).Decode(&args); err != nil {
 			c.JSON(
@@ -57,16 +144,116 @@ func (s *Server) invokeToolHandler() gin.HandlerFunc {
 		// remove name from args since it was an input for the api, not for the tool
 		delete(args, "name")
 
-		resp, err := s.mcpService.InvokeTool(c, name, args)
+		if !s.authorizeToolAccess(c, name) {
+			return
+		}
+
+		if async {
+			j, err := s.jobService.StartToolInvocation(jobContext(c), name, args, currentUsername(c))
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to start tool invocation: " + err.Error()})
+				return
+			}
+			c.JSON(http.StatusAccepted, toAPIJob(j, nil))
+			return
+		}
+
+		var ctx context.Context = c
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(c, timeout)
+			defer cancel()
+		}
+		if stream {
+			s.streamToolInvocation(ctx, c, name, args, timeout)
+			return
+		}
+		res, err := s.mcpService.CallTool(ctx, name, args)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to invoke tool: " + err.Error()})
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				c.JSON(http.StatusGatewayTimeout, gin.H{
+					"error": fmt.Sprintf("MCP server did not respond within %s", timeout),
+				})
+				return
+			}
+			c.JSON(lookupErrorStatus(err), gin.H{"error": "failed to invoke tool: " + err.Error()})
 			return
 		}
 
+		if minBytes := s.httpConfig.StreamResponseMinBytes; minBytes > 0 && mcp.ToolResultSize(res) >= minBytes {
+			s.writeLargeToolResult(c, name, res)
+			return
+		}
+		resp, err := s.mcpService.ConvertToolResult(res)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to invoke tool: " + err.Error()})
+			return
+		}
 		c.JSON(http.StatusOK, resp)
 	}
 }
 
+// writeLargeToolResult writes a large response of a tool to the client while encoding it,
+// so that the gateway doesn't hold several copies of the response in memory.
+// The response has the same format as any other, only its length isn't known in advance.
+func (s *Server) writeLargeToolResult(c *gin.Context, name string, res *mcpgo.CallToolResult) {
+	c.Header("Content-Type", "application/json; charset=utf-8")
+	c.Status(http.StatusOK)
+	if err := s.mcpService.EncodeToolInvokeResult(c.Writer, res); err != nil {
+		// the status was already sent, so the client only gets a truncated response
+		s.logger.Warn("failed to stream the response of a tool", logger.String("tool", name), logger.ErrorField(err))
+	}
+}
+
+// streamToolInvocation invokes a tool and streams the progress notifications of the upstream MCP server
+// as server-sent events, followed by a last event with the result or the error.
+// Since the response status is sent before the tool is called, errors are only reported in the last event.
+func (s *Server) streamToolInvocation(
+	ctx context.Context, c *gin.Context, name string, args map[string]any, timeout time.Duration,
+) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	// progress notifications arrive on the upstream session's goroutine,
+	// so writes are serialized and none are made once the last event was sent
+	var mu sync.Mutex
+	done := false
+	send := func(e types.ToolInvokeStreamEvent, last bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		if done {
+			return
+		}
+		c.Render(-1, sse.Event{Event: string(e.Type), Data: e})
+		c.Writer.Flush()
+		done = last
+	}
+
+	ctx = mcp.WithProgressHandler(ctx, func(p types.ToolProgress) {
+		send(types.ToolInvokeStreamEvent{Type: types.ToolInvokeStreamEventProgress, Progress: &p}, false)
+	})
+	resp, err := s.mcpService.InvokeTool(ctx, name, args)
+	switch {
+	case err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded):
+		send(types.ToolInvokeStreamEvent{
+			Type:     types.ToolInvokeStreamEventError,
+			Error:    fmt.Sprintf("MCP server did not respond within %s", timeout),
+			TimedOut: true,
+		}, true)
+	case err != nil:
+		send(types.ToolInvokeStreamEvent{
+			Type:  types.ToolInvokeStreamEventError,
+			Error: "failed to invoke tool: " + err.Error(),
+		}, true)
+	default:
+		send(types.ToolInvokeStreamEvent{Type: types.ToolInvokeStreamEventResult, Result: resp}, true)
+	}
+}
+
 // getToolHandler returns the tool with the given name.
 func (s *Server) getToolHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -77,10 +264,13 @@ func (s *Server) getToolHandler() gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "missing 'name' query parameter"})
 			return
 		}
+		if !s.authorizeToolAccess(c, name) {
+			return
+		}
 
 		tool, err := s.mcpService.GetTool(name)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get tool: " + err.Error()})
+			c.JSON(lookupErrorStatus(err), gin.H{"error": "failed to get tool: " + err.Error()})
 			return
 		}
 
@@ -88,36 +278,79 @@ func (s *Server) getToolHandler() gin.HandlerFunc {
 	}
 }
 
-// enableToolsHandler enables the given tool or all tools of the given mcp server
+// enableToolsHandler enables the given tool or all tools of the given mcp server.
+// Multiple entities can be enabled at once by supplying them in the request body instead of the "entity" query param.
 func (s *Server) enableToolsHandler() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		entity := c.Query("entity")
-		if entity == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "missing 'entity' query parameter"})
-			return
-		}
-		enabledTools, err := s.mcpService.EnableTools(entity)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to enable tool(s): " + err.Error()})
-			return
-		}
-		c.JSON(http.StatusOK, enabledTools)
-	}
+	return s.setToolsEnabledHandler(true)
 }
 
-// disableToolsHandler disables the given tool or all tools of the given mcp server
+// disableToolsHandler disables the given tool or all tools of the given mcp server.
+// Multiple entities can be disabled at once by supplying them in the request body instead of the "entity" query param.
 func (s *Server) disableToolsHandler() gin.HandlerFunc {
+	return s.setToolsEnabledHandler(false)
+}
+
+func (s *Server) setToolsEnabledHandler(enabled bool) gin.HandlerFunc {
+	action := "disable"
+	eventType := types.RegistryEventToolsDisabled
+	if enabled {
+		action = "enable"
+		eventType = types.RegistryEventToolsEnabled
+	}
+
 	return func(c *gin.Context) {
 		entity := c.Query("entity")
 		if entity == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "missing 'entity' query parameter"})
+			// no single entity supplied, so this must be a bulk request
+			entities, ok := bindBulkEntities(c)
+			if !ok {
+				return
+			}
+			var results []types.BulkEntityResult
+			if enabled {
+				results = s.mcpService.EnableToolsBulk(entities)
+			} else {
+				results = s.mcpService.DisableToolsBulk(entities)
+			}
+			for _, r := range results {
+				if r.Error == "" && len(r.Affected) > 0 {
+					s.eventBroker.Publish(eventType, r.Entity, r.Affected)
+				}
+			}
+			c.JSON(http.StatusOK, results)
 			return
 		}
-		disabledTools, err := s.mcpService.DisableTools(entity)
+
+		var (
+			tools []string
+			err   error
+		)
+		if enabled {
+			tools, err = s.mcpService.EnableTools(entity)
+		} else {
+			tools, err = s.mcpService.DisableTools(entity)
+		}
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to disable tool(s): " + err.Error()})
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to " + action + " tool(s): " + err.Error()})
 			return
 		}
-		c.JSON(http.StatusOK, disabledTools)
+		if len(tools) > 0 {
+			s.eventBroker.Publish(eventType, entity, tools)
+		}
+		c.JSON(http.StatusOK, tools)
+	}
+}
+
+// bindBulkEntities reads the entities of a bulk enable/disable request from the request body.
+// If the body is invalid, it writes a Bad Request response and returns false.
+func bindBulkEntities(c *gin.Context) ([]string, bool) {
+	var req types.BulkEntitiesRequest
+	if err := c.ShouldBindJSON(&req); err != nil || len(req.Entities) == 0 {
+		c.JSON(
+			http.StatusBadRequest,
+			gin.H{"error": "either the 'entity' query parameter or a request body with a non-empty 'entities' list is required"},
+		)
+		return nil, false
 	}
+	return req.Entities, true
 }
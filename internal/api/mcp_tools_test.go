@@ -0,0 +1,199 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestInvokeToolTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// upstream MCP server whose tool never responds on its own
+	upstream := server.NewMCPServer("slow", "test")
+	upstream.AddTool(
+		mcpgo.NewTool("wait"),
+		func(ctx context.Context, request mcpgo.CallToolRequest) (*mcpgo.CallToolResult, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	)
+	ts := httptest.NewServer(server.NewStreamableHTTPServer(upstream))
+	defer ts.Close()
+
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	srv := setup.CreateTestMcpServer("slow", "", types.TransportStreamableHTTP, []byte(`{"url": "`+ts.URL+`/mcp"}`))
+	setup.CreateTestTool("slow__wait", "", srv.ID, true, []byte(`{"type":"object"}`))
+
+	proxy := server.NewMCPServer("proxy", "test")
+	mcpService, err := mcp.NewMCPService(setup.DB, proxy, proxy, telemetry.NewNoopCustomMetrics(), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+
+	s := &Server{mcpService: mcpService}
+	router := gin.New()
+	router.POST("/tools/invoke", s.invokeToolHandler())
+
+	t.Run("upstream timeout", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/tools/invoke?timeout=200ms", strings.NewReader(`{"name": "slow__wait"}`))
+		router.ServeHTTP(w, req)
+
+		testhelpers.AssertEqual(t, http.StatusGatewayTimeout, w.Code)
+		testhelpers.AssertStringContains(t, w.Body.String(), "MCP server did not respond within 200ms")
+	})
+
+	t.Run("invalid timeout", func(t *testing.T) {
+		for _, v := range []string{"soon", "-1s", "0s"} {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/tools/invoke?timeout="+v, strings.NewReader(`{"name": "slow__wait"}`))
+			router.ServeHTTP(w, req)
+
+			testhelpers.AssertEqual(t, http.StatusBadRequest, w.Code)
+			testhelpers.AssertStringContains(t, w.Body.String(), "invalid timeout")
+		}
+	})
+}
+
+func TestInvokeToolStream(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// upstream MCP server whose tool reports its progress before returning
+	upstream := server.NewMCPServer("indexer", "test")
+	upstream.AddTool(
+		mcpgo.NewTool("index"),
+		func(ctx context.Context, request mcpgo.CallToolRequest) (*mcpgo.CallToolResult, error) {
+			token := request.Params.Meta.ProgressToken
+			for i := 1; i <= 2; i++ {
+				err := server.ServerFromContext(ctx).SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+					"progressToken": token, "progress": i, "total": 2, "message": fmt.Sprintf("step %d", i),
+				})
+				if err != nil {
+					return nil, err
+				}
+			}
+			// the upstream server sends queued notifications asynchronously and drops those
+			// still queued when the result is written, so give it time to flush them
+			time.Sleep(100 * time.Millisecond)
+			return mcpgo.NewToolResultText("indexed"), nil
+		},
+	)
+	ts := httptest.NewServer(server.NewStreamableHTTPServer(upstream))
+	defer ts.Close()
+
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	srv := setup.CreateTestMcpServer("indexer", "", types.TransportStreamableHTTP, []byte(`{"url": "`+ts.URL+`/mcp"}`))
+	setup.CreateTestTool("indexer__index", "", srv.ID, true, []byte(`{"type":"object"}`))
+
+	proxy := server.NewMCPServer("proxy", "test")
+	mcpService, err := mcp.NewMCPService(setup.DB, proxy, proxy, telemetry.NewNoopCustomMetrics(), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+
+	s := &Server{mcpService: mcpService}
+	router := gin.New()
+	router.POST("/tools/invoke", s.invokeToolHandler())
+
+	t.Run("progress and result", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/tools/invoke?stream=true", strings.NewReader(`{"name": "indexer__index"}`))
+		router.ServeHTTP(w, req)
+
+		testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+		testhelpers.AssertStringContains(t, w.Header().Get("Content-Type"), "text/event-stream")
+		body := w.Body.String()
+		testhelpers.AssertStringContains(t, body, `"progress":{"progress":1,"total":2,"message":"step 1"}`)
+		testhelpers.AssertStringContains(t, body, `"progress":{"progress":2,"total":2,"message":"step 2"}`)
+		testhelpers.AssertStringContains(t, body, "event:result")
+		testhelpers.AssertStringContains(t, body, "indexed")
+		// the result is the last event
+		testhelpers.AssertTrue(t, strings.Index(body, "step 2") < strings.Index(body, "event:result"), "expected progress before the result")
+	})
+
+	t.Run("error event", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/tools/invoke?stream=true", strings.NewReader(`{"name": "indexer__missing"}`))
+		router.ServeHTTP(w, req)
+
+		testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+		testhelpers.AssertStringContains(t, w.Body.String(), "event:error")
+		testhelpers.AssertStringContains(t, w.Body.String(), "failed to invoke tool")
+	})
+
+	t.Run("stream with async", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/tools/invoke?stream=true&async=true", strings.NewReader(`{"name": "indexer__index"}`))
+		router.ServeHTTP(w, req)
+
+		testhelpers.AssertEqual(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestInvokeToolLargeResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// upstream MCP server whose tool returns a few large content items
+	large := strings.Repeat("x", 4096)
+	upstream := server.NewMCPServer("files", "test")
+	upstream.AddTool(
+		mcpgo.NewTool("read"),
+		func(ctx context.Context, request mcpgo.CallToolRequest) (*mcpgo.CallToolResult, error) {
+			return &mcpgo.CallToolResult{Content: []mcpgo.Content{
+				mcpgo.NewTextContent(large), mcpgo.NewTextContent(large), mcpgo.NewTextContent("eof"),
+			}}, nil
+		},
+	)
+	ts := httptest.NewServer(server.NewStreamableHTTPServer(upstream))
+	defer ts.Close()
+
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	srv := setup.CreateTestMcpServer("files", "", types.TransportStreamableHTTP, []byte(`{"url": "`+ts.URL+`/mcp"}`))
+	setup.CreateTestTool("files__read", "", srv.ID, true, []byte(`{"type":"object"}`))
+
+	proxy := server.NewMCPServer("proxy", "test")
+	mcpService, err := mcp.NewMCPService(setup.DB, proxy, proxy, telemetry.NewNoopCustomMetrics(), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+
+	for _, minBytes := range []int{0, 1024} {
+		t.Run(fmt.Sprintf("stream response min bytes %d", minBytes), func(t *testing.T) {
+			s := &Server{
+				mcpService: mcpService,
+				httpConfig: HTTPServerConfig{StreamResponseMinBytes: minBytes},
+				logger:     logger.NewNop(),
+			}
+			router := gin.New()
+			router.POST("/tools/invoke", s.invokeToolHandler())
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/tools/invoke", strings.NewReader(`{"name": "files__read"}`))
+			router.ServeHTTP(w, req)
+
+			// streamed or not, the client gets the same response
+			testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+			testhelpers.AssertStringContains(t, w.Header().Get("Content-Type"), "application/json")
+			var res types.ToolInvokeResult
+			testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+			testhelpers.AssertEqual(t, 3, len(res.Content))
+			testhelpers.AssertEqual(t, large, res.Content[0]["text"])
+			testhelpers.AssertEqual(t, "eof", res.Content[2]["text"])
+		})
+	}
+}
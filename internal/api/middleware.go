@@ -2,15 +2,117 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/requestid"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcpclient"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// requestID is middleware that assigns an ID to every request.
+// The ID supplied by the client in the X-Request-ID header is honored if it is valid, otherwise a new one is generated.
+// The ID is returned in the response header, stored in the gin & request contexts, recorded on the
+// current trace span and forwarded to upstream MCP servers, so that a tool call can be traced end to end.
+func requestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestid.Header)
+		if !requestid.IsValid(id) {
+			id = requestid.New()
+		}
+
+		c.Set(requestid.ContextKey, id)
+		c.Request = c.Request.WithContext(requestid.NewContext(c.Request.Context(), id))
+		c.Header(requestid.Header, id)
+		trace.SpanFromContext(c.Request.Context()).SetAttributes(attribute.String("http.request.id", id))
+
+		c.Next()
+	}
+}
+
+// accessLog is middleware that writes a structured access log entry for every request served.
+// The entry identifies the caller (authenticated user or MCP client) and the request ID,
+// so that a request can be correlated with the logs of upstream MCP servers.
+func accessLog(l logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		started := time.Now()
+		path := c.Request.URL.Path
+		c.Next()
+
+		status := c.Writer.Status()
+		fields := []logger.Field{
+			logger.String("method", c.Request.Method),
+			logger.String("path", path),
+			logger.Int("status", status),
+			logger.Float64("latency_ms", float64(time.Since(started).Microseconds())/1000),
+			logger.String("client_ip", c.ClientIP()),
+		}
+		if id := c.GetString(requestid.ContextKey); id != "" {
+			fields = append(fields, logger.String("request_id", id))
+		}
+		if u, ok := c.Get("user"); ok {
+			if u, ok := u.(*model.User); ok && u != nil {
+				fields = append(fields, logger.String("user", u.Username))
+			}
+		}
+		if mc, ok := c.Request.Context().Value("client").(*model.McpClient); ok && mc != nil {
+			fields = append(fields, logger.String("mcp_client", mc.Name))
+		}
+		if len(c.Errors) > 0 {
+			fields = append(fields, logger.String("error", c.Errors.String()))
+		}
+
+		if status >= http.StatusInternalServerError {
+			l.Error("request", fields...)
+			return
+		}
+		l.Info("request", fields...)
+	}
+}
+
+// degradedModePostRoutes are the POST routes of the registry API that don't change the registry.
+// Like the MCP proxy, they're served from memory while the database is unavailable.
+var degradedModePostRoutes = []string{"/tools/invoke", "/prompts/render", "/servers/test"}
+
+// rejectWritesWhenDegraded is middleware that rejects the requests that change the registry
+// with a 503 Service Unavailable while the database is unavailable, ie, the server runs in degraded mode.
+// Read requests are let through, they fail on their own if they need the database.
+func (s *Server) rejectWritesWhenDegraded() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if s.dbMonitor == nil || s.dbMonitor.Available() {
+			c.Next()
+			return
+		}
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		case http.MethodPost:
+			// the routes are registered under several API prefixes, so match them by their suffix
+			for _, route := range degradedModePostRoutes {
+				if strings.HasSuffix(c.FullPath(), route) {
+					c.Next()
+					return
+				}
+			}
+		}
+		c.Header("Retry-After", "30")
+		c.AbortWithStatusJSON(
+			http.StatusServiceUnavailable,
+			gin.H{"error": "the database is unavailable, the registry is read-only until it is back"},
+		)
+	}
+}
+
 // requireInitialized is middleware to reject requests to certain routes if the server is not initialized
 func (s *Server) requireInitialized() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -58,6 +160,13 @@ func (s *Server) verifyUserAuthForAPIAccess() gin.HandlerFunc {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid access token: " + err.Error()})
 			return
 		}
+		if !authenticatedUser.Active {
+			c.AbortWithStatusJSON(
+				http.StatusForbidden,
+				gin.H{"error": "user " + authenticatedUser.Username + " is suspended, ask an administrator to reactivate it"},
+			)
+			return
+		}
 
 		// Store user in context for potential role checks in subsequent handlers
 		c.Set("user", authenticatedUser)
@@ -68,6 +177,41 @@ func (s *Server) verifyUserAuthForAPIAccess() gin.HandlerFunc {
 // requireAdminUser is middleware that ensures the authenticated user has an admin role when in enterprise mode.
 // It assumes that verifyUserAuthForAPIAccess middleware has already run and set the user in context.
 func (s *Server) requireAdminUser() gin.HandlerFunc {
+	return s.authorizeUser(func(c *gin.Context, u *model.User) (bool, error) {
+		return false, nil
+	})
+}
+
+// teamPermission is a kind of entity that the members of a team may be allowed to administer.
+type teamPermission string
+
+const (
+	teamPermissionToolGroup teamPermission = "tool_group"
+	teamPermissionServer    teamPermission = "server"
+)
+
+// requireAdminOrTeamPermission is middleware that ensures the authenticated user is an admin or belongs to a team
+// that may administer the entity named by the "name" path parameter, when in enterprise mode.
+// It assumes that verifyUserAuthForAPIAccess middleware has already run and set the user in context.
+func (s *Server) requireAdminOrTeamPermission(p teamPermission) gin.HandlerFunc {
+	return s.authorizeUser(func(c *gin.Context, u *model.User) (bool, error) {
+		if s.teamService == nil {
+			return false, nil
+		}
+		switch p {
+		case teamPermissionToolGroup:
+			return s.teamService.CanAdministerToolGroup(u.Username, c.Param("name"))
+		case teamPermissionServer:
+			return s.teamService.CanAdministerServer(u.Username, c.Param("name"))
+		default:
+			return false, fmt.Errorf("unknown team permission: %s", p)
+		}
+	})
+}
+
+// authorizeUser returns middleware that lets admin users through in enterprise mode,
+// and the other users only if allowed returns true for them. Everyone is let through in development mode.
+func (s *Server) authorizeUser(allowed func(c *gin.Context, u *model.User) (bool, error)) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		mode, exists := c.Get("mode")
 		if !exists {
@@ -96,6 +240,17 @@ func (s *Server) requireAdminUser() gin.HandlerFunc {
 			c.Next()
 			return
 		}
+		if ok && u != nil {
+			ok, err := allowed(c, u)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			if ok {
+				c.Next()
+				return
+			}
+		}
 
 		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user is not authorized to perform this action"})
 	}
@@ -170,6 +325,10 @@ func (s *Server) checkAuthForMcpProxyAccess() gin.HandlerFunc {
 			return
 		}
 		client, err := s.mcpClientService.GetClientByToken(token)
+		if errors.Is(err, mcpclient.ErrClientTokenExpired) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "MCP client token has expired"})
+			return
+		}
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid MCP client token"})
 			return
@@ -182,3 +341,33 @@ func (s *Server) checkAuthForMcpProxyAccess() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// deprecatedAPI is middleware that marks all responses of a deprecated API version as such.
+// It sets the Deprecation & Sunset (RFC 8594) headers and links to the successor API version,
+// so that integrators can detect that they must migrate before the sunset date.
+func deprecatedAPI(sunset time.Time, successorPathPrefix string) gin.HandlerFunc {
+	sunsetHeader := sunset.UTC().Format(http.TimeFormat)
+	linkHeader := fmt.Sprintf("<%s>; rel=\"successor-version\"", successorPathPrefix)
+
+	return func(c *gin.Context) {
+		c.Header("Deprecation", "true")
+		c.Header("Sunset", sunsetHeader)
+		c.Header("Link", linkHeader)
+		c.Next()
+	}
+}
+
+// httpMetrics is middleware that records the method, route, status & duration of every request.
+func httpMetrics(m *telemetry.PrometheusMetrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		started := time.Now()
+		c.Next()
+
+		// use the route pattern rather than the path, so that path parameters don't create new time series
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		m.RecordHTTPRequest(c.Request.Method, route, c.Writer.Status(), time.Since(started))
+	}
+}
@@ -1,20 +1,226 @@
 package api
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/db"
 	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/requestid"
 	"github.com/mcpjungle/mcpjungle/internal/service/config"
 	"github.com/mcpjungle/mcpjungle/internal/service/mcpclient"
+	"github.com/mcpjungle/mcpjungle/internal/service/team"
 	"github.com/mcpjungle/mcpjungle/internal/service/user"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
+func TestRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(requestID())
+	router.GET("/test", func(c *gin.Context) {
+		// the ID must be available from both the gin context & the request's context
+		testhelpers.AssertEqual(t, c.GetString(requestid.ContextKey), requestid.FromContext(c.Request.Context()))
+		testhelpers.AssertEqual(t, c.GetString(requestid.ContextKey), requestid.FromContext(c))
+		c.String(http.StatusOK, requestid.FromContext(c.Request.Context()))
+	})
+
+	tests := []struct {
+		name     string
+		header   string
+		generate bool
+	}{
+		{name: "generated when not supplied", header: "", generate: true},
+		{name: "client supplied ID is honored", header: "client-req-1", generate: false},
+		{name: "invalid client supplied ID is replaced", header: "not valid", generate: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if tt.header != "" {
+				req.Header.Set(requestid.Header, tt.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			id := w.Header().Get(requestid.Header)
+			testhelpers.AssertEqual(t, id, w.Body.String())
+			testhelpers.AssertTrue(t, requestid.IsValid(id), "Expected a valid request ID in the response")
+			if tt.generate {
+				testhelpers.AssertTrue(t, id != tt.header, "Expected a new request ID to be generated")
+			} else {
+				testhelpers.AssertEqual(t, tt.header, id)
+			}
+		})
+	}
+}
+
+// recordingLogger is a logger.Logger that keeps the entries logged at each level
+type recordingLogger struct {
+	entries []recordedEntry
+}
+
+type recordedEntry struct {
+	level  string
+	msg    string
+	fields map[string]any
+}
+
+func (l *recordingLogger) record(level, msg string, fields []logger.Field) {
+	e := recordedEntry{level: level, msg: msg, fields: make(map[string]any)}
+	for _, f := range fields {
+		e.fields[f.Key] = f.Value
+	}
+	l.entries = append(l.entries, e)
+}
+
+func (l *recordingLogger) Debug(msg string, fields ...logger.Field) { l.record("debug", msg, fields) }
+func (l *recordingLogger) Info(msg string, fields ...logger.Field)  { l.record("info", msg, fields) }
+func (l *recordingLogger) Warn(msg string, fields ...logger.Field)  { l.record("warn", msg, fields) }
+func (l *recordingLogger) Error(msg string, fields ...logger.Field) { l.record("error", msg, fields) }
+func (l *recordingLogger) WithFields(...logger.Field) logger.Logger { return l }
+func (l *recordingLogger) Sync() error                              { return nil }
+
+func TestAccessLog(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	l := &recordingLogger{}
+	router := gin.New()
+	router.Use(accessLog(l), requestID())
+	router.GET("/api/v1/servers", func(c *gin.Context) {
+		c.Set("user", &model.User{Username: "alice"})
+		c.Status(http.StatusOK)
+	})
+	router.POST("/mcp", func(c *gin.Context) {
+		ctx := context.WithValue(c.Request.Context(), "client", &model.McpClient{Name: "cursor"})
+		c.Request = c.Request.WithContext(ctx)
+		c.Status(http.StatusBadGateway)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/servers?limit=5", nil)
+	req.Header.Set(requestid.Header, "req-123")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/mcp", nil))
+
+	testhelpers.AssertEqual(t, 2, len(l.entries))
+
+	e := l.entries[0]
+	testhelpers.AssertEqual(t, "info", e.level)
+	testhelpers.AssertEqual(t, http.MethodGet, e.fields["method"])
+	testhelpers.AssertEqual(t, "/api/v1/servers", e.fields["path"])
+	testhelpers.AssertEqual(t, http.StatusOK, e.fields["status"])
+	testhelpers.AssertEqual(t, "req-123", e.fields["request_id"])
+	testhelpers.AssertEqual(t, "alice", e.fields["user"])
+	_, ok := e.fields["latency_ms"].(float64)
+	testhelpers.AssertTrue(t, ok, "Expected the latency to be logged")
+
+	e = l.entries[1]
+	testhelpers.AssertEqual(t, "error", e.level)
+	testhelpers.AssertEqual(t, http.StatusBadGateway, e.fields["status"])
+	testhelpers.AssertEqual(t, "cursor", e.fields["mcp_client"])
+	_, ok = e.fields["user"]
+	testhelpers.AssertFalse(t, ok, "Expected no user to be logged for MCP client requests")
+}
+
+func TestHTTPMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	m, err := telemetry.NewPrometheusMetrics(prometheus.NewRegistry())
+	testhelpers.AssertNoError(t, err)
+
+	router := gin.New()
+	router.Use(httpMetrics(m))
+	router.GET("/servers/:name", func(c *gin.Context) {
+		c.Status(http.StatusNotFound)
+	})
+	router.GET("/metrics", gin.WrapH(m.Handler()))
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/servers/calculator", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/nowhere", nil))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	testhelpers.AssertStringContains(
+		t, w.Body.String(), `mcpjungle_http_requests_total{method="GET",route="/servers/:name",status="404"} 1`,
+	)
+	testhelpers.AssertStringContains(
+		t, w.Body.String(), `mcpjungle_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+	)
+}
+
+// newUnavailableDBMonitor returns a monitor of a database that has become unavailable.
+func newUnavailableDBMonitor(t *testing.T) *db.Monitor {
+	t.Helper()
+	conn, err := testhelpers.CreateTestDB()
+	testhelpers.AssertNoError(t, err)
+	sqlDB, err := conn.DB()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, sqlDB.Close())
+
+	m := db.NewMonitor(conn, db.DefaultMonitorConfig(), logger.NewNop())
+	testhelpers.AssertFalse(t, m.Check(context.Background()), "Expected the database to be unavailable")
+	return m
+}
+
+func TestRejectWritesWhenDegraded(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		degraded bool
+		method   string
+		path     string
+		expected int
+	}{
+		{name: "write allowed when database is available", degraded: false, method: http.MethodPost, path: "/api/v1/servers", expected: http.StatusOK},
+		{name: "read allowed when degraded", degraded: true, method: http.MethodGet, path: "/api/v1/servers", expected: http.StatusOK},
+		{name: "write rejected when degraded", degraded: true, method: http.MethodPost, path: "/api/v1/servers", expected: http.StatusServiceUnavailable},
+		{name: "delete rejected when degraded", degraded: true, method: http.MethodDelete, path: "/api/v1/servers", expected: http.StatusServiceUnavailable},
+		{name: "tool invocation allowed when degraded", degraded: true, method: http.MethodPost, path: "/api/v1/tools/invoke", expected: http.StatusOK},
+		{name: "prompt rendering allowed when degraded", degraded: true, method: http.MethodPost, path: "/api/v0/prompts/render", expected: http.StatusOK},
+		{name: "server test allowed when degraded", degraded: true, method: http.MethodPost, path: "/api/v1/servers/test", expected: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{}
+			if tt.degraded {
+				s.dbMonitor = newUnavailableDBMonitor(t)
+			}
+			router := gin.New()
+			router.Use(s.rejectWritesWhenDegraded())
+			ok := func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			}
+			for _, prefix := range []string{"/api/v0", "/api/v1"} {
+				router.Any(prefix+"/servers", ok)
+				for _, route := range degradedModePostRoutes {
+					router.POST(prefix+route, ok)
+				}
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+			testhelpers.AssertEqual(t, tt.expected, w.Code)
+			if tt.expected == http.StatusServiceUnavailable {
+				testhelpers.AssertEqual(t, "30", w.Header().Get("Retry-After"))
+			}
+		})
+	}
+}
+
 func TestRequireInitialized(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -134,6 +340,18 @@ func TestVerifyUserAuthForAPIAccess(t *testing.T) {
 			expectedStatus: http.StatusUnauthorized,
 			expectedBody:   `{"error":"missing access token"}`,
 		},
+		{
+			name:       "enterprise mode - suspended user",
+			mode:       model.ModeEnterprise,
+			authHeader: "Bearer suspended-token",
+			setupUser: func() error {
+				return testDB.Create(&model.User{
+					Username: "alice", Role: types.UserRoleUser, AccessToken: "suspended-token",
+				}).Update("active", false).Error
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"error":"user alice is suspended, ask an administrator to reactivate it"}`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -249,6 +467,60 @@ func TestRequireAdminUser(t *testing.T) {
 	}
 }
 
+func TestRequireAdminOrTeamPermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testDB := testhelpers.SetupTestDB(t).DB
+	teamService := team.NewTeamService(testDB)
+	alice := &model.User{Model: gorm.Model{ID: 2}, Username: "alice", Role: types.UserRoleUser, AccessToken: "alice"}
+	testhelpers.AssertNoError(t, testDB.Create(alice).Error)
+	testhelpers.AssertNoError(t, teamService.CreateTeam(&model.Team{
+		Name:       "platform",
+		Members:    datatypes.JSON(`["alice"]`),
+		ToolGroups: datatypes.JSON(`["claude-tools"]`),
+		Servers:    datatypes.JSON(`["github"]`),
+	}))
+	bob := &model.User{Model: gorm.Model{ID: 3}, Username: "bob", Role: types.UserRoleUser}
+	admin := &model.User{Model: gorm.Model{ID: 1}, Username: "admin", Role: types.UserRoleAdmin}
+
+	tests := []struct {
+		name           string
+		mode           model.ServerMode
+		user           *model.User
+		permission     teamPermission
+		path           string
+		expectedStatus int
+	}{
+		{"dev mode", model.ModeDev, nil, teamPermissionServer, "/slack", http.StatusOK},
+		{"admin user", model.ModeEnterprise, admin, teamPermissionServer, "/slack", http.StatusOK},
+		{"member of a team allowed to administer the server", model.ModeEnterprise, alice, teamPermissionServer, "/github", http.StatusOK},
+		{"member of a team not allowed to administer the server", model.ModeEnterprise, alice, teamPermissionServer, "/slack", http.StatusForbidden},
+		{"member of a team allowed to administer the tool group", model.ModeEnterprise, alice, teamPermissionToolGroup, "/claude-tools", http.StatusOK},
+		{"permission granted for another kind of entity", model.ModeEnterprise, alice, teamPermissionToolGroup, "/github", http.StatusForbidden},
+		{"user without a team", model.ModeEnterprise, bob, teamPermissionServer, "/github", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set("mode", tt.mode)
+				if tt.user != nil {
+					c.Set("user", tt.user)
+				}
+			})
+			server := &Server{teamService: teamService}
+			router.GET("/:name", server.requireAdminOrTeamPermission(tt.permission), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			testhelpers.AssertEqual(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
 func TestRequireServerMode(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -396,6 +668,27 @@ func TestCheckAuthForMcpProxyAccess(t *testing.T) {
 			expectedStatus: http.StatusUnauthorized,
 			expectedBody:   `{"error":"missing MCP client access token"}`,
 		},
+		{
+			name:       "enterprise mode - expired token",
+			mode:       model.ModeEnterprise,
+			authHeader: "Bearer expired-token",
+			setupClient: func() error {
+				client := model.McpClient{Name: "expired-client", TokenTTLSeconds: 60}
+				if _, err := mcpClientService.CreateClient(client); err != nil {
+					return err
+				}
+				var c model.McpClient
+				if err := testDB.Where("name = ?", "expired-client").First(&c).Error; err != nil {
+					return err
+				}
+				expiredAt := time.Now().Add(-time.Minute)
+				c.AccessToken = "expired-token"
+				c.TokenExpiresAt = &expiredAt
+				return testDB.Save(&c).Error
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"MCP client token has expired"}`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,772 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/idempotency"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/mcpjungle/mcpjungle/pkg/version"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// OpenAPISpecPath is the path at which the OpenAPI document of the registry API is served.
+const OpenAPISpecPath = "/api/openapi.json"
+
+// apiParam describes a query parameter accepted by a registry API endpoint.
+type apiParam struct {
+	name        string
+	description string
+	required    bool
+	// schema is the JSON schema of the parameter's value, defaults to string.
+	schema map[string]any
+}
+
+// apiOperation describes a single registry API endpoint for the OpenAPI document.
+// Every route registered by registerAPIRoutes & registerMcpClientTokenRoutes must have a corresponding operation
+// in apiOperations.
+type apiOperation struct {
+	method  string
+	path    string
+	tag     string
+	summary string
+
+	// admin is true if the endpoint requires an admin user in enterprise mode.
+	admin bool
+	// mcpClient is true if the endpoint is called by an MCP client with its own access token, instead of a user.
+	mcpClient bool
+	// teamAdmin is true if the members of the teams allowed to administer the entity can also access an admin endpoint.
+	teamAdmin bool
+	// enterpriseOnly is true if the endpoint is only available in enterprise mode.
+	enterpriseOnly bool
+	// paginated is true if the endpoint accepts the limit & offset query parameters.
+	paginated bool
+	// conditional is true if the endpoint returns an ETag and honors the If-None-Match header.
+	conditional bool
+	// idempotent is true if the endpoint honors the Idempotency-Key header.
+	idempotent bool
+
+	query []apiParam
+
+	// request is a value of the type accepted as the request body, nil if the endpoint takes no body.
+	request any
+	// requestSchema overrides the schema derived from request.
+	requestSchema map[string]any
+	// requestOptional is true if the request body may be omitted.
+	requestOptional bool
+
+	// status is the HTTP status code of a successful response.
+	status int
+	// response is a value of the type returned on success, nil if the endpoint returns no body.
+	response any
+	// contentType is the media type of the successful response, defaults to application/json.
+	// For an event stream, response is the type of the data of each event.
+	contentType string
+	// altResponse is a value of another type the endpoint returns on success, depending on the request.
+	altResponse any
+	// altStatus is the HTTP status code of the alternative response, if it differs from status.
+	altStatus int
+}
+
+var (
+	boolSchema   = map[string]any{"type": "boolean"}
+	stringSchema = map[string]any{"type": "string"}
+)
+
+// apiOperations lists all registry API endpoints, relative to the API version prefix.
+var apiOperations = []apiOperation{
+	{
+		method: http.MethodGet, path: "/servers", tag: "servers", summary: "List registered MCP servers",
+		paginated: true, conditional: true, status: http.StatusOK, response: []types.McpServer{},
+	},
+	{
+		method: http.MethodPost, path: "/servers", tag: "servers", summary: "Register an MCP server",
+		admin: true, idempotent: true,
+		request: types.RegisterServerInput{}, status: http.StatusCreated, response: model.McpServer{},
+	},
+	{
+		method: http.MethodPost, path: "/servers/test", tag: "servers",
+		summary: "Test the connectivity to a registered or not yet registered MCP server",
+		admin:   true, request: types.TestServerInput{}, status: http.StatusOK, response: types.ServerTestReport{},
+	},
+	{
+		method: http.MethodPut, path: "/servers/:name", tag: "servers",
+		summary: "Update the configuration of a registered MCP server",
+		admin:   true, teamAdmin: true,
+		request: types.RegisterServerInput{}, status: http.StatusOK, response: model.McpServer{},
+	},
+	{
+		method: http.MethodDelete, path: "/servers/:name", tag: "servers", summary: "Deregister an MCP server",
+		admin: true, teamAdmin: true, status: http.StatusNoContent,
+	},
+	{
+		method: http.MethodGet, path: "/servers/:name/consumers", tag: "servers",
+		summary: "List the MCP clients and tool groups that reference an MCP server",
+		admin:   true, teamAdmin: true, status: http.StatusOK, response: types.ServerConsumers{},
+	},
+	{
+		method: http.MethodPost, path: "/servers/:name/enable", tag: "servers",
+		summary: "Enable all tools and prompts of an MCP server",
+		admin:   true, teamAdmin: true, status: http.StatusOK, response: types.EnableDisableServerResult{},
+	},
+	{
+		method: http.MethodPost, path: "/servers/:name/disable", tag: "servers",
+		summary: "Disable all tools and prompts of an MCP server",
+		admin:   true, teamAdmin: true, status: http.StatusOK, response: types.EnableDisableServerResult{},
+	},
+	{
+		method: http.MethodGet, path: "/tools", tag: "tools", summary: "List tools",
+		paginated: true, conditional: true,
+		query: []apiParam{
+			{name: "server", description: "Only list the tools provided by this MCP server"},
+			{name: "enabled", description: "Only list enabled or disabled tools", schema: boolSchema},
+			{name: "name_contains", description: "Only list tools whose name contains this string (case-insensitive)"},
+			{
+				name:        "sort",
+				description: "Order in which tools are listed",
+				schema: map[string]any{
+					"type": "string",
+					"enum": []types.ToolSortKey{types.ToolSortName, types.ToolSortServer, types.ToolSortUpdatedAt},
+				},
+			},
+		},
+		status: http.StatusOK, response: []types.Tool{},
+	},
+	{
+		method: http.MethodPost, path: "/tools/invoke", tag: "tools", summary: "Invoke a tool",
+		requestSchema: map[string]any{
+			"type":        "object",
+			"description": "Canonical name of the tool to invoke, along with the tool's input arguments",
+			"properties": map[string]any{
+				"name": stringSchema,
+			},
+			"required":             []string{"name"},
+			"additionalProperties": true,
+		},
+		query: []apiParam{
+			{
+				name:        "async",
+				description: "Invoke the tool in the background and respond immediately with a job",
+				schema:      boolSchema,
+			},
+			{
+				name: "timeout",
+				description: "Maximum time to wait for the upstream MCP server (eg- 30s), " +
+					"a 504 is returned if it doesn't respond in time. Ignored for async invocations",
+			},
+			{
+				name: "stream",
+				description: "Stream the progress notifications of the upstream MCP server and then the result " +
+					"as server-sent events (ToolInvokeStreamEvent). Cannot be used with async",
+				schema: boolSchema,
+			},
+		},
+		status: http.StatusOK, response: types.ToolInvokeResult{},
+		altStatus: http.StatusAccepted, altResponse: types.ToolInvocationJob{},
+	},
+	{
+		method: http.MethodGet, path: "/jobs/:id", tag: "tools",
+		summary: "Get the status & result of an asynchronous tool invocation",
+		status:  http.StatusOK, response: types.ToolInvocationJob{},
+	},
+	{
+		method: http.MethodGet, path: "/tool", tag: "tools", summary: "Get a tool",
+		query:  []apiParam{{name: "name", description: "Canonical name of the tool", required: true}},
+		status: http.StatusOK, response: types.Tool{},
+	},
+	{
+		method: http.MethodPost, path: "/tools/enable", tag: "tools",
+		summary: "Enable tools by name, MCP server or glob pattern",
+		admin:   true,
+		query: []apiParam{{
+			name:        "entity",
+			description: "Canonical tool name or MCP server name. If omitted, the entities are read from the request body.",
+		}},
+		request: types.BulkEntitiesRequest{}, requestOptional: true,
+		status: http.StatusOK, response: []string{}, altResponse: []types.BulkEntityResult{},
+	},
+	{
+		method: http.MethodPost, path: "/tools/disable", tag: "tools",
+		summary: "Disable tools by name, MCP server or glob pattern",
+		admin:   true,
+		query: []apiParam{{
+			name:        "entity",
+			description: "Canonical tool name or MCP server name. If omitted, the entities are read from the request body.",
+		}},
+		request: types.BulkEntitiesRequest{}, requestOptional: true,
+		status: http.StatusOK, response: []string{}, altResponse: []types.BulkEntityResult{},
+	},
+	{
+		method: http.MethodGet, path: "/prompts", tag: "prompts", summary: "List prompts",
+		paginated: true,
+		query:     []apiParam{{name: "server", description: "Only list the prompts provided by this MCP server"}},
+		status:    http.StatusOK, response: []model.Prompt{},
+	},
+	{
+		method: http.MethodGet, path: "/prompt", tag: "prompts", summary: "Get a prompt",
+		query:  []apiParam{{name: "name", description: "Canonical name of the prompt", required: true}},
+		status: http.StatusOK, response: model.Prompt{},
+	},
+	{
+		method: http.MethodPost, path: "/prompts/render", tag: "prompts", summary: "Render a prompt with arguments",
+		request: types.PromptGetRequest{}, status: http.StatusOK, response: types.PromptResult{},
+	},
+	{
+		method: http.MethodPost, path: "/prompts/enable", tag: "prompts",
+		summary: "Enable a prompt or all prompts of an MCP server",
+		admin:   true,
+		query:   []apiParam{{name: "entity", description: "Canonical prompt name or MCP server name", required: true}},
+		status:  http.StatusOK, response: []string{},
+	},
+	{
+		method: http.MethodPost, path: "/prompts/disable", tag: "prompts",
+		summary: "Disable a prompt or all prompts of an MCP server",
+		admin:   true,
+		query:   []apiParam{{name: "entity", description: "Canonical prompt name or MCP server name", required: true}},
+		status:  http.StatusOK, response: []string{},
+	},
+	{
+		method: http.MethodGet, path: "/search", tag: "search", summary: "Search tools and prompts",
+		paginated: true,
+		query: []apiParam{
+			{name: "q", description: "Search query", required: true},
+			{
+				name:        "type",
+				description: "Only return results of this type",
+				schema: map[string]any{
+					"type": "string",
+					"enum": []types.SearchResultType{types.SearchResultTool, types.SearchResultPrompt},
+				},
+			},
+		},
+		status: http.StatusOK, response: []types.SearchResult{},
+	},
+	{
+		method: http.MethodGet, path: "/users/whoami", tag: "users", summary: "Get the authenticated user",
+		enterpriseOnly: true, status: http.StatusOK, response: types.User{},
+	},
+	{
+		method: http.MethodPost, path: "/users/me/token", tag: "users", summary: "Renew the authenticated user's access token",
+		enterpriseOnly: true, status: http.StatusOK, response: types.RenewAccessTokenResponse{},
+	},
+	{
+		method: http.MethodGet, path: "/clients", tag: "clients", summary: "List MCP clients",
+		admin: true, enterpriseOnly: true, paginated: true, status: http.StatusOK, response: []model.McpClient{},
+	},
+	{
+		method: http.MethodPost, path: "/clients", tag: "clients", summary: "Create an MCP client",
+		admin: true, enterpriseOnly: true, idempotent: true,
+		request: types.McpClient{}, status: http.StatusCreated, response: model.McpClient{},
+	},
+	{
+		method: http.MethodPost, path: "/clients/token/renew", tag: "clients",
+		summary:   "Renew the authenticated MCP client's access token",
+		mcpClient: true, enterpriseOnly: true, status: http.StatusOK, response: types.RenewMcpClientTokenResponse{},
+	},
+	{
+		method: http.MethodPatch, path: "/clients/:name", tag: "clients", summary: "Update an MCP client",
+		admin: true, enterpriseOnly: true,
+		request: types.PatchMcpClientInput{}, status: http.StatusOK, response: model.McpClient{},
+	},
+	{
+		method: http.MethodDelete, path: "/clients/:name", tag: "clients", summary: "Delete an MCP client",
+		admin: true, enterpriseOnly: true, status: http.StatusNoContent,
+	},
+	{
+		method: http.MethodPost, path: "/users", tag: "users", summary: "Create a user",
+		admin: true, enterpriseOnly: true, idempotent: true,
+		request: types.CreateUserRequest{}, status: http.StatusCreated, response: types.CreateUserResponse{},
+	},
+	{
+		method: http.MethodGet, path: "/users", tag: "users", summary: "List users",
+		admin: true, enterpriseOnly: true, paginated: true, status: http.StatusOK, response: []types.User{},
+	},
+	{
+		method: http.MethodDelete, path: "/users/:username", tag: "users", summary: "Delete a user",
+		admin: true, enterpriseOnly: true, status: http.StatusNoContent,
+	},
+	{
+		method: http.MethodPost, path: "/users/:username/suspend", tag: "users", summary: "Suspend a user",
+		admin: true, enterpriseOnly: true, status: http.StatusOK, response: types.User{},
+	},
+	{
+		method: http.MethodPost, path: "/users/:username/activate", tag: "users", summary: "Reactivate a suspended user",
+		admin: true, enterpriseOnly: true, status: http.StatusOK, response: types.User{},
+	},
+	{
+		method: http.MethodPut, path: "/users/:username/tool-policy", tag: "users", summary: "Restrict a user's tools",
+		admin: true, enterpriseOnly: true,
+		request: types.ToolPolicy{}, status: http.StatusOK, response: types.User{},
+	},
+	{
+		method: http.MethodDelete, path: "/users/:username/tool-policy", tag: "users", summary: "Lift a user's tool restrictions",
+		admin: true, enterpriseOnly: true, status: http.StatusNoContent,
+	},
+	{
+		method: http.MethodPost, path: "/teams", tag: "teams", summary: "Create a team",
+		admin: true, enterpriseOnly: true, idempotent: true,
+		request: types.Team{}, status: http.StatusCreated, response: types.Team{},
+	},
+	{
+		method: http.MethodGet, path: "/teams", tag: "teams", summary: "List teams",
+		admin: true, enterpriseOnly: true, paginated: true, status: http.StatusOK, response: []types.Team{},
+	},
+	{
+		method: http.MethodGet, path: "/teams/:name", tag: "teams", summary: "Get a team",
+		admin: true, enterpriseOnly: true, status: http.StatusOK, response: types.Team{},
+	},
+	{
+		method: http.MethodPut, path: "/teams/:name", tag: "teams",
+		summary: "Update the description, members and permissions of a team",
+		admin:   true, enterpriseOnly: true, request: types.Team{}, status: http.StatusOK, response: types.Team{},
+	},
+	{
+		method: http.MethodDelete, path: "/teams/:name", tag: "teams", summary: "Delete a team",
+		admin: true, enterpriseOnly: true, status: http.StatusNoContent,
+	},
+	{
+		method: http.MethodPost, path: "/tool-groups", tag: "tool-groups", summary: "Create a tool group",
+		admin: true, idempotent: true,
+		request: types.ToolGroup{}, status: http.StatusCreated, response: types.CreateToolGroupResponse{},
+	},
+	{
+		method: http.MethodGet, path: "/tool-groups/:name", tag: "tool-groups", summary: "Get a tool group",
+		admin: true, teamAdmin: true, status: http.StatusOK, response: types.GetToolGroupResponse{},
+	},
+	{
+		method: http.MethodGet, path: "/tool-groups", tag: "tool-groups", summary: "List tool groups",
+		admin: true, paginated: true, conditional: true, status: http.StatusOK, response: []types.ToolGroup{},
+	},
+	{
+		method: http.MethodDelete, path: "/tool-groups/:name", tag: "tool-groups", summary: "Delete a tool group",
+		admin: true, teamAdmin: true, status: http.StatusNoContent,
+	},
+	{
+		method: http.MethodPut, path: "/tool-groups/:name", tag: "tool-groups", summary: "Update a tool group",
+		admin: true, teamAdmin: true,
+		request: types.ToolGroup{}, status: http.StatusOK, response: types.UpdateToolGroupResponse{},
+	},
+	{
+		method: http.MethodPatch, path: "/tool-groups/:name", tag: "tool-groups",
+		summary: "Update only the given fields of a tool group",
+		admin:   true, teamAdmin: true,
+		request: types.PatchToolGroupInput{}, status: http.StatusOK, response: types.UpdateToolGroupResponse{},
+	},
+	{
+		method: http.MethodPost, path: "/tool-groups/validate", tag: "tool-groups",
+		summary: "Validate the configuration of a tool group without creating it",
+		admin:   true, request: types.ToolGroup{}, status: http.StatusOK, response: types.ValidateToolGroupResponse{},
+	},
+	{
+		method: http.MethodGet, path: "/stats", tag: "stats",
+		summary: "Get counts of registry entities and recent tool & prompt calls",
+		admin:   true, status: http.StatusOK, response: types.RegistryStats{},
+	},
+	{
+		method: http.MethodGet, path: "/invocations", tag: "stats",
+		summary: "List recent tool invocations, most recent first",
+		admin:   true, paginated: true,
+		query: []apiParam{
+			{name: "tool", description: "Only list the invocations of this tool (canonical name)"},
+			{name: "caller", description: "Only list the invocations made by this MCP client or user"},
+			{name: "client", description: "Only list the invocations made by this MCP client"},
+			{
+				name:        "outcome",
+				description: "Only list the invocations with this outcome",
+				schema: map[string]any{
+					"type": "string",
+					"enum": []types.InvocationOutcome{types.InvocationOutcomeSuccess, types.InvocationOutcomeError},
+				},
+			},
+			{
+				name:        "since",
+				description: "Only list the invocations made at or after this time",
+				schema:      map[string]any{"type": "string", "format": "date-time"},
+			},
+			{
+				name:        "until",
+				description: "Only list the invocations made at or before this time",
+				schema:      map[string]any{"type": "string", "format": "date-time"},
+			},
+		},
+		status: http.StatusOK, response: []types.ToolInvocation{},
+	},
+	{
+		method: http.MethodGet, path: "/events", tag: "events",
+		summary: "Stream registry change events as server-sent events",
+		admin:   true, status: http.StatusOK, response: types.RegistryEvent{}, contentType: "text/event-stream",
+	},
+	{
+		method: http.MethodGet, path: "/logs", tag: "logs",
+		summary: "Get the recent entries of the server's logs, oldest first",
+		admin:   true,
+		query: []apiParam{
+			{name: "server", description: "Only return the entries about this MCP server, including its process output"},
+			{
+				name:        "since",
+				description: "Only return the entries logged at or after this time",
+				schema:      map[string]any{"type": "string", "format": "date-time"},
+			},
+			{
+				name:        "follow",
+				description: "Stream the entries as server-sent events, followed by new entries as they are logged",
+				schema:      boolSchema,
+			},
+		},
+		status: http.StatusOK, response: []types.LogEntry{},
+	},
+}
+
+// pathParamRegex matches gin path parameters, eg- ":name"
+var pathParamRegex = regexp.MustCompile(`:(\w+)`)
+
+// openAPIPath converts a gin route path to an OpenAPI path template, eg- "/servers/:name" -> "/servers/{name}"
+func openAPIPath(p string) string {
+	return pathParamRegex.ReplaceAllString(p, "{$1}")
+}
+
+// buildOpenAPISpec generates the OpenAPI 3 document of the stable (v1) registry API.
+// Request and response schemas are derived from the Go types used by the API handlers.
+func buildOpenAPISpec() map[string]any {
+	schemas := newSchemaRegistry()
+	schemas.schemas["Error"] = map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"error": stringSchema},
+	}
+	errorResponse := map[string]any{
+		"description": "Error",
+		"content": map[string]any{
+			"application/json": map[string]any{
+				"schema": map[string]any{"$ref": "#/components/schemas/Error"},
+			},
+		},
+	}
+
+	paths := map[string]any{}
+	for _, op := range apiOperations {
+		p := openAPIPath(V1ApiPathPrefix + op.path)
+
+		var params []map[string]any
+		for _, m := range pathParamRegex.FindAllStringSubmatch(op.path, -1) {
+			params = append(params, map[string]any{
+				"name": m[1], "in": "path", "required": true, "schema": stringSchema,
+			})
+		}
+		for _, q := range op.query {
+			schema := q.schema
+			if schema == nil {
+				schema = stringSchema
+			}
+			param := map[string]any{"name": q.name, "in": "query", "required": q.required, "schema": schema}
+			if q.description != "" {
+				param["description"] = q.description
+			}
+			params = append(params, param)
+		}
+		if op.paginated {
+			params = append(params,
+				map[string]any{
+					"name":        types.LimitQueryParam,
+					"in":          "query",
+					"description": "Maximum number of items to return. All items are returned if not set.",
+					"schema":      map[string]any{"type": "integer", "minimum": 1},
+				},
+				map[string]any{
+					"name":        types.OffsetQueryParam,
+					"in":          "query",
+					"description": "Number of items to skip",
+					"schema":      map[string]any{"type": "integer", "minimum": 0},
+				},
+			)
+		}
+
+		if op.conditional {
+			params = append(params, map[string]any{
+				"name":        "If-None-Match",
+				"in":          "header",
+				"description": "ETag of a previous response, the response is empty if it hasn't changed since",
+				"schema":      stringSchema,
+			})
+		}
+		if op.idempotent {
+			params = append(params, map[string]any{
+				"name": IdempotencyKeyHeader,
+				"in":   "header",
+				"description": "Unique key chosen by the client, so that the request can be safely retried: " +
+					"the response to the first request sent with the key is returned again for the next ones",
+				"schema": map[string]any{"type": "string", "maxLength": idempotency.MaxKeyLength},
+			})
+		}
+
+		responses := map[string]any{"default": errorResponse}
+		success := map[string]any{"description": http.StatusText(op.status)}
+		if op.response != nil {
+			schema := schemas.schemaFor(reflect.TypeOf(op.response))
+			if op.altResponse != nil && op.altStatus != 0 {
+				responses[fmt.Sprint(op.altStatus)] = map[string]any{
+					"description": http.StatusText(op.altStatus),
+					"content": map[string]any{
+						"application/json": map[string]any{"schema": schemas.schemaFor(reflect.TypeOf(op.altResponse))},
+					},
+				}
+			} else if op.altResponse != nil {
+				schema = map[string]any{
+					"oneOf": []map[string]any{schema, schemas.schemaFor(reflect.TypeOf(op.altResponse))},
+				}
+			}
+			contentType := op.contentType
+			if contentType == "" {
+				contentType = "application/json"
+			}
+			success["content"] = map[string]any{
+				contentType: map[string]any{"schema": schema},
+			}
+		}
+		headers := map[string]any{}
+		if op.paginated {
+			headers[types.TotalCountHeader] = map[string]any{
+				"description": "Total number of items, regardless of limit & offset",
+				"schema":      map[string]any{"type": "integer"},
+			}
+		}
+		if op.conditional {
+			headers["ETag"] = map[string]any{
+				"description": "Identifies this version of the response",
+				"schema":      stringSchema,
+			}
+			responses[fmt.Sprint(http.StatusNotModified)] = map[string]any{
+				"description": "The response hasn't changed since the ETag supplied in If-None-Match",
+			}
+		}
+		if op.idempotent {
+			headers[idempotentReplayedHeader] = map[string]any{
+				"description": "Set to true if the response was recorded for an earlier request with the same Idempotency-Key",
+				"schema":      stringSchema,
+			}
+			responses[fmt.Sprint(http.StatusUnprocessableEntity)] = map[string]any{
+				"description": "The Idempotency-Key was already used for a different request",
+				"content":     errorResponse["content"],
+			}
+		}
+		if len(headers) > 0 {
+			success["headers"] = headers
+		}
+
+		responses[fmt.Sprint(op.status)] = success
+
+		description := "Accessible by any user."
+		if op.admin {
+			description = "Requires an admin user in enterprise mode."
+		}
+		if op.teamAdmin {
+			description = "Requires an admin user, or a member of a team allowed to administer it, in enterprise mode."
+		}
+		if op.mcpClient {
+			description = "Requires the access token of an MCP client instead of a user's."
+		}
+		if op.enterpriseOnly {
+			description += " Only available in enterprise mode."
+		}
+
+		operation := map[string]any{
+			"operationId": operationID(op),
+			"summary":     op.summary,
+			"description": description,
+			"tags":        []string{op.tag},
+			"responses":   responses,
+		}
+		if len(params) > 0 {
+			operation["parameters"] = params
+		}
+
+		requestSchema := op.requestSchema
+		if requestSchema == nil && op.request != nil {
+			requestSchema = schemas.schemaFor(reflect.TypeOf(op.request))
+		}
+		if requestSchema != nil {
+			operation["requestBody"] = map[string]any{
+				"required": !op.requestOptional,
+				"content": map[string]any{
+					"application/json": map[string]any{"schema": requestSchema},
+				},
+			}
+		}
+
+		item, ok := paths[p].(map[string]any)
+		if !ok {
+			item = map[string]any{}
+			paths[p] = item
+		}
+		item[strings.ToLower(op.method)] = operation
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "MCPJungle Registry API",
+			"description": "API to manage the MCP servers, tools, prompts, tool groups, clients, users and teams of MCPJungle.",
+			"version":     version.GetVersion(),
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas.schemas,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{
+					"type":        "http",
+					"scheme":      "bearer",
+					"description": "Access token of a user, only required in enterprise mode",
+				},
+			},
+		},
+		"security": []map[string]any{{"bearerAuth": []string{}}},
+	}
+}
+
+// operationID returns a unique, camelCase identifier for an operation, eg- "GET /tool-groups/:name" -> "getToolGroupsName"
+func operationID(op apiOperation) string {
+	words := strings.FieldsFunc(op.path, func(r rune) bool {
+		return r == '/' || r == '-' || r == ':'
+	})
+	id := strings.ToLower(op.method)
+	for _, w := range words {
+		id += strings.ToUpper(w[:1]) + w[1:]
+	}
+	return id
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	deletedAtType = reflect.TypeOf(gorm.DeletedAt{})
+	jsonType      = reflect.TypeOf(datatypes.JSON{})
+)
+
+// schemaRegistry derives JSON schemas from Go types and collects the schemas of named structs
+// so they can be referenced from the components section of the OpenAPI document.
+type schemaRegistry struct {
+	schemas map[string]any
+	names   map[reflect.Type]string
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		schemas: map[string]any{},
+		names:   map[reflect.Type]string{},
+	}
+}
+
+// schemaFor returns the JSON schema of the given type as serialized by encoding/json.
+func (r *schemaRegistry) schemaFor(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case deletedAtType:
+		return map[string]any{"type": "string", "format": "date-time", "nullable": true}
+	case jsonType:
+		// arbitrary JSON value
+		return map[string]any{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": r.schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": r.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.objectSchema(t)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + r.register(t)}
+	default:
+		// interfaces can hold any JSON value
+		return map[string]any{}
+	}
+}
+
+// register adds the schema of a named struct to the registry and returns its component name.
+// If two packages define structs with the same name, the latter is prefixed with its package name.
+func (r *schemaRegistry) register(t reflect.Type) string {
+	if name, ok := r.names[t]; ok {
+		return name
+	}
+	name := t.Name()
+	if _, taken := r.schemas[name]; taken {
+		pkg := path.Base(t.PkgPath())
+		name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	}
+	r.names[t] = name
+	// reserve the name before deriving the schema so that recursive types terminate
+	r.schemas[name] = nil
+	r.schemas[name] = r.objectSchema(t)
+	return name
+}
+
+func (r *schemaRegistry) objectSchema(t reflect.Type) map[string]any {
+	props := map[string]any{}
+	r.addFields(t, props)
+	return map[string]any{"type": "object", "properties": props}
+}
+
+// addFields adds the JSON properties of a struct's fields to props.
+// Untagged embedded structs are flattened, just like encoding/json does.
+func (r *schemaRegistry) addFields(t reflect.Type, props map[string]any) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				r.addFields(ft, props)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		props[name] = r.schemaFor(f.Type)
+	}
+}
+
+// openAPIHandler serves the OpenAPI document of the registry API.
+func (s *Server) openAPIHandler() (gin.HandlerFunc, error) {
+	spec, err := json.Marshal(buildOpenAPISpec())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate OpenAPI specification: %w", err)
+	}
+	return func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json; charset=utf-8", spec)
+	}, nil
+}
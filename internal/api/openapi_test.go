@@ -0,0 +1,96 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestOpenAPIOperationsMatchRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	server, err := NewServer(&ServerOptions{Port: "8080"})
+	testhelpers.AssertNoError(t, err)
+
+	// the documented operations must be exactly the routes registered on the v1 API
+	routes := make(map[string]bool)
+	for _, r := range server.router.Routes() {
+		if suffix, ok := strings.CutPrefix(r.Path, V1ApiPathPrefix); ok {
+			routes[r.Method+" "+suffix] = true
+		}
+	}
+
+	documented := make(map[string]bool)
+	operationIDs := make(map[string]bool)
+	for _, op := range apiOperations {
+		key := op.method + " " + op.path
+		testhelpers.AssertTrue(t, routes[key], "documented operation is not registered: "+key)
+		testhelpers.AssertFalse(t, documented[key], "operation is documented twice: "+key)
+		documented[key] = true
+
+		id := operationID(op)
+		testhelpers.AssertFalse(t, operationIDs[id], "duplicate operation id: "+id)
+		operationIDs[id] = true
+	}
+	for route := range routes {
+		testhelpers.AssertTrue(t, documented[route], "registered route is not documented: "+route)
+	}
+}
+
+func TestOpenAPISpecEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	server, err := NewServer(&ServerOptions{Port: "8080"})
+	testhelpers.AssertNoError(t, err)
+
+	// the spec must be accessible without initializing the server or authenticating
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, OpenAPISpecPath, nil)
+	server.router.ServeHTTP(w, req)
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+
+	var spec struct {
+		OpenAPI string                               `json:"openapi"`
+		Paths   map[string]map[string]map[string]any `json:"paths"`
+		Comps   struct {
+			Schemas map[string]map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &spec))
+	testhelpers.AssertEqual(t, "3.0.3", spec.OpenAPI)
+
+	getGroup, ok := spec.Paths["/api/v1/tool-groups/{name}"]["get"]
+	testhelpers.AssertTrue(t, ok, "Expected GET /api/v1/tool-groups/{name} to be documented")
+	testhelpers.AssertEqual(t, "getToolGroupsName", getGroup["operationId"])
+
+	// embedded structs are flattened into the schema
+	groupResp, ok := spec.Comps.Schemas["GetToolGroupResponse"]
+	testhelpers.AssertTrue(t, ok, "Expected GetToolGroupResponse schema")
+	props := groupResp["properties"].(map[string]any)
+	_, hasName := props["name"]
+	_, hasEndpoint := props["streamable_http_endpoint"]
+	testhelpers.AssertTrue(t, hasName && hasEndpoint, "Expected embedded struct fields to be flattened")
+
+	// structs with the same name in different packages get distinct schemas
+	_, hasTypesServer := spec.Comps.Schemas["McpServer"]
+	_, hasModelServer := spec.Comps.Schemas["ModelMcpServer"]
+	testhelpers.AssertTrue(t, hasTypesServer && hasModelServer, "Expected distinct schemas for McpServer types")
+
+	// fields excluded from JSON are not documented
+	promptProps := spec.Comps.Schemas["Prompt"]["properties"].(map[string]any)
+	_, hasServer := promptProps["Server"]
+	testhelpers.AssertFalse(t, hasServer, "Expected json:\"-\" fields to be omitted")
+}
+
+func TestOpenAPIPath(t *testing.T) {
+	t.Parallel()
+
+	testhelpers.AssertEqual(t, "/servers", openAPIPath("/servers"))
+	testhelpers.AssertEqual(t, "/servers/{name}/enable", openAPIPath("/servers/:name/enable"))
+	testhelpers.AssertEqual(t, "/users/{username}", openAPIPath("/users/:username"))
+}
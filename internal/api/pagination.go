@@ -0,0 +1,60 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// pageQuery returns the page of items requested via the limit & offset query parameters.
+// If the query parameters are invalid, it writes a Bad Request response and returns false.
+func pageQuery(c *gin.Context) (types.Page, bool) {
+	var page types.Page
+	if v := c.Query(types.OffsetQueryParam); v != "" {
+		o, err := strconv.Atoi(v)
+		if err != nil || o < 0 {
+			c.JSON(
+				http.StatusBadRequest,
+				gin.H{"error": fmt.Sprintf("invalid %s: must be a non-negative integer", types.OffsetQueryParam)},
+			)
+			return types.Page{}, false
+		}
+		page.Offset = o
+	}
+
+	// by default, all items starting from the offset are returned
+	if v := c.Query(types.LimitQueryParam); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil || l <= 0 {
+			c.JSON(
+				http.StatusBadRequest,
+				gin.H{"error": fmt.Sprintf("invalid %s: must be a positive integer", types.LimitQueryParam)},
+			)
+			return types.Page{}, false
+		}
+		page.Limit = l
+	}
+	return page, true
+}
+
+// setTotalCount sets the total number of items of a list in the response headers.
+func setTotalCount(c *gin.Context, total int64) {
+	c.Header(types.TotalCountHeader, strconv.FormatInt(total, 10))
+}
+
+// paginate returns the given page of items and sets their total number in the response headers.
+// It is only meant for the lists that are ranked or filtered in memory,
+// the other lists are paginated by the database queries that fetch them.
+func paginate[T any](c *gin.Context, items []T, page types.Page) []T {
+	setTotalCount(c, int64(len(items)))
+
+	start := min(page.Offset, len(items))
+	end := len(items)
+	if page.Limit > 0 {
+		end = start + min(page.Limit, len(items)-start)
+	}
+	return items[start:end]
+}
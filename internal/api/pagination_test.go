@@ -0,0 +1,56 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestPaginate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	items := []int{1, 2, 3, 4, 5}
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedItems  []int
+		expectedStatus int
+	}{
+		{"no params returns all items", "", []int{1, 2, 3, 4, 5}, http.StatusOK},
+		{"limit only", "?limit=2", []int{1, 2}, http.StatusOK},
+		{"limit and offset", "?limit=2&offset=2", []int{3, 4}, http.StatusOK},
+		{"offset only", "?offset=3", []int{4, 5}, http.StatusOK},
+		{"last partial page", "?limit=2&offset=4", []int{5}, http.StatusOK},
+		{"offset beyond total", "?limit=2&offset=10", []int{}, http.StatusOK},
+		{"limit larger than total", "?limit=100", []int{1, 2, 3, 4, 5}, http.StatusOK},
+		{"zero limit", "?limit=0", nil, http.StatusBadRequest},
+		{"negative offset", "?offset=-1", nil, http.StatusBadRequest},
+		{"non-numeric limit", "?limit=abc", nil, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/tools"+tt.query, nil)
+
+			p, ok := pageQuery(c)
+			if tt.expectedStatus != http.StatusOK {
+				testhelpers.AssertFalse(t, ok, "Expected pagination to fail")
+				testhelpers.AssertEqual(t, tt.expectedStatus, w.Code)
+				return
+			}
+
+			testhelpers.AssertTrue(t, ok, "Expected pagination to succeed")
+			page := paginate(c, items, p)
+			testhelpers.AssertTrue(t, slices.Equal(tt.expectedItems, page), fmt.Sprintf("Expected %v, got %v", tt.expectedItems, page))
+			testhelpers.AssertEqual(t, "5", w.Header().Get(types.TotalCountHeader))
+		})
+	}
+}
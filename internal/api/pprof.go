@@ -0,0 +1,34 @@
+package api
+
+import (
+	"net/http/pprof"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PprofPathPrefix is the path under which the runtime profiles are served when profiling is enabled
+const PprofPathPrefix = "/debug/pprof"
+
+// pprofProfiles are the named runtime profiles served by pprof.Handler
+var pprofProfiles = []string{"allocs", "block", "goroutine", "heap", "mutex", "threadcreate"}
+
+// registerPprofRoutes serves the runtime profiles of net/http/pprof to admins.
+// The CPU profile & execution trace take a while to collect, so the routes are treated as streaming endpoints.
+func (s *Server) registerPprofRoutes(r *gin.Engine) {
+	g := r.Group(
+		PprofPathPrefix,
+		s.streamingEndpoint(),
+		s.requireInitialized(),
+		s.verifyUserAuthForAPIAccess(),
+		s.requireAdminUser(),
+	)
+	g.GET("/", gin.WrapF(pprof.Index))
+	g.GET("/cmdline", gin.WrapF(pprof.Cmdline))
+	g.GET("/profile", gin.WrapF(pprof.Profile))
+	g.GET("/symbol", gin.WrapF(pprof.Symbol))
+	g.POST("/symbol", gin.WrapF(pprof.Symbol))
+	g.GET("/trace", gin.WrapF(pprof.Trace))
+	for _, name := range pprofProfiles {
+		g.GET("/"+name, gin.WrapH(pprof.Handler(name)))
+	}
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/migrations"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/config"
+	"github.com/mcpjungle/mcpjungle/internal/service/user"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestPprofRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, err := testhelpers.CreateTestDB()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, migrations.Migrate(db))
+
+	configService := config.NewServerConfigService(db)
+	_, err = configService.Init(model.ModeEnterprise)
+	testhelpers.AssertNoError(t, err)
+	userService := user.NewUserService(db)
+	admin, err := userService.CreateAdminUser()
+	testhelpers.AssertNoError(t, err)
+	member, err := userService.CreateUser("alice")
+	testhelpers.AssertNoError(t, err)
+
+	get := func(s *Server, path, token string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		s.router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		s, err := NewServer(&ServerOptions{ConfigService: configService, UserService: userService})
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, http.StatusNotFound, get(s, PprofPathPrefix+"/heap", admin.AccessToken))
+	})
+
+	t.Run("enabled for admins only", func(t *testing.T) {
+		s, err := NewServer(&ServerOptions{
+			ConfigService: configService,
+			UserService:   userService,
+			PprofEnabled:  true,
+		})
+		testhelpers.AssertNoError(t, err)
+
+		testhelpers.AssertEqual(t, http.StatusUnauthorized, get(s, PprofPathPrefix+"/heap", ""))
+		testhelpers.AssertEqual(t, http.StatusForbidden, get(s, PprofPathPrefix+"/heap", member.AccessToken))
+		testhelpers.AssertEqual(t, http.StatusOK, get(s, PprofPathPrefix+"/", admin.AccessToken))
+		testhelpers.AssertEqual(t, http.StatusOK, get(s, PprofPathPrefix+"/heap", admin.AccessToken))
+		testhelpers.AssertEqual(t, http.StatusOK, get(s, PprofPathPrefix+"/goroutine?debug=1", admin.AccessToken))
+	})
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcpclient"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+)
+
+// enforceClientQuota is middleware that counts the tool calls made through the MCP proxy against the quota of
+// the authenticated MCP client. Once the client has used up its quota, its tool calls are rejected with
+// 429 (Too Many Requests) until the quota resets, and the Retry-After header tells when that happens.
+// It must run after checkAuthForMcpProxyAccess. Clients without a quota, and dev mode, are not affected.
+func (s *Server) enforceClientQuota() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		client, ok := c.Request.Context().Value("client").(*model.McpClient)
+		if !ok || client.QuotaLimit <= 0 || c.Request.Method != http.MethodPost {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		var req struct {
+			Method mcp.MCPMethod `json:"method"`
+		}
+		if err := json.Unmarshal(body, &req); err != nil || req.Method != mcp.MethodToolsCall {
+			c.Next()
+			return
+		}
+
+		err = s.mcpClientService.ConsumeQuota(client)
+		var exceeded *mcpclient.QuotaExceededError
+		if errors.As(err, &exceeded) {
+			retryAfter := math.Ceil(time.Until(exceeded.ResetsAt).Seconds())
+			c.Header("Retry-After", strconv.Itoa(int(max(retryAfter, 1))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": exceeded.Error()})
+			return
+		}
+		if err != nil {
+			// the quota must not take the MCP proxy down along with the database, so the call is let through
+			s.logger.Error(
+				"failed to enforce the quota of MCP client",
+				logger.String("client", client.Name), logger.ErrorField(err),
+			)
+		}
+		c.Next()
+	}
+}
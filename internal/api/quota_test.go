@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcpclient"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestEnforceClientQuota(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	mcpClientService := mcpclient.NewMCPClientService(setup.DB)
+	client, err := mcpClientService.CreateClient(model.McpClient{Name: "agent", QuotaLimit: 1})
+	testhelpers.AssertNoError(t, err)
+
+	server := &Server{mcpClientService: mcpClientService, logger: logger.NewNop()}
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), "client", client))
+	})
+	router.Use(server.enforceClientQuota())
+	router.POST("/mcp", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "success"})
+	})
+
+	send := func(method string) *httptest.ResponseRecorder {
+		body := `{"jsonrpc":"2.0","id":1,"method":"` + method + `","params":{"name":"time__now"}}`
+		req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	testhelpers.AssertEqual(t, http.StatusOK, send("tools/call").Code)
+
+	w := send("tools/call")
+	testhelpers.AssertEqual(t, http.StatusTooManyRequests, w.Code)
+	testhelpers.AssertStringContains(t, w.Body.String(), "mcp client agent has exceeded its daily quota of 1 tool calls")
+	testhelpers.AssertTrue(t, w.Header().Get("Retry-After") != "", "Expected the Retry-After header to be set")
+
+	// the quota only applies to tool calls
+	testhelpers.AssertEqual(t, http.StatusOK, send("tools/list").Code)
+}
@@ -0,0 +1,503 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/user"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// SCIMPathPrefix is the path prefix of the SCIM 2.0 (RFC 7643 & 7644) endpoints, which let identity providers
+// provision & deprovision the users of mcpjungle and their roles.
+const SCIMPathPrefix = "/scim/v2"
+
+const (
+	scimContentType = "application/scim+json"
+
+	scimUserSchema                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	scimServiceProviderConfigSchema = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	scimListResponseSchema          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	scimErrorSchema                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	// scimUserTokenSchema is the schema of the extension carrying the access token of a user created via SCIM.
+	// Like with the users API, the token is only returned once, in the response to the creation.
+	scimUserTokenSchema = "urn:mcpjungle:params:scim:schemas:extension:2.0:User"
+
+	// scimMaxResults is the maximum number of users returned in a single page of results.
+	scimMaxResults = 100
+)
+
+// scimUser is the SCIM representation of a user.
+// Only the attributes stored by mcpjungle are supported, the others are ignored when sent by the identity provider.
+type scimUser struct {
+	Schemas  []string       `json:"schemas"`
+	ID       string         `json:"id,omitempty"`
+	UserName string         `json:"userName"`
+	Active   *bool          `json:"active,omitempty"`
+	Roles    []scimRole     `json:"roles,omitempty"`
+	Meta     *scimMeta      `json:"meta,omitempty"`
+	Token    *scimUserToken `json:"urn:mcpjungle:params:scim:schemas:extension:2.0:User,omitempty"`
+}
+
+type scimRole struct {
+	Value   string `json:"value"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+type scimMeta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created"`
+	LastModified string `json:"lastModified"`
+	Location     string `json:"location"`
+}
+
+type scimUserToken struct {
+	AccessToken string `json:"accessToken"`
+}
+
+type scimListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int         `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    []*scimUser `json:"Resources"`
+}
+
+type scimPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []scimPatchOperation `json:"Operations"`
+}
+
+type scimPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+type scimError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+}
+
+func (e *scimError) Error() string {
+	return e.Detail
+}
+
+// scimUserUpdate holds the changes to apply to a user, nil fields are left unchanged.
+type scimUserUpdate struct {
+	role   *types.UserRole
+	active *bool
+}
+
+// scimUserNameFilter matches the only filter supported when listing users, which identity providers use
+// to find out whether a user already exists.
+var scimUserNameFilter = regexp.MustCompile(`(?i)^userName\s+eq\s+("(?:[^"\\]|\\.)*")$`)
+
+// registerSCIMRoutes registers the SCIM endpoints on the given group, whose callers must be admin users.
+func (s *Server) registerSCIMRoutes(scim *gin.RouterGroup) {
+	scim.GET("/ServiceProviderConfig", scimServiceProviderConfigHandler)
+	scim.GET("/Users", s.scimListUsersHandler())
+	scim.POST("/Users", s.scimCreateUserHandler())
+	scim.GET("/Users/:id", s.scimGetUserHandler())
+	scim.PUT("/Users/:id", s.scimReplaceUserHandler())
+	scim.PATCH("/Users/:id", s.scimPatchUserHandler())
+	scim.DELETE("/Users/:id", s.scimDeleteUserHandler())
+}
+
+func scimServiceProviderConfigHandler(c *gin.Context) {
+	scimJSON(c, http.StatusOK, gin.H{
+		"schemas":        []string{scimServiceProviderConfigSchema},
+		"patch":          gin.H{"supported": true},
+		"bulk":           gin.H{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         gin.H{"supported": true, "maxResults": scimMaxResults},
+		"changePassword": gin.H{"supported": false},
+		"sort":           gin.H{"supported": false},
+		"etag":           gin.H{"supported": false},
+		"authenticationSchemes": []gin.H{{
+			"type":        "oauthbearertoken",
+			"name":        "Bearer Token",
+			"description": "Authentication with the access token of an mcpjungle admin user",
+		}},
+	})
+}
+
+// scimListUsersHandler lists the users, optionally filtered by their username with a `userName eq "..."` filter.
+func (s *Server) scimListUsersHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		startIndex, err := scimIntQuery(c, "startIndex", 1)
+		if err != nil {
+			scimErrorJSON(c, http.StatusBadRequest, "invalidValue", err.Error())
+			return
+		}
+		count, err := scimIntQuery(c, "count", scimMaxResults)
+		if err != nil {
+			scimErrorJSON(c, http.StatusBadRequest, "invalidValue", err.Error())
+			return
+		}
+		startIndex = max(startIndex, 1)
+		count = min(max(count, 0), scimMaxResults)
+
+		var users []model.User
+		if filter := strings.TrimSpace(c.Query("filter")); filter != "" {
+			m := scimUserNameFilter.FindStringSubmatch(filter)
+			if m == nil {
+				scimErrorJSON(c, http.StatusBadRequest, "invalidFilter", "only the userName eq filter is supported")
+				return
+			}
+			var username string
+			if err := json.Unmarshal([]byte(m[1]), &username); err != nil {
+				scimErrorJSON(c, http.StatusBadRequest, "invalidFilter", "invalid userName in filter")
+				return
+			}
+			u, err := s.userService.GetUser(username)
+			if err != nil && !errors.Is(err, user.ErrUserNotFound) {
+				scimServiceError(c, err)
+				return
+			}
+			if u != nil {
+				users = append(users, *u)
+			}
+		} else {
+			users, err = s.userService.ListUsers()
+			if err != nil {
+				scimServiceError(c, err)
+				return
+			}
+		}
+
+		resp := &scimListResponse{
+			Schemas:      []string{scimListResponseSchema},
+			TotalResults: len(users),
+			StartIndex:   startIndex,
+			Resources:    []*scimUser{},
+		}
+		if startIndex <= len(users) {
+			page := users[startIndex-1:]
+			page = page[:min(count, len(page))]
+			for _, u := range page {
+				resp.Resources = append(resp.Resources, scimUserResponse(&u))
+			}
+		}
+		resp.ItemsPerPage = len(resp.Resources)
+		scimJSON(c, http.StatusOK, resp)
+	}
+}
+
+func (s *Server) scimCreateUserHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input scimUser
+		if err := c.ShouldBindJSON(&input); err != nil {
+			scimErrorJSON(c, http.StatusBadRequest, "invalidSyntax", err.Error())
+			return
+		}
+		if input.UserName == "" {
+			scimErrorJSON(c, http.StatusBadRequest, "invalidValue", "userName is required")
+			return
+		}
+		role, err := scimRoleFromRoles(input.Roles)
+		if err != nil {
+			scimErrorJSON(c, http.StatusBadRequest, "invalidValue", err.Error())
+			return
+		}
+
+		u, err := s.userService.ProvisionUser(input.UserName, role, input.Active == nil || *input.Active)
+		if err != nil {
+			scimServiceError(c, err)
+			return
+		}
+		resp := scimUserResponse(u)
+		resp.Schemas = append(resp.Schemas, scimUserTokenSchema)
+		resp.Token = &scimUserToken{AccessToken: u.AccessToken}
+		c.Header("Location", resp.Meta.Location)
+		scimJSON(c, http.StatusCreated, resp)
+	}
+}
+
+func (s *Server) scimGetUserHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		u, ok := s.scimLookupUser(c)
+		if !ok {
+			return
+		}
+		scimJSON(c, http.StatusOK, scimUserResponse(u))
+	}
+}
+
+// scimReplaceUserHandler replaces the role & state of a user.
+// As with any SCIM replacement, the user becomes active & a regular user unless the request says otherwise.
+func (s *Server) scimReplaceUserHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		u, ok := s.scimLookupUser(c)
+		if !ok {
+			return
+		}
+		var input scimUser
+		if err := c.ShouldBindJSON(&input); err != nil {
+			scimErrorJSON(c, http.StatusBadRequest, "invalidSyntax", err.Error())
+			return
+		}
+		if input.UserName != "" && input.UserName != u.Username {
+			scimErrorJSON(c, http.StatusBadRequest, "mutability", "userName cannot be changed")
+			return
+		}
+		role, err := scimRoleFromRoles(input.Roles)
+		if err != nil {
+			scimErrorJSON(c, http.StatusBadRequest, "invalidValue", err.Error())
+			return
+		}
+		active := input.Active == nil || *input.Active
+
+		s.scimUpdateUser(c, u, &scimUserUpdate{role: &role, active: &active})
+	}
+}
+
+// scimPatchUserHandler applies the operations of a SCIM PatchOp request to a user.
+// Identity providers deprovision users by replacing their active attribute with false.
+func (s *Server) scimPatchUserHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		u, ok := s.scimLookupUser(c)
+		if !ok {
+			return
+		}
+		var input scimPatchRequest
+		if err := c.ShouldBindJSON(&input); err != nil {
+			scimErrorJSON(c, http.StatusBadRequest, "invalidSyntax", err.Error())
+			return
+		}
+
+		update := &scimUserUpdate{}
+		for _, op := range input.Operations {
+			if err := update.applyPatchOperation(u, op); err != nil {
+				var se *scimError
+				if errors.As(err, &se) {
+					scimErrorJSON(c, http.StatusBadRequest, se.ScimType, se.Detail)
+					return
+				}
+				scimErrorJSON(c, http.StatusBadRequest, "invalidValue", err.Error())
+				return
+			}
+		}
+		s.scimUpdateUser(c, u, update)
+	}
+}
+
+func (s *Server) scimDeleteUserHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		u, ok := s.scimLookupUser(c)
+		if !ok {
+			return
+		}
+		if err := s.userService.DeleteUser(u.Username); err != nil {
+			scimServiceError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// scimUpdateUser applies the given changes to the user and responds with the updated user.
+func (s *Server) scimUpdateUser(c *gin.Context, u *model.User, update *scimUserUpdate) {
+	if update.role == nil && update.active == nil {
+		scimJSON(c, http.StatusOK, scimUserResponse(u))
+		return
+	}
+	// the role & active state are changed together, so that a rejected change doesn't leave the other one made
+	u, err := s.userService.UpdateUserStatus(u.Username, update.role, update.active)
+	if err != nil {
+		scimServiceError(c, err)
+		return
+	}
+	scimJSON(c, http.StatusOK, scimUserResponse(u))
+}
+
+// applyPatchOperation records the changes made by a single patch operation.
+// Operations on the attributes that mcpjungle doesn't store are ignored.
+func (update *scimUserUpdate) applyPatchOperation(u *model.User, op scimPatchOperation) error {
+	kind := strings.ToLower(op.Op)
+	if kind != "add" && kind != "replace" && kind != "remove" {
+		return &scimError{ScimType: "invalidSyntax", Detail: fmt.Sprintf("unsupported patch operation %q", op.Op)}
+	}
+
+	// without a path, the value holds the attributes to add or replace
+	if op.Path == "" {
+		if kind == "remove" {
+			return &scimError{ScimType: "noTarget", Detail: "a path is required to remove attributes"}
+		}
+		var attrs map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &attrs); err != nil {
+			return &scimError{ScimType: "invalidValue", Detail: "the value must be an object of attributes"}
+		}
+		for name, value := range attrs {
+			err := update.applyPatchOperation(u, scimPatchOperation{Op: op.Op, Path: name, Value: value})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	path := strings.ToLower(strings.TrimPrefix(op.Path, scimUserSchema+":"))
+	switch {
+	case path == "username":
+		var username string
+		if kind == "remove" || json.Unmarshal(op.Value, &username) != nil || username != u.Username {
+			return &scimError{ScimType: "mutability", Detail: "userName cannot be changed"}
+		}
+	case path == "active":
+		if kind == "remove" {
+			return &scimError{ScimType: "mutability", Detail: "active cannot be removed"}
+		}
+		active, err := scimBool(op.Value)
+		if err != nil {
+			return err
+		}
+		update.active = &active
+	case path == "roles":
+		role := types.UserRoleUser
+		if kind != "remove" {
+			var roles []scimRole
+			if err := json.Unmarshal(op.Value, &roles); err != nil {
+				return &scimError{ScimType: "invalidValue", Detail: "roles must be an array of roles"}
+			}
+			var err error
+			if role, err = scimRoleFromRoles(roles); err != nil {
+				return err
+			}
+		}
+		update.role = &role
+	case strings.HasPrefix(path, "roles"):
+		return &scimError{ScimType: "invalidPath", Detail: "only the whole roles attribute can be patched"}
+	}
+	return nil
+}
+
+// scimLookupUser returns the user identified by the "id" path parameter, or responds with an error.
+func (s *Server) scimLookupUser(c *gin.Context) (*model.User, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 0)
+	if err != nil {
+		scimErrorJSON(c, http.StatusNotFound, "", user.ErrUserNotFound.Error())
+		return nil, false
+	}
+	u, err := s.userService.GetUserByID(uint(id))
+	if err != nil {
+		scimServiceError(c, err)
+		return nil, false
+	}
+	return u, true
+}
+
+// scimRoleFromRoles returns the mcpjungle role of a user from their SCIM roles: the primary role if any,
+// otherwise the first one. Users without roles are regular users.
+func scimRoleFromRoles(roles []scimRole) (types.UserRole, error) {
+	if len(roles) == 0 {
+		return types.UserRoleUser, nil
+	}
+	r := roles[0]
+	for _, candidate := range roles {
+		if candidate.Primary {
+			r = candidate
+			break
+		}
+	}
+	role := types.UserRole(strings.ToLower(r.Value))
+	if role != types.UserRoleAdmin && role != types.UserRoleUser {
+		return "", &scimError{
+			ScimType: "invalidValue",
+			Detail:   fmt.Sprintf("invalid role %q, must be %s or %s", r.Value, types.UserRoleAdmin, types.UserRoleUser),
+		}
+	}
+	return role, nil
+}
+
+// scimBool decodes a boolean value, which some identity providers send as a string.
+func scimBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b, nil
+		}
+	}
+	return false, &scimError{ScimType: "invalidValue", Detail: "active must be a boolean"}
+}
+
+func scimIntQuery(c *gin.Context, name string, defaultValue int) (int, error) {
+	v := c.Query(name)
+	if v == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be an integer", name)
+	}
+	return n, nil
+}
+
+// scimUserResponse converts a user into its SCIM representation, which never includes the user's access token.
+func scimUserResponse(u *model.User) *scimUser {
+	id := strconv.FormatUint(uint64(u.ID), 10)
+	active := u.Active
+	return &scimUser{
+		Schemas:  []string{scimUserSchema},
+		ID:       id,
+		UserName: u.Username,
+		Active:   &active,
+		Roles:    []scimRole{{Value: string(u.Role), Primary: true}},
+		Meta: &scimMeta{
+			ResourceType: "User",
+			Created:      u.CreatedAt.UTC().Format(time.RFC3339),
+			LastModified: u.UpdatedAt.UTC().Format(time.RFC3339),
+			Location:     SCIMPathPrefix + "/Users/" + id,
+		},
+	}
+}
+
+// scimServiceError responds with the SCIM error corresponding to an error returned by the user service.
+func scimServiceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, user.ErrUserNotFound):
+		scimErrorJSON(c, http.StatusNotFound, "", err.Error())
+	case errors.Is(err, user.ErrUserExists):
+		scimErrorJSON(c, http.StatusConflict, "uniqueness", err.Error())
+	case errors.Is(err, user.ErrInvalidRole):
+		scimErrorJSON(c, http.StatusBadRequest, "invalidValue", err.Error())
+	case errors.Is(err, user.ErrAdminSuspension), errors.Is(err, user.ErrAdminDeletion),
+		errors.Is(err, user.ErrLastAdmin):
+		scimErrorJSON(c, http.StatusBadRequest, "", err.Error())
+	default:
+		scimErrorJSON(c, http.StatusInternalServerError, "", err.Error())
+	}
+}
+
+func scimErrorJSON(c *gin.Context, status int, scimType, detail string) {
+	scimJSON(c, status, &scimError{
+		Schemas:  []string{scimErrorSchema},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
+
+// scimJSON writes a SCIM response, whose media type differs from the rest of the API.
+func scimJSON(c *gin.Context, status int, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Data(status, scimContentType, body)
+}
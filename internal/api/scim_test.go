@@ -0,0 +1,208 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/user"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func newSCIMTestRouter(t *testing.T) (*gin.Engine, *user.UserService, string) {
+	gin.SetMode(gin.TestMode)
+	setup := testhelpers.SetupTestDB(t)
+	t.Cleanup(setup.Cleanup)
+
+	userService := user.NewUserService(setup.DB)
+	admin, err := userService.CreateAdminUser()
+	testhelpers.AssertNoError(t, err)
+
+	server := &Server{userService: userService}
+	router := gin.New()
+	router.Use(func(c *gin.Context) { c.Set("mode", model.ModeEnterprise) })
+	server.registerSCIMRoutes(router.Group(SCIMPathPrefix, server.verifyUserAuthForAPIAccess(), server.requireAdminUser()))
+	return router, userService, admin.AccessToken
+}
+
+func scimRequest(router *gin.Engine, token, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, SCIMPathPrefix+path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", scimContentType)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestSCIMUserLifecycle(t *testing.T) {
+	router, userService, token := newSCIMTestRouter(t)
+
+	w := scimRequest(router, token, http.MethodPost, "/Users", `{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+		"userName": "alice",
+		"name": {"givenName": "Alice"},
+		"active": true
+	}`)
+	testhelpers.AssertEqual(t, http.StatusCreated, w.Code)
+	testhelpers.AssertEqual(t, scimContentType, w.Header().Get("Content-Type"))
+	var created scimUser
+	testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	testhelpers.AssertEqual(t, "alice", created.UserName)
+	testhelpers.AssertEqual(t, "user", created.Roles[0].Value)
+	testhelpers.AssertNotNil(t, created.Token)
+	testhelpers.AssertEqual(t, SCIMPathPrefix+"/Users/"+created.ID, w.Header().Get("Location"))
+
+	// the token of the new user works, but is never returned again
+	alice, err := userService.GetUserByAccessToken(created.Token.AccessToken)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, "alice", alice.Username)
+	w = scimRequest(router, token, http.MethodGet, "/Users/"+created.ID, "")
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+	testhelpers.AssertFalse(t, strings.Contains(w.Body.String(), created.Token.AccessToken), "the token must not be returned")
+
+	// provisioning the same user twice is a conflict
+	w = scimRequest(router, token, http.MethodPost, "/Users", `{"userName": "alice"}`)
+	testhelpers.AssertEqual(t, http.StatusConflict, w.Code)
+	testhelpers.AssertStringContains(t, w.Body.String(), `"scimType":"uniqueness"`)
+
+	// promote alice to admin
+	w = scimRequest(router, token, http.MethodPatch, "/Users/"+created.ID, `{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [{"op": "replace", "path": "roles", "value": [{"value": "admin"}]}]
+	}`)
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+	alice, _ = userService.GetUser("alice")
+	testhelpers.AssertEqual(t, types.UserRoleAdmin, alice.Role)
+
+	// deprovision alice the way Entra ID does: demote & deactivate at once, with a string boolean
+	w = scimRequest(router, token, http.MethodPatch, "/Users/"+created.ID, `{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [
+			{"op": "Replace", "path": "active", "value": "False"},
+			{"op": "Remove", "path": "roles"},
+			{"op": "Replace", "path": "displayName", "value": "Alice"}
+		]
+	}`)
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+	var patched scimUser
+	testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &patched))
+	testhelpers.AssertFalse(t, *patched.Active, "Expected alice to be suspended")
+	testhelpers.AssertEqual(t, "user", patched.Roles[0].Value)
+
+	// reactivate alice by replacing her
+	w = scimRequest(router, token, http.MethodPut, "/Users/"+created.ID, `{"userName": "alice", "active": true}`)
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+	alice, _ = userService.GetUser("alice")
+	testhelpers.AssertTrue(t, alice.Active, "Expected alice to be active again")
+
+	w = scimRequest(router, token, http.MethodPut, "/Users/"+created.ID, `{"userName": "alicia"}`)
+	testhelpers.AssertEqual(t, http.StatusBadRequest, w.Code)
+	testhelpers.AssertStringContains(t, w.Body.String(), `"scimType":"mutability"`)
+
+	w = scimRequest(router, token, http.MethodDelete, "/Users/"+created.ID, "")
+	testhelpers.AssertEqual(t, http.StatusNoContent, w.Code)
+	w = scimRequest(router, token, http.MethodGet, "/Users/"+created.ID, "")
+	testhelpers.AssertEqual(t, http.StatusNotFound, w.Code)
+	testhelpers.AssertStringContains(t, w.Body.String(), `"status":"404"`)
+}
+
+func TestSCIMListUsers(t *testing.T) {
+	router, userService, token := newSCIMTestRouter(t)
+	for _, name := range []string{"alice", "bob", "carol"} {
+		_, err := userService.CreateUser(name)
+		testhelpers.AssertNoError(t, err)
+	}
+
+	list := func(query string) *scimListResponse {
+		w := scimRequest(router, token, http.MethodGet, "/Users?"+query, "")
+		testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+		var resp scimListResponse
+		testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return &resp
+	}
+
+	resp := list("")
+	testhelpers.AssertEqual(t, 4, resp.TotalResults)
+	testhelpers.AssertEqual(t, 4, resp.ItemsPerPage)
+
+	resp = list("startIndex=2&count=2")
+	testhelpers.AssertEqual(t, 4, resp.TotalResults)
+	testhelpers.AssertEqual(t, 2, resp.ItemsPerPage)
+	testhelpers.AssertEqual(t, "alice", resp.Resources[0].UserName)
+	testhelpers.AssertEqual(t, "bob", resp.Resources[1].UserName)
+
+	resp = list("filter=" + url.QueryEscape(`userName eq "bob"`))
+	testhelpers.AssertEqual(t, 1, resp.TotalResults)
+	testhelpers.AssertEqual(t, "bob", resp.Resources[0].UserName)
+
+	resp = list("filter=" + url.QueryEscape(`username EQ "dave"`))
+	testhelpers.AssertEqual(t, 0, resp.TotalResults)
+	testhelpers.AssertEqual(t, 0, len(resp.Resources))
+
+	w := scimRequest(router, token, http.MethodGet, "/Users?filter="+url.QueryEscape(`emails co "example"`), "")
+	testhelpers.AssertEqual(t, http.StatusBadRequest, w.Code)
+	testhelpers.AssertStringContains(t, w.Body.String(), `"scimType":"invalidFilter"`)
+}
+
+func TestSCIMProtectsAdmins(t *testing.T) {
+	router, userService, token := newSCIMTestRouter(t)
+	admin, err := userService.GetUser("admin")
+	testhelpers.AssertNoError(t, err)
+	id := "/Users/" + scimUserResponse(admin).ID
+
+	// the only admin can neither be demoted, suspended nor deleted
+	w := scimRequest(router, token, http.MethodPatch, id,
+		`{"Operations": [{"op": "replace", "value": {"roles": [{"value": "user"}]}}]}`)
+	testhelpers.AssertEqual(t, http.StatusBadRequest, w.Code)
+	testhelpers.AssertStringContains(t, w.Body.String(), user.ErrLastAdmin.Error())
+
+	w = scimRequest(router, token, http.MethodPatch, id, `{"Operations": [{"op": "replace", "path": "active", "value": false}]}`)
+	testhelpers.AssertEqual(t, http.StatusBadRequest, w.Code)
+
+	w = scimRequest(router, token, http.MethodDelete, id, "")
+	testhelpers.AssertEqual(t, http.StatusBadRequest, w.Code)
+
+	// a user can't be promoted & suspended at once, and neither change is made when the patch is rejected
+	bob, err := userService.CreateUser("bob")
+	testhelpers.AssertNoError(t, err)
+	w = scimRequest(router, token, http.MethodPatch, "/Users/"+scimUserResponse(bob).ID, `{"Operations": [
+		{"op": "replace", "path": "roles", "value": [{"value": "admin"}]},
+		{"op": "replace", "path": "active", "value": false}
+	]}`)
+	testhelpers.AssertEqual(t, http.StatusBadRequest, w.Code)
+	testhelpers.AssertStringContains(t, w.Body.String(), user.ErrAdminSuspension.Error())
+	bob, err = userService.GetUser("bob")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, types.UserRoleUser, bob.Role)
+	testhelpers.AssertTrue(t, bob.Active, "Expected bob to remain active")
+
+	w = scimRequest(router, token, http.MethodPost, "/Users", `{"userName": "eve", "roles": [{"value": "owner"}]}`)
+	testhelpers.AssertEqual(t, http.StatusBadRequest, w.Code)
+	testhelpers.AssertStringContains(t, w.Body.String(), `"scimType":"invalidValue"`)
+}
+
+func TestSCIMRequiresAdmin(t *testing.T) {
+	router, userService, _ := newSCIMTestRouter(t)
+	alice, err := userService.CreateUser("alice")
+	testhelpers.AssertNoError(t, err)
+
+	w := scimRequest(router, alice.AccessToken, http.MethodGet, "/Users", "")
+	testhelpers.AssertEqual(t, http.StatusForbidden, w.Code)
+	w = scimRequest(router, "", http.MethodGet, "/ServiceProviderConfig", "")
+	testhelpers.AssertEqual(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestSCIMServiceProviderConfig(t *testing.T) {
+	router, _, token := newSCIMTestRouter(t)
+
+	w := scimRequest(router, token, http.MethodGet, "/ServiceProviderConfig", "")
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+	testhelpers.AssertStringContains(t, w.Body.String(), scimServiceProviderConfigSchema)
+	testhelpers.AssertStringContains(t, w.Body.String(), `"patch":{"supported":true}`)
+}
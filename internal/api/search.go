@@ -0,0 +1,54 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// searchHandler returns the tools and prompts that match the search query supplied in the "q" query param,
+// ranked by relevance.
+// The results can be restricted to either tools or prompts via the "type" query param.
+func (s *Server) searchHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := c.Query("q")
+		if query == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "missing 'q' query parameter"})
+			return
+		}
+
+		resultType := types.SearchResultType(c.Query("type"))
+		switch resultType {
+		case "", types.SearchResultTool, types.SearchResultPrompt:
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid type: must be either tool or prompt"})
+			return
+		}
+
+		page, ok := pageQuery(c)
+		if !ok {
+			return
+		}
+
+		results, err := s.mcpService.Search(query, resultType)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		allowed, err := s.userToolAccessFilter(c)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if allowed != nil {
+			results = slices.DeleteFunc(results, func(r types.SearchResult) bool {
+				return r.Type == types.SearchResultTool && !allowed(r.Name)
+			})
+		}
+		// search results are ranked in memory, so they are paginated there too
+		results = paginate(c, results, page)
+		c.JSON(http.StatusOK, results)
+	}
+}
@@ -2,33 +2,54 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/db"
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/internal/service/config"
+	"github.com/mcpjungle/mcpjungle/internal/service/events"
+	"github.com/mcpjungle/mcpjungle/internal/service/idempotency"
+	"github.com/mcpjungle/mcpjungle/internal/service/invocation"
+	"github.com/mcpjungle/mcpjungle/internal/service/job"
 	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
 	"github.com/mcpjungle/mcpjungle/internal/service/mcpclient"
+	"github.com/mcpjungle/mcpjungle/internal/service/team"
 	"github.com/mcpjungle/mcpjungle/internal/service/toolgroup"
 	"github.com/mcpjungle/mcpjungle/internal/service/user"
 	"github.com/mcpjungle/mcpjungle/internal/telemetry"
-	"github.com/mcpjungle/mcpjungle/pkg/types"
-	"github.com/mcpjungle/mcpjungle/pkg/version"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"gorm.io/gorm"
 )
 
 const (
 	V0PathPrefix    = "/v0"
 	V0ApiPathPrefix = "/api" + V0PathPrefix
+
+	// V1ApiPathPrefix is the path prefix of the stable v1 registry API.
+	// Within v1, endpoints are never removed and their request & response fields are never
+	// removed, renamed or changed in meaning. New endpoints and optional fields may be added.
+	V1ApiPathPrefix = "/api/v1"
 )
 
+// v0ApiSunset is the date after which the v0 registry API may be removed.
+// v0 serves exactly the same endpoints as v1, so integrators only need to change the path prefix.
+var v0ApiSunset = time.Date(2027, time.April, 1, 0, 0, 0, 0, time.UTC)
+
 type ServerOptions struct {
 	// Port is the HTTP ports to bind the server to
 	Port string
+	// HTTP configures the timeouts & limits of the HTTP server
+	HTTP HTTPServerConfig
 
 	// MCPProxyServer is the MCP proxy server instance that contains tools for all MCP servers
 	// using the stdio or streamable http transport.
@@ -40,53 +61,142 @@ type ServerOptions struct {
 	// Both sse & streamable http use http, and we don't want to mix them up either.
 	SseMcpProxyServer *server.MCPServer
 
+	// DB is the connection to the registry database, used for health checks
+	DB *gorm.DB
+	// DBMonitor, if set, tracks whether the database is available.
+	// While it isn't, the server runs in a degraded mode: the MCP proxy keeps serving tool calls from its
+	// in-memory state and the registry API rejects changes.
+	DBMonitor *db.Monitor
+
 	MCPService       *mcp.MCPService
 	MCPClientService *mcpclient.McpClientService
 	ConfigService    *config.ServerConfigService
 	UserService      *user.UserService
 	ToolGroupService *toolgroup.ToolGroupService
+	JobService       *job.JobService
+	// TeamService, if set, lets the members of teams administer the tool groups & MCP servers granted to their teams
+	TeamService *team.TeamService
+	// InvocationHistory keeps the record of recent tool calls served by the invocations endpoint
+	InvocationHistory *invocation.HistoryService
+
+	// EventBroker receives an event for every change made to the registry via the API
+	EventBroker *events.Broker
+	// IdempotencyService, if set, records the responses to the create requests sent with an Idempotency-Key header,
+	// so that they can be safely retried
+	IdempotencyService *idempotency.Service
 
 	OtelProviders *telemetry.Providers
 	Metrics       telemetry.CustomMetrics
+	// InvocationStats keeps the counts of recent tool & prompt calls reported by the stats endpoint
+	InvocationStats *telemetry.InvocationStats
+	// PrometheusMetrics, if set, records HTTP request metrics and serves all metrics at /metrics
+	// when OpenTelemetry is disabled.
+	PrometheusMetrics *telemetry.PrometheusMetrics
+
+	// PprofEnabled serves the runtime profiles of net/http/pprof to admins under PprofPathPrefix
+	PprofEnabled bool
+	// DashboardEnabled serves the web dashboard under DashboardPath
+	DashboardEnabled bool
+
+	// Logger writes the access log entry of every request served and the server's other logs.
+	// It defaults to a development (console) logger.
+	Logger logger.Logger
+	// LogBuffer, if set, keeps the recent entries of the server's logs served by the logs endpoint
+	LogBuffer *logger.Buffer
 }
 
 // Server represents the MCPJungle registry server that handles MCP proxy and API requests
 type Server struct {
-	port   string
-	router *gin.Engine
+	port       string
+	httpConfig HTTPServerConfig
+	router     *gin.Engine
 
 	mcpProxyServer    *server.MCPServer
 	sseMcpProxyServer *server.MCPServer
 
+	db        *gorm.DB
+	dbMonitor *db.Monitor
+
 	mcpService       *mcp.MCPService
 	mcpClientService *mcpclient.McpClientService
 
 	configService    *config.ServerConfigService
 	userService      *user.UserService
 	toolGroupService *toolgroup.ToolGroupService
+	jobService       *job.JobService
+	teamService      *team.TeamService
+
+	invocationHistory *invocation.HistoryService
+
+	eventBroker        *events.Broker
+	idempotencyService *idempotency.Service
+
+	otelProviders   *telemetry.Providers
+	metrics         telemetry.CustomMetrics
+	invocationStats *telemetry.InvocationStats
+
+	prometheusMetrics *telemetry.PrometheusMetrics
+
+	pprofEnabled     bool
+	dashboardEnabled bool
 
-	otelProviders *telemetry.Providers
-	metrics       telemetry.CustomMetrics
+	logger    logger.Logger
+	logBuffer *logger.Buffer
 
 	// groupMcpServers keeps track of mcp-go's server.SSEServer instances created for each tool group.
 	// These instances serve the requests made to tool groups' SSE tools.
 	// We need to maintain one instance for each group for sse to work correctly.
 	groupSseServers sync.Map
+
+	// mcpSessions validates the sessions of the clients of the streamable HTTP MCP proxy endpoints
+	mcpSessions    server.SessionIdManager
+	toolsListCache *toolsListCache
+
+	// draining is cancelled by startDraining when the server starts shutting down
+	draining      context.Context
+	startDraining context.CancelFunc
 }
 
 // NewServer initializes a new Gin server for MCPJungle registry and MCP proxy
 func NewServer(opts *ServerOptions) (*Server, error) {
 	s := &Server{
-		port:              opts.Port,
-		mcpProxyServer:    opts.MCPProxyServer,
-		sseMcpProxyServer: opts.SseMcpProxyServer,
-		mcpService:        opts.MCPService,
-		mcpClientService:  opts.MCPClientService,
-		configService:     opts.ConfigService,
-		userService:       opts.UserService,
-		toolGroupService:  opts.ToolGroupService,
-		otelProviders:     opts.OtelProviders,
-		metrics:           opts.Metrics,
+		port:               opts.Port,
+		httpConfig:         opts.HTTP,
+		mcpProxyServer:     opts.MCPProxyServer,
+		sseMcpProxyServer:  opts.SseMcpProxyServer,
+		db:                 opts.DB,
+		dbMonitor:          opts.DBMonitor,
+		mcpService:         opts.MCPService,
+		mcpClientService:   opts.MCPClientService,
+		configService:      opts.ConfigService,
+		userService:        opts.UserService,
+		toolGroupService:   opts.ToolGroupService,
+		jobService:         opts.JobService,
+		teamService:        opts.TeamService,
+		invocationHistory:  opts.InvocationHistory,
+		eventBroker:        opts.EventBroker,
+		idempotencyService: opts.IdempotencyService,
+		otelProviders:      opts.OtelProviders,
+		metrics:            opts.Metrics,
+		invocationStats:    opts.InvocationStats,
+		prometheusMetrics:  opts.PrometheusMetrics,
+		pprofEnabled:       opts.PprofEnabled,
+		dashboardEnabled:   opts.DashboardEnabled,
+		logger:             opts.Logger,
+		logBuffer:          opts.LogBuffer,
+		mcpSessions:        &server.InsecureStatefulSessionIdManager{},
+		toolsListCache:     newToolsListCache(),
+	}
+	s.draining, s.startDraining = context.WithCancel(context.Background())
+	if s.metrics == nil {
+		s.metrics = telemetry.NewNoopCustomMetrics()
+	}
+	if s.logger == nil {
+		l, err := logger.NewDevelopment()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create logger: %w", err)
+		}
+		s.logger = l
 	}
 
 	// Set up the router after the server is fully initialized
@@ -134,18 +244,62 @@ func (s *Server) InitDev() error {
 	return nil
 }
 
-// Start runs the Gin server (blocking call)
-func (s *Server) Start() error {
-	if err := s.router.Run(":" + s.port); err != nil {
+// Run serves requests on the given listener, or on the server's port if it is nil, until ctx is done.
+// The server is then drained: it stops accepting connections, ends the long-lived GET streams and waits up to
+// drainTimeout for the requests in flight to complete, before closing the remaining connections.
+// Within the same drainTimeout, it then waits for the work that the requests left running in the background,
+// eg- the async tool invocations, by calling the background functions, which must block until that work is done.
+func (s *Server) Run(
+	ctx context.Context, listener net.Listener, drainTimeout time.Duration, background ...func(),
+) error {
+	srv := newHTTPServer(":"+s.port, s.router, s.httpConfig)
+	if listener == nil {
+		var err error
+		if listener, err = net.Listen("tcp", srv.Addr); err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", srv.Addr, err)
+		}
+	}
+
+	served := make(chan error, 1)
+	go func() {
+		served <- srv.Serve(listener)
+	}()
+	select {
+	case err := <-served:
 		return fmt.Errorf("failed to run the server: %w", err)
+	case <-ctx.Done():
+	}
+
+	s.logger.Info("draining the server", logger.String("timeout", drainTimeout.String()))
+	s.startDraining()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		_ = srv.Close()
+		return fmt.Errorf("failed to drain the server within %s: %w", drainTimeout, err)
+	}
+	// the requests are done, so no more background work can be started
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, wait := range background {
+			wait()
+		}
+	}()
+	select {
+	case <-done:
+	case <-shutdownCtx.Done():
+		return fmt.Errorf("failed to complete the background work within %s: %w", drainTimeout, shutdownCtx.Err())
 	}
+	s.logger.Info("server drained")
 	return nil
 }
 
 // setupRouter sets up the Gin router with the MCP proxy server and API endpoints.
 func (s *Server) setupRouter() (*gin.Engine, error) {
 	gin.SetMode(gin.ReleaseMode)
-	r := gin.Default()
+	r := gin.New()
+	r.Use(accessLog(s.logger), gin.Recovery())
 
 	// if otel is enabled, setup prometheus metrics endpoint
 	if s.otelProviders != nil && s.otelProviders.IsEnabled() {
@@ -154,8 +308,15 @@ func (s *Server) setupRouter() (*gin.Engine, error) {
 
 		// expose prometheus metrics endpoint
 		r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	} else if s.prometheusMetrics != nil {
+		// otherwise, metrics can be recorded & exposed natively in the prometheus format
+		r.Use(httpMetrics(s.prometheusMetrics))
+		r.GET("/metrics", gin.WrapH(s.prometheusMetrics.Handler()))
 	}
 
+	// the request ID middleware must run after otel so that the ID can be recorded on the request's span
+	r.Use(requestID())
+
 	r.GET(
 		"/health",
 		func(c *gin.Context) {
@@ -163,33 +324,55 @@ func (s *Server) setupRouter() (*gin.Engine, error) {
 		},
 	)
 
+	// probes for container orchestrators & load balancers
+	r.GET("/healthz", livenessHandler)
+	r.GET("/readyz", s.readinessHandler())
 	r.GET(
-		"/metadata",
-		func(c *gin.Context) {
-			m := &types.ServerMetadata{
-				Version: version.GetVersion(),
-			}
-			c.JSON(http.StatusOK, m)
-		},
+		"/health/details",
+		s.requireInitialized(),
+		s.verifyUserAuthForAPIAccess(),
+		s.requireAdminUser(),
+		s.healthDetailsHandler(),
 	)
 
-	r.POST("/init", s.registerInitServerHandler())
+	r.GET("/metadata", s.metadataHandler())
 
-	requireEnterpriseMode := s.requireServerMode(model.ModeEnterprise)
+	r.POST("/init", s.rejectWritesWhenDegraded(), s.registerInitServerHandler())
+
+	if s.pprofEnabled {
+		s.registerPprofRoutes(r)
+	}
+	if s.dashboardEnabled {
+		if err := s.registerDashboardRoutes(r); err != nil {
+			return nil, fmt.Errorf("failed to set up the dashboard: %w", err)
+		}
+	}
+
+	openAPIHandler, err := s.openAPIHandler()
+	if err != nil {
+		return nil, err
+	}
+	r.GET(OpenAPISpecPath, compressResponses(s.httpConfig.CompressionMinBytes, s.logger), openAPIHandler)
 
 	// Set up the MCP proxy server on /mcp
-	streamableHTTPServer := server.NewStreamableHTTPServer(s.mcpProxyServer)
+	streamableHTTPServer := server.NewStreamableHTTPServer(s.mcpProxyServer, server.WithSessionIdManager(s.mcpSessions))
 	r.Any(
 		"/mcp",
+		s.streamingEndpoint(),
 		s.requireInitialized(),
 		s.checkAuthForMcpProxyAccess(),
+		s.enforceClientQuota(),
+		s.cacheToolsList(func(c *gin.Context) string { return "" }),
 		gin.WrapH(streamableHTTPServer),
 	)
 
 	r.Any(
 		V0PathPrefix+"/groups/:name/mcp",
+		s.streamingEndpoint(),
 		s.requireInitialized(),
 		s.checkAuthForMcpProxyAccess(),
+		s.enforceClientQuota(),
+		s.cacheToolsList(func(c *gin.Context) string { return "group:" + c.Param("name") }),
 		s.toolGroupMCPServerCallHandler(),
 	)
 
@@ -197,6 +380,7 @@ func (s *Server) setupRouter() (*gin.Engine, error) {
 	sseServer := server.NewSSEServer(s.sseMcpProxyServer)
 	r.Any(
 		"/sse",
+		s.streamingEndpoint(),
 		s.requireInitialized(),
 		s.checkAuthForMcpProxyAccess(),
 		gin.WrapH(sseServer.SSEHandler()),
@@ -205,11 +389,13 @@ func (s *Server) setupRouter() (*gin.Engine, error) {
 		"/message",
 		s.requireInitialized(),
 		s.checkAuthForMcpProxyAccess(),
+		s.enforceClientQuota(),
 		gin.WrapH(sseServer.MessageHandler()),
 	)
 
 	r.Any(
 		V0PathPrefix+"/groups/:name/sse",
+		s.streamingEndpoint(),
 		s.requireInitialized(),
 		s.checkAuthForMcpProxyAccess(),
 		s.toolGroupSseMCPServerCallHandler(),
@@ -218,23 +404,85 @@ func (s *Server) setupRouter() (*gin.Engine, error) {
 		V0PathPrefix+"/groups/:name/message",
 		s.requireInitialized(),
 		s.checkAuthForMcpProxyAccess(),
+		s.enforceClientQuota(),
 		s.toolGroupSseMCPServerCallMessageHandler(),
 	)
 
-	// Setup /v0 API endpoints
+	// Setup the registry API endpoints.
+	// v1 is the stable API, v0 is deprecated and serves the same endpoints until its sunset date.
+	// large responses of the registry API are compressed, but the MCP proxy endpoints above are left
+	// untouched because MCP clients don't necessarily support compression.
+	apiV1 := r.Group(
+		V1ApiPathPrefix,
+		compressResponses(s.httpConfig.CompressionMinBytes, s.logger),
+		s.rejectWritesWhenDegraded(),
+		s.requireInitialized(),
+		s.verifyUserAuthForAPIAccess(),
+	)
+	s.registerAPIRoutes(apiV1)
+
 	apiV0 := r.Group(
 		V0ApiPathPrefix,
+		deprecatedAPI(v0ApiSunset, V1ApiPathPrefix),
+		compressResponses(s.httpConfig.CompressionMinBytes, s.logger),
+		s.rejectWritesWhenDegraded(),
 		s.requireInitialized(),
 		s.verifyUserAuthForAPIAccess(),
 	)
+	s.registerAPIRoutes(apiV0)
+
+	// MCP clients renew their own access token, so they are authenticated like on the MCP proxy endpoints
+	// rather than as users
+	s.registerMcpClientTokenRoutes(r.Group(
+		V1ApiPathPrefix,
+		s.rejectWritesWhenDegraded(),
+		s.requireInitialized(),
+		s.checkAuthForMcpProxyAccess(),
+	))
+	s.registerMcpClientTokenRoutes(r.Group(
+		V0ApiPathPrefix,
+		deprecatedAPI(v0ApiSunset, V1ApiPathPrefix),
+		s.rejectWritesWhenDegraded(),
+		s.requireInitialized(),
+		s.checkAuthForMcpProxyAccess(),
+	))
+
+	// identity providers provision users with the access token of an admin user
+	s.registerSCIMRoutes(r.Group(
+		SCIMPathPrefix,
+		s.rejectWritesWhenDegraded(),
+		s.requireInitialized(),
+		s.requireServerMode(model.ModeEnterprise),
+		s.verifyUserAuthForAPIAccess(),
+		s.requireAdminUser(),
+	))
+
+	return r, nil
+}
+
+// registerMcpClientTokenRoutes registers the API endpoints that MCP clients call with their own access token
+// on the given API version group.
+func (s *Server) registerMcpClientTokenRoutes(api *gin.RouterGroup) {
+	api.POST(
+		"/clients/token/renew",
+		s.requireServerMode(model.ModeEnterprise),
+		s.renewMcpClientTokenHandler(),
+	)
+}
+
+// registerAPIRoutes registers all the registry API endpoints on the given API version group.
+// All API versions share the same handlers.
+func (s *Server) registerAPIRoutes(api *gin.RouterGroup) {
+	requireEnterpriseMode := s.requireServerMode(model.ModeEnterprise)
 
 	// endpoints accessible by a standard user in enterprise mode or anyone in development mode
-	userAPI := apiV0.Group("/")
+	userAPI := api.Group("/")
 	{
-		userAPI.GET("/servers", s.listServersHandler())
+		userAPI.GET("/servers", conditionalGET(), s.listServersHandler())
 
-		userAPI.GET("/tools", s.listToolsHandler())
+		userAPI.GET("/tools", conditionalGET(), s.listToolsHandler())
 		userAPI.POST("/tools/invoke", s.invokeToolHandler())
+		userAPI.GET("/jobs/:id", s.getJobHandler())
 		userAPI.GET("/tool", s.getToolHandler())
 
 		// Prompt endpoints
@@ -242,16 +490,17 @@ func (s *Server) setupRouter() (*gin.Engine, error) {
 		userAPI.GET("/prompt", s.getPromptHandler())
 		userAPI.POST("/prompts/render", s.getPromptWithArgsHandler())
 
+		userAPI.GET("/search", s.searchHandler())
+
 		userAPI.GET("/users/whoami", requireEnterpriseMode, s.whoAmIHandler())
+		userAPI.POST("/users/me/token", requireEnterpriseMode, s.renewAccessTokenHandler())
 	}
 
 	// endpoints only accessible by an admin user in enterprise mode or anyone in development mode
-	adminAPI := apiV0.Group("/", s.requireAdminUser())
+	adminAPI := api.Group("/", s.requireAdminUser())
 	{
-		adminAPI.POST("/servers", s.registerServerHandler())
-		adminAPI.DELETE("/servers/:name", s.deregisterServerHandler())
-		adminAPI.POST("/servers/:name/enable", s.enableServerHandler())
-		adminAPI.POST("/servers/:name/disable", s.disableServerHandler())
+		adminAPI.POST("/servers", s.idempotent(), s.registerServerHandler())
+		adminAPI.POST("/servers/test", s.testServerHandler())
 
 		adminAPI.POST("/tools/enable", s.enableToolsHandler())
 		adminAPI.POST("/tools/disable", s.disableToolsHandler())
@@ -268,8 +517,14 @@ func (s *Server) setupRouter() (*gin.Engine, error) {
 		adminAPI.POST(
 			"/clients",
 			requireEnterpriseMode,
+			s.idempotent(),
 			s.createMcpClientHandler(),
 		)
+		adminAPI.PATCH(
+			"/clients/:name",
+			requireEnterpriseMode,
+			s.patchMcpClientHandler(),
+		)
 		adminAPI.DELETE(
 			"/clients/:name",
 			requireEnterpriseMode,
@@ -279,6 +534,7 @@ func (s *Server) setupRouter() (*gin.Engine, error) {
 		// endpoints for managing human users (enterprise mode only)
 		adminAPI.POST("/users",
 			requireEnterpriseMode,
+			s.idempotent(),
 			s.createUserHandler(),
 		)
 		adminAPI.GET("/users",
@@ -289,14 +545,63 @@ func (s *Server) setupRouter() (*gin.Engine, error) {
 			requireEnterpriseMode,
 			s.deleteUserHandler(),
 		)
+		adminAPI.POST("/users/:username/suspend", requireEnterpriseMode, s.suspendUserHandler())
+		adminAPI.POST("/users/:username/activate", requireEnterpriseMode, s.activateUserHandler())
+		adminAPI.PUT("/users/:username/tool-policy", requireEnterpriseMode, s.setUserToolPolicyHandler())
+		adminAPI.DELETE("/users/:username/tool-policy", requireEnterpriseMode, s.deleteUserToolPolicyHandler())
+
+		// endpoints for managing teams of users & their permissions (enterprise mode only)
+		adminAPI.POST("/teams", requireEnterpriseMode, s.idempotent(), s.createTeamHandler())
+		adminAPI.GET("/teams", requireEnterpriseMode, s.listTeamsHandler())
+		adminAPI.GET("/teams/:name", requireEnterpriseMode, s.getTeamHandler())
+		adminAPI.PUT("/teams/:name", requireEnterpriseMode, s.updateTeamHandler())
+		adminAPI.DELETE("/teams/:name", requireEnterpriseMode, s.deleteTeamHandler())
 
 		// endpoints for managing tool groups
-		adminAPI.POST("/tool-groups", s.createToolGroupHandler())
-		adminAPI.GET("/tool-groups/:name", s.getToolGroupHandler())
-		adminAPI.GET("/tool-groups", s.listToolGroupsHandler())
-		adminAPI.DELETE("/tool-groups/:name", s.deleteToolGroupHandler())
-		adminAPI.PUT("/tool-groups/:name", s.updateToolGroupHandler())
+		adminAPI.POST("/tool-groups", s.idempotent(), s.createToolGroupHandler())
+		adminAPI.GET("/tool-groups", conditionalGET(), s.listToolGroupsHandler())
+		adminAPI.POST("/tool-groups/validate", s.validateToolGroupHandler())
+
+		adminAPI.GET("/stats", s.statsHandler())
+		adminAPI.GET("/invocations", s.listInvocationsHandler())
+
+		// stream of changes made to the registry
+		adminAPI.GET("/events", s.streamingEndpoint(), s.eventsHandler())
+
+		// recent entries of the server's logs, optionally followed as a stream
+		adminAPI.GET("/logs", s.streamingEndpoint(), s.logsHandler())
 	}
 
-	return r, nil
+	// endpoints accessible by an admin user or the members of the teams allowed to administer the MCP server
+	// in enterprise mode, or anyone in development mode
+	serverAdminAPI := api.Group("/", s.requireAdminOrTeamPermission(teamPermissionServer))
+	{
+		serverAdminAPI.GET("/servers/:name/consumers", s.getServerConsumersHandler())
+		serverAdminAPI.PUT("/servers/:name", s.updateServerHandler())
+		serverAdminAPI.DELETE("/servers/:name", s.deregisterServerHandler())
+		serverAdminAPI.POST("/servers/:name/enable", s.enableServerHandler())
+		serverAdminAPI.POST("/servers/:name/disable", s.disableServerHandler())
+	}
+
+	// endpoints accessible by an admin user or the members of the teams allowed to administer the tool group
+	// in enterprise mode, or anyone in development mode
+	groupAdminAPI := api.Group("/", s.requireAdminOrTeamPermission(teamPermissionToolGroup))
+	{
+		groupAdminAPI.GET("/tool-groups/:name", s.getToolGroupHandler())
+		groupAdminAPI.DELETE("/tool-groups/:name", s.deleteToolGroupHandler())
+		groupAdminAPI.PUT("/tool-groups/:name", s.updateToolGroupHandler())
+		groupAdminAPI.PATCH("/tool-groups/:name", s.patchToolGroupHandler())
+	}
+}
+
+// lookupErrorStatus returns the HTTP status code for an error returned while looking up an entity:
+// 404 if the entity doesn't exist, 503 if a stdio MCP server runs too many processes to serve the call, 500 otherwise.
+func lookupErrorStatus(err error) int {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return http.StatusNotFound
+	}
+	if errors.Is(err, mcp.ErrStdioServerBusy) {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
 }
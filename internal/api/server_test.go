@@ -1,13 +1,23 @@
 package api
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"gorm.io/gorm"
 )
 
 func TestNewServer(t *testing.T) {
@@ -45,9 +55,7 @@ func TestNewServer(t *testing.T) {
 	}
 }
 
-func TestServer_Start(t *testing.T) {
-	// This test is limited due to the blocking nature of the Start method
-	// We can only test that the method doesn't panic with invalid port
+func TestServer_Run(t *testing.T) {
 	t.Run("invalid port", func(t *testing.T) {
 		// Create a minimal server with a router to avoid panic
 		gin.SetMode(gin.TestMode)
@@ -59,7 +67,7 @@ func TestServer_Start(t *testing.T) {
 		}
 
 		// This should return an error due to invalid port
-		err := server.Start()
+		err := server.Run(context.Background(), nil, time.Second)
 		testhelpers.AssertError(t, err)
 	})
 }
@@ -83,3 +91,160 @@ func TestRouterSetup(t *testing.T) {
 	router.ServeHTTP(w, req)
 	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
 }
+
+func TestAPIVersionRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	server, err := NewServer(&ServerOptions{Port: "8080"})
+	testhelpers.AssertNoError(t, err)
+
+	// every v0 API route must also be served by v1 with the same handler
+	v0Routes := make(map[string]string)
+	v1Routes := make(map[string]string)
+	for _, r := range server.router.Routes() {
+		if suffix, ok := strings.CutPrefix(r.Path, V0ApiPathPrefix); ok {
+			v0Routes[r.Method+" "+suffix] = r.Handler
+		}
+		if suffix, ok := strings.CutPrefix(r.Path, V1ApiPathPrefix); ok {
+			v1Routes[r.Method+" "+suffix] = r.Handler
+		}
+	}
+	testhelpers.AssertTrue(t, len(v1Routes) > 0, "Expected v1 API routes to be registered")
+	testhelpers.AssertEqual(t, len(v0Routes), len(v1Routes))
+	for route, handler := range v0Routes {
+		testhelpers.AssertEqual(t, handler, v1Routes[route])
+	}
+}
+
+func TestDeprecatedAPI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	sunset := time.Date(2027, time.April, 1, 0, 0, 0, 0, time.UTC)
+	router.GET("/old", deprecatedAPI(sunset, "/api/v1"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/old", nil)
+	router.ServeHTTP(w, req)
+
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+	testhelpers.AssertEqual(t, "true", w.Header().Get("Deprecation"))
+	testhelpers.AssertEqual(t, "Thu, 01 Apr 2027 00:00:00 GMT", w.Header().Get("Sunset"))
+	testhelpers.AssertEqual(t, `</api/v1>; rel="successor-version"`, w.Header().Get("Link"))
+}
+
+func TestLookupErrorStatus(t *testing.T) {
+	notFound := fmt.Errorf("failed to get MCP server foo from DB: %w", gorm.ErrRecordNotFound)
+	testhelpers.AssertEqual(t, http.StatusNotFound, lookupErrorStatus(notFound))
+	testhelpers.AssertEqual(t, http.StatusInternalServerError, lookupErrorStatus(errors.New("connection refused")))
+}
+
+func TestRunDrainsServer(t *testing.T) {
+	s, err := NewServer(&ServerOptions{Logger: logger.NewNop(), HTTP: DefaultHTTPServerConfig()})
+	testhelpers.AssertNoError(t, err)
+
+	release := make(chan struct{})
+	s.router.POST("/slow", func(c *gin.Context) {
+		<-release
+		c.String(http.StatusOK, "done")
+	})
+	s.router.GET("/stream", s.streamingEndpoint(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+		c.Writer.Flush()
+		<-c.Request.Context().Done()
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	testhelpers.AssertNoError(t, err)
+	baseURL := "http://" + listener.Addr().String()
+	ctx, cancel := context.WithCancel(context.Background())
+	ran := make(chan error, 1)
+	go func() { ran <- s.Run(ctx, listener, 5*time.Second) }()
+
+	stream, err := http.Get(baseURL + "/stream")
+	testhelpers.AssertNoError(t, err)
+	defer stream.Body.Close()
+
+	slow := make(chan string, 1)
+	go func() {
+		resp, err := http.Post(baseURL+"/slow", "text/plain", nil)
+		if err != nil {
+			slow <- err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		slow <- string(body)
+	}()
+	// wait for the slow request to be in flight
+	time.Sleep(100 * time.Millisecond)
+
+	cancel()
+	// the stream is ended right away, while the slow request is left to complete
+	_, err = io.ReadAll(stream.Body)
+	testhelpers.AssertNoError(t, err)
+	select {
+	case <-ran:
+		t.Fatal("the server must wait for the requests in flight")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	testhelpers.AssertEqual(t, "done", <-slow)
+	testhelpers.AssertNoError(t, <-ran)
+
+	_, err = http.Get(baseURL + "/stream")
+	testhelpers.AssertError(t, err)
+}
+
+func TestRunDrainTimeout(t *testing.T) {
+	s, err := NewServer(&ServerOptions{Logger: logger.NewNop()})
+	testhelpers.AssertNoError(t, err)
+	release := make(chan struct{})
+	defer close(release)
+	s.router.POST("/stuck", func(c *gin.Context) { <-release })
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	testhelpers.AssertNoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	ran := make(chan error, 1)
+	go func() { ran <- s.Run(ctx, listener, 100*time.Millisecond) }()
+
+	go func() {
+		resp, err := http.Post("http://"+listener.Addr().String()+"/stuck", "text/plain", nil)
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	cancel()
+	err = <-ran
+	testhelpers.AssertError(t, err)
+	testhelpers.AssertStringContains(t, err.Error(), "failed to drain the server within 100ms")
+}
+
+func TestRunWaitsForBackgroundWork(t *testing.T) {
+	s, err := NewServer(&ServerOptions{Logger: logger.NewNop()})
+	testhelpers.AssertNoError(t, err)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	run := func(drainTimeout time.Duration) error {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		testhelpers.AssertNoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		return s.Run(ctx, listener, drainTimeout, wg.Wait)
+	}
+
+	// the background work is given up on after the drain timeout
+	err = run(100 * time.Millisecond)
+	testhelpers.AssertError(t, err)
+	testhelpers.AssertStringContains(t, err.Error(), "failed to complete the background work within 100ms")
+
+	time.AfterFunc(100*time.Millisecond, wg.Done)
+	testhelpers.AssertNoError(t, run(5*time.Second))
+}
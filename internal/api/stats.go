@@ -0,0 +1,98 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// statsHandler returns an overview of the registry's entities and the calls made through the gateway recently.
+func (s *Server) statsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		stats, err := s.registryStats()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to gather statistics: " + err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, stats)
+	}
+}
+
+func (s *Server) registryStats() (*types.RegistryStats, error) {
+	stats := &types.RegistryStats{}
+
+	servers, err := s.mcpService.ListMcpServers()
+	if err != nil {
+		return nil, err
+	}
+	stats.Servers = len(servers)
+
+	tools, err := s.mcpService.ListTools()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tools {
+		stats.Tools.Total++
+		if t.Enabled {
+			stats.Tools.Enabled++
+		} else {
+			stats.Tools.Disabled++
+		}
+	}
+
+	prompts, err := s.mcpService.ListPrompts()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range prompts {
+		stats.Prompts.Total++
+		if p.Enabled {
+			stats.Prompts.Enabled++
+		} else {
+			stats.Prompts.Disabled++
+		}
+	}
+
+	groups, err := s.toolGroupService.ListToolGroups()
+	if err != nil {
+		return nil, err
+	}
+	stats.ToolGroups = len(groups)
+
+	// clients & users only exist in enterprise mode, so these counts are always 0 in development mode
+	clients, err := s.mcpClientService.ListClients()
+	if err != nil {
+		return nil, err
+	}
+	stats.McpClients = len(clients)
+
+	users, err := s.userService.ListUsers()
+	if err != nil {
+		return nil, err
+	}
+	stats.Users = len(users)
+
+	if s.invocationStats != nil {
+		counts := s.invocationStats.Counts()
+		stats.Invocations = types.InvocationStats{
+			WindowSeconds:       int64(s.invocationStats.Window().Seconds()),
+			ToolCalls:           counts.ToolCalls,
+			ToolCallErrors:      counts.ToolCallErrors,
+			ToolCallErrorRate:   errorRate(counts.ToolCallErrors, counts.ToolCalls),
+			PromptCalls:         counts.PromptCalls,
+			PromptCallErrors:    counts.PromptCallErrors,
+			PromptCallErrorRate: errorRate(counts.PromptCallErrors, counts.PromptCalls),
+		}
+	}
+
+	return stats, nil
+}
+
+// errorRate returns the fraction of calls that failed, 0 if there were no calls.
+func errorRate(errors, calls int64) float64 {
+	if calls == 0 {
+		return 0
+	}
+	return float64(errors) / float64(calls)
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcpclient"
+	"github.com/mcpjungle/mcpjungle/internal/service/toolgroup"
+	"github.com/mcpjungle/mcpjungle/internal/service/user"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestStatsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	srv := setup.CreateTestMcpServer("calculator", "", types.TransportStreamableHTTP, []byte(`{"url": "http://localhost:1"}`))
+	setup.CreateTestTool("calculator__add", "", srv.ID, true, []byte(`{"type":"object"}`))
+	disabled := setup.CreateTestTool("calculator__subtract", "", srv.ID, false, []byte(`{"type":"object"}`))
+	// gorm skips the zero value on create in favour of the column default, so disable the tool explicitly
+	testhelpers.AssertNoError(t, setup.DB.Model(disabled).Update("enabled", false).Error)
+	testhelpers.AssertNoError(t, setup.DB.Create(&model.ToolGroup{Name: "math"}).Error)
+
+	invocationStats := telemetry.NewInvocationStats(telemetry.NewNoopCustomMetrics(), time.Hour)
+	ctx := context.Background()
+	invocationStats.RecordToolCall(ctx, "calculator", "add", telemetry.ToolCallOutcomeSuccess, time.Millisecond)
+	invocationStats.RecordToolCall(ctx, "calculator", "add", telemetry.ToolCallOutcomeSuccess, time.Millisecond)
+	invocationStats.RecordToolCall(ctx, "calculator", "add", telemetry.ToolCallOutcomeSuccess, time.Millisecond)
+	invocationStats.RecordToolCall(ctx, "calculator", "add", telemetry.ToolCallOutcomeError, time.Millisecond)
+
+	proxy := server.NewMCPServer("proxy", "test")
+	mcpService, err := mcp.NewMCPService(setup.DB, proxy, proxy, invocationStats, logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+	toolGroupService, err := toolgroup.NewToolGroupService(setup.DB, mcpService, telemetry.NewNoopCustomMetrics())
+	testhelpers.AssertNoError(t, err)
+
+	s := &Server{
+		mcpService:       mcpService,
+		toolGroupService: toolGroupService,
+		mcpClientService: mcpclient.NewMCPClientService(setup.DB),
+		userService:      user.NewUserService(setup.DB),
+		invocationStats:  invocationStats,
+	}
+	router := gin.New()
+	router.GET("/stats", s.statsHandler())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stats", nil))
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+
+	var stats types.RegistryStats
+	testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+	testhelpers.AssertEqual(t, 1, stats.Servers)
+	testhelpers.AssertEqual(t, types.EntityCounts{Total: 2, Enabled: 1, Disabled: 1}, stats.Tools)
+	testhelpers.AssertEqual(t, types.EntityCounts{}, stats.Prompts)
+	testhelpers.AssertEqual(t, 1, stats.ToolGroups)
+	testhelpers.AssertEqual(t, 0, stats.McpClients)
+	testhelpers.AssertEqual(t, 0, stats.Users)
+
+	testhelpers.AssertEqual(t, int64(3600), stats.Invocations.WindowSeconds)
+	testhelpers.AssertEqual(t, int64(4), stats.Invocations.ToolCalls)
+	testhelpers.AssertEqual(t, int64(1), stats.Invocations.ToolCallErrors)
+	testhelpers.AssertEqual(t, 0.25, stats.Invocations.ToolCallErrorRate)
+	testhelpers.AssertEqual(t, 0.0, stats.Invocations.PromptCallErrorRate)
+}
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/team"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// teamModel converts a team supplied in an API request into its database model.
+func teamModel(t *types.Team) (*model.Team, error) {
+	m := &model.Team{Name: t.Name, Description: t.Description}
+	var err error
+	if m.Members, err = json.Marshal(t.Members); err != nil {
+		return nil, err
+	}
+	if m.ToolGroups, err = json.Marshal(t.ToolGroups); err != nil {
+		return nil, err
+	}
+	if m.Servers, err = json.Marshal(t.Servers); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// teamResponse converts a team's database model into its API representation.
+func teamResponse(m *model.Team) (*types.Team, error) {
+	members, err := m.GetMembers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the members of team %s: %w", m.Name, err)
+	}
+	toolGroups, err := m.GetToolGroups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the tool groups of team %s: %w", m.Name, err)
+	}
+	servers, err := m.GetServers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the servers of team %s: %w", m.Name, err)
+	}
+	return &types.Team{
+		Name:        m.Name,
+		Description: m.Description,
+		Members:     members,
+		ToolGroups:  toolGroups,
+		Servers:     servers,
+	}, nil
+}
+
+// teamErrorStatus returns the HTTP status code for an error returned by the team service.
+func teamErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, team.ErrTeamNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, team.ErrInvalidTeam):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (s *Server) createTeamHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input types.Team
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		t, err := teamModel(&input)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := s.teamService.CreateTeam(t); err != nil {
+			c.JSON(teamErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		resp, err := teamResponse(t)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusCreated, resp)
+	}
+}
+
+func (s *Server) listTeamsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, ok := pageQuery(c)
+		if !ok {
+			return
+		}
+		teams, total, err := s.teamService.ListTeamsPage(page)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		setTotalCount(c, total)
+
+		resp := make([]*types.Team, len(teams))
+		for i := range teams {
+			if resp[i], err = teamResponse(&teams[i]); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+func (s *Server) getTeamHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		t, err := s.teamService.GetTeam(c.Param("name"))
+		if err != nil {
+			c.JSON(teamErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		resp, err := teamResponse(t)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+func (s *Server) updateTeamHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Param("name")
+		var input types.Team
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if input.Name != "" && input.Name != name {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "the name of a team cannot be changed"})
+			return
+		}
+		t, err := teamModel(&input)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := s.teamService.UpdateTeam(name, t); err != nil {
+			c.JSON(teamErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		resp, err := teamResponse(t)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+func (s *Server) deleteTeamHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := s.teamService.DeleteTeam(c.Param("name")); err != nil {
+			c.JSON(teamErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/service/toolgroup"
+)
+
+// toolAccessFilter reports whether the authenticated user may see & invoke the tool with the given name.
+type toolAccessFilter func(name string) bool
+
+// userToolAccessFilter returns the filter for the tools the authenticated user may access via the API,
+// based on the user's tool access policy, ie, the tools of the allowed MCP servers and the tools exposed by
+// the allowed tool groups.
+// This mirrors what an MCP client gets from the proxy with the same allow list or the groups' endpoints.
+// It returns nil if the user may access all tools, eg- because the user is an admin, the user has no policy or
+// mcpjungle is running in development mode.
+func (s *Server) userToolAccessFilter(c *gin.Context) (toolAccessFilter, error) {
+	v, exists := c.Get("user")
+	if !exists {
+		return nil, nil
+	}
+	u, ok := v.(*model.User)
+	if !ok || !u.HasToolPolicy() {
+		return nil, nil
+	}
+
+	servers, err := u.GetAllowedServers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the allowed servers of the user: %w", err)
+	}
+	groups, err := u.GetAllowedToolGroups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the allowed tool groups of the user: %w", err)
+	}
+
+	groupTools := make(map[string]struct{})
+	for _, g := range groups {
+		if s.toolGroupService == nil {
+			break
+		}
+		tools, err := s.toolGroupService.GetEffectiveTools(g)
+		if err != nil {
+			if errors.Is(err, toolgroup.ErrToolGroupNotFound) {
+				// groups that were deleted since the policy was set simply don't grant anything
+				continue
+			}
+			return nil, fmt.Errorf("failed to resolve the tools of group %s: %w", g, err)
+		}
+		for _, t := range tools {
+			groupTools[t] = struct{}{}
+		}
+	}
+
+	return func(name string) bool {
+		if _, ok := groupTools[name]; ok {
+			return true
+		}
+		server, ok := mcp.ToolServerName(name)
+		return ok && slices.Contains(servers, server)
+	}, nil
+}
+
+// authorizeToolAccess checks that the authenticated user may access the tool with the given name.
+// If not, it writes an error response and returns false.
+func (s *Server) authorizeToolAccess(c *gin.Context, name string) bool {
+	allowed, err := s.userToolAccessFilter(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	if allowed != nil && !allowed(name) {
+		c.JSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("user is not allowed to access tool %s", name)})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/service/toolgroup"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestUserToolAccessPolicy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	calc := setup.CreateTestMcpServer("calculator", "", types.TransportStreamableHTTP, []byte(`{"url": "http://localhost:1"}`))
+	setup.CreateTestTool("add", "", calc.ID, true, []byte(`{"type":"object"}`))
+	setup.CreateTestTool("subtract", "", calc.ID, true, []byte(`{"type":"object"}`))
+	clock := setup.CreateTestMcpServer("clock", "", types.TransportStreamableHTTP, []byte(`{"url": "http://localhost:1"}`))
+	setup.CreateTestTool("now", "", clock.ID, true, []byte(`{"type":"object"}`))
+	setup.CreateTestTool("sleep", "", clock.ID, true, []byte(`{"type":"object"}`))
+
+	proxy := server.NewMCPServer("proxy", "test")
+	mcpService, err := mcp.NewMCPService(setup.DB, proxy, proxy, telemetry.NewNoopCustomMetrics(), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+	toolGroupService, err := toolgroup.NewToolGroupService(setup.DB, mcpService, telemetry.NewNoopCustomMetrics())
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, toolGroupService.CreateToolGroup(
+		&model.ToolGroup{Name: "time", IncludedTools: []byte(`["clock__now"]`)},
+	))
+
+	s := &Server{mcpService: mcpService, toolGroupService: toolGroupService}
+	newRouter := func(u *model.User) *gin.Engine {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			if u != nil {
+				c.Set("user", u)
+			}
+		})
+		router.GET("/tools", s.listToolsHandler())
+		router.GET("/tool", s.getToolHandler())
+		router.POST("/tools/invoke", s.invokeToolHandler())
+		return router
+	}
+	listTools := func(router *gin.Engine) []string {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tools", nil))
+		testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+		var tools []model.Tool
+		testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &tools))
+		names := make([]string, len(tools))
+		for i, tool := range tools {
+			names[i] = tool.Name
+		}
+		return names
+	}
+
+	restricted := &model.User{
+		Username:          "alice",
+		Role:              types.UserRoleUser,
+		AllowedServers:    []byte(`["calculator"]`),
+		AllowedToolGroups: []byte(`["time", "deleted"]`),
+	}
+
+	t.Run("unrestricted users", func(t *testing.T) {
+		unrestricted := []*model.User{
+			nil, // development mode
+			{Username: "bob", Role: types.UserRoleUser},
+			{Username: "admin", Role: types.UserRoleAdmin, AllowedServers: []byte(`[]`)},
+		}
+		for _, u := range unrestricted {
+			testhelpers.AssertEqual(t, 4, len(listTools(newRouter(u))))
+		}
+	})
+
+	t.Run("list tools", func(t *testing.T) {
+		names := listTools(newRouter(restricted))
+		testhelpers.AssertEqual(t, "calculator__add,calculator__subtract,clock__now", strings.Join(names, ","))
+	})
+
+	t.Run("paginated list of tools", func(t *testing.T) {
+		// the tools of unrestricted users are paginated by the database, the others in memory
+		for u, total := range map[*model.User]string{nil: "4", restricted: "3"} {
+			w := httptest.NewRecorder()
+			newRouter(u).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tools?limit=1&offset=2", nil))
+			testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+			testhelpers.AssertEqual(t, total, w.Header().Get(types.TotalCountHeader))
+			var tools []model.Tool
+			testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &tools))
+			testhelpers.AssertEqual(t, 1, len(tools))
+			testhelpers.AssertEqual(t, "clock__now", tools[0].Name)
+		}
+	})
+
+	t.Run("get tool", func(t *testing.T) {
+		router := newRouter(restricted)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tool?name=clock__now", nil))
+		testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tool?name=clock__sleep", nil))
+		testhelpers.AssertEqual(t, http.StatusForbidden, w.Code)
+		testhelpers.AssertStringContains(t, w.Body.String(), "not allowed to access tool clock__sleep")
+	})
+
+	t.Run("invoke tool", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/tools/invoke?async=true", strings.NewReader(`{"name": "clock__sleep"}`))
+		newRouter(restricted).ServeHTTP(w, req)
+		testhelpers.AssertEqual(t, http.StatusForbidden, w.Code)
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/internal/service/toolgroup"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
 
@@ -24,6 +25,7 @@ func (s *Server) createToolGroupHandler() gin.HandlerFunc {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
+		s.eventBroker.Publish(types.RegistryEventToolGroupCreated, input.Name, nil)
 		resp := &types.CreateToolGroupResponse{
 			ToolGroupEndpoints: getToolGroupEndpoints(c, input.Name),
 		}
@@ -35,11 +37,16 @@ func (s *Server) createToolGroupHandler() gin.HandlerFunc {
 // This API only provides basic information about each tool group, ie, name and description.
 func (s *Server) listToolGroupsHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		groups, err := s.toolGroupService.ListToolGroups()
+		page, ok := pageQuery(c)
+		if !ok {
+			return
+		}
+		groups, total, err := s.toolGroupService.ListToolGroupsPage(page)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
+		setTotalCount(c, total)
 
 		resp := make([]*types.ToolGroup, len(groups))
 		for i, g := range groups {
@@ -75,6 +82,7 @@ func (s *Server) getToolGroupHandler() gin.HandlerFunc {
 			ToolGroup: &types.ToolGroup{
 				Name:        group.Name,
 				Description: group.Description,
+				ReadOnly:    group.ReadOnly,
 			},
 			ToolGroupEndpoints: getToolGroupEndpoints(c, group.Name),
 		}
@@ -115,6 +123,18 @@ func (s *Server) getToolGroupHandler() gin.HandlerFunc {
 		}
 		resp.ExcludedTools = excludedTools
 
+		// Get cached tools
+		var cachedTools map[string]string
+		cachedTools, err = group.GetCachedTools()
+		if err != nil {
+			c.JSON(
+				http.StatusInternalServerError,
+				gin.H{"error": fmt.Sprintf("error getting cached tools of group: %s", err.Error())},
+			)
+			return
+		}
+		resp.CachedTools = cachedTools
+
 		c.JSON(http.StatusOK, resp)
 	}
 }
@@ -132,6 +152,7 @@ func (s *Server) deleteToolGroupHandler() gin.HandlerFunc {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
+		s.eventBroker.Publish(types.RegistryEventToolGroupDeleted, name, nil)
 
 		// TODO: return 404 if the group did not exist.
 		//  The tool group service should return ErrToolGroupNotFound if the group does not exist.
@@ -163,88 +184,108 @@ func (s *Server) updateToolGroupHandler() gin.HandlerFunc {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
+		s.eventBroker.Publish(types.RegistryEventToolGroupUpdated, name, nil)
 
-		// create and send response object
-		resp := &types.UpdateToolGroupResponse{
-			Name: name,
-			Old: &types.ToolGroup{
-				Name:        originalConf.Name,
-				Description: originalConf.Description,
-			},
-			New: &types.ToolGroup{
-				Name:        input.Name,
-				Description: input.Description,
-			},
-		}
+		writeUpdateToolGroupResponse(c, name, originalConf, &input)
+	}
+}
 
-		var origTools []string
-		origTools, err = originalConf.GetTools()
-		if err != nil {
-			c.JSON(
-				http.StatusInternalServerError,
-				gin.H{"error": fmt.Sprintf("error getting included tools of the original group config: %s", err.Error())},
-			)
+// patchToolGroupHandler updates only the fields of a tool group that are set in the request.
+func (s *Server) patchToolGroupHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Param("name")
+		if name == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "group name is required"})
 			return
 		}
-		resp.Old.IncludedTools = origTools
 
-		var origServers []string
-		origServers, err = originalConf.GetServers()
-		if err != nil {
-			c.JSON(
-				http.StatusInternalServerError,
-				gin.H{"error": fmt.Sprintf("error getting included servers of the original group config: %s", err.Error())},
-			)
+		var patch types.PatchToolGroupInput
+		if err := c.ShouldBindJSON(&patch); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
-		resp.Old.IncludedServers = origServers
 
-		var origExcluded []string
-		origExcluded, err = originalConf.GetExcludedTools()
+		originalConf, newConf, err := s.toolGroupService.PatchToolGroup(name, &patch)
 		if err != nil {
-			c.JSON(
-				http.StatusInternalServerError,
-				gin.H{"error": fmt.Sprintf("error getting excluded tools of the original group config: %s", err.Error())},
-			)
+			if errors.Is(err, toolgroup.ErrToolGroupNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("tool group %s does not exist", name)})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
-		resp.Old.ExcludedTools = origExcluded
+		s.eventBroker.Publish(types.RegistryEventToolGroupUpdated, name, nil)
 
-		var newTools []string
-		newTools, err = input.GetTools()
-		if err != nil {
-			c.JSON(
-				http.StatusInternalServerError,
-				gin.H{"error": fmt.Sprintf("error getting included tools of the new group config: %s", err.Error())},
-			)
-			return
-		}
-		resp.New.IncludedTools = newTools
+		writeUpdateToolGroupResponse(c, name, originalConf, newConf)
+	}
+}
 
-		var newServers []string
-		newServers, err = input.GetServers()
-		if err != nil {
-			c.JSON(
-				http.StatusInternalServerError,
-				gin.H{"error": fmt.Sprintf("error getting included servers of the new group config: %s", err.Error())},
-			)
+// validateToolGroupHandler checks the configuration of a tool group without creating it.
+// The outcome is returned with a 200 status whether the configuration is valid or not.
+func (s *Server) validateToolGroupHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input model.ToolGroup
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
-		resp.New.IncludedServers = newServers
 
-		var newExcluded []string
-		newExcluded, err = input.GetExcludedTools()
+		tools, err := s.toolGroupService.ValidateToolGroup(&input)
 		if err != nil {
-			c.JSON(
-				http.StatusInternalServerError,
-				gin.H{"error": fmt.Sprintf("error getting excluded tools of the new group config: %s", err.Error())},
-			)
+			c.JSON(http.StatusOK, &types.ValidateToolGroupResponse{Error: err.Error()})
 			return
 		}
-		resp.New.ExcludedTools = newExcluded
+		c.JSON(http.StatusOK, &types.ValidateToolGroupResponse{Valid: true, EffectiveTools: tools})
+	}
+}
 
-		c.JSON(http.StatusOK, resp)
+// writeUpdateToolGroupResponse sends the original and the new configuration of an updated tool group.
+func writeUpdateToolGroupResponse(c *gin.Context, name string, originalConf, newConf *model.ToolGroup) {
+	resp := &types.UpdateToolGroupResponse{Name: name}
+
+	var err error
+	resp.Old, err = toolGroupConfig(originalConf)
+	if err != nil {
+		c.JSON(
+			http.StatusInternalServerError,
+			gin.H{"error": fmt.Sprintf("error reading the original group config: %s", err.Error())},
+		)
+		return
+	}
+	resp.New, err = toolGroupConfig(newConf)
+	if err != nil {
+		c.JSON(
+			http.StatusInternalServerError,
+			gin.H{"error": fmt.Sprintf("error reading the new group config: %s", err.Error())},
+		)
+		return
 	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// toolGroupConfig converts the model of a tool group to its configuration as exposed by the API.
+func toolGroupConfig(g *model.ToolGroup) (*types.ToolGroup, error) {
+	conf := &types.ToolGroup{
+		Name:        g.Name,
+		Description: g.Description,
+		ReadOnly:    g.ReadOnly,
+	}
+
+	var err error
+	if conf.IncludedTools, err = g.GetTools(); err != nil {
+		return nil, fmt.Errorf("error getting included tools: %w", err)
+	}
+	if conf.IncludedServers, err = g.GetServers(); err != nil {
+		return nil, fmt.Errorf("error getting included servers: %w", err)
+	}
+	if conf.ExcludedTools, err = g.GetExcludedTools(); err != nil {
+		return nil, fmt.Errorf("error getting excluded tools: %w", err)
+	}
+	if conf.CachedTools, err = g.GetCachedTools(); err != nil {
+		return nil, fmt.Errorf("error getting cached tools: %w", err)
+	}
+	return conf, nil
 }
 
 // toolGroupMCPServerCallHandler handles incoming MCP requests from for a specific tool group.
@@ -258,12 +299,20 @@ func (s *Server) toolGroupMCPServerCallHandler() gin.HandlerFunc {
 			return
 		}
 
+		ctx := c.Request.Context()
+		s.metrics.RecordToolGroupRequest(ctx, groupName, telemetry.ToolGroupTransportStreamableHTTP)
+		if c.Request.Method == http.MethodGet {
+			// a GET request opens a session over which the server streams notifications to the client
+			s.metrics.AddToolGroupSessions(ctx, groupName, telemetry.ToolGroupTransportStreamableHTTP, 1)
+			defer s.metrics.AddToolGroupSessions(ctx, groupName, telemetry.ToolGroupTransportStreamableHTTP, -1)
+		}
+
 		// serve the MCP request using the MCP server
 		// TODO: Make this API more efficient
 		// This api sits in the hot path because we expect high traffic on MCP tool calling.
 		// It is inefficient to create a new StreamableHTTPServer for each request.
 		// Maybe pre-create a StreamableHTTPServer for each tool group and store it in the ToolGroupMCPServer struct?
-		streamableServer := server.NewStreamableHTTPServer(groupMcpServer)
+		streamableServer := server.NewStreamableHTTPServer(groupMcpServer, server.WithSessionIdManager(s.mcpSessions))
 		streamableServer.ServeHTTP(c.Writer, c.Request)
 	}
 }
@@ -311,6 +360,12 @@ func (s *Server) toolGroupSseMCPServerCallHandler() gin.HandlerFunc {
 			return
 		}
 
+		// the SSE connection lasts for the whole session
+		ctx := c.Request.Context()
+		s.metrics.RecordToolGroupRequest(ctx, groupName, telemetry.ToolGroupTransportSSE)
+		s.metrics.AddToolGroupSessions(ctx, groupName, telemetry.ToolGroupTransportSSE, 1)
+		defer s.metrics.AddToolGroupSessions(ctx, groupName, telemetry.ToolGroupTransportSSE, -1)
+
 		groupSseMcpServer.SSEHandler().ServeHTTP(c.Writer, c.Request)
 	}
 }
@@ -329,6 +384,7 @@ func (s *Server) toolGroupSseMCPServerCallMessageHandler() gin.HandlerFunc {
 			return
 		}
 
+		s.metrics.RecordToolGroupRequest(c.Request.Context(), groupName, telemetry.ToolGroupTransportSSE)
 		groupSseMcpServer.MessageHandler().ServeHTTP(c.Writer, c.Request)
 	}
 }
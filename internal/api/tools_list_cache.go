@@ -0,0 +1,158 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// toolsVersion identifies the state of the tools served by the MCP proxy servers.
+type toolsVersion struct {
+	proxy  uint64
+	groups uint64
+}
+
+type toolsListCacheKey struct {
+	// endpoint identifies the MCP proxy server the tools are listed from, ie, the global proxy or a tool group's
+	endpoint string
+	cursor   string
+}
+
+type toolsListCacheEntry struct {
+	version toolsVersion
+	result  json.RawMessage
+}
+
+// toolsListCache keeps the results of the tools/list requests served by the streamable HTTP MCP proxy endpoints.
+// Many MCP clients list the tools at the start of every session, and a proxy serving thousands of tools would
+// otherwise sort & serialize all of them again for each client. A cached result is served until the tools change.
+type toolsListCache struct {
+	mu      sync.Mutex
+	entries map[toolsListCacheKey]toolsListCacheEntry
+}
+
+func newToolsListCache() *toolsListCache {
+	return &toolsListCache{entries: make(map[toolsListCacheKey]toolsListCacheEntry)}
+}
+
+// get returns the cached result for the given key, provided it was computed from the given version of the tools.
+func (c *toolsListCache) get(key toolsListCacheKey, version toolsVersion) (json.RawMessage, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok || e.version != version {
+		return nil, false
+	}
+	return e.result, true
+}
+
+func (c *toolsListCache) set(key toolsListCacheKey, version toolsVersion, result json.RawMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = toolsListCacheEntry{version: version, result: result}
+}
+
+// currentToolsVersion returns the version of the tools served by the MCP proxy servers.
+// It returns false if the version can't be tracked, in which case the tools/list results must not be cached.
+func (s *Server) currentToolsVersion() (toolsVersion, bool) {
+	if s.mcpService == nil || s.toolsListCache == nil {
+		return toolsVersion{}, false
+	}
+	v := toolsVersion{proxy: s.mcpService.ToolsVersion()}
+	if s.toolGroupService != nil {
+		v.groups = s.toolGroupService.ToolsVersion()
+	}
+	return v, true
+}
+
+// cacheToolsList serves the tools/list requests made to a streamable HTTP MCP proxy endpoint from the cache,
+// and caches the results computed by the MCP proxy server otherwise.
+// endpoint identifies the MCP proxy server serving the request.
+// Any other request, and any request the MCP proxy server would reject, is left to the MCP proxy server.
+func (s *Server) cacheToolsList(endpoint func(c *gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodPost {
+			c.Next()
+			return
+		}
+		mediaType, _, err := mime.ParseMediaType(c.GetHeader("Content-Type"))
+		if err != nil || mediaType != "application/json" {
+			c.Next()
+			return
+		}
+		// the version must be read before the result is computed, so that a result computed while the tools
+		// change is cached under the old version
+		version, ok := s.currentToolsVersion()
+		if !ok {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method mcp.MCPMethod   `json:"method"`
+			Params struct {
+				Cursor mcp.Cursor `json:"cursor"`
+			} `json:"params"`
+		}
+		if err := json.Unmarshal(body, &req); err != nil || req.Method != mcp.MethodToolsList || len(req.ID) == 0 {
+			c.Next()
+			return
+		}
+		// the session is validated like the MCP proxy server does it
+		if terminated, err := s.mcpSessions.Validate(c.GetHeader(server.HeaderKeySessionID)); err != nil || terminated {
+			c.Next()
+			return
+		}
+
+		key := toolsListCacheKey{endpoint: endpoint(c), cursor: string(req.Params.Cursor)}
+		if result, ok := s.toolsListCache.get(key, version); ok {
+			resp, err := json.Marshal(mcp.JSONRPCResponse{JSONRPC: mcp.JSONRPC_VERSION, ID: toRequestID(req.ID), Result: result})
+			if err == nil {
+				c.Data(http.StatusOK, "application/json", resp)
+				c.Abort()
+				return
+			}
+		}
+
+		original := c.Writer
+		w := &bufferedResponseWriter{ResponseWriter: original}
+		c.Writer = w
+		c.Next()
+		c.Writer = original
+
+		if w.Status() == http.StatusOK && w.Header().Get("Content-Type") == "application/json" {
+			var resp struct {
+				Result json.RawMessage `json:"result"`
+			}
+			if err := json.Unmarshal(w.body.Bytes(), &resp); err == nil && len(resp.Result) > 0 {
+				s.toolsListCache.set(key, version, resp.Result)
+			}
+		}
+		_, _ = original.Write(w.body.Bytes())
+	}
+}
+
+// toRequestID decodes the ID of a JSON-RPC request, which is either a string or a number.
+func toRequestID(raw json.RawMessage) mcp.RequestId {
+	var id any
+	_ = json.Unmarshal(raw, &id)
+	if f, ok := id.(float64); ok && f == float64(int64(f)) {
+		return mcp.NewRequestId(int64(f))
+	}
+	return mcp.NewRequestId(id)
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestCacheToolsList(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+	srv := setup.CreateTestMcpServer("time", "", types.TransportStreamableHTTP, []byte(`{"url": "http://127.0.0.1:1/mcp"}`))
+	setup.CreateTestTool("get_time", "", srv.ID, true, []byte(`{"type":"object"}`))
+
+	// count the tools/list requests actually served by the MCP proxy server
+	var listed atomic.Int32
+	hooks := &server.Hooks{}
+	hooks.AddBeforeListTools(func(ctx context.Context, id any, req *mcpgo.ListToolsRequest) {
+		listed.Add(1)
+	})
+	proxy := server.NewMCPServer("proxy", "test", server.WithToolCapabilities(true), server.WithHooks(hooks))
+	mcpService, err := mcp.NewMCPService(setup.DB, proxy, proxy, telemetry.NewNoopCustomMetrics(), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+
+	s := &Server{
+		mcpService:     mcpService,
+		mcpSessions:    &server.InsecureStatefulSessionIdManager{},
+		toolsListCache: newToolsListCache(),
+	}
+	router := gin.New()
+	router.POST(
+		"/mcp",
+		s.cacheToolsList(func(c *gin.Context) string { return "" }),
+		gin.WrapH(server.NewStreamableHTTPServer(proxy, server.WithSessionIdManager(s.mcpSessions))),
+	)
+
+	sessionID := s.mcpSessions.Generate()
+	list := func(sessionID, id string) *httptest.ResponseRecorder {
+		body := fmt.Sprintf(`{"jsonrpc":"2.0","id":%s,"method":"tools/list"}`, id)
+		req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(server.HeaderKeySessionID, sessionID)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("served from the cache", func(t *testing.T) {
+		for _, id := range []string{"1", "2", `"third"`} {
+			w := list(sessionID, id)
+			testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+			testhelpers.AssertStringContains(t, w.Header().Get("Content-Type"), "application/json")
+			testhelpers.AssertStringContains(t, w.Body.String(), `"id":`+id+`,`)
+			testhelpers.AssertStringContains(t, w.Body.String(), `"name":"time__get_time"`)
+			testhelpers.AssertEqual(t, int32(1), listed.Load())
+		}
+	})
+
+	t.Run("invalid session", func(t *testing.T) {
+		// the request is left to the MCP proxy server, which rejects it
+		w := list("not-a-session", "4")
+		testhelpers.AssertEqual(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalidated when the tools change", func(t *testing.T) {
+		_, err := mcpService.DisableTools("time__get_time")
+		testhelpers.AssertNoError(t, err)
+
+		w := list(sessionID, "5")
+		testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+		testhelpers.AssertTrue(t, !strings.Contains(w.Body.String(), "time__get_time"), "expected the disabled tool not to be listed")
+		testhelpers.AssertEqual(t, int32(2), listed.Load())
+
+		list(sessionID, "6")
+		testhelpers.AssertEqual(t, int32(2), listed.Load())
+	})
+}
@@ -1,10 +1,12 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/user"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
 
@@ -33,18 +35,20 @@ func (s *Server) createUserHandler() gin.HandlerFunc {
 
 func (s *Server) listUsersHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		users, err := s.userService.ListUsers()
+		page, ok := pageQuery(c)
+		if !ok {
+			return
+		}
+		users, total, err := s.userService.ListUsersPage(page)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
+		setTotalCount(c, total)
 
 		resp := make([]*types.User, len(users))
 		for i, u := range users {
-			resp[i] = &types.User{
-				Username: u.Username,
-				Role:     string(u.Role),
-			}
+			resp[i] = userResponse(&u)
 		}
 
 		c.JSON(http.StatusOK, resp)
@@ -83,10 +87,120 @@ func (s *Server) whoAmIHandler() gin.HandlerFunc {
 			return
 		}
 
-		resp := types.User{
-			Username: u.Username,
-			Role:     string(u.Role),
+		c.JSON(http.StatusOK, userResponse(u))
+	}
+}
+
+// renewAccessTokenHandler lets the authenticated user replace their own access token by a new one,
+// which invalidates the previous token.
+func (s *Server) renewAccessTokenHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		v, exists := c.Get("user")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			return
 		}
-		c.JSON(http.StatusOK, resp)
+		current, ok := v.(*model.User)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get user from context"})
+			return
+		}
+
+		u, err := s.userService.RegenerateAccessToken(current.Username)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, &types.RenewAccessTokenResponse{Username: u.Username, AccessToken: u.AccessToken})
+	}
+}
+
+// suspendUserHandler suspends a user, so that their access token is rejected until they are activated again.
+func (s *Server) suspendUserHandler() gin.HandlerFunc {
+	return s.setUserActiveHandler(false)
+}
+
+// activateUserHandler lifts the suspension of a user.
+func (s *Server) activateUserHandler() gin.HandlerFunc {
+	return s.setUserActiveHandler(true)
+}
+
+func (s *Server) setUserActiveHandler(active bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			u   *model.User
+			err error
+		)
+		if active {
+			u, err = s.userService.ActivateUser(c.Param("username"))
+		} else {
+			u, err = s.userService.SuspendUser(c.Param("username"))
+		}
+		if err != nil {
+			c.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, userResponse(u))
+	}
+}
+
+// setUserToolPolicyHandler restricts the tools a user may see & invoke via the API to those allowed by
+// the policy supplied in the request body.
+func (s *Server) setUserToolPolicyHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var policy types.ToolPolicy
+		if err := c.ShouldBindJSON(&policy); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		s.setUserToolPolicy(c, &policy, http.StatusOK)
+	}
+}
+
+// deleteUserToolPolicyHandler lifts the restriction on the tools a user may see & invoke via the API.
+func (s *Server) deleteUserToolPolicyHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		s.setUserToolPolicy(c, nil, http.StatusNoContent)
+	}
+}
+
+func (s *Server) setUserToolPolicy(c *gin.Context, policy *types.ToolPolicy, status int) {
+	u, err := s.userService.SetToolPolicy(c.Param("username"), policy)
+	if err != nil {
+		c.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	if status == http.StatusNoContent {
+		c.Status(status)
+		return
+	}
+	c.JSON(status, userResponse(u))
+}
+
+// userErrorStatus returns the HTTP status code for an error returned by the user service.
+func userErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, user.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, user.ErrAdminToolPolicy), errors.Is(err, user.ErrAdminSuspension):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// userResponse converts a user into its API representation, which never includes the user's access token.
+func userResponse(u *model.User) *types.User {
+	resp := &types.User{
+		Username:  u.Username,
+		Role:      string(u.Role),
+		Suspended: !u.Active,
+	}
+	if u.HasToolPolicy() {
+		// the policy was validated when it was set, so the names can always be read
+		servers, _ := u.GetAllowedServers()
+		groups, _ := u.GetAllowedToolGroups()
+		resp.ToolPolicy = &types.ToolPolicy{Servers: servers, ToolGroups: groups}
 	}
+	return resp
 }
@@ -0,0 +1,424 @@
+// Package backup exports the registry data of MCPJungle to a portable JSON document and imports it back.
+//
+// A backup refers to entities by name rather than by their database IDs, so it can be restored
+// into any database supported by MCPJungle, eg- a backup of a SQLite database can be restored into Postgres.
+// The tool invocation history and async jobs are operational data, they are not part of a backup.
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/mcpjungle/mcpjungle/pkg/version"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// FormatVersion is the version of the backup format.
+// It must be incremented whenever a change to the format prevents older versions of mcpjungle from restoring it.
+const FormatVersion = 1
+
+// ErrRegistryNotEmpty is returned when restoring a backup into a database that already contains registry data.
+var ErrRegistryNotEmpty = errors.New("the database already contains registry data, restore requires an empty registry")
+
+// Backup contains all the registry data of MCPJungle.
+type Backup struct {
+	FormatVersion int       `json:"format_version"`
+	CreatedAt     time.Time `json:"created_at"`
+	// MCPJungleVersion is the version of mcpjungle that created the backup
+	MCPJungleVersion string `json:"mcpjungle_version"`
+
+	// ServerConfig is nil if the server was never initialized
+	ServerConfig *ServerConfig `json:"server_config,omitempty"`
+	McpServers   []McpServer   `json:"mcp_servers"`
+	ToolGroups   []ToolGroup   `json:"tool_groups"`
+	McpClients   []McpClient   `json:"mcp_clients"`
+	Users        []User        `json:"users"`
+	// Teams is nil in the backups made by the versions of mcpjungle without teams
+	Teams []Team `json:"teams"`
+}
+
+type ServerConfig struct {
+	Mode        model.ServerMode `json:"mode"`
+	Initialized bool             `json:"initialized"`
+}
+
+// McpServer is a registered MCP server along with the tools & prompts it provides.
+type McpServer struct {
+	Name        string                   `json:"name"`
+	Transport   types.McpServerTransport `json:"transport"`
+	Description string                   `json:"description,omitempty"`
+	Config      json.RawMessage          `json:"config"`
+	Tools       []Tool                   `json:"tools"`
+	Prompts     []Prompt                 `json:"prompts"`
+}
+
+type Tool struct {
+	Name         string          `json:"name"`
+	Enabled      bool            `json:"enabled"`
+	Description  string          `json:"description,omitempty"`
+	InputSchema  json.RawMessage `json:"input_schema,omitempty"`
+	OutputSchema json.RawMessage `json:"output_schema,omitempty"`
+	Annotations  json.RawMessage `json:"annotations,omitempty"`
+}
+
+type Prompt struct {
+	Name        string          `json:"name"`
+	Enabled     bool            `json:"enabled"`
+	Description string          `json:"description,omitempty"`
+	Arguments   json.RawMessage `json:"arguments,omitempty"`
+}
+
+type ToolGroup struct {
+	Name            string          `json:"name"`
+	Description     string          `json:"description,omitempty"`
+	IncludedTools   json.RawMessage `json:"included_tools,omitempty"`
+	IncludedServers json.RawMessage `json:"included_servers,omitempty"`
+	ExcludedTools   json.RawMessage `json:"excluded_tools,omitempty"`
+	ReadOnly        bool            `json:"read_only"`
+	CachedTools     json.RawMessage `json:"cached_tools,omitempty"`
+}
+
+type McpClient struct {
+	Name            string            `json:"name"`
+	Description     string            `json:"description,omitempty"`
+	AccessToken     string            `json:"access_token"`
+	AllowList       json.RawMessage   `json:"allow_list"`
+	TokenTTLSeconds int64             `json:"token_ttl_seconds,omitempty"`
+	TokenExpiresAt  *time.Time        `json:"token_expires_at,omitempty"`
+	QuotaLimit      int64             `json:"quota_limit,omitempty"`
+	QuotaPeriod     types.QuotaPeriod `json:"quota_period,omitempty"`
+	QuotaUsed       int64             `json:"quota_used,omitempty"`
+	QuotaResetsAt   *time.Time        `json:"quota_resets_at,omitempty"`
+}
+
+type User struct {
+	Username          string          `json:"username"`
+	Role              types.UserRole  `json:"role"`
+	AccessToken       string          `json:"access_token"`
+	AllowedServers    json.RawMessage `json:"allowed_servers,omitempty"`
+	AllowedToolGroups json.RawMessage `json:"allowed_tool_groups,omitempty"`
+	// Suspended is stored rather than the user's active flag, so that the users of older backups remain active
+	Suspended bool `json:"suspended,omitempty"`
+}
+
+type Team struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Members     json.RawMessage `json:"members"`
+	ToolGroups  json.RawMessage `json:"tool_groups"`
+	Servers     json.RawMessage `json:"servers"`
+}
+
+// Export reads all the registry data from the database.
+func Export(db *gorm.DB) (*Backup, error) {
+	b := &Backup{
+		FormatVersion:    FormatVersion,
+		CreatedAt:        time.Now().UTC(),
+		MCPJungleVersion: version.GetVersion(),
+		McpServers:       []McpServer{},
+		ToolGroups:       []ToolGroup{},
+		McpClients:       []McpClient{},
+		Users:            []User{},
+		Teams:            []Team{},
+	}
+
+	var config model.ServerConfig
+	err := db.First(&config).Error
+	switch {
+	case err == nil:
+		b.ServerConfig = &ServerConfig{Mode: config.Mode, Initialized: config.Initialized}
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, fmt.Errorf("failed to read server configuration: %w", err)
+	}
+
+	var servers []model.McpServer
+	if err := db.Order("id").Find(&servers).Error; err != nil {
+		return nil, fmt.Errorf("failed to read MCP servers: %w", err)
+	}
+	var tools []model.Tool
+	if err := db.Order("id").Find(&tools).Error; err != nil {
+		return nil, fmt.Errorf("failed to read tools: %w", err)
+	}
+	var prompts []model.Prompt
+	if err := db.Order("id").Find(&prompts).Error; err != nil {
+		return nil, fmt.Errorf("failed to read prompts: %w", err)
+	}
+	toolsByServer := make(map[uint][]Tool)
+	for _, t := range tools {
+		toolsByServer[t.ServerID] = append(toolsByServer[t.ServerID], Tool{
+			Name:         t.Name,
+			Enabled:      t.Enabled,
+			Description:  t.Description,
+			InputSchema:  json.RawMessage(t.InputSchema),
+			OutputSchema: json.RawMessage(t.OutputSchema),
+			Annotations:  json.RawMessage(t.Annotations),
+		})
+	}
+	promptsByServer := make(map[uint][]Prompt)
+	for _, p := range prompts {
+		promptsByServer[p.ServerID] = append(promptsByServer[p.ServerID], Prompt{
+			Name:        p.Name,
+			Enabled:     p.Enabled,
+			Description: p.Description,
+			Arguments:   json.RawMessage(p.Arguments),
+		})
+	}
+	for _, s := range servers {
+		server := McpServer{
+			Name:        s.Name,
+			Transport:   s.Transport,
+			Description: s.Description,
+			Config:      json.RawMessage(s.Config),
+			Tools:       toolsByServer[s.ID],
+			Prompts:     promptsByServer[s.ID],
+		}
+		if server.Tools == nil {
+			server.Tools = []Tool{}
+		}
+		if server.Prompts == nil {
+			server.Prompts = []Prompt{}
+		}
+		b.McpServers = append(b.McpServers, server)
+	}
+
+	var groups []model.ToolGroup
+	if err := db.Order("id").Find(&groups).Error; err != nil {
+		return nil, fmt.Errorf("failed to read tool groups: %w", err)
+	}
+	for _, g := range groups {
+		b.ToolGroups = append(b.ToolGroups, ToolGroup{
+			Name:            g.Name,
+			Description:     g.Description,
+			IncludedTools:   json.RawMessage(g.IncludedTools),
+			IncludedServers: json.RawMessage(g.IncludedServers),
+			ExcludedTools:   json.RawMessage(g.ExcludedTools),
+			ReadOnly:        g.ReadOnly,
+			CachedTools:     json.RawMessage(g.CachedTools),
+		})
+	}
+
+	var clients []model.McpClient
+	if err := db.Order("id").Find(&clients).Error; err != nil {
+		return nil, fmt.Errorf("failed to read MCP clients: %w", err)
+	}
+	for _, c := range clients {
+		b.McpClients = append(b.McpClients, McpClient{
+			Name:            c.Name,
+			Description:     c.Description,
+			AccessToken:     c.AccessToken,
+			AllowList:       json.RawMessage(c.AllowList),
+			TokenTTLSeconds: c.TokenTTLSeconds,
+			TokenExpiresAt:  c.TokenExpiresAt,
+			QuotaLimit:      c.QuotaLimit,
+			QuotaPeriod:     c.QuotaPeriod,
+			QuotaUsed:       c.QuotaUsed,
+			QuotaResetsAt:   c.QuotaResetsAt,
+		})
+	}
+
+	var users []model.User
+	if err := db.Order("id").Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("failed to read users: %w", err)
+	}
+	for _, u := range users {
+		b.Users = append(b.Users, User{
+			Username:          u.Username,
+			Role:              u.Role,
+			AccessToken:       u.AccessToken,
+			AllowedServers:    json.RawMessage(u.AllowedServers),
+			AllowedToolGroups: json.RawMessage(u.AllowedToolGroups),
+			Suspended:         !u.Active,
+		})
+	}
+
+	var teams []model.Team
+	if err := db.Order("id").Find(&teams).Error; err != nil {
+		return nil, fmt.Errorf("failed to read teams: %w", err)
+	}
+	for _, t := range teams {
+		b.Teams = append(b.Teams, Team{
+			Name:        t.Name,
+			Description: t.Description,
+			Members:     json.RawMessage(t.Members),
+			ToolGroups:  json.RawMessage(t.ToolGroups),
+			Servers:     json.RawMessage(t.Servers),
+		})
+	}
+
+	return b, nil
+}
+
+// Restore writes all the registry data of the backup into the database in a single transaction.
+// The database schema must be up-to-date and the registry must be empty, otherwise ErrRegistryNotEmpty is returned.
+// The server configuration of the backup replaces the existing one, if any.
+func Restore(db *gorm.DB, b *Backup) error {
+	if b.FormatVersion != FormatVersion {
+		return fmt.Errorf(
+			"unsupported backup format version %d, this version of mcpjungle supports version %d",
+			b.FormatVersion, FormatVersion,
+		)
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := checkEmpty(tx); err != nil {
+			return err
+		}
+
+		if b.ServerConfig != nil {
+			if err := tx.Unscoped().Where("1 = 1").Delete(&model.ServerConfig{}).Error; err != nil {
+				return fmt.Errorf("failed to replace server configuration: %w", err)
+			}
+			config := &model.ServerConfig{Mode: b.ServerConfig.Mode, Initialized: b.ServerConfig.Initialized}
+			if err := tx.Create(config).Error; err != nil {
+				return fmt.Errorf("failed to restore server configuration: %w", err)
+			}
+		}
+
+		for _, s := range b.McpServers {
+			server := &model.McpServer{
+				Name:        s.Name,
+				Transport:   s.Transport,
+				Description: s.Description,
+				Config:      toJSON(s.Config),
+			}
+			if err := tx.Create(server).Error; err != nil {
+				return fmt.Errorf("failed to restore MCP server %s: %w", s.Name, err)
+			}
+			for _, t := range s.Tools {
+				tool := &model.Tool{
+					Name:         t.Name,
+					Enabled:      t.Enabled,
+					Description:  t.Description,
+					InputSchema:  toJSON(t.InputSchema),
+					OutputSchema: toJSON(t.OutputSchema),
+					Annotations:  toJSON(t.Annotations),
+					ServerID:     server.ID,
+				}
+				if err := createWithEnabled(tx, tool, t.Enabled); err != nil {
+					return fmt.Errorf("failed to restore tool %s of MCP server %s: %w", t.Name, s.Name, err)
+				}
+			}
+			for _, p := range s.Prompts {
+				prompt := &model.Prompt{
+					Name:        p.Name,
+					Enabled:     p.Enabled,
+					Description: p.Description,
+					Arguments:   toJSON(p.Arguments),
+					ServerID:    server.ID,
+				}
+				if err := createWithEnabled(tx, prompt, p.Enabled); err != nil {
+					return fmt.Errorf("failed to restore prompt %s of MCP server %s: %w", p.Name, s.Name, err)
+				}
+			}
+		}
+
+		for _, g := range b.ToolGroups {
+			group := &model.ToolGroup{
+				Name:            g.Name,
+				Description:     g.Description,
+				IncludedTools:   toJSON(g.IncludedTools),
+				IncludedServers: toJSON(g.IncludedServers),
+				ExcludedTools:   toJSON(g.ExcludedTools),
+				ReadOnly:        g.ReadOnly,
+				CachedTools:     toJSON(g.CachedTools),
+			}
+			if err := tx.Create(group).Error; err != nil {
+				return fmt.Errorf("failed to restore tool group %s: %w", g.Name, err)
+			}
+		}
+
+		for _, c := range b.McpClients {
+			client := &model.McpClient{
+				Name:            c.Name,
+				Description:     c.Description,
+				AccessToken:     c.AccessToken,
+				AllowList:       toJSON(c.AllowList),
+				TokenTTLSeconds: c.TokenTTLSeconds,
+				TokenExpiresAt:  c.TokenExpiresAt,
+				QuotaLimit:      c.QuotaLimit,
+				QuotaPeriod:     c.QuotaPeriod,
+				QuotaUsed:       c.QuotaUsed,
+				QuotaResetsAt:   c.QuotaResetsAt,
+			}
+			if client.AllowList == nil {
+				client.AllowList = datatypes.JSON("[]")
+			}
+			if err := tx.Create(client).Error; err != nil {
+				return fmt.Errorf("failed to restore MCP client %s: %w", c.Name, err)
+			}
+		}
+
+		for _, u := range b.Users {
+			user := &model.User{
+				Username:          u.Username,
+				Role:              u.Role,
+				AccessToken:       u.AccessToken,
+				AllowedServers:    toJSON(u.AllowedServers),
+				AllowedToolGroups: toJSON(u.AllowedToolGroups),
+			}
+			if err := tx.Create(user).Error; err != nil {
+				return fmt.Errorf("failed to restore user %s: %w", u.Username, err)
+			}
+			// users are always created active because of the column's default
+			if u.Suspended {
+				if err := tx.Model(user).Update("active", false).Error; err != nil {
+					return fmt.Errorf("failed to restore user %s: %w", u.Username, err)
+				}
+			}
+		}
+
+		for _, t := range b.Teams {
+			team := &model.Team{
+				Name:        t.Name,
+				Description: t.Description,
+				Members:     toJSON(t.Members),
+				ToolGroups:  toJSON(t.ToolGroups),
+				Servers:     toJSON(t.Servers),
+			}
+			if err := tx.Create(team).Error; err != nil {
+				return fmt.Errorf("failed to restore team %s: %w", t.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// checkEmpty returns ErrRegistryNotEmpty if the database contains any MCP servers, tool groups, clients, users or teams.
+// Restoring on top of existing data would mix two registries, so it is never done.
+func checkEmpty(tx *gorm.DB) error {
+	for _, m := range []any{&model.McpServer{}, &model.ToolGroup{}, &model.McpClient{}, &model.User{}, &model.Team{}} {
+		var count int64
+		if err := tx.Model(m).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to check whether the registry is empty: %w", err)
+		}
+		if count > 0 {
+			return ErrRegistryNotEmpty
+		}
+	}
+	return nil
+}
+
+// createWithEnabled creates a tool or prompt.
+// Enabled defaults to true in the database, so gorm omits a false value on create and it must be updated afterwards.
+func createWithEnabled(tx *gorm.DB, entity any, enabled bool) error {
+	if err := tx.Create(entity).Error; err != nil {
+		return err
+	}
+	if enabled {
+		return nil
+	}
+	return tx.Model(entity).Update("enabled", false).Error
+}
+
+// toJSON converts a JSON value of the backup to a database column value, a missing or null value becomes NULL.
+func toJSON(raw json.RawMessage) datatypes.JSON {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	return datatypes.JSON(raw)
+}
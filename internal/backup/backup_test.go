@@ -0,0 +1,146 @@
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/migrations"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := testhelpers.CreateTestDB()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, migrations.Migrate(db))
+	return db
+}
+
+func populate(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	testhelpers.AssertNoError(t, db.Create(&model.ServerConfig{Mode: model.ModeEnterprise, Initialized: true}).Error)
+
+	server, err := model.NewStreamableHTTPServer("github", "GitHub tools", "https://example.com/mcp", "secret")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, db.Create(server).Error)
+	testhelpers.AssertNoError(t, db.Create(&model.Tool{
+		Name: "create_issue", ServerID: server.ID, InputSchema: datatypes.JSON(`{"type":"object"}`),
+	}).Error)
+	disabled := &model.Tool{Name: "delete_repo", ServerID: server.ID}
+	testhelpers.AssertNoError(t, db.Create(disabled).Error)
+	testhelpers.AssertNoError(t, db.Model(disabled).Update("enabled", false).Error)
+	testhelpers.AssertNoError(t, db.Create(&model.Prompt{
+		Name: "review", ServerID: server.ID, Arguments: datatypes.JSON(`[{"name":"pr"}]`),
+	}).Error)
+
+	empty, err := model.NewStdioServer("time", "", "uvx", []string{"mcp-server-time"}, nil)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, db.Create(empty).Error)
+
+	testhelpers.AssertNoError(t, db.Create(&model.ToolGroup{
+		Name: "triage", IncludedTools: datatypes.JSON(`["github__create_issue"]`), ReadOnly: true,
+	}).Error)
+	testhelpers.AssertNoError(t, db.Create(&model.McpClient{
+		Name: "cursor", AccessToken: "client-token", AllowList: datatypes.JSON(`["github"]`),
+	}).Error)
+	expiresAt := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	testhelpers.AssertNoError(t, db.Create(&model.McpClient{
+		Name: "agent", AccessToken: "agent-token", AllowList: datatypes.JSON(`[]`),
+		TokenTTLSeconds: 3600, TokenExpiresAt: &expiresAt,
+		QuotaLimit: 1000, QuotaPeriod: types.QuotaPeriodMonthly, QuotaUsed: 42, QuotaResetsAt: &expiresAt,
+	}).Error)
+	testhelpers.AssertNoError(t, db.Create(&model.User{
+		Username: "admin", Role: types.UserRoleAdmin, AccessToken: "admin-token",
+	}).Error)
+	testhelpers.AssertNoError(t, db.Create(&model.User{
+		Username: "alice", Role: types.UserRoleUser, AccessToken: "alice-token",
+		AllowedServers: datatypes.JSON(`["github"]`), AllowedToolGroups: datatypes.JSON(`[]`),
+	}).Error)
+	testhelpers.AssertNoError(t, db.Create(&model.User{
+		Username: "bob", Role: types.UserRoleUser, AccessToken: "bob-token",
+	}).Update("active", false).Error)
+	testhelpers.AssertNoError(t, db.Create(&model.Team{
+		Name:       "platform",
+		Members:    datatypes.JSON(`["alice"]`),
+		ToolGroups: datatypes.JSON(`["triage"]`),
+		Servers:    datatypes.JSON(`[]`),
+	}).Error)
+}
+
+func TestExportAndRestore(t *testing.T) {
+	src := newTestDB(t)
+	populate(t, src)
+
+	b, err := Export(src)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, FormatVersion, b.FormatVersion)
+	testhelpers.AssertEqual(t, model.ModeEnterprise, b.ServerConfig.Mode)
+	testhelpers.AssertEqual(t, 2, len(b.McpServers))
+	testhelpers.AssertEqual(t, 2, len(b.McpServers[0].Tools))
+	testhelpers.AssertFalse(t, b.McpServers[0].Tools[1].Enabled, "tool should be disabled")
+	testhelpers.AssertEqual(t, 1, len(b.McpServers[0].Prompts))
+	testhelpers.AssertEqual(t, 0, len(b.McpServers[1].Tools))
+	testhelpers.AssertEqual(t, 1, len(b.Teams))
+	testhelpers.AssertTrue(t, b.Users[2].Suspended, "user should be suspended")
+	testhelpers.AssertEqual(t, int64(3600), b.McpClients[1].TokenTTLSeconds)
+	testhelpers.AssertNotNil(t, b.McpClients[1].TokenExpiresAt)
+	testhelpers.AssertEqual(t, int64(42), b.McpClients[1].QuotaUsed)
+
+	// the backup goes through its JSON encoding, as it would when written to a file
+	data, err := json.Marshal(b)
+	testhelpers.AssertNoError(t, err)
+	var decoded Backup
+	testhelpers.AssertNoError(t, json.Unmarshal(data, &decoded))
+
+	dst := newTestDB(t)
+	testhelpers.AssertNoError(t, Restore(dst, &decoded))
+
+	restored, err := Export(dst)
+	testhelpers.AssertNoError(t, err)
+	restored.CreatedAt = b.CreatedAt
+	restoredData, err := json.Marshal(restored)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, string(data), string(restoredData))
+
+	// the restored data is usable through the models
+	var server model.McpServer
+	testhelpers.AssertNoError(t, dst.Where("name = ?", "github").First(&server).Error)
+	conf, err := server.GetStreamableHTTPConfig()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, "secret", conf.BearerToken)
+}
+
+func TestRestoreRequiresEmptyRegistry(t *testing.T) {
+	src := newTestDB(t)
+	populate(t, src)
+	b, err := Export(src)
+	testhelpers.AssertNoError(t, err)
+
+	err = Restore(src, b)
+	testhelpers.AssertTrue(t, errors.Is(err, ErrRegistryNotEmpty), "expected ErrRegistryNotEmpty")
+}
+
+func TestRestoreReplacesServerConfig(t *testing.T) {
+	dst := newTestDB(t)
+	testhelpers.AssertNoError(t, dst.Create(&model.ServerConfig{Mode: model.ModeDev, Initialized: true}).Error)
+
+	b := &Backup{FormatVersion: FormatVersion, ServerConfig: &ServerConfig{Mode: model.ModeEnterprise, Initialized: true}}
+	testhelpers.AssertNoError(t, Restore(dst, b))
+
+	var configs []model.ServerConfig
+	testhelpers.AssertNoError(t, dst.Find(&configs).Error)
+	testhelpers.AssertEqual(t, 1, len(configs))
+	testhelpers.AssertEqual(t, model.ModeEnterprise, configs[0].Mode)
+}
+
+func TestRestoreUnsupportedFormatVersion(t *testing.T) {
+	err := Restore(newTestDB(t), &Backup{FormatVersion: FormatVersion + 1})
+	testhelpers.AssertError(t, err)
+	testhelpers.AssertStringContains(t, err.Error(), "unsupported backup format version")
+}
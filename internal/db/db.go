@@ -3,13 +3,14 @@ package db
 
 import (
 	"fmt"
-	"log"
 	"os"
+	"path/filepath"
 
 	"github.com/glebarez/sqlite"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+	gormlogger "gorm.io/gorm/logger"
 )
 
 // TODO: Turn this into a singleton class.
@@ -22,7 +23,7 @@ const (
 
 // getSQLiteDBPath determines which SQLite database file to use.
 // It prioritizes the new mcpjungle.db file, but falls back to the old mcp.db file for backward compatibility.
-func getSQLiteDBPath() string {
+func getSQLiteDBPath(l logger.Logger) string {
 	// Check if the new database file exists
 	if _, err := os.Stat(dbFilename); err == nil {
 		return dbFilename
@@ -30,7 +31,10 @@ func getSQLiteDBPath() string {
 
 	// Check if the old database file exists (backward compatibility)
 	if _, err := os.Stat(deprecatedDBFilename); err == nil {
-		log.Printf("[db] WARNING: Using deprecated database file '%s'. Please consider renaming it to '%s' for future compatibility.", deprecatedDBFilename, dbFilename)
+		l.Warn(
+			"using deprecated database file, please consider renaming it for future compatibility",
+			logger.String("file", deprecatedDBFilename), logger.String("new_file", dbFilename),
+		)
 		return deprecatedDBFilename
 	}
 
@@ -39,21 +43,52 @@ func getSQLiteDBPath() string {
 }
 
 // NewDBConnection creates a new database connection based on the provided DSN.
-// If the DSN is empty, it falls back to an embedded SQLite database.
+// If the DSN is empty, it falls back to an embedded SQLite database in the working directory.
 // For backward compatibility, it will use an existing "mcp.db" file if present,
 // otherwise it creates/uses "mcpjungle.db".
-func NewDBConnection(dsn string) (*gorm.DB, error) {
-	var dialector gorm.Dialector
+func NewDBConnection(dsn string, l logger.Logger) (*gorm.DB, error) {
 	if dsn == "" {
-		dbPath := getSQLiteDBPath()
-		log.Printf("[db] DATABASE_URL not set – falling back to embedded SQLite ./%s", dbPath)
-		dialector = sqlite.Open(fmt.Sprintf("%s?_busy_timeout=5000&_journal_mode=WAL", dbPath))
-	} else {
-		dialector = postgres.Open(dsn)
+		dbPath := getSQLiteDBPath(l)
+		l.Info("DATABASE_URL not set, falling back to embedded SQLite", logger.String("file", "./"+dbPath))
+		return open(newSQLiteDialector(dbPath))
 	}
+	return open(postgres.Open(dsn))
+}
+
+// NewSQLiteDBConnection creates a connection to the embedded SQLite database stored in the file at the given path.
+// The file and its parent directories are created if they don't exist.
+func NewSQLiteDBConnection(path string, l logger.Logger) (*gorm.DB, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create the directory of the SQLite database %s: %w", path, err)
+	}
+	l.Info("using embedded SQLite", logger.String("file", path))
+	return open(newSQLiteDialector(path))
+}
+
+// NewInMemorySQLiteDBConnection creates a connection to an embedded SQLite database that is only kept in memory.
+// No file is created and all data is lost when the connection is closed.
+//
+// Every connection to an in-memory SQLite database opens a separate database,
+// so the pool is limited to a single connection that is never recycled. It must not be reconfigured.
+func NewInMemorySQLiteDBConnection(l logger.Logger) (*gorm.DB, error) {
+	conn, err := open(sqlite.Open(":memory:"))
+	if err != nil {
+		return nil, err
+	}
+	if err := ConfigurePool(conn, PoolConfig{MaxOpenConns: 1, MaxIdleConns: 1}); err != nil {
+		return nil, err
+	}
+	l.Info("using ephemeral in-memory SQLite, all data will be lost when the server stops")
+	return conn, nil
+}
+
+func newSQLiteDialector(path string) gorm.Dialector {
+	return sqlite.Open(fmt.Sprintf("%s?_busy_timeout=5000&_journal_mode=WAL", path))
+}
 
+func open(dialector gorm.Dialector) (*gorm.DB, error) {
 	c := &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Silent),
+		Logger: gormlogger.Default.LogMode(gormlogger.Silent),
 	}
 	db, err := gorm.Open(dialector, c)
 	if err != nil {
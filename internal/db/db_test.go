@@ -3,8 +3,10 @@ package db
 import (
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
 )
 
@@ -72,7 +74,7 @@ func TestNewDBConnection(t *testing.T) {
 			// Cleanup before test
 			tt.cleanup()
 
-			db, err := NewDBConnection(tt.dsn)
+			db, err := NewDBConnection(tt.dsn, logger.NewNop())
 
 			if tt.expectError {
 				testhelpers.AssertError(t, err)
@@ -113,7 +115,7 @@ func TestNewDBConnection_SQLiteFallback(t *testing.T) {
 	defer cleanup()
 
 	// Test with empty DSN
-	db, err := NewDBConnection("")
+	db, err := NewDBConnection("", logger.NewNop())
 	testhelpers.AssertNoError(t, err)
 	testhelpers.AssertNotNil(t, db)
 
@@ -148,7 +150,7 @@ func TestNewDBConnection_DatabaseConfiguration(t *testing.T) {
 	cleanup()
 	defer cleanup()
 
-	db, err := NewDBConnection("")
+	db, err := NewDBConnection("", logger.NewNop())
 	testhelpers.AssertNoError(t, err)
 	testhelpers.AssertNotNil(t, db)
 
@@ -180,11 +182,11 @@ func TestNewDBConnection_ConcurrentAccess(t *testing.T) {
 	defer cleanup()
 
 	// Test creating multiple connections to the same SQLite database
-	db1, err := NewDBConnection("")
+	db1, err := NewDBConnection("", logger.NewNop())
 	testhelpers.AssertNoError(t, err)
 	testhelpers.AssertNotNil(t, db1)
 
-	db2, err := NewDBConnection("")
+	db2, err := NewDBConnection("", logger.NewNop())
 	testhelpers.AssertNoError(t, err)
 	testhelpers.AssertNotNil(t, db2)
 
@@ -225,7 +227,7 @@ func TestNewDBConnection_WithCustomPath(t *testing.T) {
 	}()
 
 	// Test SQLite creation in temp directory
-	db, err := NewDBConnection("")
+	db, err := NewDBConnection("", logger.NewNop())
 	testhelpers.AssertNoError(t, err)
 	testhelpers.AssertNotNil(t, db)
 
@@ -244,6 +246,65 @@ func TestNewDBConnection_WithCustomPath(t *testing.T) {
 	testhelpers.AssertNoError(t, err)
 }
 
+func TestNewSQLiteDBConnection(t *testing.T) {
+	// the parent directories of the file are created
+	dbPath := filepath.Join(t.TempDir(), "var", "lib", "mcpjungle", "db.sqlite")
+
+	db, err := NewSQLiteDBConnection(dbPath, logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNotNil(t, db)
+
+	_, err = os.Stat(dbPath)
+	testhelpers.AssertNoError(t, err)
+
+	sqlDB, err := db.DB()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, sqlDB.Ping())
+	testhelpers.AssertNoError(t, sqlDB.Close())
+
+	// a file cannot be used as a directory
+	_, err = NewSQLiteDBConnection(filepath.Join(dbPath, "db.sqlite"), logger.NewNop())
+	testhelpers.AssertError(t, err)
+}
+
+func TestNewInMemorySQLiteDBConnection(t *testing.T) {
+	dir := t.TempDir()
+	originalDir, err := os.Getwd()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, os.Chdir(dir))
+	defer func() {
+		testhelpers.AssertNoError(t, os.Chdir(originalDir))
+	}()
+
+	db, err := NewInMemorySQLiteDBConnection(logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+
+	// concurrent queries must all see the same database
+	type row struct{ ID int }
+	testhelpers.AssertNoError(t, db.AutoMigrate(&row{}))
+	var wg sync.WaitGroup
+	for i := 1; i <= 10; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			testhelpers.AssertNoError(t, db.Create(&row{ID: id}).Error)
+		}(i)
+	}
+	wg.Wait()
+	var count int64
+	testhelpers.AssertNoError(t, db.Model(&row{}).Count(&count).Error)
+	testhelpers.AssertEqual(t, int64(10), count)
+
+	// no file is created
+	entries, err := os.ReadDir(dir)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 0, len(entries))
+
+	sqlDB, err := db.DB()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, sqlDB.Close())
+}
+
 func TestNewDBConnection_ErrorHandling(t *testing.T) {
 	tests := []struct {
 		name string
@@ -273,7 +334,7 @@ func TestNewDBConnection_ErrorHandling(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			db, err := NewDBConnection(tt.dsn)
+			db, err := NewDBConnection(tt.dsn, logger.NewNop())
 			testhelpers.AssertError(t, err)
 			if db != nil {
 				t.Errorf("Expected db to be nil, got %v", db)
@@ -293,7 +354,7 @@ func BenchmarkNewDBConnection_SQLite(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		db, err := NewDBConnection("")
+		db, err := NewDBConnection("", logger.NewNop())
 		if err != nil {
 			b.Fatal(err)
 		}
@@ -367,11 +428,11 @@ func TestSQLiteDBPath_BackwardCompatibility(t *testing.T) {
 			tt.setup()
 
 			// Test the path selection logic
-			result := getSQLiteDBPath()
+			result := getSQLiteDBPath(logger.NewNop())
 			testhelpers.AssertEqual(t, tt.expectedDBFile, result)
 
 			// Test that the database connection actually works
-			db, err := NewDBConnection("")
+			db, err := NewDBConnection("", logger.NewNop())
 			testhelpers.AssertNoError(t, err)
 			testhelpers.AssertNotNil(t, db)
 
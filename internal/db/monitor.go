@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// MonitorConfig configures how the connection to the database is monitored.
+type MonitorConfig struct {
+	// Interval is the time between two checks while the database is available.
+	Interval time.Duration
+	// Timeout is the time allowed for the database to respond to a check.
+	Timeout time.Duration
+	// MinBackoff & MaxBackoff bound the time between two attempts to reconnect while the database is unavailable.
+	// The time doubles after every failed attempt.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// DefaultMonitorConfig returns the default configuration of the database monitor.
+func DefaultMonitorConfig() MonitorConfig {
+	return MonitorConfig{
+		Interval:   10 * time.Second,
+		Timeout:    2 * time.Second,
+		MinBackoff: time.Second,
+		MaxBackoff: 30 * time.Second,
+	}
+}
+
+// Monitor periodically checks that the database is reachable, so that the server can detect a lost connection
+// and degrade gracefully instead of failing every request.
+//
+// database/sql discards broken connections and opens new ones on demand, so reconnecting only requires
+// the database to be reachable again. While it is not, the monitor retries with an exponential backoff.
+type Monitor struct {
+	conn   *gorm.DB
+	config MonitorConfig
+	logger logger.Logger
+
+	available atomic.Bool
+	// downSince is the time at which the database became unavailable, as unix nanoseconds
+	downSince atomic.Int64
+}
+
+// NewMonitor creates a monitor of the database connection.
+// The database is assumed to be available until a check fails.
+func NewMonitor(conn *gorm.DB, c MonitorConfig, l logger.Logger) *Monitor {
+	m := &Monitor{conn: conn, config: c, logger: l}
+	m.available.Store(true)
+	return m
+}
+
+// Available returns false if the latest check of the database failed.
+func (m *Monitor) Available() bool {
+	return m.available.Load()
+}
+
+// DownSince returns the time at which the database became unavailable, or the zero time if it is available.
+func (m *Monitor) DownSince() time.Time {
+	if m.Available() {
+		return time.Time{}
+	}
+	return time.Unix(0, m.downSince.Load())
+}
+
+// Run checks the database until the context is done.
+func (m *Monitor) Run(ctx context.Context) {
+	backoff := m.config.MinBackoff
+	for {
+		wait := m.config.Interval
+		if m.Check(ctx) {
+			backoff = m.config.MinBackoff
+		} else {
+			wait = backoff
+			backoff = min(2*backoff, m.config.MaxBackoff)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// Check pings the database, updates its availability and returns it.
+func (m *Monitor) Check(ctx context.Context) bool {
+	err := m.ping(ctx)
+	if err == nil {
+		if !m.available.Swap(true) {
+			m.logger.Info(
+				"reconnected to the database, leaving degraded mode",
+				logger.String("downtime", time.Since(time.Unix(0, m.downSince.Load())).Round(time.Second).String()),
+			)
+		}
+		return true
+	}
+	if m.available.Load() {
+		m.downSince.Store(time.Now().UnixNano())
+		m.available.Store(false)
+		m.logger.Error(
+			"lost the connection to the database, serving the MCP proxy in degraded mode until it is back",
+			logger.ErrorField(err),
+		)
+	} else {
+		m.logger.Warn("the database is still unavailable", logger.ErrorField(err))
+	}
+	return false
+}
+
+func (m *Monitor) ping(ctx context.Context) error {
+	sqlDB, err := m.conn.DB()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, m.config.Timeout)
+	defer cancel()
+	return sqlDB.PingContext(ctx)
+}
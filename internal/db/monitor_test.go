@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestMonitor(t *testing.T) {
+	conn, err := NewSQLiteDBConnection(filepath.Join(t.TempDir(), "mcpjungle.db"), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+
+	m := NewMonitor(conn, DefaultMonitorConfig(), logger.NewNop())
+	testhelpers.AssertTrue(t, m.Available(), "database should be assumed available")
+	testhelpers.AssertTrue(t, m.DownSince().IsZero(), "database should not be down")
+	testhelpers.AssertTrue(t, m.Check(context.Background()), "database should be reachable")
+
+	sqlDB, err := conn.DB()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, sqlDB.Close())
+
+	before := time.Now()
+	testhelpers.AssertFalse(t, m.Check(context.Background()), "closed database should be unreachable")
+	testhelpers.AssertFalse(t, m.Available(), "database should be unavailable")
+	testhelpers.AssertFalse(t, m.DownSince().Before(before), "database should be down since the failed check")
+
+	// a failed check while already down keeps the time at which the database went down
+	downSince := m.DownSince()
+	m.Check(context.Background())
+	testhelpers.AssertEqual(t, downSince, m.DownSince())
+}
+
+func TestMonitorRun(t *testing.T) {
+	conn, err := NewSQLiteDBConnection(filepath.Join(t.TempDir(), "mcpjungle.db"), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+	sqlDB, err := conn.DB()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, sqlDB.Close())
+
+	m := NewMonitor(conn, DefaultMonitorConfig(), logger.NewNop())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// the database is checked right away, then Run returns since the context is done
+	m.Run(ctx)
+	testhelpers.AssertFalse(t, m.Available(), "database should be unavailable")
+}
@@ -0,0 +1,27 @@
+package db
+
+import (
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/gorm"
+)
+
+// FindPage finds the given page of the records matched by query into dest.
+// It also returns the total number of records matched by query, so that callers can report it alongside the page.
+func FindPage[T any](query *gorm.DB, page types.Page, dest *[]T) (int64, error) {
+	query = query.Model(dest)
+
+	var total int64
+	// the count runs in its own session, so that it doesn't add its clauses to the query of the page
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return 0, err
+	}
+
+	query = query.Offset(page.Offset)
+	if page.Limit > 0 {
+		query = query.Limit(page.Limit)
+	}
+	if err := query.Find(dest).Error; err != nil {
+		return 0, err
+	}
+	return total, nil
+}
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestFindPage(t *testing.T) {
+	type item struct {
+		ID   uint
+		Even bool
+	}
+	db, err := NewInMemorySQLiteDBConnection(logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, db.AutoMigrate(&item{}))
+	for i := 1; i <= 5; i++ {
+		testhelpers.AssertNoError(t, db.Create(&item{ID: uint(i), Even: i%2 == 0}).Error)
+	}
+
+	ids := func(items []item) []uint {
+		out := make([]uint, len(items))
+		for i, it := range items {
+			out[i] = it.ID
+		}
+		return out
+	}
+
+	tests := []struct {
+		name     string
+		page     types.Page
+		expected []uint
+	}{
+		{"all items", types.Page{}, []uint{5, 4, 3, 2, 1}},
+		{"limit", types.Page{Limit: 2}, []uint{5, 4}},
+		{"offset", types.Page{Offset: 3}, []uint{2, 1}},
+		{"limit and offset", types.Page{Offset: 2, Limit: 2}, []uint{3, 2}},
+		{"offset beyond total", types.Page{Offset: 10}, []uint{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var items []item
+			total, err := FindPage(db.Order("id desc"), tt.page, &items)
+			testhelpers.AssertNoError(t, err)
+			testhelpers.AssertEqual(t, int64(5), total)
+			testhelpers.AssertTrue(t, slices.Equal(tt.expected, ids(items)), "unexpected page of items")
+		})
+	}
+
+	// the total only counts the records matched by the query
+	var items []item
+	total, err := FindPage(db.Where("even = ?", true).Order("id"), types.Page{Limit: 1}, &items)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, int64(2), total)
+	testhelpers.AssertTrue(t, slices.Equal([]uint{2}, ids(items)), "unexpected page of filtered items")
+}
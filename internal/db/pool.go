@@ -0,0 +1,52 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PoolConfig configures the pool of connections to the database.
+// Zero values mean no limit, like in database/sql.
+type PoolConfig struct {
+	// MaxOpenConns is the maximum number of open connections to the database.
+	MaxOpenConns int
+	// MaxIdleConns is the maximum number of connections kept open while idle.
+	MaxIdleConns int
+	// ConnMaxLifetime is the maximum amount of time a connection may be reused.
+	ConnMaxLifetime time.Duration
+	// ConnMaxIdleTime is the maximum amount of time a connection may be idle before being closed.
+	ConnMaxIdleTime time.Duration
+}
+
+// DefaultPoolConfig returns the default pool configuration for the given driver (the name of the gorm dialector).
+//
+// Postgres connections are capped so that a busy proxy doesn't exhaust the connections allowed by the server
+// (100 by default), and are recycled so that the pool recovers from failovers & restarts of the database.
+// SQLite is an embedded database, so the database/sql defaults are kept.
+func DefaultPoolConfig(driver string) PoolConfig {
+	if driver == "postgres" {
+		return PoolConfig{
+			MaxOpenConns:    25,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+		}
+	}
+	// database/sql keeps 2 idle connections by default
+	return PoolConfig{MaxIdleConns: 2}
+}
+
+// ConfigurePool applies the pool configuration to the connection.
+func ConfigurePool(conn *gorm.DB, c PoolConfig) error {
+	sqlDB, err := conn.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get the underlying database connection: %w", err)
+	}
+	sqlDB.SetMaxOpenConns(c.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(c.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(c.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(c.ConnMaxIdleTime)
+	return nil
+}
@@ -0,0 +1,34 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestDefaultPoolConfig(t *testing.T) {
+	pg := DefaultPoolConfig("postgres")
+	testhelpers.AssertEqual(t, 25, pg.MaxOpenConns)
+	testhelpers.AssertEqual(t, 10, pg.MaxIdleConns)
+	testhelpers.AssertEqual(t, 30*time.Minute, pg.ConnMaxLifetime)
+
+	sqlite := DefaultPoolConfig("sqlite")
+	testhelpers.AssertEqual(t, 0, sqlite.MaxOpenConns)
+	testhelpers.AssertEqual(t, 2, sqlite.MaxIdleConns)
+}
+
+func TestConfigurePool(t *testing.T) {
+	conn, err := NewSQLiteDBConnection(filepath.Join(t.TempDir(), "mcpjungle.db"), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+
+	err = ConfigurePool(conn, PoolConfig{MaxOpenConns: 3, MaxIdleConns: 1, ConnMaxLifetime: time.Minute})
+	testhelpers.AssertNoError(t, err)
+
+	sqlDB, err := conn.DB()
+	testhelpers.AssertNoError(t, err)
+	defer sqlDB.Close()
+	testhelpers.AssertEqual(t, 3, sqlDB.Stats().MaxOpenConnections)
+}
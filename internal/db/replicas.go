@@ -0,0 +1,38 @@
+package db
+
+import (
+	"fmt"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// UseReadReplicas routes the queries reading the tables of the given models to the Postgres read replicas
+// at the given DSNs, picking a replica at random for every query.
+// Writes, transactions, the other tables and queries using the dbresolver.Write clause keep using the primary.
+// Every replica gets its own pool of connections, configured like the primary's.
+//
+// Replicas lag behind the primary, so data written to the primary may not be visible in them for a short while.
+func UseReadReplicas(conn *gorm.DB, dsns []string, c PoolConfig, models ...any) error {
+	if conn.Name() != "postgres" {
+		return fmt.Errorf("read replicas are only supported with Postgres, not %s", conn.Name())
+	}
+	replicas := make([]gorm.Dialector, len(dsns))
+	for i, dsn := range dsns {
+		replicas[i] = postgres.Open(dsn)
+	}
+	return useReplicas(conn, replicas, c, models...)
+}
+
+func useReplicas(conn *gorm.DB, replicas []gorm.Dialector, c PoolConfig, models ...any) error {
+	resolver := dbresolver.Register(dbresolver.Config{Replicas: replicas, Policy: dbresolver.RandomPolicy{}}, models...).
+		SetMaxOpenConns(c.MaxOpenConns).
+		SetMaxIdleConns(c.MaxIdleConns).
+		SetConnMaxLifetime(c.ConnMaxLifetime).
+		SetConnMaxIdleTime(c.ConnMaxIdleTime)
+	if err := conn.Use(resolver); err != nil {
+		return fmt.Errorf("failed to connect to the read replicas: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+type replicatedEntity struct {
+	ID   uint
+	Name string
+}
+
+type primaryOnlyEntity struct {
+	ID   uint
+	Name string
+}
+
+func TestUseReadReplicas(t *testing.T) {
+	dir := t.TempDir()
+	primary, err := NewSQLiteDBConnection(filepath.Join(dir, "primary.db"), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+	replicaPath := filepath.Join(dir, "replica.db")
+	replica, err := NewSQLiteDBConnection(replicaPath, logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+	for _, conn := range []*gorm.DB{primary, replica} {
+		testhelpers.AssertNoError(t, conn.AutoMigrate(&replicatedEntity{}, &primaryOnlyEntity{}))
+	}
+	// the replica lags behind the primary
+	testhelpers.AssertNoError(t, replica.Create(&replicatedEntity{Name: "replicated"}).Error)
+	testhelpers.AssertNoError(t, replica.Create(&primaryOnlyEntity{Name: "replicated"}).Error)
+
+	err = useReplicas(primary, []gorm.Dialector{newSQLiteDialector(replicaPath)}, PoolConfig{}, &replicatedEntity{})
+	testhelpers.AssertNoError(t, err)
+
+	// writes go to the primary
+	testhelpers.AssertNoError(t, primary.Create(&replicatedEntity{Name: "written"}).Error)
+	testhelpers.AssertNoError(t, primary.Create(&primaryOnlyEntity{Name: "written"}).Error)
+
+	var e replicatedEntity
+	testhelpers.AssertNoError(t, primary.First(&e).Error)
+	testhelpers.AssertEqual(t, "replicated", e.Name)
+
+	testhelpers.AssertNoError(t, primary.Clauses(dbresolver.Write).First(&e).Error)
+	testhelpers.AssertEqual(t, "written", e.Name)
+
+	var p primaryOnlyEntity
+	testhelpers.AssertNoError(t, primary.First(&p).Error)
+	testhelpers.AssertEqual(t, "written", p.Name)
+}
+
+func TestUseReadReplicasRequiresPostgres(t *testing.T) {
+	conn, err := NewSQLiteDBConnection(filepath.Join(t.TempDir(), "mcpjungle.db"), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+	err = UseReadReplicas(conn, []string{"postgres://localhost/replica"}, PoolConfig{})
+	testhelpers.AssertError(t, err)
+}
@@ -0,0 +1,177 @@
+// Package encryption encrypts the credentials that MCPJungle stores in its database,
+// eg- the access tokens of users & MCP clients and the configuration of MCP servers, which holds their bearer tokens.
+//
+// Columns are encrypted transparently by tagging their model fields with the "encrypted" gorm serializer.
+// Encryption is deterministic: under a given key, a value always encrypts to the same ciphertext.
+// This lets encrypted columns keep their unique indexes and be looked up by value (see LookupValues).
+//
+// When no keyring is set, values are stored in plain text.
+// Plain text values are always readable, so encryption can be enabled on an existing database
+// and its values encrypted afterward using Rekey.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// KeySize is the size of an encryption key in bytes (AES-256)
+const KeySize = 32
+
+// prefix marks encrypted values. It is followed by the ID of the key and the base64-encoded ciphertext.
+const prefix = "enc:v1:"
+
+// ErrNoKeyring is returned when reading an encrypted value while no keyring is set
+var ErrNoKeyring = errors.New("the value is encrypted but no encryption key is configured")
+
+// active is the keyring used by the serializer, nil if encryption is disabled
+var active atomic.Pointer[Keyring]
+
+// Keyring encrypts values with its primary key and decrypts values encrypted with any of its keys.
+// The previous keys let the values encrypted before a key rotation be read until they're re-encrypted.
+type Keyring struct {
+	primary *key
+	keys    map[string]*key
+}
+
+type key struct {
+	id     string
+	aead   cipher.AEAD
+	macKey []byte
+}
+
+// ParseKey decodes a base64-encoded encryption key.
+func ParseKey(s string) ([]byte, error) {
+	k, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil || len(k) != KeySize {
+		return nil, fmt.Errorf(
+			"an encryption key must be %d random bytes encoded in base64, eg- generated by 'openssl rand -base64 %d'",
+			KeySize, KeySize,
+		)
+	}
+	return k, nil
+}
+
+// NewKeyring creates a keyring that encrypts with the primary key and can also decrypt with the previous keys.
+func NewKeyring(primary []byte, previous ...[]byte) (*Keyring, error) {
+	p, err := newKey(primary)
+	if err != nil {
+		return nil, err
+	}
+	k := &Keyring{primary: p, keys: map[string]*key{p.id: p}}
+	for _, secret := range previous {
+		pk, err := newKey(secret)
+		if err != nil {
+			return nil, err
+		}
+		k.keys[pk.id] = pk
+	}
+	return k, nil
+}
+
+func newKey(secret []byte) (*key, error) {
+	if len(secret) != KeySize {
+		return nil, fmt.Errorf("an encryption key must be %d bytes long, got %d", KeySize, len(secret))
+	}
+	// separate keys are derived for encryption, for the nonces and for identifying the key,
+	// so that the ID stored with every value reveals nothing about the key
+	encKey, err := hkdf.Key(sha256.New, secret, nil, "mcpjungle encryption", KeySize)
+	if err != nil {
+		return nil, err
+	}
+	macKey, err := hkdf.Key(sha256.New, secret, nil, "mcpjungle encryption nonce", KeySize)
+	if err != nil {
+		return nil, err
+	}
+	idKey, err := hkdf.Key(sha256.New, secret, nil, "mcpjungle encryption key id", 4)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(encKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &key{id: hex.EncodeToString(idKey), aead: aead, macKey: macKey}, nil
+}
+
+// encrypt seals the plaintext with AES-GCM.
+// The nonce is derived from the plaintext (as in SIV), so a nonce is only ever reused for the same plaintext,
+// which then yields the same ciphertext.
+func (k *key) encrypt(plaintext []byte) string {
+	mac := hmac.New(sha256.New, k.macKey)
+	mac.Write(plaintext)
+	nonce := mac.Sum(nil)[:k.aead.NonceSize()]
+	sealed := k.aead.Seal(nonce, nonce, plaintext, nil)
+	return prefix + k.id + ":" + base64.RawStdEncoding.EncodeToString(sealed)
+}
+
+// Encrypt encrypts the plaintext with the primary key.
+func (k *Keyring) Encrypt(plaintext []byte) string {
+	return k.primary.encrypt(plaintext)
+}
+
+// Decrypt decrypts a value encrypted with any key of the keyring.
+func (k *Keyring) Decrypt(value string) ([]byte, error) {
+	id, data, ok := strings.Cut(strings.TrimPrefix(value, prefix), ":")
+	if !IsEncrypted(value) || !ok {
+		return nil, errors.New("the value is not encrypted")
+	}
+	key, ok := k.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("the value is encrypted with an unknown key (id %s), add it to the previous keys", id)
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(data)
+	if err != nil || len(sealed) < key.aead.NonceSize() {
+		return nil, errors.New("the encrypted value is malformed")
+	}
+	nonce, ciphertext := sealed[:key.aead.NonceSize()], sealed[key.aead.NonceSize():]
+	plaintext, err := key.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt value with key %s: %w", id, err)
+	}
+	return plaintext, nil
+}
+
+// IsEncrypted returns true if the value was encrypted by a keyring
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// SetKeyring sets the keyring used to encrypt & decrypt columns. A nil keyring disables encryption.
+// It must be called before the database is accessed.
+func SetKeyring(k *Keyring) {
+	active.Store(k)
+}
+
+// Enabled returns true if a keyring is set
+func Enabled() bool {
+	return active.Load() != nil
+}
+
+// LookupValues returns all the values that an encrypted column may hold for the given plaintext,
+// ie, the plaintext itself and its encryption under every key of the keyring.
+// Use it to look up rows by an encrypted column, eg- db.Where("access_token IN ?", LookupValues(token)).
+func LookupValues(plaintext string) []string {
+	values := []string{plaintext}
+	k := active.Load()
+	if k == nil {
+		return values
+	}
+	for _, key := range k.keys {
+		values = append(values, key.encrypt([]byte(plaintext)))
+	}
+	return values
+}
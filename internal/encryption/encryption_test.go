@@ -0,0 +1,100 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+// The tests of this package can't use testhelpers, which depends on the models that use this package.
+
+func newTestKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, KeySize)
+}
+
+func newTestKeyring(t *testing.T, primary []byte, previous ...[]byte) *Keyring {
+	t.Helper()
+	k, err := NewKeyring(primary, previous...)
+	if err != nil {
+		t.Fatalf("failed to create keyring: %v", err)
+	}
+	return k
+}
+
+func TestParseKey(t *testing.T) {
+	k, err := ParseKey(base64.StdEncoding.EncodeToString(newTestKey(1)) + "\n")
+	if err != nil || len(k) != KeySize {
+		t.Errorf("expected a valid key, got %d bytes (err: %v)", len(k), err)
+	}
+	if _, err := ParseKey("not base64!"); err == nil {
+		t.Error("expected an error for a key that isn't base64")
+	}
+	if _, err := ParseKey(base64.StdEncoding.EncodeToString([]byte("too short"))); err == nil {
+		t.Error("expected an error for a short key")
+	}
+}
+
+func TestKeyring(t *testing.T) {
+	k := newTestKeyring(t, newTestKey(1))
+
+	encrypted := k.Encrypt([]byte("secret"))
+	if !IsEncrypted(encrypted) || strings.Contains(encrypted, "secret") {
+		t.Fatalf("expected the value to be encrypted, got %s", encrypted)
+	}
+	// encryption is deterministic, so that encrypted columns can be looked up
+	if encrypted != k.Encrypt([]byte("secret")) {
+		t.Error("expected the same value to encrypt to the same ciphertext")
+	}
+	if encrypted == k.Encrypt([]byte("other")) {
+		t.Error("expected different values to encrypt to different ciphertexts")
+	}
+
+	plaintext, err := k.Decrypt(encrypted)
+	if err != nil || string(plaintext) != "secret" {
+		t.Errorf("expected to decrypt the value, got %s (err: %v)", plaintext, err)
+	}
+
+	tampered := encrypted[:len(encrypted)-2] + "AA"
+	if _, err := k.Decrypt(tampered); err == nil {
+		t.Error("expected an error for a tampered value")
+	}
+	if _, err := k.Decrypt("secret"); err == nil {
+		t.Error("expected an error for a value that isn't encrypted")
+	}
+	if _, err := NewKeyring([]byte("short")); err == nil {
+		t.Error("expected an error for a short key")
+	}
+}
+
+func TestKeyringRotation(t *testing.T) {
+	encrypted := newTestKeyring(t, newTestKey(1)).Encrypt([]byte("secret"))
+
+	rotated := newTestKeyring(t, newTestKey(2), newTestKey(1))
+	plaintext, err := rotated.Decrypt(encrypted)
+	if err != nil || string(plaintext) != "secret" {
+		t.Errorf("expected the previous key to decrypt the value, got %s (err: %v)", plaintext, err)
+	}
+	if encrypted == rotated.Encrypt([]byte("secret")) {
+		t.Error("expected new values to be encrypted with the new key")
+	}
+
+	_, err = newTestKeyring(t, newTestKey(2)).Decrypt(encrypted)
+	if err == nil || !strings.Contains(err.Error(), "unknown key") {
+		t.Errorf("expected an unknown key error, got %v", err)
+	}
+}
+
+func TestLookupValues(t *testing.T) {
+	defer SetKeyring(nil)
+
+	SetKeyring(nil)
+	if v := LookupValues("token"); len(v) != 1 || v[0] != "token" {
+		t.Errorf("expected only the plain text value without a keyring, got %v", v)
+	}
+
+	SetKeyring(newTestKeyring(t, newTestKey(2), newTestKey(1)))
+	if v := LookupValues("token"); len(v) != 3 || v[0] != "token" {
+		t.Errorf("expected the plain text value and one value per key, got %v", v)
+	}
+}
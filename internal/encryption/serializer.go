@@ -0,0 +1,161 @@
+package encryption
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// SerializerName is the name to use in the gorm tag of encrypted fields, eg- `gorm:"serializer:encrypted"`
+const SerializerName = "encrypted"
+
+func init() {
+	schema.RegisterSerializer(SerializerName, Serializer{})
+}
+
+// Serializer is a gorm serializer that encrypts string, JSON & binary fields using the active keyring.
+// An encrypted JSON field (eg- datatypes.JSON) is stored as a JSON string holding the ciphertext,
+// so that the column remains valid JSON for databases that enforce it.
+// A binary field ([]byte) is stored as the bytes of the ciphertext.
+type Serializer struct{}
+
+// Scan decrypts the value read from the database into the field
+func (Serializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue any) error {
+	fieldValue := field.ReflectValueOf(ctx, dst)
+	if dbValue == nil {
+		fieldValue.Set(reflect.Zero(field.FieldType))
+		return nil
+	}
+
+	var raw []byte
+	switch v := dbValue.(type) {
+	case []byte:
+		// the driver may reuse its buffer
+		raw = append([]byte(nil), v...)
+	case string:
+		raw = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type %T for encrypted column %s", dbValue, field.DBName)
+	}
+
+	plaintext, err := decrypt(raw, isJSON(field))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt column %s: %w", field.DBName, err)
+	}
+	if fieldValue.Kind() == reflect.String {
+		fieldValue.SetString(string(plaintext))
+	} else {
+		fieldValue.SetBytes(plaintext)
+	}
+	return nil
+}
+
+// Value encrypts the field's value before it is written to the database
+func (Serializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue any) (any, error) {
+	rv := reflect.ValueOf(fieldValue)
+	var plaintext []byte
+	switch {
+	case rv.Kind() == reflect.String:
+		plaintext = []byte(rv.String())
+	case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		plaintext = rv.Bytes()
+	default:
+		return nil, fmt.Errorf("unsupported type %T for encrypted column %s", fieldValue, field.DBName)
+	}
+
+	k := active.Load()
+	if k == nil {
+		if isBinary(field) {
+			return plaintext, nil
+		}
+		return string(plaintext), nil
+	}
+	encrypted := k.Encrypt(plaintext)
+	if isBinary(field) {
+		return []byte(encrypted), nil
+	}
+	if !isJSON(field) {
+		return encrypted, nil
+	}
+	b, err := json.Marshal(encrypted)
+	return string(b), err
+}
+
+// decrypt returns the plaintext of a value read from the database, which may not be encrypted.
+func decrypt(raw []byte, jsonValue bool) ([]byte, error) {
+	value := string(raw)
+	if jsonValue {
+		// an encrypted JSON value is a JSON string, anything else is plain JSON
+		var s string
+		if json.Unmarshal(raw, &s) != nil || !IsEncrypted(s) {
+			return raw, nil
+		}
+		value = s
+	}
+	if !IsEncrypted(value) {
+		return raw, nil
+	}
+	k := active.Load()
+	if k == nil {
+		return nil, ErrNoKeyring
+	}
+	return k.Decrypt(value)
+}
+
+var bytesType = reflect.TypeOf([]byte(nil))
+
+// isJSON returns true if the field holds JSON, ie, it is a named byte slice like datatypes.JSON
+func isJSON(field *schema.Field) bool {
+	return field.FieldType.Kind() == reflect.Slice && !isBinary(field)
+}
+
+// isBinary returns true if the field holds raw bytes, ie, it is a plain []byte
+func isBinary(field *schema.Field) bool {
+	return field.FieldType == bytesType
+}
+
+// Rekey re-encrypts the encrypted columns of all rows of the given models with the primary key of the active keyring,
+// including the rows holding plain text values. It returns the number of rows rewritten.
+// It should be run in a transaction after a key rotation, so that the previous keys can be dropped.
+func Rekey(tx *gorm.DB, models ...any) (int64, error) {
+	if !Enabled() {
+		return 0, ErrNoKeyring
+	}
+	var total int64
+	for _, m := range models {
+		stmt := &gorm.Statement{DB: tx}
+		if err := stmt.Parse(m); err != nil {
+			return total, fmt.Errorf("failed to parse model %T: %w", m, err)
+		}
+		var columns []string
+		for _, f := range stmt.Schema.Fields {
+			if _, ok := f.Serializer.(Serializer); ok {
+				columns = append(columns, f.DBName)
+			}
+		}
+		if len(columns) == 0 {
+			continue
+		}
+
+		// soft-deleted rows are re-encrypted as well, they would be unreadable otherwise
+		rows := reflect.New(reflect.SliceOf(stmt.Schema.ModelType))
+		if err := tx.Unscoped().Find(rows.Interface()).Error; err != nil {
+			return total, fmt.Errorf("failed to read table %s: %w", stmt.Schema.Table, err)
+		}
+		for i := 0; i < rows.Elem().Len(); i++ {
+			row := rows.Elem().Index(i).Addr().Interface()
+			if err := tx.Unscoped().Model(row).Select(columns).UpdateColumns(row).Error; err != nil {
+				return total, fmt.Errorf("failed to re-encrypt a row of table %s: %w", stmt.Schema.Table, err)
+			}
+			total++
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,176 @@
+package encryption
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type credential struct {
+	ID        uint
+	Token     string         `gorm:"unique;serializer:encrypted"`
+	Config    datatypes.JSON `gorm:"type:jsonb;serializer:encrypted"`
+	DeletedAt gorm.DeletedAt
+}
+
+// rawCredential holds the columns of a credential as they are stored
+type rawCredential struct {
+	Token  string
+	Config string
+}
+
+func newCredentialDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	if err := db.AutoMigrate(&credential{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	return db
+}
+
+func createCredential(t *testing.T, db *gorm.DB, token, config string) *credential {
+	t.Helper()
+	c := &credential{Token: token, Config: datatypes.JSON(config)}
+	if err := db.Create(c).Error; err != nil {
+		t.Fatalf("failed to create credential: %v", err)
+	}
+	return c
+}
+
+func readRaw(t *testing.T, db *gorm.DB, id uint) rawCredential {
+	t.Helper()
+	var raw rawCredential
+	if err := db.Table("credentials").Where("id = ?", id).Take(&raw).Error; err != nil {
+		t.Fatalf("failed to read credential: %v", err)
+	}
+	return raw
+}
+
+func TestSerializer(t *testing.T) {
+	defer SetKeyring(nil)
+	SetKeyring(newTestKeyring(t, newTestKey(1)))
+
+	db := newCredentialDB(t)
+	c := createCredential(t, db, "secret-token", `{"bearer_token":"abc"}`)
+
+	raw := readRaw(t, db, c.ID)
+	if !IsEncrypted(raw.Token) {
+		t.Errorf("expected the token to be stored encrypted, got %s", raw.Token)
+	}
+	if strings.Contains(raw.Config, "abc") || !strings.HasPrefix(raw.Config, `"enc:v1:`) {
+		t.Errorf("expected the config to be stored as an encrypted JSON string, got %s", raw.Config)
+	}
+
+	var found credential
+	if err := db.Where("token IN ?", LookupValues("secret-token")).First(&found).Error; err != nil {
+		t.Fatalf("failed to look up credential by token: %v", err)
+	}
+	if found.Token != "secret-token" || string(found.Config) != `{"bearer_token":"abc"}` {
+		t.Errorf("expected decrypted values, got %s and %s", found.Token, found.Config)
+	}
+
+	// without the key, encrypted values can't be read
+	SetKeyring(nil)
+	if err := db.First(&credential{}, c.ID).Error; err == nil {
+		t.Error("expected an error when reading encrypted values without a keyring")
+	}
+}
+
+func TestSerializerBinary(t *testing.T) {
+	defer SetKeyring(nil)
+	SetKeyring(newTestKeyring(t, newTestKey(1)))
+
+	type response struct {
+		ID   uint
+		Body []byte `gorm:"serializer:encrypted"`
+	}
+	db := newCredentialDB(t)
+	if err := db.AutoMigrate(&response{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	r := &response{Body: []byte(`{"access_token":"secret"}`)}
+	if err := db.Create(r).Error; err != nil {
+		t.Fatalf("failed to create response: %v", err)
+	}
+
+	var raw struct{ Body []byte }
+	if err := db.Table("responses").Where("id = ?", r.ID).Take(&raw).Error; err != nil {
+		t.Fatalf("failed to read response: %v", err)
+	}
+	if !IsEncrypted(string(raw.Body)) {
+		t.Errorf("expected the body to be stored encrypted, got %s", raw.Body)
+	}
+
+	var found response
+	if err := db.First(&found, r.ID).Error; err != nil {
+		t.Fatalf("failed to read response: %v", err)
+	}
+	if string(found.Body) != `{"access_token":"secret"}` {
+		t.Errorf("expected the decrypted body, got %s", found.Body)
+	}
+}
+
+func TestSerializerPlaintext(t *testing.T) {
+	defer SetKeyring(nil)
+	SetKeyring(nil)
+
+	db := newCredentialDB(t)
+	c := createCredential(t, db, "plain-token", `{"url":"http://localhost"}`)
+	if raw := readRaw(t, db, c.ID); raw.Token != "plain-token" {
+		t.Errorf("expected the token to be stored in plain text, got %s", raw.Token)
+	}
+
+	// plain text values remain readable once encryption is enabled
+	SetKeyring(newTestKeyring(t, newTestKey(1)))
+	var found credential
+	if err := db.Where("token IN ?", LookupValues("plain-token")).First(&found).Error; err != nil {
+		t.Fatalf("failed to look up credential by token: %v", err)
+	}
+	if string(found.Config) != `{"url":"http://localhost"}` {
+		t.Errorf("expected the plain text config, got %s", found.Config)
+	}
+}
+
+func TestRekey(t *testing.T) {
+	defer SetKeyring(nil)
+
+	db := newCredentialDB(t)
+	SetKeyring(nil)
+	if _, err := Rekey(db, &credential{}); err == nil {
+		t.Error("expected an error without a keyring")
+	}
+
+	// one value in plain text, one encrypted with the old key and one soft-deleted
+	plain := createCredential(t, db, "plain", `{}`)
+	SetKeyring(newTestKeyring(t, newTestKey(1)))
+	createCredential(t, db, "old", `{"a":1}`)
+	deleted := createCredential(t, db, "deleted", `{}`)
+	if err := db.Delete(deleted).Error; err != nil {
+		t.Fatalf("failed to delete credential: %v", err)
+	}
+
+	SetKeyring(newTestKeyring(t, newTestKey(2), newTestKey(1)))
+	n, err := Rekey(db, &credential{})
+	if err != nil || n != 3 {
+		t.Fatalf("expected 3 rows to be re-encrypted, got %d (err: %v)", n, err)
+	}
+
+	// the old key is no longer needed
+	SetKeyring(newTestKeyring(t, newTestKey(2)))
+	var all []credential
+	if err := db.Unscoped().Order("id").Find(&all).Error; err != nil {
+		t.Fatalf("failed to read credentials with the new key only: %v", err)
+	}
+	if len(all) != 3 || all[0].Token != "plain" || string(all[1].Config) != `{"a":1}` {
+		t.Errorf("unexpected credentials after rekey: %+v", all)
+	}
+	if raw := readRaw(t, db, plain.ID); !IsEncrypted(raw.Token) {
+		t.Errorf("expected the plain text token to be encrypted, got %s", raw.Token)
+	}
+}
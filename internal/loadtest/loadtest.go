@@ -0,0 +1,232 @@
+// Package loadtest drives calls against mcpjungle at a controlled rate and concurrency,
+// and reports their latency & errors, so that the performance of the hot path can be measured.
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+)
+
+// maxErrorMessages is the maximum number of distinct error messages counted in a report,
+// the errors with any other message are counted together under OtherErrors.
+const maxErrorMessages = 20
+
+// Config controls how many calls are made and how fast.
+type Config struct {
+	// Rate is the number of calls started per second. 0 starts a call as soon as a worker is free.
+	Rate float64
+	// Concurrency is the maximum number of calls in flight at the same time.
+	Concurrency int
+	// Duration is how long calls are started for, the calls in flight once it elapses are waited for.
+	// 0 means no time limit, in which case Requests must be set.
+	Duration time.Duration
+	// Requests is the total number of calls to make. 0 means no limit, in which case Duration must be set.
+	Requests int
+}
+
+// Validate checks that the configuration describes a load test that ends.
+func (c Config) Validate() error {
+	if c.Rate < 0 {
+		return errors.New("rate cannot be negative")
+	}
+	if c.Concurrency <= 0 {
+		return errors.New("concurrency must be at least 1")
+	}
+	if c.Duration < 0 || c.Requests < 0 {
+		return errors.New("duration and number of requests cannot be negative")
+	}
+	if c.Duration == 0 && c.Requests == 0 {
+		return errors.New("either a duration or a number of requests is required")
+	}
+	return nil
+}
+
+// CallFunc makes a single call and returns an error if it failed.
+type CallFunc func(ctx context.Context) error
+
+// Latency summarizes the durations of the calls, in milliseconds.
+// Percentiles are computed using the nearest-rank method.
+type Latency struct {
+	Min  float64 `json:"min_ms"`
+	Mean float64 `json:"mean_ms"`
+	P50  float64 `json:"p50_ms"`
+	P90  float64 `json:"p90_ms"`
+	P99  float64 `json:"p99_ms"`
+	Max  float64 `json:"max_ms"`
+}
+
+// Report is the outcome of a load test.
+type Report struct {
+	Calls  int `json:"calls"`
+	Errors int `json:"errors"`
+	// ElapsedSeconds is the time from the first call's start to the last call's end
+	ElapsedSeconds float64 `json:"elapsed_seconds"`
+	// Throughput is the number of calls completed per second
+	Throughput float64 `json:"throughput"`
+	// Latency covers all the calls, whether they failed or not
+	Latency Latency `json:"latency"`
+	// ErrorMessages counts the errors by message
+	ErrorMessages map[string]int `json:"error_messages,omitempty"`
+	// OtherErrors counts the errors whose message is not in ErrorMessages
+	OtherErrors int `json:"other_errors,omitempty"`
+}
+
+// ErrorRate returns the fraction of calls that failed.
+func (r *Report) ErrorRate() float64 {
+	if r.Calls == 0 {
+		return 0
+	}
+	return float64(r.Errors) / float64(r.Calls)
+}
+
+// recorder collects the outcome of the calls made concurrently.
+type recorder struct {
+	mu        sync.Mutex
+	durations []time.Duration
+	errors    map[string]int
+	errCount  int
+	other     int
+}
+
+func (r *recorder) add(d time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.durations = append(r.durations, d)
+	if err == nil {
+		return
+	}
+	r.errCount++
+	msg := err.Error()
+	if _, ok := r.errors[msg]; ok || len(r.errors) < maxErrorMessages {
+		r.errors[msg]++
+	} else {
+		r.other++
+	}
+}
+
+func (r *recorder) report(elapsed time.Duration) *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rep := &Report{
+		Calls:          len(r.durations),
+		Errors:         r.errCount,
+		ElapsedSeconds: elapsed.Seconds(),
+		OtherErrors:    r.other,
+	}
+	if len(r.errors) > 0 {
+		rep.ErrorMessages = r.errors
+	}
+	if rep.Calls == 0 {
+		return rep
+	}
+	if elapsed > 0 {
+		rep.Throughput = float64(rep.Calls) / elapsed.Seconds()
+	}
+
+	d := slices.Clone(r.durations)
+	slices.Sort(d)
+	var total time.Duration
+	for _, v := range d {
+		total += v
+	}
+	percentile := func(p int) float64 {
+		rank := (len(d)*p + 99) / 100
+		return ms(d[max(rank, 1)-1])
+	}
+	rep.Latency = Latency{
+		Min:  ms(d[0]),
+		Mean: ms(total / time.Duration(len(d))),
+		P50:  percentile(50),
+		P90:  percentile(90),
+		P99:  percentile(99),
+		Max:  ms(d[len(d)-1]),
+	}
+	return rep
+}
+
+// timedOut returns true if the given timer channel fired, without waiting for it.
+func timedOut(c <-chan time.Time) bool {
+	select {
+	case <-c:
+		return true
+	default:
+		return false
+	}
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// Run makes calls as described by the configuration and reports their outcome once they have all completed.
+// Cancelling ctx stops starting new calls and cancels the calls in flight, the report then covers the calls
+// made so far.
+func Run(ctx context.Context, conf Config, call CallFunc) (*Report, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+
+	rec := &recorder{errors: make(map[string]int)}
+	slots := make(chan struct{}, conf.Concurrency)
+	var wg sync.WaitGroup
+
+	started := time.Now()
+	var stop <-chan time.Time
+	if conf.Duration > 0 {
+		timer := time.NewTimer(conf.Duration)
+		defer timer.Stop()
+		stop = timer.C
+	}
+
+	// wait blocks until the given time, it returns false if no more calls must be started
+	wait := func(until time.Time) bool {
+		d := time.Until(until)
+		if d <= 0 {
+			return true
+		}
+		t := time.NewTimer(d)
+		defer t.Stop()
+		select {
+		case <-t.C:
+			return true
+		case <-stop:
+			return false
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+loop:
+	for i := 0; conf.Requests == 0 || i < conf.Requests; i++ {
+		if conf.Rate > 0 && !wait(started.Add(time.Duration(float64(i)/conf.Rate*float64(time.Second)))) {
+			break
+		}
+		select {
+		case slots <- struct{}{}:
+		case <-stop:
+			break loop
+		case <-ctx.Done():
+			break loop
+		}
+		// a worker may have been freed at the same time as the test was stopped
+		if ctx.Err() != nil || timedOut(stop) {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			callStarted := time.Now()
+			err := call(ctx)
+			rec.add(time.Since(callStarted), err)
+		}()
+	}
+
+	wg.Wait()
+	return rec.report(time.Since(started)), nil
+}
@@ -0,0 +1,100 @@
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestConfigValidate(t *testing.T) {
+	testhelpers.AssertNoError(t, Config{Concurrency: 1, Requests: 10}.Validate())
+	testhelpers.AssertNoError(t, Config{Concurrency: 1, Duration: time.Second, Rate: 5}.Validate())
+
+	invalid := map[string]Config{
+		"negative rate":  {Concurrency: 1, Requests: 10, Rate: -1},
+		"no concurrency": {Requests: 10},
+		"never ends":     {Concurrency: 1},
+	}
+	for name, conf := range invalid {
+		t.Run(name, func(t *testing.T) {
+			testhelpers.AssertError(t, conf.Validate())
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Run("number of requests", func(t *testing.T) {
+		var inFlight, maxInFlight atomic.Int32
+		var calls atomic.Int32
+		rep, err := Run(context.Background(), Config{Concurrency: 3, Requests: 30}, func(ctx context.Context) error {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				m := maxInFlight.Load()
+				if n <= m || maxInFlight.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			if calls.Add(1)%10 == 0 {
+				return errors.New("upstream unavailable")
+			}
+			return nil
+		})
+		testhelpers.AssertNoError(t, err)
+
+		testhelpers.AssertEqual(t, 30, rep.Calls)
+		testhelpers.AssertEqual(t, 3, rep.Errors)
+		testhelpers.AssertEqual(t, 3, rep.ErrorMessages["upstream unavailable"])
+		testhelpers.AssertTrue(t, maxInFlight.Load() <= 3, "expected at most 3 calls in flight")
+		testhelpers.AssertTrue(t, rep.Latency.Min >= 1, "expected calls to take at least 1ms")
+		testhelpers.AssertTrue(t, rep.Latency.Min <= rep.Latency.P50 && rep.Latency.P50 <= rep.Latency.Max, "expected ordered latencies")
+		testhelpers.AssertTrue(t, rep.Throughput > 0, "expected a throughput")
+	})
+
+	t.Run("rate", func(t *testing.T) {
+		started := time.Now()
+		rep, err := Run(context.Background(), Config{Rate: 100, Concurrency: 10, Requests: 11}, func(ctx context.Context) error {
+			return nil
+		})
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, 11, rep.Calls)
+		// the 11th call starts 100ms after the first one
+		testhelpers.AssertTrue(t, time.Since(started) >= 100*time.Millisecond, "expected the calls to be paced")
+	})
+
+	t.Run("duration", func(t *testing.T) {
+		rep, err := Run(context.Background(), Config{Concurrency: 2, Duration: 50 * time.Millisecond}, func(ctx context.Context) error {
+			time.Sleep(5 * time.Millisecond)
+			return nil
+		})
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertTrue(t, rep.Calls > 0 && rep.Calls <= 22, fmt.Sprintf("unexpected number of calls %d", rep.Calls))
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		rep, err := Run(ctx, Config{Concurrency: 1, Requests: 1000}, func(ctx context.Context) error {
+			cancel()
+			return nil
+		})
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, 1, rep.Calls)
+	})
+
+	t.Run("distinct error messages are capped", func(t *testing.T) {
+		var calls atomic.Int32
+		rep, err := Run(context.Background(), Config{Concurrency: 1, Requests: maxErrorMessages + 5}, func(ctx context.Context) error {
+			return fmt.Errorf("request %d failed", calls.Add(1))
+		})
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, maxErrorMessages, len(rep.ErrorMessages))
+		testhelpers.AssertEqual(t, 5, rep.OtherErrors)
+		testhelpers.AssertEqual(t, 1.0, rep.ErrorRate())
+	})
+}
@@ -0,0 +1,148 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// The initial schema is the one that mcpjungle created using auto-migration before migrations were versioned.
+// Applying it to a database that was auto-migrated by an earlier version of mcpjungle changes nothing,
+// it only records the database as being at version 1.
+
+type mcpServerV1 struct {
+	gorm.Model
+	Name        string         `gorm:"uniqueIndex;not null"`
+	Transport   string         `gorm:"type:varchar(30);not null"`
+	Description string         ``
+	Config      datatypes.JSON `gorm:"type:jsonb;not null"`
+}
+
+func (mcpServerV1) TableName() string { return "mcp_servers" }
+
+type toolV1 struct {
+	gorm.Model
+	Name        string         `gorm:"not null"`
+	Enabled     bool           `gorm:"default:true"`
+	Description string         ``
+	InputSchema datatypes.JSON `gorm:"type:jsonb"`
+	Annotations datatypes.JSON `gorm:"type:jsonb"`
+	ServerID    uint           `gorm:"not null"`
+	Server      mcpServerV1    `gorm:"foreignKey:ServerID;references:ID"`
+}
+
+func (toolV1) TableName() string { return "tools" }
+
+type serverConfigV1 struct {
+	gorm.Model
+	Mode        string `gorm:"type:varchar(12);not null"`
+	Initialized bool   `gorm:"not null;default:false"`
+}
+
+func (serverConfigV1) TableName() string { return "server_configs" }
+
+type userV1 struct {
+	gorm.Model
+	Username    string `gorm:"unique; not null"`
+	Role        string `gorm:"not null"`
+	AccessToken string `gorm:"unique; not null"`
+}
+
+func (userV1) TableName() string { return "users" }
+
+type mcpClientV1 struct {
+	gorm.Model
+	Name        string         `gorm:"uniqueIndex;not null"`
+	Description string         ``
+	AccessToken string         `gorm:"unique; not null"`
+	AllowList   datatypes.JSON `gorm:"type:jsonb; not null"`
+}
+
+func (mcpClientV1) TableName() string { return "mcp_clients" }
+
+type toolGroupV1 struct {
+	gorm.Model
+	Name            string         `gorm:"unique; not null"`
+	Description     string         ``
+	IncludedTools   datatypes.JSON `gorm:"type:jsonb"`
+	IncludedServers datatypes.JSON `gorm:"type:jsonb"`
+	ExcludedTools   datatypes.JSON `gorm:"type:jsonb"`
+	ReadOnly        bool           `gorm:"default:false"`
+	CachedTools     datatypes.JSON `gorm:"type:jsonb"`
+}
+
+func (toolGroupV1) TableName() string { return "tool_groups" }
+
+type promptV1 struct {
+	gorm.Model
+	Name        string         `gorm:"not null"`
+	Enabled     bool           `gorm:"default:true"`
+	Description string         ``
+	Arguments   datatypes.JSON `gorm:"type:jsonb"`
+	ServerID    uint           `gorm:"not null"`
+	Server      mcpServerV1    `gorm:"foreignKey:ServerID;references:ID"`
+}
+
+func (promptV1) TableName() string { return "prompts" }
+
+type toolInvocationJobV1 struct {
+	gorm.Model
+	JobID       string         `gorm:"uniqueIndex;not null"`
+	ToolName    string         `gorm:"not null"`
+	Status      string         `gorm:"type:varchar(20);not null"`
+	CreatedBy   string         ``
+	Result      datatypes.JSON `gorm:"type:jsonb"`
+	Error       string         ``
+	StartedAt   *time.Time     ``
+	CompletedAt *time.Time     ``
+}
+
+func (toolInvocationJobV1) TableName() string { return "tool_invocation_jobs" }
+
+type toolInvocationV1 struct {
+	ID                 uint      `gorm:"primarykey"`
+	ToolName           string    `gorm:"index;not null"`
+	Caller             string    `gorm:"index"`
+	Client             string    `gorm:"index"`
+	Outcome            string    `gorm:"type:varchar(20);not null"`
+	Error              string    ``
+	DurationMs         int64     ``
+	Arguments          string    `gorm:"type:text"`
+	ArgumentsTruncated bool      ``
+	CreatedAt          time.Time `gorm:"index"`
+}
+
+func (toolInvocationV1) TableName() string { return "tool_invocations" }
+
+// initialSchemaTables are the tables of the initial schema, in the order they're created
+var initialSchemaTables = []any{
+	&mcpServerV1{},
+	&toolV1{},
+	&serverConfigV1{},
+	&userV1{},
+	&mcpClientV1{},
+	&toolGroupV1{},
+	&promptV1{},
+	&toolInvocationJobV1{},
+	&toolInvocationV1{},
+}
+
+func init() {
+	register(Migration{
+		Version: 1,
+		Name:    "initial_schema",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(initialSchemaTables...)
+		},
+		Down: func(tx *gorm.DB) error {
+			// tables are dropped in reverse order, so that tools & prompts are dropped before the servers they reference
+			for i := len(initialSchemaTables) - 1; i >= 0; i-- {
+				if err := tx.Migrator().DropTable(initialSchemaTables[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
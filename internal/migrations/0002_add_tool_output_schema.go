@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// toolV2 is the tools table with the output schema that MCP servers can provide for their tools.
+type toolV2 struct {
+	OutputSchema datatypes.JSON `gorm:"type:jsonb"`
+}
+
+func (toolV2) TableName() string { return "tools" }
+
+func init() {
+	register(Migration{
+		Version: 2,
+		Name:    "add_tool_output_schema",
+		Up: func(tx *gorm.DB) error {
+			return tx.Migrator().AddColumn(&toolV2{}, "OutputSchema")
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&toolV2{}, "OutputSchema")
+		},
+	})
+}
@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// idempotencyKeyV3 records the requests sent with an Idempotency-Key header and their responses.
+type idempotencyKeyV3 struct {
+	ID           uint       `gorm:"primarykey"`
+	Key          string     `gorm:"uniqueIndex:idx_idempotency_keys_caller_key;not null"`
+	Caller       string     `gorm:"uniqueIndex:idx_idempotency_keys_caller_key;not null;default:''"`
+	Method       string     `gorm:"type:varchar(10);not null"`
+	Path         string     `gorm:"not null"`
+	RequestHash  string     `gorm:"type:varchar(64);not null"`
+	StatusCode   int        ``
+	ContentType  string     ``
+	ResponseBody []byte     ``
+	CreatedAt    time.Time  `gorm:"index"`
+	CompletedAt  *time.Time ``
+}
+
+func (idempotencyKeyV3) TableName() string { return "idempotency_keys" }
+
+func init() {
+	register(Migration{
+		Version: 3,
+		Name:    "add_idempotency_keys",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&idempotencyKeyV3{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&idempotencyKeyV3{})
+		},
+	})
+}
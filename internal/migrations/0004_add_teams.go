@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// teamV4 groups users, so that permissions can be granted to all the members of a team at once.
+type teamV4 struct {
+	gorm.Model
+	Name        string         `gorm:"unique; not null"`
+	Description string         ``
+	Members     datatypes.JSON `gorm:"type:jsonb"`
+	ToolGroups  datatypes.JSON `gorm:"type:jsonb"`
+	Servers     datatypes.JSON `gorm:"type:jsonb"`
+}
+
+func (teamV4) TableName() string { return "teams" }
+
+func init() {
+	register(Migration{
+		Version: 4,
+		Name:    "add_teams",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&teamV4{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&teamV4{})
+		},
+	})
+}
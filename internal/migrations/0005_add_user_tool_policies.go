@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// userV5 is the users table with the policies that restrict which tools a user may access via the API.
+type userV5 struct {
+	AllowedServers    datatypes.JSON `gorm:"type:jsonb"`
+	AllowedToolGroups datatypes.JSON `gorm:"type:jsonb"`
+}
+
+func (userV5) TableName() string { return "users" }
+
+var userV5Columns = []string{"AllowedServers", "AllowedToolGroups"}
+
+func init() {
+	register(Migration{
+		Version: 5,
+		Name:    "add_user_tool_policies",
+		Up: func(tx *gorm.DB) error {
+			for _, col := range userV5Columns {
+				if err := tx.Migrator().AddColumn(&userV5{}, col); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			for _, col := range userV5Columns {
+				if err := tx.Migrator().DropColumn(&userV5{}, col); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+// userV6 is the users table with the flag that tells whether a user is suspended.
+type userV6 struct {
+	Active bool `gorm:"not null; default:true"`
+}
+
+func (userV6) TableName() string { return "users" }
+
+func init() {
+	register(Migration{
+		Version: 6,
+		Name:    "add_user_active",
+		Up: func(tx *gorm.DB) error {
+			// the existing users remain active thanks to the column's default
+			return tx.Migrator().AddColumn(&userV6{}, "Active")
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&userV6{}, "Active")
+		},
+	})
+}
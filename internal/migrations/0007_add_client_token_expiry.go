@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// mcpClientV7 is the mcp_clients table with the lifetime & expiry of the clients' access tokens.
+type mcpClientV7 struct {
+	TokenTTLSeconds int64 `gorm:"not null; default:0"`
+	TokenExpiresAt  *time.Time
+}
+
+func (mcpClientV7) TableName() string { return "mcp_clients" }
+
+var mcpClientV7Columns = []string{"TokenTTLSeconds", "TokenExpiresAt"}
+
+func init() {
+	register(Migration{
+		Version: 7,
+		Name:    "add_client_token_expiry",
+		Up: func(tx *gorm.DB) error {
+			for _, col := range mcpClientV7Columns {
+				// the tokens of the existing clients never expire thanks to the columns' defaults
+				if err := tx.Migrator().AddColumn(&mcpClientV7{}, col); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			for _, col := range mcpClientV7Columns {
+				if err := tx.Migrator().DropColumn(&mcpClientV7{}, col); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
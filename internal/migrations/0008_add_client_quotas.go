@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// mcpClientV8 is the mcp_clients table with the tool call quotas of the clients & their usage.
+type mcpClientV8 struct {
+	QuotaLimit    int64 `gorm:"not null; default:0"`
+	QuotaPeriod   string
+	QuotaUsed     int64 `gorm:"not null; default:0"`
+	QuotaResetsAt *time.Time
+}
+
+func (mcpClientV8) TableName() string { return "mcp_clients" }
+
+var mcpClientV8Columns = []string{"QuotaLimit", "QuotaPeriod", "QuotaUsed", "QuotaResetsAt"}
+
+func init() {
+	register(Migration{
+		Version: 8,
+		Name:    "add_client_quotas",
+		Up: func(tx *gorm.DB) error {
+			for _, col := range mcpClientV8Columns {
+				// the existing clients remain unlimited thanks to the columns' defaults
+				if err := tx.Migrator().AddColumn(&mcpClientV8{}, col); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			for _, col := range mcpClientV8Columns {
+				if err := tx.Migrator().DropColumn(&mcpClientV8{}, col); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
@@ -1,35 +1,265 @@
 // Package migrations provides database migration functionality for the MCPJungle application.
+//
+// The schema is changed by versioned migrations, one per file named after its version (eg- 0002_add_foo.go).
+// The migrations applied to a database are recorded in its schema_migrations table, so that every migration
+// is applied exactly once and can be rolled back in reverse order.
+//
+// A migration must never depend on the current models of the model package, because they keep changing
+// after the migration is written. Instead, it declares snapshots of the tables it creates or changes.
 package migrations
 
 import (
+	"database/sql"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"gorm.io/gorm"
 )
 
-// Migrate performs the database migration for the application.
+// Migration is a versioned change to the database schema.
+type Migration struct {
+	// Version orders the migrations. It must be unique and never be reused, even if the migration is removed.
+	Version int
+	// Name briefly describes the change
+	Name string
+	// Up applies the change
+	Up func(tx *gorm.DB) error
+	// Down reverts the change. A migration without Down cannot be rolled back.
+	Down func(tx *gorm.DB) error
+}
+
+// Status describes whether a migration has been applied to the database.
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// schemaMigration is a record of a migration applied to the database
+type schemaMigration struct {
+	Version   int       `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// registered contains all the migrations, sorted by version
+var registered []Migration
+
+// register adds a migration to the list of migrations.
+// It is called by the init function of every migration file.
+func register(m Migration) {
+	for _, r := range registered {
+		if r.Version == m.Version {
+			panic(fmt.Sprintf("duplicate migration version %d: %s and %s", m.Version, r.Name, m.Name))
+		}
+	}
+	registered = append(registered, m)
+	sort.Slice(registered, func(i, j int) bool { return registered[i].Version < registered[j].Version })
+}
+
+// Migrate applies all the pending migrations to the database.
 func Migrate(db *gorm.DB) error {
-	if err := db.AutoMigrate(&model.McpServer{}); err != nil {
-		return fmt.Errorf("auto‑migration failed for McpServer model: %v", err)
+	_, err := Apply(db)
+	return err
+}
+
+// Apply applies all the pending migrations to the database, in order, and returns the ones it applied.
+// Every migration is applied in its own transaction, so a failed migration leaves the schema
+// at the version of the previous one.
+func Apply(db *gorm.DB) ([]Migration, error) {
+	return apply(db, nil)
+}
+
+// apply applies the pending migrations like Apply, calling onApplied after every migration applied.
+func apply(db *gorm.DB, onApplied func(Migration)) ([]Migration, error) {
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkKnown(applied); err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, m := range registered {
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := m.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %d (%s) failed: %w", m.Version, m.Name, err)
+		}
+		done = append(done, m)
+		if onApplied != nil {
+			onApplied(m)
+		}
+	}
+	return done, nil
+}
+
+// Rollback reverts the last n migrations applied to the database, in reverse order,
+// and returns the ones it reverted.
+func Rollback(db *gorm.DB, n int) ([]Migration, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("the number of migrations to roll back must be positive")
+	}
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
 	}
-	if err := db.AutoMigrate(&model.Tool{}); err != nil {
-		return fmt.Errorf("auto‑migration failed for Tool model: %v", err)
+	if err := checkKnown(applied); err != nil {
+		return nil, err
 	}
-	if err := db.AutoMigrate(&model.ServerConfig{}); err != nil {
-		return fmt.Errorf("auto‑migration failed for ServerConfig model: %v", err)
+
+	var done []Migration
+	for i := len(registered) - 1; i >= 0 && len(done) < n; i-- {
+		m := registered[i]
+		if _, ok := applied[m.Version]; !ok {
+			continue
+		}
+		if m.Down == nil {
+			return done, fmt.Errorf("migration %d (%s) cannot be rolled back", m.Version, m.Name)
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := m.Down(tx); err != nil {
+				return err
+			}
+			return tx.Delete(&schemaMigration{Version: m.Version}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("rollback of migration %d (%s) failed: %w", m.Version, m.Name, err)
+		}
+		done = append(done, m)
+	}
+	return done, nil
+}
+
+// GetStatus returns the status of all the migrations, sorted by version.
+func GetStatus(db *gorm.DB) ([]Status, error) {
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]Status, len(registered))
+	for i, m := range registered {
+		statuses[i] = Status{Migration: m}
+		if a, ok := applied[m.Version]; ok {
+			statuses[i].Applied = true
+			statuses[i].AppliedAt = a.AppliedAt
+		}
+	}
+	return statuses, nil
+}
+
+// appliedMigrations returns the migrations recorded in the database, keyed by version.
+// The schema_migrations table is created if it doesn't exist yet.
+func appliedMigrations(db *gorm.DB) (map[int]schemaMigration, error) {
+	if err := db.AutoMigrate(&schemaMigration{}); err != nil {
+		return nil, fmt.Errorf("failed to create the schema_migrations table: %w", err)
+	}
+	var records []schemaMigration
+	if err := db.Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to list the applied migrations: %w", err)
 	}
-	if err := db.AutoMigrate(&model.User{}); err != nil {
-		return fmt.Errorf("auto‑migration failed for User model: %v", err)
+	applied := make(map[int]schemaMigration, len(records))
+	for _, r := range records {
+		applied[r.Version] = r
 	}
-	if err := db.AutoMigrate(&model.McpClient{}); err != nil {
-		return fmt.Errorf("auto‑migration failed for McpClient model: %v", err)
+	return applied, nil
+}
+
+// checkKnown returns an error if the database has migrations that this build doesn't know about,
+// ie, it was migrated by a newer version of mcpjungle.
+// Running an older version against it could corrupt the data, so the newer migrations must be rolled back first.
+func checkKnown(applied map[int]schemaMigration) error {
+	known := make(map[int]bool, len(registered))
+	for _, m := range registered {
+		known[m.Version] = true
 	}
-	if err := db.AutoMigrate(&model.ToolGroup{}); err != nil {
-		return fmt.Errorf("auto‑migration failed for ToolGroup model: %v", err)
+	for v, r := range applied {
+		if !known[v] {
+			return fmt.Errorf(
+				"the database has migration %d (%s) applied, which is unknown to this version of mcpjungle;"+
+					" roll it back using the version of mcpjungle that applied it",
+				v, r.Name,
+			)
+		}
 	}
-	if err := db.AutoMigrate(&model.Prompt{}); err != nil {
-		return fmt.Errorf("auto‑migration failed for Prompt model: %v", err)
+	return nil
+}
+
+// CheckVersion verifies that the database schema is at the version of the latest migration known to this build.
+// Unlike Check, it only compares the latest version recorded in the schema_migrations table, which takes a single query,
+// so it is cheap enough to run on every readiness probe.
+func CheckVersion(db *gorm.DB) error {
+	var applied sql.NullInt64
+	if err := db.Model(&schemaMigration{}).Select("MAX(version)").Scan(&applied).Error; err != nil {
+		return fmt.Errorf("failed to get the latest applied migration: %w", err)
+	}
+	expected := 0
+	if len(registered) > 0 {
+		expected = registered[len(registered)-1].Version
+	}
+
+	switch version := int(applied.Int64); {
+	case version < expected:
+		return fmt.Errorf("the database schema is at version %d, migration %d is not applied yet", version, expected)
+	case version > expected:
+		return fmt.Errorf(
+			"the database schema is at version %d, which is unknown to this version of mcpjungle (latest: %d)",
+			version, expected,
+		)
+	}
+	return nil
+}
+
+// migratedModels lists all models whose tables are managed by the migrations.
+var migratedModels = []any{
+	&model.McpServer{},
+	&model.Tool{},
+	&model.ServerConfig{},
+	&model.User{},
+	&model.McpClient{},
+	&model.ToolGroup{},
+	&model.Prompt{},
+	&model.ToolInvocationJob{},
+	&model.ToolInvocation{},
+	&model.IdempotencyKey{},
+	&model.Team{},
+}
+
+// Check verifies that the database schema is up-to-date, ie, the tables and columns
+// of all models exist in the database.
+// It returns an error describing the first missing table or column.
+func Check(db *gorm.DB) error {
+	m := db.Migrator()
+	for _, mdl := range migratedModels {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(mdl); err != nil {
+			return fmt.Errorf("failed to parse model %T: %w", mdl, err)
+		}
+		if !m.HasTable(mdl) {
+			return fmt.Errorf("table %s does not exist", stmt.Schema.Table)
+		}
+		for _, f := range stmt.Schema.Fields {
+			if f.DBName == "" {
+				continue
+			}
+			if !m.HasColumn(mdl, f.DBName) {
+				return fmt.Errorf("column %s of table %s does not exist", f.DBName, stmt.Schema.Table)
+			}
+		}
 	}
 	return nil
 }
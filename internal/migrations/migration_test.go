@@ -0,0 +1,129 @@
+package migrations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestCheck(t *testing.T) {
+	db, err := testhelpers.CreateTestDB()
+	testhelpers.AssertNoError(t, err)
+
+	// nothing is migrated yet
+	testhelpers.AssertError(t, Check(db))
+
+	testhelpers.AssertNoError(t, Migrate(db))
+	testhelpers.AssertNoError(t, Check(db))
+
+	// a column added to a model after the last migration must be detected
+	testhelpers.AssertNoError(t, db.Migrator().DropColumn(&model.ToolGroup{}, "read_only"))
+	err = Check(db)
+	testhelpers.AssertError(t, err)
+	testhelpers.AssertStringContains(t, err.Error(), "read_only")
+}
+
+func TestCheckVersion(t *testing.T) {
+	db, err := testhelpers.CreateTestDB()
+	testhelpers.AssertNoError(t, err)
+
+	// nothing is migrated yet
+	testhelpers.AssertError(t, CheckVersion(db))
+
+	testhelpers.AssertNoError(t, Migrate(db))
+	testhelpers.AssertNoError(t, CheckVersion(db))
+
+	_, err = Rollback(db, 1)
+	testhelpers.AssertNoError(t, err)
+	err = CheckVersion(db)
+	testhelpers.AssertError(t, err)
+	testhelpers.AssertStringContains(t, err.Error(), "is not applied yet")
+
+	testhelpers.AssertNoError(t, Migrate(db))
+	latest := registered[len(registered)-1].Version
+	unknown := &schemaMigration{Version: latest + 1, Name: "from the future", AppliedAt: time.Now()}
+	testhelpers.AssertNoError(t, db.Create(unknown).Error)
+	err = CheckVersion(db)
+	testhelpers.AssertError(t, err)
+	testhelpers.AssertStringContains(t, err.Error(), "unknown to this version of mcpjungle")
+}
+
+func TestApplyAndRollback(t *testing.T) {
+	db, err := testhelpers.CreateTestDB()
+	testhelpers.AssertNoError(t, err)
+
+	applied, err := Apply(db)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, len(registered), len(applied))
+
+	// applying again is a no-op
+	applied, err = Apply(db)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 0, len(applied))
+
+	statuses, err := GetStatus(db)
+	testhelpers.AssertNoError(t, err)
+	for _, s := range statuses {
+		testhelpers.AssertTrue(t, s.Applied, "migration should be applied")
+	}
+
+	reverted, err := Rollback(db, len(registered))
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, len(registered), len(reverted))
+	testhelpers.AssertEqual(t, 1, reverted[len(reverted)-1].Version)
+	testhelpers.AssertFalse(t, db.Migrator().HasTable(&model.McpServer{}), "tables should be dropped")
+
+	statuses, err = GetStatus(db)
+	testhelpers.AssertNoError(t, err)
+	for _, s := range statuses {
+		testhelpers.AssertFalse(t, s.Applied, "migration should be pending")
+	}
+
+	// nothing left to roll back
+	reverted, err = Rollback(db, 1)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 0, len(reverted))
+
+	_, err = Rollback(db, 0)
+	testhelpers.AssertError(t, err)
+}
+
+func TestApplyToAutoMigratedDatabase(t *testing.T) {
+	db, err := testhelpers.CreateTestDB()
+	testhelpers.AssertNoError(t, err)
+
+	// databases created by earlier versions of mcpjungle were auto-migrated from the models of the initial schema
+	testhelpers.AssertNoError(t, db.AutoMigrate(initialSchemaTables...))
+	testhelpers.AssertNoError(t, db.Create(&userV1{Username: "admin", Role: "admin", AccessToken: "t"}).Error)
+
+	testhelpers.AssertNoError(t, Migrate(db))
+	testhelpers.AssertNoError(t, Check(db))
+
+	var count int64
+	testhelpers.AssertNoError(t, db.Model(&model.User{}).Count(&count).Error)
+	testhelpers.AssertEqual(t, int64(1), count)
+}
+
+func TestApplyUnknownMigration(t *testing.T) {
+	db, err := testhelpers.CreateTestDB()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, Migrate(db))
+
+	// the database was migrated by a newer version of mcpjungle
+	testhelpers.AssertNoError(t, db.Create(&schemaMigration{Version: 9999, Name: "from_the_future"}).Error)
+
+	_, err = Apply(db)
+	testhelpers.AssertError(t, err)
+	testhelpers.AssertStringContains(t, err.Error(), "from_the_future")
+
+	_, err = Rollback(db, 1)
+	testhelpers.AssertError(t, err)
+}
+
+func TestRegisterDuplicateVersion(t *testing.T) {
+	testhelpers.AssertPanic(t, func() {
+		register(Migration{Version: 1, Name: "duplicate"})
+	})
+}
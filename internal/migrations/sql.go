@@ -0,0 +1,95 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// Script is the SQL that a step of Apply runs against the database.
+type Script struct {
+	// Migration is the migration applied by the statements,
+	// nil for the statements that create the schema_migrations table.
+	Migration *Migration
+	// Statements are the SQL statements, with their parameters inlined
+	Statements []string
+}
+
+// SQL returns the statements that Apply would run against the database to apply the pending migrations,
+// without changing the database. On an empty database, this is the DDL of the whole schema.
+//
+// The pending migrations are applied in a transaction that is rolled back, recording the statements that change
+// the database along the way. Both Postgres & SQLite support transactional DDL, so nothing is left behind.
+// The statements include the ones recording the migrations in the schema_migrations table,
+// so a database migrated by running them is recognized as up-to-date by Apply.
+func SQL(db *gorm.DB) ([]Script, error) {
+	rec := &recorder{Interface: db.Logger}
+	tx := db.Session(&gorm.Session{Logger: rec}).Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	defer tx.Rollback()
+
+	if _, err := appliedMigrations(tx); err != nil {
+		return nil, err
+	}
+	var scripts []Script
+	if stmts := rec.take(); len(stmts) > 0 {
+		scripts = append(scripts, Script{Statements: stmts})
+	}
+	_, err := apply(tx, func(m Migration) {
+		scripts = append(scripts, Script{Migration: &m, Statements: rec.take()})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return scripts, nil
+}
+
+// changeStatements are the leading keywords of the statements that change the database.
+// Other statements, eg- the queries that gorm's migrator runs to inspect the schema, are not recorded.
+var changeStatements = []string{"CREATE ", "ALTER ", "DROP ", "INSERT ", "UPDATE ", "DELETE "}
+
+// recorder is a gorm logger that records the statements that change the database.
+type recorder struct {
+	gormlogger.Interface
+
+	mu         sync.Mutex
+	statements []string
+}
+
+func (r *recorder) LogMode(gormlogger.LogLevel) gormlogger.Interface {
+	return r
+}
+
+func (r *recorder) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	r.Interface.Trace(ctx, begin, fc, err)
+	if err != nil {
+		return
+	}
+	sql, _ := fc()
+	sql = strings.TrimSpace(sql)
+	upper := strings.ToUpper(sql)
+	for _, prefix := range changeStatements {
+		if strings.HasPrefix(upper, prefix) {
+			r.mu.Lock()
+			r.statements = append(r.statements, sql)
+			r.mu.Unlock()
+			return
+		}
+	}
+}
+
+// take returns the statements recorded since the last call
+func (r *recorder) take() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stmts := r.statements
+	r.statements = nil
+	return stmts
+}
@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestSQL(t *testing.T) {
+	db, err := testhelpers.CreateTestDB()
+	testhelpers.AssertNoError(t, err)
+
+	scripts, err := SQL(db)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, len(registered)+1, len(scripts))
+
+	// the schema_migrations table is created first
+	testhelpers.AssertTrue(t, scripts[0].Migration == nil, "first script should create the schema_migrations table")
+	testhelpers.AssertStringContains(t, scripts[0].Statements[0], "schema_migrations")
+
+	initial := scripts[1]
+	testhelpers.AssertEqual(t, 1, initial.Migration.Version)
+	testhelpers.AssertStringContains(t, strings.Join(initial.Statements, "\n"), "CREATE TABLE `mcp_servers`")
+	last := initial.Statements[len(initial.Statements)-1]
+	testhelpers.AssertStringContains(t, last, "INSERT INTO `schema_migrations`")
+
+	// the database is left untouched
+	testhelpers.AssertFalse(t, db.Migrator().HasTable(&schemaMigration{}), "schema_migrations should not be created")
+	testhelpers.AssertFalse(t, db.Migrator().HasTable(&model.McpServer{}), "tables should not be created")
+
+	// running the statements migrates the database
+	for _, s := range scripts {
+		for _, stmt := range s.Statements {
+			testhelpers.AssertNoError(t, db.Exec(stmt).Error)
+		}
+	}
+	testhelpers.AssertNoError(t, Check(db))
+	applied, err := Apply(db)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 0, len(applied))
+
+	// nothing left to apply
+	scripts, err = SQL(db)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 0, len(scripts))
+}
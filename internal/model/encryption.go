@@ -0,0 +1,10 @@
+package model
+
+import (
+	// registers the serializer of the columns tagged with `serializer:encrypted`
+	_ "github.com/mcpjungle/mcpjungle/internal/encryption"
+)
+
+// EncryptedModels lists the models that have encrypted columns, whose values must be re-encrypted
+// when the encryption key is rotated.
+var EncryptedModels = []any{&McpServer{}, &McpClient{}, &User{}, &IdempotencyKey{}}
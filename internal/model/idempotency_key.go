@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// IdempotencyKey records a request sent with an Idempotency-Key header and the response it got,
+// so that the same request sent again (eg- retried after a timeout) gets the same response instead of being
+// processed twice. Keys are scoped to the caller that sent them and expire after a while (see retention.Config).
+type IdempotencyKey struct {
+	ID uint `gorm:"primarykey"`
+
+	// Key is the value of the Idempotency-Key header chosen by the client.
+	Key string `gorm:"uniqueIndex:idx_idempotency_keys_caller_key;not null"`
+	// Caller is the username of the user that sent the request, empty in development mode.
+	Caller string `gorm:"uniqueIndex:idx_idempotency_keys_caller_key;not null;default:''"`
+
+	Method string `gorm:"type:varchar(10);not null"`
+	Path   string `gorm:"not null"`
+	// RequestHash is the SHA-256 hash of the request body. A key can't be reused for a different request.
+	RequestHash string `gorm:"type:varchar(64);not null"`
+
+	// StatusCode is the status of the response, 0 while the request is being processed.
+	StatusCode  int    ``
+	ContentType string ``
+	// ResponseBody is encrypted since it may hold credentials, eg- the access token of a new client.
+	ResponseBody []byte `gorm:"serializer:encrypted"`
+
+	CreatedAt   time.Time  `gorm:"index"`
+	CompletedAt *time.Time ``
+}
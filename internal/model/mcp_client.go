@@ -2,7 +2,9 @@ package model
 
 import (
 	"encoding/json"
+	"time"
 
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
@@ -14,7 +16,22 @@ type McpClient struct {
 	Name        string `json:"name" gorm:"uniqueIndex;not null"`
 	Description string `json:"description"`
 
-	AccessToken string `json:"access_token" gorm:"unique; not null"`
+	AccessToken string `json:"access_token" gorm:"unique; not null; serializer:encrypted"`
+
+	// TokenTTLSeconds is the lifetime of the client's access tokens, 0 if they never expire.
+	// Every token issued to the client, including the ones issued when it renews its token, lives this long.
+	TokenTTLSeconds int64 `json:"token_ttl_seconds,omitempty" gorm:"not null; default:0"`
+	// TokenExpiresAt is the time after which the client's current access token is rejected, nil if it never expires.
+	TokenExpiresAt *time.Time `json:"token_expires_at,omitempty"`
+
+	// QuotaLimit is the number of tool calls the client may make per quota period, 0 if it is unlimited.
+	QuotaLimit  int64             `json:"quota_limit,omitempty" gorm:"not null; default:0"`
+	QuotaPeriod types.QuotaPeriod `json:"quota_period,omitempty"`
+	// QuotaUsed is the number of tool calls the client made in the current quota period.
+	QuotaUsed int64 `json:"quota_used,omitempty" gorm:"not null; default:0"`
+	// QuotaResetsAt is the end of the current quota period, nil if the client made no tool call in it.
+	// A new period starts with the client's first tool call after it ends.
+	QuotaResetsAt *time.Time `json:"quota_resets_at,omitempty"`
 
 	// AllowList contains a list of MCP Server names that this client is allowed to view and call
 	// storing the list of server names as a JSON array is a convenient way for now.
@@ -39,3 +56,36 @@ func (c *McpClient) CheckHasServerAccess(serverName string) bool {
 	}
 	return false
 }
+
+// TokenExpired returns true if the client's current access token has expired at the given time.
+func (c *McpClient) TokenExpired(now time.Time) bool {
+	return c.TokenExpiresAt != nil && !now.Before(*c.TokenExpiresAt)
+}
+
+// NextTokenExpiry returns the time at which a token issued to the client at the given time expires,
+// or nil if the client's tokens never expire.
+func (c *McpClient) NextTokenExpiry(now time.Time) *time.Time {
+	if c.TokenTTLSeconds <= 0 {
+		return nil
+	}
+	expiresAt := now.Add(time.Duration(c.TokenTTLSeconds) * time.Second).UTC()
+	return &expiresAt
+}
+
+// NextQuotaReset returns the end of the client's quota period that contains the given time.
+func (c *McpClient) NextQuotaReset(now time.Time) time.Time {
+	now = now.UTC()
+	if c.QuotaPeriod == types.QuotaPeriodMonthly {
+		return time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+}
+
+// RefreshQuota clears the quota usage of the client if its quota period has ended at the given time,
+// so that it reflects the usage of the current period.
+func (c *McpClient) RefreshQuota(now time.Time) {
+	if c.QuotaResetsAt != nil && !now.Before(*c.QuotaResetsAt) {
+		c.QuotaUsed = 0
+		c.QuotaResetsAt = nil
+	}
+}
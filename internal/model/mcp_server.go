@@ -13,9 +13,9 @@ type StreamableHTTPConfig struct {
 	// URL must be a valid http/https URL.
 	URL string `json:"url"`
 
-	// TODO: Store the bearer token in a more secure way, e.g., encrypted instead of plaintext.
 	// BearerToken is an optional token used for authenticating requests to the MCP server.
 	// If present, it will be used to set the Authorization header in all requests to this MCP server.
+	// It is encrypted at rest along with the rest of the server's config, see McpServer.Config.
 	BearerToken string `json:"bearer_token,omitempty"`
 }
 
@@ -48,7 +48,7 @@ type McpServer struct {
 
 	// Config describes the transport-specific configuration for the MCP server.
 	// It contains the JSON representation of either StreamableHTTPConfig or StdioConfig.
-	Config datatypes.JSON `json:"config" gorm:"type:jsonb;not null"`
+	Config datatypes.JSON `json:"config" gorm:"type:jsonb;not null;serializer:encrypted"`
 }
 
 // NewStreamableHTTPServer creates a new MCP server with streamable HTTP transport configuration.
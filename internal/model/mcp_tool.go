@@ -24,6 +24,12 @@ type Tool struct {
 	// InputSchema is a JSON schema that describes the input parameters for the tool.
 	InputSchema datatypes.JSON `json:"input_schema" gorm:"type:jsonb"`
 
+	// OutputSchema is a JSON schema that describes the structured content returned by the tool, if any.
+	OutputSchema datatypes.JSON `json:"output_schema,omitempty" gorm:"type:jsonb"`
+
+	// Annotations contains the hints (read-only, destructive, etc.) that the MCP server provided about the tool.
+	Annotations datatypes.JSON `json:"annotations" gorm:"type:jsonb"`
+
 	// ServerID is the ID of the MCP server that provides this tool.
 	ServerID uint      `json:"-" gorm:"not null"`
 	Server   McpServer `json:"-" gorm:"foreignKey:ServerID;references:ID"`
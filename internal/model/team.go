@@ -0,0 +1,89 @@
+package model
+
+import (
+	"encoding/json"
+	"slices"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// Team represents a group of users in enterprise mode.
+// Permissions are granted to a team rather than to its members individually,
+// so that every member of the team gets them.
+type Team struct {
+	gorm.Model
+
+	Name        string `json:"name" gorm:"unique; not null"`
+	Description string `json:"description"`
+
+	// Members contains the usernames of the users that belong to this team.
+	// storing the list of usernames as a JSON array is a convenient way for now.
+	Members datatypes.JSON `json:"members" gorm:"type:jsonb"`
+
+	// ToolGroups contains the names of the tool groups that the members of this team may administer.
+	ToolGroups datatypes.JSON `json:"tool_groups" gorm:"type:jsonb"`
+
+	// Servers contains the names of the MCP servers that the members of this team may administer.
+	Servers datatypes.JSON `json:"servers" gorm:"type:jsonb"`
+}
+
+// GetMembers unmarshals the Members JSON array into a slice of usernames.
+func (t *Team) GetMembers() ([]string, error) {
+	return unmarshalNames(t.Members)
+}
+
+// GetToolGroups unmarshals the ToolGroups JSON array into a slice of tool group names.
+func (t *Team) GetToolGroups() ([]string, error) {
+	return unmarshalNames(t.ToolGroups)
+}
+
+// GetServers unmarshals the Servers JSON array into a slice of MCP server names.
+func (t *Team) GetServers() ([]string, error) {
+	return unmarshalNames(t.Servers)
+}
+
+// HasMember returns true if the given user belongs to this team.
+func (t *Team) HasMember(username string) bool {
+	members, err := t.GetMembers()
+	return err == nil && slices.Contains(members, username)
+}
+
+// RemoveMember removes the given user from this team.
+// It returns true if the user was a member of the team.
+func (t *Team) RemoveMember(username string) (bool, error) {
+	members, err := t.GetMembers()
+	if err != nil {
+		return false, err
+	}
+	i := slices.Index(members, username)
+	if i < 0 {
+		return false, nil
+	}
+	t.Members, err = json.Marshal(slices.Delete(members, i, i+1))
+	return true, err
+}
+
+// CanAdministerToolGroup returns true if the members of this team may administer the given tool group.
+func (t *Team) CanAdministerToolGroup(name string) bool {
+	groups, err := t.GetToolGroups()
+	return err == nil && slices.Contains(groups, name)
+}
+
+// CanAdministerServer returns true if the members of this team may administer the given MCP server.
+func (t *Team) CanAdministerServer(name string) bool {
+	servers, err := t.GetServers()
+	return err == nil && slices.Contains(servers, name)
+}
+
+// unmarshalNames unmarshals a JSON array of names, which is empty if the array was never set.
+func unmarshalNames(data datatypes.JSON) ([]string, error) {
+	if data == nil {
+		return []string{}, nil
+	}
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
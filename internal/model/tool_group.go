@@ -3,6 +3,8 @@ package model
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
+	"time"
 
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
@@ -31,6 +33,14 @@ type ToolGroup struct {
 
 	// ExcludedTools contains a list of tool names to exclude from the group.
 	ExcludedTools datatypes.JSON `json:"excluded_tools" gorm:"type:jsonb"`
+
+	// ReadOnly indicates that the group must only expose tools that don't modify their environment.
+	// Tools annotated by their MCP server as destructive or not read-only are left out of the group.
+	ReadOnly bool `json:"read_only" gorm:"default:false"`
+
+	// CachedTools maps names of idempotent tools in this group to the duration for which their responses are cached.
+	// Durations are strings in Go duration format, eg- "30s", "5m".
+	CachedTools datatypes.JSON `json:"cached_tools" gorm:"type:jsonb"`
 }
 
 // GetTools unmarshals the IncludedTools JSON array into a slice of strings.
@@ -63,6 +73,67 @@ func (g *ToolGroup) GetExcludedTools() ([]string, error) {
 	return tools, err
 }
 
+// GetCachedTools unmarshals the CachedTools JSON object into a map of tool name to cache TTL string.
+func (g *ToolGroup) GetCachedTools() (map[string]string, error) {
+	if g.CachedTools == nil {
+		return map[string]string{}, nil
+	}
+	var cachedTools map[string]string
+	err := json.Unmarshal(g.CachedTools, &cachedTools)
+	return cachedTools, err
+}
+
+// GetCacheTTLs returns the parsed cache TTL of every cached tool in this group.
+// It returns an error if any TTL is not a valid, positive duration.
+func (g *ToolGroup) GetCacheTTLs() (map[string]time.Duration, error) {
+	cachedTools, err := g.GetCachedTools()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cached tools: %w", err)
+	}
+
+	ttls := make(map[string]time.Duration, len(cachedTools))
+	for tool, ttl := range cachedTools {
+		d, err := time.ParseDuration(ttl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache ttl %q for tool %s: %w", ttl, tool, err)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("invalid cache ttl %q for tool %s: ttl must be positive", ttl, tool)
+		}
+		ttls[tool] = d
+	}
+	return ttls, nil
+}
+
+// IncludesTool reports whether the given tool is part of this group according to the group's rules.
+// A tool is included if it is listed in included_tools or if its parent server is listed in included_servers,
+// unless it is also listed in excluded_tools.
+// Unlike ResolveEffectiveTools, this method does not need to look up any tools, which makes it cheap to call
+// whenever a single new tool is added to mcpjungle.
+func (g *ToolGroup) IncludesTool(toolName, serverName string) (bool, error) {
+	excludedTools, err := g.GetExcludedTools()
+	if err != nil {
+		return false, fmt.Errorf("failed to get excluded tools: %w", err)
+	}
+	if slices.Contains(excludedTools, toolName) {
+		return false, nil
+	}
+
+	includedTools, err := g.GetTools()
+	if err != nil {
+		return false, fmt.Errorf("failed to get included tools: %w", err)
+	}
+	if slices.Contains(includedTools, toolName) {
+		return true, nil
+	}
+
+	includedServers, err := g.GetServers()
+	if err != nil {
+		return false, fmt.Errorf("failed to get included servers: %w", err)
+	}
+	return slices.Contains(includedServers, serverName), nil
+}
+
 // ResolveEffectiveTools resolves all effective tools for this group by combining
 // included_tools, included_servers, and applying excluded_tools.
 // Note that tool exclusions are applied at last, so if a tool is both included and excluded,
@@ -3,6 +3,7 @@ package model
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"gorm.io/datatypes"
 )
@@ -82,6 +83,40 @@ func TestToolGroup_GetExcludedTools(t *testing.T) {
 	}
 }
 
+func TestToolGroup_GetCacheTTLs(t *testing.T) {
+	group := &ToolGroup{
+		CachedTools: datatypes.JSON(`{"server1__lookup": "30s", "server1__search": "5m"}`),
+	}
+
+	ttls, err := group.GetCacheTTLs()
+	if err != nil {
+		t.Fatalf("GetCacheTTLs() failed: %v", err)
+	}
+	if len(ttls) != 2 {
+		t.Errorf("Expected 2 cached tools, got %d", len(ttls))
+	}
+	if ttls["server1__lookup"] != 30*time.Second || ttls["server1__search"] != 5*time.Minute {
+		t.Errorf("Unexpected cache TTLs: %v", ttls)
+	}
+
+	// no cached tools configured
+	ttls, err = (&ToolGroup{}).GetCacheTTLs()
+	if err != nil {
+		t.Fatalf("GetCacheTTLs() failed for group without cached tools: %v", err)
+	}
+	if len(ttls) != 0 {
+		t.Errorf("Expected no cached tools, got %v", ttls)
+	}
+
+	// invalid TTLs must be rejected
+	for _, ttl := range []string{"abc", "0s", "-1m"} {
+		group := &ToolGroup{CachedTools: datatypes.JSON(`{"server1__lookup": "` + ttl + `"}`)}
+		if _, err := group.GetCacheTTLs(); err == nil {
+			t.Errorf("Expected error for invalid cache TTL %q", ttl)
+		}
+	}
+}
+
 func TestToolGroup_ResolveEffectiveTools(t *testing.T) {
 	// Create mock resolver with some test data
 	resolver := &mockToolResolver{
@@ -285,3 +320,40 @@ func TestToolGroup_ResolveEffectiveTools_EmptyGroup(t *testing.T) {
 		t.Errorf("Expected 0 tools for empty group, got %d", len(result))
 	}
 }
+
+func TestToolGroup_IncludesTool(t *testing.T) {
+	toolsJSON, _ := json.Marshal([]string{"manual__tool1", "time__convert_time"})
+	serversJSON, _ := json.Marshal([]string{"time"})
+	excludedJSON, _ := json.Marshal([]string{"time__convert_time", "time__format_time"})
+
+	group := &ToolGroup{
+		IncludedTools:   datatypes.JSON(toolsJSON),
+		IncludedServers: datatypes.JSON(serversJSON),
+		ExcludedTools:   datatypes.JSON(excludedJSON),
+	}
+
+	tests := []struct {
+		name       string
+		toolName   string
+		serverName string
+		expected   bool
+	}{
+		{"explicitly included tool", "manual__tool1", "manual", true},
+		{"new tool of included server", "time__get_current_time", "time", true},
+		{"excluded tool of included server", "time__format_time", "time", false},
+		{"tool both included and excluded", "time__convert_time", "time", false},
+		{"tool of a server not in the group", "deepwiki__search_wiki", "deepwiki", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := group.IncludesTool(tt.toolName, tt.serverName)
+			if err != nil {
+				t.Fatalf("IncludesTool() failed: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("IncludesTool(%q, %q) = %v; want %v", tt.toolName, tt.serverName, result, tt.expected)
+			}
+		})
+	}
+}
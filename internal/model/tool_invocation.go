@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// ToolInvocation is a record of a tool call made through mcpjungle, kept for auditing & debugging.
+// Only a limited number of the most recent invocations are retained, optionally for a limited time (see retention.Config).
+type ToolInvocation struct {
+	ID uint `json:"id" gorm:"primarykey"`
+
+	// ToolName is the canonical name of the tool that was called.
+	ToolName string `json:"tool_name" gorm:"index;not null"`
+	// Caller is the name of the MCP client or the username of the user that called the tool.
+	Caller string `json:"caller" gorm:"index"`
+	// Client is the name of the MCP client that called the tool, empty if the tool wasn't called by an MCP client.
+	Client  string                  `json:"client" gorm:"index"`
+	Outcome types.InvocationOutcome `json:"outcome" gorm:"type:varchar(20);not null"`
+	Error   string                  `json:"error"`
+
+	DurationMs int64 `json:"duration_ms"`
+
+	// Arguments is the redacted & truncated JSON-encoded input of the call.
+	Arguments          string `json:"arguments" gorm:"type:text"`
+	ArgumentsTruncated bool   `json:"arguments_truncated"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// ToolInvocationJob is a tool invocation that runs in the background.
+// Jobs are persisted so that their status & result can be retrieved after the invocation request returns.
+type ToolInvocationJob struct {
+	gorm.Model
+
+	// JobID is the public, unguessable identifier of the job.
+	JobID string `json:"job_id" gorm:"uniqueIndex;not null"`
+	// ToolName is the canonical name of the tool being invoked.
+	ToolName string          `json:"tool_name" gorm:"not null"`
+	Status   types.JobStatus `json:"status" gorm:"type:varchar(20);not null"`
+
+	// CreatedBy is the username of the user who started the job.
+	// It is empty in development mode, where there are no users.
+	CreatedBy string `json:"created_by"`
+
+	// Result is the JSON-encoded types.ToolInvokeResult of a successful job.
+	Result datatypes.JSON `json:"result" gorm:"type:jsonb"`
+	// Error describes why the job failed.
+	Error string `json:"error"`
+
+	StartedAt   *time.Time `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+// GetResult returns the decoded result of the job, or nil if the job has no result yet.
+func (j *ToolInvocationJob) GetResult() (*types.ToolInvokeResult, error) {
+	if len(j.Result) == 0 {
+		return nil, nil
+	}
+	var result types.ToolInvokeResult
+	if err := json.Unmarshal(j.Result, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
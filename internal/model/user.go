@@ -3,6 +3,7 @@ package model
 
 import (
 	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -14,5 +15,36 @@ type User struct {
 
 	Username    string         `json:"username" gorm:"unique; not null"`
 	Role        types.UserRole `json:"role" gorm:"not null"`
-	AccessToken string         `json:"access_token" gorm:"unique; not null"`
+	AccessToken string         `json:"access_token" gorm:"unique; not null; serializer:encrypted"`
+
+	// Active is false while the user is suspended, ie, their access token is rejected.
+	// Suspending a user keeps their account, team memberships and tool policy, unlike deleting them.
+	Active bool `json:"active" gorm:"not null; default:true"`
+
+	// AllowedServers contains the names of the MCP servers whose tools a regular user may see & invoke
+	// via the API, if the user has a tool access policy.
+	AllowedServers datatypes.JSON `json:"allowed_servers" gorm:"type:jsonb"`
+
+	// AllowedToolGroups contains the names of the tool groups whose tools a regular user may see & invoke
+	// via the API, if the user has a tool access policy.
+	AllowedToolGroups datatypes.JSON `json:"allowed_tool_groups" gorm:"type:jsonb"`
+}
+
+// HasToolPolicy returns true if the tools this user may access via the API are restricted.
+// Users without a policy may access all tools, and so may admins regardless of their policy.
+func (u *User) HasToolPolicy() bool {
+	if u.Role == types.UserRoleAdmin {
+		return false
+	}
+	return u.AllowedServers != nil || u.AllowedToolGroups != nil
+}
+
+// GetAllowedServers unmarshals the AllowedServers JSON array into a slice of MCP server names.
+func (u *User) GetAllowedServers() ([]string, error) {
+	return unmarshalNames(u.AllowedServers)
+}
+
+// GetAllowedToolGroups unmarshals the AllowedToolGroups JSON array into a slice of tool group names.
+func (u *User) GetAllowedToolGroups() ([]string, error) {
+	return unmarshalNames(u.AllowedToolGroups)
 }
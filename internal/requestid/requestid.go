@@ -0,0 +1,54 @@
+// Package requestid provides functionality to identify a request across mcpjungle and the upstream MCP servers it calls.
+package requestid
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Header is the HTTP header carrying the request ID, both in requests to mcpjungle and to upstream MCP servers.
+const Header = "X-Request-ID"
+
+// MetaKey is the key of the request ID in the _meta of tool calls forwarded to upstream MCP servers.
+// This makes the request ID available to servers that don't use HTTP, like stdio servers.
+const MetaKey = "mcpjungle/requestId"
+
+// ContextKey is the key under which the request ID is stored in a context.
+// Like the other values mcpjungle stores in a context, the key is a plain string so that
+// the ID can be read from a gin.Context as well as from the underlying request's context.
+const ContextKey = "request_id"
+
+// maxLength is the maximum length of a request ID supplied by a client
+const maxLength = 128
+
+// New generates a new request ID.
+func New() string {
+	return uuid.NewString()
+}
+
+// IsValid returns true if a request ID supplied by a client can be used as-is.
+// It must be non-empty, not too long and only contain printable ASCII characters,
+// so that it is safe to log and forward in HTTP headers.
+func IsValid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// NewContext returns a copy of ctx that carries the given request ID.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ContextKey, id)
+}
+
+// FromContext returns the request ID carried by ctx, or an empty string if there is none.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(ContextKey).(string)
+	return id
+}
@@ -0,0 +1,42 @@
+package requestid
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestIsValid(t *testing.T) {
+	testCases := []struct {
+		id    string
+		valid bool
+	}{
+		{id: "3f2b8c1e-8a4d-4b0e-9c55-0a6f1d2e7b90", valid: true},
+		{id: "trace-123_abc.def", valid: true},
+		{id: "", valid: false},
+		{id: "has space", valid: false},
+		{id: "line\nbreak", valid: false},
+		{id: "ünicode", valid: false},
+		{id: strings.Repeat("a", maxLength), valid: true},
+		{id: strings.Repeat("a", maxLength+1), valid: false},
+	}
+	for _, tc := range testCases {
+		testhelpers.AssertEqual(t, tc.valid, IsValid(tc.id))
+	}
+}
+
+func TestNewIsValid(t *testing.T) {
+	id := New()
+	testhelpers.AssertTrue(t, IsValid(id), "generated request ID should be valid")
+	testhelpers.AssertTrue(t, id != New(), "generated request IDs should be unique")
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	testhelpers.AssertEqual(t, "", FromContext(ctx))
+
+	ctx = NewContext(ctx, "req-1")
+	testhelpers.AssertEqual(t, "req-1", FromContext(ctx))
+}
@@ -0,0 +1,258 @@
+// Package alert provides alerting on elevated tool call error rates via webhooks.
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+)
+
+const (
+	// DefaultWindow is the default sliding window over which error rates are computed
+	DefaultWindow = 5 * time.Minute
+	// DefaultErrorRateThreshold is the default error rate (between 0 and 1) at which an alert is raised
+	DefaultErrorRateThreshold = 0.5
+	// DefaultMinCalls is the default number of calls a server must receive within the window
+	// before its error rate is evaluated, so that a single failed call doesn't raise an alert
+	DefaultMinCalls = 10
+
+	webhookTimeout = 10 * time.Second
+)
+
+// Config configures when alerts are raised and where they're sent.
+type Config struct {
+	// WebhookURL is the URL that alerts are posted to.
+	// The payload is compatible with Slack incoming webhooks.
+	WebhookURL string
+	// Window is the sliding window over which the error rate of each server is computed.
+	Window time.Duration
+	// ErrorRateThreshold is the error rate (between 0 and 1) at or above which an alert is raised.
+	ErrorRateThreshold float64
+	// MinCalls is the minimum number of calls within the window for the error rate to be evaluated.
+	MinCalls int64
+}
+
+// webhookPayload is the body posted to the webhook.
+// Text is all that Slack needs, the other fields are for webhook receivers that process alerts.
+type webhookPayload struct {
+	Text      string  `json:"text"`
+	Status    string  `json:"status"`
+	Server    string  `json:"server"`
+	ErrorRate float64 `json:"error_rate"`
+	Calls     int64   `json:"calls"`
+	Errors    int64   `json:"errors"`
+	Window    string  `json:"window"`
+
+	// url is the webhook the payload is posted to
+	url string
+}
+
+const (
+	statusFiring   = "firing"
+	statusResolved = "resolved"
+)
+
+// serverState holds the recent call counts of an MCP server and whether an alert is firing for it.
+type serverState struct {
+	stats  *telemetry.InvocationStats
+	firing bool
+}
+
+// ErrorRateAlerter is a CustomMetrics implementation that watches the tool call error rate of every
+// MCP server over a sliding window, and forwards every call to another CustomMetrics implementation.
+// When the error rate of a server crosses the threshold, an alert is posted to the webhook.
+// Once the error rate drops below the threshold again, a resolution is posted.
+type ErrorRateAlerter struct {
+	next   telemetry.CustomMetrics
+	config Config
+
+	mu      sync.Mutex
+	servers map[string]*serverState
+
+	httpClient *http.Client
+	logger     logger.Logger
+	// wg tracks the webhook requests in flight
+	wg sync.WaitGroup
+}
+
+// NewErrorRateAlerter creates a new ErrorRateAlerter.
+// Zero values in the config are replaced by the defaults.
+func NewErrorRateAlerter(next telemetry.CustomMetrics, config Config, l logger.Logger) *ErrorRateAlerter {
+	return &ErrorRateAlerter{
+		next:       next,
+		config:     config.withDefaults(),
+		servers:    make(map[string]*serverState),
+		httpClient: &http.Client{Timeout: webhookTimeout},
+		logger:     l,
+	}
+}
+
+// SetConfig replaces the configuration of the alerter while it is in use, eg- when the server reloads its settings.
+// Zero values in the config are replaced by the defaults, and no alerts are posted while the webhook URL is empty.
+// Changing the window starts counting the calls to every server afresh.
+func (a *ErrorRateAlerter) SetConfig(config Config) {
+	config = config.withDefaults()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if config.Window != a.config.Window {
+		a.servers = make(map[string]*serverState)
+	}
+	a.config = config
+}
+
+func (c Config) withDefaults() Config {
+	if c.Window <= 0 {
+		c.Window = DefaultWindow
+	}
+	if c.ErrorRateThreshold <= 0 {
+		c.ErrorRateThreshold = DefaultErrorRateThreshold
+	}
+	if c.MinCalls <= 0 {
+		c.MinCalls = DefaultMinCalls
+	}
+	return c
+}
+
+func (a *ErrorRateAlerter) RecordToolCall(
+	ctx context.Context, serverName, toolName string, outcome telemetry.ToolCallOutcome, elapsedTime time.Duration,
+) {
+	a.next.RecordToolCall(ctx, serverName, toolName, outcome, elapsedTime)
+	if p := a.evaluate(ctx, serverName, toolName, outcome, elapsedTime); p != nil {
+		a.wg.Add(1)
+		go func() {
+			defer a.wg.Done()
+			a.post(p)
+		}()
+	}
+}
+
+func (a *ErrorRateAlerter) RecordSlowToolCall(
+	ctx context.Context, serverName, toolName string, elapsedTime time.Duration,
+) {
+	a.next.RecordSlowToolCall(ctx, serverName, toolName, elapsedTime)
+}
+
+func (a *ErrorRateAlerter) RecordPromptCall(
+	ctx context.Context, serverName, promptName string, outcome telemetry.PromptCallOutcome, elapsedTime time.Duration,
+) {
+	a.next.RecordPromptCall(ctx, serverName, promptName, outcome, elapsedTime)
+}
+
+func (a *ErrorRateAlerter) RecordUpstreamServerHealth(
+	ctx context.Context, serverName string, reachable bool, consecutiveFailures int64,
+) {
+	a.next.RecordUpstreamServerHealth(ctx, serverName, reachable, consecutiveFailures)
+}
+
+func (a *ErrorRateAlerter) RecordToolGroupRequest(
+	ctx context.Context, groupName string, transport telemetry.ToolGroupTransport,
+) {
+	a.next.RecordToolGroupRequest(ctx, groupName, transport)
+}
+
+func (a *ErrorRateAlerter) AddToolGroupSessions(
+	ctx context.Context, groupName string, transport telemetry.ToolGroupTransport, delta int64,
+) {
+	a.next.AddToolGroupSessions(ctx, groupName, transport, delta)
+}
+
+func (a *ErrorRateAlerter) RecordToolGroupToolCall(
+	ctx context.Context, groupName, toolName string, outcome telemetry.ToolCallOutcome, elapsedTime time.Duration,
+) {
+	a.next.RecordToolGroupToolCall(ctx, groupName, toolName, outcome, elapsedTime)
+}
+
+// Wait blocks until all the alerts being posted have been sent.
+func (a *ErrorRateAlerter) Wait() {
+	a.wg.Wait()
+}
+
+// evaluate records the tool call in the server's counts and returns the alert to post if the
+// server's error rate crossed the threshold in either direction, or nil otherwise.
+func (a *ErrorRateAlerter) evaluate(
+	ctx context.Context, serverName, toolName string, outcome telemetry.ToolCallOutcome, elapsedTime time.Duration,
+) *webhookPayload {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	s, ok := a.servers[serverName]
+	if !ok {
+		s = &serverState{stats: telemetry.NewInvocationStats(telemetry.NewNoopCustomMetrics(), a.config.Window)}
+		a.servers[serverName] = s
+	}
+	s.stats.RecordToolCall(ctx, serverName, toolName, outcome, elapsedTime)
+
+	counts := s.stats.Counts()
+	if counts.ToolCalls == 0 {
+		return nil
+	}
+	rate := float64(counts.ToolCallErrors) / float64(counts.ToolCalls)
+
+	var status string
+	switch {
+	case !s.firing && counts.ToolCalls >= a.config.MinCalls && rate >= a.config.ErrorRateThreshold:
+		s.firing = true
+		status = statusFiring
+	case s.firing && rate < a.config.ErrorRateThreshold:
+		s.firing = false
+		status = statusResolved
+	default:
+		return nil
+	}
+	if a.config.WebhookURL == "" {
+		return nil
+	}
+
+	p := &webhookPayload{
+		url:       a.config.WebhookURL,
+		Status:    status,
+		Server:    serverName,
+		ErrorRate: rate,
+		Calls:     counts.ToolCalls,
+		Errors:    counts.ToolCallErrors,
+		Window:    s.stats.Window().String(),
+	}
+	if status == statusFiring {
+		p.Text = fmt.Sprintf(
+			":rotating_light: MCPJungle: %.0f%% of tool calls to MCP server *%s* failed in the last %s (%d of %d calls)",
+			rate*100, serverName, p.Window, counts.ToolCallErrors, counts.ToolCalls,
+		)
+	} else {
+		p.Text = fmt.Sprintf(
+			":white_check_mark: MCPJungle: the error rate of tool calls to MCP server *%s* is back to %.0f%%",
+			serverName, rate*100,
+		)
+	}
+	return p
+}
+
+// post sends the alert to the webhook.
+// Failures are only logged because alerting must never affect tool calls.
+func (a *ErrorRateAlerter) post(p *webhookPayload) {
+	fields := []logger.Field{logger.String("server", p.Server), logger.String("status", p.Status)}
+
+	body, err := json.Marshal(p)
+	if err != nil {
+		a.logger.Error("failed to encode alert", append(fields, logger.ErrorField(err))...)
+		return
+	}
+	resp, err := a.httpClient.Post(p.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		a.logger.Error("failed to post alert to webhook", append(fields, logger.ErrorField(err))...)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		a.logger.Error("alert webhook returned an error", append(fields, logger.Int("status_code", resp.StatusCode))...)
+		return
+	}
+	a.logger.Info("posted alert to webhook", fields...)
+}
@@ -0,0 +1,122 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestErrorRateAlerter(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []webhookPayload
+	)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p webhookPayload
+		testhelpers.AssertNoError(t, json.NewDecoder(r.Body).Decode(&p))
+		mu.Lock()
+		received = append(received, p)
+		mu.Unlock()
+	}))
+	defer webhook.Close()
+
+	a := NewErrorRateAlerter(
+		telemetry.NewNoopCustomMetrics(),
+		Config{WebhookURL: webhook.URL, ErrorRateThreshold: 0.5, MinCalls: 4},
+		logger.NewNop(),
+	)
+	ctx := context.Background()
+	call := func(server string, outcome telemetry.ToolCallOutcome) {
+		a.RecordToolCall(ctx, server, "tool", outcome, time.Millisecond)
+	}
+
+	// too few calls to evaluate the error rate
+	for range 3 {
+		call("flaky", telemetry.ToolCallOutcomeError)
+	}
+	a.Wait()
+	testhelpers.AssertEqual(t, 0, len(received))
+
+	// the threshold is crossed, but the alert is only sent once
+	call("flaky", telemetry.ToolCallOutcomeError)
+	call("flaky", telemetry.ToolCallOutcomeError)
+	call("healthy", telemetry.ToolCallOutcomeSuccess)
+	a.Wait()
+	testhelpers.AssertEqual(t, 1, len(received))
+	testhelpers.AssertEqual(t, statusFiring, received[0].Status)
+	testhelpers.AssertEqual(t, "flaky", received[0].Server)
+	testhelpers.AssertEqual(t, int64(4), received[0].Calls)
+	testhelpers.AssertEqual(t, "5m0s", received[0].Window)
+	testhelpers.AssertStringContains(t, received[0].Text, "100% of tool calls to MCP server *flaky* failed")
+
+	// the error rate drops below the threshold
+	for range 6 {
+		call("flaky", telemetry.ToolCallOutcomeSuccess)
+	}
+	a.Wait()
+	testhelpers.AssertEqual(t, 2, len(received))
+	testhelpers.AssertEqual(t, statusResolved, received[1].Status)
+	testhelpers.AssertEqual(t, int64(11), received[1].Calls)
+	testhelpers.AssertEqual(t, int64(5), received[1].Errors)
+}
+
+func TestNewErrorRateAlerterDefaults(t *testing.T) {
+	a := NewErrorRateAlerter(telemetry.NewNoopCustomMetrics(), Config{WebhookURL: "http://localhost"}, logger.NewNop())
+	testhelpers.AssertEqual(t, DefaultWindow, a.config.Window)
+	testhelpers.AssertEqual(t, DefaultErrorRateThreshold, a.config.ErrorRateThreshold)
+	testhelpers.AssertEqual(t, int64(DefaultMinCalls), a.config.MinCalls)
+}
+
+func TestErrorRateAlerterSetConfig(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []string
+	)
+	newWebhook := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			received = append(received, name)
+			mu.Unlock()
+		}))
+	}
+	first, second := newWebhook("first"), newWebhook("second")
+	defer first.Close()
+	defer second.Close()
+
+	a := NewErrorRateAlerter(
+		telemetry.NewNoopCustomMetrics(), Config{WebhookURL: first.URL, MinCalls: 1}, logger.NewNop(),
+	)
+	ctx := context.Background()
+	call := func(outcome telemetry.ToolCallOutcome) {
+		a.RecordToolCall(ctx, "flaky", "tool", outcome, time.Millisecond)
+	}
+
+	// without a webhook URL, no alert is posted
+	a.SetConfig(Config{MinCalls: 1})
+	call(telemetry.ToolCallOutcomeError)
+	a.Wait()
+	testhelpers.AssertEqual(t, 0, len(received))
+
+	// the alert is resolved through the new webhook
+	a.SetConfig(Config{WebhookURL: second.URL, MinCalls: 1})
+	testhelpers.AssertEqual(t, DefaultWindow, a.config.Window)
+	call(telemetry.ToolCallOutcomeSuccess)
+	call(telemetry.ToolCallOutcomeSuccess)
+	a.Wait()
+	testhelpers.AssertEqual(t, 1, len(received))
+	testhelpers.AssertEqual(t, "second", received[0])
+
+	// a new window starts counting afresh
+	a.SetConfig(Config{WebhookURL: second.URL, MinCalls: 1, Window: time.Minute})
+	call(telemetry.ToolCallOutcomeError)
+	a.Wait()
+	testhelpers.AssertEqual(t, 2, len(received))
+}
@@ -4,6 +4,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"sync/atomic"
 
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"gorm.io/gorm"
@@ -12,6 +13,10 @@ import (
 // ServerConfigService provides methods to manage server configuration in the database.
 type ServerConfigService struct {
 	db *gorm.DB
+
+	// lastKnown is the latest initialized configuration read from the database.
+	// The configuration never changes once the server is initialized, so it is served while the database is unavailable.
+	lastKnown atomic.Pointer[model.ServerConfig]
 }
 
 func NewServerConfigService(db *gorm.DB) *ServerConfigService {
@@ -20,6 +25,7 @@ func NewServerConfigService(db *gorm.DB) *ServerConfigService {
 
 // GetConfig retrieves the server configuration from the database.
 // If no configuration exists, it returns a default uninitialized config.
+// If the database is unavailable, it returns the last known configuration of the initialized server.
 func (s *ServerConfigService) GetConfig() (model.ServerConfig, error) {
 	var config model.ServerConfig
 	err := s.db.First(&config).Error
@@ -27,8 +33,14 @@ func (s *ServerConfigService) GetConfig() (model.ServerConfig, error) {
 		return model.ServerConfig{Initialized: false}, nil
 	}
 	if err != nil {
+		if last := s.lastKnown.Load(); last != nil {
+			return *last, nil
+		}
 		return model.ServerConfig{}, fmt.Errorf("failed to fetch server configuration from db: %v", err)
 	}
+	if config.Initialized {
+		s.lastKnown.Store(&config)
+	}
 	return config, nil
 }
 
@@ -119,3 +119,45 @@ func TestInitIdempotent(t *testing.T) {
 		t.Errorf("Expected mode to remain %v, got %v", model.ModeDev, config.Mode)
 	}
 }
+
+func TestGetConfigDatabaseUnavailable(t *testing.T) {
+	db, err := testhelpers.CreateTestDB()
+	testhelpers.AssertNoError(t, err)
+	err = db.AutoMigrate(&model.ServerConfig{})
+	testhelpers.AssertNoError(t, err)
+
+	svc := NewServerConfigService(db)
+	_, err = svc.Init(model.ModeEnterprise)
+	testhelpers.AssertNoError(t, err)
+	_, err = svc.GetConfig()
+	testhelpers.AssertNoError(t, err)
+
+	sqlDB, err := db.DB()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, sqlDB.Close())
+
+	// the last known configuration is served while the database is unavailable
+	config, err := svc.GetConfig()
+	testhelpers.AssertNoError(t, err)
+	if !config.Initialized || config.Mode != model.ModeEnterprise {
+		t.Errorf("Expected the last known config, got %+v", config)
+	}
+}
+
+func TestGetConfigDatabaseUnavailableNotInitialized(t *testing.T) {
+	db, err := testhelpers.CreateTestDB()
+	testhelpers.AssertNoError(t, err)
+	err = db.AutoMigrate(&model.ServerConfig{})
+	testhelpers.AssertNoError(t, err)
+
+	svc := NewServerConfigService(db)
+	_, err = svc.GetConfig()
+	testhelpers.AssertNoError(t, err)
+
+	sqlDB, err := db.DB()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, sqlDB.Close())
+
+	_, err = svc.GetConfig()
+	testhelpers.AssertError(t, err)
+}
@@ -0,0 +1,80 @@
+// Package events provides an in-memory broker that fans out registry change events to subscribers.
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// subscriberBufferSize is the number of events that can be queued for a subscriber before
+// new events get dropped for it.
+const subscriberBufferSize = 64
+
+// Broker delivers every published registry event to all current subscribers.
+// Events are not persisted, so a subscriber only receives events published while it is subscribed.
+type Broker struct {
+	mu          sync.Mutex
+	lastID      uint64
+	subscribers map[chan types.RegistryEvent]struct{}
+
+	logger logger.Logger
+}
+
+// NewBroker creates a new Broker without any subscribers.
+func NewBroker(l logger.Logger) *Broker {
+	return &Broker{
+		subscribers: make(map[chan types.RegistryEvent]struct{}),
+		logger:      l,
+	}
+}
+
+// Publish assigns an ID & timestamp to the event and delivers it to all subscribers.
+// It never blocks. If a subscriber is too slow to keep up, the event is dropped for that subscriber.
+// It is safe to call Publish on a nil Broker, in which case the event is discarded.
+func (b *Broker) Publish(eventType types.RegistryEventType, entity string, affected []string) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	e := types.RegistryEvent{
+		ID:        b.lastID,
+		Type:      eventType,
+		Entity:    entity,
+		Affected:  affected,
+		Timestamp: time.Now(),
+	}
+	for ch := range b.subscribers {
+		select {
+		case ch <- e:
+		default:
+			b.logger.Warn("dropped registry event for a slow subscriber", logger.Any("event_id", e.ID))
+		}
+	}
+}
+
+// Subscribe registers a new subscriber and returns the channel on which it receives events.
+// The returned function must be called to unsubscribe once the subscriber is done, it closes the channel.
+func (b *Broker) Subscribe() (<-chan types.RegistryEvent, func()) {
+	ch := make(chan types.RegistryEvent, subscriberBufferSize)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+	return ch, unsubscribe
+}
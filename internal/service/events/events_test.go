@@ -0,0 +1,63 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestBrokerPublishesToAllSubscribers(t *testing.T) {
+	b := NewBroker(logger.NewNop())
+	ch1, unsubscribe1 := b.Subscribe()
+	defer unsubscribe1()
+	ch2, unsubscribe2 := b.Subscribe()
+	defer unsubscribe2()
+
+	b.Publish(types.RegistryEventToolsDisabled, "calculator__add", []string{"calculator__add"})
+	b.Publish(types.RegistryEventServerDeregistered, "calculator", nil)
+
+	for _, ch := range []<-chan types.RegistryEvent{ch1, ch2} {
+		e := <-ch
+		testhelpers.AssertEqual(t, uint64(1), e.ID)
+		testhelpers.AssertEqual(t, types.RegistryEventToolsDisabled, e.Type)
+		testhelpers.AssertEqual(t, "calculator__add", e.Entity)
+		testhelpers.AssertEqual(t, 1, len(e.Affected))
+		testhelpers.AssertFalse(t, e.Timestamp.IsZero(), "event timestamp should be set")
+
+		e = <-ch
+		testhelpers.AssertEqual(t, uint64(2), e.ID)
+		testhelpers.AssertEqual(t, types.RegistryEventServerDeregistered, e.Type)
+	}
+}
+
+func TestBrokerUnsubscribe(t *testing.T) {
+	b := NewBroker(logger.NewNop())
+	ch, unsubscribe := b.Subscribe()
+	unsubscribe()
+	// unsubscribing twice must be harmless
+	unsubscribe()
+
+	_, ok := <-ch
+	testhelpers.AssertFalse(t, ok, "channel should be closed after unsubscribing")
+
+	// publishing after the only subscriber left must not panic
+	b.Publish(types.RegistryEventToolGroupCreated, "group", nil)
+}
+
+func TestBrokerDropsEventsForSlowSubscribers(t *testing.T) {
+	b := NewBroker(logger.NewNop())
+	ch, unsubscribe := b.Subscribe()
+	defer unsubscribe()
+
+	for range subscriberBufferSize + 10 {
+		b.Publish(types.RegistryEventToolGroupUpdated, "group", nil)
+	}
+	testhelpers.AssertEqual(t, subscriberBufferSize, len(ch))
+}
+
+func TestNilBrokerPublish(t *testing.T) {
+	var b *Broker
+	b.Publish(types.RegistryEventToolGroupDeleted, "group", nil)
+}
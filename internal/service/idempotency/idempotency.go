@@ -0,0 +1,134 @@
+// Package idempotency makes the requests that change the registry safe to retry.
+// The response to a request sent with an idempotency key is recorded, and replayed when the same request
+// is sent again with the same key instead of processing it twice.
+package idempotency
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MaxKeyLength is the maximum length of an idempotency key
+const MaxKeyLength = 255
+
+// staleAfter is how long a request may be processed before its key is considered abandoned,
+// eg- because the server stopped while processing it. The key can then be taken over by a retry.
+const staleAfter = time.Minute
+
+var (
+	// ErrKeyReused is returned when a key is sent again with a different request.
+	ErrKeyReused = errors.New("the idempotency key was already used for a different request")
+	// ErrInProgress is returned when a key is sent again while the first request sent with it is being processed.
+	ErrInProgress = errors.New("a request with the same idempotency key is being processed")
+)
+
+// Request identifies a request sent with an idempotency key.
+type Request struct {
+	// Caller is the username of the user that sent the request, empty in development mode
+	Caller string
+	Key    string
+	Method string
+	Path   string
+	Body   []byte
+}
+
+// Response is a recorded response to a request.
+type Response struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// Service records the requests sent with an idempotency key and their responses.
+type Service struct {
+	db *gorm.DB
+}
+
+// NewService creates a new idempotency Service.
+func NewService(db *gorm.DB) *Service {
+	return &Service{db: db}
+}
+
+// Begin claims the key of the request before it is processed.
+// If the same request was already processed, its recorded response is returned and the request must not be
+// processed again. Otherwise, the returned record must be passed to Complete once the request is processed,
+// or to Abandon if it failed in a way that a retry may fix.
+func (s *Service) Begin(r *Request) (*model.IdempotencyKey, *Response, error) {
+	sum := sha256.Sum256(r.Body)
+	rec := &model.IdempotencyKey{
+		Key:         r.Key,
+		Caller:      r.Caller,
+		Method:      r.Method,
+		Path:        r.Path,
+		RequestHash: hex.EncodeToString(sum[:]),
+	}
+	res := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(rec)
+	if res.Error != nil {
+		return nil, nil, fmt.Errorf("failed to record idempotency key: %w", res.Error)
+	}
+	if res.RowsAffected == 1 {
+		return rec, nil, nil
+	}
+
+	var existing model.IdempotencyKey
+	err := s.db.Where(map[string]any{"caller": r.Caller, "key": r.Key}).First(&existing).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	if existing.Method != rec.Method || existing.Path != rec.Path || existing.RequestHash != rec.RequestHash {
+		return nil, nil, ErrKeyReused
+	}
+	if existing.CompletedAt != nil {
+		return nil, &Response{
+			StatusCode:  existing.StatusCode,
+			ContentType: existing.ContentType,
+			Body:        existing.ResponseBody,
+		}, nil
+	}
+
+	// the request is still being processed, unless it was abandoned
+	now := time.Now()
+	res = s.db.Model(&model.IdempotencyKey{}).
+		Where("id = ? AND completed_at IS NULL AND created_at < ?", existing.ID, now.Add(-staleAfter)).
+		Update("created_at", now)
+	if res.Error != nil {
+		return nil, nil, fmt.Errorf("failed to take over idempotency key: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return nil, nil, ErrInProgress
+	}
+	existing.CreatedAt = now
+	return &existing, nil, nil
+}
+
+// Complete records the response to the request that claimed the key,
+// so that it is replayed for the next requests sent with the same key.
+func (s *Service) Complete(rec *model.IdempotencyKey, resp *Response) error {
+	now := time.Now()
+	err := s.db.Model(rec).Updates(map[string]any{
+		"status_code":   resp.StatusCode,
+		"content_type":  resp.ContentType,
+		"response_body": resp.Body,
+		"completed_at":  now,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to record response for idempotency key: %w", err)
+	}
+	return nil
+}
+
+// Abandon releases the key claimed by a request without recording its response,
+// so that the request can be retried with the same key.
+func (s *Service) Abandon(rec *model.IdempotencyKey) error {
+	if err := s.db.Delete(rec).Error; err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
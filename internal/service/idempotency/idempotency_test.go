@@ -0,0 +1,89 @@
+package idempotency
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func newRequest(body string) *Request {
+	return &Request{Caller: "alice", Key: "key-1", Method: http.MethodPost, Path: "/api/v1/servers", Body: []byte(body)}
+}
+
+func TestBeginAndReplay(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+	s := NewService(setup.DB)
+
+	rec, recorded, err := s.Begin(newRequest(`{"name":"time"}`))
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, recorded == nil, "Expected no recorded response for a new key")
+
+	// the same request sent while the first one is processed
+	_, _, err = s.Begin(newRequest(`{"name":"time"}`))
+	testhelpers.AssertTrue(t, errors.Is(err, ErrInProgress), "Expected ErrInProgress, got "+errString(err))
+
+	resp := &Response{StatusCode: http.StatusCreated, ContentType: "application/json", Body: []byte(`{"name":"time"}`)}
+	testhelpers.AssertNoError(t, s.Complete(rec, resp))
+
+	_, recorded, err = s.Begin(newRequest(`{"name":"time"}`))
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNotNil(t, recorded)
+	testhelpers.AssertEqual(t, http.StatusCreated, recorded.StatusCode)
+	testhelpers.AssertEqual(t, "application/json", recorded.ContentType)
+	testhelpers.AssertEqual(t, `{"name":"time"}`, string(recorded.Body))
+
+	// the key can't be reused for another request
+	_, _, err = s.Begin(newRequest(`{"name":"github"}`))
+	testhelpers.AssertTrue(t, errors.Is(err, ErrKeyReused), "Expected ErrKeyReused, got "+errString(err))
+
+	// keys are scoped to their caller
+	other := newRequest(`{"name":"github"}`)
+	other.Caller = "bob"
+	_, recorded, err = s.Begin(other)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, recorded == nil, "Expected no recorded response for another caller")
+}
+
+func TestAbandon(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+	s := NewService(setup.DB)
+
+	rec, _, err := s.Begin(newRequest(`{}`))
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, s.Abandon(rec))
+
+	// the request can be retried with the same key
+	_, recorded, err := s.Begin(newRequest(`{}`))
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, recorded == nil, "Expected no recorded response after the key was abandoned")
+}
+
+func TestTakeOverStaleKey(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+	s := NewService(setup.DB)
+
+	// the server stopped while processing the request
+	_, _, err := s.Begin(newRequest(`{}`))
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, setup.DB.Model(&model.IdempotencyKey{}).
+		Where("1 = 1").Update("created_at", time.Now().Add(-2*staleAfter)).Error)
+
+	rec, recorded, err := s.Begin(newRequest(`{}`))
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, recorded == nil, "Expected no recorded response for an abandoned key")
+	testhelpers.AssertNotNil(t, rec)
+}
+
+func errString(err error) string {
+	if err == nil {
+		return "nil"
+	}
+	return err.Error()
+}
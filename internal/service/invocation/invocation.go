@@ -0,0 +1,196 @@
+// Package invocation provides functionality to keep a history of the tool calls made through mcpjungle.
+package invocation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/db"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultHistorySize is the default number of invocations retained in the history
+	DefaultHistorySize = 1000
+
+	// maxArgumentValueLength is the maximum number of characters of a string argument value that are recorded
+	maxArgumentValueLength = 256
+	// maxArgumentsLength is the maximum size in bytes of the recorded JSON-encoded arguments
+	maxArgumentsLength = 4096
+
+	redactedValue  = "[REDACTED]"
+	truncatedValue = "...(truncated)"
+)
+
+// ListFilter narrows down the invocations returned by HistoryService.List.
+// Zero-valued fields don't filter anything.
+type ListFilter struct {
+	Tool    string
+	Caller  string
+	Client  string
+	Outcome types.InvocationOutcome
+	Since   time.Time
+	Until   time.Time
+}
+
+// HistoryService records tool invocations in the database and retains only the most recent ones.
+type HistoryService struct {
+	db       *gorm.DB
+	size     int
+	redactor *redactor
+	logger   logger.Logger
+}
+
+// NewHistoryService creates a new HistoryService that retains the given number of most recent invocations.
+// A size of 0 disables the history, ie, no invocations are recorded.
+// The values of arguments matching DefaultRedactionPatterns are redacted before they're recorded.
+func NewHistoryService(db *gorm.DB, size int, l logger.Logger) *HistoryService {
+	r, _ := newRedactor(DefaultRedactionPatterns)
+	return &HistoryService{db: db, size: size, redactor: r, logger: l}
+}
+
+// AddRedactionPatterns adds patterns of argument names whose values must be redacted, on top of the defaults.
+// Patterns use shell glob syntax (eg- "*token*") and are matched case-insensitively against the argument
+// names at all levels of the arguments.
+// It must be called before any invocation is recorded.
+func (s *HistoryService) AddRedactionPatterns(patterns ...string) error {
+	return s.redactor.add(patterns...)
+}
+
+// Enabled returns true if invocations are being recorded.
+func (s *HistoryService) Enabled() bool {
+	return s.size > 0
+}
+
+// RecordToolInvocation adds a tool call to the history and removes the invocations that no longer fit in it.
+// Failures are only logged because they must never affect the tool call itself.
+func (s *HistoryService) RecordToolInvocation(
+	ctx context.Context,
+	name string,
+	args map[string]any,
+	outcome telemetry.ToolCallOutcome,
+	callErr error,
+	elapsedTime time.Duration,
+) {
+	if !s.Enabled() {
+		return
+	}
+
+	inv := &model.ToolInvocation{
+		ToolName:   name,
+		Caller:     callerFromContext(ctx),
+		Client:     clientFromContext(ctx),
+		Outcome:    types.InvocationOutcomeSuccess,
+		DurationMs: elapsedTime.Milliseconds(),
+	}
+	if outcome == telemetry.ToolCallOutcomeError {
+		inv.Outcome = types.InvocationOutcomeError
+	}
+	if callErr != nil {
+		inv.Outcome = types.InvocationOutcomeError
+		inv.Error = callErr.Error()
+	}
+	inv.Arguments, inv.ArgumentsTruncated = encodeArguments(s.redactor, args)
+
+	if err := s.db.Create(inv).Error; err != nil {
+		s.logger.Error("failed to record tool invocation", logger.String("tool", name), logger.ErrorField(err))
+		return
+	}
+	if err := s.prune(); err != nil {
+		s.logger.Error("failed to prune tool invocation history", logger.ErrorField(err))
+	}
+}
+
+// prune deletes the invocations older than the most recent ones that fit in the history.
+func (s *HistoryService) prune() error {
+	var ids []uint
+	err := s.db.Model(&model.ToolInvocation{}).
+		Order("id desc").
+		Offset(s.size).
+		Limit(1).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.db.Where("id <= ?", ids[0]).Delete(&model.ToolInvocation{}).Error
+}
+
+// List returns the given page of the recorded invocations that match the filter, most recent first,
+// along with the total number of invocations that match it.
+func (s *HistoryService) List(filter ListFilter, page types.Page) ([]model.ToolInvocation, int64, error) {
+	q := s.db.Model(&model.ToolInvocation{})
+	if filter.Tool != "" {
+		q = q.Where("tool_name = ?", filter.Tool)
+	}
+	if filter.Caller != "" {
+		q = q.Where("caller = ?", filter.Caller)
+	}
+	if filter.Client != "" {
+		q = q.Where("client = ?", filter.Client)
+	}
+	if filter.Outcome != "" {
+		q = q.Where("outcome = ?", filter.Outcome)
+	}
+	if !filter.Since.IsZero() {
+		q = q.Where("created_at >= ?", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		q = q.Where("created_at <= ?", filter.Until)
+	}
+
+	var invocations []model.ToolInvocation
+	total, err := db.FindPage(q.Order("id desc"), page, &invocations)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list tool invocations: %w", err)
+	}
+	return invocations, total, nil
+}
+
+// callerFromContext returns the name of the MCP client or user that made the request,
+// or an empty string if the caller is not authenticated (development mode).
+func callerFromContext(ctx context.Context) string {
+	if c := clientFromContext(ctx); c != "" {
+		return c
+	}
+	if u, ok := ctx.Value("user").(*model.User); ok && u != nil {
+		return u.Username
+	}
+	return ""
+}
+
+// clientFromContext returns the name of the MCP client that made the request,
+// or an empty string if the request wasn't made by an authenticated MCP client.
+func clientFromContext(ctx context.Context) string {
+	if c, ok := ctx.Value("client").(*model.McpClient); ok && c != nil {
+		return c.Name
+	}
+	return ""
+}
+
+// encodeArguments returns the JSON encoding of the tool call arguments with sensitive values redacted and
+// long values truncated, so that the history neither leaks secrets nor grows unbounded.
+// It also returns true if the encoding had to be cut short.
+func encodeArguments(r *redactor, args map[string]any) (string, bool) {
+	if len(args) == 0 {
+		return "", false
+	}
+	data, err := json.Marshal(r.sanitize(args))
+	if err != nil {
+		return "", false
+	}
+	if len(data) > maxArgumentsLength {
+		// the cut may split a multibyte character, which must not end up in the text column
+		return strings.ToValidUTF8(string(data[:maxArgumentsLength]), "") + truncatedValue, true
+	}
+	return string(data), false
+}
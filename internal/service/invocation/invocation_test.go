@@ -0,0 +1,160 @@
+package invocation
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestRecordToolInvocation(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	s := NewHistoryService(setup.DB, 10, logger.NewNop())
+
+	ctx := context.WithValue(context.Background(), "client", &model.McpClient{Name: "cursor"})
+	args := map[string]any{
+		"query":    "select 1",
+		"password": "hunter2",
+		"options":  map[string]any{"api_key": "abc", "limit": 5},
+	}
+	s.RecordToolInvocation(ctx, "db__query", args, telemetry.ToolCallOutcomeSuccess, nil, 1500*time.Millisecond)
+	s.RecordToolInvocation(
+		context.Background(), "db__query", nil, telemetry.ToolCallOutcomeError, errors.New("boom"), time.Second,
+	)
+
+	invocations, _, err := s.List(ListFilter{}, types.Page{})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 2, len(invocations))
+
+	// most recent first
+	failed := invocations[0]
+	testhelpers.AssertEqual(t, types.InvocationOutcomeError, failed.Outcome)
+	testhelpers.AssertEqual(t, "boom", failed.Error)
+	testhelpers.AssertEqual(t, "", failed.Caller)
+	testhelpers.AssertEqual(t, "", failed.Arguments)
+
+	succeeded := invocations[1]
+	testhelpers.AssertEqual(t, "db__query", succeeded.ToolName)
+	testhelpers.AssertEqual(t, "cursor", succeeded.Caller)
+	testhelpers.AssertEqual(t, "cursor", succeeded.Client)
+	testhelpers.AssertEqual(t, types.InvocationOutcomeSuccess, succeeded.Outcome)
+	testhelpers.AssertEqual(t, int64(1500), succeeded.DurationMs)
+	testhelpers.AssertEqual(
+		t,
+		`{"options":{"api_key":"[REDACTED]","limit":5},"password":"[REDACTED]","query":"select 1"}`,
+		succeeded.Arguments,
+	)
+	testhelpers.AssertFalse(t, succeeded.ArgumentsTruncated, "Expected arguments not to be truncated")
+}
+
+func TestRecordToolInvocationDisabled(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	s := NewHistoryService(setup.DB, 0, logger.NewNop())
+	s.RecordToolInvocation(context.Background(), "db__query", nil, telemetry.ToolCallOutcomeSuccess, nil, time.Second)
+
+	invocations, _, err := s.List(ListFilter{}, types.Page{})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 0, len(invocations))
+}
+
+func TestHistoryIsPruned(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	s := NewHistoryService(setup.DB, 3, logger.NewNop())
+	for _, name := range []string{"a__1", "a__2", "a__3", "a__4", "a__5"} {
+		s.RecordToolInvocation(context.Background(), name, nil, telemetry.ToolCallOutcomeSuccess, nil, time.Millisecond)
+	}
+
+	invocations, _, err := s.List(ListFilter{}, types.Page{})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 3, len(invocations))
+	testhelpers.AssertEqual(t, "a__5", invocations[0].ToolName)
+	testhelpers.AssertEqual(t, "a__3", invocations[2].ToolName)
+}
+
+func TestListFilters(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	s := NewHistoryService(setup.DB, 10, logger.NewNop())
+	alice := context.WithValue(context.Background(), "user", &model.User{Username: "alice"})
+	s.RecordToolInvocation(alice, "git__commit", nil, telemetry.ToolCallOutcomeSuccess, nil, time.Millisecond)
+	s.RecordToolInvocation(alice, "git__push", nil, telemetry.ToolCallOutcomeError, nil, time.Millisecond)
+	s.RecordToolInvocation(context.Background(), "git__push", nil, telemetry.ToolCallOutcomeSuccess, nil, time.Millisecond)
+	cursor := context.WithValue(context.Background(), "client", &model.McpClient{Name: "cursor"})
+	s.RecordToolInvocation(cursor, "git__push", nil, telemetry.ToolCallOutcomeSuccess, nil, time.Millisecond)
+
+	count := func(filter ListFilter) int {
+		invocations, total, err := s.List(filter, types.Page{})
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, int64(len(invocations)), total)
+		return len(invocations)
+	}
+	testhelpers.AssertEqual(t, 3, count(ListFilter{Tool: "git__push"}))
+	testhelpers.AssertEqual(t, 2, count(ListFilter{Caller: "alice"}))
+	testhelpers.AssertEqual(t, 0, count(ListFilter{Client: "alice"}))
+	testhelpers.AssertEqual(t, 1, count(ListFilter{Client: "cursor"}))
+	testhelpers.AssertEqual(t, 1, count(ListFilter{Caller: "alice", Outcome: types.InvocationOutcomeError}))
+	testhelpers.AssertEqual(t, 4, count(ListFilter{Since: time.Now().Add(-time.Minute)}))
+	testhelpers.AssertEqual(t, 0, count(ListFilter{Since: time.Now().Add(time.Minute)}))
+	testhelpers.AssertEqual(t, 0, count(ListFilter{Until: time.Now().Add(-time.Minute)}))
+
+	// the total counts all the matching invocations, not just the page of them
+	invocations, total, err := s.List(ListFilter{Tool: "git__push"}, types.Page{Offset: 2, Limit: 1})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, int64(3), total)
+	testhelpers.AssertEqual(t, 1, len(invocations))
+	testhelpers.AssertEqual(t, "alice", invocations[0].Caller)
+}
+
+func TestAddRedactionPatterns(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	s := NewHistoryService(setup.DB, 10, logger.NewNop())
+	testhelpers.AssertNoError(t, s.AddRedactionPatterns("ssn", "*_PIN"))
+	testhelpers.AssertTrue(t, s.AddRedactionPatterns("[") != nil, "Expected an error for a malformed pattern")
+
+	args := map[string]any{"ssn": "123-45-6789", "card_pin": "1234", "name": "alice", "token": "abc"}
+	s.RecordToolInvocation(context.Background(), "bank__pay", args, telemetry.ToolCallOutcomeSuccess, nil, time.Millisecond)
+
+	invocations, _, err := s.List(ListFilter{}, types.Page{})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 1, len(invocations))
+	testhelpers.AssertEqual(
+		t,
+		`{"card_pin":"[REDACTED]","name":"alice","ssn":"[REDACTED]","token":"[REDACTED]"}`,
+		invocations[0].Arguments,
+	)
+}
+
+func TestEncodeArguments(t *testing.T) {
+	r, err := newRedactor(DefaultRedactionPatterns)
+	testhelpers.AssertNoError(t, err)
+
+	long := strings.Repeat("x", maxArgumentValueLength+10)
+	encoded, truncated := encodeArguments(r, map[string]any{"text": long, "items": []any{long}})
+	testhelpers.AssertFalse(t, truncated, "Expected long values to be shortened without truncating the arguments")
+	testhelpers.AssertStringContains(t, encoded, strings.Repeat("x", maxArgumentValueLength)+truncatedValue)
+	testhelpers.AssertFalse(t, strings.Contains(encoded, long), "Expected long values to be shortened")
+
+	many := make(map[string]any)
+	for i := range 100 {
+		many[strings.Repeat("k", i+1)] = strings.Repeat("v", 100)
+	}
+	encoded, truncated = encodeArguments(r, many)
+	testhelpers.AssertTrue(t, truncated, "Expected large arguments to be truncated")
+	testhelpers.AssertEqual(t, maxArgumentsLength+len(truncatedValue), len(encoded))
+}
@@ -0,0 +1,82 @@
+package invocation
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// DefaultRedactionPatterns are the patterns of argument names whose values are never recorded.
+var DefaultRedactionPatterns = []string{
+	"*password*", "*passwd*", "*secret*", "*token*", "*apikey*", "*api_key*", "*api-key*",
+	"*authorization*", "*credential*", "*private_key*", "*privatekey*", "*cookie*",
+}
+
+// redactor redacts the values of arguments whose names match any of its patterns.
+// Patterns use shell glob syntax (eg- "*token*") and are matched case-insensitively.
+type redactor struct {
+	patterns []string
+}
+
+func newRedactor(patterns []string) (*redactor, error) {
+	r := &redactor{}
+	if err := r.add(patterns...); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// add adds the given patterns to the redaction rules.
+func (r *redactor) add(patterns ...string) error {
+	for _, p := range patterns {
+		p = strings.ToLower(strings.TrimSpace(p))
+		if p == "" {
+			continue
+		}
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid redaction pattern '%s': %w", p, err)
+		}
+		r.patterns = append(r.patterns, p)
+	}
+	return nil
+}
+
+// isSensitive returns true if the value of the argument with the given name must be redacted.
+func (r *redactor) isSensitive(name string) bool {
+	name = strings.ToLower(name)
+	for _, p := range r.patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitize returns a copy of the value with sensitive map entries redacted and long strings truncated.
+func (r *redactor) sanitize(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, val := range v {
+			if r.isSensitive(k) {
+				out[k] = redactedValue
+				continue
+			}
+			out[k] = r.sanitize(val)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, val := range v {
+			out[i] = r.sanitize(val)
+		}
+		return out
+	case string:
+		if s := []rune(v); len(s) > maxArgumentValueLength {
+			return string(s[:maxArgumentValueLength]) + truncatedValue
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,22 @@
+package invocation
+
+import (
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestRedactorIsSensitive(t *testing.T) {
+	r, err := newRedactor(append([]string{" Session? "}, DefaultRedactionPatterns...))
+	testhelpers.AssertNoError(t, err)
+
+	for _, name := range []string{"password", "DB_PASSWORD", "githubToken", "x-api-key", "sessions"} {
+		testhelpers.AssertTrue(t, r.isSensitive(name), "Expected "+name+" to be redacted")
+	}
+	for _, name := range []string{"query", "session", "path", "limit"} {
+		testhelpers.AssertFalse(t, r.isSensitive(name), "Expected "+name+" not to be redacted")
+	}
+
+	_, err = newRedactor([]string{"[a-"})
+	testhelpers.AssertTrue(t, err != nil, "Expected an error for a malformed pattern")
+}
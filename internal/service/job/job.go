@@ -0,0 +1,176 @@
+// Package job provides functionality to run tool invocations asynchronously in the background.
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+var ErrJobNotFound = errors.New("job not found")
+
+// interruptedJobError is recorded for jobs that were still in progress when the server stopped.
+const interruptedJobError = "job was interrupted because the mcpjungle server stopped before it completed"
+
+// DefaultTimeout is how long a tool invocation job may run before it is cancelled.
+const DefaultTimeout = time.Hour
+
+// leaseGrace is how long a job may go without an update past its timeout before it is considered interrupted.
+// It leaves the server that runs the job enough time to record its outcome once the timeout expires.
+const leaseGrace = time.Minute
+
+// ToolInvoker calls a tool provided by an upstream MCP server.
+type ToolInvoker interface {
+	InvokeTool(ctx context.Context, name string, args map[string]any) (*types.ToolInvokeResult, error)
+}
+
+// JobService runs tool invocations in the background and persists their status & result.
+type JobService struct {
+	db      *gorm.DB
+	invoker ToolInvoker
+	timeout time.Duration
+	logger  logger.Logger
+
+	// wg tracks the jobs currently running in this process
+	wg sync.WaitGroup
+}
+
+// NewJobService creates a new JobService.
+// Each job is cancelled if it runs for longer than timeout.
+//
+// Several servers may share the database, so a job that is still in progress may be running on another one.
+// A job is only marked as failed once it has gone without an update for longer than it may run,
+// which means that the server running it stopped before it completed.
+func NewJobService(db *gorm.DB, invoker ToolInvoker, timeout time.Duration, l logger.Logger) (*JobService, error) {
+	s := &JobService{db: db, invoker: invoker, timeout: timeout, logger: l}
+	if err := s.failInterruptedJobs(s.db.Model(&model.ToolInvocationJob{})); err != nil {
+		return nil, fmt.Errorf("failed to clean up interrupted jobs: %w", err)
+	}
+	return s, nil
+}
+
+// failInterruptedJobs marks the jobs selected by query that are still in progress past their lease as failed.
+func (s *JobService) failInterruptedJobs(query *gorm.DB) error {
+	return query.
+		Where("status IN ?", []types.JobStatus{types.JobStatusPending, types.JobStatusRunning}).
+		Where("updated_at < ?", s.leaseCutoff()).
+		Updates(map[string]any{
+			"status":       types.JobStatusFailed,
+			"error":        interruptedJobError,
+			"completed_at": time.Now(),
+		}).Error
+}
+
+// StartToolInvocation creates a new job and invokes the tool in the background.
+// It returns as soon as the job is persisted, without waiting for the tool call.
+// createdBy is the username of the user starting the job, empty in development mode.
+func (s *JobService) StartToolInvocation(
+	ctx context.Context, name string, args map[string]any, createdBy string,
+) (*model.ToolInvocationJob, error) {
+	j := &model.ToolInvocationJob{
+		JobID:     uuid.NewString(),
+		ToolName:  name,
+		Status:    types.JobStatusPending,
+		CreatedBy: createdBy,
+	}
+	if err := s.db.Create(j).Error; err != nil {
+		return nil, fmt.Errorf("failed to create job: %w", err)
+	}
+
+	// the job must outlive the request that started it, but it should still carry the request's values
+	s.wg.Add(1)
+	go s.run(context.WithoutCancel(ctx), j.JobID, name, args)
+
+	return j, nil
+}
+
+// GetJob returns the job with the given ID.
+// It returns ErrJobNotFound if no such job exists.
+func (s *JobService) GetJob(id string) (*model.ToolInvocationJob, error) {
+	j, err := s.getJob(id)
+	if err != nil {
+		return nil, err
+	}
+	inProgress := j.Status == types.JobStatusPending || j.Status == types.JobStatusRunning
+	if inProgress && j.UpdatedAt.Before(s.leaseCutoff()) {
+		// the server running the job stopped after this one started
+		if err := s.failInterruptedJobs(s.db.Model(&model.ToolInvocationJob{}).Where("job_id = ?", id)); err != nil {
+			return nil, err
+		}
+		return s.getJob(id)
+	}
+	return j, nil
+}
+
+// leaseCutoff returns the time before which a job that is still in progress must have been interrupted.
+func (s *JobService) leaseCutoff() time.Time {
+	return time.Now().Add(-(s.timeout + leaseGrace))
+}
+
+func (s *JobService) getJob(id string) (*model.ToolInvocationJob, error) {
+	var j model.ToolInvocationJob
+	if err := s.db.Where("job_id = ?", id).First(&j).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrJobNotFound
+		}
+		return nil, err
+	}
+	return &j, nil
+}
+
+// Wait blocks until all jobs started by this service have completed.
+func (s *JobService) Wait() {
+	s.wg.Wait()
+}
+
+func (s *JobService) run(ctx context.Context, jobID, name string, args map[string]any) {
+	defer s.wg.Done()
+
+	s.update(jobID, map[string]any{
+		"status":     types.JobStatusRunning,
+		"started_at": time.Now(),
+	})
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	updates := map[string]any{}
+	result, err := s.invoker.InvokeTool(ctx, name, args)
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = fmt.Errorf("job timed out after %s: %w", s.timeout, err)
+	}
+	if err == nil {
+		encoded, marshalErr := json.Marshal(result)
+		if marshalErr != nil {
+			err = fmt.Errorf("failed to encode tool result: %w", marshalErr)
+		} else {
+			updates["result"] = datatypes.JSON(encoded)
+		}
+	}
+	if err != nil {
+		updates["status"] = types.JobStatusFailed
+		updates["error"] = err.Error()
+	} else {
+		updates["status"] = types.JobStatusSucceeded
+	}
+	updates["completed_at"] = time.Now()
+
+	s.update(jobID, updates)
+}
+
+func (s *JobService) update(jobID string, updates map[string]any) {
+	err := s.db.Model(&model.ToolInvocationJob{}).Where("job_id = ?", jobID).Updates(updates).Error
+	if err != nil {
+		s.logger.Error("failed to update job", logger.String("job_id", jobID), logger.ErrorField(err))
+	}
+}
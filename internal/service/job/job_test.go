@@ -0,0 +1,150 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+type fakeInvoker struct {
+	result *types.ToolInvokeResult
+	err    error
+}
+
+func (f *fakeInvoker) InvokeTool(ctx context.Context, name string, args map[string]any) (*types.ToolInvokeResult, error) {
+	return f.result, f.err
+}
+
+// blockingInvoker never returns until the tool call is cancelled.
+type blockingInvoker struct{}
+
+func (blockingInvoker) InvokeTool(ctx context.Context, name string, args map[string]any) (*types.ToolInvokeResult, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestStartToolInvocation(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	invoker := &fakeInvoker{
+		result: &types.ToolInvokeResult{Content: []map[string]any{{"type": "text", "text": "done"}}},
+	}
+	s, err := NewJobService(setup.DB, invoker, DefaultTimeout, logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+
+	j, err := s.StartToolInvocation(context.Background(), "slow__report", map[string]any{"x": 1}, "alice")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, j.JobID != "", "Expected job ID to be generated")
+	testhelpers.AssertEqual(t, types.JobStatusPending, j.Status)
+
+	s.Wait()
+
+	got, err := s.GetJob(j.JobID)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, types.JobStatusSucceeded, got.Status)
+	testhelpers.AssertEqual(t, "slow__report", got.ToolName)
+	testhelpers.AssertEqual(t, "alice", got.CreatedBy)
+	testhelpers.AssertNotNil(t, got.StartedAt)
+	testhelpers.AssertNotNil(t, got.CompletedAt)
+
+	result, err := got.GetResult()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, "done", result.Content[0]["text"])
+}
+
+func TestStartToolInvocationFailure(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	s, err := NewJobService(setup.DB, &fakeInvoker{err: errors.New("upstream unreachable")}, DefaultTimeout, logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+
+	j, err := s.StartToolInvocation(context.Background(), "slow__report", nil, "")
+	testhelpers.AssertNoError(t, err)
+	s.Wait()
+
+	got, err := s.GetJob(j.JobID)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, types.JobStatusFailed, got.Status)
+	testhelpers.AssertEqual(t, "upstream unreachable", got.Error)
+
+	result, err := got.GetResult()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, result == nil, "Expected no result for a failed job")
+}
+
+func TestGetJobNotFound(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	s, err := NewJobService(setup.DB, &fakeInvoker{}, DefaultTimeout, logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+
+	_, err = s.GetJob("does-not-exist")
+	testhelpers.AssertTrue(t, errors.Is(err, ErrJobNotFound), "Expected ErrJobNotFound")
+}
+
+func TestNewJobServiceFailsInterruptedJobs(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	stale := time.Now().Add(-(DefaultTimeout + 2*leaseGrace))
+	for id, status := range map[string]types.JobStatus{
+		"pending":   types.JobStatusPending,
+		"running":   types.JobStatusRunning,
+		"succeeded": types.JobStatusSucceeded,
+	} {
+		j := &model.ToolInvocationJob{JobID: id, ToolName: "slow__report", Status: status}
+		j.UpdatedAt = stale
+		testhelpers.AssertNoError(t, setup.DB.Create(j).Error)
+	}
+	// a job updated recently may still be running on another server sharing the database
+	live := &model.ToolInvocationJob{JobID: "live", ToolName: "slow__report", Status: types.JobStatusRunning}
+	testhelpers.AssertNoError(t, setup.DB.Create(live).Error)
+
+	s, err := NewJobService(setup.DB, &fakeInvoker{}, DefaultTimeout, logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+
+	for id, expected := range map[string]types.JobStatus{
+		"pending":   types.JobStatusFailed,
+		"running":   types.JobStatusFailed,
+		"succeeded": types.JobStatusSucceeded,
+		"live":      types.JobStatusRunning,
+	} {
+		got, err := s.GetJob(id)
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, expected, got.Status)
+	}
+
+	// the server running the job stops without completing it
+	testhelpers.AssertNoError(t, setup.DB.Model(live).UpdateColumn("updated_at", stale).Error)
+	got, err := s.GetJob("live")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, types.JobStatusFailed, got.Status)
+	testhelpers.AssertEqual(t, interruptedJobError, got.Error)
+}
+
+func TestJobTimeout(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	s, err := NewJobService(setup.DB, blockingInvoker{}, 10*time.Millisecond, logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+
+	j, err := s.StartToolInvocation(context.Background(), "slow__report", nil, "")
+	testhelpers.AssertNoError(t, err)
+	s.Wait()
+
+	got, err := s.GetJob(j.JobID)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, types.JobStatusFailed, got.Status)
+	testhelpers.AssertTrue(t, strings.Contains(got.Error, "job timed out after 10ms"), "Expected a timeout error, got: "+got.Error)
+}
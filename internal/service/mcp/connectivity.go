@@ -0,0 +1,111 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// serverTestTimeout is the maximum time allowed for all the steps of an MCP server connectivity test
+const serverTestTimeout = 30 * time.Second
+
+// TestMcpServer checks that mcpjungle can work with the given MCP server, which doesn't need to be registered:
+// it opens a session (initialize), lists the server's tools and optionally calls one of them.
+// The tool must be annotated as read-only, so that a test never modifies any data.
+// Failures are reported in the returned report rather than as an error.
+func (m *MCPService) TestMcpServer(
+	ctx context.Context, s *model.McpServer, toolName string, args map[string]any,
+) *types.ServerTestReport {
+	report := &types.ServerTestReport{Server: s.Name}
+	ctx, cancel := context.WithTimeout(ctx, serverTestTimeout)
+	defer cancel()
+
+	// step runs f as a named step of the test and returns whether it passed
+	step := func(name string, f func() (detail string, err error)) bool {
+		started := time.Now()
+		detail, err := f()
+		st := types.ServerTestStep{Name: name, DurationMs: time.Since(started).Milliseconds(), Detail: detail}
+		if err != nil {
+			st.Error = err.Error()
+		} else {
+			st.Passed = true
+		}
+		report.Steps = append(report.Steps, st)
+		return st.Passed
+	}
+	defer func() {
+		report.Passed = report.Steps[len(report.Steps)-1].Passed
+	}()
+
+	var session *client.Client
+	ok := step("initialize", func() (string, error) {
+		var err error
+		session, err = m.newMcpServerSession(ctx, s)
+		return "", err
+	})
+	if !ok {
+		return report
+	}
+	defer session.Close()
+
+	var tools []mcp.Tool
+	ok = step("list tools", func() (string, error) {
+		resp, err := session.ListTools(ctx, mcp.ListToolsRequest{})
+		if err != nil {
+			return "", err
+		}
+		tools = resp.Tools
+		return fmt.Sprintf("%d tools", len(tools)), nil
+	})
+	if !ok || toolName == "" {
+		return report
+	}
+
+	step("call tool "+toolName, func() (string, error) {
+		var tool *mcp.Tool
+		for i := range tools {
+			if tools[i].Name == toolName {
+				tool = &tools[i]
+				break
+			}
+		}
+		if tool == nil {
+			return "", fmt.Errorf("the server has no tool named %s", toolName)
+		}
+		a := tool.Annotations
+		if a.ReadOnlyHint == nil || !*a.ReadOnlyHint || (a.DestructiveHint != nil && *a.DestructiveHint) {
+			return "", fmt.Errorf("tool %s is not annotated as read-only, only read-only tools can be called by a test", toolName)
+		}
+
+		req := mcp.CallToolRequest{}
+		req.Params.Name = toolName
+		req.Params.Arguments = args
+		req.Params.Meta = withRequestIDMeta(ctx, nil)
+		resp, err := session.CallTool(ctx, req)
+		if err != nil {
+			return "", err
+		}
+		if resp.IsError {
+			return "", fmt.Errorf("the tool returned an error: %s", toolResultText(resp))
+		}
+		return fmt.Sprintf("%d content items", len(resp.Content)), nil
+	})
+	return report
+}
+
+// toolResultText returns the text content of a tool call result, which describes the error of a failed call.
+func toolResultText(resp *mcp.CallToolResult) string {
+	var texts []string
+	for _, c := range resp.Content {
+		if t, ok := mcp.AsTextContent(c); ok {
+			texts = append(texts, t.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
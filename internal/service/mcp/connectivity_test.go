@@ -0,0 +1,88 @@
+package mcp
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestTestMcpServer(t *testing.T) {
+	upstream := server.NewMCPServer("time", "test")
+	upstream.AddTool(
+		mcp.NewTool("get_current_time", mcp.WithReadOnlyHintAnnotation(true), mcp.WithDestructiveHintAnnotation(false)),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("12:00"), nil
+		},
+	)
+	upstream.AddTool(
+		mcp.NewTool("set_timezone", mcp.WithReadOnlyHintAnnotation(false)),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			t.Error("a tool that isn't read-only must never be called by a test")
+			return mcp.NewToolResultText("done"), nil
+		},
+	)
+	upstream.AddTool(
+		mcp.NewTool("broken", mcp.WithReadOnlyHintAnnotation(true), mcp.WithDestructiveHintAnnotation(false)),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultError("clock is broken"), nil
+		},
+	)
+	ts := httptest.NewServer(server.NewStreamableHTTPServer(upstream))
+	defer ts.Close()
+
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+	proxy := server.NewMCPServer("proxy", "test")
+	mcpService, err := NewMCPService(setup.DB, proxy, proxy, telemetry.NewNoopCustomMetrics(), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+
+	// the server doesn't need to be registered
+	s, err := model.NewStreamableHTTPServer("time", "", ts.URL+"/mcp", "")
+	testhelpers.AssertNoError(t, err)
+
+	t.Run("initialize and list tools", func(t *testing.T) {
+		report := mcpService.TestMcpServer(context.Background(), s, "", nil)
+		testhelpers.AssertTrue(t, report.Passed, "expected the test to pass")
+		testhelpers.AssertEqual(t, 2, len(report.Steps))
+		testhelpers.AssertEqual(t, "initialize", report.Steps[0].Name)
+		testhelpers.AssertEqual(t, "3 tools", report.Steps[1].Detail)
+	})
+
+	t.Run("read-only tool call", func(t *testing.T) {
+		report := mcpService.TestMcpServer(context.Background(), s, "get_current_time", nil)
+		testhelpers.AssertTrue(t, report.Passed, "expected the test to pass")
+		testhelpers.AssertEqual(t, 3, len(report.Steps))
+		testhelpers.AssertEqual(t, "call tool get_current_time", report.Steps[2].Name)
+	})
+
+	t.Run("failing tool calls", func(t *testing.T) {
+		tests := map[string]string{
+			"set_timezone": "not annotated as read-only",
+			"broken":       "the tool returned an error: clock is broken",
+			"missing":      "the server has no tool named missing",
+		}
+		for tool, expected := range tests {
+			report := mcpService.TestMcpServer(context.Background(), s, tool, nil)
+			testhelpers.AssertFalse(t, report.Passed, "expected the test to fail")
+			testhelpers.AssertStringContains(t, report.Steps[2].Error, expected)
+		}
+	})
+
+	t.Run("unreachable server", func(t *testing.T) {
+		down, err := model.NewStreamableHTTPServer("down", "", "http://127.0.0.1:1/mcp", "")
+		testhelpers.AssertNoError(t, err)
+
+		report := mcpService.TestMcpServer(context.Background(), down, "", nil)
+		testhelpers.AssertFalse(t, report.Passed, "expected the test to fail")
+		// the test stops at the first failed step
+		testhelpers.AssertEqual(t, 1, len(report.Steps))
+		testhelpers.AssertTrue(t, report.Steps[0].Error != "", "expected an error")
+	})
+}
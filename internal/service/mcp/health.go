@@ -0,0 +1,106 @@
+package mcp
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// serverHealthCheckTimeout is the maximum time allowed to connect to and ping an upstream MCP server
+const serverHealthCheckTimeout = 10 * time.Second
+
+// serverHealthCacheTTL is how long the health of the upstream MCP servers is reused after checking it,
+// so that frequent requests for it don't open a session with every upstream server each time.
+const serverHealthCacheTTL = 15 * time.Second
+
+// CheckServersHealth connects to every registered MCP server and pings it.
+// All servers are checked concurrently and the results are returned in the order the servers were registered.
+// The results are reused for serverHealthCacheTTL, and concurrent calls wait for a single check to complete.
+func (m *MCPService) CheckServersHealth(ctx context.Context) ([]types.UpstreamServerHealth, error) {
+	m.serverHealthMu.Lock()
+	defer m.serverHealthMu.Unlock()
+	if m.serverHealth != nil && time.Since(m.serverHealthCheckedAt) < serverHealthCacheTTL {
+		return slices.Clone(m.serverHealth), nil
+	}
+
+	servers, err := m.ListMcpServers()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]types.UpstreamServerHealth, len(servers))
+	var wg sync.WaitGroup
+	for i := range servers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = m.checkServerHealth(ctx, &servers[i])
+		}(i)
+	}
+	wg.Wait()
+
+	// the results of a check cut short by the caller don't reflect the health of the servers
+	if ctx.Err() == nil {
+		m.serverHealth = results
+		m.serverHealthCheckedAt = time.Now()
+	}
+	return slices.Clone(results), nil
+}
+
+// checkServerHealth opens a new session with the given MCP server and pings it.
+func (m *MCPService) checkServerHealth(ctx context.Context, s *model.McpServer) (result types.UpstreamServerHealth) {
+	result.Name = s.Name
+	result.Transport = string(s.Transport)
+
+	ctx, cancel := context.WithTimeout(ctx, serverHealthCheckTimeout)
+	defer cancel()
+
+	started := time.Now()
+	defer func() {
+		result.LatencyMs = time.Since(started).Milliseconds()
+	}()
+
+	c, err := m.newMcpServerSession(ctx, s)
+	if err != nil {
+		result.Error = err.Error()
+		result.ConsecutiveFailures = m.upstreamConsecutiveFailures(s.Name)
+		return result
+	}
+	defer c.Close()
+
+	if err := c.Ping(ctx); err != nil {
+		result.Error = "ping failed: " + err.Error()
+		result.ConsecutiveFailures = m.recordUpstreamHealth(ctx, s.Name, err)
+		return result
+	}
+	result.Healthy = true
+	return result
+}
+
+// recordUpstreamHealth records the outcome of an attempt to reach an upstream MCP server,
+// be it to check its health, register it or forward a call to it.
+// It returns the number of consecutive failed attempts, which is reset by a successful one.
+func (m *MCPService) recordUpstreamHealth(ctx context.Context, serverName string, err error) int64 {
+	m.upstreamFailuresMu.Lock()
+	if err == nil {
+		delete(m.upstreamFailures, serverName)
+	} else {
+		m.upstreamFailures[serverName]++
+	}
+	failures := m.upstreamFailures[serverName]
+	m.upstreamFailuresMu.Unlock()
+
+	m.metrics.RecordUpstreamServerHealth(ctx, serverName, err == nil, failures)
+	return failures
+}
+
+// upstreamConsecutiveFailures returns the number of consecutive failed attempts to reach an upstream MCP server.
+func (m *MCPService) upstreamConsecutiveFailures(serverName string) int64 {
+	m.upstreamFailuresMu.Lock()
+	defer m.upstreamFailuresMu.Unlock()
+	return m.upstreamFailures[serverName]
+}
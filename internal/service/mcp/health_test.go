@@ -0,0 +1,87 @@
+package mcp
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestCheckServersHealth(t *testing.T) {
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	upstream := httptest.NewServer(server.NewStreamableHTTPServer(server.NewMCPServer("upstream", "test")))
+	defer upstream.Close()
+
+	setup.CreateTestMcpServer(
+		"healthy", "", types.TransportStreamableHTTP, []byte(`{"url": "`+upstream.URL+`"}`),
+	)
+	setup.CreateTestMcpServer(
+		"broken", "", types.TransportStdio, []byte(`{"command": "mcpjungle-nonexistent-command"}`),
+	)
+
+	metrics := &upstreamHealthMetrics{reachable: make(map[string]bool), failures: make(map[string]int64)}
+	m := &MCPService{
+		db:               setup.DB,
+		upstreamFailures: make(map[string]int64),
+		metrics:          metrics,
+		logger:           logger.NewNop(),
+	}
+	results, err := m.CheckServersHealth(context.Background())
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 2, len(results))
+
+	testhelpers.AssertEqual(t, "healthy", results[0].Name)
+	testhelpers.AssertEqual(t, string(types.TransportStreamableHTTP), results[0].Transport)
+	testhelpers.AssertTrue(t, results[0].Healthy, "Expected server to be healthy: "+results[0].Error)
+	testhelpers.AssertEqual(t, "", results[0].Error)
+
+	testhelpers.AssertEqual(t, "broken", results[1].Name)
+	testhelpers.AssertFalse(t, results[1].Healthy, "Expected server to be unhealthy")
+	testhelpers.AssertTrue(t, results[1].Error != "", "Expected an error describing why the server is unhealthy")
+	testhelpers.AssertEqual(t, int64(1), results[1].ConsecutiveFailures)
+
+	// the servers are not checked again while the results are fresh
+	results, err = m.CheckServersHealth(context.Background())
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, int64(1), results[1].ConsecutiveFailures)
+
+	// failures accumulate until the server is reachable again
+	m.serverHealthCheckedAt = time.Now().Add(-serverHealthCacheTTL)
+	results, err = m.CheckServersHealth(context.Background())
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, int64(0), results[0].ConsecutiveFailures)
+	testhelpers.AssertEqual(t, int64(2), results[1].ConsecutiveFailures)
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	testhelpers.AssertTrue(t, metrics.reachable["healthy"], "Expected the healthy server to be recorded as reachable")
+	testhelpers.AssertFalse(t, metrics.reachable["broken"], "Expected the broken server to be recorded as unreachable")
+	testhelpers.AssertEqual(t, int64(0), metrics.failures["healthy"])
+	testhelpers.AssertEqual(t, int64(2), metrics.failures["broken"])
+}
+
+// upstreamHealthMetrics keeps the latest health recorded for every upstream server
+type upstreamHealthMetrics struct {
+	telemetry.NoopCustomMetrics
+	mu        sync.Mutex
+	reachable map[string]bool
+	failures  map[string]int64
+}
+
+func (m *upstreamHealthMetrics) RecordUpstreamServerHealth(
+	_ context.Context, serverName string, reachable bool, consecutiveFailures int64,
+) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reachable[serverName] = reachable
+	m.failures[serverName] = consecutiveFailures
+}
@@ -2,13 +2,19 @@
 package mcp
 
 import (
+	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
 )
 
 // MCPService coordinates operations amongst the registry database, mcp proxy server and upstream MCP servers.
@@ -22,6 +28,14 @@ type MCPService struct {
 	// toolInstances keeps track of all the in-memory mcp.Tool instances, keyed by their unique names.
 	toolInstances map[string]mcp.Tool
 	mu            sync.RWMutex
+	// toolsVersion is incremented whenever the tool instances change
+	toolsVersion atomic.Uint64
+
+	// toolLoader tracks the servers whose tools are not loaded yet, if tools are loaded lazily (see WithLazyToolLoading)
+	toolLoader *toolLoader
+
+	// servers caches the details of the MCP servers read from the database for the tool & prompt calls.
+	servers *serverCache
 
 	// toolDeletionCallback is a callback that gets invoked when one or more tools is removed
 	// (deregistered or disabled) from mcpjungle.
@@ -29,17 +43,50 @@ type MCPService struct {
 	// toolAdditionCallback is a callback that gets invoked when one or more tools is added
 	// (registered or (re)enabled) in mcpjungle.
 	toolAdditionCallback ToolAdditionCallback
+	// toolInvocationCallback is a callback that gets invoked after every tool call made through mcpjungle.
+	toolInvocationCallback ToolInvocationCallback
+
+	// slowToolCallThreshold is the duration above which a tool call is logged & counted as slow.
+	// A value of 0 disables the detection of slow tool calls.
+	slowToolCallThreshold time.Duration
+
+	// upstreamFailures counts the consecutive failed attempts to reach each upstream MCP server, keyed by server name.
+	// Servers that were reachable on the latest attempt have no entry.
+	upstreamFailures   map[string]int64
+	upstreamFailuresMu sync.Mutex
+
+	// serverHealth is the health of the upstream MCP servers found by the latest check, made at serverHealthCheckedAt.
+	// It is reused by the checks made within serverHealthCacheTTL of it.
+	serverHealth          []types.UpstreamServerHealth
+	serverHealthCheckedAt time.Time
+	serverHealthMu        sync.Mutex
+
+	// sessions keeps the sessions opened with the upstream MCP servers by the tool & prompt calls,
+	// so that they can be reused by the following calls.
+	sessions *sessionPool
+	// stdioProcesses bounds the processes run for the stdio MCP servers
+	stdioProcesses *stdioLimiter
 
 	metrics telemetry.CustomMetrics
+	logger  logger.Logger
+}
+
+// primary returns the database to read from in the code paths that change the registry or the MCP proxy servers.
+// Their reads must not see the stale data of a lagging read replica, so they always go to the primary database.
+func (m *MCPService) primary() *gorm.DB {
+	return m.db.Clauses(dbresolver.Write).Session(&gorm.Session{})
 }
 
 // NewMCPService creates a new instance of MCPService.
-// It initializes the MCP proxy server by loading all registered tools from the database.
+// It initializes the MCP proxy server by loading all registered tools from the database,
+// unless they are loaded lazily (see WithLazyToolLoading).
 func NewMCPService(
 	db *gorm.DB,
 	mcpProxyServer *server.MCPServer,
 	sseMcpProxyServer *server.MCPServer,
 	metrics telemetry.CustomMetrics,
+	l logger.Logger,
+	opts ...Option,
 ) (*MCPService, error) {
 	s := &MCPService{
 		db: db,
@@ -52,9 +99,23 @@ func NewMCPService(
 
 		// initialize the callbacks to NOOP functions
 		toolDeletionCallback: func(toolNames ...string) {},
-		toolAdditionCallback: func(toolName string) error { return nil },
+		toolAdditionCallback: func(toolNames ...string) error { return nil },
+		toolInvocationCallback: func(
+			ctx context.Context, name string, args map[string]any,
+			outcome telemetry.ToolCallOutcome, err error, elapsedTime time.Duration,
+		) {
+		},
+
+		upstreamFailures: make(map[string]int64),
+		servers:          newServerCache(DefaultServerCacheTTL),
+		stdioProcesses:   newStdioLimiter(DefaultStdioLimits()),
 
 		metrics: metrics,
+		logger:  l,
+	}
+	s.sessions = newSessionPool(DefaultSessionPoolConfig(), s.newMcpServerSession)
+	for _, opt := range opts {
+		opt(s)
 	}
 	if err := s.initMCPProxyServer(); err != nil {
 		return nil, fmt.Errorf("failed to initialize MCP proxy server: %w", err)
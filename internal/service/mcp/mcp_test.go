@@ -6,6 +6,7 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
 	"gorm.io/gorm"
 )
@@ -37,7 +38,7 @@ func TestNewMCPService(t *testing.T) {
 				db = tt.db
 			}
 
-			mcpService, err := NewMCPService(db, tt.mcpProxyServer, tt.mcpProxyServer, telemetry.NewNoopCustomMetrics())
+			mcpService, err := NewMCPService(db, tt.mcpProxyServer, tt.mcpProxyServer, telemetry.NewNoopCustomMetrics(), logger.NewNop())
 
 			if tt.expectError {
 				testhelpers.AssertError(t, err)
@@ -67,7 +68,7 @@ func TestMCPServiceInitialization(t *testing.T) {
 
 	proxyServer := &server.MCPServer{}
 
-	mcpService, err := NewMCPService(setup.DB, proxyServer, proxyServer, telemetry.NewNoopCustomMetrics())
+	mcpService, err := NewMCPService(setup.DB, proxyServer, proxyServer, telemetry.NewNoopCustomMetrics(), logger.NewNop())
 	testhelpers.AssertNoError(t, err)
 	testhelpers.AssertNotNil(t, mcpService)
 
@@ -99,7 +100,7 @@ func TestMCPServiceCallbacks(t *testing.T) {
 
 	proxyServer := &server.MCPServer{}
 
-	mcpService, err := NewMCPService(db, proxyServer, proxyServer, telemetry.NewNoopCustomMetrics())
+	mcpService, err := NewMCPService(db, proxyServer, proxyServer, telemetry.NewNoopCustomMetrics(), logger.NewNop())
 	testhelpers.AssertNoError(t, err)
 
 	// Test that callbacks are initialized to NOOP functions
@@ -127,7 +128,7 @@ func TestMCPServiceConcurrency(t *testing.T) {
 
 	proxyServer := &server.MCPServer{}
 
-	mcpService, err := NewMCPService(db, proxyServer, proxyServer, telemetry.NewNoopCustomMetrics())
+	mcpService, err := NewMCPService(db, proxyServer, proxyServer, telemetry.NewNoopCustomMetrics(), logger.NewNop())
 	testhelpers.AssertNoError(t, err)
 
 	// Test that the service can handle concurrent access to toolInstances
@@ -163,7 +164,7 @@ func TestMCPServiceToolInstances(t *testing.T) {
 
 	proxyServer := &server.MCPServer{}
 
-	mcpService, err := NewMCPService(db, proxyServer, proxyServer, telemetry.NewNoopCustomMetrics())
+	mcpService, err := NewMCPService(db, proxyServer, proxyServer, telemetry.NewNoopCustomMetrics(), logger.NewNop())
 	testhelpers.AssertNoError(t, err)
 
 	// Test that toolInstances map is properly initialized
@@ -198,7 +199,7 @@ func TestMCPServiceErrorHandling(t *testing.T) {
 
 	proxyServer := &server.MCPServer{}
 
-	mcpService, err := NewMCPService(db, proxyServer, proxyServer, telemetry.NewNoopCustomMetrics())
+	mcpService, err := NewMCPService(db, proxyServer, proxyServer, telemetry.NewNoopCustomMetrics(), logger.NewNop())
 	testhelpers.AssertNoError(t, err)
 	testhelpers.AssertNotNil(t, mcpService)
 
@@ -209,3 +210,38 @@ func TestMCPServiceErrorHandling(t *testing.T) {
 		t.Error("Expected toolInstances to be initialized")
 	}
 }
+
+func TestGetMcpServerForCallDatabaseUnavailable(t *testing.T) {
+	db, err := testhelpers.CreateTestDB()
+	testhelpers.AssertNoError(t, err)
+	err = db.AutoMigrate(&model.McpServer{}, &model.Tool{}, &model.Prompt{})
+	testhelpers.AssertNoError(t, err)
+
+	for _, name := range []string{"github", "unseen"} {
+		s, err := model.NewStreamableHTTPServer(name, "", "http://127.0.0.1:1/mcp", "")
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertNoError(t, db.Create(s).Error)
+	}
+
+	proxyServer := server.NewMCPServer("proxy", "test")
+	mcpService, err := NewMCPService(db, proxyServer, proxyServer, telemetry.NewNoopCustomMetrics(), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+
+	s, err := mcpService.getMcpServerForCall("github")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, "github", s.Name)
+	_, err = mcpService.getMcpServerForCall("missing")
+	testhelpers.AssertError(t, err)
+
+	sqlDB, err := db.DB()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, sqlDB.Close())
+
+	// the last known details of the server are used while the database is unavailable
+	s, err = mcpService.getMcpServerForCall("github")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, "github", s.Name)
+
+	_, err = mcpService.getMcpServerForCall("unseen")
+	testhelpers.AssertError(t, err)
+}
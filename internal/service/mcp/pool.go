@@ -0,0 +1,297 @@
+package mcp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+)
+
+// sessionHealthCheckTimeout is the maximum time an idle session may take to respond to a ping before it is reused
+const sessionHealthCheckTimeout = 5 * time.Second
+
+// SessionPoolConfig controls how the sessions opened with upstream MCP servers are reused by the tool & prompt calls.
+// Opening a session requires a handshake with the upstream server (and starting a process for stdio servers),
+// which often takes longer than the call itself.
+type SessionPoolConfig struct {
+	// MaxSessionsPerServer caps the number of sessions open with an upstream MCP server, whether in use or idle.
+	// A call waits for a session to be released once the cap is reached.
+	// 0 disables pooling, so every call opens its own session and closes it once done.
+	MaxSessionsPerServer int
+	// IdleTimeout is how long an unused session is kept open before it is closed.
+	IdleTimeout time.Duration
+	// HealthCheckInterval is how long a session may be idle before it is pinged to check that it still works
+	// before it is reused. A session that doesn't respond is replaced by a new one.
+	HealthCheckInterval time.Duration
+}
+
+// DefaultSessionPoolConfig returns the default configuration of the upstream session pool.
+func DefaultSessionPoolConfig() SessionPoolConfig {
+	return SessionPoolConfig{
+		MaxSessionsPerServer: 8,
+		IdleTimeout:          5 * time.Minute,
+		HealthCheckInterval:  30 * time.Second,
+	}
+}
+
+// upstreamSession is a session with an upstream MCP server, used by a single call at a time.
+type upstreamSession struct {
+	*client.Client
+
+	server string
+	// fingerprint identifies the configuration of the server the session was opened with
+	fingerprint string
+	lastUsed    time.Time
+	idleTimer   *time.Timer
+
+	mu sync.Mutex
+	// notificationHandler receives the notifications sent by the server during the current call
+	notificationHandler func(n mcp.JSONRPCNotification)
+}
+
+// setNotificationHandler makes h receive the notifications sent by the upstream server until the session is released.
+func (s *upstreamSession) setNotificationHandler(h func(n mcp.JSONRPCNotification)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notificationHandler = h
+}
+
+func (s *upstreamSession) handleNotification(n mcp.JSONRPCNotification) {
+	s.mu.Lock()
+	h := s.notificationHandler
+	s.mu.Unlock()
+	if h != nil {
+		h(n)
+	}
+}
+
+// serverSessions are the sessions open with an upstream MCP server.
+type serverSessions struct {
+	// slots limits the number of sessions open, it holds a value for every session in use or being opened
+	slots chan struct{}
+	// fingerprint identifies the latest known configuration of the server,
+	// sessions opened with an older configuration are not reused
+	fingerprint string
+	// idle are the open sessions that are not in use, the most recently used last
+	idle []*upstreamSession
+}
+
+// sessionPool keeps the sessions opened with upstream MCP servers, so that they can be reused across calls.
+type sessionPool struct {
+	config SessionPoolConfig
+	// dial opens a new session with an upstream MCP server
+	dial func(ctx context.Context, s *model.McpServer) (*client.Client, error)
+
+	mu      sync.Mutex
+	servers map[string]*serverSessions
+	closed  bool
+}
+
+func newSessionPool(
+	config SessionPoolConfig, dial func(ctx context.Context, s *model.McpServer) (*client.Client, error),
+) *sessionPool {
+	return &sessionPool{config: config, dial: dial, servers: make(map[string]*serverSessions)}
+}
+
+// serverFingerprint identifies the configuration of an MCP server,
+// it changes whenever the server is updated or registered again.
+func serverFingerprint(s *model.McpServer) string {
+	h := sha256.New()
+	h.Write([]byte(s.Transport))
+	h.Write([]byte{0})
+	h.Write(s.Config)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// acquire returns a session with the given MCP server for the exclusive use of the caller, who must release it.
+// An idle session is reused if there is one, otherwise a new session is opened.
+func (p *sessionPool) acquire(ctx context.Context, s *model.McpServer) (*upstreamSession, error) {
+	if p.config.MaxSessionsPerServer <= 0 {
+		return p.open(ctx, s, serverFingerprint(s), false)
+	}
+
+	fingerprint := serverFingerprint(s)
+	p.mu.Lock()
+	sessions, stale := p.serverSessions(s.Name, fingerprint)
+	p.mu.Unlock()
+	closeAll(stale)
+
+	select {
+	case sessions.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	for {
+		p.mu.Lock()
+		n := len(sessions.idle)
+		if n == 0 {
+			p.mu.Unlock()
+			break
+		}
+		session := sessions.idle[n-1]
+		sessions.idle = sessions.idle[:n-1]
+		session.idleTimer.Stop()
+		p.mu.Unlock()
+
+		if time.Since(session.lastUsed) < p.config.HealthCheckInterval || p.healthy(ctx, session) {
+			return session, nil
+		}
+		_ = session.Close()
+	}
+
+	session, err := p.open(ctx, s, fingerprint, true)
+	if err != nil {
+		<-sessions.slots
+		return nil, err
+	}
+	return session, nil
+}
+
+// serverSessions returns the sessions of the given server, along with the idle ones opened with an older
+// configuration which must be closed. It must be called with p.mu held.
+func (p *sessionPool) serverSessions(name, fingerprint string) (*serverSessions, []*upstreamSession) {
+	sessions, ok := p.servers[name]
+	if !ok {
+		sessions = &serverSessions{slots: make(chan struct{}, p.config.MaxSessionsPerServer), fingerprint: fingerprint}
+		p.servers[name] = sessions
+	}
+	if sessions.fingerprint == fingerprint {
+		return sessions, nil
+	}
+	sessions.fingerprint = fingerprint
+	return sessions, takeIdle(sessions)
+}
+
+// open opens a new session with the server.
+// A pooled session outlives the call that opened it, so it must not be bound to the call's context.
+func (p *sessionPool) open(ctx context.Context, s *model.McpServer, fingerprint string, pooled bool) (*upstreamSession, error) {
+	if pooled {
+		ctx = context.WithoutCancel(ctx)
+	}
+	c, err := p.dial(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	session := &upstreamSession{Client: c, server: s.Name, fingerprint: fingerprint}
+	c.OnNotification(session.handleNotification)
+	return session, nil
+}
+
+// healthy returns true if the session responds to a ping.
+func (p *sessionPool) healthy(ctx context.Context, session *upstreamSession) bool {
+	ctx, cancel := context.WithTimeout(ctx, sessionHealthCheckTimeout)
+	defer cancel()
+	return session.Ping(ctx) == nil
+}
+
+// release gives a session back to the pool once the caller is done with it.
+// The session is closed instead of being reused if the call failed, since the session may be broken,
+// or if the server's configuration changed in the meantime.
+func (p *sessionPool) release(session *upstreamSession, callErr error) {
+	session.setNotificationHandler(nil)
+	if p.config.MaxSessionsPerServer <= 0 {
+		_ = session.Close()
+		return
+	}
+
+	p.mu.Lock()
+	sessions, ok := p.servers[session.server]
+	reuse := ok && !p.closed && callErr == nil && sessions.fingerprint == session.fingerprint
+	if reuse {
+		session.lastUsed = time.Now()
+		session.idleTimer = time.AfterFunc(p.config.IdleTimeout, func() { p.expire(session) })
+		sessions.idle = append(sessions.idle, session)
+	}
+	p.mu.Unlock()
+
+	if !reuse {
+		_ = session.Close()
+	}
+	if ok {
+		<-sessions.slots
+	}
+}
+
+// expire closes a session that stayed idle for too long, unless it was acquired in the meantime.
+func (p *sessionPool) expire(session *upstreamSession) {
+	p.mu.Lock()
+	sessions, ok := p.servers[session.server]
+	found := false
+	if ok {
+		for i, s := range sessions.idle {
+			if s == session {
+				sessions.idle = append(sessions.idle[:i], sessions.idle[i+1:]...)
+				found = true
+				break
+			}
+		}
+	}
+	p.mu.Unlock()
+
+	if found {
+		_ = session.Close()
+	}
+}
+
+// drop closes the idle sessions of the given server, eg- because it was deregistered.
+// The sessions in use are closed when they are released.
+func (p *sessionPool) drop(name string) {
+	p.mu.Lock()
+	sessions, ok := p.servers[name]
+	var idle []*upstreamSession
+	if ok {
+		idle = takeIdle(sessions)
+		// sessions released later don't match any configuration, so they are closed
+		sessions.fingerprint = ""
+	}
+	p.mu.Unlock()
+	closeAll(idle)
+}
+
+// close closes all the idle sessions, and the sessions in use as soon as they are released.
+func (p *sessionPool) close() {
+	p.mu.Lock()
+	p.closed = true
+	var idle []*upstreamSession
+	for _, sessions := range p.servers {
+		idle = append(idle, takeIdle(sessions)...)
+	}
+	p.mu.Unlock()
+	closeAll(idle)
+}
+
+// takeIdle removes the idle sessions of a server from the pool and returns them.
+// It must be called with the pool's mutex held.
+func takeIdle(sessions *serverSessions) []*upstreamSession {
+	idle := sessions.idle
+	for _, s := range idle {
+		s.idleTimer.Stop()
+	}
+	sessions.idle = nil
+	return idle
+}
+
+func closeAll(sessions []*upstreamSession) {
+	for _, s := range sessions {
+		_ = s.Close()
+	}
+}
+
+// SetSessionPoolConfig changes how the sessions with upstream MCP servers are reused by the tool & prompt calls.
+// It must be called before the service starts serving calls, since the sessions pooled so far are closed.
+func (m *MCPService) SetSessionPoolConfig(config SessionPoolConfig) {
+	m.sessions.close()
+	m.sessions = newSessionPool(config, m.newMcpServerSession)
+}
+
+// CloseSessions closes the sessions kept open with upstream MCP servers.
+// Sessions still in use are closed as soon as their calls complete, and no session is reused afterwards.
+func (m *MCPService) CloseSessions() {
+	m.sessions.close()
+}
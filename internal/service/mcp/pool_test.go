@@ -0,0 +1,219 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// newTestSessionPool returns a pool of sessions with an upstream MCP server, and the number of sessions it opened.
+func newTestSessionPool(t *testing.T, config SessionPoolConfig) (*sessionPool, *model.McpServer, *atomic.Int32) {
+	s, err := model.NewStreamableHTTPServer("time", "", newUpstreamServer(t, "get_time"), "")
+	testhelpers.AssertNoError(t, err)
+
+	var dials atomic.Int32
+	pool := newSessionPool(config, func(ctx context.Context, s *model.McpServer) (*client.Client, error) {
+		dials.Add(1)
+		return createHTTPMcpServerConn(ctx, s)
+	})
+	t.Cleanup(pool.close)
+	return pool, s, &dials
+}
+
+func TestSessionPool(t *testing.T) {
+	config := SessionPoolConfig{MaxSessionsPerServer: 2, IdleTimeout: time.Minute, HealthCheckInterval: time.Minute}
+
+	t.Run("reuses sessions", func(t *testing.T) {
+		pool, s, dials := newTestSessionPool(t, config)
+
+		first, err := pool.acquire(context.Background(), s)
+		testhelpers.AssertNoError(t, err)
+		pool.release(first, nil)
+		second, err := pool.acquire(context.Background(), s)
+		testhelpers.AssertNoError(t, err)
+		defer pool.release(second, nil)
+
+		testhelpers.AssertTrue(t, first == second, "expected the idle session to be reused")
+		testhelpers.AssertEqual(t, int32(1), dials.Load())
+		_, err = second.CallTool(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "get_time"}})
+		testhelpers.AssertNoError(t, err)
+	})
+
+	t.Run("failed call discards the session", func(t *testing.T) {
+		pool, s, dials := newTestSessionPool(t, config)
+
+		session, err := pool.acquire(context.Background(), s)
+		testhelpers.AssertNoError(t, err)
+		pool.release(session, errors.New("connection reset"))
+		session, err = pool.acquire(context.Background(), s)
+		testhelpers.AssertNoError(t, err)
+		defer pool.release(session, nil)
+
+		testhelpers.AssertEqual(t, int32(2), dials.Load())
+	})
+
+	t.Run("caps the sessions per server", func(t *testing.T) {
+		pool, s, _ := newTestSessionPool(t, config)
+
+		first, err := pool.acquire(context.Background(), s)
+		testhelpers.AssertNoError(t, err)
+		second, err := pool.acquire(context.Background(), s)
+		testhelpers.AssertNoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err = pool.acquire(ctx, s)
+		testhelpers.AssertTrue(t, errors.Is(err, context.DeadlineExceeded), "expected the call to wait for a session")
+
+		pool.release(first, nil)
+		third, err := pool.acquire(context.Background(), s)
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertTrue(t, first == third, "expected the released session to be reused")
+		pool.release(second, nil)
+		pool.release(third, nil)
+	})
+
+	t.Run("idle sessions expire", func(t *testing.T) {
+		conf := config
+		conf.IdleTimeout = 10 * time.Millisecond
+		pool, s, dials := newTestSessionPool(t, conf)
+
+		session, err := pool.acquire(context.Background(), s)
+		testhelpers.AssertNoError(t, err)
+		pool.release(session, nil)
+
+		deadline := time.Now().Add(time.Second)
+		for idleSessions(pool, s.Name) > 0 && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+		testhelpers.AssertEqual(t, 0, idleSessions(pool, s.Name))
+
+		session, err = pool.acquire(context.Background(), s)
+		testhelpers.AssertNoError(t, err)
+		defer pool.release(session, nil)
+		testhelpers.AssertEqual(t, int32(2), dials.Load())
+	})
+
+	t.Run("broken idle session is replaced", func(t *testing.T) {
+		conf := config
+		conf.HealthCheckInterval = 0
+		pool, s, dials := newTestSessionPool(t, conf)
+
+		broken, err := pool.acquire(context.Background(), s)
+		testhelpers.AssertNoError(t, err)
+		pool.release(broken, nil)
+		_ = broken.Close()
+
+		session, err := pool.acquire(context.Background(), s)
+		testhelpers.AssertNoError(t, err)
+		defer pool.release(session, nil)
+		testhelpers.AssertTrue(t, session != broken, "expected a new session")
+		testhelpers.AssertEqual(t, int32(2), dials.Load())
+	})
+
+	t.Run("configuration change", func(t *testing.T) {
+		pool, s, dials := newTestSessionPool(t, config)
+
+		old, err := pool.acquire(context.Background(), s)
+		testhelpers.AssertNoError(t, err)
+		pool.release(old, nil)
+
+		updated, err := model.NewStreamableHTTPServer("time", "", newUpstreamServer(t, "get_time"), "")
+		testhelpers.AssertNoError(t, err)
+		session, err := pool.acquire(context.Background(), updated)
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertTrue(t, session != old, "expected a session with the new configuration")
+		testhelpers.AssertEqual(t, int32(2), dials.Load())
+		pool.release(session, nil)
+	})
+
+	t.Run("dropped server", func(t *testing.T) {
+		pool, s, _ := newTestSessionPool(t, config)
+
+		idle, err := pool.acquire(context.Background(), s)
+		testhelpers.AssertNoError(t, err)
+		inUse, err := pool.acquire(context.Background(), s)
+		testhelpers.AssertNoError(t, err)
+		pool.release(idle, nil)
+
+		pool.drop(s.Name)
+		testhelpers.AssertEqual(t, 0, idleSessions(pool, s.Name))
+		// the session in use when the server was dropped is not reused either
+		pool.release(inUse, nil)
+		testhelpers.AssertEqual(t, 0, idleSessions(pool, s.Name))
+	})
+
+	t.Run("pooling disabled", func(t *testing.T) {
+		pool, s, dials := newTestSessionPool(t, SessionPoolConfig{})
+
+		for range 2 {
+			session, err := pool.acquire(context.Background(), s)
+			testhelpers.AssertNoError(t, err)
+			pool.release(session, nil)
+		}
+		testhelpers.AssertEqual(t, int32(2), dials.Load())
+	})
+}
+
+func idleSessions(pool *sessionPool, name string) int {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	if sessions, ok := pool.servers[name]; ok {
+		return len(sessions.idle)
+	}
+	return 0
+}
+
+func TestInvokeToolReusesSessions(t *testing.T) {
+	var initializations atomic.Int32
+	hooks := &server.Hooks{}
+	hooks.AddAfterInitialize(func(ctx context.Context, id any, req *mcp.InitializeRequest, res *mcp.InitializeResult) {
+		initializations.Add(1)
+	})
+	upstreamServer := server.NewMCPServer("upstream", "test", server.WithHooks(hooks))
+	upstreamServer.AddTool(mcp.NewTool("echo"), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("hello"), nil
+	})
+	upstream := httptest.NewServer(server.NewStreamableHTTPServer(upstreamServer))
+	defer upstream.Close()
+
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+	setup.CreateTestMcpServer("upstream", "", types.TransportStreamableHTTP, []byte(`{"url": "`+upstream.URL+`"}`))
+
+	m, err := NewMCPService(
+		setup.DB,
+		server.NewMCPServer("proxy", "test"),
+		server.NewMCPServer("sse proxy", "test"),
+		telemetry.NewNoopCustomMetrics(),
+		logger.NewNop(),
+	)
+	testhelpers.AssertNoError(t, err)
+	defer m.CloseSessions()
+
+	for range 3 {
+		_, err := m.InvokeTool(context.Background(), "upstream__echo", nil)
+		testhelpers.AssertNoError(t, err)
+	}
+	testhelpers.AssertEqual(t, int32(1), initializations.Load())
+
+	// without pooling, every call goes through the handshake
+	m.SetSessionPoolConfig(SessionPoolConfig{})
+	for range 2 {
+		_, err := m.InvokeTool(context.Background(), "upstream__echo", nil)
+		testhelpers.AssertNoError(t, err)
+	}
+	testhelpers.AssertEqual(t, int32(3), initializations.Load())
+}
@@ -0,0 +1,78 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// progressNotificationMethod is the method of the notifications MCP servers send to report the progress of a request
+const progressNotificationMethod = "notifications/progress"
+
+// ProgressHandler receives the progress notifications an upstream MCP server sends while it serves a tool call.
+// It may be called concurrently with the tool call, but never after InvokeTool returns.
+type ProgressHandler func(p types.ToolProgress)
+
+type progressHandlerKey struct{}
+
+// WithProgressHandler returns a context which makes InvokeTool ask the upstream MCP server for progress notifications
+// and pass them to h.
+func WithProgressHandler(ctx context.Context, h ProgressHandler) context.Context {
+	return context.WithValue(ctx, progressHandlerKey{}, h)
+}
+
+func progressHandlerFromContext(ctx context.Context) ProgressHandler {
+	h, _ := ctx.Value(progressHandlerKey{}).(ProgressHandler)
+	return h
+}
+
+// newProgressToken returns a token that identifies the progress notifications of a single tool call.
+func newProgressToken() mcp.ProgressToken {
+	return "mcpjungle-" + uuid.NewString()
+}
+
+// onProgress registers h to receive the progress notifications with the given token sent on the upstream session,
+// until the session is released.
+func onProgress(session *upstreamSession, token mcp.ProgressToken, h func(params map[string]any)) {
+	session.setNotificationHandler(func(n mcp.JSONRPCNotification) {
+		if n.Method != progressNotificationMethod {
+			return
+		}
+		params := n.Params.AdditionalFields
+		// tokens are either strings or numbers, which may have been decoded into a different numeric type
+		if fmt.Sprint(params["progressToken"]) != fmt.Sprint(token) {
+			return
+		}
+		h(params)
+	})
+}
+
+// toToolProgress converts the params of a progress notification to a ToolProgress.
+func toToolProgress(params map[string]any) types.ToolProgress {
+	p := types.ToolProgress{}
+	p.Progress, _ = params["progress"].(float64)
+	p.Total, _ = params["total"].(float64)
+	p.Message, _ = params["message"].(string)
+	return p
+}
+
+// forwardProgressToClient relays the progress notifications of an upstream tool call to the downstream MCP client
+// that made the call through the proxy, if it asked for them by giving a progress token.
+func forwardProgressToClient(ctx context.Context, session *upstreamSession, meta *mcp.Meta) {
+	if meta == nil || meta.ProgressToken == nil {
+		return
+	}
+	srv := server.ServerFromContext(ctx)
+	if srv == nil {
+		return
+	}
+	// the downstream & upstream tokens are the same since the request's meta is forwarded as-is
+	onProgress(session, meta.ProgressToken, func(params map[string]any) {
+		// best-effort, the client may have gone away in the meantime
+		_ = srv.SendNotificationToClient(ctx, progressNotificationMethod, params)
+	})
+}
@@ -0,0 +1,29 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestToToolProgress(t *testing.T) {
+	p := toToolProgress(map[string]any{"progressToken": "abc", "progress": 3.0, "total": 10.0, "message": "indexing"})
+	testhelpers.AssertEqual(t, types.ToolProgress{Progress: 3, Total: 10, Message: "indexing"}, p)
+
+	// total & message are optional
+	p = toToolProgress(map[string]any{"progressToken": "abc", "progress": 1.0})
+	testhelpers.AssertEqual(t, types.ToolProgress{Progress: 1}, p)
+}
+
+func TestWithProgressHandler(t *testing.T) {
+	testhelpers.AssertTrue(t, progressHandlerFromContext(context.Background()) == nil, "expected no handler")
+
+	var got []types.ToolProgress
+	ctx := WithProgressHandler(context.Background(), func(p types.ToolProgress) { got = append(got, p) })
+	h := progressHandlerFromContext(ctx)
+	testhelpers.AssertNotNil(t, h)
+	h(types.ToolProgress{Progress: 1})
+	testhelpers.AssertEqual(t, 1, len(got))
+}
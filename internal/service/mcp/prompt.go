@@ -4,44 +4,54 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 
-	"github.com/mark3labs/mcp-go/client"
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/db"
 	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/gorm"
 )
 
 // ListPrompts returns all prompts registered in the registry.
 func (m *MCPService) ListPrompts() ([]model.Prompt, error) {
+	return m.listPrompts(m.db)
+}
+
+func (m *MCPService) listPrompts(db *gorm.DB) ([]model.Prompt, error) {
+	// the servers are fetched along with their prompts, so that listing the prompts takes a single query
 	var prompts []model.Prompt
-	if err := m.db.Find(&prompts).Error; err != nil {
+	if err := db.Joins("Server").Order("prompts.id").Find(&prompts).Error; err != nil {
 		return nil, err
 	}
 	// prepend server name to prompt names to ensure we only return the unique names of prompts to user
 	for i := range prompts {
-		var s model.McpServer
-		if err := m.db.First(&s, "id = ?", prompts[i].ServerID).Error; err != nil {
-			return nil, fmt.Errorf("failed to get server for prompt %s: %w", prompts[i].Name, err)
+		if prompts[i].Server.ID == 0 {
+			return nil, fmt.Errorf("failed to get server for prompt %s: %w", prompts[i].Name, gorm.ErrRecordNotFound)
 		}
-		prompts[i].Name = mergeServerPromptNames(s.Name, prompts[i].Name)
+		prompts[i].Name = mergeServerPromptNames(prompts[i].Server.Name, prompts[i].Name)
 	}
 	return prompts, nil
 }
 
 // ListPromptsByServer fetches prompts provided by an MCP server from the registry.
 func (m *MCPService) ListPromptsByServer(name string) ([]model.Prompt, error) {
+	return m.listPromptsByServer(m.db, name)
+}
+
+func (m *MCPService) listPromptsByServer(db *gorm.DB, name string) ([]model.Prompt, error) {
 	if err := validateServerName(name); err != nil {
 		return nil, err
 	}
 
-	s, err := m.GetMcpServer(name)
+	s, err := m.getMcpServer(db, name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get MCP server %s from DB: %w", name, err)
 	}
 
 	var prompts []model.Prompt
-	if err := m.db.Where("server_id = ?", s.ID).Find(&prompts).Error; err != nil {
+	if err := db.Where("server_id = ?", s.ID).Order("id").Find(&prompts).Error; err != nil {
 		return nil, fmt.Errorf("failed to get prompts for server %s from DB: %w", name, err)
 	}
 
@@ -53,6 +63,35 @@ func (m *MCPService) ListPromptsByServer(name string) ([]model.Prompt, error) {
 	return prompts, nil
 }
 
+// ListPromptsPage returns the given page of the prompts in the registry, along with the total number of prompts.
+// If server is not empty, only the prompts provided by that MCP server are listed.
+func (m *MCPService) ListPromptsPage(server string, page types.Page) ([]model.Prompt, int64, error) {
+	q := m.db.Joins("Server").Order("prompts.id")
+	if server != "" {
+		if err := validateServerName(server); err != nil {
+			return nil, 0, err
+		}
+		s, err := m.getMcpServer(m.db, server)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get MCP server %s from DB: %w", server, err)
+		}
+		q = q.Where("prompts.server_id = ?", s.ID)
+	}
+
+	var prompts []model.Prompt
+	total, err := db.FindPage(q, page, &prompts)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range prompts {
+		if prompts[i].Server.ID == 0 {
+			return nil, 0, fmt.Errorf("failed to get server for prompt %s: %w", prompts[i].Name, gorm.ErrRecordNotFound)
+		}
+		prompts[i].Name = mergeServerPromptNames(prompts[i].Server.Name, prompts[i].Name)
+	}
+	return prompts, total, nil
+}
+
 // GetPrompt fetches a prompt from the database by its canonical name.
 func (m *MCPService) GetPrompt(name string) (*model.Prompt, error) {
 	serverName, promptName, ok := splitServerPromptName(name)
@@ -75,12 +114,17 @@ func (m *MCPService) GetPrompt(name string) (*model.Prompt, error) {
 }
 
 // GetPromptWithArgs retrieves a prompt with provided arguments and returns the rendered template.
-func (m *MCPService) GetPromptWithArgs(ctx context.Context, name string, args map[string]any) (*types.PromptResult, error) {
+func (m *MCPService) GetPromptWithArgs(
+	ctx context.Context, name string, args map[string]any,
+) (result *types.PromptResult, err error) {
 	serverName, promptName, ok := splitServerPromptName(name)
 	if !ok {
 		return nil, fmt.Errorf("invalid input: prompt name does not contain a %s separator", serverPromptNameSep)
 	}
 
+	ctx, span := startPromptSpan(ctx, serverName, promptName)
+	defer func() { endSpan(span, err) }()
+
 	serverModel, err := m.GetMcpServer(serverName)
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -90,11 +134,11 @@ func (m *MCPService) GetPromptWithArgs(ctx context.Context, name string, args ma
 		)
 	}
 
-	mcpClient, err := newMcpServerSession(ctx, serverModel)
+	session, err := m.sessions.acquire(ctx, serverModel)
 	if err != nil {
 		return nil, err
 	}
-	defer mcpClient.Close()
+	defer func() { m.sessions.release(session, err) }()
 
 	getPromptReq := mcp.GetPromptRequest{}
 	getPromptReq.Params.Name = promptName
@@ -113,7 +157,7 @@ func (m *MCPService) GetPromptWithArgs(ctx context.Context, name string, args ma
 	}
 	getPromptReq.Params.Arguments = stringArgs
 
-	getPromptResp, err := mcpClient.GetPrompt(ctx, getPromptReq)
+	getPromptResp, err := session.GetPrompt(ctx, getPromptReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get prompt %s from MCP server %s: %w", promptName, serverName, err)
 	}
@@ -138,7 +182,7 @@ func (m *MCPService) GetPromptWithArgs(ctx context.Context, name string, args ma
 
 	metaMap := m.convertMCPMetaToMap(getPromptResp.Meta)
 
-	result := &types.PromptResult{
+	result = &types.PromptResult{
 		Description: getPromptResp.Description,
 		Messages:    messages,
 		Meta:        metaMap,
@@ -165,16 +209,17 @@ func (m *MCPService) DisablePrompts(entity string) ([]string, error) {
 // setPromptsEnabled does the heavy lifting of enabling or disabling one or more prompts.
 func (m *MCPService) setPromptsEnabled(entity string, enabled bool) ([]string, error) {
 	serverName, promptName, ok := splitServerPromptName(entity)
+	db := m.primary()
 	if ok {
 		// splitting was successful, so the entity is a prompt name
 		// only this prompt needs to be enabled/disabled
-		s, err := m.GetMcpServer(serverName)
+		s, err := m.getMcpServer(db, serverName)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get MCP server %s: %w", serverName, err)
 		}
 
 		var prompt model.Prompt
-		if err := m.db.Where("server_id = ? AND name = ?", s.ID, promptName).First(&prompt).Error; err != nil {
+		if err := db.Where("server_id = ? AND name = ?", s.ID, promptName).First(&prompt).Error; err != nil {
 			return nil, fmt.Errorf("failed to get prompt %s: %w", entity, err)
 		}
 
@@ -215,13 +260,13 @@ func (m *MCPService) setPromptsEnabled(entity string, enabled bool) ([]string, e
 
 	// splitting was unsuccessful, so the entity is a server name
 	// all prompts of this server need to be enabled/disabled
-	s, err := m.GetMcpServer(entity)
+	s, err := m.getMcpServer(db, entity)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get MCP server %s: %w", serverName, err)
 	}
 
 	var prompts []model.Prompt
-	if err := m.db.Where("server_id = ?", s.ID).Find(&prompts).Error; err != nil {
+	if err := db.Where("server_id = ?", s.ID).Find(&prompts).Error; err != nil {
 		return nil, fmt.Errorf("failed to get prompts for server %s: %w", entity, err)
 	}
 
@@ -263,50 +308,53 @@ func (m *MCPService) setPromptsEnabled(entity string, enabled bool) ([]string, e
 	return changedPromptNames, nil
 }
 
-// registerServerPrompts fetches all prompts from an MCP server and registers them in the DB.
-func (m *MCPService) registerServerPrompts(ctx context.Context, s *model.McpServer, c *client.Client) error {
-	// fetch all prompts from the server so they can be added to the DB
-	resp, err := c.ListPrompts(ctx, mcp.ListPromptsRequest{})
-	if err != nil {
-		return fmt.Errorf("failed to fetch prompts from MCP server %s: %w", s.Name, err)
-	}
-	for _, prompt := range resp.Prompts {
-		canonicalPromptName := mergeServerPromptNames(s.Name, prompt.GetName())
-
+// registerServerPrompts registers the prompts fetched from an MCP server in the DB, and adds them to the MCP proxy server.
+// Registration is on best-effort basis: a prompt that fails to be inserted in the DB is logged and skipped.
+func (m *MCPService) registerServerPrompts(s *model.McpServer, prompts []mcp.Prompt) {
+	rows := make([]*model.Prompt, len(prompts))
+	for i, prompt := range prompts {
 		// extracting json schema is currently on best-effort basis
-		// if it fails, we log the error and continue with the next prompt
 		jsonArguments, _ := json.Marshal(prompt.Arguments)
 
-		p := &model.Prompt{
+		rows[i] = &model.Prompt{
 			ServerID:    s.ID,
 			Name:        prompt.GetName(),
 			Description: prompt.Description,
 			Arguments:   jsonArguments,
 		}
-		if err := m.db.Create(p).Error; err != nil {
+	}
+
+	errs := createInBatches(m.db, rows)
+	proxyPrompts := make([]server.ServerPrompt, 0, len(prompts))
+	for i, prompt := range prompts {
+		canonicalPromptName := mergeServerPromptNames(s.Name, prompt.GetName())
+		if errs[i] != nil {
 			// If registration of a prompt fails, we should not fail the entire server registration.
 			// Instead, continue with the next prompt.
-			log.Printf("[ERROR] failed to register prompt %s in DB: %v", canonicalPromptName, err)
-		} else {
-			// Set prompt name to include the server name prefix to make it recognizable by MCPJungle
-			// then add the prompt to the MCP proxy server
-			prompt.Name = canonicalPromptName
-
-			if s.Transport == types.TransportSSE {
-				m.sseMcpProxyServer.AddPrompt(prompt, m.mcpProxyPromptHandler)
-			} else {
-				m.mcpProxyServer.AddPrompt(prompt, m.mcpProxyPromptHandler)
-			}
+			m.logger.Error(
+				"failed to register prompt in DB",
+				logger.String("server", s.Name), logger.String("prompt", canonicalPromptName), logger.ErrorField(errs[i]),
+			)
+			continue
 		}
+
+		// Set prompt name to include the server name prefix to make it recognizable by MCPJungle
+		prompt.Name = canonicalPromptName
+		proxyPrompts = append(proxyPrompts, server.ServerPrompt{Prompt: prompt, Handler: m.mcpProxyPromptHandler})
+	}
+
+	if s.Transport == types.TransportSSE {
+		m.sseMcpProxyServer.AddPrompts(proxyPrompts...)
+	} else {
+		m.mcpProxyServer.AddPrompts(proxyPrompts...)
 	}
-	return nil
 }
 
 // deregisterServerPrompts deletes all prompts that belong to an MCP server from the DB.
 // It also removes the prompts from the MCP proxy server.
 func (m *MCPService) deregisterServerPrompts(s *model.McpServer) error {
 	// load all prompts for the server from the DB so we can delete them from the MCP proxy
-	prompts, err := m.ListPromptsByServer(s.Name)
+	prompts, err := m.listPromptsByServer(m.primary(), s.Name)
 	if err != nil {
 		return fmt.Errorf("failed to list prompts for server %s: %w", s.Name, err)
 	}
@@ -1,6 +1,7 @@
 package mcp
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 
@@ -247,3 +248,20 @@ func TestConvertPromptModelToMcpObject(t *testing.T) {
 	assert.Equal(t, "code", mcpPrompt.Arguments[0].Name)
 	assert.True(t, mcpPrompt.Arguments[0].Required)
 }
+
+func TestMCPProxyPromptHandlerChecksServerAccess(t *testing.T) {
+	m := &MCPService{}
+	client := &model.McpClient{Name: "cursor", AllowList: []byte(`["other-server"]`)}
+
+	// the deprecated production mode is the same as the enterprise mode
+	for _, mode := range []model.ServerMode{model.ModeEnterprise, model.ModeProd} {
+		ctx := context.WithValue(context.Background(), "mode", mode)
+		ctx = context.WithValue(ctx, "client", client)
+
+		req := mcp.GetPromptRequest{}
+		req.Params.Name = "test-server__code_review"
+		_, err := m.mcpProxyPromptHandler(ctx, req)
+		require.Error(t, err, "mode %s", mode)
+		assert.Contains(t, err.Error(), "client cursor is not authorized to access MCP server test-server")
+	}
+}
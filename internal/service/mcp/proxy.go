@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/internal/telemetry"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
@@ -14,7 +15,9 @@ import (
 // MCPProxyToolCallHandler handles tool calls for the MCP proxy server
 // by forwarding the request to the appropriate upstream MCP server and
 // relaying the response back.
-func (m *MCPService) MCPProxyToolCallHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+func (m *MCPService) MCPProxyToolCallHandler(
+	ctx context.Context, request mcp.CallToolRequest,
+) (res *mcp.CallToolResult, err error) {
 	started := time.Now()
 	outcome := telemetry.ToolCallOutcomeSuccess
 
@@ -24,25 +27,20 @@ func (m *MCPService) MCPProxyToolCallHandler(ctx context.Context, request mcp.Ca
 		return nil, fmt.Errorf("invalid input: tool name does not contain a %s separator", serverToolNameSep)
 	}
 
-	serverMode := ctx.Value("mode").(model.ServerMode)
-	if model.IsEnterpriseMode(serverMode) {
-		// In enterprise mode, we need to check whether the MCP client is authorized to access the MCP server.
-		// If not, return error Unauthorized.
-		c := ctx.Value("client").(*model.McpClient)
-		if !c.CheckHasServerAccess(serverName) {
-			return nil, fmt.Errorf(
-				"client %s is not authorized to access MCP server %s", c.Name, serverName,
-			)
-		}
+	if err := authorizeServerAccess(ctx, serverName); err != nil {
+		return nil, err
 	}
 
-	// Record the tool call metrics at the end of the function
+	ctx, span := startToolCallSpan(ctx, serverName, toolName)
+	defer func() { endSpan(span, err) }()
+
+	// Record the tool call metrics & history at the end of the function
 	defer func() {
-		m.metrics.RecordToolCall(ctx, serverName, toolName, outcome, time.Since(started))
+		m.recordToolCall(ctx, serverName, toolName, request.GetArguments(), outcome, err, time.Since(started))
 	}()
 
 	// get the MCP server details from the database
-	server, err := m.GetMcpServer(serverName)
+	server, err := m.getMcpServerForCall(serverName)
 	if err != nil {
 		// TODO: differentiate between "server not found" and other errors.
 		// server not found is not an internal error, so outcome should be success.
@@ -53,17 +51,19 @@ func (m *MCPService) MCPProxyToolCallHandler(ctx context.Context, request mcp.Ca
 		)
 	}
 
-	mcpClient, err := newMcpServerSession(ctx, server)
+	session, err := m.sessions.acquire(ctx, server)
 	if err != nil {
 		outcome = telemetry.ToolCallOutcomeError
 		return nil, err
 	}
-	defer mcpClient.Close()
+	defer func() { m.sessions.release(session, err) }()
 
 	// Ensure the tool name is set correctly, ie, without the server name prefix
 	request.Params.Name = toolName
+	request.Params.Meta = withRequestIDMeta(ctx, request.Params.Meta)
+	forwardProgressToClient(ctx, session, request.Params.Meta)
 
-	res, err := mcpClient.CallTool(ctx, request)
+	res, err = session.CallTool(ctx, request)
 	if err != nil {
 		outcome = telemetry.ToolCallOutcomeError
 	}
@@ -72,10 +72,40 @@ func (m *MCPService) MCPProxyToolCallHandler(ctx context.Context, request mcp.Ca
 	return res, err
 }
 
+// AuthorizeToolCall checks whether the MCP client in the given context is allowed to call the given tool.
+// The input name must be the canonical tool name, ie, it must contain the server name prefix (eg- "server__tool").
+// This is useful for callers that serve a tool call without going through MCPProxyToolCallHandler.
+func (m *MCPService) AuthorizeToolCall(ctx context.Context, name string) error {
+	serverName, _, ok := splitServerToolName(name)
+	if !ok {
+		return fmt.Errorf("invalid input: tool name does not contain a %s separator", serverToolNameSep)
+	}
+	return authorizeServerAccess(ctx, serverName)
+}
+
+// authorizeServerAccess returns an error if the MCP client making the request is not allowed to access the MCP server.
+// This check only applies in enterprise mode, all clients are allowed in development mode.
+func authorizeServerAccess(ctx context.Context, serverName string) error {
+	serverMode := ctx.Value("mode").(model.ServerMode)
+	if model.IsEnterpriseMode(serverMode) {
+		// In enterprise mode, we need to check whether the MCP client is authorized to access the MCP server.
+		// If not, return error Unauthorized.
+		c := ctx.Value("client").(*model.McpClient)
+		if !c.CheckHasServerAccess(serverName) {
+			return fmt.Errorf(
+				"client %s is not authorized to access MCP server %s", c.Name, serverName,
+			)
+		}
+	}
+	return nil
+}
+
 // mcpProxyPromptHandler handles prompt requests for the MCP proxy server
 // by forwarding the request to the appropriate upstream MCP server and
 // relaying the response back.
-func (m *MCPService) mcpProxyPromptHandler(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+func (m *MCPService) mcpProxyPromptHandler(
+	ctx context.Context, request mcp.GetPromptRequest,
+) (res *mcp.GetPromptResult, err error) {
 	started := time.Now()
 	outcome := telemetry.PromptCallOutcomeSuccess
 
@@ -85,25 +115,20 @@ func (m *MCPService) mcpProxyPromptHandler(ctx context.Context, request mcp.GetP
 		return nil, fmt.Errorf("invalid input: prompt name does not contain a %s separator", serverPromptNameSep)
 	}
 
-	serverMode := ctx.Value("mode").(model.ServerMode)
-	if serverMode == model.ModeProd {
-		// In production mode, we need to check whether the MCP client is authorized to access the MCP server.
-		// If not, return error Unauthorized.
-		c := ctx.Value("client").(*model.McpClient)
-		if !c.CheckHasServerAccess(serverName) {
-			return nil, fmt.Errorf(
-				"client %s is not authorized to access MCP server %s", c.Name, serverName,
-			)
-		}
+	if err := authorizeServerAccess(ctx, serverName); err != nil {
+		return nil, err
 	}
 
+	ctx, span := startPromptSpan(ctx, serverName, promptName)
+	defer func() { endSpan(span, err) }()
+
 	// Record the prompt call metrics at the end of the function
 	defer func() {
 		m.metrics.RecordPromptCall(ctx, serverName, promptName, outcome, time.Since(started))
 	}()
 
 	// get the MCP server details from the database
-	server, err := m.GetMcpServer(serverName)
+	server, err := m.getMcpServerForCall(serverName)
 	if err != nil {
 		// TODO: differentiate between "server not found" and other errors.
 		// server not found is not an internal error, so outcome should be success.
@@ -114,18 +139,18 @@ func (m *MCPService) mcpProxyPromptHandler(ctx context.Context, request mcp.GetP
 		)
 	}
 
-	mcpClient, err := newMcpServerSession(ctx, server)
+	session, err := m.sessions.acquire(ctx, server)
 	if err != nil {
 		outcome = telemetry.PromptCallOutcomeError
 		return nil, err
 	}
-	defer mcpClient.Close()
+	defer func() { m.sessions.release(session, err) }()
 
 	// Ensure the prompt name is set correctly, ie, without the server name prefix
 	request.Params.Name = promptName
 
 	// forward the request to the upstream MCP server and relay the response back
-	res, err := mcpClient.GetPrompt(ctx, request)
+	res, err = session.GetPrompt(ctx, request)
 	if err != nil {
 		outcome = telemetry.PromptCallOutcomeError
 	}
@@ -135,15 +160,27 @@ func (m *MCPService) mcpProxyPromptHandler(ctx context.Context, request mcp.GetP
 
 // initMCPProxyServer initializes the MCP proxy server.
 // It loads all the registered MCP tools and prompts from the database into the proxy server.
+// If tools are loaded lazily, only the prompts are loaded.
 func (m *MCPService) initMCPProxyServer() error {
 	mcpServerModelsCache := make(map[string]*model.McpServer)
+	db := m.primary()
 
 	// Load Tools
-	tools, err := m.ListTools()
-	if err != nil {
-		return fmt.Errorf("failed to list tools from DB: %w", err)
+	var tools []model.Tool
+	if m.toolLoader != nil {
+		if err := m.initLazyToolLoading(); err != nil {
+			return err
+		}
+	} else {
+		var err error
+		if tools, err = m.listTools(db); err != nil {
+			return fmt.Errorf("failed to list tools from DB: %w", err)
+		}
 	}
 
+	// the tools are added to each proxy server at once, rather than one by one
+	var proxyTools, sseProxyTools []server.ServerTool
+	instances := make([]mcp.Tool, 0, len(tools))
 	for _, tm := range tools {
 		if !tm.Enabled {
 			// do not add disabled tools to the proxy
@@ -159,32 +196,38 @@ func (m *MCPService) initMCPProxyServer() error {
 		// get the tool's MCP server so we can determine the transport type
 		// use a cache to avoid querying the DB multiple times for the same server
 		// since multiple tools can belong to the same server
-		var server *model.McpServer
 		serverName, _, _ := splitServerToolName(tool.Name)
 
-		server, exists := mcpServerModelsCache[serverName]
+		parent, exists := mcpServerModelsCache[serverName]
 		if !exists {
-			server, err = m.GetMcpServer(serverName)
+			parent, err = m.getMcpServer(db, serverName)
 			if err != nil {
 				return fmt.Errorf(
 					"init mcp proxy server: failed to get MCP server %s for tool %s from DB: %w", serverName, tool.Name, err,
 				)
 			}
 			// store the server model in cache so we don't have to query the DB again for the same server
-			mcpServerModelsCache[serverName] = server
+			mcpServerModelsCache[serverName] = parent
 		}
 
-		if server.Transport == types.TransportSSE {
-			m.sseMcpProxyServer.AddTool(tool, m.MCPProxyToolCallHandler)
+		serverTool := server.ServerTool{Tool: tool, Handler: m.MCPProxyToolCallHandler}
+		if parent.Transport == types.TransportSSE {
+			sseProxyTools = append(sseProxyTools, serverTool)
 		} else {
-			m.mcpProxyServer.AddTool(tool, m.MCPProxyToolCallHandler)
+			proxyTools = append(proxyTools, serverTool)
 		}
-
-		m.addToolInstance(tool)
+		instances = append(instances, tool)
+	}
+	if len(proxyTools) > 0 {
+		m.mcpProxyServer.AddTools(proxyTools...)
 	}
+	if len(sseProxyTools) > 0 {
+		m.sseMcpProxyServer.AddTools(sseProxyTools...)
+	}
+	m.addToolInstances(instances...)
 
 	// Load prompts
-	prompts, err := m.ListPrompts()
+	prompts, err := m.listPrompts(db)
 	if err != nil {
 		return fmt.Errorf("failed to list prompts from DB: %w", err)
 	}
@@ -207,7 +250,7 @@ func (m *MCPService) initMCPProxyServer() error {
 
 		server, exists := mcpServerModelsCache[serverName]
 		if !exists {
-			server, err = m.GetMcpServer(serverName)
+			server, err = m.getMcpServer(db, serverName)
 			if err != nil {
 				return fmt.Errorf(
 					"init mcp proxy server: failed to get MCP server %s for tool %s from DB: %w", serverName, prompt.Name, err,
@@ -223,5 +266,11 @@ func (m *MCPService) initMCPProxyServer() error {
 		}
 	}
 
+	// the calls to the servers loaded so far don't need to read them from the database again
+	generation := m.servers.currentGeneration()
+	for _, server := range mcpServerModelsCache {
+		m.servers.store(server, generation)
+	}
+
 	return nil
 }
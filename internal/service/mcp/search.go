@@ -0,0 +1,131 @@
+package mcp
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/gorm"
+)
+
+// Weights used to rank search results.
+// A term matching an entity's own name counts for much more than a term matching its description.
+const (
+	searchScoreExactName    = 10
+	searchScoreNamePrefix   = 6
+	searchScoreNameContains = 4
+	searchScoreServerName   = 2
+	searchScoreDescription  = 1
+)
+
+// Search finds the tools and prompts whose names or descriptions match the given query.
+// The query is split into whitespace-separated terms and an entity matches only if it matches every term.
+// Results are ranked by relevance, best matches first.
+// If resultType is not empty, only entities of that type are searched.
+func (m *MCPService) Search(query string, resultType types.SearchResultType) ([]types.SearchResult, error) {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("search query must not be empty")
+	}
+
+	var results []types.SearchResult
+
+	if resultType == "" || resultType == types.SearchResultTool {
+		var tools []model.Tool
+		if err := m.searchQuery(&model.Tool{}, "tools", terms).Find(&tools).Error; err != nil {
+			return nil, fmt.Errorf("failed to search tools: %w", err)
+		}
+		for _, t := range tools {
+			score := searchScore(terms, t.Server.Name, t.Name, t.Description)
+			if score == 0 {
+				continue
+			}
+			results = append(results, types.SearchResult{
+				Type:        types.SearchResultTool,
+				Name:        mergeServerToolNames(t.Server.Name, t.Name),
+				Description: t.Description,
+				Enabled:     t.Enabled,
+				Score:       score,
+			})
+		}
+	}
+
+	if resultType == "" || resultType == types.SearchResultPrompt {
+		var prompts []model.Prompt
+		if err := m.searchQuery(&model.Prompt{}, "prompts", terms).Find(&prompts).Error; err != nil {
+			return nil, fmt.Errorf("failed to search prompts: %w", err)
+		}
+		for _, p := range prompts {
+			score := searchScore(terms, p.Server.Name, p.Name, p.Description)
+			if score == 0 {
+				continue
+			}
+			results = append(results, types.SearchResult{
+				Type:        types.SearchResultPrompt,
+				Name:        mergeServerPromptNames(p.Server.Name, p.Name),
+				Description: p.Description,
+				Enabled:     p.Enabled,
+				Score:       score,
+			})
+		}
+	}
+
+	slices.SortStableFunc(results, func(a, b types.SearchResult) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.Name, b.Name))
+	})
+	return results, nil
+}
+
+// searchQuery returns a DB query that fetches the candidate entities of the given table for the search terms,
+// along with their MCP server.
+// It matches entities in which any of the terms appears, the results must be narrowed down using searchScore.
+func (m *MCPService) searchQuery(entity any, table string, terms []string) *gorm.DB {
+	cond := m.db
+	for _, term := range terms {
+		like := likeContains(term)
+		cond = cond.Or(
+			fmt.Sprintf(
+				`LOWER(%[1]s.name) LIKE ? ESCAPE '\' OR LOWER(%[1]s.description) LIKE ? ESCAPE '\' `+
+					`OR LOWER("Server".name) LIKE ? ESCAPE '\'`,
+				table,
+			),
+			like, like, like,
+		)
+	}
+	return m.db.Model(entity).Joins("Server").Where(cond)
+}
+
+// searchScore computes how well an entity matches all the search terms.
+// It returns 0 if any term doesn't match the entity at all.
+func searchScore(terms []string, serverName, name, description string) int {
+	serverName = strings.ToLower(serverName)
+	name = strings.ToLower(name)
+	description = strings.ToLower(description)
+
+	total := 0
+	for _, term := range terms {
+		var score int
+		switch {
+		case name == term:
+			score = searchScoreExactName
+		case strings.HasPrefix(name, term):
+			score = searchScoreNamePrefix
+		case strings.Contains(name, term):
+			score = searchScoreNameContains
+		}
+		if strings.Contains(serverName, term) {
+			score += searchScoreServerName
+		}
+		if strings.Contains(description, term) {
+			score += searchScoreDescription
+		}
+		if score == 0 {
+			return 0
+		}
+		total += score
+	}
+	return total
+}
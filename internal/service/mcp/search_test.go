@@ -0,0 +1,77 @@
+package mcp
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestSearch(t *testing.T) {
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	github := setup.CreateTestMcpServer("github", "", types.TransportStreamableHTTP, []byte(`{}`))
+	fs := setup.CreateTestMcpServer("filesystem", "", types.TransportStreamableHTTP, []byte(`{}`))
+	setup.CreateTestTool("create_pull_request", "Create a new pull request", github.ID, true, nil)
+	setup.CreateTestTool("list_issues", "List issues in a repository", github.ID, true, nil)
+	setup.CreateTestTool("read_file", "Read the contents of a file", fs.ID, true, nil)
+	setup.CreateTestTool("search_files", "Find files whose name matches a pattern", fs.ID, true, nil)
+	prompt := &model.Prompt{Name: "review_pull_request", Description: "Review a pull request", ServerID: github.ID}
+	testhelpers.AssertNoError(t, setup.DB.Create(prompt).Error)
+
+	m := &MCPService{db: setup.DB}
+
+	tests := []struct {
+		name       string
+		query      string
+		resultType types.SearchResultType
+		expected   []string
+	}{
+		{"single term", "issues", "", []string{"github__list_issues"}},
+		{"multiple terms must all match", "pull request", "", []string{"github__create_pull_request", "github__review_pull_request"}},
+		{"server name", "filesystem", "", []string{"filesystem__read_file", "filesystem__search_files"}},
+		{"ties are ordered by name", "file", "", []string{"filesystem__read_file", "filesystem__search_files"}},
+		{"exact name ranks first", "READ_FILE", "", []string{"filesystem__read_file"}},
+		{"tools only", "pull", types.SearchResultTool, []string{"github__create_pull_request"}},
+		{"prompts only", "pull", types.SearchResultPrompt, []string{"github__review_pull_request"}},
+		{"no matches", "kubernetes", "", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := m.Search(tt.query, tt.resultType)
+			testhelpers.AssertNoError(t, err)
+
+			names := make([]string, len(results))
+			for i, r := range results {
+				names[i] = r.Name
+			}
+			if !reflect.DeepEqual(tt.expected, names) {
+				t.Errorf("Expected %v, got %v", tt.expected, names)
+			}
+		})
+	}
+
+	// the LIKE wildcards in the terms are matched literally, so they don't make every entity a candidate
+	var candidates []model.Tool
+	testhelpers.AssertNoError(t, m.searchQuery(&model.Tool{}, "tools", []string{"%"}).Find(&candidates).Error)
+	testhelpers.AssertEqual(t, 0, len(candidates))
+	testhelpers.AssertNoError(t, m.searchQuery(&model.Tool{}, "tools", []string{"h_f"}).Find(&candidates).Error)
+	testhelpers.AssertEqual(t, 1, len(candidates))
+	testhelpers.AssertEqual(t, "search_files", candidates[0].Name)
+
+	_, err := m.Search("   ", "")
+	testhelpers.AssertError(t, err)
+}
+
+func TestSearchScore(t *testing.T) {
+	testhelpers.AssertEqual(t, 0, searchScore([]string{"foo"}, "srv", "bar", "baz"))
+	testhelpers.AssertEqual(t, searchScoreExactName, searchScore([]string{"bar"}, "srv", "bar", ""))
+	testhelpers.AssertEqual(t, searchScoreNamePrefix, searchScore([]string{"ba"}, "srv", "bar", ""))
+	testhelpers.AssertEqual(t, searchScoreNameContains, searchScore([]string{"ar"}, "srv", "bar", ""))
+	testhelpers.AssertEqual(t, searchScoreServerName+searchScoreDescription, searchScore([]string{"srv"}, "srv", "bar", "uses srv"))
+	testhelpers.AssertEqual(t, 0, searchScore([]string{"bar", "qux"}, "srv", "bar", ""))
+}
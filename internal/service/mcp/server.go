@@ -2,10 +2,17 @@ package mcp
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
+	"sync"
 
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/db"
 	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/gorm"
 )
 
 // RegisterMcpServer registers a new MCP server in the database.
@@ -17,27 +24,21 @@ func (m *MCPService) RegisterMcpServer(ctx context.Context, s *model.McpServer)
 		return err
 	}
 
-	mcpClient, err := newMcpServerSession(ctx, s)
+	mcpClient, err := m.newMcpServerSession(ctx, s)
 	if err != nil {
 		return err
 	}
 	defer mcpClient.Close()
+	entities := fetchServerEntities(ctx, s, mcpClient)
 
 	// register the server in the DB
 	if err := m.db.Create(s).Error; err != nil {
 		return fmt.Errorf("failed to register mcp server: %w", err)
 	}
+	// details cached for a server registered earlier with the same name, eg- by another instance, are outdated
+	m.servers.invalidate(s.Name)
 
-	if err = m.registerServerTools(ctx, s, mcpClient); err != nil {
-		return fmt.Errorf("failed to register tools for MCP server %s: %w", s.Name, err)
-	}
-
-	// Register prompts (best-effort, don't fail server registration)
-	if err = m.registerServerPrompts(ctx, s, mcpClient); err != nil {
-		log.Printf("[WARN] failed to register prompts for MCP server %s: %v", s.Name, err)
-	}
-
-	return nil
+	return m.registerServerEntities(s, entities)
 }
 
 // DeregisterMcpServer deregisters an MCP server from the database.
@@ -45,7 +46,7 @@ func (m *MCPService) RegisterMcpServer(ctx context.Context, s *model.McpServer)
 // If even a single tool or prompt fails to deregister, the server deregistration fails.
 // Deregistered tools and prompts are also removed from the MCP proxy server.
 func (m *MCPService) DeregisterMcpServer(name string) error {
-	s, err := m.GetMcpServer(name)
+	s, err := m.getMcpServer(m.primary(), name)
 	if err != nil {
 		return fmt.Errorf("failed to get MCP server %s from DB: %w", name, err)
 	}
@@ -67,27 +68,246 @@ func (m *MCPService) DeregisterMcpServer(name string) error {
 		return fmt.Errorf("failed to deregister server %s: %w", name, err)
 	}
 
+	// a server registered later with the same name must not inherit the failures nor the sessions
+	m.upstreamFailuresMu.Lock()
+	delete(m.upstreamFailures, name)
+	m.upstreamFailuresMu.Unlock()
+	m.servers.invalidate(name)
+	m.sessions.drop(name)
+
+	return nil
+}
+
+// UpdateMcpServer replaces the description and the transport configuration of a registered MCP server.
+// The server is connected to with its new configuration before anything is changed, so a bad configuration
+// leaves the registered server untouched.
+// Its tools and prompts are then registered again from the server, since they may have changed.
+// Those that were disabled before the update remain disabled.
+func (m *MCPService) UpdateMcpServer(ctx context.Context, name string, updated *model.McpServer) error {
+	if err := validateServerName(name); err != nil {
+		return err
+	}
+	if updated.Name != name {
+		return fmt.Errorf("MCP server %s cannot be renamed to %s", name, updated.Name)
+	}
+	s, err := m.getMcpServer(m.primary(), name)
+	if err != nil {
+		return fmt.Errorf("failed to get MCP server %s from DB: %w", name, err)
+	}
+
+	mcpClient, err := m.newMcpServerSession(ctx, updated)
+	if err != nil {
+		return err
+	}
+	defer mcpClient.Close()
+	entities := fetchServerEntities(ctx, updated, mcpClient)
+
+	// remember what the admin disabled, so that the update doesn't silently enable it again
+	disabledTools, disabledPrompts, err := m.disabledServerEntities(name)
+	if err != nil {
+		return err
+	}
+
+	if err := m.deregisterServerTools(s); err != nil {
+		return fmt.Errorf("failed to deregister tools for server %s: %w", name, err)
+	}
+	if err := m.deregisterServerPrompts(s); err != nil {
+		return fmt.Errorf("failed to deregister prompts for server %s: %w", name, err)
+	}
+
+	updated.Model = s.Model
+	if err := m.db.Save(updated).Error; err != nil {
+		return fmt.Errorf("failed to update mcp server %s: %w", name, err)
+	}
+
+	if err := m.registerServerEntities(updated, entities); err != nil {
+		return err
+	}
+
+	// tools and prompts that the server no longer provides are simply skipped
+	for _, t := range disabledTools {
+		if _, err := m.setToolsEnabled(t, false); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			m.logger.Warn("failed to disable tool again after server update", logger.String("tool", t), logger.ErrorField(err))
+		}
+	}
+	for _, p := range disabledPrompts {
+		if _, err := m.setPromptsEnabled(p, false); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			m.logger.Warn("failed to disable prompt again after server update", logger.String("prompt", p), logger.ErrorField(err))
+		}
+	}
+
+	// the failures seen with the previous configuration don't say anything about the new one,
+	// and its sessions must not be reused
+	m.upstreamFailuresMu.Lock()
+	delete(m.upstreamFailures, name)
+	m.upstreamFailuresMu.Unlock()
+	m.servers.invalidate(name)
+	m.sessions.drop(name)
+
 	return nil
 }
 
+// registrationBatchSize is the number of tools or prompts inserted in the DB at once when registering an MCP server
+const registrationBatchSize = 100
+
+// serverEntities are the tools and prompts fetched from an MCP server to be registered.
+type serverEntities struct {
+	tools      []mcp.Tool
+	toolsErr   error
+	prompts    []mcp.Prompt
+	promptsErr error
+}
+
+// fetchServerEntities fetches the tools and prompts provided by an MCP server concurrently.
+func fetchServerEntities(ctx context.Context, s *model.McpServer, c *client.Client) *serverEntities {
+	e := &serverEntities{}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		resp, err := c.ListTools(ctx, mcp.ListToolsRequest{})
+		if err != nil {
+			e.toolsErr = fmt.Errorf("failed to fetch tools from MCP server %s: %w", s.Name, err)
+			return
+		}
+		e.tools = resp.Tools
+	}()
+	go func() {
+		defer wg.Done()
+		resp, err := c.ListPrompts(ctx, mcp.ListPromptsRequest{})
+		if err != nil {
+			e.promptsErr = fmt.Errorf("failed to fetch prompts from MCP server %s: %w", s.Name, err)
+			return
+		}
+		e.prompts = resp.Prompts
+	}()
+	wg.Wait()
+	return e
+}
+
+// registerServerEntities registers the tools and prompts fetched from an MCP server.
+// It fails if the tools couldn't be fetched, but prompts are registered on best-effort basis.
+func (m *MCPService) registerServerEntities(s *model.McpServer, e *serverEntities) error {
+	if e.toolsErr != nil {
+		return fmt.Errorf("failed to register tools for MCP server %s: %w", s.Name, e.toolsErr)
+	}
+	m.registerServerTools(s, e.tools)
+
+	// Register prompts (best-effort, don't fail server registration)
+	if e.promptsErr != nil {
+		m.logger.Warn("failed to register prompts for MCP server", logger.String("server", s.Name), logger.ErrorField(e.promptsErr))
+		return nil
+	}
+	m.registerServerPrompts(s, e.prompts)
+	return nil
+}
+
+// createInBatches inserts the given rows in the DB, registrationBatchSize rows at a time.
+// If a batch fails, eg- because one of its rows is invalid, its rows are inserted one by one instead,
+// so that a single bad row doesn't prevent the others from being inserted.
+// It returns the error of every row, which is nil if the row was inserted.
+func createInBatches[T any](db *gorm.DB, rows []*T) []error {
+	errs := make([]error, len(rows))
+	for start := 0; start < len(rows); start += registrationBatchSize {
+		batch := rows[start:min(start+registrationBatchSize, len(rows))]
+		if err := db.CreateInBatches(batch, registrationBatchSize).Error; err == nil {
+			continue
+		}
+		for i, row := range batch {
+			errs[start+i] = db.Create(row).Error
+		}
+	}
+	return errs
+}
+
+// disabledServerEntities returns the canonical names of the disabled tools and prompts of a server.
+func (m *MCPService) disabledServerEntities(name string) ([]string, []string, error) {
+	tools, err := m.listToolsByServer(m.primary(), name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list tools for server %s: %w", name, err)
+	}
+	prompts, err := m.listPromptsByServer(m.primary(), name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list prompts for server %s: %w", name, err)
+	}
+
+	var disabledTools, disabledPrompts []string
+	for _, t := range tools {
+		if !t.Enabled {
+			disabledTools = append(disabledTools, t.Name)
+		}
+	}
+	for _, p := range prompts {
+		if !p.Enabled {
+			disabledPrompts = append(disabledPrompts, p.Name)
+		}
+	}
+	return disabledTools, disabledPrompts, nil
+}
+
 // ListMcpServers returns all registered MCP servers.
 func (m *MCPService) ListMcpServers() ([]model.McpServer, error) {
 	var servers []model.McpServer
-	if err := m.db.Find(&servers).Error; err != nil {
+	if err := m.db.Order("id").Find(&servers).Error; err != nil {
 		return nil, err
 	}
 	return servers, nil
 }
 
+// ListMcpServersPage returns the given page of registered MCP servers, along with the total number of servers.
+func (m *MCPService) ListMcpServersPage(page types.Page) ([]model.McpServer, int64, error) {
+	var servers []model.McpServer
+	total, err := db.FindPage(m.db.Order("id"), page, &servers)
+	if err != nil {
+		return nil, 0, err
+	}
+	return servers, total, nil
+}
+
 // GetMcpServer fetches a server from the database by name.
 func (m *MCPService) GetMcpServer(name string) (*model.McpServer, error) {
+	return m.getMcpServer(m.db, name)
+}
+
+func (m *MCPService) getMcpServer(db *gorm.DB, name string) (*model.McpServer, error) {
 	var serverModel model.McpServer
-	if err := m.db.Where("name = ?", name).First(&serverModel).Error; err != nil {
+	if err := db.Where("name = ?", name).First(&serverModel).Error; err != nil {
 		return nil, err
 	}
 	return &serverModel, nil
 }
 
+// getMcpServerForCall fetches the MCP server to forward a tool or prompt call to.
+// Its details are served from the server cache while they are fresh, otherwise they are read from the database.
+// If the database is unavailable, the last known details of the server are used instead, so that the proxy
+// keeps serving the tools & prompts it holds in memory in a degraded mode.
+func (m *MCPService) getMcpServerForCall(name string) (*model.McpServer, error) {
+	last, fresh := m.servers.get(name)
+	if fresh {
+		return last, nil
+	}
+
+	generation := m.servers.currentGeneration()
+	s, err := m.GetMcpServer(name)
+	if err == nil {
+		m.servers.store(s, generation)
+		return s, nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// the server was deregistered, eg- by another instance sharing the database
+		m.servers.invalidate(name)
+		return nil, err
+	}
+	if last == nil {
+		return nil, err
+	}
+	m.logger.Warn(
+		"failed to get MCP server from DB, using its last known details",
+		logger.String("server", name), logger.ErrorField(err),
+	)
+	return last, nil
+}
+
 // EnableMcpServer enables all tools and prompts registered by the given MCP server.
 // It returns the names of the enabled tools and prompts.
 // If even a single tool or prompt fails to enable, the operation fails.
@@ -0,0 +1,88 @@
+package mcp
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+)
+
+// DefaultServerCacheTTL is how long the details of an MCP server are served from memory by default
+// before they are read from the database again.
+const DefaultServerCacheTTL = 30 * time.Second
+
+// cachedServer holds the details of an MCP server read from the database, along with when they were read.
+type cachedServer struct {
+	server    *model.McpServer
+	fetchedAt time.Time
+}
+
+// serverCache keeps the latest details of the MCP servers read from the database, keyed by name,
+// so that the tool & prompt calls don't need a database round trip to find the server to forward them to.
+//
+// The details of a server are invalidated whenever it is registered, updated or deregistered by this instance.
+// Other instances sharing the database may change a server as well, so the details are only trusted for a limited
+// time (ttl), after which they are read again. Expired details are still kept to let the proxy keep forwarding calls
+// to the upstream servers while the database is unavailable.
+type serverCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cachedServer
+	// generation is incremented by every invalidation, so that details read from the database before an
+	// invalidation are not cached after it
+	generation uint64
+}
+
+func newServerCache(ttl time.Duration) *serverCache {
+	return &serverCache{ttl: ttl, entries: make(map[string]cachedServer)}
+}
+
+// get returns the cached details of the given server, and whether they are still fresh.
+// It returns nil if the server's details aren't cached.
+func (c *serverCache) get(name string) (*model.McpServer, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[name]
+	if !ok {
+		return nil, false
+	}
+	return e.server, time.Since(e.fetchedAt) < c.ttl
+}
+
+// currentGeneration must be called before reading a server from the database, and passed to store afterwards.
+func (c *serverCache) currentGeneration() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// store caches the details of a server read from the database,
+// unless the cache was invalidated since the given generation.
+func (c *serverCache) store(s *model.McpServer, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+	c.entries[s.Name] = cachedServer{server: s, fetchedAt: time.Now()}
+}
+
+// invalidate drops the cached details of the given server.
+func (c *serverCache) invalidate(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, name)
+	c.generation++
+}
+
+func (c *serverCache) setTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ttl = ttl
+}
+
+// SetServerCacheTTL sets how long the details of an MCP server are served from memory to the tool & prompt calls
+// before they are read from the database again. A TTL of 0 reads them on every call.
+func (m *MCPService) SetServerCacheTTL(ttl time.Duration) {
+	m.servers.setTTL(ttl)
+}
@@ -0,0 +1,91 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestServerCache(t *testing.T) {
+	c := newServerCache(time.Minute)
+	s := &model.McpServer{Name: "github"}
+
+	got, fresh := c.get("github")
+	testhelpers.AssertTrue(t, got == nil && !fresh, "expected nothing cached")
+
+	c.store(s, c.currentGeneration())
+	got, fresh = c.get("github")
+	testhelpers.AssertTrue(t, got == s && fresh, "expected the server to be cached")
+
+	// details read before an invalidation are not cached after it
+	generation := c.currentGeneration()
+	c.invalidate("github")
+	c.store(s, generation)
+	got, _ = c.get("github")
+	testhelpers.AssertTrue(t, got == nil, "expected the outdated details not to be cached")
+
+	// expired details are kept, but no longer fresh
+	c.setTTL(0)
+	c.store(s, c.currentGeneration())
+	got, fresh = c.get("github")
+	testhelpers.AssertTrue(t, got == s && !fresh, "expected the details to be expired")
+}
+
+func TestGetMcpServerForCallUsesCache(t *testing.T) {
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+	proxy := server.NewMCPServer("proxy", "test")
+	m, err := NewMCPService(setup.DB, proxy, proxy, telemetry.NewNoopCustomMetrics(), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+
+	s, err := model.NewStreamableHTTPServer("time", "old", newUpstreamServer(t, "get_time"), "")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, m.RegisterMcpServer(context.Background(), s))
+	queries := countQueries(t, setup.DB)
+
+	for range 3 {
+		got, err := m.getMcpServerForCall("time")
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, "old", got.Description)
+	}
+	testhelpers.AssertEqual(t, int32(1), queries.Load())
+
+	t.Run("invalidated by update", func(t *testing.T) {
+		updated, err := model.NewStreamableHTTPServer("time", "new", newUpstreamServer(t, "get_time"), "")
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertNoError(t, m.UpdateMcpServer(context.Background(), "time", updated))
+
+		got, err := m.getMcpServerForCall("time")
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, "new", got.Description)
+	})
+
+	t.Run("invalidated by deregistration", func(t *testing.T) {
+		_, err := m.getMcpServerForCall("time")
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertNoError(t, m.DeregisterMcpServer("time"))
+
+		_, err = m.getMcpServerForCall("time")
+		testhelpers.AssertError(t, err)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		other, err := model.NewStreamableHTTPServer("clock", "", newUpstreamServer(t, "get_time"), "")
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertNoError(t, m.RegisterMcpServer(context.Background(), other))
+		m.SetServerCacheTTL(0)
+
+		_, err = m.getMcpServerForCall("clock")
+		testhelpers.AssertNoError(t, err)
+		// the server was deregistered behind the cache's back, eg- by another instance sharing the database
+		testhelpers.AssertNoError(t, setup.DB.Unscoped().Where("name = ?", "clock").Delete(&model.McpServer{}).Error)
+		_, err = m.getMcpServerForCall("clock")
+		testhelpers.AssertError(t, err)
+	})
+}
@@ -0,0 +1,165 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+// newUpstreamServer starts an MCP server providing tools with the given names and returns its URL.
+func newUpstreamServer(t *testing.T, tools ...string) string {
+	upstream := server.NewMCPServer("upstream", "test")
+	for _, name := range tools {
+		upstream.AddTool(mcp.NewTool(name), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(name), nil
+		})
+	}
+	ts := httptest.NewServer(server.NewStreamableHTTPServer(upstream))
+	t.Cleanup(ts.Close)
+	return ts.URL + "/mcp"
+}
+
+func TestUpdateMcpServer(t *testing.T) {
+	oldURL := newUpstreamServer(t, "get_time", "set_timezone")
+	newURL := newUpstreamServer(t, "set_timezone", "list_timezones")
+
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+	proxy := server.NewMCPServer("proxy", "test")
+	mcpService, err := NewMCPService(setup.DB, proxy, proxy, telemetry.NewNoopCustomMetrics(), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+
+	s, err := model.NewStreamableHTTPServer("time", "old", oldURL, "")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, mcpService.RegisterMcpServer(context.Background(), s))
+	_, err = mcpService.DisableTools("time__set_timezone")
+	testhelpers.AssertNoError(t, err)
+
+	t.Run("unreachable server", func(t *testing.T) {
+		down, err := model.NewStreamableHTTPServer("time", "down", "http://127.0.0.1:1/mcp", "")
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertError(t, mcpService.UpdateMcpServer(context.Background(), "time", down))
+
+		// the registered server is left untouched
+		got, err := mcpService.GetMcpServer("time")
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, "old", got.Description)
+		tools, err := mcpService.ListToolsByServer("time")
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, 2, len(tools))
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		renamed, err := model.NewStreamableHTTPServer("clock", "", newURL, "")
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertError(t, mcpService.UpdateMcpServer(context.Background(), "time", renamed))
+	})
+
+	t.Run("new configuration", func(t *testing.T) {
+		updated, err := model.NewStreamableHTTPServer("time", "new", newURL, "")
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertNoError(t, mcpService.UpdateMcpServer(context.Background(), "time", updated))
+
+		got, err := mcpService.GetMcpServer("time")
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, "new", got.Description)
+		testhelpers.AssertEqual(t, s.ID, got.ID)
+		conf, err := got.GetStreamableHTTPConfig()
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, newURL, conf.URL)
+
+		// the tools are those of the new server, and the disabled tool remains disabled
+		tools, err := mcpService.ListToolsByServer("time")
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, 2, len(tools))
+		enabled := make(map[string]bool)
+		for _, tool := range tools {
+			enabled[tool.Name] = tool.Enabled
+		}
+		testhelpers.AssertFalse(t, enabled["time__set_timezone"], "expected set_timezone to remain disabled")
+		testhelpers.AssertTrue(t, enabled["time__list_timezones"], "expected list_timezones to be enabled")
+		_, ok := enabled["time__get_time"]
+		testhelpers.AssertFalse(t, ok, "expected get_time to be removed")
+	})
+
+	t.Run("unknown server", func(t *testing.T) {
+		missing, err := model.NewStreamableHTTPServer("missing", "", newURL, "")
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertError(t, mcpService.UpdateMcpServer(context.Background(), "missing", missing))
+	})
+}
+
+func TestRegisterMcpServerWithManyTools(t *testing.T) {
+	upstream := server.NewMCPServer("upstream", "test")
+	tools := make([]string, 2*registrationBatchSize+50)
+	for i := range tools {
+		tools[i] = fmt.Sprintf("tool_%d", i)
+		upstream.AddTool(mcp.NewTool(tools[i]), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("ok"), nil
+		})
+	}
+	upstream.AddPrompt(mcp.NewPrompt("greet"), func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		return mcp.NewGetPromptResult("greeting", nil), nil
+	})
+	ts := httptest.NewServer(server.NewStreamableHTTPServer(upstream))
+	defer ts.Close()
+
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+	proxy := server.NewMCPServer("proxy", "test")
+	mcpService, err := NewMCPService(setup.DB, proxy, proxy, telemetry.NewNoopCustomMetrics(), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+
+	s, err := model.NewStreamableHTTPServer("many", "", ts.URL+"/mcp", "")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, mcpService.RegisterMcpServer(context.Background(), s))
+
+	registered, err := mcpService.ListToolsByServer("many")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, len(tools), len(registered))
+	testhelpers.AssertEqual(t, len(tools), len(proxy.ListTools()))
+	_, ok := mcpService.GetToolInstance("many__tool_249")
+	testhelpers.AssertTrue(t, ok, "expected the last tool to be added to the proxy")
+
+	prompts, err := mcpService.ListPromptsByServer("many")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 1, len(prompts))
+}
+
+func TestCreateInBatches(t *testing.T) {
+	type row struct {
+		ID   uint
+		Name string `gorm:"uniqueIndex"`
+	}
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+	testhelpers.AssertNoError(t, setup.DB.AutoMigrate(&row{}))
+	testhelpers.AssertNoError(t, setup.DB.Create(&row{Name: "taken"}).Error)
+
+	rows := make([]*row, registrationBatchSize+10)
+	for i := range rows {
+		rows[i] = &row{Name: fmt.Sprintf("row_%d", i)}
+	}
+	// the batch containing the conflicting row falls back to inserting its rows one by one
+	rows[registrationBatchSize+5].Name = "taken"
+
+	errs := createInBatches(setup.DB, rows)
+	for i, err := range errs {
+		if i == registrationBatchSize+5 {
+			testhelpers.AssertError(t, err)
+		} else {
+			testhelpers.AssertNoError(t, err)
+		}
+	}
+	var count int64
+	testhelpers.AssertNoError(t, setup.DB.Model(&row{}).Count(&count).Error)
+	testhelpers.AssertEqual(t, int64(len(rows)), count)
+}
@@ -0,0 +1,115 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client/transport"
+)
+
+// ErrStdioServerBusy is returned when a stdio MCP server already runs as many processes as it is allowed to,
+// and none of them exited in time for a new one to be started.
+var ErrStdioServerBusy = errors.New("stdio MCP server is busy")
+
+// StdioLimits bounds the processes run for the stdio MCP servers.
+// Every session with a stdio server runs its own process (often through npx or uvx), so without a limit
+// a burst of calls could start more processes than the host can handle.
+type StdioLimits struct {
+	// MaxProcessesPerServer caps the number of processes running at the same time for a stdio MCP server.
+	// Starting one more process waits for a running one to exit. 0 means no limit.
+	MaxProcessesPerServer int
+	// QueueTimeout is the maximum time to wait for a process to exit once the cap is reached,
+	// after which ErrStdioServerBusy is returned. 0 waits for as long as the caller's context allows.
+	QueueTimeout time.Duration
+}
+
+// DefaultStdioLimits returns the default limits of the processes run for the stdio MCP servers.
+func DefaultStdioLimits() StdioLimits {
+	return StdioLimits{
+		MaxProcessesPerServer: 8,
+		QueueTimeout:          30 * time.Second,
+	}
+}
+
+// stdioLimiter keeps track of the processes running for every stdio MCP server, to enforce StdioLimits.
+type stdioLimiter struct {
+	limits StdioLimits
+
+	mu sync.Mutex
+	// slots holds a value for every process running for a server, key: server name
+	slots map[string]chan struct{}
+}
+
+func newStdioLimiter(limits StdioLimits) *stdioLimiter {
+	return &stdioLimiter{limits: limits, slots: make(map[string]chan struct{})}
+}
+
+// acquire reserves a process for the given server, waiting for one to exit if the server runs too many already.
+// The returned function must be called once the process exits.
+func (l *stdioLimiter) acquire(ctx context.Context, name string) (func(), error) {
+	if l == nil || l.limits.MaxProcessesPerServer <= 0 {
+		return func() {}, nil
+	}
+
+	l.mu.Lock()
+	slots, ok := l.slots[name]
+	if !ok {
+		slots = make(chan struct{}, l.limits.MaxProcessesPerServer)
+		l.slots[name] = slots
+	}
+	l.mu.Unlock()
+
+	release := func() { <-slots }
+	select {
+	case slots <- struct{}{}:
+		return release, nil
+	default:
+	}
+
+	var timeout <-chan time.Time
+	if l.limits.QueueTimeout > 0 {
+		timer := time.NewTimer(l.limits.QueueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case slots <- struct{}{}:
+		return release, nil
+	case <-timeout:
+		return nil, fmt.Errorf(
+			"%w: %s already runs %d processes, none exited within %s",
+			ErrStdioServerBusy, name, l.limits.MaxProcessesPerServer, l.limits.QueueTimeout,
+		)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// stdioProcess is the transport of a session with a stdio MCP server,
+// it frees the process' slot in the stdioLimiter once the process is closed.
+type stdioProcess struct {
+	*transport.Stdio
+
+	release   func()
+	closeOnce sync.Once
+}
+
+// Start does nothing, the process is started before the session's client is created.
+func (p *stdioProcess) Start(ctx context.Context) error {
+	return nil
+}
+
+func (p *stdioProcess) Close() error {
+	err := p.Stdio.Close()
+	p.closeOnce.Do(p.release)
+	return err
+}
+
+// SetStdioLimits changes the limits of the processes run for the stdio MCP servers.
+// It must be called before the service starts serving calls, since the processes running so far are not counted.
+func (m *MCPService) SetStdioLimits(limits StdioLimits) {
+	m.stdioProcesses = newStdioLimiter(limits)
+}
@@ -0,0 +1,84 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestStdioLimiter(t *testing.T) {
+	t.Run("caps the processes per server", func(t *testing.T) {
+		l := newStdioLimiter(StdioLimits{MaxProcessesPerServer: 2, QueueTimeout: 20 * time.Millisecond})
+
+		first, err := l.acquire(context.Background(), "filesystem")
+		testhelpers.AssertNoError(t, err)
+		_, err = l.acquire(context.Background(), "filesystem")
+		testhelpers.AssertNoError(t, err)
+		// other servers have their own limit
+		_, err = l.acquire(context.Background(), "git")
+		testhelpers.AssertNoError(t, err)
+
+		_, err = l.acquire(context.Background(), "filesystem")
+		testhelpers.AssertTrue(t, errors.Is(err, ErrStdioServerBusy), "expected the server to be busy")
+
+		first()
+		_, err = l.acquire(context.Background(), "filesystem")
+		testhelpers.AssertNoError(t, err)
+	})
+
+	t.Run("waits for a process to exit", func(t *testing.T) {
+		l := newStdioLimiter(StdioLimits{MaxProcessesPerServer: 1, QueueTimeout: time.Minute})
+
+		release, err := l.acquire(context.Background(), "filesystem")
+		testhelpers.AssertNoError(t, err)
+		time.AfterFunc(20*time.Millisecond, release)
+
+		_, err = l.acquire(context.Background(), "filesystem")
+		testhelpers.AssertNoError(t, err)
+	})
+
+	t.Run("call times out while waiting", func(t *testing.T) {
+		l := newStdioLimiter(StdioLimits{MaxProcessesPerServer: 1})
+
+		_, err := l.acquire(context.Background(), "filesystem")
+		testhelpers.AssertNoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err = l.acquire(ctx, "filesystem")
+		testhelpers.AssertTrue(t, errors.Is(err, context.DeadlineExceeded), "expected the call's deadline to be exceeded")
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		l := newStdioLimiter(StdioLimits{})
+		for range 100 {
+			_, err := l.acquire(context.Background(), "filesystem")
+			testhelpers.AssertNoError(t, err)
+		}
+	})
+}
+
+func TestRunStdioServerReleasesProcess(t *testing.T) {
+	m := &MCPService{
+		stdioProcesses: newStdioLimiter(StdioLimits{MaxProcessesPerServer: 1, QueueTimeout: 20 * time.Millisecond}),
+		logger:         logger.NewNop(),
+	}
+
+	// cat doesn't speak MCP, so the session can't be established
+	s, err := model.NewStdioServer("echo", "", "cat", nil, nil)
+	testhelpers.AssertNoError(t, err)
+
+	for range 2 {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		_, err := m.runStdioServer(ctx, s)
+		cancel()
+		testhelpers.AssertError(t, err)
+		// the process of the failed session was stopped, so the next one may start
+		testhelpers.AssertTrue(t, !errors.Is(err, ErrStdioServerBusy), "expected the process to be released")
+	}
+}
@@ -4,14 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"path"
+	"strings"
 	"time"
 
-	"github.com/mark3labs/mcp-go/client"
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/db"
 	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/requestid"
 	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/gorm"
 )
 
 // ToolDeletionCallback is a function type that can be registered to be called
@@ -20,43 +25,64 @@ import (
 type ToolDeletionCallback func(toolNames ...string)
 
 // ToolAdditionCallback is a function type that can be registered to be called
-// whenever a tool is added (registered or re-enabled).
-// The callback receives the name of the added tool as argument.
-type ToolAdditionCallback func(toolName string) error
+// whenever one or more tools are added (registered or re-enabled).
+// The callback receives the names of the added tools as arguments.
+type ToolAdditionCallback func(toolNames ...string) error
+
+// ToolInvocationCallback is a function type that can be registered to be called
+// after every tool call made through mcpjungle, whether it succeeded or not.
+// The callback receives the canonical name of the tool, the arguments of the call, its outcome,
+// the error returned by the call (if any) and how long the call took.
+type ToolInvocationCallback func(
+	ctx context.Context,
+	name string,
+	args map[string]any,
+	outcome telemetry.ToolCallOutcome,
+	err error,
+	elapsedTime time.Duration,
+)
 
 // ListTools returns all tools registered in the registry.
 // It sets each tool's name to its canonical form by prepending its mcp server's name.
 // For example, if a tool named "commit" is provided by a server named "git",
 // its name will be set to "git__commit".
 func (m *MCPService) ListTools() ([]model.Tool, error) {
+	return m.listTools(m.db)
+}
+
+func (m *MCPService) listTools(db *gorm.DB) ([]model.Tool, error) {
+	// the servers are fetched along with their tools, so that listing the tools takes a single query
 	var tools []model.Tool
-	if err := m.db.Find(&tools).Error; err != nil {
+	if err := db.Joins("Server").Order("tools.id").Find(&tools).Error; err != nil {
 		return nil, err
 	}
 	// prepend server name to tool names to ensure we only return the unique names of tools to user
 	for i := range tools {
-		var s model.McpServer
-		if err := m.db.First(&s, "id = ?", tools[i].ServerID).Error; err != nil {
-			return nil, fmt.Errorf("failed to get server for tool %s: %w", tools[i].Name, err)
+		if tools[i].Server.ID == 0 {
+			return nil, fmt.Errorf("failed to get server for tool %s: %w", tools[i].Name, gorm.ErrRecordNotFound)
 		}
-		tools[i].Name = mergeServerToolNames(s.Name, tools[i].Name)
+		tools[i].Name = mergeServerToolNames(tools[i].Server.Name, tools[i].Name)
 	}
 	return tools, nil
 }
 
 // ListToolsByServer fetches tools provided by an MCP server from the registry.
 func (m *MCPService) ListToolsByServer(name string) ([]model.Tool, error) {
+	return m.listToolsByServer(m.db, name)
+}
+
+func (m *MCPService) listToolsByServer(db *gorm.DB, name string) ([]model.Tool, error) {
 	if err := validateServerName(name); err != nil {
 		return nil, err
 	}
 
-	s, err := m.GetMcpServer(name)
+	s, err := m.getMcpServer(db, name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get MCP server %s from DB: %w", name, err)
 	}
 
 	var tools []model.Tool
-	if err := m.db.Where("server_id = ?", s.ID).Find(&tools).Error; err != nil {
+	if err := db.Where("server_id = ?", s.ID).Order("id").Find(&tools).Error; err != nil {
 		return nil, fmt.Errorf("failed to get tools for server %s from DB: %w", name, err)
 	}
 
@@ -68,6 +94,63 @@ func (m *MCPService) ListToolsByServer(name string) ([]model.Tool, error) {
 	return tools, nil
 }
 
+// ListToolsFiltered returns the given page of the tools in the registry that match the given options,
+// in the requested order, along with the total number of tools that match them.
+func (m *MCPService) ListToolsFiltered(opts *types.ListToolsOptions, page types.Page) ([]model.Tool, int64, error) {
+	// the canonical name of a tool is made of its server's name and its own name, see mergeServerToolNames
+	var order string
+	switch opts.Sort {
+	case "":
+		// keep the registration order
+		order = "tools.id"
+	case types.ToolSortName:
+		order = `tools.name, "Server".name`
+	case types.ToolSortServer:
+		order = `"Server".name, tools.name`
+	case types.ToolSortUpdatedAt:
+		order = "tools.updated_at, tools.id"
+	default:
+		return nil, 0, fmt.Errorf(
+			"invalid sort key '%s': must be one of %s, %s, %s",
+			opts.Sort, types.ToolSortName, types.ToolSortServer, types.ToolSortUpdatedAt,
+		)
+	}
+
+	q := m.db.Joins("Server").Order(order)
+	if opts.Server != "" {
+		if err := validateServerName(opts.Server); err != nil {
+			return nil, 0, err
+		}
+		s, err := m.getMcpServer(m.db, opts.Server)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get MCP server %s from DB: %w", opts.Server, err)
+		}
+		q = q.Where("tools.server_id = ?", s.ID)
+	}
+	if opts.Enabled != nil {
+		q = q.Where("tools.enabled = ?", *opts.Enabled)
+	}
+	if opts.NameContains != "" {
+		q = q.Where(
+			`LOWER("Server".name || ? || tools.name) LIKE ? ESCAPE '\'`,
+			serverToolNameSep, likeContains(strings.ToLower(opts.NameContains)),
+		)
+	}
+
+	var tools []model.Tool
+	total, err := db.FindPage(q, page, &tools)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range tools {
+		if tools[i].Server.ID == 0 {
+			return nil, 0, fmt.Errorf("failed to get server for tool %s: %w", tools[i].Name, gorm.ErrRecordNotFound)
+		}
+		tools[i].Name = mergeServerToolNames(tools[i].Server.Name, tools[i].Name)
+	}
+	return tools, total, nil
+}
+
 func (m *MCPService) GetTool(name string) (*model.Tool, error) {
 	serverName, toolName, ok := splitServerToolName(name)
 	if !ok {
@@ -90,7 +173,14 @@ func (m *MCPService) GetTool(name string) (*model.Tool, error) {
 
 // GetToolInstance returns the in-memory mcp.Tool instance for the given tool name.
 // Returns the tool instance and a boolean indicating if it was found.
+// If tools are loaded lazily, the tools of the tool's server are loaded first.
 func (m *MCPService) GetToolInstance(name string) (mcp.Tool, bool) {
+	if serverName, _, ok := splitServerToolName(name); ok {
+		if err := m.loadServerTools(serverName); err != nil {
+			m.logger.Error("failed to load tools", logger.String("server", serverName), logger.ErrorField(err))
+		}
+	}
+
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	tool, exists := m.toolInstances[name]
@@ -108,7 +198,32 @@ func (m *MCPService) GetToolParentServer(name string) (*model.McpServer, error)
 }
 
 // InvokeTool invokes a tool from a registered MCP server and returns its response.
+// If ctx carries a ProgressHandler (see WithProgressHandler), the progress notifications of the call are passed to it.
 func (m *MCPService) InvokeTool(ctx context.Context, name string, args map[string]any) (*types.ToolInvokeResult, error) {
+	callToolResp, err := m.CallTool(ctx, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: callToolResp.Content is a list of Content objects.
+	// If the tool returns a list as its result, it gets converted to a list of Content objects.
+	// But if the tool returns any other type of object (string, map, number, etc), then it is
+	// completely available in Content[0].
+
+	// Convert MCP response to ToolInvokeResult
+	result, err := m.ConvertToolResult(callToolResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert MCP response to api response: %w", err)
+	}
+	return result, nil
+}
+
+// CallTool invokes a tool from a registered MCP server and returns its response as received from the server.
+// Unlike InvokeTool, the response is not converted, which lets callers serve large responses without copying them.
+// See EncodeToolInvokeResult.
+func (m *MCPService) CallTool(
+	ctx context.Context, name string, args map[string]any,
+) (result *mcp.CallToolResult, err error) {
 	started := time.Now()
 	outcome := telemetry.ToolCallOutcomeError
 
@@ -117,12 +232,15 @@ func (m *MCPService) InvokeTool(ctx context.Context, name string, args map[strin
 		return nil, fmt.Errorf("invalid input: tool name does not contain a %s separator", serverToolNameSep)
 	}
 
-	// record the tool call metrics when the function returns
+	ctx, span := startToolCallSpan(ctx, serverName, toolName)
+	defer func() { endSpan(span, err) }()
+
+	// record the tool call metrics & history when the function returns
 	defer func() {
-		m.metrics.RecordToolCall(ctx, serverName, toolName, outcome, time.Since(started))
+		m.recordToolCall(ctx, serverName, toolName, args, outcome, err, time.Since(started))
 	}()
 
-	serverModel, err := m.GetMcpServer(serverName)
+	serverModel, err := m.getMcpServerForCall(serverName)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"failed to get details about MCP server %s from DB: %w",
@@ -131,30 +249,25 @@ func (m *MCPService) InvokeTool(ctx context.Context, name string, args map[strin
 		)
 	}
 
-	mcpClient, err := newMcpServerSession(ctx, serverModel)
+	session, err := m.sessions.acquire(ctx, serverModel)
 	if err != nil {
 		return nil, err
 	}
-	defer mcpClient.Close()
+	defer func() { m.sessions.release(session, err) }()
 
 	callToolReq := mcp.CallToolRequest{}
 	callToolReq.Params.Name = toolName
 	callToolReq.Params.Arguments = args
-
-	callToolResp, err := mcpClient.CallTool(ctx, callToolReq)
-	if err != nil {
-		return nil, fmt.Errorf("failed to call tool %s on MCP server %s: %w", toolName, serverName, err)
+	var meta *mcp.Meta
+	if h := progressHandlerFromContext(ctx); h != nil {
+		meta = &mcp.Meta{ProgressToken: newProgressToken()}
+		onProgress(session, meta.ProgressToken, func(params map[string]any) { h(toToolProgress(params)) })
 	}
+	callToolReq.Params.Meta = withRequestIDMeta(ctx, meta)
 
-	// NOTE: callToolResp.Content is a list of Content objects.
-	// If the tool returns a list as its result, it gets converted to a list of Content objects.
-	// But if the tool returns any other type of object (string, map, number, etc), then it is
-	// completely available in Content[0].
-
-	// Convert MCP response to ToolInvokeResult
-	result, err := m.convertToolCallResToAPIRes(callToolResp)
+	result, err = session.CallTool(ctx, callToolReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert MCP response to api response: %w", err)
+		return nil, fmt.Errorf("failed to call tool %s on MCP server %s: %w", toolName, serverName, err)
 	}
 
 	outcome = telemetry.ToolCallOutcomeSuccess
@@ -176,6 +289,51 @@ func (m *MCPService) SetToolAdditionCallback(callback ToolAdditionCallback) {
 	m.toolAdditionCallback = callback
 }
 
+// SetToolInvocationCallback registers a callback function to be called after every tool call.
+func (m *MCPService) SetToolInvocationCallback(callback ToolInvocationCallback) {
+	m.toolInvocationCallback = callback
+}
+
+// SetSlowToolCallThreshold sets the duration above which tool calls are logged as warnings and counted
+// in the slow tool calls metric. A threshold of 0 disables this.
+func (m *MCPService) SetSlowToolCallThreshold(threshold time.Duration) {
+	m.slowToolCallThreshold = threshold
+}
+
+// recordToolCall records the metrics & history of a tool call.
+// If the call took longer than the slow tool call threshold, it is also logged with its full context.
+func (m *MCPService) recordToolCall(
+	ctx context.Context,
+	serverName, toolName string,
+	args map[string]any,
+	outcome telemetry.ToolCallOutcome,
+	err error,
+	elapsed time.Duration,
+) {
+	m.metrics.RecordToolCall(ctx, serverName, toolName, outcome, elapsed)
+	m.toolInvocationCallback(ctx, mergeServerToolNames(serverName, toolName), args, outcome, err, elapsed)
+
+	if m.slowToolCallThreshold <= 0 || elapsed < m.slowToolCallThreshold {
+		return
+	}
+	m.metrics.RecordSlowToolCall(ctx, serverName, toolName, elapsed)
+
+	fields := []logger.Field{
+		logger.String("server", serverName),
+		logger.String("tool", toolName),
+		logger.Int64("duration_ms", elapsed.Milliseconds()),
+		logger.Int64("threshold_ms", m.slowToolCallThreshold.Milliseconds()),
+		logger.String("outcome", string(outcome)),
+	}
+	if c, ok := ctx.Value("client").(*model.McpClient); ok && c != nil {
+		fields = append(fields, logger.String("client", c.Name))
+	}
+	if id := requestid.FromContext(ctx); id != "" {
+		fields = append(fields, logger.String("request_id", id))
+	}
+	m.logger.Warn("slow tool call", fields...)
+}
+
 // EnableTools enables one or more tools.
 // If the entity is a tool name, only that tool is enabled.
 // If the entity is a server name, all tools of that server are enabled.
@@ -196,22 +354,96 @@ func (m *MCPService) DisableTools(entity string) ([]string, error) {
 	return m.setToolsEnabled(entity, false)
 }
 
+// EnableToolsBulk enables multiple tools in one go.
+// Each entity can be a tool name, a server name or a glob pattern matched against the canonical names of all tools.
+// Entities are processed independently, so a failure to enable one entity doesn't affect the others.
+// The function returns one result per entity, in the order the entities were supplied.
+func (m *MCPService) EnableToolsBulk(entities []string) []types.BulkEntityResult {
+	return m.setToolsEnabledBulk(entities, true)
+}
+
+// DisableToolsBulk disables multiple tools in one go.
+// It accepts the same entities as EnableToolsBulk.
+func (m *MCPService) DisableToolsBulk(entities []string) []types.BulkEntityResult {
+	return m.setToolsEnabledBulk(entities, false)
+}
+
+func (m *MCPService) setToolsEnabledBulk(entities []string, enabled bool) []types.BulkEntityResult {
+	results := make([]types.BulkEntityResult, len(entities))
+	for i, entity := range entities {
+		results[i].Entity = entity
+
+		var (
+			affected []string
+			err      error
+		)
+		if isGlobPattern(entity) {
+			affected, err = m.setToolsEnabledByPattern(entity, enabled)
+		} else {
+			affected, err = m.setToolsEnabled(entity, enabled)
+		}
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		results[i].Affected = affected
+	}
+	return results
+}
+
+// setToolsEnabledByPattern enables or disables all tools whose canonical name matches the given glob pattern.
+// It returns an error if no tool matches the pattern.
+func (m *MCPService) setToolsEnabledByPattern(pattern string, enabled bool) ([]string, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid pattern %s: %w", pattern, err)
+	}
+
+	tools, err := m.listTools(m.primary())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tools: %w", err)
+	}
+
+	affected := make([]string, 0)
+	for _, t := range tools {
+		if ok, _ := path.Match(pattern, t.Name); !ok {
+			continue
+		}
+		changed, err := m.setToolsEnabled(t.Name, enabled)
+		if err != nil {
+			return nil, err
+		}
+		affected = append(affected, changed...)
+	}
+	if len(affected) == 0 {
+		return nil, fmt.Errorf("no tools match the pattern %s", pattern)
+	}
+	return affected, nil
+}
+
 // setToolsEnabled does the heavy lifting of enabling or disabling one or more tools.
 // entity can be either a tool name or a server name.
 // If entity is a tool name, only that tool is enabled/disabled.
 // If entity is a server name, all tools of that server are enabled/disabled.
 func (m *MCPService) setToolsEnabled(entity string, enabled bool) ([]string, error) {
 	serverName, toolName, ok := splitServerToolName(entity)
+	if !ok {
+		serverName = entity
+	}
+	// the server's tools must be loaded before they are changed, or loading them later could undo the change
+	if err := m.loadServerTools(serverName); err != nil {
+		return nil, err
+	}
+	db := m.primary()
 	if ok {
 		// splitting was successful, so the entity is a tool name
 		// only this tool needs to be enabled/disabled
-		s, err := m.GetMcpServer(serverName)
+		s, err := m.getMcpServer(db, serverName)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get MCP server %s: %w", serverName, err)
 		}
 
 		var tool model.Tool
-		if err := m.db.Where("server_id = ? AND name = ?", s.ID, toolName).First(&tool).Error; err != nil {
+		if err := db.Where("server_id = ? AND name = ?", s.ID, toolName).First(&tool).Error; err != nil {
 			return nil, fmt.Errorf("failed to get tool %s: %w", entity, err)
 		}
 
@@ -240,7 +472,7 @@ func (m *MCPService) setToolsEnabled(entity string, enabled bool) ([]string, err
 			}
 
 			// also add the tool to the in-memory tool instance tracker
-			m.addToolInstance(mcpTool)
+			m.addToolInstances(mcpTool)
 			// notify any registered callbacks about the tool addition (re-enabling)
 			m.notifyToolAddition(mcpTool.Name)
 		} else {
@@ -262,17 +494,19 @@ func (m *MCPService) setToolsEnabled(entity string, enabled bool) ([]string, err
 
 	// splitting was unsuccessful, so the entity is a server name
 	// all tools of this server need to be enabled/disabled
-	s, err := m.GetMcpServer(entity)
+	s, err := m.getMcpServer(db, entity)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get MCP server %s: %w", serverName, err)
 	}
 
 	var tools []model.Tool
-	if err := m.db.Where("server_id = ?", s.ID).Find(&tools).Error; err != nil {
+	if err := db.Where("server_id = ?", s.ID).Find(&tools).Error; err != nil {
 		return nil, fmt.Errorf("failed to get tools for server %s: %w", entity, err)
 	}
 
 	var changedToolNames []string
+	var addedTools []server.ServerTool
+	var addedInstances []mcp.Tool
 	for i := range tools {
 		if tools[i].Enabled == enabled {
 			continue // no change needed
@@ -290,82 +524,117 @@ func (m *MCPService) setToolsEnabled(entity string, enabled bool) ([]string, err
 			}
 			// set the tool name to its canonical form in the proxy
 			mcpTool.Name = canonicalToolName
+			addedTools = append(addedTools, server.ServerTool{Tool: mcpTool, Handler: m.MCPProxyToolCallHandler})
+			addedInstances = append(addedInstances, mcpTool)
+		}
 
-			if s.Transport == types.TransportSSE {
-				m.sseMcpProxyServer.AddTool(mcpTool, m.MCPProxyToolCallHandler)
-			} else {
-				m.mcpProxyServer.AddTool(mcpTool, m.MCPProxyToolCallHandler)
-			}
+		changedToolNames = append(changedToolNames, canonicalToolName)
+	}
+	if len(changedToolNames) == 0 {
+		return changedToolNames, nil
+	}
 
-			m.addToolInstance(mcpTool)
-			m.notifyToolAddition(mcpTool.Name)
+	// change all the tools of the proxy server at once, so that its clients are only notified once about the change
+	if enabled {
+		m.addProxyTools(s, addedTools...)
+		m.addToolInstances(addedInstances...)
+		m.notifyToolAddition(changedToolNames...)
+	} else {
+		if s.Transport == types.TransportSSE {
+			m.sseMcpProxyServer.DeleteTools(changedToolNames...)
 		} else {
-			if s.Transport == types.TransportSSE {
-				m.sseMcpProxyServer.DeleteTools(canonicalToolName)
-			} else {
-				m.mcpProxyServer.DeleteTools(canonicalToolName)
-			}
-
-			m.deleteToolInstances(canonicalToolName)
-			m.notifyToolDeletion(canonicalToolName)
+			m.mcpProxyServer.DeleteTools(changedToolNames...)
 		}
-
-		changedToolNames = append(changedToolNames, canonicalToolName)
+		m.deleteToolInstances(changedToolNames...)
+		m.notifyToolDeletion(changedToolNames...)
 	}
 
 	return changedToolNames, nil
 }
 
-// registerServerTools fetches all tools from an MCP server and registers them in the DB.
-func (m *MCPService) registerServerTools(ctx context.Context, s *model.McpServer, c *client.Client) error {
-	// fetch all tools from the server so they can be added to the DB
-	resp, err := c.ListTools(ctx, mcp.ListToolsRequest{})
-	if err != nil {
-		return fmt.Errorf("failed to fetch tools from MCP server %s: %w", s.Name, err)
-	}
-	for _, tool := range resp.Tools {
-		canonicalToolName := mergeServerToolNames(s.Name, tool.GetName())
-
+// registerServerTools registers the tools fetched from an MCP server in the DB, and adds them to the MCP proxy server.
+// Registration is on best-effort basis: a tool that fails to be inserted in the DB is logged and skipped.
+func (m *MCPService) registerServerTools(s *model.McpServer, tools []mcp.Tool) {
+	rows := make([]*model.Tool, len(tools))
+	for i, tool := range tools {
 		// extracting json schema is currently on best-effort basis
-		// if it fails, we log the error and continue with the next tool
 		jsonSchema, _ := json.Marshal(tool.InputSchema)
+		annotations, _ := json.Marshal(tool.Annotations)
+		// most tools don't return structured content, so they don't have an output schema
+		var outputSchema []byte
+		if tool.OutputSchema.Type != "" {
+			outputSchema, _ = json.Marshal(tool.OutputSchema)
+		} else if len(tool.RawOutputSchema) > 0 {
+			outputSchema = tool.RawOutputSchema
+		}
 
-		t := &model.Tool{
-			ServerID:    s.ID,
-			Name:        tool.GetName(),
-			Description: tool.Description,
-			InputSchema: jsonSchema,
+		rows[i] = &model.Tool{
+			ServerID:     s.ID,
+			Name:         tool.GetName(),
+			Description:  tool.Description,
+			InputSchema:  jsonSchema,
+			OutputSchema: outputSchema,
+			Annotations:  annotations,
 		}
-		if err := m.db.Create(t).Error; err != nil {
+	}
+
+	errs := createInBatches(m.db, rows)
+	proxyTools := make([]server.ServerTool, 0, len(tools))
+	for i, tool := range tools {
+		canonicalToolName := mergeServerToolNames(s.Name, tool.GetName())
+		if errs[i] != nil {
 			// If registration of a tool fails, we should not fail the entire server registration.
 			// Instead, continue with the next tool.
-			log.Printf("[ERROR] failed to register tool %s in DB: %v", canonicalToolName, err)
+			m.logger.Error(
+				"failed to register tool in DB",
+				logger.String("server", s.Name), logger.String("tool", canonicalToolName), logger.ErrorField(errs[i]),
+			)
 			continue
 		}
 
 		// Set tool name to include the server name prefix to make it recognizable by MCPJungle
-		// then add the tool to the appropriate MCP proxy server
 		tool.Name = canonicalToolName
+		proxyTools = append(proxyTools, server.ServerTool{Tool: tool, Handler: m.MCPProxyToolCallHandler})
+	}
 
-		if s.Transport == types.TransportSSE {
-			m.sseMcpProxyServer.AddTool(tool, m.MCPProxyToolCallHandler)
-		} else {
-			m.mcpProxyServer.AddTool(tool, m.MCPProxyToolCallHandler)
-		}
+	// add all the tools to the appropriate MCP proxy server at once,
+	// so that its clients are only notified once about the change
+	m.addProxyTools(s, proxyTools...)
 
-		// also add the tool to the in-memory tool instance tracker
-		m.addToolInstance(tool)
-		// notify any registered callbacks about the tool addition
-		m.notifyToolAddition(tool.Name)
+	// also add the tools to the in-memory tool instance tracker
+	names := make([]string, len(proxyTools))
+	instances := make([]mcp.Tool, len(proxyTools))
+	for i, t := range proxyTools {
+		names[i] = t.Tool.Name
+		instances[i] = t.Tool
+	}
+	m.addToolInstances(instances...)
+	// notify any registered callbacks about the tool additions
+	m.notifyToolAddition(names...)
+}
+
+// addProxyTools adds tools of the given MCP server to the MCP proxy server of its transport, all at once.
+func (m *MCPService) addProxyTools(s *model.McpServer, tools ...server.ServerTool) {
+	// the clients of the proxy server are notified even if no tool is added
+	if len(tools) == 0 {
+		return
+	}
+	if s.Transport == types.TransportSSE {
+		m.sseMcpProxyServer.AddTools(tools...)
+	} else {
+		m.mcpProxyServer.AddTools(tools...)
 	}
-	return nil
 }
 
 // deregisterServerTools deletes all tools that belong to an MCP server from the DB.
 // It also removes the tools from the MCP proxy server.
 func (m *MCPService) deregisterServerTools(s *model.McpServer) error {
+	if err := m.loadServerTools(s.Name); err != nil {
+		return err
+	}
+
 	// load all tools for the server from the DB so we can delete them from the MCP proxy
-	tools, err := m.ListToolsByServer(s.Name)
+	tools, err := m.listToolsByServer(m.primary(), s.Name)
 	if err != nil {
 		return fmt.Errorf("failed to list tools for server %s: %w", s.Name, err)
 	}
@@ -397,13 +666,16 @@ func (m *MCPService) deregisterServerTools(s *model.McpServer) error {
 	return nil
 }
 
-// addToolInstance adds a tool instance to the in-memory tool instance tracker.
+// addToolInstances adds one or more tool instances to the in-memory tool instance tracker.
 // This method does not check for duplicates.
 // If a tool with the same name already exists, it is overwritten.
-func (m *MCPService) addToolInstance(tool mcp.Tool) {
+func (m *MCPService) addToolInstances(tools ...mcp.Tool) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.toolInstances[tool.GetName()] = tool
+	for _, tool := range tools {
+		m.toolInstances[tool.GetName()] = tool
+	}
+	m.toolsVersion.Add(1)
 }
 
 // deleteToolInstances deletes one or more tool instances from the in-memory tool instance tracker.
@@ -413,6 +685,14 @@ func (m *MCPService) deleteToolInstances(toolNames ...string) {
 	for _, name := range toolNames {
 		delete(m.toolInstances, name)
 	}
+	m.toolsVersion.Add(1)
+}
+
+// ToolsVersion returns a number that changes whenever tools are added to or removed from the MCP proxy servers.
+// Since the tool instances are tracked after the proxy servers are changed, anything computed from the tools served
+// by the proxy after reading a version is up-to-date as long as the version remains the same.
+func (m *MCPService) ToolsVersion() uint64 {
+	return m.toolsVersion.Load()
 }
 
 // notifyToolDeletion calls all registered tool deletion callbacks with the given tool names.
@@ -422,18 +702,24 @@ func (m *MCPService) notifyToolDeletion(toolNames ...string) {
 
 // notifyToolAddition calls all registered tool addition callbacks with the given tool names.
 // This method works on best-effort basis. If a callback fails, it logs the error but does not propagate it.
-func (m *MCPService) notifyToolAddition(toolName string) {
-	if err := m.toolAdditionCallback(toolName); err != nil {
+func (m *MCPService) notifyToolAddition(toolNames ...string) {
+	if len(toolNames) == 0 {
+		return
+	}
+	if err := m.toolAdditionCallback(toolNames...); err != nil {
 		// log the issue, but do not fail the entire operation
-		// as the tool has already been added successfully
-		log.Printf("[ERROR] tool addition callback failed for tool %s: %v", toolName, err)
+		// as the tools have already been added successfully
+		m.logger.Error(
+			"tool addition callback failed",
+			logger.String("tools", strings.Join(toolNames, ",")), logger.ErrorField(err),
+		)
 	}
 }
 
-// convertToolCallResToAPIRes converts an MCP CallToolResult to types.ToolInvokeResult.
+// ConvertToolResult converts the response of a tool call (see CallTool) to types.ToolInvokeResult.
 // This function handles the conversion from the SDK types to the internal types
 // used by MCPJungle, with proper error handling and validation.
-func (m *MCPService) convertToolCallResToAPIRes(resp *mcp.CallToolResult) (*types.ToolInvokeResult, error) {
+func (m *MCPService) ConvertToolResult(resp *mcp.CallToolResult) (*types.ToolInvokeResult, error) {
 	// Convert content
 	contentList, err := m.convertToolCallRespContent(resp.Content)
 	if err != nil {
@@ -460,6 +746,12 @@ func (m *MCPService) convertToolCallRespContent(content []mcp.Content) ([]map[st
 	contentList := make([]map[string]any, 0, len(content))
 
 	for i, item := range content {
+		// the common kinds of content are converted directly
+		if contentMap, ok := contentToMap(item); ok {
+			contentList = append(contentList, contentMap)
+			continue
+		}
+
 		// Use a single marshal/unmarshal with proper error handling
 		serialized, err := json.Marshal(item)
 		if err != nil {
@@ -0,0 +1,168 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+)
+
+// Option configures an MCPService when it is created.
+type Option func(*MCPService)
+
+// WithLazyToolLoading makes the service load the tools of each MCP server into the MCP proxy servers
+// the first time they are needed, instead of loading all of them when it is created.
+// This cuts the start-up time of huge registries, at the cost of slower first calls to every server.
+//
+// The tools of a server are loaded when one of them is called through the proxy or looked up
+// (see GetToolInstance), and the tools of all the servers are loaded when the proxy's tools are listed.
+// The proxy servers must be created with the hooks added by AddToolLoadingHooks for that to happen.
+// Use WarmUpTools to load the tools in the background.
+func WithLazyToolLoading() Option {
+	return func(m *MCPService) {
+		m.toolLoader = &toolLoader{}
+	}
+}
+
+// toolLoader keeps track of the MCP servers whose tools are not loaded in the MCP proxy servers yet.
+type toolLoader struct {
+	// mu is held while tools are loaded, so that the tools of a server are only loaded once
+	mu sync.Mutex
+	// pending holds the names of the servers whose tools are not loaded yet
+	pending map[string]struct{}
+}
+
+// isPending returns true if the tools of the given server are not loaded yet.
+func (l *toolLoader) isPending(name string) bool {
+	_, ok := l.pending[name]
+	return ok
+}
+
+// initLazyToolLoading marks all the registered MCP servers as having their tools pending.
+func (m *MCPService) initLazyToolLoading() error {
+	var names []string
+	if err := m.primary().Model(&model.McpServer{}).Pluck("name", &names).Error; err != nil {
+		return fmt.Errorf("failed to list MCP servers from DB: %w", err)
+	}
+	m.toolLoader.pending = make(map[string]struct{}, len(names))
+	for _, name := range names {
+		m.toolLoader.pending[name] = struct{}{}
+	}
+	return nil
+}
+
+// loadServerTools loads the tools of the given MCP server into the MCP proxy servers,
+// unless they are loaded already. It does nothing if the tools are not loaded lazily.
+func (m *MCPService) loadServerTools(name string) error {
+	l := m.toolLoader
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.isPending(name) {
+		return nil
+	}
+	if err := m.addServerToolsToProxy(name); err != nil {
+		return err
+	}
+	delete(l.pending, name)
+	return nil
+}
+
+// loadAllTools loads the tools of all the MCP servers that are not loaded yet into the MCP proxy servers.
+// It returns the errors of all the servers whose tools failed to load.
+func (m *MCPService) loadAllTools() error {
+	l := m.toolLoader
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var errs []error
+	for name := range l.pending {
+		if err := m.addServerToolsToProxy(name); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		delete(l.pending, name)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to load the tools of %d MCP servers: %w", len(errs), errs[0])
+	}
+	return nil
+}
+
+// addServerToolsToProxy reads the enabled tools of an MCP server from the DB and adds them to the MCP proxy server.
+func (m *MCPService) addServerToolsToProxy(name string) error {
+	db := m.primary()
+	s, err := m.getMcpServer(db, name)
+	if err != nil {
+		return fmt.Errorf("failed to get MCP server %s from DB: %w", name, err)
+	}
+	tools, err := m.listToolsByServer(db, name)
+	if err != nil {
+		return err
+	}
+
+	proxyTools := make([]server.ServerTool, 0, len(tools))
+	instances := make([]mcp.Tool, 0, len(tools))
+	for _, tm := range tools {
+		if !tm.Enabled {
+			continue
+		}
+		tool, err := convertToolModelToMcpObject(&tm)
+		if err != nil {
+			return fmt.Errorf("failed to convert tool model to MCP object for tool %s: %w", tm.Name, err)
+		}
+		proxyTools = append(proxyTools, server.ServerTool{Tool: tool, Handler: m.MCPProxyToolCallHandler})
+		instances = append(instances, tool)
+	}
+
+	m.addProxyTools(s, proxyTools...)
+	m.addToolInstances(instances...)
+	return nil
+}
+
+// AddToolLoadingHooks adds the hooks that load the tools needed by the requests of an MCP proxy server to the given
+// hooks, which must be set on the MCP proxy servers (see server.WithHooks).
+// The hooks do nothing unless the tools are loaded lazily (see WithLazyToolLoading).
+func (m *MCPService) AddToolLoadingHooks(hooks *server.Hooks) {
+	hooks.AddBeforeListTools(func(ctx context.Context, id any, message *mcp.ListToolsRequest) {
+		if err := m.loadAllTools(); err != nil {
+			m.logger.Error("failed to load tools before listing them", logger.ErrorField(err))
+		}
+	})
+	hooks.AddBeforeCallTool(func(ctx context.Context, id any, message *mcp.CallToolRequest) {
+		serverName, _, ok := splitServerToolName(message.Params.Name)
+		if !ok {
+			return
+		}
+		if err := m.loadServerTools(serverName); err != nil {
+			m.logger.Error(
+				"failed to load tools before calling one of them",
+				logger.String("server", serverName), logger.ErrorField(err),
+			)
+		}
+	})
+}
+
+// WarmUpTools loads the tools of all the MCP servers that are not loaded yet, so that the first calls to them
+// don't have to wait for it. It is meant to run in the background after the service starts serving requests.
+func (m *MCPService) WarmUpTools() {
+	if m.toolLoader == nil {
+		return
+	}
+	started := time.Now()
+	if err := m.loadAllTools(); err != nil {
+		m.logger.Error("failed to warm up tools", logger.ErrorField(err))
+		return
+	}
+	m.logger.Info("warmed up tools", logger.String("elapsed", time.Since(started).String()))
+}
@@ -0,0 +1,80 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestLazyToolLoading(t *testing.T) {
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	git := setup.CreateTestMcpServer("git", "", types.TransportStreamableHTTP, []byte(`{}`))
+	fs := setup.CreateTestMcpServer("fs", "", types.TransportStreamableHTTP, []byte(`{}`))
+	schema := []byte(`{"type": "object"}`)
+	setup.CreateTestTool("status", "", git.ID, true, schema)
+	setup.CreateTestTool("read_file", "", fs.ID, true, schema)
+	setup.CreateTestTool("write_file", "", fs.ID, true, schema)
+
+	hooks := &server.Hooks{}
+	proxy := server.NewMCPServer("proxy", "test", server.WithToolCapabilities(true), server.WithHooks(hooks))
+	m, err := NewMCPService(
+		setup.DB,
+		proxy,
+		server.NewMCPServer("sse proxy", "test"),
+		telemetry.NewNoopCustomMetrics(),
+		logger.NewNop(),
+		WithLazyToolLoading(),
+	)
+	testhelpers.AssertNoError(t, err)
+	m.AddToolLoadingHooks(hooks)
+
+	testhelpers.AssertEqual(t, 0, len(proxy.ListTools()))
+
+	// looking up a tool loads the tools of its server only
+	_, ok := m.GetToolInstance("git__status")
+	testhelpers.AssertTrue(t, ok, "expected the tool to be loaded on first use")
+	testhelpers.AssertTrue(t, proxy.GetTool("git__status") != nil, "expected the tool to be added to the proxy")
+	testhelpers.AssertTrue(t, proxy.GetTool("fs__read_file") == nil, "expected the tools of other servers not to be loaded")
+
+	// a tool changed before its server's tools are loaded stays changed once they are
+	_, err = m.DisableTools("fs__write_file")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, proxy.GetTool("fs__write_file") == nil, "expected the disabled tool not to be served")
+
+	// listing the tools of the proxy loads all of them
+	proxy.HandleMessage(context.Background(), []byte(`{"jsonrpc": "2.0", "id": 1, "method": "tools/list"}`))
+	testhelpers.AssertEqual(t, 2, len(proxy.ListTools()))
+	testhelpers.AssertTrue(t, proxy.GetTool("fs__read_file") != nil, "expected all the tools to be loaded when listed")
+	testhelpers.AssertTrue(t, proxy.GetTool("fs__write_file") == nil, "expected the disabled tool not to be served")
+}
+
+func TestWarmUpTools(t *testing.T) {
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	git := setup.CreateTestMcpServer("git", "", types.TransportStreamableHTTP, []byte(`{}`))
+	events := setup.CreateTestMcpServer("events", "", types.TransportSSE, []byte(`{}`))
+	schema := []byte(`{"type": "object"}`)
+	setup.CreateTestTool("status", "", git.ID, true, schema)
+	setup.CreateTestTool("subscribe", "", events.ID, true, schema)
+
+	proxy := server.NewMCPServer("proxy", "test")
+	sseProxy := server.NewMCPServer("sse proxy", "test")
+	m, err := NewMCPService(
+		setup.DB, proxy, sseProxy, telemetry.NewNoopCustomMetrics(), logger.NewNop(), WithLazyToolLoading(),
+	)
+	testhelpers.AssertNoError(t, err)
+	version := m.ToolsVersion()
+
+	m.WarmUpTools()
+	testhelpers.AssertTrue(t, proxy.GetTool("git__status") != nil, "expected the tool to be loaded")
+	testhelpers.AssertTrue(t, sseProxy.GetTool("events__subscribe") != nil, "expected the SSE tool to be loaded in the SSE proxy")
+	testhelpers.AssertTrue(t, m.ToolsVersion() != version, "expected the tools version to change")
+}
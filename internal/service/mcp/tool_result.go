@@ -0,0 +1,131 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ToolResultSize returns the approximate size of the content of a tool call's response, in bytes.
+// Only the bulk of every content item (text, base64 data) is counted, so the size of the encoded response is
+// slightly larger.
+func ToolResultSize(res *mcp.CallToolResult) int {
+	size := 0
+	for _, item := range res.Content {
+		switch c := item.(type) {
+		case mcp.TextContent:
+			size += len(c.Text)
+		case mcp.ImageContent:
+			size += len(c.Data)
+		case mcp.AudioContent:
+			size += len(c.Data)
+		case mcp.EmbeddedResource:
+			switch r := c.Resource.(type) {
+			case mcp.TextResourceContents:
+				size += len(r.Text)
+			case mcp.BlobResourceContents:
+				size += len(r.Blob)
+			}
+		}
+	}
+	return size
+}
+
+// contentToMap converts a content item of a tool call's response to the map its JSON encoding decodes to,
+// without encoding it. This is only possible for the common kinds of content without annotations & metadata,
+// false is returned for the others.
+func contentToMap(item mcp.Content) (map[string]any, bool) {
+	switch c := item.(type) {
+	case mcp.TextContent:
+		if c.Annotations != nil || c.Meta != nil {
+			return nil, false
+		}
+		return map[string]any{"type": c.Type, "text": c.Text}, true
+	case mcp.ImageContent:
+		if c.Annotations != nil || c.Meta != nil {
+			return nil, false
+		}
+		return map[string]any{"type": c.Type, "data": c.Data, "mimeType": c.MIMEType}, true
+	case mcp.AudioContent:
+		if c.Annotations != nil || c.Meta != nil {
+			return nil, false
+		}
+		return map[string]any{"type": c.Type, "data": c.Data, "mimeType": c.MIMEType}, true
+	case mcp.EmbeddedResource:
+		if c.Annotations != nil || c.Meta != nil {
+			return nil, false
+		}
+		var resource map[string]any
+		switch r := c.Resource.(type) {
+		case mcp.TextResourceContents:
+			if r.Meta != nil {
+				return nil, false
+			}
+			resource = map[string]any{"uri": r.URI, "text": r.Text}
+			if r.MIMEType != "" {
+				resource["mimeType"] = r.MIMEType
+			}
+		case mcp.BlobResourceContents:
+			if r.Meta != nil {
+				return nil, false
+			}
+			resource = map[string]any{"uri": r.URI, "blob": r.Blob}
+			if r.MIMEType != "" {
+				resource["mimeType"] = r.MIMEType
+			}
+		default:
+			return nil, false
+		}
+		return map[string]any{"type": c.Type, "resource": resource}, true
+	}
+	return nil, false
+}
+
+// EncodeToolInvokeResult writes the response of a tool call to w as the JSON encoding of the equivalent
+// types.ToolInvokeResult, ie, what InvokeTool would have returned.
+// The content items are encoded and written one at a time, so that a large response isn't copied in memory
+// as a whole. If w is an HTTP response, the response can be sent to the client while it is being encoded.
+func (m *MCPService) EncodeToolInvokeResult(w io.Writer, res *mcp.CallToolResult) error {
+	head, err := json.Marshal(struct {
+		Meta              map[string]any `json:"_meta,omitempty"`
+		IsError           bool           `json:"isError,omitempty"`
+		StructuredContent any            `json:"structuredContent,omitempty"`
+	}{
+		Meta:              m.convertMCPMetaToMap(res.Meta),
+		IsError:           res.IsError,
+		StructuredContent: res.StructuredContent,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal tool call response: %w", err)
+	}
+
+	// the content list is appended to the other fields of the object
+	head = head[:len(head)-1]
+	if len(head) > 1 {
+		head = append(head, ',')
+	}
+	head = append(head, `"content":[`...)
+	if _, err := w.Write(head); err != nil {
+		return err
+	}
+
+	for i, item := range res.Content {
+		serialized, err := json.Marshal(item)
+		if err != nil {
+			return fmt.Errorf("failed to marshal content item %d: %w", i, err)
+		}
+		if i > 0 {
+			if _, err := w.Write([]byte{','}); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(serialized); err != nil {
+			return err
+		}
+	}
+
+	_, err = w.Write([]byte("]}"))
+	return err
+}
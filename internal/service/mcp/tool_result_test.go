@@ -0,0 +1,116 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestToolResultSize(t *testing.T) {
+	res := &mcp.CallToolResult{Content: []mcp.Content{
+		mcp.NewTextContent("hello"),
+		mcp.NewImageContent("aGVsbG8=", "image/png"),
+		mcp.NewEmbeddedResource(mcp.TextResourceContents{URI: "file:///a", Text: "abc"}),
+		mcp.NewEmbeddedResource(mcp.BlobResourceContents{URI: "file:///b", Blob: "YWJj"}),
+		mcp.NewResourceLink("file:///c", "c", "", ""),
+	}}
+	testhelpers.AssertEqual(t, 5+8+3+4, ToolResultSize(res))
+}
+
+func TestEncodeToolInvokeResult(t *testing.T) {
+	tests := map[string]*mcp.CallToolResult{
+		"no content": {},
+		"text":       {Content: []mcp.Content{mcp.NewTextContent("hello"), mcp.NewTextContent("<world>")}},
+		"error": {
+			Content: []mcp.Content{mcp.NewTextContent("boom")},
+			IsError: true,
+		},
+		"meta & structured content": {
+			Result:            mcp.Result{Meta: &mcp.Meta{AdditionalFields: map[string]any{"source": "test"}}},
+			Content:           []mcp.Content{mcp.NewImageContent("aGVsbG8=", "image/png")},
+			StructuredContent: map[string]any{"answer": 42},
+		},
+	}
+
+	for name, res := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := MCPService{}
+			var buf bytes.Buffer
+			testhelpers.AssertNoError(t, m.EncodeToolInvokeResult(&buf, res))
+
+			// the encoded response must be the same as the one of the converted result
+			converted, err := m.ConvertToolResult(res)
+			testhelpers.AssertNoError(t, err)
+			expected, err := json.Marshal(converted)
+			testhelpers.AssertNoError(t, err)
+
+			var got, want any
+			testhelpers.AssertNoError(t, json.Unmarshal(buf.Bytes(), &got))
+			testhelpers.AssertNoError(t, json.Unmarshal(expected, &want))
+			if !reflect.DeepEqual(want, got) {
+				t.Errorf("expected %s, got %s", expected, buf.String())
+			}
+		})
+	}
+}
+
+// toolResultContents holds a content item of each kind a tool call's response may contain.
+var toolResultContents = []mcp.Content{
+	mcp.NewTextContent("hello"),
+	mcp.NewImageContent("aGVsbG8=", "image/png"),
+	mcp.NewImageContent("aGVsbG8=", ""),
+	mcp.NewAudioContent("aGVsbG8=", "audio/wav"),
+	mcp.NewEmbeddedResource(mcp.TextResourceContents{URI: "file:///a", MIMEType: "text/plain", Text: "abc"}),
+	mcp.NewEmbeddedResource(mcp.TextResourceContents{URI: "file:///a", Text: "abc"}),
+	mcp.NewEmbeddedResource(mcp.BlobResourceContents{URI: "file:///b", MIMEType: "image/png", Blob: "YWJj"}),
+	mcp.NewEmbeddedResource(mcp.BlobResourceContents{URI: "file:///b", Blob: "YWJj"}),
+	mcp.NewResourceLink("file:///c", "c", "a file", "text/plain"),
+	mcp.TextContent{
+		Annotated: mcp.Annotated{Annotations: &mcp.Annotations{Audience: []mcp.Role{mcp.RoleUser}, Priority: 0.5}},
+		Type:      "text",
+		Text:      "annotated",
+	},
+	mcp.TextContent{Meta: &mcp.Meta{AdditionalFields: map[string]any{"count": 1}}, Type: "text", Text: "meta"},
+	mcp.NewEmbeddedResource(mcp.TextResourceContents{
+		Meta: &mcp.Meta{AdditionalFields: map[string]any{"count": 1}},
+		URI:  "file:///a",
+		Text: "meta",
+	}),
+}
+
+// TestConvertToolCallRespContentMatchesJSON checks that the contents converted without a JSON round-trip
+// are converted exactly like the others.
+func TestConvertToolCallRespContentMatchesJSON(t *testing.T) {
+	m := MCPService{}
+	for _, item := range toolResultContents {
+		serialized, err := json.Marshal(item)
+		testhelpers.AssertNoError(t, err)
+		var expected map[string]any
+		testhelpers.AssertNoError(t, json.Unmarshal(serialized, &expected))
+
+		result, err := m.convertToolCallRespContent([]mcp.Content{item})
+		testhelpers.AssertNoError(t, err)
+		if !reflect.DeepEqual(expected, result[0]) {
+			t.Errorf("content %s:\nexpected: %#v\nactual:   %#v", serialized, expected, result[0])
+		}
+	}
+}
+
+func BenchmarkConvertToolCallRespContent(b *testing.B) {
+	m := MCPService{}
+	content := []mcp.Content{
+		mcp.NewTextContent("The current time in UTC is 12:00"),
+		mcp.NewImageContent("aGVsbG8=", "image/png"),
+		mcp.NewEmbeddedResource(mcp.TextResourceContents{URI: "file:///a", MIMEType: "text/plain", Text: "abc"}),
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := m.convertToolCallRespContent(content); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
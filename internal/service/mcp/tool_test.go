@@ -1,12 +1,23 @@
 package mcp
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"reflect"
+	"slices"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/gorm"
 )
 
 func TestConvertMCPResponse(t *testing.T) {
@@ -225,7 +236,7 @@ func TestConvertMCPResponse(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			m := MCPService{}
-			result, err := m.convertToolCallResToAPIRes(tt.input)
+			result, err := m.ConvertToolResult(tt.input)
 
 			// Check error expectations
 			if tt.expectedError != "" {
@@ -470,3 +481,293 @@ func TestConvertMCPMeta(t *testing.T) {
 		})
 	}
 }
+
+func TestListToolsFiltered(t *testing.T) {
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	git := setup.CreateTestMcpServer("git", "", types.TransportStreamableHTTP, []byte(`{}`))
+	fs := setup.CreateTestMcpServer("fs", "", types.TransportStreamableHTTP, []byte(`{}`))
+	setup.CreateTestTool("status", "", git.ID, true, nil)
+	setup.CreateTestTool("read_file", "", fs.ID, true, nil)
+	setup.CreateTestTool("commit", "", git.ID, true, nil)
+	disabled := setup.CreateTestTool("write_file", "", fs.ID, true, nil)
+	testhelpers.AssertNoError(t, setup.DB.Model(disabled).Update("enabled", false).Error)
+
+	m := &MCPService{db: setup.DB}
+	enabled, notEnabled := true, false
+
+	tests := []struct {
+		name     string
+		opts     *types.ListToolsOptions
+		expected []string
+	}{
+		{"no options", &types.ListToolsOptions{}, []string{"git__status", "fs__read_file", "git__commit", "fs__write_file"}},
+		{"by server", &types.ListToolsOptions{Server: "fs"}, []string{"fs__read_file", "fs__write_file"}},
+		{"enabled only", &types.ListToolsOptions{Enabled: &enabled}, []string{"git__status", "fs__read_file", "git__commit"}},
+		{"disabled only", &types.ListToolsOptions{Enabled: &notEnabled}, []string{"fs__write_file"}},
+		{"name contains", &types.ListToolsOptions{NameContains: "FILE"}, []string{"fs__read_file", "fs__write_file"}},
+		{"name contains server", &types.ListToolsOptions{NameContains: "git__"}, []string{"git__status", "git__commit"}},
+		{"sort by name", &types.ListToolsOptions{Sort: types.ToolSortName}, []string{"git__commit", "fs__read_file", "git__status", "fs__write_file"}},
+		{"sort by server", &types.ListToolsOptions{Sort: types.ToolSortServer}, []string{"fs__read_file", "fs__write_file", "git__commit", "git__status"}},
+		{
+			"combined filters and sort",
+			&types.ListToolsOptions{Enabled: &enabled, NameContains: "_", Sort: types.ToolSortServer},
+			[]string{"fs__read_file", "git__commit", "git__status"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tools, total, err := m.ListToolsFiltered(tt.opts, types.Page{})
+			testhelpers.AssertNoError(t, err)
+			testhelpers.AssertEqual(t, int64(len(tt.expected)), total)
+
+			names := make([]string, len(tools))
+			for i, tool := range tools {
+				names[i] = tool.Name
+			}
+			if !reflect.DeepEqual(tt.expected, names) {
+				t.Errorf("Expected %v, got %v", tt.expected, names)
+			}
+		})
+	}
+
+	// the total counts all the matching tools, not just the page of them
+	tools, total, err := m.ListToolsFiltered(&types.ListToolsOptions{Sort: types.ToolSortName}, types.Page{Offset: 1, Limit: 2})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, int64(4), total)
+	testhelpers.AssertEqual(t, 2, len(tools))
+	testhelpers.AssertEqual(t, "fs__read_file", tools[0].Name)
+	testhelpers.AssertEqual(t, "git__status", tools[1].Name)
+
+	_, _, err = m.ListToolsFiltered(&types.ListToolsOptions{Sort: "invalid"}, types.Page{})
+	testhelpers.AssertError(t, err)
+}
+
+func TestDisableToolsBulk(t *testing.T) {
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	git := setup.CreateTestMcpServer("git", "", types.TransportStreamableHTTP, []byte(`{}`))
+	fs := setup.CreateTestMcpServer("fs", "", types.TransportStreamableHTTP, []byte(`{}`))
+	schema := []byte(`{"type": "object"}`)
+	setup.CreateTestTool("status", "", git.ID, true, schema)
+	setup.CreateTestTool("commit", "", git.ID, true, schema)
+	setup.CreateTestTool("read_file", "", fs.ID, true, schema)
+	setup.CreateTestTool("write_file", "", fs.ID, true, schema)
+
+	m, err := NewMCPService(
+		setup.DB,
+		server.NewMCPServer("proxy", "test"),
+		server.NewMCPServer("sse proxy", "test"),
+		telemetry.NewNoopCustomMetrics(),
+		logger.NewNop(),
+	)
+	testhelpers.AssertNoError(t, err)
+
+	results := m.DisableToolsBulk([]string{"git", "fs__*_file", "fs__unknown", "nomatch__*", "["})
+	testhelpers.AssertEqual(t, 5, len(results))
+
+	testhelpers.AssertEqual(t, "git", results[0].Entity)
+	testhelpers.AssertTrue(t, slices.Equal([]string{"git__status", "git__commit"}, results[0].Affected), "unexpected tools disabled by server name")
+	testhelpers.AssertEqual(t, "", results[0].Error)
+
+	testhelpers.AssertTrue(t, slices.Equal([]string{"fs__read_file", "fs__write_file"}, results[1].Affected), "unexpected tools disabled by pattern")
+	testhelpers.AssertEqual(t, "", results[1].Error)
+
+	// failures are reported per entity
+	testhelpers.AssertTrue(t, results[2].Error != "", "Expected an error for a non-existent tool")
+	testhelpers.AssertStringContains(t, results[3].Error, "no tools match")
+	testhelpers.AssertStringContains(t, results[4].Error, "invalid pattern")
+
+	enabled := true
+	remaining, _, err := m.ListToolsFiltered(&types.ListToolsOptions{Enabled: &enabled}, types.Page{})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 0, len(remaining))
+
+	// disabling already disabled tools is not an error
+	results = m.DisableToolsBulk([]string{"git__status"})
+	testhelpers.AssertEqual(t, "", results[0].Error)
+
+	results = m.EnableToolsBulk([]string{"*__status"})
+	testhelpers.AssertTrue(t, slices.Equal([]string{"git__status"}, results[0].Affected), "unexpected tools enabled by pattern")
+}
+
+func TestInvokeToolCallsInvocationCallback(t *testing.T) {
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	m, err := NewMCPService(
+		setup.DB,
+		server.NewMCPServer("proxy", "test"),
+		server.NewMCPServer("sse proxy", "test"),
+		telemetry.NewNoopCustomMetrics(),
+		logger.NewNop(),
+	)
+	testhelpers.AssertNoError(t, err)
+
+	var (
+		calledName    string
+		calledArgs    map[string]any
+		calledOutcome telemetry.ToolCallOutcome
+		calledErr     error
+	)
+	m.SetToolInvocationCallback(func(
+		ctx context.Context, name string, args map[string]any,
+		outcome telemetry.ToolCallOutcome, err error, elapsedTime time.Duration,
+	) {
+		calledName, calledArgs, calledOutcome, calledErr = name, args, outcome, err
+	})
+
+	_, err = m.InvokeTool(context.Background(), "unknown__tool", map[string]any{"x": 1})
+	testhelpers.AssertTrue(t, err != nil, "Expected an error for a tool of an unknown server")
+
+	testhelpers.AssertEqual(t, "unknown__tool", calledName)
+	testhelpers.AssertEqual(t, 1, calledArgs["x"])
+	testhelpers.AssertEqual(t, telemetry.ToolCallOutcomeError, calledOutcome)
+	testhelpers.AssertTrue(t, calledErr == err, "Expected the callback to receive the error returned to the caller")
+}
+
+// slowCallMetrics counts the slow tool calls recorded
+type slowCallMetrics struct {
+	telemetry.NoopCustomMetrics
+	slowCalls []string
+}
+
+func (m *slowCallMetrics) RecordSlowToolCall(ctx context.Context, serverName, toolName string, _ time.Duration) {
+	m.slowCalls = append(m.slowCalls, mergeServerToolNames(serverName, toolName))
+}
+
+// warnLogger keeps the fields of the warnings logged
+type warnLogger struct {
+	logger.Logger
+	warnings []map[string]any
+}
+
+func (l *warnLogger) Warn(msg string, fields ...logger.Field) {
+	w := map[string]any{"msg": msg}
+	for _, f := range fields {
+		w[f.Key] = f.Value
+	}
+	l.warnings = append(l.warnings, w)
+}
+
+func TestRecordToolCallDetectsSlowCalls(t *testing.T) {
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	metrics := &slowCallMetrics{}
+	l := &warnLogger{Logger: logger.NewNop()}
+	m, err := NewMCPService(
+		setup.DB,
+		server.NewMCPServer("proxy", "test"),
+		server.NewMCPServer("sse proxy", "test"),
+		metrics,
+		l,
+	)
+	testhelpers.AssertNoError(t, err)
+
+	ctx := context.WithValue(context.Background(), "client", &model.McpClient{Name: "cursor"})
+
+	// slow calls aren't detected unless a threshold is set
+	m.recordToolCall(ctx, "github", "search", nil, telemetry.ToolCallOutcomeSuccess, nil, time.Hour)
+	testhelpers.AssertEqual(t, 0, len(metrics.slowCalls))
+
+	m.SetSlowToolCallThreshold(time.Second)
+	m.recordToolCall(ctx, "github", "search", nil, telemetry.ToolCallOutcomeSuccess, nil, 500*time.Millisecond)
+	m.recordToolCall(ctx, "github", "search", nil, telemetry.ToolCallOutcomeError, nil, 2*time.Second)
+
+	testhelpers.AssertEqual(t, 1, len(metrics.slowCalls))
+	testhelpers.AssertEqual(t, "github__search", metrics.slowCalls[0])
+	testhelpers.AssertEqual(t, 1, len(l.warnings))
+	w := l.warnings[0]
+	testhelpers.AssertEqual(t, "github", w["server"])
+	testhelpers.AssertEqual(t, "search", w["tool"])
+	testhelpers.AssertEqual(t, "cursor", w["client"])
+	testhelpers.AssertEqual(t, int64(2000), w["duration_ms"])
+	testhelpers.AssertEqual(t, "error", w["outcome"])
+}
+
+// countQueries counts the SELECT queries run on the DB from now on.
+func countQueries(t testing.TB, db *gorm.DB) *atomic.Int32 {
+	var queries atomic.Int32
+	err := db.Callback().Query().After("gorm:query").Register("test:count_queries", func(*gorm.DB) {
+		queries.Add(1)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &queries
+}
+
+// setupRegistry returns a DB holding the given number of servers, each providing the given number of tools & prompts.
+func setupRegistry(t testing.TB, servers, entitiesPerServer int) *gorm.DB {
+	db, err := testhelpers.CreateTestDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&model.McpServer{}, &model.Tool{}, &model.Prompt{}); err != nil {
+		t.Fatal(err)
+	}
+	for i := range servers {
+		s := &model.McpServer{Name: fmt.Sprintf("server_%d", i), Transport: types.TransportStreamableHTTP, Config: []byte(`{}`)}
+		if err := db.Create(s).Error; err != nil {
+			t.Fatal(err)
+		}
+		tools := make([]model.Tool, entitiesPerServer)
+		prompts := make([]model.Prompt, entitiesPerServer)
+		for j := range entitiesPerServer {
+			tools[j] = model.Tool{ServerID: s.ID, Name: fmt.Sprintf("tool_%d", j)}
+			prompts[j] = model.Prompt{ServerID: s.ID, Name: fmt.Sprintf("prompt_%d", j)}
+		}
+		if err := db.CreateInBatches(tools, 100).Error; err != nil {
+			t.Fatal(err)
+		}
+		if err := db.CreateInBatches(prompts, 100).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	return db
+}
+
+func TestListToolsAndPromptsInSingleQuery(t *testing.T) {
+	db := setupRegistry(t, 3, 5)
+	m := &MCPService{db: db}
+	queries := countQueries(t, db)
+
+	tools, err := m.ListTools()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 15, len(tools))
+	testhelpers.AssertEqual(t, "server_0__tool_0", tools[0].Name)
+	testhelpers.AssertEqual(t, "server_2__tool_4", tools[14].Name)
+	testhelpers.AssertEqual(t, int32(1), queries.Load())
+
+	prompts, err := m.ListPrompts()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 15, len(prompts))
+	testhelpers.AssertEqual(t, "server_1__prompt_0", prompts[5].Name)
+	testhelpers.AssertEqual(t, int32(2), queries.Load())
+}
+
+// BenchmarkListTools lists the tools of a registry holding thousands of them.
+// Before the servers were fetched along with their tools, it ran one query per tool.
+func BenchmarkListTools(b *testing.B) {
+	m := &MCPService{db: setupRegistry(b, 50, 100)}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := m.ListTools(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkListPrompts(b *testing.B) {
+	m := &MCPService{db: setupRegistry(b, 50, 100)}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := m.ListPrompts(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
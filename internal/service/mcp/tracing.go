@@ -0,0 +1,58 @@
+package mcp
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/mcpjungle/mcpjungle/internal/service/mcp"
+
+const (
+	attrMCPMethodName = "mcp.method.name"
+	attrMCPServerName = "mcp.server.name"
+	attrMCPToolName   = "mcp.tool.name"
+	attrMCPPromptName = "mcp.prompt.name"
+)
+
+// startToolCallSpan starts a span covering a tool call forwarded to an upstream MCP server.
+// The span is a child of the span in ctx, if any, and the returned context carries it so that
+// the trace context gets propagated to the upstream server.
+func startToolCallSpan(ctx context.Context, serverName, toolName string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(
+		ctx,
+		"tools/call "+serverName+serverToolNameSep+toolName,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String(attrMCPMethodName, "tools/call"),
+			attribute.String(attrMCPServerName, serverName),
+			attribute.String(attrMCPToolName, toolName),
+		),
+	)
+}
+
+// startPromptSpan starts a span covering a prompt request forwarded to an upstream MCP server.
+func startPromptSpan(ctx context.Context, serverName, promptName string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(
+		ctx,
+		"prompts/get "+serverName+serverPromptNameSep+promptName,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String(attrMCPMethodName, "prompts/get"),
+			attribute.String(attrMCPServerName, serverName),
+			attribute.String(attrMCPPromptName, promptName),
+		),
+	)
+}
+
+// endSpan records the error of the operation, if any, and ends the span.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,110 @@
+package mcp
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInvokeToolPropagatesTraceContext(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	originalTP, originalPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(originalTP)
+		otel.SetTextMapPropagator(originalPropagator)
+	}()
+
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	upstreamServer := server.NewMCPServer("upstream", "test")
+	upstreamServer.AddTool(
+		mcp.NewTool("traceparent"),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(req.Header.Get("traceparent")), nil
+		},
+	)
+	upstream := httptest.NewServer(server.NewStreamableHTTPServer(upstreamServer))
+	defer upstream.Close()
+
+	setup.CreateTestMcpServer(
+		"upstream", "", types.TransportStreamableHTTP, []byte(`{"url": "`+upstream.URL+`"}`),
+	)
+
+	m, err := NewMCPService(
+		setup.DB,
+		server.NewMCPServer("proxy", "test"),
+		server.NewMCPServer("sse proxy", "test"),
+		telemetry.NewNoopCustomMetrics(),
+		logger.NewNop(),
+	)
+	testhelpers.AssertNoError(t, err)
+
+	// the span of the incoming request, eg- created by the HTTP middleware
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "incoming request")
+	result, err := m.InvokeTool(ctx, "upstream__traceparent", nil)
+	parent.End()
+	testhelpers.AssertNoError(t, err)
+
+	var callSpan sdktrace.ReadOnlySpan
+	for _, s := range recorder.Ended() {
+		if s.Name() == "tools/call upstream__traceparent" {
+			callSpan = s
+		}
+	}
+	testhelpers.AssertNotNil(t, callSpan)
+	testhelpers.AssertEqual(t, parent.SpanContext().SpanID(), callSpan.Parent().SpanID())
+
+	// the upstream server received the trace context of the tool call span
+	traceparent, _ := result.Content[0]["text"].(string)
+	testhelpers.AssertTrue(
+		t,
+		strings.Contains(traceparent, callSpan.SpanContext().TraceID().String()+"-"+callSpan.SpanContext().SpanID().String()),
+		"Expected the upstream server to receive the trace context of the tool call, got "+traceparent,
+	)
+}
+
+func TestToolCallSpanRecordsErrors(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	originalTP := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	defer otel.SetTracerProvider(originalTP)
+
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	m, err := NewMCPService(
+		setup.DB,
+		server.NewMCPServer("proxy", "test"),
+		server.NewMCPServer("sse proxy", "test"),
+		telemetry.NewNoopCustomMetrics(),
+		logger.NewNop(),
+	)
+	testhelpers.AssertNoError(t, err)
+
+	_, err = m.InvokeTool(context.Background(), "unknown__tool", nil)
+	testhelpers.AssertTrue(t, err != nil, "Expected an error for a tool of an unknown server")
+
+	spans := recorder.Ended()
+	testhelpers.AssertEqual(t, 1, len(spans))
+	testhelpers.AssertEqual(t, "Error", spans[0].Status().Code.String())
+	testhelpers.AssertEqual(t, err.Error(), spans[0].Status().Description)
+}
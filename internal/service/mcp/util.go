@@ -6,7 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
+	"maps"
 	"net"
 	"net/url"
 	"os"
@@ -19,7 +19,11 @@ import (
 	"github.com/mark3labs/mcp-go/client/transport"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/requestid"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 // serverInitRequestTimeout is the timeout (in seconds) for the initialization request to the MCP server
@@ -73,6 +77,13 @@ func splitServerToolName(name string) (string, string, bool) {
 	return strings.Cut(name, serverToolNameSep)
 }
 
+// ToolServerName returns the name of the MCP server that provides the tool with the given unique name.
+// It returns false if the name doesn't contain a server name.
+func ToolServerName(name string) (string, bool) {
+	s, _, ok := splitServerToolName(name)
+	return s, ok
+}
+
 // mergeServerPromptNames combines the server name and prompt name into a single prompt name unique across the registry.
 func mergeServerPromptNames(s, p string) string {
 	return s + serverPromptNameSep + p
@@ -83,6 +94,22 @@ func splitServerPromptName(name string) (string, string, bool) {
 	return strings.Cut(name, serverPromptNameSep)
 }
 
+// likeEscaper escapes the wildcards of a LIKE pattern, along with the escape character itself.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// likeContains returns the LIKE pattern that matches the values containing s.
+// The pattern must be used along with ESCAPE '\', so that the wildcards in s are matched literally.
+func likeContains(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
+
+// isGlobPattern returns true if the given entity name contains glob metacharacters.
+// Server names can never contain these characters and tool names practically never do,
+// so such an entity is treated as a pattern.
+func isGlobPattern(entity string) bool {
+	return strings.ContainsAny(entity, "*?[")
+}
+
 // isLoopbackURL returns true if rawURL resolves to a loopback address.
 // It assumes that rawURL is a valid URL.
 func isLoopbackURL(rawURL string) bool {
@@ -120,7 +147,22 @@ func convertToolModelToMcpObject(t *model.Tool) (mcp.Tool, error) {
 	}
 	mcpTool.InputSchema = inputSchema
 
-	// TODO: Add other attributes to the tool, such as annotations
+	if len(t.OutputSchema) > 0 {
+		// the schema is passed on as is, mcp.ToolOutputSchema doesn't support all JSON schema keywords
+		mcpTool.RawOutputSchema = json.RawMessage(t.OutputSchema)
+	}
+
+	// tools registered before annotations were stored in the DB don't have any
+	if len(t.Annotations) > 0 {
+		var annotations mcp.ToolAnnotation
+		if err := json.Unmarshal(t.Annotations, &annotations); err != nil {
+			return mcp.Tool{}, fmt.Errorf(
+				"failed to unmarshal annotations %s for tool %s: %w", t.Annotations, t.Name, err,
+			)
+		}
+		mcpTool.Annotations = annotations
+	}
+
 	// NOTE: if more fields are added to the tool in DB, they should be set here as well
 
 	return mcpTool, nil
@@ -151,7 +193,7 @@ func createHTTPMcpServerConn(ctx context.Context, s *model.McpServer) (*client.C
 		return nil, fmt.Errorf("failed to get streamable HTTP config for MCP server %s: %w", s.Name, err)
 	}
 
-	var opts []transport.StreamableHTTPCOption
+	opts := []transport.StreamableHTTPCOption{transport.WithHTTPHeaderFunc(upstreamRequestHeaders)}
 	if conf.BearerToken != "" {
 		// If bearer token is provided, set the Authorization header
 		o := transport.WithHTTPHeaders(map[string]string{
@@ -164,6 +206,10 @@ func createHTTPMcpServerConn(ctx context.Context, s *model.McpServer) (*client.C
 	if err != nil {
 		return nil, fmt.Errorf("failed to create streamable HTTP client for MCP server: %w", err)
 	}
+	// starting the client delivers the notifications sent by the server, eg- progress, to its handlers
+	if err = c.Start(ctx); err != nil {
+		return nil, fmt.Errorf("failed to start streamable HTTP transport for MCP server: %w", err)
+	}
 
 	initRequest := mcp.InitializeRequest{}
 	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
@@ -197,31 +243,30 @@ func createHTTPMcpServerConn(ctx context.Context, s *model.McpServer) (*client.C
 // captureStdioServerStderr captures the stderr output of a stdio MCP server in the background
 // and writes it to mcpjungle server logs.
 // This is useful for troubleshooting and visibility into the stdio server's behaviour.
-func captureStdioServerStderr(name string, c *client.Client) {
-	stdioTransport := c.GetTransport().(*transport.Stdio)
-
+func (m *MCPService) captureStdioServerStderr(name string, stdioTransport *transport.Stdio) {
+	l := m.logger.WithFields(logger.String("server", name))
 	go func() {
 		buf := make([]byte, 4096) // 4KB buffer for reading stderr
 		for {
 			n, err := stdioTransport.Stderr().Read(buf)
 			if err != nil {
 				if err == io.EOF || errors.Is(err, os.ErrClosed) {
-					log.Printf("['%s' MCP Server] [DEBUG] server process has exited gracefully", name)
+					l.Debug("stdio MCP server process has exited gracefully")
 				} else {
-					log.Printf("['%s' MCP STDERR] Error reading stderr: %v", name, err)
+					l.Error("failed to read stderr of stdio MCP server", logger.ErrorField(err))
 				}
-				log.Printf("['%s' MCP server] [DEBUG] exiting goroutine", name)
 				break
 			}
 			if n > 0 {
-				log.Printf("['%s' MCP STDERR] %s", name, string(buf[:n]))
+				l.Info("stdio MCP server stderr", logger.String("stderr", string(buf[:n])))
 			}
 		}
 	}()
 }
 
 // runStdioServer runs a stdio MCP server and returns the client.
-func runStdioServer(ctx context.Context, s *model.McpServer) (*client.Client, error) {
+// The process is stopped when the client is closed.
+func (m *MCPService) runStdioServer(ctx context.Context, s *model.McpServer) (_ *client.Client, err error) {
 	conf, err := s.GetStdioConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get stdio config for MCP server %s: %w", s.Name, err)
@@ -235,14 +280,32 @@ func runStdioServer(ctx context.Context, s *model.McpServer) (*client.Client, er
 		}
 	}
 
-	c, err := client.NewStdioMCPClient(conf.Command, envVars, conf.Args...)
+	// the process only starts once the server runs few enough processes, see StdioLimits
+	release, err := m.stdioProcesses.acquire(ctx, s.Name)
 	if err != nil {
+		return nil, err
+	}
+	stdioTransport := transport.NewStdio(conf.Command, envVars, conf.Args...)
+	if err := stdioTransport.Start(context.Background()); err != nil {
+		release()
 		return nil, fmt.Errorf("failed to create stdio client for MCP server: %w", err)
 	}
+	c := client.NewClient(&stdioProcess{Stdio: stdioTransport, release: release})
+	defer func() {
+		// stop the process if the session could not be established
+		if err != nil {
+			_ = c.Close()
+		}
+	}()
 
 	// currently, we only capture the stderr output in the mcpjungle server logs.
 	// TODO: Propagate the stderr output to the client as well to provide them quicker feedback on errors.
-	captureStdioServerStderr(s.Name, c)
+	m.captureStdioServerStderr(s.Name, stdioTransport)
+
+	// the process is already running, starting the client delivers the notifications it sends to their handlers
+	if err = c.Start(ctx); err != nil {
+		return nil, fmt.Errorf("failed to start stdio client for MCP server: %w", err)
+	}
 
 	initRequest := mcp.InitializeRequest{}
 	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
@@ -277,7 +340,7 @@ func createSSEMcpServerConn(ctx context.Context, s *model.McpServer) (*client.Cl
 		return nil, fmt.Errorf("failed to get SSE transport config for MCP server %s: %w", s.Name, err)
 	}
 
-	var opts []transport.ClientOption
+	opts := []transport.ClientOption{transport.WithHeaderFunc(upstreamRequestHeaders)}
 	if conf.BearerToken != "" {
 		// If bearer token is provided, set the Authorization header
 		o := transport.WithHeaders(map[string]string{
@@ -310,7 +373,43 @@ func createSSEMcpServerConn(ctx context.Context, s *model.McpServer) (*client.Cl
 	return c, nil
 }
 
-func newMcpServerSession(ctx context.Context, s *model.McpServer) (*client.Client, error) {
+// upstreamRequestHeaders returns the headers to add to a request made to an upstream MCP server
+// on behalf of the request in ctx, ie, its request ID and the W3C trace context (traceparent & tracestate)
+// so that the upstream server's spans become part of the same trace.
+func upstreamRequestHeaders(ctx context.Context) map[string]string {
+	headers := make(map[string]string)
+	if id := requestid.FromContext(ctx); id != "" {
+		headers[requestid.Header] = id
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(headers))
+	if len(headers) == 0 {
+		return nil
+	}
+	return headers
+}
+
+// withRequestIDMeta adds the request ID in ctx to the _meta of a request forwarded to an upstream MCP server.
+// Unlike the request ID header, this reaches upstream servers using any transport.
+// The given meta is not modified, a copy is returned instead.
+func withRequestIDMeta(ctx context.Context, meta *mcp.Meta) *mcp.Meta {
+	id := requestid.FromContext(ctx)
+	if id == "" {
+		return meta
+	}
+	m := &mcp.Meta{AdditionalFields: map[string]any{}}
+	if meta != nil {
+		m.ProgressToken = meta.ProgressToken
+		maps.Copy(m.AdditionalFields, meta.AdditionalFields)
+	}
+	m.AdditionalFields[requestid.MetaKey] = id
+	return m
+}
+
+// newMcpServerSession opens a new session with the given upstream MCP server.
+// The outcome is recorded in the server's health metrics.
+func (m *MCPService) newMcpServerSession(ctx context.Context, s *model.McpServer) (c *client.Client, err error) {
+	defer func() { m.recordUpstreamHealth(ctx, s.Name, err) }()
+
 	if s.Transport == types.TransportStreamableHTTP {
 		mcpClient, err := createHTTPMcpServerConn(ctx, s)
 		if err != nil {
@@ -331,11 +430,9 @@ func newMcpServerSession(ctx context.Context, s *model.McpServer) (*client.Clien
 		return mcpClient, nil
 	}
 
-	// A new sub-process is spun up for each call to a STDIO mcp server.
-	// This is especially a problem for the MCP proxy server, which is expected to call tools frequently.
-	// This causes a serious performance hit, but is easy to implement so it is used for now.
-	// TODO: Think of a better solution, ie, re-use connections to stdio MCP servers.
-	mcpClient, err := runStdioServer(ctx, s)
+	// A new sub-process is spun up for each session with a STDIO mcp server.
+	// Tool & prompt calls reuse the sessions kept in the session pool, so this only happens when the pool grows.
+	mcpClient, err := m.runStdioServer(ctx, s)
 	if err != nil {
 		return nil, fmt.Errorf("failed to run stdio MCP server %s: %w", s.Name, err)
 	}
@@ -1,8 +1,22 @@
 package mcp
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/requestid"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/datatypes"
 )
 
 func TestValidateServerName(t *testing.T) {
@@ -82,6 +96,23 @@ func TestSplitServerToolName(t *testing.T) {
 	}
 }
 
+func TestLikeContains(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"file", "%file%"},
+		{"read_file", `%read\_file%`},
+		{"100%", `%100\%%`},
+		{`C:\tmp`, `%C:\\tmp%`},
+	}
+	for _, tt := range tests {
+		if got := likeContains(tt.s); got != tt.want {
+			t.Errorf("likeContains(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
 func TestIsLoopbackURL(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -128,4 +159,83 @@ func TestIsLoopbackURL(t *testing.T) {
 	}
 }
 
-// todo: add tests for convertToolModelToMcpObject()
+func TestConvertToolModelToMcpObject(t *testing.T) {
+	tool := &model.Tool{
+		Name:         "time__get_current_time",
+		Description:  "Get the current time",
+		InputSchema:  datatypes.JSON(`{"type":"object","properties":{"timezone":{"type":"string"}}}`),
+		OutputSchema: datatypes.JSON(`{"type":"object","properties":{"time":{"type":"string"}}}`),
+		Annotations:  datatypes.JSON(`{"readOnlyHint":true}`),
+	}
+	mcpTool, err := convertToolModelToMcpObject(tool)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, "object", mcpTool.InputSchema.Type)
+	testhelpers.AssertTrue(t, *mcpTool.Annotations.ReadOnlyHint, "Expected the read-only hint to be set")
+
+	// the output schema is exposed to MCP clients as it was provided by the MCP server
+	j, err := json.Marshal(mcpTool)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertStringContains(t, string(j), `"outputSchema":{"type":"object","properties":{"time":{"type":"string"}}}`)
+
+	// tools registered before output schemas & annotations were stored don't have any
+	tool.OutputSchema = nil
+	tool.Annotations = nil
+	mcpTool, err = convertToolModelToMcpObject(tool)
+	testhelpers.AssertNoError(t, err)
+	j, err = json.Marshal(mcpTool)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertFalse(t, strings.Contains(string(j), "outputSchema"), "Expected no output schema")
+}
+
+func TestWithRequestIDMeta(t *testing.T) {
+	// without a request ID, the meta is left as-is
+	testhelpers.AssertTrue(t, withRequestIDMeta(context.Background(), nil) == nil, "Expected nil meta")
+
+	ctx := requestid.NewContext(context.Background(), "req-1")
+	original := &mcp.Meta{ProgressToken: "token", AdditionalFields: map[string]any{"foo": "bar"}}
+	m := withRequestIDMeta(ctx, original)
+
+	testhelpers.AssertEqual(t, "req-1", m.AdditionalFields[requestid.MetaKey])
+	testhelpers.AssertEqual(t, "bar", m.AdditionalFields["foo"])
+	testhelpers.AssertEqual(t, mcp.ProgressToken("token"), m.ProgressToken)
+	_, modified := original.AdditionalFields[requestid.MetaKey]
+	testhelpers.AssertFalse(t, modified, "Expected the original meta not to be modified")
+}
+
+func TestInvokeToolPropagatesRequestID(t *testing.T) {
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	upstreamServer := server.NewMCPServer("upstream", "test")
+	upstreamServer.AddTool(
+		mcp.NewTool("whoami"),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var metaID any
+			if req.Params.Meta != nil {
+				metaID = req.Params.Meta.AdditionalFields[requestid.MetaKey]
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("%s %v", req.Header.Get(requestid.Header), metaID)), nil
+		},
+	)
+	upstream := httptest.NewServer(server.NewStreamableHTTPServer(upstreamServer))
+	defer upstream.Close()
+
+	setup.CreateTestMcpServer(
+		"upstream", "", types.TransportStreamableHTTP, []byte(`{"url": "`+upstream.URL+`"}`),
+	)
+
+	m, err := NewMCPService(
+		setup.DB,
+		server.NewMCPServer("proxy", "test"),
+		server.NewMCPServer("sse proxy", "test"),
+		telemetry.NewNoopCustomMetrics(),
+		logger.NewNop(),
+	)
+	testhelpers.AssertNoError(t, err)
+	ctx := requestid.NewContext(context.Background(), "req-42")
+	result, err := m.InvokeTool(ctx, "upstream__whoami", nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	testhelpers.AssertEqual(t, "req-42 req-42", result.Content[0]["text"])
+}
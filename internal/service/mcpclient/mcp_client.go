@@ -2,17 +2,60 @@
 package mcpclient
 
 import (
+	"crypto/sha256"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/mcpjungle/mcpjungle/internal"
+	"github.com/mcpjungle/mcpjungle/internal/db"
+	"github.com/mcpjungle/mcpjungle/internal/encryption"
 	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"gorm.io/gorm"
 )
 
+// ErrClientNotFound is returned when an MCP client does not exist.
+var ErrClientNotFound = errors.New("mcp client not found")
+
+// ErrInvalidQuota is returned when the tool call quota of an MCP client is invalid.
+var ErrInvalidQuota = errors.New("invalid quota")
+
+// ErrQuotaExceeded is returned when an MCP client has used up its tool call quota for the current period.
+var ErrQuotaExceeded = errors.New("mcp client quota exceeded")
+
+// QuotaExceededError is returned when an MCP client has used up its tool call quota for the current period.
+// It matches ErrQuotaExceeded.
+type QuotaExceededError struct {
+	Client   string
+	Limit    int64
+	Period   types.QuotaPeriod
+	ResetsAt time.Time
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf(
+		"mcp client %s has exceeded its %s quota of %d tool calls, it resets at %s",
+		e.Client, e.Period, e.Limit, e.ResetsAt.Format(time.RFC3339),
+	)
+}
+
+func (e *QuotaExceededError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
+
+// ErrClientTokenExpired is returned when an MCP client authenticates with an access token that has expired.
+var ErrClientTokenExpired = errors.New("mcp client access token has expired")
+
 // McpClientService provides methods to manage MCP clients in the database.
 type McpClientService struct {
 	db *gorm.DB
+
+	// lastKnown holds the clients last authenticated by their access token, keyed by the token's hash.
+	// They are only used to authenticate clients while the database is unavailable.
+	lastKnown sync.Map
 }
 
 func NewMCPClientService(db *gorm.DB) *McpClientService {
@@ -22,20 +65,62 @@ func NewMCPClientService(db *gorm.DB) *McpClientService {
 // ListClients retrieves all MCP clients known to mcpjungle from the database
 func (m *McpClientService) ListClients() ([]*model.McpClient, error) {
 	var clients []*model.McpClient
-	if err := m.db.Find(&clients).Error; err != nil {
+	if err := m.db.Order("id").Find(&clients).Error; err != nil {
 		return nil, err
 	}
+	now := time.Now()
+	for _, c := range clients {
+		c.RefreshQuota(now)
+	}
 	return clients, nil
 }
 
+// ListClientsPage retrieves the given page of MCP clients from the database, along with the total number of clients.
+func (m *McpClientService) ListClientsPage(page types.Page) ([]*model.McpClient, int64, error) {
+	var clients []*model.McpClient
+	total, err := db.FindPage(m.db.Order("id"), page, &clients)
+	if err != nil {
+		return nil, 0, err
+	}
+	now := time.Now()
+	for _, c := range clients {
+		c.RefreshQuota(now)
+	}
+	return clients, total, nil
+}
+
+// ListClientsWithServerAccess returns the names of the MCP clients whose allow list includes the given MCP server.
+func (m *McpClientService) ListClientsWithServerAccess(serverName string) ([]string, error) {
+	clients, err := m.ListClients()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0)
+	for _, c := range clients {
+		if c.CheckHasServerAccess(serverName) {
+			names = append(names, c.Name)
+		}
+	}
+	return names, nil
+}
+
 // CreateClient creates a new MCP client in the database.
-// It also generates a new access token for the client.
+// It also generates a new access token for the client, which expires after the client's token TTL, if any.
 func (m *McpClientService) CreateClient(client model.McpClient) (*model.McpClient, error) {
+	if client.TokenTTLSeconds < 0 {
+		return nil, errors.New("token TTL must not be negative")
+	}
+	if err := validateQuota(&client); err != nil {
+		return nil, err
+	}
 	token, err := internal.GenerateAccessToken()
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 	client.AccessToken = token
+	client.TokenExpiresAt = client.NextTokenExpiry(time.Now())
+	client.QuotaUsed = 0
+	client.QuotaResetsAt = nil
 
 	// Initialize AllowList with empty array if not provided
 	if client.AllowList == nil {
@@ -48,16 +133,169 @@ func (m *McpClientService) CreateClient(client model.McpClient) (*model.McpClien
 	return &client, nil
 }
 
+// PatchClient updates only the fields of an MCP client that are set in the patch.
+// The client keeps its access token, and the new allow list applies to its very next request.
+func (m *McpClientService) PatchClient(name string, patch *types.PatchMcpClientInput) (*model.McpClient, error) {
+	var client model.McpClient
+	if err := m.db.Where("name = ?", name).First(&client).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrClientNotFound
+		}
+		return nil, err
+	}
+
+	if patch.Description != nil {
+		client.Description = *patch.Description
+	}
+	if patch.AllowList != nil {
+		allowList := *patch.AllowList
+		if allowList == nil {
+			allowList = []string{}
+		}
+		var err error
+		if client.AllowList, err = json.Marshal(allowList); err != nil {
+			return nil, fmt.Errorf("failed to serialize allow list: %w", err)
+		}
+	}
+
+	if patch.QuotaLimit != nil {
+		client.QuotaLimit = *patch.QuotaLimit
+	}
+	if patch.QuotaPeriod != nil && *patch.QuotaPeriod != client.QuotaPeriod {
+		client.QuotaPeriod = *patch.QuotaPeriod
+		patch.ResetQuota = true
+	}
+	if err := validateQuota(&client); err != nil {
+		return nil, err
+	}
+	if patch.ResetQuota {
+		client.QuotaUsed = 0
+		client.QuotaResetsAt = nil
+	}
+
+	err := m.db.Model(&client).
+		Select("Description", "AllowList", "QuotaLimit", "QuotaPeriod", "QuotaUsed", "QuotaResetsAt").
+		Updates(&client).Error
+	if err != nil {
+		return nil, err
+	}
+	m.forget(name)
+	client.RefreshQuota(time.Now())
+	return &client, nil
+}
+
+// validateQuota checks the quota of a client, and makes it daily if it has a limit but no period.
+func validateQuota(client *model.McpClient) error {
+	if client.QuotaLimit < 0 {
+		return fmt.Errorf("%w: the limit must not be negative", ErrInvalidQuota)
+	}
+	switch client.QuotaPeriod {
+	case types.QuotaPeriodDaily, types.QuotaPeriodMonthly:
+	case "":
+		if client.QuotaLimit > 0 {
+			client.QuotaPeriod = types.QuotaPeriodDaily
+		}
+	default:
+		return fmt.Errorf(
+			"%w: unknown period %q, must be %s or %s",
+			ErrInvalidQuota, client.QuotaPeriod, types.QuotaPeriodDaily, types.QuotaPeriodMonthly,
+		)
+	}
+	return nil
+}
+
+// ConsumeQuota counts a tool call against the quota of the given client, if it has one.
+// It returns a *QuotaExceededError if the client has already used up its quota for the current period.
+// A new period starts with the first call after the current one ends.
+func (m *McpClientService) ConsumeQuota(client *model.McpClient) error {
+	if client.QuotaLimit <= 0 {
+		return nil
+	}
+	now := time.Now().UTC()
+
+	err := m.db.Model(&model.McpClient{}).
+		Where("id = ? AND (quota_resets_at IS NULL OR quota_resets_at <= ?)", client.ID, now).
+		UpdateColumns(map[string]any{"quota_used": 0, "quota_resets_at": client.NextQuotaReset(now)}).Error
+	if err != nil {
+		return fmt.Errorf("failed to start a new quota period: %w", err)
+	}
+
+	// the quota is checked & consumed atomically, so that concurrent calls can't exceed it
+	result := m.db.Model(&model.McpClient{}).
+		Where("id = ? AND quota_used < quota_limit", client.ID).
+		UpdateColumn("quota_used", gorm.Expr("quota_used + 1"))
+	if result.Error != nil {
+		return fmt.Errorf("failed to consume quota: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	var current model.McpClient
+	if err := m.db.Select("quota_limit", "quota_resets_at").First(&current, client.ID).Error; err != nil {
+		return fmt.Errorf("failed to read quota: %w", err)
+	}
+	exceeded := &QuotaExceededError{Client: client.Name, Limit: current.QuotaLimit, Period: client.QuotaPeriod}
+	if current.QuotaResetsAt != nil {
+		exceeded.ResetsAt = current.QuotaResetsAt.UTC()
+	}
+	return exceeded
+}
+
 // GetClientByToken retrieves an MCP client by its access token from the database.
-// It returns an error if no such client is found.
+// It returns an error if no such client is found, and ErrClientTokenExpired if the token has expired.
+// If the database is unavailable, the client last authenticated with the same token is returned, if any.
 func (m *McpClientService) GetClientByToken(token string) (*model.McpClient, error) {
+	client, err := m.lookupClientByToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if client.TokenExpired(time.Now()) {
+		return nil, ErrClientTokenExpired
+	}
+	return client, nil
+}
+
+func (m *McpClientService) lookupClientByToken(token string) (*model.McpClient, error) {
+	key := sha256.Sum256([]byte(token))
 	var client model.McpClient
-	if err := m.db.Where("access_token = ?", token).First(&client).Error; err != nil {
+	if err := m.db.Where("access_token IN ?", encryption.LookupValues(token)).First(&client).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
+			m.lastKnown.Delete(key)
 			return nil, errors.New("client not found")
 		}
+		if c, ok := m.lastKnown.Load(key); ok {
+			return c.(*model.McpClient), nil
+		}
+		return nil, err
+	}
+	m.lastKnown.Store(key, &client)
+	return &client, nil
+}
+
+// RenewClientToken replaces the access token of an MCP client by a new one, which lives as long as the client's
+// token TTL from now on. The previous token stops working immediately.
+// Clients are expected to renew their token before it expires, so that they never lose access.
+func (m *McpClientService) RenewClientToken(name string) (*model.McpClient, error) {
+	var client model.McpClient
+	if err := m.db.Where("name = ?", name).First(&client).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrClientNotFound
+		}
+		return nil, err
+	}
+
+	token, err := internal.GenerateAccessToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+	client.AccessToken = token
+	client.TokenExpiresAt = client.NextTokenExpiry(time.Now())
+
+	if err := m.db.Model(&client).Select("AccessToken", "TokenExpiresAt").Updates(&client).Error; err != nil {
 		return nil, err
 	}
+	m.forget(name)
 	return &client, nil
 }
 
@@ -65,5 +303,20 @@ func (m *McpClientService) GetClientByToken(token string) (*model.McpClient, err
 // It is an idempotent operation. Deleting a client that does not exist will not return an error.
 func (m *McpClientService) DeleteClient(name string) error {
 	result := m.db.Unscoped().Where("name = ?", name).Delete(&model.McpClient{})
-	return result.Error
+	if result.Error != nil {
+		return result.Error
+	}
+	m.forget(name)
+	return nil
+}
+
+// forget removes the given client from the clients last authenticated, so that its stale copy
+// is never used to authenticate it while the database is unavailable.
+func (m *McpClientService) forget(name string) {
+	m.lastKnown.Range(func(key, c any) bool {
+		if c.(*model.McpClient).Name == name {
+			m.lastKnown.Delete(key)
+		}
+		return true
+	})
 }
@@ -1,10 +1,13 @@
 package mcpclient
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
 
 func TestNewMCPClientService(t *testing.T) {
@@ -251,3 +254,226 @@ func TestClientTokenUniqueness(t *testing.T) {
 		tokens[client.AccessToken] = true
 	}
 }
+
+func TestPatchClient(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	svc := NewMCPClientService(setup.DB)
+	created, err := svc.CreateClient(model.McpClient{
+		Name: "cursor", Description: "old", AllowList: []byte(`["github"]`),
+	})
+	testhelpers.AssertNoError(t, err)
+
+	allowList := []string{"github", "time"}
+	client, err := svc.PatchClient("cursor", &types.PatchMcpClientInput{AllowList: &allowList})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, "old", client.Description)
+
+	// the client keeps its token and gets the new allow list
+	found, err := svc.GetClientByToken(created.AccessToken)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, found.CheckHasServerAccess("time"), "Expected the client to access the new server")
+
+	description := "new"
+	client, err = svc.PatchClient("cursor", &types.PatchMcpClientInput{Description: &description})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, "new", client.Description)
+	testhelpers.AssertTrue(t, client.CheckHasServerAccess("time"), "Expected the allow list to be left unchanged")
+
+	_, err = svc.PatchClient("claude", &types.PatchMcpClientInput{Description: &description})
+	testhelpers.AssertTrue(t, errors.Is(err, ErrClientNotFound), "Expected ErrClientNotFound")
+}
+
+func TestGetClientByTokenDatabaseUnavailable(t *testing.T) {
+	db, err := testhelpers.CreateTestDB()
+	testhelpers.AssertNoError(t, err)
+	err = db.AutoMigrate(&model.McpClient{})
+	testhelpers.AssertNoError(t, err)
+
+	svc := NewMCPClientService(db)
+	cursor, err := svc.CreateClient(model.McpClient{Name: "cursor"})
+	testhelpers.AssertNoError(t, err)
+	claude, err := svc.CreateClient(model.McpClient{Name: "claude"})
+	testhelpers.AssertNoError(t, err)
+	unseen, err := svc.CreateClient(model.McpClient{Name: "unseen"})
+	testhelpers.AssertNoError(t, err)
+
+	for _, c := range []*model.McpClient{cursor, claude} {
+		_, err = svc.GetClientByToken(c.AccessToken)
+		testhelpers.AssertNoError(t, err)
+	}
+	// a deleted client must not be authenticated from memory
+	testhelpers.AssertNoError(t, svc.DeleteClient("claude"))
+
+	sqlDB, err := db.DB()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, sqlDB.Close())
+
+	// clients authenticated before the database became unavailable are still authenticated
+	c, err := svc.GetClientByToken(cursor.AccessToken)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, "cursor", c.Name)
+
+	_, err = svc.GetClientByToken(claude.AccessToken)
+	testhelpers.AssertError(t, err)
+	_, err = svc.GetClientByToken(unseen.AccessToken)
+	testhelpers.AssertError(t, err)
+	_, err = svc.GetClientByToken("invalid-token")
+	testhelpers.AssertError(t, err)
+}
+
+func TestClientTokenExpiry(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	svc := NewMCPClientService(setup.DB)
+
+	forever, err := svc.CreateClient(model.McpClient{Name: "forever"})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, forever.TokenExpiresAt == nil, "Expected the token to never expire without a TTL")
+
+	client, err := svc.CreateClient(model.McpClient{Name: "agent", TokenTTLSeconds: 3600})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNotNil(t, client.TokenExpiresAt)
+	remaining := time.Until(*client.TokenExpiresAt)
+	testhelpers.AssertTrue(t, remaining > 59*time.Minute && remaining <= time.Hour, "Expected the token to expire in an hour")
+
+	_, err = svc.GetClientByToken(client.AccessToken)
+	testhelpers.AssertNoError(t, err)
+
+	// once expired, the token is rejected, even from memory
+	err = setup.DB.Model(&model.McpClient{}).Where("name = ?", "agent").
+		Update("token_expires_at", time.Now().Add(-time.Second)).Error
+	testhelpers.AssertNoError(t, err)
+	_, err = svc.GetClientByToken(client.AccessToken)
+	testhelpers.AssertTrue(t, errors.Is(err, ErrClientTokenExpired), "Expected ErrClientTokenExpired")
+
+	_, err = svc.CreateClient(model.McpClient{Name: "negative", TokenTTLSeconds: -1})
+	testhelpers.AssertError(t, err)
+}
+
+func TestRenewClientToken(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	svc := NewMCPClientService(setup.DB)
+	client, err := svc.CreateClient(model.McpClient{Name: "agent", TokenTTLSeconds: 3600})
+	testhelpers.AssertNoError(t, err)
+	_, err = svc.GetClientByToken(client.AccessToken)
+	testhelpers.AssertNoError(t, err)
+
+	renewed, err := svc.RenewClientToken("agent")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, renewed.AccessToken != client.AccessToken, "Expected a new access token")
+	testhelpers.AssertEqual(t, int64(3600), renewed.TokenTTLSeconds)
+	testhelpers.AssertNotNil(t, renewed.TokenExpiresAt)
+
+	// the previous token no longer works, the new one does
+	_, err = svc.GetClientByToken(client.AccessToken)
+	testhelpers.AssertError(t, err)
+	c, err := svc.GetClientByToken(renewed.AccessToken)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, "agent", c.Name)
+
+	_, err = svc.RenewClientToken("missing")
+	testhelpers.AssertTrue(t, errors.Is(err, ErrClientNotFound), "Expected ErrClientNotFound")
+}
+
+func TestConsumeQuota(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	svc := NewMCPClientService(setup.DB)
+
+	unlimited, err := svc.CreateClient(model.McpClient{Name: "unlimited"})
+	testhelpers.AssertNoError(t, err)
+	for i := 0; i < 3; i++ {
+		testhelpers.AssertNoError(t, svc.ConsumeQuota(unlimited))
+	}
+
+	client, err := svc.CreateClient(model.McpClient{Name: "agent", QuotaLimit: 2})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, types.QuotaPeriodDaily, client.QuotaPeriod)
+
+	testhelpers.AssertNoError(t, svc.ConsumeQuota(client))
+	testhelpers.AssertNoError(t, svc.ConsumeQuota(client))
+	err = svc.ConsumeQuota(client)
+	testhelpers.AssertTrue(t, errors.Is(err, ErrQuotaExceeded), "Expected ErrQuotaExceeded")
+	var exceeded *QuotaExceededError
+	testhelpers.AssertTrue(t, errors.As(err, &exceeded), "Expected a QuotaExceededError")
+	testhelpers.AssertEqual(t, int64(2), exceeded.Limit)
+	testhelpers.AssertEqual(t, client.NextQuotaReset(time.Now()), exceeded.ResetsAt)
+
+	clients, err := svc.ListClients()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, int64(2), clients[1].QuotaUsed)
+
+	// a new period starts once the current one is over
+	err = setup.DB.Model(&model.McpClient{}).Where("name = ?", "agent").
+		Update("quota_resets_at", time.Now().Add(-time.Second)).Error
+	testhelpers.AssertNoError(t, err)
+	clients, err = svc.ListClients()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, int64(0), clients[1].QuotaUsed)
+	testhelpers.AssertNoError(t, svc.ConsumeQuota(client))
+
+	// an admin can reset the quota right away
+	testhelpers.AssertNoError(t, svc.ConsumeQuota(client))
+	testhelpers.AssertError(t, svc.ConsumeQuota(client))
+	updated, err := svc.PatchClient("agent", &types.PatchMcpClientInput{ResetQuota: true})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, int64(0), updated.QuotaUsed)
+	testhelpers.AssertNoError(t, svc.ConsumeQuota(client))
+}
+
+func TestClientQuotaValidation(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	svc := NewMCPClientService(setup.DB)
+
+	_, err := svc.CreateClient(model.McpClient{Name: "negative", QuotaLimit: -1})
+	testhelpers.AssertTrue(t, errors.Is(err, ErrInvalidQuota), "Expected ErrInvalidQuota")
+	_, err = svc.CreateClient(model.McpClient{Name: "weekly", QuotaLimit: 10, QuotaPeriod: "weekly"})
+	testhelpers.AssertTrue(t, errors.Is(err, ErrInvalidQuota), "Expected ErrInvalidQuota")
+
+	_, err = svc.CreateClient(model.McpClient{Name: "agent", QuotaLimit: 10})
+	testhelpers.AssertNoError(t, err)
+	monthly := types.QuotaPeriodMonthly
+	limit := int64(500)
+	updated, err := svc.PatchClient("agent", &types.PatchMcpClientInput{QuotaLimit: &limit, QuotaPeriod: &monthly})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, int64(500), updated.QuotaLimit)
+	testhelpers.AssertEqual(t, types.QuotaPeriodMonthly, updated.QuotaPeriod)
+
+	weekly := types.QuotaPeriod("weekly")
+	_, err = svc.PatchClient("agent", &types.PatchMcpClientInput{QuotaPeriod: &weekly})
+	testhelpers.AssertTrue(t, errors.Is(err, ErrInvalidQuota), "Expected ErrInvalidQuota")
+}
+
+func TestListClientsWithServerAccess(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	svc := NewMCPClientService(setup.DB)
+	for name, allowList := range map[string]string{
+		"cursor": `["github", "time"]`,
+		"claude": `["time"]`,
+		"agent":  `[]`,
+	} {
+		_, err := svc.CreateClient(model.McpClient{Name: name, AllowList: []byte(allowList)})
+		testhelpers.AssertNoError(t, err)
+	}
+
+	names, err := svc.ListClientsWithServerAccess("time")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 2, len(names))
+	names, err = svc.ListClientsWithServerAccess("github")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 1, len(names))
+	testhelpers.AssertEqual(t, "cursor", names[0])
+	names, err = svc.ListClientsWithServerAccess("slack")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 0, len(names))
+}
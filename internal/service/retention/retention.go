@@ -0,0 +1,172 @@
+// Package retention prunes historical data from the database once it is older than its retention window,
+// so that the database doesn't grow unbounded.
+package retention
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultJobRetention is the default time for which completed jobs are kept
+	DefaultJobRetention = 7 * 24 * time.Hour
+	// DefaultSoftDeletedRetention is the default time for which soft-deleted records are kept
+	DefaultSoftDeletedRetention = 30 * 24 * time.Hour
+	// DefaultIdempotencyKeyRetention is the default time for which idempotency keys are kept
+	DefaultIdempotencyKeyRetention = 24 * time.Hour
+	// DefaultInterval is the default interval between two runs of the background pruning
+	DefaultInterval = time.Hour
+)
+
+// Config holds the retention window of every kind of historical data.
+// A zero window disables the pruning of that kind of data.
+type Config struct {
+	// InvocationHistory is how long tool invocations are kept in the history.
+	// The history is also capped to a number of invocations, see invocation.HistoryService.
+	InvocationHistory time.Duration
+	// Jobs is how long completed (succeeded or failed) jobs are kept after they complete
+	Jobs time.Duration
+	// SoftDeleted is how long soft-deleted records are kept before they're deleted for good
+	SoftDeleted time.Duration
+	// IdempotencyKeys is how long idempotency keys & their recorded responses are kept, ie,
+	// how long a client may retry a request with the same key and get the same response
+	IdempotencyKeys time.Duration
+}
+
+// DefaultConfig returns the default retention windows.
+// Tool invocations are kept until they no longer fit in the history.
+func DefaultConfig() Config {
+	return Config{
+		Jobs:            DefaultJobRetention,
+		SoftDeleted:     DefaultSoftDeletedRetention,
+		IdempotencyKeys: DefaultIdempotencyKeyRetention,
+	}
+}
+
+// Result is the number of records deleted by a pruning run
+type Result struct {
+	Invocations     int64
+	Jobs            int64
+	SoftDeleted     int64
+	IdempotencyKeys int64
+}
+
+// Total returns the total number of records deleted
+func (r Result) Total() int64 {
+	return r.Invocations + r.Jobs + r.SoftDeleted + r.IdempotencyKeys
+}
+
+// softDeletableModels are the models that have a DeletedAt column.
+// They are purged in this order, so that tools & prompts go before the servers they reference.
+var softDeletableModels = []any{
+	&model.Tool{},
+	&model.Prompt{},
+	&model.McpServer{},
+	&model.ToolGroup{},
+	&model.McpClient{},
+	&model.User{},
+	&model.Team{},
+	&model.ToolInvocationJob{},
+	&model.ServerConfig{},
+}
+
+// Pruner deletes the historical data that is older than its retention window.
+type Pruner struct {
+	db     *gorm.DB
+	config Config
+	logger logger.Logger
+}
+
+// NewPruner creates a new Pruner.
+func NewPruner(db *gorm.DB, config Config, l logger.Logger) *Pruner {
+	return &Pruner{db: db, config: config, logger: l}
+}
+
+// Prune deletes the records that are older than their retention window at the given time.
+func (p *Pruner) Prune(now time.Time) (Result, error) {
+	var r Result
+
+	if p.config.InvocationHistory > 0 {
+		res := p.db.Where("created_at < ?", now.Add(-p.config.InvocationHistory)).Delete(&model.ToolInvocation{})
+		if res.Error != nil {
+			return r, fmt.Errorf("failed to prune tool invocation history: %w", res.Error)
+		}
+		r.Invocations = res.RowsAffected
+	}
+
+	if p.config.Jobs > 0 {
+		res := p.db.Unscoped().
+			Where("status IN ?", []types.JobStatus{types.JobStatusSucceeded, types.JobStatusFailed}).
+			Where("completed_at < ?", now.Add(-p.config.Jobs)).
+			Delete(&model.ToolInvocationJob{})
+		if res.Error != nil {
+			return r, fmt.Errorf("failed to prune completed jobs: %w", res.Error)
+		}
+		r.Jobs = res.RowsAffected
+	}
+
+	if p.config.SoftDeleted > 0 {
+		cutoff := now.Add(-p.config.SoftDeleted)
+		for _, m := range softDeletableModels {
+			q := p.db.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff)
+			if _, ok := m.(*model.McpServer); ok {
+				// a server is only purged once nothing references it anymore
+				q = q.Where("id NOT IN (?)", p.db.Unscoped().Model(&model.Tool{}).Select("server_id")).
+					Where("id NOT IN (?)", p.db.Unscoped().Model(&model.Prompt{}).Select("server_id"))
+			}
+			res := q.Delete(m)
+			if res.Error != nil {
+				return r, fmt.Errorf("failed to purge soft-deleted records of %T: %w", m, res.Error)
+			}
+			r.SoftDeleted += res.RowsAffected
+		}
+	}
+
+	if p.config.IdempotencyKeys > 0 {
+		res := p.db.Where("created_at < ?", now.Add(-p.config.IdempotencyKeys)).Delete(&model.IdempotencyKey{})
+		if res.Error != nil {
+			return r, fmt.Errorf("failed to prune idempotency keys: %w", res.Error)
+		}
+		r.IdempotencyKeys = res.RowsAffected
+	}
+
+	return r, nil
+}
+
+// Run prunes the database at every interval until the context is done.
+// Failures are only logged, the next run tries again.
+func (p *Pruner) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		p.runOnce()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Pruner) runOnce() {
+	r, err := p.Prune(time.Now())
+	if err != nil {
+		p.logger.Error("failed to prune historical data", logger.ErrorField(err))
+		return
+	}
+	if r.Total() > 0 {
+		p.logger.Info(
+			"pruned historical data",
+			logger.Int("invocations", int(r.Invocations)),
+			logger.Int("jobs", int(r.Jobs)),
+			logger.Int("soft_deleted", int(r.SoftDeleted)),
+			logger.Int("idempotency_keys", int(r.IdempotencyKeys)),
+		)
+	}
+}
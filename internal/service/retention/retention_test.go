@@ -0,0 +1,167 @@
+package retention
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/gorm"
+)
+
+var now = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func softDelete(t *testing.T, db *gorm.DB, m any, at time.Time) {
+	t.Helper()
+	testhelpers.AssertNoError(t, db.Unscoped().Model(m).Update("deleted_at", at).Error)
+}
+
+func TestPruneInvocationHistory(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	for _, age := range []time.Duration{time.Hour, 23 * time.Hour, 25 * time.Hour, 48 * time.Hour} {
+		inv := &model.ToolInvocation{ToolName: "a__b", Outcome: types.InvocationOutcomeSuccess, CreatedAt: now.Add(-age)}
+		testhelpers.AssertNoError(t, setup.DB.Create(inv).Error)
+	}
+
+	p := NewPruner(setup.DB, Config{InvocationHistory: 24 * time.Hour}, logger.NewNop())
+	r, err := p.Prune(now)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, int64(2), r.Invocations)
+
+	var count int64
+	setup.DB.Model(&model.ToolInvocation{}).Count(&count)
+	testhelpers.AssertEqual(t, int64(2), count)
+}
+
+func TestPruneJobs(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	old := now.Add(-10 * 24 * time.Hour)
+	recent := now.Add(-time.Hour)
+	jobs := []*model.ToolInvocationJob{
+		{JobID: "old-succeeded", ToolName: "a__b", Status: types.JobStatusSucceeded, CompletedAt: &old},
+		{JobID: "old-failed", ToolName: "a__b", Status: types.JobStatusFailed, CompletedAt: &old},
+		{JobID: "recent", ToolName: "a__b", Status: types.JobStatusSucceeded, CompletedAt: &recent},
+		{JobID: "running", ToolName: "a__b", Status: types.JobStatusRunning},
+	}
+	for _, j := range jobs {
+		testhelpers.AssertNoError(t, setup.DB.Create(j).Error)
+	}
+
+	p := NewPruner(setup.DB, Config{Jobs: DefaultJobRetention}, logger.NewNop())
+	r, err := p.Prune(now)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, int64(2), r.Jobs)
+
+	var remaining []model.ToolInvocationJob
+	setup.DB.Unscoped().Order("job_id").Find(&remaining)
+	testhelpers.AssertEqual(t, 2, len(remaining))
+	testhelpers.AssertEqual(t, "recent", remaining[0].JobID)
+	testhelpers.AssertEqual(t, "running", remaining[1].JobID)
+}
+
+func TestPruneSoftDeleted(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	old := now.Add(-60 * 24 * time.Hour)
+
+	// a server whose tool is still soft-deleted within the retention window
+	referenced := &model.McpServer{Name: "referenced", Transport: types.TransportStreamableHTTP, Config: []byte(`{}`)}
+	unreferenced := &model.McpServer{Name: "unreferenced", Transport: types.TransportStreamableHTTP, Config: []byte(`{}`)}
+	live := &model.McpServer{Name: "live", Transport: types.TransportStreamableHTTP, Config: []byte(`{}`)}
+	for _, s := range []*model.McpServer{referenced, unreferenced, live} {
+		testhelpers.AssertNoError(t, setup.DB.Create(s).Error)
+	}
+	recentTool := &model.Tool{Name: "recent", ServerID: referenced.ID}
+	oldTool := &model.Tool{Name: "old", ServerID: unreferenced.ID}
+	testhelpers.AssertNoError(t, setup.DB.Create(recentTool).Error)
+	testhelpers.AssertNoError(t, setup.DB.Create(oldTool).Error)
+
+	softDelete(t, setup.DB, referenced, old)
+	softDelete(t, setup.DB, unreferenced, old)
+	softDelete(t, setup.DB, oldTool, old)
+	softDelete(t, setup.DB, recentTool, now.Add(-time.Hour))
+
+	p := NewPruner(setup.DB, Config{SoftDeleted: DefaultSoftDeletedRetention}, logger.NewNop())
+	r, err := p.Prune(now)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, int64(2), r.SoftDeleted)
+
+	var servers []model.McpServer
+	setup.DB.Unscoped().Order("name").Find(&servers)
+	testhelpers.AssertEqual(t, 2, len(servers))
+	testhelpers.AssertEqual(t, "live", servers[0].Name)
+	testhelpers.AssertEqual(t, "referenced", servers[1].Name)
+
+	var tools []model.Tool
+	setup.DB.Unscoped().Find(&tools)
+	testhelpers.AssertEqual(t, 1, len(tools))
+	testhelpers.AssertEqual(t, "recent", tools[0].Name)
+}
+
+func TestPruneIdempotencyKeys(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	for i, age := range []time.Duration{time.Hour, 25 * time.Hour, 48 * time.Hour} {
+		key := &model.IdempotencyKey{
+			Key: string(rune('a' + i)), Method: "POST", Path: "/api/v1/servers", RequestHash: "h", CreatedAt: now.Add(-age),
+		}
+		testhelpers.AssertNoError(t, setup.DB.Create(key).Error)
+	}
+
+	p := NewPruner(setup.DB, Config{IdempotencyKeys: DefaultIdempotencyKeyRetention}, logger.NewNop())
+	r, err := p.Prune(now)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, int64(2), r.IdempotencyKeys)
+
+	var keys []model.IdempotencyKey
+	testhelpers.AssertNoError(t, setup.DB.Find(&keys).Error)
+	testhelpers.AssertEqual(t, 1, len(keys))
+	testhelpers.AssertEqual(t, "a", keys[0].Key)
+}
+
+func TestPruneDisabled(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	old := now.Add(-365 * 24 * time.Hour)
+	inv := &model.ToolInvocation{ToolName: "a__b", Outcome: types.InvocationOutcomeSuccess, CreatedAt: old}
+	testhelpers.AssertNoError(t, setup.DB.Create(inv).Error)
+	job := &model.ToolInvocationJob{JobID: "j", ToolName: "a__b", Status: types.JobStatusSucceeded, CompletedAt: &old}
+	testhelpers.AssertNoError(t, setup.DB.Create(job).Error)
+	softDelete(t, setup.DB, job, old)
+
+	p := NewPruner(setup.DB, Config{}, logger.NewNop())
+	r, err := p.Prune(now)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, int64(0), r.Total())
+}
+
+func TestRun(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	inv := &model.ToolInvocation{
+		ToolName:  "a__b",
+		Outcome:   types.InvocationOutcomeSuccess,
+		CreatedAt: time.Now().Add(-48 * time.Hour),
+	}
+	testhelpers.AssertNoError(t, setup.DB.Create(inv).Error)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// the first run happens immediately, even if the context is already done
+	NewPruner(setup.DB, Config{InvocationHistory: 24 * time.Hour}, logger.NewNop()).Run(ctx, time.Hour)
+
+	var count int64
+	setup.DB.Model(&model.ToolInvocation{}).Count(&count)
+	testhelpers.AssertEqual(t, int64(0), count)
+}
@@ -0,0 +1,196 @@
+// Package team provides team service functionality for the MCPJungle application.
+package team
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/mcpjungle/mcpjungle/internal/db"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/gorm"
+)
+
+// ErrTeamNotFound is returned when the requested team does not exist.
+var ErrTeamNotFound = errors.New("team not found")
+
+// ErrInvalidTeam is returned when the configuration of a team is invalid, eg- one of its members does not exist.
+var ErrInvalidTeam = errors.New("invalid team")
+
+// ValidTeamName is the pattern that the names of teams must match.
+var ValidTeamName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+// TeamService provides methods to manage teams of users and the permissions granted to them.
+type TeamService struct {
+	db *gorm.DB
+}
+
+func NewTeamService(db *gorm.DB) *TeamService {
+	return &TeamService{db: db}
+}
+
+// CreateTeam creates a new team in the database.
+// All of its members must be existing users.
+func (s *TeamService) CreateTeam(team *model.Team) error {
+	if err := validateTeam(s.db, team); err != nil {
+		return err
+	}
+	if err := s.db.Create(team).Error; err != nil {
+		return fmt.Errorf("failed to create team: %w", err)
+	}
+	return nil
+}
+
+// GetTeam retrieves a team by its name.
+func (s *TeamService) GetTeam(name string) (*model.Team, error) {
+	var team model.Team
+	if err := s.db.Where("name = ?", name).First(&team).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTeamNotFound
+		}
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+	return &team, nil
+}
+
+// ListTeams retrieves all teams from the database.
+func (s *TeamService) ListTeams() ([]model.Team, error) {
+	var teams []model.Team
+	if err := s.db.Order("id").Find(&teams).Error; err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+	return teams, nil
+}
+
+// ListTeamsPage retrieves the given page of teams from the database, along with the total number of teams.
+func (s *TeamService) ListTeamsPage(page types.Page) ([]model.Team, int64, error) {
+	var teams []model.Team
+	total, err := db.FindPage(s.db.Order("id"), page, &teams)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list teams: %w", err)
+	}
+	return teams, total, nil
+}
+
+// UpdateTeam replaces the description, members and permissions of an existing team with the given ones.
+// The name of a team cannot be changed.
+func (s *TeamService) UpdateTeam(name string, team *model.Team) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var existing model.Team
+		if err := tx.Where("name = ?", name).First(&existing).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTeamNotFound
+			}
+			return fmt.Errorf("failed to get team: %w", err)
+		}
+
+		team.Name = name
+		if err := validateTeam(tx, team); err != nil {
+			return err
+		}
+		err := tx.Model(&existing).Select("Description", "Members", "ToolGroups", "Servers").Updates(team).Error
+		if err != nil {
+			return fmt.Errorf("failed to update team: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeleteTeam removes a team from the database, which immediately revokes the permissions it granted to its members.
+func (s *TeamService) DeleteTeam(name string) error {
+	result := s.db.Unscoped().Where("name = ?", name).Delete(&model.Team{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete team: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrTeamNotFound
+	}
+	return nil
+}
+
+// ListUserTeams retrieves the teams that the given user is a member of.
+func (s *TeamService) ListUserTeams(username string) ([]model.Team, error) {
+	teams, err := s.ListTeams()
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(teams, func(t model.Team) bool {
+		return !t.HasMember(username)
+	}), nil
+}
+
+// CanAdministerToolGroup returns true if one of the teams of the given user may administer the tool group.
+func (s *TeamService) CanAdministerToolGroup(username, group string) (bool, error) {
+	teams, err := s.ListUserTeams(username)
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(teams, func(t model.Team) bool {
+		return t.CanAdministerToolGroup(group)
+	}), nil
+}
+
+// CanAdministerServer returns true if one of the teams of the given user may administer the MCP server.
+func (s *TeamService) CanAdministerServer(username, server string) (bool, error) {
+	teams, err := s.ListUserTeams(username)
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(teams, func(t model.Team) bool {
+		return t.CanAdministerServer(server)
+	}), nil
+}
+
+// validateTeam checks the name of a team and that all of its members exist.
+// The members are deduplicated, and the empty lists of the team are initialized to empty JSON arrays.
+func validateTeam(tx *gorm.DB, team *model.Team) error {
+	if !ValidTeamName.MatchString(team.Name) {
+		return fmt.Errorf(
+			"%w: name must start with an alphanumeric character and "+
+				"can only contain alphanumeric characters, underscores, and hyphens",
+			ErrInvalidTeam,
+		)
+	}
+
+	members, err := team.GetMembers()
+	if err != nil {
+		return fmt.Errorf("%w: members must be a list of usernames: %w", ErrInvalidTeam, err)
+	}
+	if _, err := team.GetToolGroups(); err != nil {
+		return fmt.Errorf("%w: tool groups must be a list of names: %w", ErrInvalidTeam, err)
+	}
+	if _, err := team.GetServers(); err != nil {
+		return fmt.Errorf("%w: servers must be a list of names: %w", ErrInvalidTeam, err)
+	}
+
+	if members == nil {
+		members = []string{}
+	}
+	slices.Sort(members)
+	members = slices.Compact(members)
+	if len(members) > 0 {
+		var existing []string
+		if err := tx.Model(&model.User{}).Where("username IN ?", members).Pluck("username", &existing).Error; err != nil {
+			return fmt.Errorf("failed to look up the members of the team: %w", err)
+		}
+		for _, m := range members {
+			if !slices.Contains(existing, m) {
+				return fmt.Errorf("%w: user %s does not exist", ErrInvalidTeam, m)
+			}
+		}
+	}
+	if team.Members, err = json.Marshal(members); err != nil {
+		return fmt.Errorf("failed to encode the members of the team: %w", err)
+	}
+
+	if team.ToolGroups == nil {
+		team.ToolGroups = []byte("[]")
+	}
+	if team.Servers == nil {
+		team.Servers = []byte("[]")
+	}
+	return nil
+}
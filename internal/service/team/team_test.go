@@ -0,0 +1,102 @@
+package team
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/user"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"gorm.io/datatypes"
+)
+
+func TestCreateTeam(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+	users := user.NewUserService(setup.DB)
+	_, _ = users.CreateUser("alice")
+	_, _ = users.CreateUser("bob")
+	svc := NewTeamService(setup.DB)
+
+	team := &model.Team{Name: "platform", Members: datatypes.JSON(`["bob", "alice", "bob"]`)}
+	testhelpers.AssertNoError(t, svc.CreateTeam(team))
+
+	created, err := svc.GetTeam("platform")
+	testhelpers.AssertNoError(t, err)
+	members, err := created.GetMembers()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 2, len(members))
+	testhelpers.AssertEqual(t, "alice", members[0])
+	testhelpers.AssertEqual(t, "[]", string(created.ToolGroups))
+
+	// the members of a team must exist
+	err = svc.CreateTeam(&model.Team{Name: "ghosts", Members: datatypes.JSON(`["carol"]`)})
+	testhelpers.AssertTrue(t, errors.Is(err, ErrInvalidTeam), "expected an unknown member to be rejected")
+
+	err = svc.CreateTeam(&model.Team{Name: "-invalid"})
+	testhelpers.AssertTrue(t, errors.Is(err, ErrInvalidTeam), "expected an invalid name to be rejected")
+
+	testhelpers.AssertError(t, svc.CreateTeam(&model.Team{Name: "platform"}))
+}
+
+func TestUpdateAndDeleteTeam(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+	users := user.NewUserService(setup.DB)
+	_, _ = users.CreateUser("alice")
+	svc := NewTeamService(setup.DB)
+
+	testhelpers.AssertNoError(t, svc.CreateTeam(&model.Team{Name: "platform"}))
+
+	err := svc.UpdateTeam("platform", &model.Team{
+		Description: "the platform team",
+		Members:     datatypes.JSON(`["alice"]`),
+		Servers:     datatypes.JSON(`["github"]`),
+	})
+	testhelpers.AssertNoError(t, err)
+	updated, err := svc.GetTeam("platform")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, "the platform team", updated.Description)
+	testhelpers.AssertTrue(t, updated.HasMember("alice"), "expected alice to be a member")
+	testhelpers.AssertTrue(t, updated.CanAdministerServer("github"), "expected the team to administer github")
+
+	err = svc.UpdateTeam("missing", &model.Team{})
+	testhelpers.AssertTrue(t, errors.Is(err, ErrTeamNotFound), "expected a missing team not to be updated")
+
+	testhelpers.AssertNoError(t, svc.DeleteTeam("platform"))
+	_, err = svc.GetTeam("platform")
+	testhelpers.AssertTrue(t, errors.Is(err, ErrTeamNotFound), "expected the team to be deleted")
+	testhelpers.AssertTrue(t, errors.Is(svc.DeleteTeam("platform"), ErrTeamNotFound), "expected a missing team")
+}
+
+func TestTeamPermissions(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+	users := user.NewUserService(setup.DB)
+	_, _ = users.CreateUser("alice")
+	_, _ = users.CreateUser("bob")
+	svc := NewTeamService(setup.DB)
+
+	testhelpers.AssertNoError(t, svc.CreateTeam(&model.Team{
+		Name:       "platform",
+		Members:    datatypes.JSON(`["alice"]`),
+		ToolGroups: datatypes.JSON(`["claude-tools"]`),
+		Servers:    datatypes.JSON(`["github"]`),
+	}))
+
+	ok, err := svc.CanAdministerToolGroup("alice", "claude-tools")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, ok, "expected a member to administer the team's tool group")
+	ok, _ = svc.CanAdministerToolGroup("alice", "cursor-tools")
+	testhelpers.AssertFalse(t, ok, "expected a member not to administer the other tool groups")
+	ok, _ = svc.CanAdministerServer("alice", "github")
+	testhelpers.AssertTrue(t, ok, "expected a member to administer the team's server")
+	ok, _ = svc.CanAdministerServer("bob", "github")
+	testhelpers.AssertFalse(t, ok, "expected a non-member not to administer the team's server")
+
+	// deleting a user removes them from their teams
+	testhelpers.AssertNoError(t, users.DeleteUser("alice"))
+	_, _ = users.CreateUser("alice")
+	ok, _ = svc.CanAdministerServer("alice", "github")
+	testhelpers.AssertFalse(t, ok, "expected a new user not to inherit the permissions of a deleted one")
+}
@@ -0,0 +1,117 @@
+package toolgroup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxCacheEntries is the maximum number of responses cached for a single tool group.
+const maxCacheEntries = 1000
+
+type cacheEntry struct {
+	result    *mcpgo.CallToolResult
+	expiresAt time.Time
+}
+
+// responseCache is an in-memory cache of tool call responses for a single tool group.
+// Only the tools that the group declares as cacheable are cached, each with its own TTL.
+type responseCache struct {
+	// ttls contains the cache TTL of each cacheable tool
+	// key: canonical tool name, value: duration for which the tool's responses are cached
+	ttls map[string]time.Duration
+
+	entries map[string]cacheEntry
+	mu      sync.Mutex
+}
+
+func newResponseCache(ttls map[string]time.Duration) *responseCache {
+	return &responseCache{
+		ttls:    ttls,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+// ttl returns the cache TTL of the given tool and whether the tool is cacheable at all.
+func (c *responseCache) ttl(toolName string) (time.Duration, bool) {
+	ttl, ok := c.ttls[toolName]
+	return ttl, ok
+}
+
+// get returns the cached response for the given key, if one exists and has not expired yet.
+func (c *responseCache) get(key string) (*mcpgo.CallToolResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.result, true
+}
+
+// set caches the response for the given key for the specified duration.
+// If the cache is full even after evicting expired entries, the response is simply not cached.
+func (c *responseCache) set(key string, result *mcpgo.CallToolResult, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= maxCacheEntries {
+		c.evictExpired()
+		if len(c.entries) >= maxCacheEntries {
+			return
+		}
+	}
+	c.entries[key] = cacheEntry{result: result, expiresAt: time.Now().Add(ttl)}
+}
+
+// invalidateTools removes all cached responses of the given tools.
+func (c *responseCache) invalidateTools(toolNames ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.entries {
+		for _, name := range toolNames {
+			if toolNameFromCacheKey(key) == name {
+				delete(c.entries, key)
+				break
+			}
+		}
+	}
+}
+
+// evictExpired removes all expired entries from the cache.
+// The caller must hold the lock.
+func (c *responseCache) evictExpired() {
+	now := time.Now()
+	for key, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// cacheKey returns the key that identifies a tool call, ie, the tool name + a hash of its arguments.
+// json.Marshal sorts map keys, so identical arguments always produce the same key.
+func cacheKey(toolName string, arguments any) (string, error) {
+	args, err := json.Marshal(arguments)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(args)
+	return toolName + "\x00" + hex.EncodeToString(sum[:]), nil
+}
+
+// toolNameFromCacheKey extracts the tool name from a key created by cacheKey.
+func toolNameFromCacheKey(key string) string {
+	// the hash is always a 64-character hex string, preceded by a null byte
+	return key[:len(key)-65]
+}
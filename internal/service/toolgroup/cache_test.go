@@ -0,0 +1,53 @@
+package toolgroup
+
+import (
+	"testing"
+	"time"
+
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestCacheKey(t *testing.T) {
+	k1, err := cacheKey("server__tool", map[string]any{"a": 1, "b": "x"})
+	testhelpers.AssertNoError(t, err)
+	k2, err := cacheKey("server__tool", map[string]any{"b": "x", "a": 1})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, k1, k2)
+
+	k3, err := cacheKey("server__tool", map[string]any{"a": 2, "b": "x"})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, k1 != k3, "Expected different arguments to produce different keys")
+
+	testhelpers.AssertEqual(t, "server__tool", toolNameFromCacheKey(k1))
+}
+
+func TestResponseCache(t *testing.T) {
+	c := newResponseCache(map[string]time.Duration{"server__lookup": time.Minute})
+
+	ttl, ok := c.ttl("server__lookup")
+	testhelpers.AssertTrue(t, ok, "Expected server__lookup to be cacheable")
+	testhelpers.AssertEqual(t, time.Minute, ttl)
+	_, ok = c.ttl("server__write")
+	testhelpers.AssertFalse(t, ok, "Expected server__write to not be cacheable")
+
+	key, err := cacheKey("server__lookup", map[string]any{"q": "foo"})
+	testhelpers.AssertNoError(t, err)
+	_, ok = c.get(key)
+	testhelpers.AssertFalse(t, ok, "Expected cache miss on empty cache")
+
+	res := mcpgo.NewToolResultText("bar")
+	c.set(key, res, time.Minute)
+	cached, ok := c.get(key)
+	testhelpers.AssertTrue(t, ok, "Expected cache hit after set")
+	testhelpers.AssertEqual(t, res, cached)
+
+	c.invalidateTools("server__lookup")
+	_, ok = c.get(key)
+	testhelpers.AssertFalse(t, ok, "Expected cache miss after invalidation")
+
+	// expired entries must not be served
+	c.set(key, res, -time.Second)
+	_, ok = c.get(key)
+	testhelpers.AssertFalse(t, ok, "Expected cache miss for expired entry")
+}
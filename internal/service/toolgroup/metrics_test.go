@@ -0,0 +1,41 @@
+package toolgroup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+// groupCallMetrics records the tool calls made via tool groups
+type groupCallMetrics struct {
+	telemetry.NoopCustomMetrics
+	calls []string
+}
+
+func (m *groupCallMetrics) RecordToolGroupToolCall(
+	ctx context.Context, groupName, toolName string, outcome telemetry.ToolCallOutcome, elapsedTime time.Duration,
+) {
+	m.calls = append(m.calls, groupName+"/"+toolName+"/"+string(outcome))
+}
+
+func TestGroupToolCallHandlerRecordsMetrics(t *testing.T) {
+	metrics := &groupCallMetrics{}
+	s := &ToolGroupService{
+		mcpService: &mcp.MCPService{},
+		metrics:    metrics,
+	}
+
+	// the tool name has no server prefix, so the call fails before reaching any upstream server
+	req := mcpgo.CallToolRequest{}
+	req.Params.Name = "add"
+	_, err := s.groupToolCallHandler("research")(context.Background(), req)
+	testhelpers.AssertTrue(t, err != nil, "Expected the tool call to fail")
+
+	testhelpers.AssertEqual(t, 1, len(metrics.calls))
+	testhelpers.AssertEqual(t, "research/add/error", metrics.calls[0])
+}
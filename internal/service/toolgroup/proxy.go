@@ -0,0 +1,87 @@
+package toolgroup
+
+import (
+	"sync"
+	"sync/atomic"
+
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// groupProxy holds what serves the MCP traffic of a tool group: its MCP proxy servers (normal + SSE)
+// and the response cache of its tools.
+type groupProxy struct {
+	// mu serializes the changes to the tools served by the group, so that an update of the group and the
+	// addition or deletion of a tool in mcpjungle don't interleave.
+	// It is not needed to serve the group's traffic, the MCP servers are safe for concurrent use.
+	mu sync.Mutex
+
+	mcpServer    *server.MCPServer
+	sseMcpServer *server.MCPServer
+	cache        atomic.Pointer[responseCache]
+}
+
+func newGroupProxy(mcpServer, sseMcpServer *server.MCPServer, cache *responseCache) *groupProxy {
+	p := &groupProxy{mcpServer: mcpServer, sseMcpServer: sseMcpServer}
+	p.cache.Store(cache)
+	return p
+}
+
+// toolChanges collects changes to the tools served by a group, so that they are made to its MCP servers at once.
+type toolChanges struct {
+	add, addSSE       []server.ServerTool
+	remove, removeSSE []string
+}
+
+// addTool adds a tool to the group's MCP server of the right transport.
+func (c *toolChanges) addTool(tool mcpgo.Tool, sse bool, handler server.ToolHandlerFunc) {
+	t := server.ServerTool{Tool: tool, Handler: handler}
+	if sse {
+		c.addSSE = append(c.addSSE, t)
+	} else {
+		c.add = append(c.add, t)
+	}
+}
+
+// removeTool removes a tool from the group's MCP server of the right transport.
+func (c *toolChanges) removeTool(name string, sse bool) {
+	if sse {
+		c.removeSSE = append(c.removeSSE, name)
+	} else {
+		c.remove = append(c.remove, name)
+	}
+}
+
+// applyLocked makes the changes to the group's MCP servers, p.mu must be held.
+// Each MCP server is changed at most once per kind of change, and only if needed, since every change locks
+// the MCP server and notifies all its clients that the list of tools changed.
+func (p *groupProxy) applyLocked(c *toolChanges) {
+	if len(c.remove) > 0 {
+		p.mcpServer.DeleteTools(c.remove...)
+	}
+	if len(c.removeSSE) > 0 {
+		p.sseMcpServer.DeleteTools(c.removeSSE...)
+	}
+	if len(c.add) > 0 {
+		p.mcpServer.AddTools(c.add...)
+	}
+	if len(c.addSSE) > 0 {
+		p.sseMcpServer.AddTools(c.addSSE...)
+	}
+}
+
+// apply makes the changes to the group's MCP servers.
+func (p *groupProxy) apply(c *toolChanges) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.applyLocked(c)
+}
+
+// deleteTools removes tools from the group's MCP servers, along with their cached responses.
+func (p *groupProxy) deleteTools(tools ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.mcpServer.DeleteTools(tools...)
+	p.sseMcpServer.DeleteTools(tools...)
+	p.cache.Load().invalidateTools(tools...)
+}
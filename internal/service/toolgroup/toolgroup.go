@@ -2,18 +2,27 @@
 package toolgroup
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"regexp"
+	"slices"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	mcpgo "github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/db"
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"github.com/mcpjungle/mcpjungle/pkg/util"
 	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
 )
 
 var ErrToolGroupNotFound = errors.New("tool group not found")
@@ -29,30 +38,28 @@ type ToolGroupService struct {
 	db *gorm.DB
 
 	mcpService *mcp.MCPService
-
-	// mcpServers manages the MCP proxy servers for all the tool groups
-	// key: tool group name, value: MCP proxy server
-	mcpServers map[string]*server.MCPServer
-	// mcpServersMu protects access to the mcpServers map
-	mcpServersMu sync.RWMutex
-
-	// sseMcpServers manages the SSE MCP proxy servers for all the tool groups
-	// key: tool group name, value: MCP proxy server
-	sseMcpServers map[string]*server.MCPServer
-	// sseMcpServerMu protects access to the sseMcpServers map
-	sseMcpServerMu sync.RWMutex
+	metrics    telemetry.CustomMetrics
+
+	// groups manages the MCP proxy servers & response caches of all the tool groups
+	// key: tool group name, value: the group's proxy
+	// The map is copy-on-write: it is never modified once stored, but replaced by an updated copy whenever a group
+	// is added or removed. So serving the groups' MCP traffic never takes a lock, and changes to a group only
+	// lock that group.
+	groups atomic.Pointer[map[string]*groupProxy]
+	// groupsMu serializes the replacements of the groups map
+	groupsMu sync.Mutex
+
+	// toolsVersion is incremented whenever the tools served by any tool group change
+	toolsVersion atomic.Uint64
 }
 
-func NewToolGroupService(db *gorm.DB, mcpService *mcp.MCPService) (*ToolGroupService, error) {
+func NewToolGroupService(
+	db *gorm.DB, mcpService *mcp.MCPService, metrics telemetry.CustomMetrics,
+) (*ToolGroupService, error) {
 	s := &ToolGroupService{
 		db:         db,
 		mcpService: mcpService,
-
-		mcpServers:   make(map[string]*server.MCPServer),
-		mcpServersMu: sync.RWMutex{},
-
-		sseMcpServers:  make(map[string]*server.MCPServer),
-		sseMcpServerMu: sync.RWMutex{},
+		metrics:    metrics,
 	}
 
 	// register callbacks with mcp service to be notified when a tool gets added/removed
@@ -65,35 +72,62 @@ func NewToolGroupService(db *gorm.DB, mcpService *mcp.MCPService) (*ToolGroupSer
 	return s, nil
 }
 
-// CreateToolGroup creates a new tool group in the database and a Proxy MCP server that just exposes the specified tools.
-func (s *ToolGroupService) CreateToolGroup(group *model.ToolGroup) error {
+// ValidateToolGroup checks the configuration of a tool group without creating it.
+// It returns the sorted names of the tools the group would expose.
+// Whether a group with the same name already exists is not checked.
+func (s *ToolGroupService) ValidateToolGroup(group *model.ToolGroup) ([]string, error) {
 	// validate the tool group name
 	if len(group.Name) == 0 {
-		return errors.New("tool group name cannot be empty")
+		return nil, errors.New("tool group name cannot be empty")
 	}
 	if !ValidGroupName.MatchString(group.Name) {
-		return fmt.Errorf(
+		return nil, fmt.Errorf(
 			"invalid group name: name must start with an alphanumeric character and " +
 				"can only contain alphanumeric characters, underscores, and hyphens",
 		)
 	}
 
+	if _, err := group.GetCacheTTLs(); err != nil {
+		return nil, fmt.Errorf("invalid cache configuration: %w", err)
+	}
+
 	// resolve all effective tools for this group
-	toolNames, err := group.ResolveEffectiveTools(s.mcpService)
+	toolNames, err := s.resolveGroupTools(group)
 	if err != nil {
-		return fmt.Errorf("failed to resolve effective tools: %w", err)
+		return nil, fmt.Errorf("failed to resolve effective tools: %w", err)
 	}
 	if len(toolNames) == 0 {
-		return errors.New("tool group must contain at least one tool after resolving servers and exclusions")
+		return nil, errors.New("tool group must contain at least one tool after resolving servers, exclusions and read-only mode")
+	}
+	for _, name := range toolNames {
+		if _, exists := s.mcpService.GetToolInstance(name); !exists {
+			return nil, fmt.Errorf("tool %s does not exist or is disabled", name)
+		}
+	}
+
+	slices.Sort(toolNames)
+	return toolNames, nil
+}
+
+// CreateToolGroup creates a new tool group in the database and a Proxy MCP server that just exposes the specified tools.
+func (s *ToolGroupService) CreateToolGroup(group *model.ToolGroup) error {
+	toolNames, err := s.ValidateToolGroup(group)
+	if err != nil {
+		return err
+	}
+	cacheTTLs, err := group.GetCacheTTLs()
+	if err != nil {
+		return fmt.Errorf("invalid cache configuration: %w", err)
 	}
 
 	// create the proxy MCP servers that expose only specified tools
-	mcpServer := s.newMCPServer(group.Name)
-	sseMcpServer := s.newSseMCPServer(group.Name)
+	proxy := newGroupProxy(s.newMCPServer(group.Name), s.newSseMCPServer(group.Name), newResponseCache(cacheTTLs))
+	toolCallHandler := s.groupToolCallHandler(group.Name)
 
 	// populate the MCP servers with the specified tools
 	// this also has a side effect of validating that the tools exist in mcpjungle.
 	// if a tool does not exist, return an error without creating the group.
+	var changes toolChanges
 	for _, name := range toolNames {
 		tool, exists := s.mcpService.GetToolInstance(name)
 		if !exists {
@@ -105,12 +139,9 @@ func (s *ToolGroupService) CreateToolGroup(group *model.ToolGroup) error {
 			return fmt.Errorf("failed to get parent MCP server of the tool %s: %w", name, err)
 		}
 
-		if parentServer.Transport == types.TransportSSE {
-			sseMcpServer.AddTool(tool, s.mcpService.MCPProxyToolCallHandler)
-		} else {
-			mcpServer.AddTool(tool, s.mcpService.MCPProxyToolCallHandler)
-		}
+		changes.addTool(tool, parentServer.Transport == types.TransportSSE, toolCallHandler)
 	}
+	proxy.apply(&changes)
 
 	// first, add the tool group to the database
 	// this also checks for uniqueness of the group's name
@@ -119,8 +150,8 @@ func (s *ToolGroupService) CreateToolGroup(group *model.ToolGroup) error {
 	}
 
 	// finally, add the proxy MCPs to the tool group MCPs manager so that it is ready to serve
-	s.addToolGroupMCPServer(group.Name, mcpServer)
-	s.addToolGroupSseMCPServer(group.Name, sseMcpServer)
+	s.setGroupProxy(group.Name, proxy)
+	s.toolsVersion.Add(1)
 
 	return nil
 }
@@ -129,7 +160,7 @@ func (s *ToolGroupService) CreateToolGroup(group *model.ToolGroup) error {
 // It returns the configuration of the original tool group before the update.
 // If the tool group does not exist, it returns ErrToolGroupNotFound.
 func (s *ToolGroupService) UpdateToolGroup(name string, updatedGroup *model.ToolGroup) (*model.ToolGroup, error) {
-	oldGroup, err := s.GetToolGroup(name)
+	oldGroup, err := s.getToolGroup(s.primary(), name)
 	if err != nil {
 		if errors.Is(err, ErrToolGroupNotFound) {
 			return nil, err
@@ -137,12 +168,22 @@ func (s *ToolGroupService) UpdateToolGroup(name string, updatedGroup *model.Tool
 		return nil, fmt.Errorf("failed to retrieve the tool group: %w", err)
 	}
 
+	oldCacheTTLs, err := oldGroup.GetCacheTTLs()
+	if err != nil {
+		return nil, fmt.Errorf("invalid cache configuration of original group: %w", err)
+	}
+	updatedCacheTTLs, err := updatedGroup.GetCacheTTLs()
+	if err != nil {
+		return nil, fmt.Errorf("invalid cache configuration: %w", err)
+	}
+	cacheChanged := !maps.Equal(oldCacheTTLs, updatedCacheTTLs)
+
 	// determine which tools were added or removed from the group
-	oldToolNames, err := oldGroup.ResolveEffectiveTools(s.mcpService)
+	oldToolNames, err := s.resolveGroupTools(oldGroup)
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve effective tools of original group: %w", err)
 	}
-	updatedToolNames, err := updatedGroup.ResolveEffectiveTools(s.mcpService)
+	updatedToolNames, err := s.resolveGroupTools(updatedGroup)
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve effective tools of the updated group: %w", err)
 	}
@@ -150,23 +191,21 @@ func (s *ToolGroupService) UpdateToolGroup(name string, updatedGroup *model.Tool
 	toolsAdded, toolsRemoved := util.DiffTools(oldToolNames, updatedToolNames)
 
 	// if nothing was actually changed in the group, no need to proceed further
-	if updatedGroup.Description == oldGroup.Description && len(toolsAdded) == 0 && len(toolsRemoved) == 0 {
+	if updatedGroup.Description == oldGroup.Description && updatedGroup.ReadOnly == oldGroup.ReadOnly &&
+		!cacheChanged && len(toolsAdded) == 0 && len(toolsRemoved) == 0 {
 		return oldGroup, nil
 	}
 
 	// determine the changes to make to the tool group's proxy MCP server instances (normal + SSE)
 	// all changes are ultimately made at the end of this method to avoid inconsistent state in case of errors.
-	mcpServer, exists := s.GetToolGroupMCPServer(name)
+	proxy, exists := s.getGroupProxy(name)
 	if !exists {
 		return nil, fmt.Errorf("MCP server for tool group %s does not exist", name)
 	}
-	sseMcpServer, exists := s.GetToolGroupSseMCPServer(name)
-	if !exists {
-		return nil, fmt.Errorf("SSE MCP server for tool group %s does not exist", name)
-	}
 
 	// tools added to the group must be added to its MCP server instances
-	var sseToolsToAdd, normalToolsToAdd []mcpgo.Tool
+	var changes toolChanges
+	toolCallHandler := s.groupToolCallHandler(name)
 	for _, toolName := range toolsAdded {
 		tool, exists := s.mcpService.GetToolInstance(toolName)
 		if !exists {
@@ -178,55 +217,113 @@ func (s *ToolGroupService) UpdateToolGroup(name string, updatedGroup *model.Tool
 			return nil, fmt.Errorf("failed to get parent MCP server of the tool %s: %w", toolName, err)
 		}
 
-		if parentServer.Transport == types.TransportSSE {
-			sseToolsToAdd = append(sseToolsToAdd, tool)
-		} else {
-			normalToolsToAdd = append(normalToolsToAdd, tool)
-		}
+		changes.addTool(tool, parentServer.Transport == types.TransportSSE, toolCallHandler)
 	}
 
 	// tools removed from the group must be removed from its MCP server instances
-	var sseToolsToRemove, normalToolsToRemove []string
 	for _, toolName := range toolsRemoved {
 		parentServer, err := s.mcpService.GetToolParentServer(toolName)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get parent MCP server of the tool %s: %w", toolName, err)
 		}
 
-		if parentServer.Transport == types.TransportSSE {
-			sseToolsToRemove = append(sseToolsToRemove, toolName)
-		} else {
-			normalToolsToRemove = append(normalToolsToRemove, toolName)
-		}
+		changes.removeTool(toolName, parentServer.Transport == types.TransportSSE)
 	}
 
 	// make all the changes together to avoid inconsistent state in case of errors
-	mcpServer.DeleteTools(normalToolsToRemove...)
-	sseMcpServer.DeleteTools(sseToolsToRemove...)
+	proxy.mu.Lock()
+	proxy.applyLocked(&changes)
+	s.toolsVersion.Add(1)
 
-	for _, tool := range normalToolsToAdd {
-		mcpServer.AddTool(tool, s.mcpService.MCPProxyToolCallHandler)
-	}
-	for _, tool := range sseToolsToAdd {
-		sseMcpServer.AddTool(tool, s.mcpService.MCPProxyToolCallHandler)
+	// replacing the cache also drops all responses cached under the old configuration
+	if cacheChanged {
+		proxy.cache.Store(newResponseCache(updatedCacheTTLs))
 	}
+	proxy.mu.Unlock()
 
 	// as a final step, update the tool group record in the database
 	// we only persist this update after successfully updating the in-memory state
 
 	// ensure the group name remains unchanged in the db record
 	updatedGroup.Name = name
-	if err := s.db.Model(&model.ToolGroup{}).Where("name = ?", name).Updates(updatedGroup).Error; err != nil {
+	// the new configuration completely overrides the old one, so columns are selected explicitly.
+	// otherwise gorm would skip zero values like read_only=false.
+	err = s.db.Model(&model.ToolGroup{}).
+		Where("name = ?", name).
+		Select("description", "included_tools", "included_servers", "excluded_tools", "read_only", "cached_tools").
+		Updates(updatedGroup).Error
+	if err != nil {
 		return nil, fmt.Errorf("failed to update tool group in DB: %w", err)
 	}
 
 	return oldGroup, nil
 }
 
+// PatchToolGroup updates only the fields of a tool group that are set in the patch, leaving the others unchanged.
+// It returns the configuration of the tool group before and after the update.
+// If the tool group does not exist, it returns ErrToolGroupNotFound.
+func (s *ToolGroupService) PatchToolGroup(
+	name string, patch *types.PatchToolGroupInput,
+) (*model.ToolGroup, *model.ToolGroup, error) {
+	group, err := s.getToolGroup(s.primary(), name)
+	if err != nil {
+		if errors.Is(err, ErrToolGroupNotFound) {
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("failed to retrieve the tool group: %w", err)
+	}
+	if err := applyToolGroupPatch(group, patch); err != nil {
+		return nil, nil, err
+	}
+
+	oldGroup, err := s.UpdateToolGroup(name, group)
+	if err != nil {
+		return nil, nil, err
+	}
+	return oldGroup, group, nil
+}
+
+// applyToolGroupPatch sets the fields of the group that are set in the patch.
+func applyToolGroupPatch(group *model.ToolGroup, patch *types.PatchToolGroupInput) error {
+	if patch.Description != nil {
+		group.Description = *patch.Description
+	}
+	if patch.ReadOnly != nil {
+		group.ReadOnly = *patch.ReadOnly
+	}
+
+	var err error
+	if patch.IncludedTools != nil {
+		if group.IncludedTools, err = json.Marshal(*patch.IncludedTools); err != nil {
+			return fmt.Errorf("failed to serialize included tools: %w", err)
+		}
+	}
+	if patch.IncludedServers != nil {
+		if group.IncludedServers, err = json.Marshal(*patch.IncludedServers); err != nil {
+			return fmt.Errorf("failed to serialize included servers: %w", err)
+		}
+	}
+	if patch.ExcludedTools != nil {
+		if group.ExcludedTools, err = json.Marshal(*patch.ExcludedTools); err != nil {
+			return fmt.Errorf("failed to serialize excluded tools: %w", err)
+		}
+	}
+	if patch.CachedTools != nil {
+		if group.CachedTools, err = json.Marshal(*patch.CachedTools); err != nil {
+			return fmt.Errorf("failed to serialize cached tools: %w", err)
+		}
+	}
+	return nil
+}
+
 // GetToolGroup retrieves a tool group by name from the database.
 func (s *ToolGroupService) GetToolGroup(name string) (*model.ToolGroup, error) {
+	return s.getToolGroup(s.db, name)
+}
+
+func (s *ToolGroupService) getToolGroup(db *gorm.DB, name string) (*model.ToolGroup, error) {
 	var group model.ToolGroup
-	if err := s.db.Where("name = ?", name).First(&group).Error; err != nil {
+	if err := db.Where("name = ?", name).First(&group).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrToolGroupNotFound
 		}
@@ -237,15 +334,30 @@ func (s *ToolGroupService) GetToolGroup(name string) (*model.ToolGroup, error) {
 
 // ListToolGroups retrieves all tool groups from the database.
 func (s *ToolGroupService) ListToolGroups() ([]model.ToolGroup, error) {
+	return s.listToolGroups(s.db)
+}
+
+func (s *ToolGroupService) listToolGroups(db *gorm.DB) ([]model.ToolGroup, error) {
 	var groups []model.ToolGroup
-	if err := s.db.Find(&groups).Error; err != nil {
+	if err := db.Order("id").Find(&groups).Error; err != nil {
 		return nil, err
 	}
 	return groups, nil
 }
 
+// ListToolGroupsPage retrieves the given page of tool groups from the database, along with the total number of groups.
+func (s *ToolGroupService) ListToolGroupsPage(page types.Page) ([]model.ToolGroup, int64, error) {
+	var groups []model.ToolGroup
+	total, err := db.FindPage(s.db.Order("id"), page, &groups)
+	if err != nil {
+		return nil, 0, err
+	}
+	return groups, total, nil
+}
+
 func (s *ToolGroupService) DeleteToolGroup(name string) error {
-	s.deleteToolGroupMCPServers(name)
+	s.deleteGroupProxy(name)
+	s.toolsVersion.Add(1)
 
 	err := s.db.Unscoped().Where("name = ?", name).Delete(&model.ToolGroup{}).Error
 	if err != nil {
@@ -254,20 +366,104 @@ func (s *ToolGroupService) DeleteToolGroup(name string) error {
 	return nil
 }
 
+// GetEffectiveTools returns the names of the tools exposed by the given tool group,
+// ie, after applying its inclusion & exclusion rules and its read-only mode.
+func (s *ToolGroupService) GetEffectiveTools(name string) ([]string, error) {
+	group, err := s.getToolGroup(s.primary(), name)
+	if err != nil {
+		return nil, err
+	}
+	return s.resolveGroupTools(group)
+}
+
+// ListGroupsUsingServer returns the tool groups that expose tools of the given MCP server,
+// along with the names of those tools.
+func (s *ToolGroupService) ListGroupsUsingServer(serverName string) ([]types.ToolGroupConsumer, error) {
+	groups, err := s.ListToolGroups()
+	if err != nil {
+		return nil, err
+	}
+	consumers := make([]types.ToolGroupConsumer, 0)
+	for i := range groups {
+		tools, err := s.resolveGroupTools(&groups[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve the tools of group %s: %w", groups[i].Name, err)
+		}
+		var serverTools []string
+		for _, t := range tools {
+			if name, ok := mcp.ToolServerName(t); ok && name == serverName {
+				serverTools = append(serverTools, t)
+			}
+		}
+		if len(serverTools) > 0 {
+			consumers = append(consumers, types.ToolGroupConsumer{Name: groups[i].Name, Tools: serverTools})
+		}
+	}
+	return consumers, nil
+}
+
+// resolveGroupTools resolves the names of all tools that should be exposed by a group.
+// On top of the group's inclusion & exclusion rules, this also applies read-only mode (if enabled).
+func (s *ToolGroupService) resolveGroupTools(group *model.ToolGroup) ([]string, error) {
+	toolNames, err := group.ResolveEffectiveTools(s.mcpService)
+	if err != nil {
+		return nil, err
+	}
+	if !group.ReadOnly {
+		return toolNames, nil
+	}
+
+	result := make([]string, 0, len(toolNames))
+	for _, name := range toolNames {
+		tool, exists := s.mcpService.GetToolInstance(name)
+		if exists && isWriteTool(tool) {
+			continue
+		}
+		// non-existent tools are kept so that callers can decide how to deal with them.
+		result = append(result, name)
+	}
+	return result, nil
+}
+
+// isWriteTool returns true if the tool's annotations indicate that it may modify its environment,
+// ie, it is marked as destructive or explicitly marked as not read-only.
+func isWriteTool(tool mcpgo.Tool) bool {
+	a := tool.Annotations
+	if a.DestructiveHint != nil && *a.DestructiveHint {
+		return true
+	}
+	return a.ReadOnlyHint != nil && !*a.ReadOnlyHint
+}
+
+// primary returns the database to read from in the code paths that change the tool groups or their proxies.
+// Their reads must not see the stale data of a lagging read replica, so they always go to the primary database.
+func (s *ToolGroupService) primary() *gorm.DB {
+	return s.db.Clauses(dbresolver.Write).Session(&gorm.Session{})
+}
+
+// ToolsVersion returns a number that changes whenever the tools served by the tool groups' MCP proxy servers change.
+// Anything computed from the tools served by a group after reading a version is up-to-date
+// as long as the version remains the same.
+func (s *ToolGroupService) ToolsVersion() uint64 {
+	return s.toolsVersion.Load()
+}
+
 // GetToolGroupMCPServer retrieves the MCP proxy server for a given tool group name.
 func (s *ToolGroupService) GetToolGroupMCPServer(name string) (*server.MCPServer, bool) {
-	s.mcpServersMu.RLock()
-	defer s.mcpServersMu.RUnlock()
-	mcpServer, exists := s.mcpServers[name]
-	return mcpServer, exists
+	proxy, exists := s.getGroupProxy(name)
+	if !exists {
+		return nil, false
+	}
+	return proxy.mcpServer, true
 }
 
 // GetToolGroupSseMCPServer retrieves the SSE MCP proxy server for a given tool group name.
 func (s *ToolGroupService) GetToolGroupSseMCPServer(name string) (*server.MCPServer, bool) {
-	s.sseMcpServerMu.RLock()
-	defer s.sseMcpServerMu.RUnlock()
-	mcpServer, exists := s.sseMcpServers[name]
-	return mcpServer, exists
+	proxy, exists := s.getGroupProxy(name)
+	if !exists {
+		return nil, false
+	}
+	return proxy.sseMcpServer, true
 }
 
 // newMCPServer creates a new MCP proxy server for a given tool group name.
@@ -290,56 +486,133 @@ func (s *ToolGroupService) newSseMCPServer(groupName string) *server.MCPServer {
 	)
 }
 
-// addToolGroupMCPServer adds or updates the MCP proxy server for a given tool group name.
-// If a group with the same name already exists, it will be replaced.
+// groupProxies returns the proxies of all the tool groups.
+// The returned map must not be modified.
+func (s *ToolGroupService) groupProxies() map[string]*groupProxy {
+	if groups := s.groups.Load(); groups != nil {
+		return *groups
+	}
+	return nil
+}
+
+// getGroupProxy retrieves the proxy of a given tool group name.
+func (s *ToolGroupService) getGroupProxy(name string) (*groupProxy, bool) {
+	proxy, exists := s.groupProxies()[name]
+	return proxy, exists
+}
+
+// setGroupProxy adds or replaces the proxy of a given tool group name.
 // This method is safe to call concurrently.
-func (s *ToolGroupService) addToolGroupMCPServer(name string, mcpServer *server.MCPServer) {
-	s.mcpServersMu.Lock()
-	defer s.mcpServersMu.Unlock()
-	s.mcpServers[name] = mcpServer
+func (s *ToolGroupService) setGroupProxy(name string, proxy *groupProxy) {
+	s.groupsMu.Lock()
+	defer s.groupsMu.Unlock()
+	groups := maps.Clone(s.groupProxies())
+	if groups == nil {
+		groups = make(map[string]*groupProxy)
+	}
+	groups[name] = proxy
+	s.groups.Store(&groups)
 }
 
-// addToolGroupSseMCPServer adds or updates the SSE MCP proxy server for a given tool group name.
-// If a group with the same name already exists, it will be replaced.
+// deleteGroupProxy removes the proxy of a given tool group name.
 // This method is safe to call concurrently.
-func (s *ToolGroupService) addToolGroupSseMCPServer(name string, mcpServer *server.MCPServer) {
-	s.sseMcpServerMu.Lock()
-	defer s.sseMcpServerMu.Unlock()
-	s.sseMcpServers[name] = mcpServer
+func (s *ToolGroupService) deleteGroupProxy(name string) {
+	s.groupsMu.Lock()
+	defer s.groupsMu.Unlock()
+	groups := maps.Clone(s.groupProxies())
+	delete(groups, name)
+	s.groups.Store(&groups)
 }
 
-// deleteToolGroupMCPServers removes the MCP proxy servers for a given tool group name.
-func (s *ToolGroupService) deleteToolGroupMCPServers(name string) {
-	// first, acquire both locks to ensure complete cleanup of the group
-	s.mcpServersMu.Lock()
-	defer s.mcpServersMu.Unlock()
+// getResponseCache retrieves the response cache for a given tool group name.
+func (s *ToolGroupService) getResponseCache(name string) (*responseCache, bool) {
+	proxy, exists := s.getGroupProxy(name)
+	if !exists {
+		return nil, false
+	}
+	return proxy.cache.Load(), true
+}
 
-	s.sseMcpServerMu.Lock()
-	defer s.sseMcpServerMu.Unlock()
+// groupToolCallHandler returns the handler for tool calls made via the MCP proxy servers of a tool group.
+// Every call is recorded in the group's metrics, so that operators can see which groups are used.
+func (s *ToolGroupService) groupToolCallHandler(groupName string) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcpgo.CallToolRequest) (*mcpgo.CallToolResult, error) {
+		started := time.Now()
+		name := request.Params.Name
 
-	// proceed to delete both normal & sse proxies for the group, then release the locks
-	delete(s.mcpServers, name)
-	delete(s.sseMcpServers, name)
+		res, err := s.callGroupTool(ctx, groupName, request)
+
+		outcome := telemetry.ToolCallOutcomeSuccess
+		if err != nil {
+			outcome = telemetry.ToolCallOutcomeError
+		}
+		s.metrics.RecordToolGroupToolCall(ctx, groupName, name, outcome, time.Since(started))
+		return res, err
+	}
+}
+
+// callGroupTool serves a tool call made via the MCP proxy servers of a tool group.
+// Calls to the group's cacheable tools are served from its response cache when possible,
+// all other calls are simply forwarded to the upstream MCP server.
+func (s *ToolGroupService) callGroupTool(
+	ctx context.Context, groupName string, request mcpgo.CallToolRequest,
+) (*mcpgo.CallToolResult, error) {
+	name := request.Params.Name
+
+	cache, exists := s.getResponseCache(groupName)
+	if !exists {
+		return s.mcpService.MCPProxyToolCallHandler(ctx, request)
+	}
+	ttl, cacheable := cache.ttl(name)
+	if !cacheable {
+		return s.mcpService.MCPProxyToolCallHandler(ctx, request)
+	}
+	key, err := cacheKey(name, request.Params.Arguments)
+	if err != nil {
+		// arguments that cannot be hashed are not worth failing the call over
+		return s.mcpService.MCPProxyToolCallHandler(ctx, request)
+	}
+
+	// a cached response must only be served to clients that are allowed to call the tool
+	if err := s.mcpService.AuthorizeToolCall(ctx, name); err != nil {
+		return nil, err
+	}
+	if res, ok := cache.get(key); ok {
+		return res, nil
+	}
+
+	res, err := s.mcpService.MCPProxyToolCallHandler(ctx, request)
+	if err == nil && res != nil && !res.IsError {
+		// only successful responses are cached, errors may be transient
+		cache.set(key, res, ttl)
+	}
+	return res, err
 }
 
 // initToolGroupMCPServers initializes the MCP proxy servers for all existing tool groups in the database.
 // It initializes both the mcpServers and sseMcpServers.
 func (s *ToolGroupService) initToolGroupMCPServers() error {
-	groups, err := s.ListToolGroups()
+	groups, err := s.listToolGroups(s.primary())
 	if err != nil {
 		return fmt.Errorf("failed to list tool groups from DB: %w", err)
 	}
 
 	for _, group := range groups {
-		toolNames, err := group.ResolveEffectiveTools(s.mcpService)
+		toolNames, err := s.resolveGroupTools(&group)
 		if err != nil {
 			return fmt.Errorf("failed to resolve effective tools for group %s: %w", group.Name, err)
 		}
 		// TODO: Log a warning if a group has no tools, ie, len(toolNames) == 0
 
-		mcpServer := s.newMCPServer(group.Name)
-		sseMcpServer := s.newSseMCPServer(group.Name)
+		cacheTTLs, err := group.GetCacheTTLs()
+		if err != nil {
+			return fmt.Errorf("invalid cache configuration for group %s: %w", group.Name, err)
+		}
 
+		proxy := newGroupProxy(s.newMCPServer(group.Name), s.newSseMCPServer(group.Name), newResponseCache(cacheTTLs))
+		toolCallHandler := s.groupToolCallHandler(group.Name)
+
+		var changes toolChanges
 		for _, name := range toolNames {
 			tool, exists := s.mcpService.GetToolInstance(name)
 			if !exists {
@@ -354,15 +627,11 @@ func (s *ToolGroupService) initToolGroupMCPServers() error {
 				return fmt.Errorf("failed to get parent MCP server of the tool %s: %w", name, err)
 			}
 
-			if parentServer.Transport == types.TransportSSE {
-				sseMcpServer.AddTool(tool, s.mcpService.MCPProxyToolCallHandler)
-			} else {
-				mcpServer.AddTool(tool, s.mcpService.MCPProxyToolCallHandler)
-			}
+			changes.addTool(tool, parentServer.Transport == types.TransportSSE, toolCallHandler)
 		}
+		proxy.apply(&changes)
 
-		s.addToolGroupMCPServer(group.Name, mcpServer)
-		s.addToolGroupSseMCPServer(group.Name, sseMcpServer)
+		s.setGroupProxy(group.Name, proxy)
 	}
 
 	return nil
@@ -371,81 +640,70 @@ func (s *ToolGroupService) initToolGroupMCPServers() error {
 // handleToolDeletion is a callback that is called when one or more tools is deleted or disabled.
 // It removes the tools from all tool group MCP proxy servers.
 func (s *ToolGroupService) handleToolDeletion(tools ...string) {
-	s.mcpServersMu.RLock()
-	defer s.mcpServersMu.RUnlock()
-
-	s.sseMcpServerMu.Lock()
-	defer s.sseMcpServerMu.Unlock()
-
-	for _, mcpServer := range s.mcpServers {
-		mcpServer.DeleteTools(tools...)
+	for _, proxy := range s.groupProxies() {
+		proxy.deleteTools(tools...)
 	}
+	s.toolsVersion.Add(1)
+}
 
-	for _, sseMcpServer := range s.sseMcpServers {
-		sseMcpServer.DeleteTools(tools...)
+// handleToolAddition is a callback that is called when one or more tools are added or (re)enabled in mcpjungle.
+// this callback adds the new tools to MCP proxy servers of all groups that include them, either explicitly
+// or because the group includes the tool's parent MCP server (and doesn't exclude the tool).
+// The tools are added to each group at once, so that its clients are only notified once about the change.
+func (s *ToolGroupService) handleToolAddition(newTools ...string) error {
+	type addedTool struct {
+		instance     mcpgo.Tool
+		parentServer *model.McpServer
+	}
+	added := make([]addedTool, len(newTools))
+	for i, name := range newTools {
+		instance, exists := s.mcpService.GetToolInstance(name)
+		if !exists {
+			// this should not happen because the tool should exist if we are in this callback
+			return fmt.Errorf("tool instance %s does not exist", name)
+		}
+		parentServer, err := s.mcpService.GetToolParentServer(name)
+		if err != nil {
+			return fmt.Errorf("failed to get parent MCP server of the tool %s: %w", name, err)
+		}
+		added[i] = addedTool{instance: instance, parentServer: parentServer}
 	}
-}
 
-// handleToolAddition is a callback that is called when a tool is added or (re)enabled in mcpjungle.
-// this callback adds the new tool to MCP proxy servers of all groups that include it.
-func (s *ToolGroupService) handleToolAddition(newTool string) error {
 	// get all tool groups from the database
-	groups, err := s.ListToolGroups()
+	groups, err := s.listToolGroups(s.primary())
 	if err != nil {
 		return fmt.Errorf("failed to list tool groups from DB: %w", err)
 	}
 
-	// find all groups that include the added tool
-	groupsToUpdate := make([]string, 0, len(groups))
+	// find the added tools included by each group
+	changes := make(map[string]*toolChanges, len(groups))
 	for i := range groups {
-		name := groups[i].Name
-		groupTools, err := groups[i].ResolveEffectiveTools(s.mcpService)
-		if err != nil {
-			return fmt.Errorf("failed to resolve effective tools for group %s: %w", name, err)
-		}
-		for _, t := range groupTools {
-			if t != newTool {
+		handler := s.groupToolCallHandler(groups[i].Name)
+		for _, t := range added {
+			included, err := groups[i].IncludesTool(t.instance.Name, t.parentServer.Name)
+			if err != nil {
+				return fmt.Errorf("failed to evaluate rules of group %s: %w", groups[i].Name, err)
+			}
+			if !included || (groups[i].ReadOnly && isWriteTool(t.instance)) {
 				continue
 			}
-			// current group includes the added tool, so add the tool instance to the group's MCP server
-			groupsToUpdate = append(groupsToUpdate, name)
-			// no need to check other tools in this group anymore, so exit the loop and move on to the next group
-			break
-		}
-	}
-
-	newToolInstance, exists := s.mcpService.GetToolInstance(newTool)
-	if !exists {
-		// this should not happen because the tool should exist if we are in this callback
-		return fmt.Errorf("tool instance %s does not exist", newTool)
-	}
-
-	parentServer, err := s.mcpService.GetToolParentServer(newTool)
-	if err != nil {
-		return fmt.Errorf("failed to get parent MCP server of the tool %s: %w", newTool, err)
-	}
-
-	// add the new tool instance to all relevant MCP proxy servers
-	s.mcpServersMu.RLock()
-	defer s.mcpServersMu.RUnlock()
-
-	s.sseMcpServerMu.Lock()
-	defer s.sseMcpServerMu.Unlock()
-
-	for _, name := range groupsToUpdate {
-		if parentServer.Transport == types.TransportSSE {
-			sseMcpServer, exists := s.sseMcpServers[name]
-			if exists {
-				sseMcpServer.AddTool(newToolInstance, s.mcpService.MCPProxyToolCallHandler)
+			c, ok := changes[groups[i].Name]
+			if !ok {
+				c = &toolChanges{}
+				changes[groups[i].Name] = c
 			}
-			continue
+			c.addTool(t.instance, t.parentServer.Transport == types.TransportSSE, handler)
 		}
+	}
 
-		mcpServer, exists := s.mcpServers[name]
+	// add the new tool instances to all relevant MCP proxy servers
+	for name, c := range changes {
+		proxy, exists := s.getGroupProxy(name)
 		if exists {
-			mcpServer.AddTool(newToolInstance, s.mcpService.MCPProxyToolCallHandler)
+			proxy.apply(c)
 		}
 	}
+	s.toolsVersion.Add(1)
 
 	return nil
 }
@@ -1,9 +1,24 @@
 package toolgroup
 
 import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
 	"testing"
+	"time"
 
+	"github.com/glebarez/sqlite"
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
 )
 
 func TestValidGroupNameRegex(t *testing.T) {
@@ -129,3 +144,231 @@ func TestValidGroupNameConsistency(t *testing.T) {
 		}
 	}
 }
+
+func TestIsWriteTool(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations mcpgo.ToolAnnotation
+		expected    bool
+	}{
+		{"no annotations", mcpgo.ToolAnnotation{}, false},
+		{"read-only tool", mcpgo.ToolAnnotation{ReadOnlyHint: mcpgo.ToBoolPtr(true)}, false},
+		{"not read-only tool", mcpgo.ToolAnnotation{ReadOnlyHint: mcpgo.ToBoolPtr(false)}, true},
+		{"destructive tool", mcpgo.ToolAnnotation{DestructiveHint: mcpgo.ToBoolPtr(true)}, true},
+		{
+			"read-only but destructive tool",
+			mcpgo.ToolAnnotation{ReadOnlyHint: mcpgo.ToBoolPtr(true), DestructiveHint: mcpgo.ToBoolPtr(true)},
+			true,
+		},
+		{"mcp-go defaults", mcpgo.NewTool("test").Annotations, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := mcpgo.Tool{Name: "server__tool", Annotations: tt.annotations}
+			testhelpers.AssertEqual(t, tt.expected, isWriteTool(tool))
+		})
+	}
+}
+
+// newTestToolGroupService creates a tool group service backed by a calculator MCP server providing 2 tools.
+func newTestToolGroupService(t *testing.T) *ToolGroupService {
+	setup := testhelpers.SetupMCPTest(t)
+	t.Cleanup(setup.Cleanup)
+
+	srv := setup.CreateTestMcpServer("calculator", "", types.TransportStreamableHTTP, []byte(`{"url": "http://localhost:1"}`))
+	setup.CreateTestTool("add", "", srv.ID, true, []byte(`{"type":"object"}`))
+	setup.CreateTestTool("subtract", "", srv.ID, true, []byte(`{"type":"object"}`))
+
+	proxy := server.NewMCPServer("proxy", "test")
+	mcpService, err := mcp.NewMCPService(setup.DB, proxy, proxy, telemetry.NewNoopCustomMetrics(), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+	s, err := NewToolGroupService(setup.DB, mcpService, telemetry.NewNoopCustomMetrics())
+	testhelpers.AssertNoError(t, err)
+	return s
+}
+
+func TestValidateToolGroup(t *testing.T) {
+	s := newTestToolGroupService(t)
+
+	tools, err := s.ValidateToolGroup(&model.ToolGroup{Name: "math", IncludedServers: []byte(`["calculator"]`)})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 2, len(tools))
+	testhelpers.AssertEqual(t, "calculator__add", tools[0])
+	testhelpers.AssertEqual(t, "calculator__subtract", tools[1])
+
+	invalid := map[string]*model.ToolGroup{
+		"invalid group name": {Name: "-math", IncludedTools: []byte(`["calculator__add"]`)},
+		"invalid cache ttl":  {Name: "math", IncludedTools: []byte(`["calculator__add"]`), CachedTools: []byte(`{"calculator__add": "soon"}`)},
+		"at least one tool":  {Name: "math", IncludedServers: []byte(`["calculator"]`), ExcludedTools: []byte(`["calculator__add", "calculator__subtract"]`)},
+		"does not exist":     {Name: "math", IncludedTools: []byte(`["calculator__divide"]`)},
+	}
+	for expected, group := range invalid {
+		_, err := s.ValidateToolGroup(group)
+		testhelpers.AssertError(t, err)
+		testhelpers.AssertStringContains(t, err.Error(), expected)
+	}
+
+	// validation doesn't create the group
+	_, err = s.GetToolGroup("math")
+	testhelpers.AssertTrue(t, errors.Is(err, ErrToolGroupNotFound), "expected the group not to exist")
+}
+
+func TestPatchToolGroup(t *testing.T) {
+	s := newTestToolGroupService(t)
+	testhelpers.AssertNoError(t, s.CreateToolGroup(&model.ToolGroup{
+		Name:          "math",
+		Description:   "old",
+		IncludedTools: []byte(`["calculator__add"]`),
+	}))
+
+	t.Run("description only", func(t *testing.T) {
+		description := "new"
+		oldGroup, newGroup, err := s.PatchToolGroup("math", &types.PatchToolGroupInput{Description: &description})
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, "old", oldGroup.Description)
+		testhelpers.AssertEqual(t, "new", newGroup.Description)
+
+		// the tools are left unchanged
+		group, err := s.GetToolGroup("math")
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, "new", group.Description)
+		tools, err := group.GetTools()
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, 1, len(tools))
+	})
+
+	t.Run("included tools", func(t *testing.T) {
+		included := []string{"calculator__add", "calculator__subtract"}
+		_, newGroup, err := s.PatchToolGroup("math", &types.PatchToolGroupInput{IncludedTools: &included})
+		testhelpers.AssertNoError(t, err)
+		tools, err := newGroup.GetTools()
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, 2, len(tools))
+		testhelpers.AssertEqual(t, "new", newGroup.Description)
+
+		mcpServer, ok := s.GetToolGroupMCPServer("math")
+		testhelpers.AssertTrue(t, ok, "expected the group's MCP server to exist")
+		testhelpers.AssertEqual(t, 2, len(mcpServer.ListTools()))
+	})
+
+	t.Run("unknown group", func(t *testing.T) {
+		_, _, err := s.PatchToolGroup("missing", &types.PatchToolGroupInput{})
+		testhelpers.AssertTrue(t, errors.Is(err, ErrToolGroupNotFound), "expected ErrToolGroupNotFound")
+	})
+}
+
+func TestPatchToolGroupReadsPrimary(t *testing.T) {
+	s := newTestToolGroupService(t)
+	testhelpers.AssertNoError(t, s.CreateToolGroup(&model.ToolGroup{
+		Name: "math", IncludedTools: []byte(`["calculator__add"]`),
+	}))
+
+	// the group has not reached the read replica yet
+	replicaPath := filepath.Join(t.TempDir(), "replica.db")
+	replica, err := gorm.Open(sqlite.Open(replicaPath), &gorm.Config{})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, replica.AutoMigrate(&model.ToolGroup{}))
+	resolver := dbresolver.Register(dbresolver.Config{Replicas: []gorm.Dialector{sqlite.Open(replicaPath)}}, &model.ToolGroup{})
+	testhelpers.AssertNoError(t, s.db.Use(resolver))
+
+	_, err = s.GetToolGroup("math")
+	testhelpers.AssertTrue(t, errors.Is(err, ErrToolGroupNotFound), "expected the group to be read from the replica")
+
+	included := []string{"calculator__add", "calculator__subtract"}
+	_, _, err = s.PatchToolGroup("math", &types.PatchToolGroupInput{IncludedTools: &included})
+	testhelpers.AssertNoError(t, err)
+	mcpServer, _ := s.GetToolGroupMCPServer("math")
+	testhelpers.AssertEqual(t, 2, len(mcpServer.ListTools()))
+}
+
+func TestListGroupsUsingServer(t *testing.T) {
+	s := newTestToolGroupService(t)
+	testhelpers.AssertNoError(t, s.CreateToolGroup(&model.ToolGroup{
+		Name: "math", IncludedServers: []byte(`["calculator"]`), ExcludedTools: []byte(`["calculator__subtract"]`),
+	}))
+	testhelpers.AssertNoError(t, s.CreateToolGroup(&model.ToolGroup{
+		Name: "everything", IncludedTools: []byte(`["calculator__add", "calculator__subtract"]`),
+	}))
+
+	consumers, err := s.ListGroupsUsingServer("calculator")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 2, len(consumers))
+	testhelpers.AssertEqual(t, "math", consumers[0].Name)
+	testhelpers.AssertTrue(t, slices.Equal([]string{"calculator__add"}, consumers[0].Tools), "unexpected tools of math")
+	testhelpers.AssertEqual(t, "everything", consumers[1].Name)
+	testhelpers.AssertEqual(t, 2, len(consumers[1].Tools))
+
+	consumers, err = s.ListGroupsUsingServer("time")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 0, len(consumers))
+}
+
+func TestToolGroupUpdatesDontContend(t *testing.T) {
+	s := newTestToolGroupService(t)
+	for _, name := range []string{"math", "sums"} {
+		testhelpers.AssertNoError(t, s.CreateToolGroup(&model.ToolGroup{Name: name, IncludedTools: []byte(`["calculator__add"]`)}))
+	}
+
+	// a change to the tools of "math" is in progress
+	math, ok := s.getGroupProxy("math")
+	testhelpers.AssertTrue(t, ok, "expected the group's proxy to exist")
+	math.mu.Lock()
+	defer math.mu.Unlock()
+
+	done := make(chan error, 1)
+	go func() {
+		if _, ok := s.GetToolGroupMCPServer("math"); !ok {
+			done <- errors.New("expected the group's MCP server to exist")
+			return
+		}
+		_, err := s.UpdateToolGroup("sums", &model.ToolGroup{IncludedTools: []byte(`["calculator__add", "calculator__subtract"]`)})
+		if err == nil {
+			err = s.CreateToolGroup(&model.ToolGroup{Name: "diffs", IncludedTools: []byte(`["calculator__subtract"]`)})
+		}
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		testhelpers.AssertNoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("other groups were blocked by the change to the tools of a group")
+	}
+	sums, _ := s.GetToolGroupMCPServer("sums")
+	testhelpers.AssertEqual(t, 2, len(sums.ListTools()))
+}
+
+// notifiedSession is an MCP client session that counts the notifications it receives.
+type notifiedSession struct {
+	notifications chan mcpgo.JSONRPCNotification
+}
+
+func (s *notifiedSession) Initialize()       {}
+func (s *notifiedSession) Initialized() bool { return true }
+func (s *notifiedSession) SessionID() string { return "test-session" }
+func (s *notifiedSession) NotificationChannel() chan<- mcpgo.JSONRPCNotification {
+	return s.notifications
+}
+
+func TestToolChangesNotifyGroupClientsOnce(t *testing.T) {
+	s := newTestToolGroupService(t)
+	testhelpers.AssertNoError(t, s.CreateToolGroup(&model.ToolGroup{Name: "math", IncludedServers: []byte(`["calculator"]`)}))
+
+	mcpServer, ok := s.GetToolGroupMCPServer("math")
+	testhelpers.AssertTrue(t, ok, "expected the group's MCP server to exist")
+	session := &notifiedSession{notifications: make(chan mcpgo.JSONRPCNotification, 10)}
+	testhelpers.AssertNoError(t, mcpServer.RegisterSession(context.Background(), session))
+
+	// all the tools of the server are disabled, then enabled again
+	_, err := s.mcpService.DisableTools("calculator")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 0, len(mcpServer.ListTools()))
+	testhelpers.AssertEqual(t, 1, len(session.notifications))
+	<-session.notifications
+
+	_, err = s.mcpService.EnableTools("calculator")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 2, len(mcpServer.ListTools()))
+	testhelpers.AssertEqual(t, 1, len(session.notifications))
+}
@@ -2,15 +2,40 @@
 package user
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/mcpjungle/mcpjungle/internal"
+	"github.com/mcpjungle/mcpjungle/internal/db"
+	"github.com/mcpjungle/mcpjungle/internal/encryption"
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"gorm.io/gorm"
 )
 
+// ErrUserNotFound is returned when a user does not exist.
+var ErrUserNotFound = errors.New("user not found")
+
+// ErrAdminToolPolicy is returned when a tool access policy is set for an admin, who may always access all tools.
+var ErrAdminToolPolicy = errors.New("the tools of an admin user cannot be restricted")
+
+// ErrAdminSuspension is returned when suspending an admin, which could lock everyone out of mcpjungle.
+var ErrAdminSuspension = errors.New("cannot suspend an admin user")
+
+// ErrAdminDeletion is returned when deleting an admin user.
+var ErrAdminDeletion = errors.New("cannot delete an admin user")
+
+// ErrUserExists is returned when creating a user whose username is already taken.
+var ErrUserExists = errors.New("user already exists")
+
+// ErrInvalidRole is returned when a user is given a role that doesn't exist.
+var ErrInvalidRole = errors.New("invalid user role")
+
+// ErrLastAdmin is returned when demoting the last active admin, which would lock everyone out of mcpjungle.
+var ErrLastAdmin = errors.New("cannot demote the last active admin user")
+
 // UserService provides methods to manage users in the MCPJungle system.
 type UserService struct {
 	db *gorm.DB
@@ -41,7 +66,7 @@ func (u *UserService) CreateAdminUser() (*model.User, error) {
 // If no user is found, an error is returned.
 func (u *UserService) GetUserByAccessToken(token string) (*model.User, error) {
 	var user model.User
-	if err := u.db.Where("access_token = ?", token).First(&user).Error; err != nil {
+	if err := u.db.Where("access_token IN ?", encryption.LookupValues(token)).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("user not found")
 		}
@@ -50,6 +75,27 @@ func (u *UserService) GetUserByAccessToken(token string) (*model.User, error) {
 	return &user, nil
 }
 
+// GetUser returns the user with the specified username.
+func (u *UserService) GetUser(username string) (*model.User, error) {
+	return u.findUser("username = ?", username)
+}
+
+// GetUserByID returns the user with the specified ID.
+func (u *UserService) GetUserByID(id uint) (*model.User, error) {
+	return u.findUser("id = ?", id)
+}
+
+func (u *UserService) findUser(query string, arg any) (*model.User, error) {
+	var user model.User
+	if err := u.db.Where(query, arg).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	return &user, nil
+}
+
 // CreateUser creates a new user with the specified username.
 // This method currently only supports creating a standard user, ie, user with the "user" role.
 func (u *UserService) CreateUser(username string) (*model.User, error) {
@@ -68,16 +114,153 @@ func (u *UserService) CreateUser(username string) (*model.User, error) {
 	return &user, nil
 }
 
+// ProvisionUser creates a user with the specified username, role & state on behalf of an identity provider.
+// Unlike CreateUser, it may create admins and suspended users, and returns ErrUserExists if the username is taken.
+func (u *UserService) ProvisionUser(username string, role types.UserRole, active bool) (*model.User, error) {
+	if !isValidRole(role) {
+		return nil, ErrInvalidRole
+	}
+	token, err := internal.GenerateAccessToken()
+	if err != nil {
+		return nil, err
+	}
+	user := model.User{
+		Username:    username,
+		Role:        role,
+		AccessToken: token,
+		Active:      true,
+	}
+	err = u.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&model.User{}).Where("username = ?", username).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to find user: %w", err)
+		}
+		if count > 0 {
+			return ErrUserExists
+		}
+		if err := tx.Create(&user).Error; err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+		// false is the zero value, so the column's default would apply if it was set on creation
+		if !active {
+			if err := tx.Model(&user).Update("active", false).Error; err != nil {
+				return fmt.Errorf("failed to suspend user: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// SetUserRole changes the role of the user with the specified username.
+// The last active admin cannot be demoted, so that mcpjungle can always be administered.
+func (u *UserService) SetUserRole(username string, role types.UserRole) (*model.User, error) {
+	return u.UpdateUserStatus(username, &role, nil)
+}
+
+// UpdateUserStatus changes the role and the active state of the user with the specified username at once.
+// A nil role or active leaves it unchanged.
+// The changes are validated together and made in a single transaction, so either both of them are made or neither.
+// For example, an admin can be demoted & suspended at once, but not promoted & suspended.
+func (u *UserService) UpdateUserStatus(username string, role *types.UserRole, active *bool) (*model.User, error) {
+	if role != nil && !isValidRole(*role) {
+		return nil, ErrInvalidRole
+	}
+
+	var user model.User
+	err := u.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("username = ?", username).First(&user).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrUserNotFound
+			}
+			return fmt.Errorf("failed to find user: %w", err)
+		}
+
+		changes := make(map[string]any)
+		if role != nil && *role != user.Role {
+			if user.Role == types.UserRoleAdmin {
+				var admins int64
+				err := tx.Model(&model.User{}).
+					Where("role = ? AND active = ? AND id <> ?", types.UserRoleAdmin, true, user.ID).
+					Count(&admins).Error
+				if err != nil {
+					return fmt.Errorf("failed to count admin users: %w", err)
+				}
+				if admins == 0 {
+					return ErrLastAdmin
+				}
+			}
+			changes["role"] = *role
+			user.Role = *role
+		}
+		if active != nil && *active != user.Active {
+			changes["active"] = *active
+			user.Active = *active
+		}
+		if !user.Active && user.Role == types.UserRoleAdmin {
+			return ErrAdminSuspension
+		}
+
+		if len(changes) == 0 {
+			return nil
+		}
+		if err := tx.Model(&model.User{}).Where("id = ?", user.ID).Updates(changes).Error; err != nil {
+			return fmt.Errorf("failed to update user: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// RegenerateAccessToken replaces the access token of the user with the specified username by a new one.
+// The previous token is invalidated immediately.
+func (u *UserService) RegenerateAccessToken(username string) (*model.User, error) {
+	var user model.User
+	if err := u.db.Where("username = ?", username).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	token, err := internal.GenerateAccessToken()
+	if err != nil {
+		return nil, err
+	}
+	user.AccessToken = token
+	// the token is encrypted by the column's serializer, which only applies when updating from the model
+	if err := u.db.Model(&user).Select("AccessToken").Updates(&user).Error; err != nil {
+		return nil, fmt.Errorf("failed to update access token: %w", err)
+	}
+	return &user, nil
+}
+
 // ListUsers retrieves all users from the database.
 func (u *UserService) ListUsers() ([]model.User, error) {
 	var users []model.User
-	if err := u.db.Find(&users).Error; err != nil {
+	if err := u.db.Order("id").Find(&users).Error; err != nil {
 		return nil, fmt.Errorf("failed to list users: %w", err)
 	}
 	return users, nil
 }
 
-// DeleteUser removes a user with the specified username from the database.
+// ListUsersPage retrieves the given page of users from the database, along with the total number of users.
+func (u *UserService) ListUsersPage(page types.Page) ([]model.User, int64, error) {
+	var users []model.User
+	total, err := db.FindPage(u.db.Order("id"), page, &users)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+	}
+	return users, total, nil
+}
+
+// DeleteUser removes a user with the specified username from the database, along with their team memberships.
 // If a user's role is admin, the deletion will be rejected.
 func (u *UserService) DeleteUser(username string) error {
 	var user model.User
@@ -90,12 +273,96 @@ func (u *UserService) DeleteUser(username string) error {
 	}
 
 	if user.Role == types.UserRoleAdmin {
-		return fmt.Errorf("cannot delete an admin user")
+		return ErrAdminDeletion
 	}
 
-	err = u.db.Unscoped().Where("username = ?", username).Delete(&model.User{}).Error
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("username = ?", username).Delete(&model.User{}).Error; err != nil {
+			return fmt.Errorf("failed to delete user: %w", err)
+		}
+
+		// a user created later with the same username must not inherit the permissions of the deleted one
+		var teams []model.Team
+		if err := tx.Find(&teams).Error; err != nil {
+			return fmt.Errorf("failed to list the teams of the user: %w", err)
+		}
+		for _, t := range teams {
+			removed, err := t.RemoveMember(username)
+			if err != nil {
+				return fmt.Errorf("failed to remove the user from team %s: %w", t.Name, err)
+			}
+			if !removed {
+				continue
+			}
+			if err := tx.Model(&t).Update("members", t.Members).Error; err != nil {
+				return fmt.Errorf("failed to remove the user from team %s: %w", t.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// SuspendUser suspends the user with the specified username, ie, their access token is rejected until they are
+// activated again. Unlike deleting the user, this preserves their account, team memberships and tool policy.
+// Admin users cannot be suspended.
+func (u *UserService) SuspendUser(username string) (*model.User, error) {
+	return u.setUserActive(username, false)
+}
+
+// ActivateUser lifts the suspension of the user with the specified username.
+func (u *UserService) ActivateUser(username string) (*model.User, error) {
+	return u.setUserActive(username, true)
+}
+
+func (u *UserService) setUserActive(username string, active bool) (*model.User, error) {
+	return u.UpdateUserStatus(username, nil, &active)
+}
+
+// SetToolPolicy restricts the tools the given user may see & invoke via the API to those allowed by the policy.
+// A nil policy lifts the restriction, ie, the user may access all tools again.
+// Admins may always access all tools, so their tools cannot be restricted.
+func (u *UserService) SetToolPolicy(username string, policy *types.ToolPolicy) (*model.User, error) {
+	var user model.User
+	if err := u.db.Where("username = ?", username).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	if policy == nil {
+		user.AllowedServers = nil
+		user.AllowedToolGroups = nil
+	} else {
+		if user.Role == types.UserRoleAdmin {
+			return nil, ErrAdminToolPolicy
+		}
+		var err error
+		if user.AllowedServers, err = marshalNames(policy.Servers); err != nil {
+			return nil, fmt.Errorf("failed to encode the allowed servers: %w", err)
+		}
+		if user.AllowedToolGroups, err = marshalNames(policy.ToolGroups); err != nil {
+			return nil, fmt.Errorf("failed to encode the allowed tool groups: %w", err)
+		}
+	}
+
+	err := u.db.Model(&user).Select("AllowedServers", "AllowedToolGroups").Updates(&user).Error
 	if err != nil {
-		return fmt.Errorf("failed to delete user: %w", err)
+		return nil, fmt.Errorf("failed to update the tool policy of the user: %w", err)
+	}
+	return &user, nil
+}
+
+func isValidRole(role types.UserRole) bool {
+	return role == types.UserRoleAdmin || role == types.UserRoleUser
+}
+
+// marshalNames encodes a list of names as a sorted JSON array without duplicates.
+func marshalNames(names []string) ([]byte, error) {
+	names = slices.Clone(names)
+	if names == nil {
+		names = []string{}
 	}
-	return nil
+	slices.Sort(names)
+	return json.Marshal(slices.Compact(names))
 }
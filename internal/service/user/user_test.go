@@ -1,8 +1,11 @@
 package user
 
 import (
+	"errors"
+	"slices"
 	"testing"
 
+	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
@@ -146,3 +149,154 @@ func TestDeleteAdminUser(t *testing.T) {
 	retrievedUser, _ := svc.GetUserByAccessToken(admin.AccessToken)
 	testhelpers.AssertEqual(t, "admin", retrievedUser.Username)
 }
+
+func TestSetToolPolicy(t *testing.T) {
+	setup, _ := testhelpers.SetupUserTest(t)
+	defer setup.Cleanup()
+	svc := NewUserService(setup.DB)
+	_, _ = svc.CreateUser("alice")
+
+	user, err := svc.SetToolPolicy("alice", &types.ToolPolicy{Servers: []string{"time", "calc", "time"}})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, user.HasToolPolicy(), "Expected the user to have a tool policy")
+
+	var stored model.User
+	testhelpers.AssertNoError(t, setup.DB.Where("username = ?", "alice").First(&stored).Error)
+	servers, _ := stored.GetAllowedServers()
+	testhelpers.AssertTrue(t, slices.Equal([]string{"calc", "time"}, servers), "Expected the servers to be sorted & deduplicated")
+	groups, _ := stored.GetAllowedToolGroups()
+	testhelpers.AssertEqual(t, 0, len(groups))
+
+	_, err = svc.SetToolPolicy("alice", nil)
+	testhelpers.AssertNoError(t, err)
+	var cleared model.User
+	testhelpers.AssertNoError(t, setup.DB.Where("username = ?", "alice").First(&cleared).Error)
+	testhelpers.AssertFalse(t, cleared.HasToolPolicy(), "Expected the tool policy to be cleared")
+
+	_, err = svc.SetToolPolicy("bob", &types.ToolPolicy{})
+	testhelpers.AssertTrue(t, errors.Is(err, ErrUserNotFound), "Expected ErrUserNotFound")
+
+	_, _ = svc.CreateAdminUser()
+	_, err = svc.SetToolPolicy("admin", &types.ToolPolicy{})
+	testhelpers.AssertTrue(t, errors.Is(err, ErrAdminToolPolicy), "Expected ErrAdminToolPolicy")
+}
+
+func TestRegenerateAccessToken(t *testing.T) {
+	setup, _ := testhelpers.SetupUserTest(t)
+	defer setup.Cleanup()
+	svc := NewUserService(setup.DB)
+	created, _ := svc.CreateUser("alice")
+
+	user, err := svc.RegenerateAccessToken("alice")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, user.AccessToken != created.AccessToken, "Expected a new access token")
+
+	found, err := svc.GetUserByAccessToken(user.AccessToken)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, "alice", found.Username)
+	_, err = svc.GetUserByAccessToken(created.AccessToken)
+	testhelpers.AssertError(t, err)
+
+	_, err = svc.RegenerateAccessToken("bob")
+	testhelpers.AssertTrue(t, errors.Is(err, ErrUserNotFound), "Expected ErrUserNotFound")
+}
+
+func TestSuspendUser(t *testing.T) {
+	setup, _ := testhelpers.SetupUserTest(t)
+	defer setup.Cleanup()
+	svc := NewUserService(setup.DB)
+	created, _ := svc.CreateUser("alice")
+	testhelpers.AssertTrue(t, created.Active, "Expected a new user to be active")
+
+	user, err := svc.SuspendUser("alice")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertFalse(t, user.Active, "Expected the user to be suspended")
+	found, err := svc.GetUserByAccessToken(created.AccessToken)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertFalse(t, found.Active, "Expected the suspension to be stored")
+
+	user, err = svc.ActivateUser("alice")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, user.Active, "Expected the user to be active again")
+
+	_, err = svc.SuspendUser("bob")
+	testhelpers.AssertTrue(t, errors.Is(err, ErrUserNotFound), "Expected ErrUserNotFound")
+
+	_, _ = svc.CreateAdminUser()
+	_, err = svc.SuspendUser("admin")
+	testhelpers.AssertTrue(t, errors.Is(err, ErrAdminSuspension), "Expected ErrAdminSuspension")
+}
+
+func TestProvisionUser(t *testing.T) {
+	setup, _ := testhelpers.SetupUserTest(t)
+	defer setup.Cleanup()
+	svc := NewUserService(setup.DB)
+
+	user, err := svc.ProvisionUser("alice", types.UserRoleAdmin, true)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, types.UserRoleAdmin, user.Role)
+	testhelpers.AssertTrue(t, user.Active, "Expected the user to be active")
+	testhelpers.AssertTrue(t, user.AccessToken != "", "Expected an access token to be generated")
+
+	user, err = svc.ProvisionUser("bob", types.UserRoleUser, false)
+	testhelpers.AssertNoError(t, err)
+	found, err := svc.GetUserByID(user.ID)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertFalse(t, found.Active, "Expected the user to be created suspended")
+
+	_, err = svc.ProvisionUser("alice", types.UserRoleUser, true)
+	testhelpers.AssertTrue(t, errors.Is(err, ErrUserExists), "Expected ErrUserExists")
+	_, err = svc.ProvisionUser("carol", "owner", true)
+	testhelpers.AssertTrue(t, errors.Is(err, ErrInvalidRole), "Expected ErrInvalidRole")
+
+	_, err = svc.GetUser("carol")
+	testhelpers.AssertTrue(t, errors.Is(err, ErrUserNotFound), "Expected ErrUserNotFound")
+}
+
+func TestSetUserRole(t *testing.T) {
+	setup, _ := testhelpers.SetupUserTest(t)
+	defer setup.Cleanup()
+	svc := NewUserService(setup.DB)
+	_, _ = svc.CreateAdminUser()
+	_, _ = svc.CreateUser("alice")
+
+	user, err := svc.SetUserRole("alice", types.UserRoleAdmin)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, types.UserRoleAdmin, user.Role)
+
+	// another admin remains, so the default admin may be demoted, but alice can't be demoted afterwards
+	user, err = svc.SetUserRole("admin", types.UserRoleUser)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, types.UserRoleUser, user.Role)
+	_, err = svc.SetUserRole("alice", types.UserRoleUser)
+	testhelpers.AssertTrue(t, errors.Is(err, ErrLastAdmin), "Expected ErrLastAdmin")
+
+	found, err := svc.GetUser("admin")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, types.UserRoleUser, found.Role)
+
+	_, err = svc.SetUserRole("alice", "owner")
+	testhelpers.AssertTrue(t, errors.Is(err, ErrInvalidRole), "Expected ErrInvalidRole")
+	_, err = svc.SetUserRole("bob", types.UserRoleUser)
+	testhelpers.AssertTrue(t, errors.Is(err, ErrUserNotFound), "Expected ErrUserNotFound")
+}
+
+func TestUpdateUserStatus(t *testing.T) {
+	setup, _ := testhelpers.SetupUserTest(t)
+	defer setup.Cleanup()
+	svc := NewUserService(setup.DB)
+	_, _ = svc.CreateUser("alice")
+
+	// promoting & suspending a user at once is rejected without changing either
+	admin, suspended := types.UserRoleAdmin, false
+	_, err := svc.UpdateUserStatus("alice", &admin, &suspended)
+	testhelpers.AssertTrue(t, errors.Is(err, ErrAdminSuspension), "Expected ErrAdminSuspension")
+	found, err := svc.GetUser("alice")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, types.UserRoleUser, found.Role)
+	testhelpers.AssertTrue(t, found.Active, "Expected the user to remain active")
+
+	user, err := svc.UpdateUserStatus("alice", nil, &suspended)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertFalse(t, user.Active, "Expected the user to be suspended")
+}
@@ -0,0 +1,150 @@
+package telemetry
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// invocationStatsBucketSize is the time span covered by each bucket of counts
+const invocationStatsBucketSize = time.Minute
+
+// InvocationCounts holds the number of tool & prompt calls made in a time window.
+type InvocationCounts struct {
+	ToolCalls        int64
+	ToolCallErrors   int64
+	PromptCalls      int64
+	PromptCallErrors int64
+}
+
+// invocationStatsBucket holds the counts of calls made within one bucket-sized time span.
+type invocationStatsBucket struct {
+	start time.Time
+	InvocationCounts
+}
+
+// InvocationStats is a CustomMetrics implementation that keeps in-memory counts of the tool & prompt calls
+// made within a recent time window, and forwards every call to another CustomMetrics implementation.
+// Unlike the otel metrics, these counts are always available, but they reset when the server restarts.
+type InvocationStats struct {
+	next   CustomMetrics
+	window time.Duration
+
+	mu sync.Mutex
+	// buckets is a ring buffer of counts, one bucket per invocationStatsBucketSize
+	buckets []invocationStatsBucket
+
+	// now returns the current time, it can be replaced in tests
+	now func() time.Time
+}
+
+// NewInvocationStats returns an InvocationStats that keeps counts of the calls made in the given window
+// and forwards all calls to next.
+// The window is rounded up to a whole number of minutes.
+func NewInvocationStats(next CustomMetrics, window time.Duration) *InvocationStats {
+	n := int((window + invocationStatsBucketSize - 1) / invocationStatsBucketSize)
+	if n < 1 {
+		n = 1
+	}
+	return &InvocationStats{
+		next:    next,
+		window:  time.Duration(n) * invocationStatsBucketSize,
+		buckets: make([]invocationStatsBucket, n),
+		now:     time.Now,
+	}
+}
+
+// Window returns the time window over which the calls are counted.
+func (s *InvocationStats) Window() time.Duration {
+	return s.window
+}
+
+func (s *InvocationStats) RecordToolCall(
+	ctx context.Context, serverName, toolName string, outcome ToolCallOutcome, elapsedTime time.Duration,
+) {
+	s.record(func(b *invocationStatsBucket) {
+		b.ToolCalls++
+		if outcome == ToolCallOutcomeError {
+			b.ToolCallErrors++
+		}
+	})
+	s.next.RecordToolCall(ctx, serverName, toolName, outcome, elapsedTime)
+}
+
+func (s *InvocationStats) RecordSlowToolCall(
+	ctx context.Context, serverName, toolName string, elapsedTime time.Duration,
+) {
+	s.next.RecordSlowToolCall(ctx, serverName, toolName, elapsedTime)
+}
+
+func (s *InvocationStats) RecordPromptCall(
+	ctx context.Context, serverName, promptName string, outcome PromptCallOutcome, elapsedTime time.Duration,
+) {
+	s.record(func(b *invocationStatsBucket) {
+		b.PromptCalls++
+		if outcome == PromptCallOutcomeError {
+			b.PromptCallErrors++
+		}
+	})
+	s.next.RecordPromptCall(ctx, serverName, promptName, outcome, elapsedTime)
+}
+
+func (s *InvocationStats) RecordUpstreamServerHealth(
+	ctx context.Context, serverName string, reachable bool, consecutiveFailures int64,
+) {
+	s.next.RecordUpstreamServerHealth(ctx, serverName, reachable, consecutiveFailures)
+}
+
+func (s *InvocationStats) RecordToolGroupRequest(ctx context.Context, groupName string, transport ToolGroupTransport) {
+	s.next.RecordToolGroupRequest(ctx, groupName, transport)
+}
+
+func (s *InvocationStats) AddToolGroupSessions(
+	ctx context.Context, groupName string, transport ToolGroupTransport, delta int64,
+) {
+	s.next.AddToolGroupSessions(ctx, groupName, transport, delta)
+}
+
+func (s *InvocationStats) RecordToolGroupToolCall(
+	ctx context.Context, groupName, toolName string, outcome ToolCallOutcome, elapsedTime time.Duration,
+) {
+	s.next.RecordToolGroupToolCall(ctx, groupName, toolName, outcome, elapsedTime)
+}
+
+// Counts returns the number of calls made within the window.
+func (s *InvocationStats) Counts() InvocationCounts {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	oldest := s.currentBucketStart().Add(-s.window + invocationStatsBucketSize)
+
+	var c InvocationCounts
+	for _, b := range s.buckets {
+		if b.start.IsZero() || b.start.Before(oldest) {
+			continue
+		}
+		c.ToolCalls += b.ToolCalls
+		c.ToolCallErrors += b.ToolCallErrors
+		c.PromptCalls += b.PromptCalls
+		c.PromptCallErrors += b.PromptCallErrors
+	}
+	return c
+}
+
+// record applies the given update to the bucket of the current time.
+func (s *InvocationStats) record(update func(b *invocationStatsBucket)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	start := s.currentBucketStart()
+	i := int(start.Unix()/int64(invocationStatsBucketSize/time.Second)) % len(s.buckets)
+	if !s.buckets[i].start.Equal(start) {
+		// the bucket holds counts from a previous round of the ring buffer, which are outside the window now
+		s.buckets[i] = invocationStatsBucket{start: start}
+	}
+	update(&s.buckets[i])
+}
+
+func (s *InvocationStats) currentBucketStart() time.Time {
+	return s.now().Truncate(invocationStatsBucketSize)
+}
@@ -0,0 +1,50 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestInvocationStats(t *testing.T) {
+	s := NewInvocationStats(NewNoopCustomMetrics(), 90*time.Second)
+	if s.Window() != 2*time.Minute {
+		t.Fatalf("expected window to be rounded up to 2m, got %s", s.Window())
+	}
+
+	now := time.Date(2025, time.October, 1, 12, 0, 30, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	s.RecordToolCall(ctx, "calculator", "add", ToolCallOutcomeSuccess, time.Millisecond)
+	s.RecordToolCall(ctx, "calculator", "add", ToolCallOutcomeError, time.Millisecond)
+	s.RecordPromptCall(ctx, "calculator", "explain", PromptCallOutcomeSuccess, time.Millisecond)
+
+	now = now.Add(time.Minute)
+	s.RecordToolCall(ctx, "calculator", "subtract", ToolCallOutcomeSuccess, time.Millisecond)
+
+	expected := InvocationCounts{ToolCalls: 3, ToolCallErrors: 1, PromptCalls: 1}
+	if c := s.Counts(); c != expected {
+		t.Errorf("expected counts %+v, got %+v", expected, c)
+	}
+
+	// calls made in the first minute fall out of the window
+	now = now.Add(time.Minute)
+	expected = InvocationCounts{ToolCalls: 1}
+	if c := s.Counts(); c != expected {
+		t.Errorf("expected counts %+v, got %+v", expected, c)
+	}
+
+	// a bucket is reset when it is reused for a new minute
+	s.RecordToolCall(ctx, "calculator", "add", ToolCallOutcomeError, time.Millisecond)
+	expected = InvocationCounts{ToolCalls: 2, ToolCallErrors: 1}
+	if c := s.Counts(); c != expected {
+		t.Errorf("expected counts %+v, got %+v", expected, c)
+	}
+
+	// all calls fall out of the window after a long time
+	now = now.Add(time.Hour)
+	if c := s.Counts(); c != (InvocationCounts{}) {
+		t.Errorf("expected no counts, got %+v", c)
+	}
+}
@@ -3,6 +3,8 @@ package telemetry
 import (
 	"context"
 	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
 )
 
 // ToolCallOutcome represents the outcome of a tool call, either success or error.
@@ -25,12 +27,50 @@ const (
 	PromptCallOutcomeError PromptCallOutcome = "error"
 )
 
+// ToolGroupTransport is the transport over which a tool group's MCP proxy server is accessed.
+type ToolGroupTransport string
+
+const (
+	// ToolGroupTransportStreamableHTTP is the streamable HTTP transport (the /mcp endpoint of a group)
+	ToolGroupTransportStreamableHTTP ToolGroupTransport = "streamable_http"
+	// ToolGroupTransportSSE is the SSE transport (the /sse & /message endpoints of a group)
+	ToolGroupTransportSSE ToolGroupTransport = "sse"
+)
+
 // CustomMetrics defines the interface for recording custom metrics from mcpjungle.
 // It provides convenience methods for recording metrics related to http server, mcp servers, tools, usage, etc.
 type CustomMetrics interface {
 	// RecordToolCall records a tool invocation, its latency, and its outcome (success or error).
 	RecordToolCall(ctx context.Context, serverName, toolName string, outcome ToolCallOutcome, elapsedTime time.Duration)
 
+	// RecordSlowToolCall records a tool call that took longer than the slow tool call threshold.
+	RecordSlowToolCall(ctx context.Context, serverName, toolName string, elapsedTime time.Duration)
+
 	// RecordPromptCall records a prompt invocation, its latency, and its outcome (success or error).
 	RecordPromptCall(ctx context.Context, serverName, promptName string, outcome PromptCallOutcome, elapsedTime time.Duration)
+
+	// RecordUpstreamServerHealth records the outcome of the latest attempt to reach an upstream MCP server
+	// and the number of consecutive failed attempts (0 if it is reachable).
+	RecordUpstreamServerHealth(ctx context.Context, serverName string, reachable bool, consecutiveFailures int64)
+
+	// RecordToolGroupRequest records a request made to the MCP proxy server of a tool group.
+	RecordToolGroupRequest(ctx context.Context, groupName string, transport ToolGroupTransport)
+
+	// AddToolGroupSessions adds delta to the number of active sessions of a tool group's MCP proxy server.
+	// A session is a long-lived connection over which the server streams messages to the client.
+	AddToolGroupSessions(ctx context.Context, groupName string, transport ToolGroupTransport, delta int64)
+
+	// RecordToolGroupToolCall records a tool call made via a tool group, its latency, and its outcome.
+	RecordToolGroupToolCall(
+		ctx context.Context, groupName, toolName string, outcome ToolCallOutcome, elapsedTime time.Duration,
+	)
+}
+
+// mcpClientName returns the name of the MCP client that made the call, so that usage can be attributed to it.
+// It returns an empty string if the call wasn't made by an authenticated MCP client (eg- in development mode).
+func mcpClientName(ctx context.Context) string {
+	if c, ok := ctx.Value("client").(*model.McpClient); ok && c != nil {
+		return c.Name
+	}
+	return ""
 }
@@ -20,8 +20,36 @@ func (m *NoopCustomMetrics) RecordToolCall(
 	// No-op
 }
 
+func (m *NoopCustomMetrics) RecordSlowToolCall(
+	ctx context.Context, serverName, toolName string, elapsedTime time.Duration,
+) {
+	// No-op
+}
+
 func (m *NoopCustomMetrics) RecordPromptCall(
 	ctx context.Context, serverName, promptName string, outcome PromptCallOutcome, elapsedTime time.Duration,
 ) {
 	// No-op
 }
+
+func (m *NoopCustomMetrics) RecordUpstreamServerHealth(
+	ctx context.Context, serverName string, reachable bool, consecutiveFailures int64,
+) {
+	// No-op
+}
+
+func (m *NoopCustomMetrics) RecordToolGroupRequest(ctx context.Context, groupName string, transport ToolGroupTransport) {
+	// No-op
+}
+
+func (m *NoopCustomMetrics) AddToolGroupSessions(
+	ctx context.Context, groupName string, transport ToolGroupTransport, delta int64,
+) {
+	// No-op
+}
+
+func (m *NoopCustomMetrics) RecordToolGroupToolCall(
+	ctx context.Context, groupName, toolName string, outcome ToolCallOutcome, elapsedTime time.Duration,
+) {
+	// No-op
+}
@@ -6,24 +6,69 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
 )
 
+// TracesExporter selects where the spans recorded by mcpjungle are exported to
+type TracesExporter string
+
+const (
+	// TracesExporterNone doesn't export spans.
+	// Traces are still propagated from mcpjungle's clients to the upstream MCP servers.
+	TracesExporterNone TracesExporter = "none"
+	// TracesExporterConsole writes spans to stdout, which is useful for debugging
+	TracesExporterConsole TracesExporter = "console"
+	// TracesExporterOTLP exports spans to the configured OTLP endpoint
+	TracesExporterOTLP TracesExporter = "otlp"
+)
+
+// TracesSampler selects which traces are sampled, ie, whose spans are recorded & exported.
+// The names are the ones of the OTEL_TRACES_SAMPLER env var in the OpenTelemetry spec.
+type TracesSampler string
+
+const (
+	// TracesSamplerAlwaysOn samples every trace
+	TracesSamplerAlwaysOn TracesSampler = "always_on"
+	// TracesSamplerAlwaysOff samples no trace
+	TracesSamplerAlwaysOff TracesSampler = "always_off"
+	// TracesSamplerRatio samples the given ratio of traces, based on their trace ID
+	TracesSamplerRatio TracesSampler = "traceidratio"
+	// TracesSamplerParentBasedAlwaysOn follows the caller's sampling decision and samples every trace started by mcpjungle
+	TracesSamplerParentBasedAlwaysOn TracesSampler = "parentbased_always_on"
+	// TracesSamplerParentBasedAlwaysOff follows the caller's sampling decision and samples no trace started by mcpjungle
+	TracesSamplerParentBasedAlwaysOff TracesSampler = "parentbased_always_off"
+	// TracesSamplerParentBasedRatio follows the caller's sampling decision and samples the given ratio
+	// of the traces started by mcpjungle
+	TracesSamplerParentBasedRatio TracesSampler = "parentbased_traceidratio"
+)
+
 // Config holds otel configuration options
 type Config struct {
 	ServiceName string
 	Enabled     bool
+	// TracesExporter defaults to TracesExporterOTLP if an OTLP endpoint is configured, TracesExporterNone otherwise
+	TracesExporter TracesExporter
+	// TracesSampler defaults to TracesSamplerParentBasedAlwaysOn
+	TracesSampler TracesSampler
+	// TracesSamplerRatio is the ratio (between 0 and 1) of traces sampled by the ratio-based samplers
+	TracesSamplerRatio float64
+	// OTLP, if set, exports metrics (in addition to serving them at /metrics) and spans to an OTLP endpoint
+	OTLP *OTLPConfig
 }
 
-// Providers holds the Otel configuration and metrics provider.
-// Eventually, it will also hold providers for tracing and logging
+// Providers holds the Otel configuration and the metrics & tracing providers.
+// Eventually, it will also hold providers for logging
 type Providers struct {
-	Config        *Config
-	MeterProvider *sdkmetric.MeterProvider
-	Meter         metric.Meter
+	Config         *Config
+	MeterProvider  *sdkmetric.MeterProvider
+	Meter          metric.Meter
+	TracerProvider *sdktrace.TracerProvider
 }
 
 // Init initializes Otel with the provided configuration
@@ -56,10 +101,21 @@ func Init(ctx context.Context, config *Config) (*Providers, error) {
 	}
 
 	// Create meter provider with Prometheus exporter
-	meterProvider := sdkmetric.NewMeterProvider(
+	meterOpts := []sdkmetric.Option{
 		sdkmetric.WithReader(exporter),
 		sdkmetric.WithResource(res),
-	)
+	}
+	if config.OTLP != nil {
+		if err := config.OTLP.Validate(); err != nil {
+			return nil, err
+		}
+		reader, err := newOTLPMetricReader(ctx, config.OTLP)
+		if err != nil {
+			return nil, err
+		}
+		meterOpts = append(meterOpts, sdkmetric.WithReader(reader))
+	}
+	meterProvider := sdkmetric.NewMeterProvider(meterOpts...)
 
 	// Set the global meter provider
 	otel.SetMeterProvider(meterProvider)
@@ -67,14 +123,94 @@ func Init(ctx context.Context, config *Config) (*Providers, error) {
 	// Create meter for the service
 	meter := meterProvider.Meter(config.ServiceName)
 
+	tracerProvider, err := newTracerProvider(ctx, config, res)
+	if err != nil {
+		return nil, err
+	}
+	otel.SetTracerProvider(tracerProvider)
+
+	// Extract the W3C trace context from incoming requests & inject it into the requests made to upstream MCP servers,
+	// so that a single trace spans the MCP client, mcpjungle and the upstream MCP server.
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
 	providers := &Providers{
-		Config:        config,
-		MeterProvider: meterProvider,
-		Meter:         meter,
+		Config:         config,
+		MeterProvider:  meterProvider,
+		Meter:          meter,
+		TracerProvider: tracerProvider,
 	}
 	return providers, nil
 }
 
+// newTracerProvider creates a tracer provider that samples traces & exports spans as configured.
+func newTracerProvider(
+	ctx context.Context, config *Config, res *sdkresource.Resource,
+) (*sdktrace.TracerProvider, error) {
+	sampler, err := newSampler(config)
+	if err != nil {
+		return nil, err
+	}
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sampler),
+	}
+
+	tracesExporter := config.TracesExporter
+	if tracesExporter == "" && config.OTLP != nil {
+		tracesExporter = TracesExporterOTLP
+	}
+
+	switch tracesExporter {
+	case "", TracesExporterNone:
+	case TracesExporterConsole:
+		exporter, err := stdouttrace.New()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create console trace exporter: %w", err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	case TracesExporterOTLP:
+		if config.OTLP == nil {
+			return nil, fmt.Errorf("the OTLP traces exporter requires an OTLP endpoint")
+		}
+		exporter, err := newOTLPSpanExporter(ctx, config.OTLP)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	default:
+		return nil, fmt.Errorf("unsupported traces exporter: %s", config.TracesExporter)
+	}
+
+	return sdktrace.NewTracerProvider(opts...), nil
+}
+
+// newSampler creates the configured sampler.
+// By default, spans are always sampled if the caller's trace was sampled, so that traces are never broken up.
+func newSampler(config *Config) (sdktrace.Sampler, error) {
+	switch config.TracesSampler {
+	case TracesSamplerAlwaysOn:
+		return sdktrace.AlwaysSample(), nil
+	case TracesSamplerAlwaysOff:
+		return sdktrace.NeverSample(), nil
+	case "", TracesSamplerParentBasedAlwaysOn:
+		return sdktrace.ParentBased(sdktrace.AlwaysSample()), nil
+	case TracesSamplerParentBasedAlwaysOff:
+		return sdktrace.ParentBased(sdktrace.NeverSample()), nil
+	}
+
+	if config.TracesSamplerRatio < 0 || config.TracesSamplerRatio > 1 {
+		return nil, fmt.Errorf("invalid traces sampler ratio %v, expected a number between 0 and 1", config.TracesSamplerRatio)
+	}
+	switch config.TracesSampler {
+	case TracesSamplerRatio:
+		return sdktrace.TraceIDRatioBased(config.TracesSamplerRatio), nil
+	case TracesSamplerParentBasedRatio:
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.TracesSamplerRatio)), nil
+	default:
+		return nil, fmt.Errorf("unsupported traces sampler: %s", config.TracesSampler)
+	}
+}
+
 // Shutdown gracefully shuts down the otel providers
 func (p *Providers) Shutdown(ctx context.Context) error {
 	if p == nil {
@@ -85,6 +221,11 @@ func (p *Providers) Shutdown(ctx context.Context) error {
 			return fmt.Errorf("failed to shutdown meter provider: %w", err)
 		}
 	}
+	if p.TracerProvider != nil {
+		if err := p.TracerProvider.Shutdown(ctx); err != nil {
+			return fmt.Errorf("failed to shutdown tracer provider: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -11,8 +11,11 @@ import (
 
 const (
 	labelMCPServerName   = "mcp_server_name"
+	labelMCPClientName   = "mcp_client_name"
 	labelToolName        = "tool_name"
 	labelToolCallOutcome = "outcome"
+	labelToolGroupName   = "tool_group"
+	labelTransport       = "transport"
 )
 
 const (
@@ -26,6 +29,15 @@ const (
 type OtelCustomMetrics struct {
 	toolCalls       metric.Int64Counter
 	toolCallLatency metric.Float64Histogram
+	slowToolCalls   metric.Int64Counter
+
+	upstreamServerUp                  metric.Int64Gauge
+	upstreamServerConsecutiveFailures metric.Int64Gauge
+
+	toolGroupRequests        metric.Int64Counter
+	toolGroupSessions        metric.Int64UpDownCounter
+	toolGroupToolCalls       metric.Int64Counter
+	toolGroupToolCallLatency metric.Float64Histogram
 }
 
 // NewOtelCustomMetrics initializes all metric instruments required by MCPJungle.
@@ -55,9 +67,82 @@ func NewOtelCustomMetrics(meter metric.Meter) (CustomMetrics, error) {
 		return nil, fmt.Errorf("failed to create tool latency histogram: %w", err)
 	}
 
+	slowToolCalls, err := meter.Int64Counter(
+		"mcpjungle_slow_tool_calls_total",
+		metric.WithDescription("Total number of tool calls that took longer than the slow tool call threshold"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create slow tool calls counter: %w", err)
+	}
+
+	upstreamUp, err := meter.Int64Gauge(
+		"mcpjungle_upstream_server_up",
+		metric.WithDescription("Whether the latest attempt to reach an upstream MCP server succeeded (1) or failed (0)"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create upstream server up gauge: %w", err)
+	}
+
+	upstreamFailures, err := meter.Int64Gauge(
+		"mcpjungle_upstream_server_consecutive_failures",
+		metric.WithDescription("Number of consecutive failed attempts to reach an upstream MCP server"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create upstream server consecutive failures gauge: %w", err)
+	}
+
+	groupRequests, err := meter.Int64Counter(
+		"mcpjungle_tool_group_requests_total",
+		metric.WithDescription("Total number of requests made to the MCP proxy servers of tool groups"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tool group requests counter: %w", err)
+	}
+
+	groupSessions, err := meter.Int64UpDownCounter(
+		"mcpjungle_tool_group_active_sessions",
+		metric.WithDescription("Number of active sessions of the MCP proxy servers of tool groups"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tool group sessions gauge: %w", err)
+	}
+
+	groupToolCalls, err := meter.Int64Counter(
+		"mcpjungle_tool_group_tool_calls_total",
+		metric.WithDescription("Total number of tool calls made via tool groups"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tool group tool calls counter: %w", err)
+	}
+
+	groupToolLat, err := meter.Float64Histogram(
+		"mcpjungle_tool_group_tool_call_latency_seconds",
+		metric.WithDescription("Latency of tool calls made via tool groups in seconds"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2, 5, 10, 20, 30),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tool group tool call latency histogram: %w", err)
+	}
+
 	return &OtelCustomMetrics{
 		toolCalls:       toolInv,
 		toolCallLatency: toolLat,
+		slowToolCalls:   slowToolCalls,
+
+		upstreamServerUp:                  upstreamUp,
+		upstreamServerConsecutiveFailures: upstreamFailures,
+
+		toolGroupRequests:        groupRequests,
+		toolGroupSessions:        groupSessions,
+		toolGroupToolCalls:       groupToolCalls,
+		toolGroupToolCallLatency: groupToolLat,
 	}, nil
 }
 
@@ -68,11 +153,22 @@ func (m *OtelCustomMetrics) RecordToolCall(
 		attribute.String(labelMCPServerName, boundString(mcpServerName)),
 		attribute.String(labelToolName, boundString(toolName)),
 		attribute.String(labelToolCallOutcome, string(outcome)),
+		attribute.String(labelMCPClientName, boundString(mcpClientName(ctx))),
 	}
 	m.toolCalls.Add(ctx, 1, metric.WithAttributes(attrs...))
 	m.toolCallLatency.Record(ctx, elapsedTime.Seconds(), metric.WithAttributes(attrs...))
 }
 
+func (m *OtelCustomMetrics) RecordSlowToolCall(
+	ctx context.Context, mcpServerName, toolName string, elapsedTime time.Duration,
+) {
+	m.slowToolCalls.Add(ctx, 1, metric.WithAttributes(
+		attribute.String(labelMCPServerName, boundString(mcpServerName)),
+		attribute.String(labelToolName, boundString(toolName)),
+		attribute.String(labelMCPClientName, boundString(mcpClientName(ctx))),
+	))
+}
+
 func (m *OtelCustomMetrics) RecordPromptCall(
 	ctx context.Context, mcpServerName, promptName string, outcome PromptCallOutcome, elapsedTime time.Duration,
 ) {
@@ -80,11 +176,55 @@ func (m *OtelCustomMetrics) RecordPromptCall(
 		attribute.String(labelMCPServerName, boundString(mcpServerName)),
 		attribute.String(labelToolName, boundString(promptName)),
 		attribute.String(labelToolCallOutcome, string(outcome)),
+		attribute.String(labelMCPClientName, boundString(mcpClientName(ctx))),
 	}
 	m.toolCalls.Add(ctx, 1, metric.WithAttributes(attrs...))
 	m.toolCallLatency.Record(ctx, elapsedTime.Seconds(), metric.WithAttributes(attrs...))
 }
 
+func (m *OtelCustomMetrics) RecordUpstreamServerHealth(
+	ctx context.Context, serverName string, reachable bool, consecutiveFailures int64,
+) {
+	attrs := metric.WithAttributes(attribute.String(labelMCPServerName, boundString(serverName)))
+	var up int64
+	if reachable {
+		up = 1
+	}
+	m.upstreamServerUp.Record(ctx, up, attrs)
+	m.upstreamServerConsecutiveFailures.Record(ctx, consecutiveFailures, attrs)
+}
+
+func (m *OtelCustomMetrics) RecordToolGroupRequest(
+	ctx context.Context, groupName string, transport ToolGroupTransport,
+) {
+	m.toolGroupRequests.Add(ctx, 1, metric.WithAttributes(
+		attribute.String(labelToolGroupName, boundString(groupName)),
+		attribute.String(labelTransport, string(transport)),
+	))
+}
+
+func (m *OtelCustomMetrics) AddToolGroupSessions(
+	ctx context.Context, groupName string, transport ToolGroupTransport, delta int64,
+) {
+	m.toolGroupSessions.Add(ctx, delta, metric.WithAttributes(
+		attribute.String(labelToolGroupName, boundString(groupName)),
+		attribute.String(labelTransport, string(transport)),
+	))
+}
+
+func (m *OtelCustomMetrics) RecordToolGroupToolCall(
+	ctx context.Context, groupName, toolName string, outcome ToolCallOutcome, elapsedTime time.Duration,
+) {
+	attrs := []attribute.KeyValue{
+		attribute.String(labelToolGroupName, boundString(groupName)),
+		attribute.String(labelToolName, boundString(toolName)),
+		attribute.String(labelToolCallOutcome, string(outcome)),
+		attribute.String(labelMCPClientName, boundString(mcpClientName(ctx))),
+	}
+	m.toolGroupToolCalls.Add(ctx, 1, metric.WithAttributes(attrs...))
+	m.toolGroupToolCallLatency.Record(ctx, elapsedTime.Seconds(), metric.WithAttributes(attrs...))
+}
+
 // boundString ensures strings are capped at maxLen and not empty.
 func boundString(s string) string {
 	if s == "" {
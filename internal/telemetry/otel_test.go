@@ -0,0 +1,59 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+)
+
+func TestNewTracerProvider(t *testing.T) {
+	ctx := context.Background()
+	for _, exporter := range []TracesExporter{"", TracesExporterNone, TracesExporterConsole} {
+		tp, err := newTracerProvider(ctx, &Config{TracesExporter: exporter}, sdkresource.Empty())
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertNoError(t, tp.Shutdown(ctx))
+	}
+
+	otlp := &OTLPConfig{Endpoint: "http://localhost:4318"}
+	for _, exporter := range []TracesExporter{"", TracesExporterOTLP} {
+		tp, err := newTracerProvider(ctx, &Config{TracesExporter: exporter, OTLP: otlp}, sdkresource.Empty())
+		testhelpers.AssertNoError(t, err)
+		// nothing is listening on the endpoint, but no spans were recorded so shutting down doesn't export anything
+		testhelpers.AssertNoError(t, tp.Shutdown(ctx))
+	}
+
+	_, err := newTracerProvider(ctx, &Config{TracesExporter: "zipkin"}, sdkresource.Empty())
+	testhelpers.AssertTrue(t, err != nil, "Expected an error for an unsupported exporter")
+
+	_, err = newTracerProvider(ctx, &Config{TracesExporter: TracesExporterOTLP}, sdkresource.Empty())
+	testhelpers.AssertTrue(t, err != nil, "Expected an error for the OTLP exporter without an endpoint")
+}
+
+func TestNewSampler(t *testing.T) {
+	for _, sampler := range []TracesSampler{
+		"", TracesSamplerAlwaysOn, TracesSamplerAlwaysOff,
+		TracesSamplerParentBasedAlwaysOn, TracesSamplerParentBasedAlwaysOff,
+	} {
+		_, err := newSampler(&Config{TracesSampler: sampler})
+		testhelpers.AssertNoError(t, err)
+	}
+
+	s, err := newSampler(&Config{TracesSampler: TracesSamplerRatio, TracesSamplerRatio: 0.25})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, "TraceIDRatioBased{0.25}", s.Description())
+
+	s, err = newSampler(&Config{TracesSampler: TracesSamplerParentBasedRatio, TracesSamplerRatio: 0.25})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertStringContains(t, s.Description(), "root:TraceIDRatioBased{0.25}")
+
+	_, err = newSampler(&Config{TracesSampler: TracesSamplerRatio, TracesSamplerRatio: 1.5})
+	testhelpers.AssertTrue(t, err != nil, "Expected an error for a ratio greater than 1")
+
+	_, err = newSampler(&Config{TracesSampler: "jaeger_remote"})
+	testhelpers.AssertTrue(t, err != nil, "Expected an error for an unsupported sampler")
+
+	_, err = newTracerProvider(context.Background(), &Config{TracesSampler: "jaeger_remote"}, sdkresource.Empty())
+	testhelpers.AssertTrue(t, err != nil, "Expected an error for an unsupported sampler")
+}
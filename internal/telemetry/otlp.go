@@ -0,0 +1,235 @@
+package telemetry
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc/credentials"
+)
+
+// OTLPProtocol is the transport protocol used to export telemetry to an OTLP endpoint
+type OTLPProtocol string
+
+const (
+	// OTLPProtocolGRPC exports telemetry over gRPC (usually port 4317)
+	OTLPProtocolGRPC OTLPProtocol = "grpc"
+	// OTLPProtocolHTTP exports telemetry as protobuf over HTTP (usually port 4318)
+	OTLPProtocolHTTP OTLPProtocol = "http/protobuf"
+)
+
+// OTLPConfig configures the export of metrics & spans to an OTLP endpoint, eg- an OpenTelemetry collector.
+type OTLPConfig struct {
+	// Endpoint is the base URL of the OTLP receiver, eg- "http://otel-collector:4318".
+	// An "http" scheme disables TLS. For the HTTP protocol, the signal's path (eg- "/v1/traces") is appended to it.
+	Endpoint string
+	// Protocol defaults to OTLPProtocolHTTP
+	Protocol OTLPProtocol
+	// Headers are sent with every export request, eg- for authentication
+	Headers map[string]string
+
+	// Insecure disables TLS even if the endpoint has an "https" scheme
+	Insecure bool
+	// CACertFile is the path of a PEM-encoded CA certificate used to verify the endpoint's certificate
+	CACertFile string
+	// ClientCertFile & ClientKeyFile are the paths of the PEM-encoded client certificate & key for mutual TLS
+	ClientCertFile string
+	ClientKeyFile  string
+}
+
+// ParseOTLPProtocol parses the name of an OTLP protocol.
+// "http" is accepted as a shorthand for "http/protobuf".
+func ParseOTLPProtocol(s string) (OTLPProtocol, error) {
+	switch strings.ToLower(s) {
+	case "", "http", string(OTLPProtocolHTTP):
+		return OTLPProtocolHTTP, nil
+	case string(OTLPProtocolGRPC):
+		return OTLPProtocolGRPC, nil
+	default:
+		return "", fmt.Errorf("unsupported OTLP protocol '%s', valid values are 'grpc' and 'http'", s)
+	}
+}
+
+// ParseOTLPHeaders parses headers given as a comma-separated list of key=value pairs,
+// the format of the OTEL_EXPORTER_OTLP_HEADERS env var.
+func ParseOTLPHeaders(s string) (map[string]string, error) {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(pair, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid OTLP header '%s', expected key=value", strings.TrimSpace(pair))
+		}
+		// values may be URL-encoded, as per the OTEL spec
+		if decoded, err := url.QueryUnescape(strings.TrimSpace(v)); err == nil {
+			v = decoded
+		}
+		headers[k] = strings.TrimSpace(v)
+	}
+	return headers, nil
+}
+
+// Validate checks that the OTLP configuration is complete & consistent.
+func (c *OTLPConfig) Validate() error {
+	u, err := url.Parse(c.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid OTLP endpoint '%s', expected a URL like 'http://otel-collector:4318'", c.Endpoint)
+	}
+	if _, err := ParseOTLPProtocol(string(c.Protocol)); err != nil {
+		return err
+	}
+	if (c.ClientCertFile == "") != (c.ClientKeyFile == "") {
+		return fmt.Errorf("both the client certificate and key must be set for mutual TLS")
+	}
+	if c.Insecure && (c.CACertFile != "" || c.ClientCertFile != "") {
+		return fmt.Errorf("TLS certificates cannot be used when TLS is disabled")
+	}
+	return nil
+}
+
+// String describes the effective configuration for logging.
+// Header values are left out because they usually contain credentials.
+func (c *OTLPConfig) String() string {
+	protocol, _ := ParseOTLPProtocol(string(c.Protocol))
+	tlsMode := "enabled"
+	if _, _, insecure := c.endpoint(); insecure {
+		tlsMode = "disabled"
+	} else if c.ClientCertFile != "" {
+		tlsMode = "mutual"
+	}
+	headerNames := make([]string, 0, len(c.Headers))
+	for k := range c.Headers {
+		headerNames = append(headerNames, k)
+	}
+	slices.Sort(headerNames)
+	return fmt.Sprintf(
+		"endpoint=%s protocol=%s tls=%s headers=[%s]", c.Endpoint, protocol, tlsMode, strings.Join(headerNames, ","),
+	)
+}
+
+// endpoint returns the host:port of the endpoint, the base path and whether the connection is insecure.
+func (c *OTLPConfig) endpoint() (string, string, bool) {
+	u, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return "", "", c.Insecure
+	}
+	return u.Host, u.Path, c.Insecure || u.Scheme == "http"
+}
+
+// tlsConfig returns the TLS configuration for the custom certificates, or nil if none are configured.
+func (c *OTLPConfig) tlsConfig() (*tls.Config, error) {
+	if c.CACertFile == "" && c.ClientCertFile == "" {
+		return nil, nil
+	}
+	conf := &tls.Config{MinVersion: tls.VersionTLS12}
+	if c.CACertFile != "" {
+		pem, err := os.ReadFile(c.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read OTLP CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid certificate found in OTLP CA certificate file %s", c.CACertFile)
+		}
+		conf.RootCAs = pool
+	}
+	if c.ClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.ClientCertFile, c.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load OTLP client certificate: %w", err)
+		}
+		conf.Certificates = []tls.Certificate{cert}
+	}
+	return conf, nil
+}
+
+// newOTLPMetricReader creates a reader that periodically exports metrics to the OTLP endpoint.
+func newOTLPMetricReader(ctx context.Context, c *OTLPConfig) (sdkmetric.Reader, error) {
+	tlsConf, err := c.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	host, basePath, insecure := c.endpoint()
+	var exporter sdkmetric.Exporter
+	if protocol, _ := ParseOTLPProtocol(string(c.Protocol)); protocol == OTLPProtocolGRPC {
+		opts := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpoint(host), otlpmetricgrpc.WithHeaders(c.Headers)}
+		if insecure {
+			opts = append(opts, otlpmetricgrpc.WithInsecure())
+		}
+		if tlsConf != nil {
+			opts = append(opts, otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(tlsConf)))
+		}
+		exporter, err = otlpmetricgrpc.New(ctx, opts...)
+	} else {
+		opts := []otlpmetrichttp.Option{
+			otlpmetrichttp.WithEndpoint(host),
+			otlpmetrichttp.WithURLPath(path.Join("/", basePath, "v1/metrics")),
+			otlpmetrichttp.WithHeaders(c.Headers),
+		}
+		if insecure {
+			opts = append(opts, otlpmetrichttp.WithInsecure())
+		}
+		if tlsConf != nil {
+			opts = append(opts, otlpmetrichttp.WithTLSClientConfig(tlsConf))
+		}
+		exporter, err = otlpmetrichttp.New(ctx, opts...)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP metric exporter: %w", err)
+	}
+	return sdkmetric.NewPeriodicReader(exporter), nil
+}
+
+// newOTLPSpanExporter creates an exporter that sends spans to the OTLP endpoint.
+func newOTLPSpanExporter(ctx context.Context, c *OTLPConfig) (sdktrace.SpanExporter, error) {
+	tlsConf, err := c.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	host, basePath, insecure := c.endpoint()
+	var exporter sdktrace.SpanExporter
+	if protocol, _ := ParseOTLPProtocol(string(c.Protocol)); protocol == OTLPProtocolGRPC {
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(host), otlptracegrpc.WithHeaders(c.Headers)}
+		if insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		if tlsConf != nil {
+			opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsConf)))
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	} else {
+		opts := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(host),
+			otlptracehttp.WithURLPath(path.Join("/", basePath, "v1/traces")),
+			otlptracehttp.WithHeaders(c.Headers),
+		}
+		if insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		if tlsConf != nil {
+			opts = append(opts, otlptracehttp.WithTLSClientConfig(tlsConf))
+		}
+		exporter, err = otlptracehttp.New(ctx, opts...)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+	return exporter, nil
+}
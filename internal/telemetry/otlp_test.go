@@ -0,0 +1,86 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestParseOTLPProtocol(t *testing.T) {
+	for input, expected := range map[string]OTLPProtocol{
+		"":              OTLPProtocolHTTP,
+		"http":          OTLPProtocolHTTP,
+		"HTTP/protobuf": OTLPProtocolHTTP,
+		"grpc":          OTLPProtocolGRPC,
+	} {
+		p, err := ParseOTLPProtocol(input)
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, expected, p)
+	}
+
+	_, err := ParseOTLPProtocol("http/json")
+	testhelpers.AssertTrue(t, err != nil, "Expected an error for an unsupported protocol")
+}
+
+func TestParseOTLPHeaders(t *testing.T) {
+	headers, err := ParseOTLPHeaders("api-key=secret, x-tenant = acme%20corp,")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 2, len(headers))
+	testhelpers.AssertEqual(t, "secret", headers["api-key"])
+	testhelpers.AssertEqual(t, "acme corp", headers["x-tenant"])
+
+	_, err = ParseOTLPHeaders("api-key")
+	testhelpers.AssertTrue(t, err != nil, "Expected an error for a header without a value")
+}
+
+func TestOTLPConfigValidate(t *testing.T) {
+	valid := []OTLPConfig{
+		{Endpoint: "http://otel-collector:4318"},
+		{Endpoint: "https://otel.example.com", Protocol: OTLPProtocolGRPC, CACertFile: "/etc/ca.pem"},
+		{Endpoint: "https://otel.example.com", ClientCertFile: "/etc/client.pem", ClientKeyFile: "/etc/client.key"},
+	}
+	for _, c := range valid {
+		testhelpers.AssertNoError(t, c.Validate())
+	}
+
+	invalid := []OTLPConfig{
+		{Endpoint: "otel-collector:4318"},
+		{Endpoint: "http://otel-collector:4318", Protocol: "udp"},
+		{Endpoint: "https://otel.example.com", ClientCertFile: "/etc/client.pem"},
+		{Endpoint: "https://otel.example.com", Insecure: true, CACertFile: "/etc/ca.pem"},
+	}
+	for _, c := range invalid {
+		testhelpers.AssertTrue(t, c.Validate() != nil, "Expected an error for "+c.String())
+	}
+}
+
+func TestOTLPConfigString(t *testing.T) {
+	c := &OTLPConfig{
+		Endpoint: "https://otel.example.com",
+		Protocol: OTLPProtocolGRPC,
+		Headers:  map[string]string{"x-tenant": "acme", "api-key": "secret"},
+	}
+	testhelpers.AssertEqual(t, "endpoint=https://otel.example.com protocol=grpc tls=enabled headers=[api-key,x-tenant]", c.String())
+
+	c = &OTLPConfig{Endpoint: "http://localhost:4318"}
+	testhelpers.AssertEqual(t, "endpoint=http://localhost:4318 protocol=http/protobuf tls=disabled headers=[]", c.String())
+}
+
+func TestNewOTLPExporters(t *testing.T) {
+	ctx := context.Background()
+	for _, p := range []OTLPProtocol{OTLPProtocolHTTP, OTLPProtocolGRPC} {
+		c := &OTLPConfig{Endpoint: "http://localhost:4318", Protocol: p}
+
+		reader, err := newOTLPMetricReader(ctx, c)
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertNoError(t, reader.Shutdown(ctx))
+
+		exporter, err := newOTLPSpanExporter(ctx, c)
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertNoError(t, exporter.Shutdown(ctx))
+	}
+
+	_, err := newOTLPSpanExporter(ctx, &OTLPConfig{Endpoint: "https://localhost:4318", CACertFile: "/does/not/exist"})
+	testhelpers.AssertTrue(t, err != nil, "Expected an error for a missing CA certificate")
+}
@@ -0,0 +1,252 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	labelPromptName = "prompt_name"
+	labelHTTPMethod = "method"
+	labelHTTPRoute  = "route"
+	labelHTTPStatus = "status"
+)
+
+// latencyBuckets are the histogram buckets (in seconds) used for all latency metrics
+var latencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2, 5, 10, 20, 30}
+
+// PrometheusMetrics records MCPJungle's metrics natively in a Prometheus registry, without OpenTelemetry.
+// It implements the CustomMetrics interface and additionally records HTTP request metrics.
+// The registry also collects the Go runtime & process metrics.
+// Tool & prompt call metrics are labelled with the MCP client that made the call (enterprise mode).
+// The tool call metrics have the same names & labels as the ones exported via OpenTelemetry,
+// so dashboards work regardless of how the metrics are collected.
+type PrometheusMetrics struct {
+	registry *prometheus.Registry
+
+	toolCalls         *prometheus.CounterVec
+	toolCallLatency   *prometheus.HistogramVec
+	slowToolCalls     *prometheus.CounterVec
+	promptCalls       *prometheus.CounterVec
+	promptCallLatency *prometheus.HistogramVec
+
+	upstreamServerUp                  *prometheus.GaugeVec
+	upstreamServerConsecutiveFailures *prometheus.GaugeVec
+
+	toolGroupRequests        *prometheus.CounterVec
+	toolGroupSessions        *prometheus.GaugeVec
+	toolGroupToolCalls       *prometheus.CounterVec
+	toolGroupToolCallLatency *prometheus.HistogramVec
+
+	httpRequests       *prometheus.CounterVec
+	httpRequestLatency *prometheus.HistogramVec
+}
+
+// NewPrometheusMetrics registers all of MCPJungle's metrics in the given registry.
+func NewPrometheusMetrics(registry *prometheus.Registry) (*PrometheusMetrics, error) {
+	m := &PrometheusMetrics{
+		registry: registry,
+		toolCalls: prometheus.NewCounterVec(
+			prometheus.CounterOpts{Name: "mcpjungle_tool_calls_total", Help: "Total number of tool calls"},
+			[]string{labelMCPServerName, labelToolName, labelToolCallOutcome, labelMCPClientName},
+		),
+		toolCallLatency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "mcpjungle_tool_call_latency_seconds",
+				Help:    "Latency of tool calls in seconds",
+				Buckets: latencyBuckets,
+			},
+			[]string{labelMCPServerName, labelToolName, labelToolCallOutcome, labelMCPClientName},
+		),
+		slowToolCalls: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "mcpjungle_slow_tool_calls_total",
+				Help: "Total number of tool calls that took longer than the slow tool call threshold",
+			},
+			[]string{labelMCPServerName, labelToolName, labelMCPClientName},
+		),
+		promptCalls: prometheus.NewCounterVec(
+			prometheus.CounterOpts{Name: "mcpjungle_prompt_calls_total", Help: "Total number of prompt calls"},
+			[]string{labelMCPServerName, labelPromptName, labelToolCallOutcome, labelMCPClientName},
+		),
+		promptCallLatency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "mcpjungle_prompt_call_latency_seconds",
+				Help:    "Latency of prompt calls in seconds",
+				Buckets: latencyBuckets,
+			},
+			[]string{labelMCPServerName, labelPromptName, labelToolCallOutcome, labelMCPClientName},
+		),
+		upstreamServerUp: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "mcpjungle_upstream_server_up",
+				Help: "Whether the latest attempt to reach an upstream MCP server succeeded (1) or failed (0)",
+			},
+			[]string{labelMCPServerName},
+		),
+		upstreamServerConsecutiveFailures: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "mcpjungle_upstream_server_consecutive_failures",
+				Help: "Number of consecutive failed attempts to reach an upstream MCP server",
+			},
+			[]string{labelMCPServerName},
+		),
+		toolGroupRequests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "mcpjungle_tool_group_requests_total",
+				Help: "Total number of requests made to the MCP proxy servers of tool groups",
+			},
+			[]string{labelToolGroupName, labelTransport},
+		),
+		toolGroupSessions: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "mcpjungle_tool_group_active_sessions",
+				Help: "Number of active sessions of the MCP proxy servers of tool groups",
+			},
+			[]string{labelToolGroupName, labelTransport},
+		),
+		toolGroupToolCalls: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "mcpjungle_tool_group_tool_calls_total",
+				Help: "Total number of tool calls made via tool groups",
+			},
+			[]string{labelToolGroupName, labelToolName, labelToolCallOutcome, labelMCPClientName},
+		),
+		toolGroupToolCallLatency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "mcpjungle_tool_group_tool_call_latency_seconds",
+				Help:    "Latency of tool calls made via tool groups in seconds",
+				Buckets: latencyBuckets,
+			},
+			[]string{labelToolGroupName, labelToolName, labelToolCallOutcome, labelMCPClientName},
+		),
+		httpRequests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{Name: "mcpjungle_http_requests_total", Help: "Total number of HTTP requests served"},
+			[]string{labelHTTPMethod, labelHTTPRoute, labelHTTPStatus},
+		),
+		httpRequestLatency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "mcpjungle_http_request_duration_seconds",
+				Help:    "Time taken to serve HTTP requests in seconds",
+				Buckets: latencyBuckets,
+			},
+			[]string{labelHTTPMethod, labelHTTPRoute},
+		),
+	}
+
+	toRegister := []prometheus.Collector{
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.toolCalls,
+		m.toolCallLatency,
+		m.slowToolCalls,
+		m.promptCalls,
+		m.promptCallLatency,
+		m.upstreamServerUp,
+		m.upstreamServerConsecutiveFailures,
+		m.toolGroupRequests,
+		m.toolGroupSessions,
+		m.toolGroupToolCalls,
+		m.toolGroupToolCallLatency,
+		m.httpRequests,
+		m.httpRequestLatency,
+	}
+	for _, c := range toRegister {
+		if err := registry.Register(c); err != nil {
+			return nil, fmt.Errorf("failed to register prometheus collector: %w", err)
+		}
+	}
+	return m, nil
+}
+
+func (m *PrometheusMetrics) RecordToolCall(
+	ctx context.Context, mcpServerName, toolName string, outcome ToolCallOutcome, elapsedTime time.Duration,
+) {
+	labels := prometheus.Labels{
+		labelMCPServerName:   boundString(mcpServerName),
+		labelToolName:        boundString(toolName),
+		labelToolCallOutcome: string(outcome),
+		labelMCPClientName:   boundString(mcpClientName(ctx)),
+	}
+	m.toolCalls.With(labels).Inc()
+	m.toolCallLatency.With(labels).Observe(elapsedTime.Seconds())
+}
+
+func (m *PrometheusMetrics) RecordSlowToolCall(
+	ctx context.Context, mcpServerName, toolName string, elapsedTime time.Duration,
+) {
+	m.slowToolCalls.With(prometheus.Labels{
+		labelMCPServerName: boundString(mcpServerName),
+		labelToolName:      boundString(toolName),
+		labelMCPClientName: boundString(mcpClientName(ctx)),
+	}).Inc()
+}
+
+func (m *PrometheusMetrics) RecordPromptCall(
+	ctx context.Context, mcpServerName, promptName string, outcome PromptCallOutcome, elapsedTime time.Duration,
+) {
+	labels := prometheus.Labels{
+		labelMCPServerName:   boundString(mcpServerName),
+		labelPromptName:      boundString(promptName),
+		labelToolCallOutcome: string(outcome),
+		labelMCPClientName:   boundString(mcpClientName(ctx)),
+	}
+	m.promptCalls.With(labels).Inc()
+	m.promptCallLatency.With(labels).Observe(elapsedTime.Seconds())
+}
+
+func (m *PrometheusMetrics) RecordUpstreamServerHealth(
+	ctx context.Context, serverName string, reachable bool, consecutiveFailures int64,
+) {
+	up := 0.0
+	if reachable {
+		up = 1
+	}
+	m.upstreamServerUp.WithLabelValues(boundString(serverName)).Set(up)
+	m.upstreamServerConsecutiveFailures.WithLabelValues(boundString(serverName)).Set(float64(consecutiveFailures))
+}
+
+func (m *PrometheusMetrics) RecordToolGroupRequest(
+	ctx context.Context, groupName string, transport ToolGroupTransport,
+) {
+	m.toolGroupRequests.WithLabelValues(boundString(groupName), string(transport)).Inc()
+}
+
+func (m *PrometheusMetrics) AddToolGroupSessions(
+	ctx context.Context, groupName string, transport ToolGroupTransport, delta int64,
+) {
+	m.toolGroupSessions.WithLabelValues(boundString(groupName), string(transport)).Add(float64(delta))
+}
+
+func (m *PrometheusMetrics) RecordToolGroupToolCall(
+	ctx context.Context, groupName, toolName string, outcome ToolCallOutcome, elapsedTime time.Duration,
+) {
+	labels := prometheus.Labels{
+		labelToolGroupName:   boundString(groupName),
+		labelToolName:        boundString(toolName),
+		labelToolCallOutcome: string(outcome),
+		labelMCPClientName:   boundString(mcpClientName(ctx)),
+	}
+	m.toolGroupToolCalls.With(labels).Inc()
+	m.toolGroupToolCallLatency.With(labels).Observe(elapsedTime.Seconds())
+}
+
+// RecordHTTPRequest records a request served by the HTTP server.
+// route must be the route pattern (eg- "/api/v1/servers/:name") rather than the actual path,
+// so that the number of time series stays bounded.
+func (m *PrometheusMetrics) RecordHTTPRequest(method, route string, status int, elapsedTime time.Duration) {
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.httpRequestLatency.WithLabelValues(method, route).Observe(elapsedTime.Seconds())
+}
+
+// Handler returns the HTTP handler that serves the metrics in the Prometheus exposition format.
+func (m *PrometheusMetrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
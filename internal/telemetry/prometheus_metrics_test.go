@@ -0,0 +1,76 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestPrometheusMetrics(t *testing.T) {
+	m, err := NewPrometheusMetrics(prometheus.NewRegistry())
+	testhelpers.AssertNoError(t, err)
+
+	ctx := context.Background()
+	clientCtx := context.WithValue(ctx, "client", &model.McpClient{Name: "cursor"})
+	m.RecordToolCall(clientCtx, "calculator", "add", ToolCallOutcomeSuccess, 20*time.Millisecond)
+	m.RecordToolCall(ctx, "calculator", "add", ToolCallOutcomeError, time.Second)
+	m.RecordSlowToolCall(clientCtx, "calculator", "add", time.Minute)
+	m.RecordPromptCall(ctx, "docs", "summarize", PromptCallOutcomeSuccess, time.Millisecond)
+	m.RecordUpstreamServerHealth(ctx, "calculator", false, 3)
+	m.RecordToolGroupRequest(ctx, "research", ToolGroupTransportSSE)
+	m.AddToolGroupSessions(ctx, "research", ToolGroupTransportSSE, 2)
+	m.AddToolGroupSessions(ctx, "research", ToolGroupTransportSSE, -1)
+	m.RecordToolGroupToolCall(clientCtx, "research", "calculator__add", ToolCallOutcomeSuccess, time.Millisecond)
+	m.RecordHTTPRequest(http.MethodGet, "/api/v1/servers", http.StatusOK, time.Millisecond)
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+
+	body := w.Body.String()
+	testhelpers.AssertStringContains(
+		t, body, `mcpjungle_tool_calls_total{mcp_client_name="cursor",mcp_server_name="calculator",outcome="success",tool_name="add"} 1`,
+	)
+	testhelpers.AssertStringContains(
+		t, body, `mcpjungle_tool_calls_total{mcp_client_name="unknown",mcp_server_name="calculator",outcome="error",tool_name="add"} 1`,
+	)
+	testhelpers.AssertStringContains(t, body, `mcpjungle_tool_call_latency_seconds_bucket`)
+	testhelpers.AssertStringContains(
+		t, body, `mcpjungle_slow_tool_calls_total{mcp_client_name="cursor",mcp_server_name="calculator",tool_name="add"} 1`,
+	)
+	testhelpers.AssertStringContains(
+		t, body, `mcpjungle_prompt_calls_total{mcp_client_name="unknown",mcp_server_name="docs",outcome="success",prompt_name="summarize"} 1`,
+	)
+	testhelpers.AssertStringContains(t, body, `mcpjungle_upstream_server_up{mcp_server_name="calculator"} 0`)
+	testhelpers.AssertStringContains(
+		t, body, `mcpjungle_upstream_server_consecutive_failures{mcp_server_name="calculator"} 3`,
+	)
+	testhelpers.AssertStringContains(
+		t, body, `mcpjungle_tool_group_requests_total{tool_group="research",transport="sse"} 1`,
+	)
+	testhelpers.AssertStringContains(
+		t, body, `mcpjungle_tool_group_active_sessions{tool_group="research",transport="sse"} 1`,
+	)
+	testhelpers.AssertStringContains(
+		t, body, `mcpjungle_tool_group_tool_calls_total{mcp_client_name="cursor",outcome="success",tool_group="research",tool_name="calculator__add"} 1`,
+	)
+	testhelpers.AssertStringContains(
+		t, body, `mcpjungle_http_requests_total{method="GET",route="/api/v1/servers",status="200"} 1`,
+	)
+	testhelpers.AssertStringContains(t, body, "go_goroutines")
+}
+
+func TestNewPrometheusMetricsRejectsDuplicateRegistration(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	_, err := NewPrometheusMetrics(registry)
+	testhelpers.AssertNoError(t, err)
+
+	_, err = NewPrometheusMetrics(registry)
+	testhelpers.AssertTrue(t, err != nil, "Expected an error when registering the metrics twice")
+}
@@ -13,6 +13,6 @@ func main() {
 		if !errors.Is(err, cmd.ErrSilent) {
 			_, _ = fmt.Fprintln(os.Stderr, err)
 		}
-		os.Exit(1)
+		os.Exit(cmd.ExitCode(err))
 	}
 }
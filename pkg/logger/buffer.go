@@ -0,0 +1,143 @@
+package logger
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// bufferSubscriberSize is the number of entries that can be queued for a subscriber of a Buffer before
+// new entries get dropped for it.
+const bufferSubscriberSize = 256
+
+// Entry is a log entry recorded by a Buffer.
+type Entry struct {
+	// ID increases with every entry recorded, so that a reader can tell which entries it has already seen
+	ID      uint64
+	Time    time.Time
+	Level   string
+	Message string
+	Fields  map[string]any
+}
+
+// Buffer keeps the most recent entries written to a logger in memory, so that they can be read remotely.
+// Once it is full, the oldest entries are discarded.
+type Buffer struct {
+	mu      sync.Mutex
+	entries []Entry
+	// next is the index in entries where the next entry is recorded
+	next   int
+	full   bool
+	lastID uint64
+
+	subscribers map[chan Entry]struct{}
+}
+
+// NewBuffer creates a Buffer that keeps up to size entries.
+func NewBuffer(size int) *Buffer {
+	return &Buffer{
+		entries:     make([]Entry, size),
+		subscribers: make(map[chan Entry]struct{}),
+	}
+}
+
+// Entries returns the entries in the buffer, oldest first.
+func (b *Buffer) Entries() []Entry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.full {
+		return append([]Entry(nil), b.entries[:b.next]...)
+	}
+	out := make([]Entry, 0, len(b.entries))
+	out = append(out, b.entries[b.next:]...)
+	return append(out, b.entries[:b.next]...)
+}
+
+// Subscribe registers a new subscriber and returns the channel on which it receives the entries
+// recorded from now on.
+// The returned function must be called to unsubscribe once the subscriber is done, it closes the channel.
+func (b *Buffer) Subscribe() (<-chan Entry, func()) {
+	ch := make(chan Entry, bufferSubscriberSize)
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+	return ch, unsubscribe
+}
+
+// add records an entry and delivers it to the subscribers.
+// It never blocks. If a subscriber is too slow to keep up, the entry is dropped for that subscriber.
+func (b *Buffer) add(e Entry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	e.ID = b.lastID
+	if len(b.entries) > 0 {
+		b.entries[b.next] = e
+		b.next++
+		if b.next == len(b.entries) {
+			b.next = 0
+			b.full = true
+		}
+	}
+	for ch := range b.subscribers {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
+
+// bufferCore is a zapcore.Core that records the entries in a Buffer.
+type bufferCore struct {
+	zapcore.LevelEnabler
+	buf    *Buffer
+	fields []zapcore.Field
+}
+
+func (c *bufferCore) With(fields []zapcore.Field) zapcore.Core {
+	return &bufferCore{
+		LevelEnabler: c.LevelEnabler,
+		buf:          c.buf,
+		fields:       append(c.fields[:len(c.fields):len(c.fields)], fields...),
+	}
+}
+
+func (c *bufferCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(e.Level) {
+		return ce.AddCore(e, c)
+	}
+	return ce
+}
+
+func (c *bufferCore) Write(e zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range c.fields {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	c.buf.add(Entry{
+		Time:    e.Time,
+		Level:   e.Level.String(),
+		Message: e.Message,
+		Fields:  enc.Fields,
+	})
+	return nil
+}
+
+func (c *bufferCore) Sync() error {
+	return nil
+}
@@ -0,0 +1,85 @@
+package logger
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBufferKeepsMostRecentEntries(t *testing.T) {
+	buf := NewBuffer(3)
+	l, err := New(&Config{Level: "info", Development: true, Buffer: buf})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	l.Debug("not recorded below the level")
+	for _, msg := range []string{"one", "two", "three", "four"} {
+		l.Info(msg)
+	}
+
+	entries := buf.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+	for i, want := range []string{"two", "three", "four"} {
+		if entries[i].Message != want {
+			t.Errorf("Expected entry %d to be '%s', got '%s'", i, want, entries[i].Message)
+		}
+	}
+	if entries[0].ID != 2 || entries[2].ID != 4 {
+		t.Errorf("Expected entry IDs 2 to 4, got %d to %d", entries[0].ID, entries[2].ID)
+	}
+}
+
+func TestBufferRecordsFields(t *testing.T) {
+	buf := NewBuffer(10)
+	l, err := New(&Config{Level: "info", Development: true, Buffer: buf})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	l.WithFields(String("server", "time")).Error("tool call failed", ErrorField(errors.New("timeout")))
+
+	entries := buf.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+	e := entries[0]
+	if e.Level != "error" {
+		t.Errorf("Expected level 'error', got '%s'", e.Level)
+	}
+	if e.Fields["server"] != "time" {
+		t.Errorf("Expected server field 'time', got %v", e.Fields["server"])
+	}
+	if e.Fields["error"] != "timeout" {
+		t.Errorf("Expected error field 'timeout', got %v", e.Fields["error"])
+	}
+	if e.Time.IsZero() {
+		t.Error("Expected entry time to be set")
+	}
+}
+
+func TestBufferSubscribe(t *testing.T) {
+	buf := NewBuffer(10)
+	l, err := New(&Config{Level: "info", Development: true, Buffer: buf})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	l.Info("before subscribing")
+	ch, unsubscribe := buf.Subscribe()
+	l.Info("after subscribing")
+
+	e := <-ch
+	if e.Message != "after subscribing" {
+		t.Errorf("Expected 'after subscribing', got '%s'", e.Message)
+	}
+
+	unsubscribe()
+	// unsubscribing twice must be harmless
+	unsubscribe()
+	if _, ok := <-ch; ok {
+		t.Error("Expected channel to be closed after unsubscribing")
+	}
+	l.Info("after unsubscribing")
+}
@@ -28,11 +28,15 @@ type Field struct {
 type Config struct {
 	Level       string `json:"level"`       // "debug", "info", "warn", "error"
 	Development bool   `json:"development"` // true for development mode (console), false for production (json)
+	// Buffer, if set, also records the log entries so that they can be read remotely
+	Buffer *Buffer `json:"-"`
 }
 
 // zapLogger implements the Logger interface using uber/zap
 type zapLogger struct {
 	*zap.Logger
+	// level is the minimum level of the entries written, shared by the loggers derived from this one
+	level *zap.AtomicLevel
 }
 
 // DefaultConfig returns a default configuration for development
@@ -106,10 +110,11 @@ func New(config *Config) (Logger, error) {
 	}
 
 	// Parse log level
-	level, err := zapcore.ParseLevel(config.Level)
+	parsed, err := zapcore.ParseLevel(config.Level)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse log level: %w", err)
 	}
+	level := zap.NewAtomicLevelAt(parsed)
 
 	// Create encoder config
 	var encoderConfig zapcore.EncoderConfig
@@ -126,11 +131,29 @@ func New(config *Config) (Logger, error) {
 	// Create core with stdout
 	writeSyncer := zapcore.AddSync(os.Stdout)
 	core := zapcore.NewCore(encoder, writeSyncer, level)
+	if config.Buffer != nil {
+		core = zapcore.NewTee(core, &bufferCore{LevelEnabler: level, buf: config.Buffer})
+	}
 
 	// Create zap logger
 	zapLog := zap.New(core)
 
-	return &zapLogger{Logger: zapLog}, nil
+	return &zapLogger{Logger: zapLog, level: &level}, nil
+}
+
+// SetLevel changes the minimum level of the entries written by a logger created by New,
+// as well as by all the loggers derived from it with WithFields.
+func SetLevel(l Logger, level string) error {
+	zl, ok := l.(*zapLogger)
+	if !ok || zl.level == nil {
+		return fmt.Errorf("the level of this logger cannot be changed")
+	}
+	parsed, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return fmt.Errorf("invalid log level: %s", level)
+	}
+	zl.level.SetLevel(parsed)
+	return nil
 }
 
 // NewDevelopment creates a logger with development configuration
@@ -143,6 +166,11 @@ func NewProduction() (Logger, error) {
 	return New(ProductionConfig())
 }
 
+// NewNop creates a logger that discards all log entries, eg- for use in tests
+func NewNop() Logger {
+	return &zapLogger{Logger: zap.NewNop()}
+}
+
 // Convert fields to zap fields
 func fieldsToZap(fields []Field) []zap.Field {
 	if len(fields) == 0 {
@@ -193,7 +221,7 @@ func (l *zapLogger) WithFields(fields ...Field) Logger {
 	zapFields := fieldsToZap(fields)
 	newLogger := l.With(zapFields...)
 
-	return &zapLogger{Logger: newLogger}
+	return &zapLogger{Logger: newLogger, level: l.level}
 }
 
 // Sync flushes any buffered log entries
@@ -212,6 +212,17 @@ func TestNewProduction(t *testing.T) {
 	}
 }
 
+func TestNewNop(t *testing.T) {
+	logger := NewNop()
+	if logger == nil {
+		t.Fatal("NewNop() returned nil logger")
+	}
+	logger.Info("discarded", String("key", "value"))
+	if err := logger.Sync(); err != nil {
+		t.Errorf("Sync() error = %v", err)
+	}
+}
+
 func TestZapLoggerMethods(t *testing.T) {
 	logger, err := NewDevelopment()
 	if err != nil {
@@ -373,3 +384,34 @@ func BenchmarkLoggerWithFields(b *testing.B) {
 		logger.WithFields(fields...)
 	}
 }
+
+func TestSetLevel(t *testing.T) {
+	buf := NewBuffer(10)
+	l, err := New(&Config{Level: "info", Development: true, Buffer: buf})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	derived := l.WithFields(String("component", "test"))
+
+	derived.Debug("dropped")
+	if err := SetLevel(l, "debug"); err != nil {
+		t.Fatalf("SetLevel() error = %v", err)
+	}
+	derived.Debug("recorded")
+	if err := SetLevel(l, "error"); err != nil {
+		t.Fatalf("SetLevel() error = %v", err)
+	}
+	l.Warn("dropped")
+
+	entries := buf.Entries()
+	if len(entries) != 1 || entries[0].Message != "recorded" {
+		t.Errorf("Expected only the entry written at the debug level to be recorded, got %v", entries)
+	}
+
+	if err := SetLevel(l, "verbose"); err == nil {
+		t.Error("Expected an error for an invalid level")
+	}
+	if err := SetLevel(NewNop(), "debug"); err == nil {
+		t.Error("Expected an error for a logger whose level cannot be changed")
+	}
+}
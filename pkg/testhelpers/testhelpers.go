@@ -217,6 +217,10 @@ func SetupTestDB(t *testing.T) *TestDBSetup {
 		&model.ServerConfig{},
 		&model.ToolGroup{},
 		&model.Prompt{},
+		&model.ToolInvocationJob{},
+		&model.ToolInvocation{},
+		&model.IdempotencyKey{},
+		&model.Team{},
 	)
 	AssertNoError(t, err)
 
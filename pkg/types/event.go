@@ -0,0 +1,38 @@
+package types
+
+import "time"
+
+// RegistryEventType describes what changed in the registry.
+type RegistryEventType string
+
+const (
+	RegistryEventServerRegistered   RegistryEventType = "server.registered"
+	RegistryEventServerDeregistered RegistryEventType = "server.deregistered"
+	RegistryEventServerUpdated      RegistryEventType = "server.updated"
+	RegistryEventServerEnabled      RegistryEventType = "server.enabled"
+	RegistryEventServerDisabled     RegistryEventType = "server.disabled"
+
+	RegistryEventToolsEnabled  RegistryEventType = "tools.enabled"
+	RegistryEventToolsDisabled RegistryEventType = "tools.disabled"
+
+	RegistryEventPromptsEnabled  RegistryEventType = "prompts.enabled"
+	RegistryEventPromptsDisabled RegistryEventType = "prompts.disabled"
+
+	RegistryEventToolGroupCreated RegistryEventType = "tool_group.created"
+	RegistryEventToolGroupUpdated RegistryEventType = "tool_group.updated"
+	RegistryEventToolGroupDeleted RegistryEventType = "tool_group.deleted"
+)
+
+// RegistryEvent is a change made to the MCPJungle registry.
+// These events are streamed to subscribers of the registry events endpoint.
+type RegistryEvent struct {
+	// ID is a sequence number that increases with every event published by the server.
+	// It restarts from 1 when the server restarts.
+	ID   uint64            `json:"id"`
+	Type RegistryEventType `json:"type"`
+	// Entity is the name of the MCP server, tool, prompt or tool group that the change was made to.
+	Entity string `json:"entity"`
+	// Affected contains the names of the tools or prompts affected by the change, if any.
+	Affected  []string  `json:"affected,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
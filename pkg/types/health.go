@@ -0,0 +1,61 @@
+package types
+
+// HealthStatus is the overall status reported by the health endpoints.
+type HealthStatus string
+
+const (
+	HealthStatusOK          HealthStatus = "ok"
+	HealthStatusUnavailable HealthStatus = "unavailable"
+	// HealthStatusDegraded means that the database is unavailable, but the MCP proxy still serves tool calls
+	// from its in-memory state. The registry is read-only until the database is back.
+	HealthStatusDegraded HealthStatus = "degraded"
+)
+
+// HealthCheckOK is the result of a readiness check that passed.
+const HealthCheckOK = "ok"
+
+// ReadinessResponse is the response of the readiness endpoint.
+type ReadinessResponse struct {
+	Status HealthStatus `json:"status"`
+	// Checks maps the name of each readiness check to its result.
+	// The result is "ok" if the check passed, otherwise it describes the failure.
+	Checks map[string]string `json:"checks"`
+}
+
+// UpstreamServerHealth summarizes the health of an MCP server registered in mcpjungle.
+type UpstreamServerHealth struct {
+	Name      string `json:"name"`
+	Transport string `json:"transport"`
+	// Healthy is true if mcpjungle could connect to the server and the server responded to a ping.
+	Healthy bool `json:"healthy"`
+	// LatencyMs is the time taken to connect to and ping the server, in milliseconds.
+	LatencyMs int64 `json:"latency_ms"`
+	// Error describes why the server is unhealthy.
+	Error string `json:"error,omitempty"`
+	// ConsecutiveFailures is the number of consecutive failed attempts to reach the server,
+	// including the ones made to forward tool & prompt calls to it.
+	ConsecutiveFailures int64 `json:"consecutive_failures"`
+}
+
+// HealthDetails is the response of the detailed health endpoint.
+// It contains the readiness checks of mcpjungle itself as well as the health of all upstream MCP servers.
+type HealthDetails struct {
+	ReadinessResponse
+
+	Version     string                 `json:"version"`
+	Mode        string                 `json:"mode"`
+	Initialized bool                   `json:"initialized"`
+	Servers     []UpstreamServerHealth `json:"servers"`
+}
+
+// ServerInfo describes an MCPJungle server and whether it is ready to serve traffic.
+type ServerInfo struct {
+	ServerMetadata
+	ReadinessResponse
+}
+
+// Ready returns true if the server can serve all requests.
+// A degraded server still serves the MCP proxy, but its registry is read-only.
+func (r *ReadinessResponse) Ready() bool {
+	return r.Status == HealthStatusOK
+}
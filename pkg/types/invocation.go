@@ -0,0 +1,52 @@
+package types
+
+import "time"
+
+// InvocationOutcome is the outcome of a tool invocation recorded in the invocation history.
+type InvocationOutcome string
+
+const (
+	InvocationOutcomeSuccess InvocationOutcome = "success"
+	InvocationOutcomeError   InvocationOutcome = "error"
+)
+
+// ToolInvocation is an entry in the history of tool calls made through mcpjungle.
+type ToolInvocation struct {
+	ID uint `json:"id"`
+	// Tool is the canonical name of the tool that was called.
+	Tool string `json:"tool"`
+	// Caller is the name of the MCP client or the username of the user that called the tool.
+	// It is empty in development mode, where callers are not authenticated.
+	Caller string `json:"caller,omitempty"`
+	// Client is the name of the MCP client that called the tool (enterprise mode).
+	// It is empty if the tool was called by a user through the API.
+	Client  string            `json:"client,omitempty"`
+	Outcome InvocationOutcome `json:"outcome"`
+	// Error describes why the call failed.
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+	// Arguments is the JSON-encoded input of the call.
+	// Values of sensitive-looking arguments (eg- passwords & tokens) are redacted and long values are truncated.
+	Arguments string `json:"arguments,omitempty"`
+	// ArgumentsTruncated is true if Arguments was cut short because the input was too large.
+	ArgumentsTruncated bool `json:"arguments_truncated,omitempty"`
+
+	InvokedAt time.Time `json:"invoked_at"`
+}
+
+// ListInvocationsOptions contains the filters supported by the list invocations API.
+// Zero values mean no filtering.
+type ListInvocationsOptions struct {
+	// Tool only lists the invocations of this tool (canonical name).
+	Tool string
+	// Caller only lists the invocations made by this MCP client or user.
+	Caller string
+	// Client only lists the invocations made by this MCP client.
+	Client string
+	// Outcome only lists the invocations with this outcome.
+	Outcome InvocationOutcome
+	// Since only lists the invocations made at or after this time.
+	Since time.Time
+	// Until only lists the invocations made at or before this time.
+	Until time.Time
+}
@@ -0,0 +1,40 @@
+package types
+
+import "time"
+
+// JobStatus is the state of an asynchronous tool invocation job.
+type JobStatus string
+
+const (
+	// JobStatusPending means that the job has been accepted but the tool has not been called yet.
+	JobStatusPending JobStatus = "pending"
+	// JobStatusRunning means that the tool call is in progress.
+	JobStatusRunning JobStatus = "running"
+	// JobStatusSucceeded means that the tool call completed and its result is available.
+	JobStatusSucceeded JobStatus = "succeeded"
+	// JobStatusFailed means that the tool could not be called or the call failed.
+	JobStatusFailed JobStatus = "failed"
+)
+
+// IsTerminal returns true if the job has completed, ie, its status will not change anymore.
+func (s JobStatus) IsTerminal() bool {
+	return s == JobStatusSucceeded || s == JobStatusFailed
+}
+
+// ToolInvocationJob represents a tool invocation that runs in the background.
+type ToolInvocationJob struct {
+	// ID uniquely identifies the job.
+	ID string `json:"id"`
+	// Tool is the canonical name of the tool being invoked.
+	Tool   string    `json:"tool"`
+	Status JobStatus `json:"status"`
+	// Result is the result of the tool call, only set once the job has succeeded.
+	// Note that a tool may still report an error in its result.
+	Result *ToolInvokeResult `json:"result,omitempty"`
+	// Error describes why the job failed.
+	Error string `json:"error,omitempty"`
+
+	CreatedAt   time.Time  `json:"created_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
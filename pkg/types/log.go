@@ -0,0 +1,23 @@
+package types
+
+import "time"
+
+// LogEntry is an entry of the logs of the mcpjungle server.
+type LogEntry struct {
+	// ID increases with every entry logged by the server
+	ID      uint64    `json:"id"`
+	Time    time.Time `json:"time"`
+	Level   string    `json:"level"`
+	Message string    `json:"message"`
+	// Fields are the structured context of the entry, eg- the request ID or the MCP server concerned
+	Fields map[string]any `json:"fields,omitempty"`
+}
+
+// LogsOptions contains the filters supported by the logs API.
+// Zero values mean no filtering.
+type LogsOptions struct {
+	// Server only returns the entries about this MCP server, including the output of its process for STDIO servers.
+	Server string
+	// Since only returns the entries logged at or after this time.
+	Since time.Time
+}
@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 // McpClient represents an MCP client that is authorized to access the MCPJungle MCP Proxy server.
 type McpClient struct {
 	// Name is the name of the client that uniquely identifies it within mcpungle.
@@ -8,4 +10,52 @@ type McpClient struct {
 
 	// AllowList is a list of MCP Servers that this client is allowed to access from MCPJungle.
 	AllowList []string `json:"allow_list"`
+
+	// TokenTTLSeconds is the lifetime of the client's access tokens in seconds, 0 if they never expire.
+	// A client whose token expires must renew it before it expires to keep its access.
+	TokenTTLSeconds int64 `json:"token_ttl_seconds,omitempty"`
+	// TokenExpiresAt is the time at which the client's current access token expires, nil if it never expires.
+	TokenExpiresAt *time.Time `json:"token_expires_at,omitempty"`
+
+	// QuotaLimit is the number of tool calls the client may make per quota period, 0 if it is unlimited.
+	QuotaLimit int64 `json:"quota_limit,omitempty"`
+	// QuotaPeriod is the period after which the client's quota is reset, daily by default.
+	QuotaPeriod QuotaPeriod `json:"quota_period,omitempty"`
+	// QuotaUsed is the number of tool calls the client made in the current quota period.
+	QuotaUsed int64 `json:"quota_used,omitempty"`
+	// QuotaResetsAt is the time at which the current quota period ends, nil if the client made no tool call in it.
+	QuotaResetsAt *time.Time `json:"quota_resets_at,omitempty"`
+}
+
+// QuotaPeriod is the period after which the tool call quota of an MCP client is reset.
+// Periods start at midnight UTC, on the first day of the month for monthly quotas.
+type QuotaPeriod string
+
+const (
+	QuotaPeriodDaily   QuotaPeriod = "daily"
+	QuotaPeriodMonthly QuotaPeriod = "monthly"
+)
+
+// PatchMcpClientInput describes a partial update of an MCP client.
+// Only the fields that are set are changed, the others keep their current value.
+// The client's access token is never changed, so the agents using it keep working.
+type PatchMcpClientInput struct {
+	Description *string   `json:"description,omitempty"`
+	AllowList   *[]string `json:"allow_list,omitempty"`
+
+	// QuotaLimit sets the number of tool calls the client may make per quota period, 0 removes the quota.
+	QuotaLimit *int64 `json:"quota_limit,omitempty"`
+	// QuotaPeriod sets the period of the client's quota. Changing it starts a new period.
+	QuotaPeriod *QuotaPeriod `json:"quota_period,omitempty"`
+	// ResetQuota starts a new quota period right away, so that a client that exceeded its quota can call tools again.
+	ResetQuota bool `json:"reset_quota,omitempty"`
+}
+
+// RenewMcpClientTokenResponse is returned when an MCP client renews its own access token.
+// The client must use the new token from now on, the previous one no longer works.
+type RenewMcpClientTokenResponse struct {
+	Name        string `json:"name"`
+	AccessToken string `json:"access_token"`
+	// ExpiresAt is the time at which the new token expires, nil if it never expires.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
 }
@@ -62,6 +62,10 @@ type RegisterServerInput struct {
 // ServerMetadata represents the server metadata response
 type ServerMetadata struct {
 	Version string `json:"version"`
+	// Mode is the mode the server was initialized in, empty if it is not initialized or its configuration
+	// can't be read.
+	Mode        string `json:"mode,omitempty"`
+	Initialized bool   `json:"initialized"`
 }
 
 // EnableDisableServerResult represents the result of enabling or disabling an MCP server
@@ -74,6 +78,23 @@ type EnableDisableServerResult struct {
 	PromptsAffected []string `json:"prompts_affected"`
 }
 
+// ServerConsumers lists the entities that reference an MCP server, ie, the ones affected if it is deregistered.
+type ServerConsumers struct {
+	// Server is the name of the MCP server
+	Server string `json:"server"`
+	// McpClients are the names of the MCP clients whose allow list includes the server
+	McpClients []string `json:"mcp_clients"`
+	// ToolGroups are the tool groups that expose tools of the server
+	ToolGroups []ToolGroupConsumer `json:"tool_groups"`
+}
+
+// ToolGroupConsumer is a tool group that exposes tools of an MCP server.
+type ToolGroupConsumer struct {
+	Name string `json:"name"`
+	// Tools are the names of the server's tools exposed by the group
+	Tools []string `json:"tools"`
+}
+
 // ValidateTransport validates the input string and returns the corresponding model.McpServerTransport.
 // It returns an error if the input is invalid or empty.
 func ValidateTransport(input string) (McpServerTransport, error) {
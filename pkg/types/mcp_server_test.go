@@ -93,7 +93,7 @@ func TestServerMetadata(t *testing.T) {
 	t.Parallel()
 
 	// Test basic JSON marshaling/unmarshaling
-	metadata := ServerMetadata{Version: "v1.2.3"}
+	metadata := ServerMetadata{Version: "v1.2.3", Mode: "development", Initialized: true}
 
 	// Marshal to JSON
 	jsonData, err := json.Marshal(metadata)
@@ -101,7 +101,7 @@ func TestServerMetadata(t *testing.T) {
 		t.Fatalf("Failed to marshal: %v", err)
 	}
 
-	expected := `{"version":"v1.2.3"}`
+	expected := `{"version":"v1.2.3","mode":"development","initialized":true}`
 	if string(jsonData) != expected {
 		t.Errorf("Expected JSON %s, got %s", expected, string(jsonData))
 	}
@@ -113,7 +113,17 @@ func TestServerMetadata(t *testing.T) {
 		t.Fatalf("Failed to unmarshal: %v", err)
 	}
 
-	if result.Version != "v1.2.3" {
-		t.Errorf("Expected version v1.2.3, got %s", result.Version)
+	if result != metadata {
+		t.Errorf("Expected %+v, got %+v", metadata, result)
+	}
+
+	// the mode is omitted until the server is initialized
+	jsonData, err = json.Marshal(ServerMetadata{Version: "v1.2.3"})
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	expected = `{"version":"v1.2.3","initialized":false}`
+	if string(jsonData) != expected {
+		t.Errorf("Expected JSON %s, got %s", expected, string(jsonData))
 	}
 }
@@ -7,12 +7,52 @@ type ToolInputSchema struct {
 	Required   []string       `json:"required,omitempty"`
 }
 
+// ToolAnnotations contains the hints that an MCP server provides about the behaviour of a tool.
+// A nil hint means that the server didn't provide it.
+type ToolAnnotations struct {
+	Title           string `json:"title,omitempty"`
+	ReadOnlyHint    *bool  `json:"readOnlyHint,omitempty"`
+	DestructiveHint *bool  `json:"destructiveHint,omitempty"`
+	IdempotentHint  *bool  `json:"idempotentHint,omitempty"`
+	OpenWorldHint   *bool  `json:"openWorldHint,omitempty"`
+}
+
 // Tool represents a tool provided by an MCP Server registered in the registry.
 type Tool struct {
 	Name        string          `json:"name"`
 	Enabled     bool            `json:"enabled"`
 	Description string          `json:"description"`
 	InputSchema ToolInputSchema `json:"input_schema"`
+	// OutputSchema is the JSON schema of the structured content returned by the tool, if the server provided one
+	OutputSchema map[string]any `json:"output_schema,omitempty"`
+	// Annotations is nil for tools registered before mcpjungle stored them
+	Annotations *ToolAnnotations `json:"annotations,omitempty"`
+}
+
+// ToolSortKey is the field by which the list tools API sorts tools.
+type ToolSortKey string
+
+const (
+	// ToolSortName sorts tools by their name within their MCP server.
+	ToolSortName ToolSortKey = "name"
+	// ToolSortServer sorts tools by the name of their MCP server, then by their name.
+	ToolSortServer ToolSortKey = "server"
+	// ToolSortUpdatedAt sorts tools by the time they were last updated, least recent first.
+	ToolSortUpdatedAt ToolSortKey = "updated_at"
+)
+
+// ListToolsOptions contains the filters and sort order supported by the list tools API.
+// Zero values mean no filtering.
+type ListToolsOptions struct {
+	// Server only lists the tools provided by this MCP server.
+	Server string
+	// Enabled, if set, only lists tools whose enabled state matches its value.
+	Enabled *bool
+	// NameContains only lists tools whose canonical name contains this string (case-insensitive).
+	NameContains string
+	// Sort is the order in which tools are listed.
+	// If not set, tools are listed in the order in which they were registered.
+	Sort ToolSortKey
 }
 
 // ToolInvokeResult represents the result of a Tool call.
@@ -24,3 +64,56 @@ type ToolInvokeResult struct {
 	Content           []map[string]any `json:"content"`
 	StructuredContent any              `json:"structuredContent,omitempty"`
 }
+
+// ToolProgress is a progress notification sent by an upstream MCP server while it serves a tool call.
+type ToolProgress struct {
+	// Progress is the progress made so far, it increases with every notification.
+	Progress float64 `json:"progress"`
+	// Total is the total progress to make, 0 if the MCP server doesn't know it.
+	Total float64 `json:"total,omitempty"`
+	// Message is a human-readable description of the progress.
+	Message string `json:"message,omitempty"`
+}
+
+// ToolInvokeStreamEventType is the type of an event streamed while a tool is invoked with the "stream" query param.
+type ToolInvokeStreamEventType string
+
+const (
+	ToolInvokeStreamEventProgress ToolInvokeStreamEventType = "progress"
+	ToolInvokeStreamEventResult   ToolInvokeStreamEventType = "result"
+	ToolInvokeStreamEventError    ToolInvokeStreamEventType = "error"
+)
+
+// ToolInvokeStreamEvent is a server-sent event streamed while a tool is invoked with the "stream" query param.
+// Zero or more progress events are followed by exactly one result or error event, which ends the stream.
+type ToolInvokeStreamEvent struct {
+	Type ToolInvokeStreamEventType `json:"type"`
+
+	// Progress is set for progress events.
+	Progress *ToolProgress `json:"progress,omitempty"`
+	// Result is set for the result event.
+	Result *ToolInvokeResult `json:"result,omitempty"`
+	// Error is set for the error event, when the tool could not be called.
+	Error string `json:"error,omitempty"`
+	// TimedOut is true if the error event reports that the upstream MCP server didn't respond in time.
+	TimedOut bool `json:"timed_out,omitempty"`
+}
+
+// BulkEntitiesRequest is the request body for enabling or disabling multiple entities in a single request.
+type BulkEntitiesRequest struct {
+	// Entities is a list of entity names or glob patterns.
+	// For tools, an entity can be a tool name, an MCP server name or a glob pattern (eg- "github__*")
+	// that is matched against the canonical names of all tools.
+	Entities []string `json:"entities"`
+}
+
+// BulkEntityResult is the outcome of enabling or disabling a single entity supplied in a BulkEntitiesRequest.
+type BulkEntityResult struct {
+	// Entity is the entity name or pattern exactly as supplied in the request.
+	Entity string `json:"entity"`
+	// Affected contains the canonical names of the tools or prompts that were enabled/disabled because of this entity.
+	Affected []string `json:"affected"`
+	// Error describes why the entity could not be enabled/disabled.
+	// It is empty if the operation succeeded.
+	Error string `json:"error,omitempty"`
+}
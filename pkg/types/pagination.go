@@ -0,0 +1,20 @@
+package types
+
+const (
+	// TotalCountHeader is the response header in which list APIs report the total number of items,
+	// regardless of the page of items returned in the response body.
+	TotalCountHeader = "X-Total-Count"
+
+	// LimitQueryParam is the query parameter that specifies the maximum number of items a list API must return.
+	// If it is not specified, the API returns all items.
+	LimitQueryParam = "limit"
+	// OffsetQueryParam is the query parameter that specifies the number of items a list API must skip.
+	OffsetQueryParam = "offset"
+)
+
+// Page is the range of items a list API returns: Limit items, after skipping the first Offset items.
+// A Limit of 0 means that all the items after the offset are returned.
+type Page struct {
+	Offset int
+	Limit  int
+}
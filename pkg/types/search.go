@@ -0,0 +1,21 @@
+package types
+
+// SearchResultType is the kind of entity matched by a search.
+type SearchResultType string
+
+const (
+	SearchResultTool   SearchResultType = "tool"
+	SearchResultPrompt SearchResultType = "prompt"
+)
+
+// SearchResult represents a single tool or prompt that matched a search query.
+type SearchResult struct {
+	Type SearchResultType `json:"type"`
+	// Name is the canonical name of the tool or prompt, ie, it contains the MCP server name prefix.
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+	// Score indicates how well the entity matched the query.
+	// Results are ranked by descending score.
+	Score int `json:"score"`
+}
@@ -0,0 +1,37 @@
+package types
+
+// TestServerInput is the request body of the API that tests the connectivity to an MCP server.
+// Exactly one of Name & Server must be set.
+type TestServerInput struct {
+	// Name is the name of a registered MCP server to test.
+	Name string `json:"name,omitempty"`
+	// Server is the configuration of an MCP server to test before registering it.
+	Server *RegisterServerInput `json:"server,omitempty"`
+
+	// Tool is the name of a tool to call once the server's tools are listed, without the server name prefix.
+	// It is optional. The tool must be annotated as read-only, so that testing a server never modifies any data.
+	Tool string `json:"tool,omitempty"`
+	// Arguments is the input of the tool call.
+	Arguments map[string]any `json:"arguments,omitempty"`
+}
+
+// ServerTestStep is the outcome of a single step of an MCP server connectivity test.
+type ServerTestStep struct {
+	// Name describes the step, eg- "initialize".
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	// DurationMs is how long the step took, in milliseconds.
+	DurationMs int64 `json:"duration_ms"`
+	// Detail summarizes the step's result, eg- the number of tools listed.
+	Detail string `json:"detail,omitempty"`
+	// Error describes why the step failed.
+	Error string `json:"error,omitempty"`
+}
+
+// ServerTestReport is the result of an MCP server connectivity test.
+// The steps are run in order and the test stops at the first step that fails.
+type ServerTestReport struct {
+	Server string           `json:"server"`
+	Passed bool             `json:"passed"`
+	Steps  []ServerTestStep `json:"steps"`
+}
@@ -0,0 +1,37 @@
+package types
+
+// EntityCounts holds the number of entities of a kind that can be enabled or disabled, like tools.
+type EntityCounts struct {
+	Total    int `json:"total"`
+	Enabled  int `json:"enabled"`
+	Disabled int `json:"disabled"`
+}
+
+// InvocationStats describes the tool & prompt calls made through mcpjungle within a recent time window.
+// These statistics are kept in memory, so they only cover calls made since the server last started.
+type InvocationStats struct {
+	// WindowSeconds is the length of the time window, in seconds
+	WindowSeconds int64 `json:"window_seconds"`
+
+	ToolCalls      int64 `json:"tool_calls"`
+	ToolCallErrors int64 `json:"tool_call_errors"`
+	// ToolCallErrorRate is the fraction of tool calls that failed, between 0 and 1
+	ToolCallErrorRate float64 `json:"tool_call_error_rate"`
+
+	PromptCalls      int64 `json:"prompt_calls"`
+	PromptCallErrors int64 `json:"prompt_call_errors"`
+	// PromptCallErrorRate is the fraction of prompt calls that failed, between 0 and 1
+	PromptCallErrorRate float64 `json:"prompt_call_error_rate"`
+}
+
+// RegistryStats is an overview of the entities in the mcpjungle registry and how they are used.
+type RegistryStats struct {
+	Servers    int          `json:"servers"`
+	Tools      EntityCounts `json:"tools"`
+	Prompts    EntityCounts `json:"prompts"`
+	ToolGroups int          `json:"tool_groups"`
+	McpClients int          `json:"mcp_clients"`
+	Users      int          `json:"users"`
+
+	Invocations InvocationStats `json:"invocations"`
+}
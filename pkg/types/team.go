@@ -0,0 +1,18 @@
+package types
+
+// Team represents a group of users in enterprise mode.
+// Permissions are granted to a team rather than to its members individually.
+type Team struct {
+	// Name is the unique name of the team (mandatory).
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	// Members is a list of the usernames of the users that belong to this team.
+	Members []string `json:"members"`
+	// ToolGroups is a list of the tool groups that the members of this team may administer,
+	// ie, view, update and delete.
+	ToolGroups []string `json:"tool_groups"`
+	// Servers is a list of the MCP servers that the members of this team may administer,
+	// ie, update, enable, disable and deregister.
+	Servers []string `json:"servers"`
+}
@@ -12,6 +12,11 @@ type ToolGroup struct {
 	IncludedServers []string `json:"included_servers,omitempty"`
 	// ExcludedTools is a list of tools to exclude from the group (useful with IncludedServers).
 	ExcludedTools []string `json:"excluded_tools,omitempty"`
+	// ReadOnly, if set, leaves out all tools that are annotated as destructive or as not read-only.
+	ReadOnly bool `json:"read_only,omitempty"`
+	// CachedTools maps idempotent tools to a cache TTL (eg- "30s", "5m").
+	// Repeated identical calls to these tools via the group are served from cache until the TTL expires.
+	CachedTools map[string]string `json:"cached_tools,omitempty"`
 
 	Description string `json:"description"`
 }
@@ -41,3 +46,24 @@ type UpdateToolGroupResponse struct {
 	// New contains the now-live configuration of the tool group.
 	New *ToolGroup `json:"new"`
 }
+
+// PatchToolGroupInput describes a partial update of a tool group.
+// Only the fields that are set are changed, the others keep their current value.
+// Setting a list or map field to an empty value clears it.
+type PatchToolGroupInput struct {
+	Description     *string            `json:"description,omitempty"`
+	IncludedTools   *[]string          `json:"included_tools,omitempty"`
+	IncludedServers *[]string          `json:"included_servers,omitempty"`
+	ExcludedTools   *[]string          `json:"excluded_tools,omitempty"`
+	ReadOnly        *bool              `json:"read_only,omitempty"`
+	CachedTools     *map[string]string `json:"cached_tools,omitempty"`
+}
+
+// ValidateToolGroupResponse is the outcome of validating the configuration of a tool group without creating it.
+type ValidateToolGroupResponse struct {
+	Valid bool `json:"valid"`
+	// Error describes why the configuration is invalid.
+	Error string `json:"error,omitempty"`
+	// EffectiveTools lists the tools the group would expose, after resolving servers, exclusions and read-only mode.
+	EffectiveTools []string `json:"effective_tools,omitempty"`
+}
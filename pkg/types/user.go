@@ -14,6 +14,20 @@ const (
 type User struct {
 	Username string `json:"username"`
 	Role     string `json:"role"`
+	// Suspended is true while the user's access token is rejected, see the user's active flag in the registry.
+	Suspended bool `json:"suspended,omitempty"`
+
+	// ToolPolicy restricts the tools the user may see & invoke via the API.
+	// It is nil if the user may access all tools.
+	ToolPolicy *ToolPolicy `json:"tool_policy,omitempty"`
+}
+
+// ToolPolicy restricts the tools a regular user may see & invoke via the API to the tools of
+// the given MCP servers and the tools exposed by the given tool groups.
+// Admins may always access all tools.
+type ToolPolicy struct {
+	Servers    []string `json:"servers"`
+	ToolGroups []string `json:"tool_groups"`
 }
 
 type CreateUserRequest struct {
@@ -25,3 +39,10 @@ type CreateUserResponse struct {
 	Role        string `json:"role"`
 	AccessToken string `json:"access_token"`
 }
+
+// RenewAccessTokenResponse contains the new access token of a user who regenerated their own token.
+// The user's previous token no longer works.
+type RenewAccessTokenResponse struct {
+	Username    string `json:"username"`
+	AccessToken string `json:"access_token"`
+}